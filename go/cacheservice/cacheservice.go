@@ -26,6 +26,8 @@ var DefaultCacheService string
 type Config struct {
 	Address string
 	Timeout time.Duration
+	// Capacity is the size in bytes of in-process caches.
+	Capacity int64
 }
 
 // Result gives the cached data.
@@ -96,3 +98,16 @@ func Connect(config Config) (CacheService, error) {
 	}
 	return fn(config)
 }
+
+// ConnectByName returns a CacheService of the named type using the
+// given config. Unlike Connect, it doesn't depend on DefaultCacheService,
+// and returns an error if name was not registered.
+func ConnectByName(name string, config Config) (CacheService, error) {
+	mu.Lock()
+	fn, ok := services[name]
+	mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("cache service %v is not registered", name)
+	}
+	return fn(config)
+}
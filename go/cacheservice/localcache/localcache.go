@@ -0,0 +1,228 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package localcache is an in-process implementation of
+// cacheservice.CacheService, backed by a LRU cache.
+package localcache
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gitql/vitess/go/cache"
+	"github.com/gitql/vitess/go/cacheservice"
+)
+
+// DefaultCapacity is the capacity used if the config doesn't specify one.
+const DefaultCapacity = 64 * 1024 * 1024
+
+// Timeouts bigger than this are absolute unix times, like in memcache.
+const maxRelativeTimeout = 30 * 24 * 60 * 60
+
+// Cache is an in-process CacheService. It is safe for concurrent use.
+type Cache struct {
+	// mu makes the read-modify-write operations atomic.
+	mu      sync.Mutex
+	lru     *cache.LRUCache
+	lastCas uint64
+}
+
+type item struct {
+	value   []byte
+	flags   uint16
+	cas     uint64
+	expires time.Time
+}
+
+// Size is part of the cache.Value interface.
+func (it *item) Size() int {
+	return len(it.value)
+}
+
+// New creates a new Cache of the given capacity in bytes.
+func New(capacity int64) *Cache {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Cache{lru: cache.NewLRUCache(capacity)}
+}
+
+// Get is part of the CacheService interface.
+func (c *Cache) Get(keys ...string) ([]cacheservice.Result, error) {
+	return c.Gets(keys...)
+}
+
+// Gets is part of the CacheService interface.
+func (c *Cache) Gets(keys ...string) ([]cacheservice.Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	results := make([]cacheservice.Result, 0, len(keys))
+	for _, key := range keys {
+		it := c.get(key)
+		if it == nil {
+			continue
+		}
+		results = append(results, cacheservice.Result{
+			Key:   key,
+			Value: it.value,
+			Flags: it.flags,
+			Cas:   it.cas,
+		})
+	}
+	return results, nil
+}
+
+// Set is part of the CacheService interface.
+func (c *Cache) Set(key string, flags uint16, timeout uint64, value []byte) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, flags, timeout, value)
+	return true, nil
+}
+
+// Add is part of the CacheService interface.
+func (c *Cache) Add(key string, flags uint16, timeout uint64, value []byte) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.get(key) != nil {
+		return false, nil
+	}
+	c.set(key, flags, timeout, value)
+	return true, nil
+}
+
+// Replace is part of the CacheService interface.
+func (c *Cache) Replace(key string, flags uint16, timeout uint64, value []byte) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.get(key) == nil {
+		return false, nil
+	}
+	c.set(key, flags, timeout, value)
+	return true, nil
+}
+
+// Append is part of the CacheService interface.
+// Like in memcache, flags and timeout are ignored.
+func (c *Cache) Append(key string, flags uint16, timeout uint64, value []byte) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	it := c.get(key)
+	if it == nil {
+		return false, nil
+	}
+	c.update(key, it, bytes.Join([][]byte{it.value, value}, nil))
+	return true, nil
+}
+
+// Prepend is part of the CacheService interface.
+// Like in memcache, flags and timeout are ignored.
+func (c *Cache) Prepend(key string, flags uint16, timeout uint64, value []byte) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	it := c.get(key)
+	if it == nil {
+		return false, nil
+	}
+	c.update(key, it, bytes.Join([][]byte{value, it.value}, nil))
+	return true, nil
+}
+
+// Cas is part of the CacheService interface.
+func (c *Cache) Cas(key string, flags uint16, timeout uint64, value []byte, cas uint64) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	it := c.get(key)
+	if it == nil || it.cas != cas {
+		return false, nil
+	}
+	c.set(key, flags, timeout, value)
+	return true, nil
+}
+
+// Delete is part of the CacheService interface.
+func (c *Cache) Delete(key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.get(key) == nil {
+		return false, nil
+	}
+	return c.lru.Delete(key), nil
+}
+
+// FlushAll is part of the CacheService interface.
+func (c *Cache) FlushAll() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Clear()
+	return nil
+}
+
+// Stats is part of the CacheService interface.
+// It returns the stats of the LRU cache, in the memcache format.
+// The argument is ignored.
+func (c *Cache) Stats(argument string) ([]byte, error) {
+	length, size, capacity, _ := c.lru.Stats()
+	return []byte(fmt.Sprintf("STAT curr_items %d\nSTAT bytes %d\nSTAT limit_maxbytes %d\nEND\n", length, size, capacity)), nil
+}
+
+// Close is part of the CacheService interface.
+func (c *Cache) Close() {
+	c.lru.Clear()
+}
+
+// get returns the item for key, or nil if there is none.
+// Expired items are deleted. c.mu must be held.
+func (c *Cache) get(key string) *item {
+	v, ok := c.lru.Get(key)
+	if !ok {
+		return nil
+	}
+	it := v.(*item)
+	if !it.expires.IsZero() && !time.Now().Before(it.expires) {
+		c.lru.Delete(key)
+		return nil
+	}
+	return it
+}
+
+// set stores a new item. c.mu must be held.
+func (c *Cache) set(key string, flags uint16, timeout uint64, value []byte) {
+	c.lastCas++
+	it := &item{
+		value: append([]byte(nil), value...),
+		flags: flags,
+		cas:   c.lastCas,
+	}
+	switch {
+	case timeout == 0:
+	case timeout > maxRelativeTimeout:
+		it.expires = time.Unix(int64(timeout), 0)
+	default:
+		it.expires = time.Now().Add(time.Duration(timeout) * time.Second)
+	}
+	c.lru.Set(key, it)
+}
+
+// update changes the value of an existing item. c.mu must be held.
+func (c *Cache) update(key string, it *item, value []byte) {
+	c.lastCas++
+	c.lru.Set(key, &item{
+		value:   value,
+		flags:   it.flags,
+		cas:     c.lastCas,
+		expires: it.expires,
+	})
+}
+
+func init() {
+	cacheservice.Register(
+		"localcache",
+		func(config cacheservice.Config) (cacheservice.CacheService, error) {
+			return New(config.Capacity), nil
+		},
+	)
+}
@@ -0,0 +1,116 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package localcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gitql/vitess/go/cacheservice"
+)
+
+func TestLocalCache(t *testing.T) {
+	c := New(1024)
+
+	// Set
+	if stored, err := c.Set("Hello", 0, 0, []byte("world")); err != nil || !stored {
+		t.Fatalf("Set: %v, %v, want true, nil", stored, err)
+	}
+	expect(t, c, "Hello", "world")
+
+	// Add
+	if stored, _ := c.Add("Hello", 0, 0, []byte("Jupiter")); stored {
+		t.Errorf("Add of existing key succeeded")
+	}
+	expect(t, c, "Hello", "world")
+	if stored, _ := c.Add("Hi", 0, 0, []byte("Jupiter")); !stored {
+		t.Errorf("Add of new key failed")
+	}
+	expect(t, c, "Hi", "Jupiter")
+
+	// Replace
+	if stored, _ := c.Replace("Bye", 0, 0, []byte("World")); stored {
+		t.Errorf("Replace of missing key succeeded")
+	}
+	expect(t, c, "Bye", "")
+	if stored, _ := c.Replace("Hello", 0, 0, []byte("World")); !stored {
+		t.Errorf("Replace of existing key failed")
+	}
+	expect(t, c, "Hello", "World")
+
+	// Append & Prepend
+	c.Append("Hello", 0, 0, []byte("!"))
+	c.Prepend("Hello", 0, 0, []byte("Hello "))
+	expect(t, c, "Hello", "Hello World!")
+
+	// Cas
+	results, _ := c.Gets("Hello")
+	cas := results[0].Cas
+	if stored, _ := c.Cas("Hello", 0, 0, []byte("Mars"), cas+1); stored {
+		t.Errorf("Cas with wrong value succeeded")
+	}
+	if stored, _ := c.Cas("Hello", 0, 0, []byte("Mars"), cas); !stored {
+		t.Errorf("Cas failed")
+	}
+	expect(t, c, "Hello", "Mars")
+
+	// Delete
+	if deleted, _ := c.Delete("Hello"); !deleted {
+		t.Errorf("Delete failed")
+	}
+	if deleted, _ := c.Delete("Hello"); deleted {
+		t.Errorf("Delete of missing key succeeded")
+	}
+	expect(t, c, "Hello", "")
+
+	// FlushAll
+	c.FlushAll()
+	expect(t, c, "Hi", "")
+}
+
+func TestLocalCacheExpiry(t *testing.T) {
+	c := New(1024)
+	c.Set("Hello", 0, uint64(time.Now().Add(-time.Second).Unix()), []byte("world"))
+	expect(t, c, "Hello", "")
+	c.Set("Hello", 0, 100, []byte("world"))
+	expect(t, c, "Hello", "world")
+}
+
+func TestLocalCacheEviction(t *testing.T) {
+	c := New(10)
+	c.Set("a", 0, 0, []byte("12345"))
+	c.Set("b", 0, 0, []byte("12345"))
+	c.Get("a")
+	c.Set("c", 0, 0, []byte("12345"))
+	expect(t, c, "a", "12345")
+	expect(t, c, "b", "")
+	expect(t, c, "c", "12345")
+}
+
+func TestLocalCacheRegistered(t *testing.T) {
+	cs, err := cacheservice.ConnectByName("localcache", cacheservice.Config{Capacity: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Close()
+	cs.Set("Hello", 0, 0, []byte("world"))
+	expect(t, cs, "Hello", "world")
+}
+
+func expect(t *testing.T, c cacheservice.CacheService, key, value string) {
+	results, err := c.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if value == "" {
+		if len(results) != 0 {
+			t.Errorf("Get(%s): %s, want nothing", key, results[0].Value)
+		}
+		return
+	}
+	if len(results) != 1 || string(results[0].Value) != value {
+		t.Errorf("Get(%s): %v, want %s", key, results, value)
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// This plugin imports fstopo to register the fs implementation of TopoServer.
+
+import (
+	_ "github.com/gitql/vitess/go/vt/topo/fstopo"
+)
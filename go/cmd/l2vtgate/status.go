@@ -19,6 +19,9 @@ func addStatusParts(l2vtgate *l2vtgate.L2VTGate) {
 	servenv.AddStatusPart("Gateway Status", gateway.StatusTemplate, func() interface{} {
 		return l2vtgate.GetGatewayCacheStatus()
 	})
+	servenv.AddStatusPart("Tablet Picker", gateway.TabletPickerStatusTemplate, func() interface{} {
+		return l2vtgate.GetGatewayTabletPickerStatus()
+	})
 	servenv.AddStatusPart("Health Check Cache", discovery.HealthCheckTemplate, func() interface{} {
 		return healthCheck.CacheStatus()
 	})
@@ -326,8 +326,8 @@ func (itc *internalTabletConn) StreamExecute(ctx context.Context, target *queryp
 }
 
 // Begin is part of queryservice.QueryService
-func (itc *internalTabletConn) Begin(ctx context.Context, target *querypb.Target) (int64, error) {
-	transactionID, err := itc.tablet.qsc.QueryService().Begin(ctx, target)
+func (itc *internalTabletConn) Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (int64, error) {
+	transactionID, err := itc.tablet.qsc.QueryService().Begin(ctx, target, options)
 	if err != nil {
 		return 0, tabletconn.TabletErrorFromGRPC(vterrors.ToGRPCError(err))
 	}
@@ -335,9 +335,12 @@ func (itc *internalTabletConn) Begin(ctx context.Context, target *querypb.Target
 }
 
 // Commit is part of queryservice.QueryService
-func (itc *internalTabletConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) error {
-	err := itc.tablet.qsc.QueryService().Commit(ctx, target, transactionID)
-	return tabletconn.TabletErrorFromGRPC(vterrors.ToGRPCError(err))
+func (itc *internalTabletConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (string, error) {
+	position, err := itc.tablet.qsc.QueryService().Commit(ctx, target, transactionID)
+	if err != nil {
+		return "", tabletconn.TabletErrorFromGRPC(vterrors.ToGRPCError(err))
+	}
+	return position, nil
 }
 
 // Rollback is part of queryservice.QueryService
@@ -396,7 +399,7 @@ func (itc *internalTabletConn) ReadTransaction(ctx context.Context, target *quer
 
 // BeginExecute is part of queryservice.QueryService
 func (itc *internalTabletConn) BeginExecute(ctx context.Context, target *querypb.Target, query string, bindVars map[string]interface{}, options *querypb.ExecuteOptions) (*sqltypes.Result, int64, error) {
-	transactionID, err := itc.Begin(ctx, target)
+	transactionID, err := itc.Begin(ctx, target, options)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -406,7 +409,7 @@ func (itc *internalTabletConn) BeginExecute(ctx context.Context, target *querypb
 
 // BeginExecuteBatch is part of queryservice.QueryService
 func (itc *internalTabletConn) BeginExecuteBatch(ctx context.Context, target *querypb.Target, queries []querytypes.BoundQuery, asTransaction bool, options *querypb.ExecuteOptions) ([]sqltypes.Result, int64, error) {
-	transactionID, err := itc.Begin(ctx, target)
+	transactionID, err := itc.Begin(ctx, target, options)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -0,0 +1,22 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// Imports and register the 'fs' topo.Server and its Explorer.
+
+import (
+	"github.com/gitql/vitess/go/vt/servenv"
+	"github.com/gitql/vitess/go/vt/topo/fstopo"
+	"github.com/gitql/vitess/go/vt/vtctld"
+)
+
+func init() {
+	// Wait until flags are parsed, so we can check which topo server is in use.
+	servenv.OnRun(func() {
+		if s, ok := ts.Impl.(*fstopo.Server); ok {
+			vtctld.HandleExplorer("fs", vtctld.NewBackendExplorer(s))
+		}
+	})
+}
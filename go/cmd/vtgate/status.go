@@ -21,6 +21,9 @@ func addStatusParts(vtg *vtgate.VTGate) {
 	servenv.AddStatusPart("Gateway Status", gateway.StatusTemplate, func() interface{} {
 		return vtg.GetGatewayCacheStatus()
 	})
+	servenv.AddStatusPart("Tablet Picker", gateway.TabletPickerStatusTemplate, func() interface{} {
+		return vtg.GetGatewayTabletPickerStatus()
+	})
 	servenv.AddStatusPart("Health Check Cache", discovery.HealthCheckTemplate, func() interface{} {
 		return healthCheck.CacheStatus()
 	})
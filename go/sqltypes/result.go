@@ -73,7 +73,8 @@ func (result *Result) Copy() *Result {
 	}
 	if result.Extras != nil {
 		out.Extras = &querypb.ResultExtras{
-			Fresher: result.Extras.Fresher,
+			Fresher:  result.Extras.Fresher,
+			Position: result.Extras.Position,
 		}
 		if result.Extras.EventToken != nil {
 			out.Extras.EventToken = &querypb.EventToken{
@@ -73,7 +73,7 @@ func (evs *EventStreamer) transactionToEvent(trans *binlogdatapb.BinlogTransacti
 			binlogdatapb.BinlogTransaction_Statement_BL_UPDATE,
 			binlogdatapb.BinlogTransaction_Statement_BL_DELETE:
 			var dmlStatement *querypb.StreamEvent_Statement
-			dmlStatement, insertid, err = evs.buildDMLStatement(string(stmt.Sql), insertid)
+			dmlStatement, insertid, err = ParseStreamComment(string(stmt.Sql), insertid)
 			if err != nil {
 				dmlStatement = &querypb.StreamEvent_Statement{
 					Category: querypb.StreamEvent_Statement_Error,
@@ -102,12 +102,13 @@ func (evs *EventStreamer) transactionToEvent(trans *binlogdatapb.BinlogTransacti
 }
 
 /*
-buildDMLStatement parses the tuples of the full stream comment.
+ParseStreamComment parses the tuples of the full stream comment.
 The _stream comment is extracted into a StreamEvent.Statement.
 */
 // Example query: insert into _table_(foo) values ('foo') /* _stream _table_ (eid id name ) (null 1 'bmFtZQ==' ); */
-// the "null" value is used for auto-increment columns.
-func (evs *EventStreamer) buildDMLStatement(sql string, insertid int64) (*querypb.StreamEvent_Statement, int64, error) {
+// the "null" value is used for auto-increment columns, and is replaced
+// by insertid. The returned insertid is the next auto-increment value.
+func ParseStreamComment(sql string, insertid int64) (*querypb.StreamEvent_Statement, int64, error) {
 	// first extract the comment
 	commentIndex := strings.LastIndex(sql, streamCommentStart)
 	if commentIndex == -1 {
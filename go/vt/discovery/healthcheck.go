@@ -138,6 +138,12 @@ type TabletStats struct {
 	// Stats is the current health status, as received by the
 	// StreamHealth RPC (replication lag, ...).
 	Stats *querypb.RealtimeStats
+	// TableSchemas has the columns of every table of the tablet, as
+	// received by the StreamHealth RPC. It is only populated if the
+	// tablet runs with -queryserver-config-publish-schema. It is not
+	// included in the JSON output: vtgate serves the schemas on
+	// /debug/keyspace_schema.
+	TableSchemas []*querypb.TableSchema `json:"-"`
 	// LastError is the error we last saw when trying to get the
 	// tablet's healthcheck.
 	LastError error
@@ -458,6 +464,7 @@ func (hcc *healthCheckConn) update(shr *querypb.StreamHealthResponse, serving bo
 	hcc.tabletStats.Serving = serving
 	hcc.tabletStats.TabletExternallyReparentedTimestamp = shr.TabletExternallyReparentedTimestamp
 	hcc.tabletStats.Stats = shr.RealtimeStats
+	hcc.tabletStats.TableSchemas = shr.TableSchemas
 	hcc.tabletStats.LastError = healthErr
 	return hcc.tabletStats
 }
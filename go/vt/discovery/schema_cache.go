@@ -0,0 +1,139 @@
+package discovery
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// SchemaCache is a HealthCheckStatsListener that keeps the table
+// schemas published by vttablets in the health stream, per keyspace.
+// All the shards of a keyspace are expected to have the same schema.
+// So, the cache keeps the latest schema reported by a master tablet
+// of the keyspace. Non-master tablets are used only until a master
+// reports its schema.
+type SchemaCache struct {
+	// mu protects all the following fields.
+	mu        sync.RWMutex
+	keyspaces map[string]*KeyspaceSchema
+	listeners []func(keyspace string)
+}
+
+// KeyspaceSchema is the schema of a keyspace as known by SchemaCache.
+// It must not be modified once it's in the cache.
+type KeyspaceSchema struct {
+	Keyspace string
+	// Source is the alias of the tablet that reported the schema.
+	Source     string
+	SourceType topodatapb.TabletType
+	LastChange time.Time
+	Tables     map[string]*querypb.TableSchema
+}
+
+// NewSchemaCache creates an empty SchemaCache. It's up to the caller
+// to forward the health check updates to it.
+func NewSchemaCache() *SchemaCache {
+	return &SchemaCache{
+		keyspaces: make(map[string]*KeyspaceSchema),
+	}
+}
+
+// AddListener registers a function that will be called every time
+// the schema of a keyspace changes.
+func (sc *SchemaCache) AddListener(f func(keyspace string)) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.listeners = append(sc.listeners, f)
+}
+
+// StatsUpdate is part of the HealthCheckStatsListener interface.
+func (sc *SchemaCache) StatsUpdate(ts *TabletStats) {
+	if !ts.Up || ts.Target == nil || len(ts.TableSchemas) == 0 {
+		return
+	}
+	isMaster := ts.Target.TabletType == topodatapb.TabletType_MASTER
+
+	sc.mu.Lock()
+	current := sc.keyspaces[ts.Target.Keyspace]
+	if current != nil {
+		if !isMaster && current.SourceType == topodatapb.TabletType_MASTER {
+			sc.mu.Unlock()
+			return
+		}
+		if sameTables(current.Tables, ts.TableSchemas) {
+			sc.mu.Unlock()
+			return
+		}
+	}
+	ks := &KeyspaceSchema{
+		Keyspace:   ts.Target.Keyspace,
+		SourceType: ts.Target.TabletType,
+		LastChange: time.Now(),
+		Tables:     make(map[string]*querypb.TableSchema, len(ts.TableSchemas)),
+	}
+	if ts.Tablet != nil {
+		ks.Source = topoproto.TabletAliasString(ts.Tablet.Alias)
+	}
+	for _, table := range ts.TableSchemas {
+		ks.Tables[table.Name] = table
+	}
+	sc.keyspaces[ts.Target.Keyspace] = ks
+	listeners := sc.listeners
+	sc.mu.Unlock()
+
+	for _, f := range listeners {
+		f(ts.Target.Keyspace)
+	}
+}
+
+// sameTables returns true if the list of tables matches
+// the ones in the map.
+func sameTables(tables map[string]*querypb.TableSchema, list []*querypb.TableSchema) bool {
+	if len(tables) != len(list) {
+		return false
+	}
+	for _, table := range list {
+		if !proto.Equal(tables[table.Name], table) {
+			return false
+		}
+	}
+	return true
+}
+
+// Table returns the schema of a table, or nil if it's unknown.
+func (sc *SchemaCache) Table(keyspace, table string) *querypb.TableSchema {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	ks, ok := sc.keyspaces[keyspace]
+	if !ok {
+		return nil
+	}
+	return ks.Tables[table]
+}
+
+// Keyspaces returns the schema of all known keyspaces,
+// sorted by keyspace name.
+func (sc *SchemaCache) Keyspaces() []*KeyspaceSchema {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	names := make([]string, 0, len(sc.keyspaces))
+	for name := range sc.keyspaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]*KeyspaceSchema, 0, len(names))
+	for _, name := range names {
+		result = append(result, sc.keyspaces[name])
+	}
+	return result
+}
+
+// Compile-time interface check.
+var _ HealthCheckStatsListener = (*SchemaCache)(nil)
@@ -0,0 +1,88 @@
+package discovery
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gitql/vitess/go/vt/topo"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+func TestSchemaCache(t *testing.T) {
+	sc := NewSchemaCache()
+	var changes []string
+	sc.AddListener(func(keyspace string) {
+		changes = append(changes, keyspace)
+	})
+
+	t1 := &querypb.TableSchema{
+		Name: "t1",
+		Columns: []*querypb.Field{{
+			Name: "id",
+			Type: querypb.Type_INT64,
+		}, {
+			Name: "name",
+			Type: querypb.Type_VARCHAR,
+		}},
+	}
+	t1Altered := &querypb.TableSchema{
+		Name: "t1",
+		Columns: []*querypb.Field{{
+			Name: "id",
+			Type: querypb.Type_INT64,
+		}},
+	}
+
+	// A replica reports its schema.
+	replica := &TabletStats{
+		Key:          "replica",
+		Tablet:       topo.NewTablet(10, "cell", "host1"),
+		Target:       &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+		Up:           true,
+		Serving:      true,
+		TableSchemas: []*querypb.TableSchema{t1},
+	}
+	sc.StatsUpdate(replica)
+	if got := sc.Table("k", "t1"); !reflect.DeepEqual(got, t1) {
+		t.Errorf("Table(k, t1): %v, want %v", got, t1)
+	}
+	if got := sc.Table("k", "t2"); got != nil {
+		t.Errorf("Table(k, t2): %v, want nil", got)
+	}
+
+	// Same schema again: no notification.
+	sc.StatsUpdate(replica)
+	if want := []string{"k"}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes: %v, want %v", changes, want)
+	}
+
+	// The master reports a different schema, it takes over.
+	master := &TabletStats{
+		Key:          "master",
+		Tablet:       topo.NewTablet(11, "cell", "host2"),
+		Target:       &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_MASTER},
+		Up:           true,
+		Serving:      true,
+		TableSchemas: []*querypb.TableSchema{t1Altered},
+	}
+	sc.StatsUpdate(master)
+	if got := sc.Table("k", "t1"); !reflect.DeepEqual(got, t1Altered) {
+		t.Errorf("Table(k, t1): %v, want %v", got, t1Altered)
+	}
+
+	// Replicas are ignored once a master has reported.
+	sc.StatsUpdate(replica)
+	if got := sc.Table("k", "t1"); !reflect.DeepEqual(got, t1Altered) {
+		t.Errorf("Table(k, t1): %v, want %v", got, t1Altered)
+	}
+	if want := []string{"k", "k"}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes: %v, want %v", changes, want)
+	}
+
+	keyspaces := sc.Keyspaces()
+	if len(keyspaces) != 1 || keyspaces[0].Source != "cell-0000000011" {
+		t.Errorf("Keyspaces: %v, want one keyspace from cell-0000000011", keyspaces)
+	}
+}
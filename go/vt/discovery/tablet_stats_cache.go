@@ -1,7 +1,10 @@
 package discovery
 
 import (
+	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	querypb "github.com/gitql/vitess/go/vt/proto/query"
 	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
@@ -13,6 +16,9 @@ import (
 // - for non-master tablets, we filter the list using FilterByReplicationLag.
 // It keeps entries for all tablets in the cell it's configured to serve for,
 // and for the master independently of which cell it's in.
+// If it's configured with a list of regions instead, it keeps the
+// non-master tablets of all their cells, and serves from the first
+// region that has healthy tablets.
 // Note the healthy tablet computation is done when we receive a tablet
 // update only, not at serving time.
 // Also note the cache may not have the last entry received by the tablet.
@@ -23,6 +29,13 @@ type TabletStatsCache struct {
 	// Note we keep track of all master tablets in all cells.
 	cell string
 
+	// regions, if set, lists the groups of cells to serve
+	// non-master tablets from, by order of preference.
+	regions []CellRegion
+	// maxLag, if set, is the replication lag after which
+	// tablets are not served, if another region can serve.
+	maxLag time.Duration
+
 	// mu protects the entries map. It does not protect individual
 	// entries in the map.
 	mu sync.RWMutex
@@ -62,6 +75,19 @@ func NewTabletStatsCacheDoNotSetListener(cell string) *TabletStatsCache {
 	return newTabletStatsCache(nil, cell, false /* setListener */)
 }
 
+// NewTabletStatsCacheForRegions is like NewTabletStatsCacheDoNotSetListener,
+// but keeps the non-master tablets of all the cells of the regions. The
+// healthy tablets of a keyspace/shard/tabletType are the ones of the
+// first region that has healthy tablets with a replication lag under
+// maxLag. If all regions lag more, they are the ones of the first region
+// with healthy tablets. A zero maxLag means that lag doesn't cause a failover.
+func NewTabletStatsCacheForRegions(regions []CellRegion, maxLag time.Duration) *TabletStatsCache {
+	tc := newTabletStatsCache(nil, "", false /* setListener */)
+	tc.regions = regions
+	tc.maxLag = maxLag
+	return tc
+}
+
 func newTabletStatsCache(hc HealthCheck, cell string, setListener bool) *TabletStatsCache {
 	tc := &TabletStatsCache{
 		cell:    cell,
@@ -126,7 +152,7 @@ func (tc *TabletStatsCache) getOrCreateEntry(target *querypb.Target) *tabletStat
 
 // StatsUpdate is part of the HealthCheckStatsListener interface.
 func (tc *TabletStatsCache) StatsUpdate(ts *TabletStats) {
-	if ts.Target.TabletType != topodatapb.TabletType_MASTER && ts.Tablet.Alias.Cell != tc.cell {
+	if ts.Target.TabletType != topodatapb.TabletType_MASTER && tc.regionIndex(ts.Tablet.Alias.Cell) == -1 {
 		// this is for a non-master tablet in a different cell, drop it
 		return
 	}
@@ -141,7 +167,7 @@ func (tc *TabletStatsCache) StatsUpdate(ts *TabletStats) {
 		if ts.Up {
 			// We have an existing entry, and a new entry.
 			// Remember if they are both good (most common case).
-			trivialNonMasterUpdate = existing.LastError == nil && existing.Serving && ts.LastError == nil && ts.Serving && ts.Target.TabletType != topodatapb.TabletType_MASTER && TrivialStatsUpdate(existing, ts)
+			trivialNonMasterUpdate = existing.LastError == nil && existing.Serving && ts.LastError == nil && ts.Serving && ts.Target.TabletType != topodatapb.TabletType_MASTER && TrivialStatsUpdate(existing, ts) && !tc.crossesMaxLag(existing, ts)
 
 			// We already have the entry, update the
 			// values if necessary.  (will update both
@@ -203,11 +229,84 @@ func (tc *TabletStatsCache) StatsUpdate(ts *TabletStats) {
 	if trivialNonMasterUpdate {
 		return
 	}
-	allArray := make([]*TabletStats, 0, len(e.all))
-	for _, s := range e.all {
-		allArray = append(allArray, s)
+	if tc.regions == nil {
+		allArray := make([]*TabletStats, 0, len(e.all))
+		for _, s := range e.all {
+			allArray = append(allArray, s)
+		}
+		e.healthy = FilterByReplicationLag(allArray)
+		return
 	}
-	e.healthy = FilterByReplicationLag(allArray)
+	e.healthy = tc.filterByRegion(e.all)
+}
+
+// regionIndex returns the index of the region of the cell,
+// or -1 if the cell is not served.
+func (tc *TabletStatsCache) regionIndex(cell string) int {
+	if tc.regions == nil {
+		if cell == tc.cell {
+			return 0
+		}
+		return -1
+	}
+	for i, region := range tc.regions {
+		for _, c := range region.Cells {
+			if c == cell {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// filterByRegion returns the healthy tablets of the first region that
+// has some under maxLag. If all regions lag more than maxLag, it
+// returns the healthy tablets of the first region that has some.
+func (tc *TabletStatsCache) filterByRegion(all map[string]*TabletStats) []*TabletStats {
+	byRegion := make([][]*TabletStats, len(tc.regions))
+	for _, s := range all {
+		i := tc.regionIndex(s.Tablet.Alias.Cell)
+		byRegion[i] = append(byRegion[i], s)
+	}
+	var fallback []*TabletStats
+	for _, tablets := range byRegion {
+		healthy := FilterByReplicationLag(tablets)
+		if len(healthy) == 0 {
+			continue
+		}
+		if fallback == nil {
+			fallback = healthy
+		}
+		if tc.maxLag != 0 {
+			lagging := healthy
+			healthy = make([]*TabletStats, 0, len(lagging))
+			for _, s := range lagging {
+				if tc.underMaxLag(s) {
+					healthy = append(healthy, s)
+				}
+			}
+		}
+		if len(healthy) != 0 {
+			return healthy
+		}
+	}
+	return fallback
+}
+
+// underMaxLag returns true if the replication lag of the tablet
+// is at most maxLag.
+func (tc *TabletStatsCache) underMaxLag(ts *TabletStats) bool {
+	return float64(ts.Stats.SecondsBehindMaster) <= tc.maxLag.Seconds()
+}
+
+// crossesMaxLag returns true if the replication lag of the tablet
+// moved across maxLag. Such an update changes the healthy tablets,
+// even if TrivialStatsUpdate considers it trivial.
+func (tc *TabletStatsCache) crossesMaxLag(o, n *TabletStats) bool {
+	if tc.maxLag == 0 {
+		return false
+	}
+	return tc.underMaxLag(o) != tc.underMaxLag(n)
 }
 
 // GetTabletStats returns the full list of available targets.
@@ -254,5 +353,51 @@ func (tc *TabletStatsCache) ResetForTesting() {
 	tc.entries = make(map[string]map[string]map[topodatapb.TabletType]*tabletStatsCacheEntry)
 }
 
+// CellRegion is a named group of cells. Tablets of the
+// cells of a region are equally preferred for serving.
+type CellRegion struct {
+	Name  string
+	Cells []string
+}
+
+// ParseCellRegions parses a comma-separated list of regions, by order
+// of preference. Each region is a '|'-separated list of cells, with an
+// optional name followed by ':'. For instance, "east:c1|c2,c3" is a
+// region named east with cells c1 and c2, followed by a region named
+// c3 with cell c3.
+func ParseCellRegions(value string) ([]CellRegion, error) {
+	var regions []CellRegion
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(value, ",") {
+		if entry == "" {
+			continue
+		}
+		region := CellRegion{}
+		cells := entry
+		if i := strings.Index(entry, ":"); i != -1 {
+			region.Name = entry[:i]
+			cells = entry[i+1:]
+		}
+		for _, cell := range strings.Split(cells, "|") {
+			if cell == "" {
+				continue
+			}
+			if seen[cell] {
+				return nil, fmt.Errorf("cell %v is in more than one region", cell)
+			}
+			seen[cell] = true
+			region.Cells = append(region.Cells, cell)
+		}
+		if len(region.Cells) == 0 {
+			return nil, fmt.Errorf("region %v has no cell", entry)
+		}
+		if region.Name == "" {
+			region.Name = strings.Join(region.Cells, "|")
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
+
 // Compile-time interface check.
 var _ HealthCheckStatsListener = (*TabletStatsCache)(nil)
@@ -1,8 +1,11 @@
 package discovery
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/gitql/vitess/go/vt/topo"
 
@@ -193,3 +196,136 @@ func TestTabletStatsCache(t *testing.T) {
 		t.Errorf("unexpected result: %v", a)
 	}
 }
+
+// TestTabletStatsCacheRegions tests the failover of non-master
+// tablets across the regions of a TabletStatsCache.
+func TestTabletStatsCacheRegions(t *testing.T) {
+	regions := []CellRegion{
+		{Name: "local", Cells: []string{"cell1", "cell2"}},
+		{Name: "remote", Cells: []string{"cell3"}},
+	}
+	tsc := NewTabletStatsCacheForRegions(regions, 10*time.Second)
+	newTabletStats := func(uid uint32, cell string, lag uint32, serving bool) *TabletStats {
+		return &TabletStats{
+			Key:     fmt.Sprintf("t%d", uid),
+			Tablet:  topo.NewTablet(uid, cell, fmt.Sprintf("host%d", uid)),
+			Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+			Up:      true,
+			Serving: serving,
+			Stats:   &querypb.RealtimeStats{SecondsBehindMaster: lag},
+		}
+	}
+	healthyKeys := func() []string {
+		var keys []string
+		for _, ts := range tsc.GetHealthyTabletStats("k", "s", topodatapb.TabletType_REPLICA) {
+			keys = append(keys, ts.Key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	// Tablets of unknown cells are dropped.
+	tsc.StatsUpdate(newTabletStats(4, "cell4", 1, true))
+	if got := healthyKeys(); got != nil {
+		t.Errorf("healthy tablets: %v, want none", got)
+	}
+
+	// The local region is preferred.
+	tsc.StatsUpdate(newTabletStats(1, "cell1", 1, true))
+	tsc.StatsUpdate(newTabletStats(2, "cell2", 1, true))
+	tsc.StatsUpdate(newTabletStats(3, "cell3", 1, true))
+	if got, want := healthyKeys(), []string{"t1", "t2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("healthy tablets: %v, want %v", got, want)
+	}
+
+	// One local tablet is enough.
+	tsc.StatsUpdate(newTabletStats(1, "cell1", 1, false))
+	if got, want := healthyKeys(), []string{"t2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("healthy tablets: %v, want %v", got, want)
+	}
+
+	// Overflow to the remote region when the local
+	// tablets lag too much.
+	tsc.StatsUpdate(newTabletStats(2, "cell2", 60, true))
+	if got, want := healthyKeys(), []string{"t3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("healthy tablets: %v, want %v", got, want)
+	}
+
+	// And back once a local tablet is healthy again.
+	tsc.StatsUpdate(newTabletStats(1, "cell1", 1, true))
+	if got, want := healthyKeys(), []string{"t1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("healthy tablets: %v, want %v", got, want)
+	}
+}
+
+// TestTabletStatsCacheRegionsMaxLag tests the failover with a maxLag
+// under low_replication_lag, and when all regions lag.
+func TestTabletStatsCacheRegionsMaxLag(t *testing.T) {
+	regions := []CellRegion{
+		{Name: "local", Cells: []string{"cell1"}},
+		{Name: "remote", Cells: []string{"cell2"}},
+	}
+	maxLag := *lowReplicationLag / 3
+	tsc := NewTabletStatsCacheForRegions(regions, maxLag)
+	newTabletStats := func(uid uint32, cell string, lag time.Duration) *TabletStats {
+		return &TabletStats{
+			Key:     fmt.Sprintf("t%d", uid),
+			Tablet:  topo.NewTablet(uid, cell, fmt.Sprintf("host%d", uid)),
+			Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+			Up:      true,
+			Serving: true,
+			Stats:   &querypb.RealtimeStats{SecondsBehindMaster: uint32(lag.Seconds())},
+		}
+	}
+	healthyKeys := func() []string {
+		var keys []string
+		for _, ts := range tsc.GetHealthyTabletStats("k", "s", topodatapb.TabletType_REPLICA) {
+			keys = append(keys, ts.Key)
+		}
+		return keys
+	}
+
+	tsc.StatsUpdate(newTabletStats(1, "cell1", 0))
+	tsc.StatsUpdate(newTabletStats(2, "cell2", 0))
+	if got, want := healthyKeys(), []string{"t1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("healthy tablets: %v, want %v", got, want)
+	}
+
+	// The lag stays under low_replication_lag, but exceeds maxLag.
+	tsc.StatsUpdate(newTabletStats(1, "cell1", 2*maxLag))
+	if got, want := healthyKeys(), []string{"t2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("healthy tablets: %v, want %v", got, want)
+	}
+
+	// When all regions lag, the first region serves.
+	tsc.StatsUpdate(newTabletStats(2, "cell2", 2*maxLag))
+	if got, want := healthyKeys(), []string{"t1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("healthy tablets: %v, want %v", got, want)
+	}
+
+	// And the remote region serves again once it catches up.
+	tsc.StatsUpdate(newTabletStats(2, "cell2", 0))
+	if got, want := healthyKeys(), []string{"t2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("healthy tablets: %v, want %v", got, want)
+	}
+}
+
+func TestParseCellRegions(t *testing.T) {
+	got, err := ParseCellRegions("east:c1|c2,c3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CellRegion{
+		{Name: "east", Cells: []string{"c1", "c2"}},
+		{Name: "c3", Cells: []string{"c3"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCellRegions: %v, want %v", got, want)
+	}
+
+	for _, value := range []string{"c1,c1", "east:,c1"} {
+		if _, err := ParseCellRegions(value); err == nil {
+			t.Errorf("ParseCellRegions(%v) succeeded, want an error", value)
+		}
+	}
+}
@@ -29,11 +29,13 @@ type TabletRecorder interface {
 }
 
 // NewCellTabletsWatcher returns a TopologyWatcher that monitors all
-// the tablets in a cell, and starts refreshing.
+// the tablets in a cell, and starts watching them. If the topology
+// server cannot watch the tablets, it polls them every refreshInterval
+// instead.
 func NewCellTabletsWatcher(topoServer topo.Server, tr TabletRecorder, cell string, refreshInterval time.Duration, topoReadConcurrency int) *TopologyWatcher {
-	return NewTopologyWatcher(topoServer, tr, cell, refreshInterval, topoReadConcurrency, func(tw *TopologyWatcher) ([]*topodatapb.TabletAlias, error) {
+	return newTopologyWatcher(topoServer, tr, cell, refreshInterval, topoReadConcurrency, func(tw *TopologyWatcher) ([]*topodatapb.TabletAlias, error) {
 		return tw.topoServer.GetTabletsByCell(tw.ctx, tw.cell)
-	})
+	}, true /* watchTablets */)
 }
 
 // NewShardReplicationWatcher returns a TopologyWatcher that
@@ -66,7 +68,8 @@ type tabletInfo struct {
 }
 
 // TopologyWatcher polls tablet from a configurable set of tablets
-// periodically. When tablets are added / removed, it calls
+// periodically, or watches all the tablets of a cell for changes.
+// When tablets are added / removed, it calls
 // the TabletRecorder AddTablet / RemoveTablet interface appropriately.
 type TopologyWatcher struct {
 	// set at construction time
@@ -78,6 +81,10 @@ type TopologyWatcher struct {
 	sem             chan int
 	ctx             context.Context
 	cancelFunc      context.CancelFunc
+	// watchTablets is true if we use topo.Server.WatchTablets
+	// for the cell, and only poll if the watch fails. It is only
+	// accessed by the watch go routine after construction.
+	watchTablets bool
 	// wg keeps track of all launched Go routines.
 	wg sync.WaitGroup
 
@@ -93,6 +100,10 @@ type TopologyWatcher struct {
 // NewTopologyWatcher returns a TopologyWatcher that monitors all
 // the tablets in a cell, and starts refreshing.
 func NewTopologyWatcher(topoServer topo.Server, tr TabletRecorder, cell string, refreshInterval time.Duration, topoReadConcurrency int, getTablets func(tw *TopologyWatcher) ([]*topodatapb.TabletAlias, error)) *TopologyWatcher {
+	return newTopologyWatcher(topoServer, tr, cell, refreshInterval, topoReadConcurrency, getTablets, false /* watchTablets */)
+}
+
+func newTopologyWatcher(topoServer topo.Server, tr TabletRecorder, cell string, refreshInterval time.Duration, topoReadConcurrency int, getTablets func(tw *TopologyWatcher) ([]*topodatapb.TabletAlias, error), watchTablets bool) *TopologyWatcher {
 	tw := &TopologyWatcher{
 		topoServer:      topoServer,
 		tr:              tr,
@@ -100,6 +111,7 @@ func NewTopologyWatcher(topoServer topo.Server, tr TabletRecorder, cell string,
 		refreshInterval: refreshInterval,
 		getTablets:      getTablets,
 		sem:             make(chan int, topoReadConcurrency),
+		watchTablets:    watchTablets,
 		tablets:         make(map[string]*tabletInfo),
 	}
 	tw.firstLoadChan = make(chan struct{})
@@ -109,12 +121,32 @@ func NewTopologyWatcher(topoServer topo.Server, tr TabletRecorder, cell string,
 	return tw
 }
 
-// watch polls all tablets and notifies TabletRecorder by adding/removing tablets.
+// watch watches or polls all tablets and notifies TabletRecorder by
+// adding/removing tablets. When watching, it only polls while the
+// watch cannot be set.
 func (tw *TopologyWatcher) watch() {
 	defer tw.wg.Done()
 	ticker := time.NewTicker(tw.refreshInterval)
 	defer ticker.Stop()
 	for {
+		if tw.watchTablets {
+			err := tw.streamTablets()
+			switch err {
+			case nil:
+				// We were stopped.
+				return
+			case topo.ErrNotImplemented:
+				log.Infof("topology server cannot watch the tablets of cell %v, polling them every %v", tw.cell, tw.refreshInterval)
+				tw.watchTablets = false
+			default:
+				select {
+				case <-tw.ctx.Done():
+					return
+				default:
+				}
+				log.Warningf("cannot watch the tablets of cell %v, polling them until the watch works again: %v", tw.cell, err)
+			}
+		}
 		tw.loadTablets()
 		select {
 		case <-tw.ctx.Done():
@@ -165,6 +197,12 @@ func (tw *TopologyWatcher) loadTablets() {
 	}
 
 	wg.Wait()
+	tw.setTablets(newTablets)
+}
+
+// setTablets replaces the current tablets with newTablets, and
+// updates TabletRecorder.
+func (tw *TopologyWatcher) setTablets(newTablets map[string]*tabletInfo) {
 	tw.mu.Lock()
 	for key, tep := range newTablets {
 		if _, ok := tw.tablets[key]; !ok {
@@ -184,6 +222,80 @@ func (tw *TopologyWatcher) loadTablets() {
 	tw.mu.Unlock()
 }
 
+// streamTablets watches the tablets of the cell, and updates
+// TabletRecorder for each change. It returns nil when the
+// TopologyWatcher is stopped, or the error that ended the watch.
+func (tw *TopologyWatcher) streamTablets() error {
+	current, changes, cancel, err := tw.topoServer.WatchTablets(tw.ctx, tw.cell)
+	if err != nil {
+		return err
+	}
+	newTablets := make(map[string]*tabletInfo)
+	for _, tablet := range current {
+		newTablets[TabletToMapKey(tablet)] = &tabletInfo{
+			alias:  topoproto.TabletAliasString(tablet.Alias),
+			tablet: tablet,
+		}
+	}
+	tw.setTablets(newTablets)
+
+	for {
+		select {
+		case <-tw.ctx.Done():
+			// Cancel the watch, drain channel.
+			cancel()
+			for range changes {
+			}
+			return nil
+		case wd, ok := <-changes:
+			if !ok {
+				return fmt.Errorf("watch channel for the tablets of cell %v unexpectedly closed", tw.cell)
+			}
+			if wd.Alias == nil {
+				// Last error value, the channel will be
+				// closed right after this.
+				return wd.Err
+			}
+			tw.updateTablet(topoproto.TabletAliasString(wd.Alias), wd.Value)
+		}
+	}
+}
+
+// updateTablet applies the change of a tablet to the current tablets,
+// and updates TabletRecorder. tablet is nil if it was deleted.
+func (tw *TopologyWatcher) updateTablet(alias string, tablet *topodatapb.Tablet) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	// The tablets are indexed by address, find the previous
+	// version of this one.
+	for key, tep := range tw.tablets {
+		if tep.alias != alias {
+			continue
+		}
+		if tablet != nil && key == TabletToMapKey(tablet) {
+			// Same address, nothing to do.
+			tep.tablet = tablet
+			return
+		}
+		tw.tr.RemoveTablet(tep.tablet)
+		delete(tw.tablets, key)
+		break
+	}
+
+	if tablet == nil {
+		return
+	}
+	key := TabletToMapKey(tablet)
+	if _, ok := tw.tablets[key]; !ok {
+		tw.tr.AddTablet(tablet, alias)
+	}
+	tw.tablets[key] = &tabletInfo{
+		alias:  alias,
+		tablet: tablet,
+	}
+}
+
 // WaitForInitialTopology waits until the watcher reads all of the topology data
 // for the first time and transfers the information to TabletRecorder via its
 // AddTablet() method.
@@ -8,6 +8,7 @@ import (
 
 	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
 	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/memorytopo"
 	"github.com/gitql/vitess/go/vt/topo/test/faketopo"
 	"golang.org/x/net/context"
 )
@@ -71,6 +72,87 @@ func checkWatcher(t *testing.T, cellTablets bool) {
 	tw.Stop()
 }
 
+func TestCellTabletsWatcherEvents(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("aa")
+	fhc := NewFakeHealthCheck()
+
+	// Create a tablet before we start watching.
+	tablet := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "aa",
+			Uid:  1,
+		},
+		Hostname: "host1",
+		PortMap:  map[string]int32{"vt": 123},
+	}
+	if err := ts.CreateTablet(ctx, tablet); err != nil {
+		t.Fatalf("CreateTablet failed: %v", err)
+	}
+
+	// The refresh interval is long enough for the test to only
+	// see the changes through the watch.
+	tw := NewCellTabletsWatcher(ts, fhc, "aa", 10*time.Minute, 5)
+	defer tw.Stop()
+	if err := tw.WaitForInitialTopology(); err != nil {
+		t.Fatalf("WaitForInitialTopology failed: %v", err)
+	}
+	waitForTablets(t, fhc, tablet)
+
+	// Add a tablet.
+	tablet2 := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "aa",
+			Uid:  2,
+		},
+		Hostname: "host2",
+		PortMap:  map[string]int32{"vt": 123},
+	}
+	if err := ts.CreateTablet(ctx, tablet2); err != nil {
+		t.Fatalf("CreateTablet failed: %v", err)
+	}
+	waitForTablets(t, fhc, tablet, tablet2)
+
+	// Change the port of a tablet, the previous one should go
+	// away, the new one be added.
+	tablet2, err := ts.UpdateTabletFields(ctx, tablet2.Alias, func(t *topodatapb.Tablet) error {
+		t.PortMap["vt"] = 456
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateTabletFields failed: %v", err)
+	}
+	waitForTablets(t, fhc, tablet, tablet2)
+
+	// Delete a tablet.
+	if err := ts.DeleteTablet(ctx, tablet.Alias); err != nil {
+		t.Fatalf("DeleteTablet failed: %v", err)
+	}
+	waitForTablets(t, fhc, tablet2)
+}
+
+// waitForTablets waits until the FakeHealthCheck has exactly the
+// provided tablets.
+func waitForTablets(t *testing.T, fhc *FakeHealthCheck, tablets ...*topodatapb.Tablet) {
+	timeout := time.Now().Add(10 * time.Second)
+	for {
+		allTablets := fhc.GetAllTablets()
+		found := 0
+		for _, tablet := range tablets {
+			if _, ok := allTablets[TabletToMapKey(tablet)]; ok {
+				found++
+			}
+		}
+		if found == len(tablets) && len(allTablets) == len(tablets) {
+			return
+		}
+		if time.Now().After(timeout) {
+			t.Fatalf("timed out waiting for tablets, got %v, want %v", allTablets, tablets)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 func newFakeTopo(expectGetTabletsByCell bool) *fakeTopo {
 	return &fakeTopo{
 		expectGetTabletsByCell: expectGetTabletsByCell,
@@ -140,3 +140,10 @@ func (s *Server) Watch(ctx context.Context, cellName, filePath string) (*topo.Wa
 
 	return wd, notifications, cancel
 }
+
+// WatchRecursive is part of the topo.Backend interface.
+// It is not supported by this implementation, as it doesn't store all
+// the objects in the Backend format. Use etcd2topo instead.
+func (s *Server) WatchRecursive(ctx context.Context, cellName, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	return nil, nil, nil, topo.ErrNotImplemented
+}
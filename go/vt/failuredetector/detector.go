@@ -0,0 +1,319 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package failuredetector detects the failed masters, and replaces
+// them with an emergency reparent.
+//
+// A master is suspected when its health check has been failing for a
+// grace period. The failure is then confirmed from the other vantage
+// points: the master must not answer the failure detector, and most
+// of the replicas of the shard must have lost their replication
+// connection to it. The most advanced replica is then promoted.
+// The failure detector only watches the keyspaces it is enabled for,
+// and it records all its decisions in the topology.
+package failuredetector
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+
+	replicationdatapb "github.com/gitql/vitess/go/vt/proto/replicationdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// Config is the configuration of a Detector.
+type Config struct {
+	// GracePeriod is how long the health check of a master must
+	// fail before the master is suspected.
+	GracePeriod time.Duration
+	// CheckInterval is how often the suspected masters are checked.
+	CheckInterval time.Duration
+	// Cooldown is the minimum time between two reparents of a shard.
+	Cooldown time.Duration
+	// WaitSlaveTimeout is the timeout of the RPCs to the tablets,
+	// and of the emergency reparent.
+	WaitSlaveTimeout time.Duration
+	// DryRun forces the dry-run mode for all the keyspaces.
+	DryRun bool
+}
+
+// TabletManagerClient is the part of the tmclient.TabletManagerClient
+// the Detector uses to check the tablets.
+type TabletManagerClient interface {
+	MasterPosition(ctx context.Context, tablet *topodatapb.Tablet) (string, error)
+	SlaveStatus(ctx context.Context, tablet *topodatapb.Tablet) (*replicationdatapb.Status, error)
+}
+
+// Reparenter is the part of the wrangler.Wrangler the Detector uses to
+// replace a master.
+type Reparenter interface {
+	ChooseNewMaster(ctx context.Context, keyspace, shard string, avoidMasterTabletAlias *topodatapb.TabletAlias, waitSlaveTimeout time.Duration) (*topodatapb.TabletAlias, error)
+	EmergencyReparentShard(ctx context.Context, keyspace, shard string, masterElectTabletAlias *topodatapb.TabletAlias, waitSlaveTimeout time.Duration) error
+}
+
+// tabletHealth is the health of a tablet, as seen by the health check.
+type tabletHealth struct {
+	tablet *topodatapb.Tablet
+	// unhealthySince is the time the health check started
+	// failing. It is zero if the tablet is healthy.
+	unhealthySince time.Time
+	// lastAction is the action of the last decision recorded for
+	// the current failure, so the same decision is only recorded
+	// once.
+	lastAction string
+	// lastRecorded is when the last decision was recorded.
+	lastRecorded time.Time
+}
+
+// Detector watches the health of the tablets, and replaces the failed
+// masters. It is a discovery.HealthCheckStatsListener.
+type Detector struct {
+	ts         topo.Server
+	tmc        TabletManagerClient
+	reparenter Reparenter
+	config     Config
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+
+	// mu protects the following fields.
+	mu sync.Mutex
+	// tablets is the health of the tablets, by alias.
+	tablets map[string]*tabletHealth
+}
+
+// NewDetector creates a Detector.
+func NewDetector(ts topo.Server, tmc TabletManagerClient, reparenter Reparenter, config Config) *Detector {
+	return &Detector{
+		ts:         ts,
+		tmc:        tmc,
+		reparenter: reparenter,
+		config:     config,
+		now:        time.Now,
+		tablets:    make(map[string]*tabletHealth),
+	}
+}
+
+// StatsUpdate is part of the discovery.HealthCheckStatsListener interface.
+func (d *Detector) StatsUpdate(stats *discovery.TabletStats) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	alias := topoproto.TabletAliasString(stats.Tablet.Alias)
+	if !stats.Up {
+		// The tablet was removed from the topology.
+		delete(d.tablets, alias)
+		return
+	}
+	th, ok := d.tablets[alias]
+	if !ok {
+		th = &tabletHealth{}
+		d.tablets[alias] = th
+	}
+	th.tablet = stats.Tablet
+	switch {
+	case stats.LastError == nil:
+		th.unhealthySince = time.Time{}
+		th.lastAction = ""
+	case th.unhealthySince.IsZero():
+		th.unhealthySince = d.now()
+	}
+}
+
+// Run checks the suspected masters until the context is canceled.
+func (d *Detector) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		d.checkSuspects(ctx)
+	}
+}
+
+// suspects returns the tablets whose health check has been failing for
+// longer than the grace period.
+func (d *Detector) suspects() []*tabletHealth {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var result []*tabletHealth
+	now := d.now()
+	for _, th := range d.tablets {
+		if !th.unhealthySince.IsZero() && now.Sub(th.unhealthySince) >= d.config.GracePeriod {
+			result = append(result, th)
+		}
+	}
+	return result
+}
+
+// checkSuspects checks all the suspected tablets which are masters.
+func (d *Detector) checkSuspects(ctx context.Context) {
+	for _, th := range d.suspects() {
+		if err := d.checkMaster(ctx, th); err != nil {
+			log.Warningf("failure detector: cannot check tablet %v: %v", topoproto.TabletAliasString(th.tablet.Alias), err)
+		}
+	}
+}
+
+// checkMaster confirms the failure of a suspected tablet if it is a
+// master, and reparents its shard.
+func (d *Detector) checkMaster(ctx context.Context, th *tabletHealth) error {
+	tablet := th.tablet
+	keyspace, shard := tablet.Keyspace, tablet.Shard
+	settings, err := GetKeyspaceSettings(ctx, d.ts, keyspace)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled {
+		return nil
+	}
+	si, err := d.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	if !topoproto.TabletAliasEqual(si.MasterAlias, tablet.Alias) {
+		// Only the masters are reparented.
+		return nil
+	}
+
+	decision := &Decision{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Master:   topoproto.TabletAliasString(tablet.Alias),
+	}
+	// The time of the last reparent is kept in the shard record,
+	// so the cooldown survives a restart, or a failover to another
+	// vtctld.
+	if si.FailureDetectorReparentTime != 0 {
+		lastReparent := time.Unix(si.FailureDetectorReparentTime, 0)
+		if d.now().Sub(lastReparent) < d.config.Cooldown {
+			decision.Action = ActionCooldown
+			decision.Reason = fmt.Sprintf("shard was reparented at %v", lastReparent.UTC().Format(time.RFC3339))
+			return d.record(ctx, th, decision)
+		}
+	}
+
+	confirmed, reason, err := d.confirmFailure(ctx, tablet)
+	if err != nil {
+		return err
+	}
+	decision.Reason = reason
+	if !confirmed {
+		decision.Action = ActionUnconfirmed
+		return d.record(ctx, th, decision)
+	}
+
+	newMaster, err := d.reparenter.ChooseNewMaster(ctx, keyspace, shard, tablet.Alias, d.config.WaitSlaveTimeout)
+	switch {
+	case err != nil:
+		decision.Action = ActionFailed
+		decision.Reason += fmt.Sprintf(", cannot choose new master: %v", err)
+		return d.record(ctx, th, decision)
+	case newMaster == nil:
+		decision.Action = ActionNoCandidate
+		return d.record(ctx, th, decision)
+	}
+	decision.NewMaster = topoproto.TabletAliasString(newMaster)
+
+	if d.config.DryRun || settings.DryRun {
+		decision.Action = ActionDryRun
+		return d.record(ctx, th, decision)
+	}
+
+	if _, err := d.ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.FailureDetectorReparentTime = d.now().Unix()
+		return nil
+	}); err != nil {
+		return fmt.Errorf("cannot record the reparent time of %v/%v: %v", keyspace, shard, err)
+	}
+	log.Infof("failure detector: reparenting %v/%v from failed master %v to %v: %v", keyspace, shard, decision.Master, decision.NewMaster, reason)
+	if err := d.reparenter.EmergencyReparentShard(ctx, keyspace, shard, newMaster, d.config.WaitSlaveTimeout); err != nil {
+		decision.Action = ActionFailed
+		decision.Reason += fmt.Sprintf(", EmergencyReparentShard failed: %v", err)
+		return d.record(ctx, th, decision)
+	}
+	decision.Action = ActionReparented
+	return d.record(ctx, th, decision)
+}
+
+// confirmFailure checks a suspected master from the other vantage
+// points: the master must not answer, and a majority of the replicas
+// which answer must have lost their replication connection.
+func (d *Detector) confirmFailure(ctx context.Context, master *topodatapb.Tablet) (bool, string, error) {
+	checkCtx, cancel := context.WithTimeout(ctx, d.config.WaitSlaveTimeout)
+	defer cancel()
+	if _, err := d.tmc.MasterPosition(checkCtx, master); err == nil {
+		return false, "master is reachable from the failure detector", nil
+	}
+
+	tabletMap, err := d.ts.GetTabletMapForShard(ctx, master.Keyspace, master.Shard)
+	if err != nil && err != topo.ErrPartialResult {
+		return false, "", err
+	}
+	var mu sync.Mutex
+	wg := sync.WaitGroup{}
+	answered, lost := 0, 0
+	for _, ti := range tabletMap {
+		if topoproto.TabletAliasEqual(ti.Alias, master.Alias) || !topo.IsSlaveType(ti.Type) {
+			continue
+		}
+		wg.Add(1)
+		go func(tablet *topodatapb.Tablet) {
+			defer wg.Done()
+			status, err := d.tmc.SlaveStatus(checkCtx, tablet)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			answered++
+			if !status.SlaveIoRunning {
+				lost++
+			}
+		}(ti.Tablet)
+	}
+	wg.Wait()
+
+	reason := fmt.Sprintf("master is unreachable from the failure detector, %v of %v answering replicas lost their replication connection", lost, answered)
+	return lost > 0 && 2*lost > answered, reason, nil
+}
+
+// record records a decision, unless it is the same as the last one
+// taken for the failure of the tablet. A failure is retried at every
+// check, so the same failure is recorded at most once per cooldown.
+func (d *Detector) record(ctx context.Context, th *tabletHealth, decision *Decision) error {
+	now := d.now()
+	d.mu.Lock()
+	if th.lastAction == decision.Action {
+		switch decision.Action {
+		case ActionReparented:
+		case ActionFailed:
+			if now.Sub(th.lastRecorded) < d.config.Cooldown {
+				d.mu.Unlock()
+				return nil
+			}
+		default:
+			d.mu.Unlock()
+			return nil
+		}
+	}
+	th.lastAction = decision.Action
+	th.lastRecorded = now
+	d.mu.Unlock()
+
+	decision.Time = now
+	log.Infof("failure detector decision for %v/%v: master %v, %v %v: %v", decision.Keyspace, decision.Shard, decision.Master, decision.Action, decision.NewMaster, decision.Reason)
+	return recordDecision(ctx, d.ts, decision)
+}
@@ -0,0 +1,330 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package failuredetector
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/memorytopo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+
+	replicationdatapb "github.com/gitql/vitess/go/vt/proto/replicationdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// fakeTabletManagerClient answers for the tablets, by alias.
+type fakeTabletManagerClient struct {
+	mu sync.Mutex
+	// masterReachable is true if the master answers.
+	masterReachable bool
+	// ioRunning are the replicas which are still replicating.
+	ioRunning map[string]bool
+	// down are the replicas which don't answer.
+	down map[string]bool
+}
+
+func (f *fakeTabletManagerClient) MasterPosition(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.masterReachable {
+		return "MariaDB/0-1-1", nil
+	}
+	return "", errors.New("connection refused")
+}
+
+func (f *fakeTabletManagerClient) SlaveStatus(ctx context.Context, tablet *topodatapb.Tablet) (*replicationdatapb.Status, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	alias := topoproto.TabletAliasString(tablet.Alias)
+	if f.down[alias] {
+		return nil, errors.New("connection refused")
+	}
+	return &replicationdatapb.Status{SlaveIoRunning: f.ioRunning[alias]}, nil
+}
+
+// fakeReparenter records the reparents.
+type fakeReparenter struct {
+	newMaster *topodatapb.TabletAlias
+	// chooseErr is returned by ChooseNewMaster, if set.
+	chooseErr error
+	reparents []string
+}
+
+func (f *fakeReparenter) ChooseNewMaster(ctx context.Context, keyspace, shard string, avoidMasterTabletAlias *topodatapb.TabletAlias, waitSlaveTimeout time.Duration) (*topodatapb.TabletAlias, error) {
+	return f.newMaster, f.chooseErr
+}
+
+func (f *fakeReparenter) EmergencyReparentShard(ctx context.Context, keyspace, shard string, masterElectTabletAlias *topodatapb.TabletAlias, waitSlaveTimeout time.Duration) error {
+	f.reparents = append(f.reparents, fmt.Sprintf("%v/%v: %v", keyspace, shard, topoproto.TabletAliasString(masterElectTabletAlias)))
+	return nil
+}
+
+type testEnv struct {
+	ts         topo.Server
+	tmc        *fakeTabletManagerClient
+	reparenter *fakeReparenter
+	detector   *Detector
+	now        time.Time
+	master     *topodatapb.Tablet
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	if err := ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	if err := ts.CreateShard(ctx, "ks", "0"); err != nil {
+		t.Fatalf("CreateShard failed: %v", err)
+	}
+	var master *topodatapb.Tablet
+	for i, tabletType := range []topodatapb.TabletType{topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY} {
+		tablet := &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: uint32(100 + i)},
+			Keyspace: "ks",
+			Shard:    "0",
+			Type:     tabletType,
+		}
+		if err := ts.CreateTablet(ctx, tablet); err != nil {
+			t.Fatalf("CreateTablet failed: %v", err)
+		}
+		if i == 0 {
+			master = tablet
+		}
+	}
+	if _, err := ts.UpdateShardFields(ctx, "ks", "0", func(si *topo.ShardInfo) error {
+		si.MasterAlias = master.Alias
+		si.Cells = []string{"cell1"}
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateShardFields failed: %v", err)
+	}
+
+	env := &testEnv{
+		ts: ts,
+		tmc: &fakeTabletManagerClient{
+			ioRunning: make(map[string]bool),
+			down:      make(map[string]bool),
+		},
+		reparenter: &fakeReparenter{
+			newMaster: &topodatapb.TabletAlias{Cell: "cell1", Uid: 101},
+		},
+		now:    time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+		master: master,
+	}
+	env.newDetector()
+	return env
+}
+
+// newDetector replaces the detector, as a vtctld restart would.
+func (env *testEnv) newDetector() {
+	env.detector = NewDetector(env.ts, env.tmc, env.reparenter, Config{
+		GracePeriod:      30 * time.Second,
+		Cooldown:         10 * time.Minute,
+		WaitSlaveTimeout: time.Second,
+	})
+	env.detector.now = func() time.Time { return env.now }
+}
+
+// masterHealth sends a health check update of the master.
+func (env *testEnv) masterHealth(err error) {
+	env.detector.StatsUpdate(&discovery.TabletStats{
+		Tablet:    env.master,
+		Up:        true,
+		Serving:   err == nil,
+		LastError: err,
+	})
+}
+
+// check advances the time, and checks the suspects.
+func (env *testEnv) check(d time.Duration) {
+	env.now = env.now.Add(d)
+	env.detector.checkSuspects(context.Background())
+}
+
+func (env *testEnv) decisions(t *testing.T) []string {
+	decisions, err := GetDecisions(context.Background(), env.ts, "ks")
+	if err != nil {
+		t.Fatalf("GetDecisions failed: %v", err)
+	}
+	var result []string
+	for _, d := range decisions {
+		result = append(result, d.Action+" "+d.NewMaster)
+	}
+	return result
+}
+
+func (env *testEnv) setSettings(t *testing.T, settings *KeyspaceSettings) {
+	if err := SetKeyspaceSettings(context.Background(), env.ts, "ks", settings); err != nil {
+		t.Fatalf("SetKeyspaceSettings failed: %v", err)
+	}
+}
+
+func checkDecisions(t *testing.T, env *testEnv, want ...string) {
+	got := env.decisions(t)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("decisions: %q, want %q", got, want)
+	}
+}
+
+func TestDetectorReparent(t *testing.T) {
+	env := newTestEnv(t)
+	env.setSettings(t, &KeyspaceSettings{Enabled: true})
+
+	env.masterHealth(nil)
+	env.masterHealth(errors.New("healthcheck timed out"))
+
+	// Within the grace period, nothing happens.
+	env.check(10 * time.Second)
+	checkDecisions(t, env)
+
+	// The master still answers the failure detector.
+	env.tmc.masterReachable = true
+	env.check(30 * time.Second)
+	checkDecisions(t, env, "unconfirmed ")
+
+	// The replicas are still replicating: a partition between the
+	// failure detector and the master. The same decision is not
+	// recorded twice.
+	env.tmc.masterReachable = false
+	env.tmc.ioRunning["cell1-0000000101"] = true
+	env.tmc.ioRunning["cell1-0000000102"] = true
+	env.check(5 * time.Second)
+	checkDecisions(t, env, "unconfirmed ")
+	if len(env.reparenter.reparents) != 0 {
+		t.Fatalf("unexpected reparents: %v", env.reparenter.reparents)
+	}
+
+	// One replica is down, the two others lost the master.
+	env.tmc.ioRunning = map[string]bool{}
+	env.tmc.down["cell1-0000000101"] = true
+	env.check(5 * time.Second)
+	checkDecisions(t, env, "unconfirmed ", "reparented cell1-0000000101")
+	if got, want := strings.Join(env.reparenter.reparents, ","), "ks/0: cell1-0000000101"; got != want {
+		t.Errorf("reparents: %v, want %v", got, want)
+	}
+
+	// The shard record still has the old master: the cooldown
+	// prevents another reparent.
+	env.check(5 * time.Second)
+	checkDecisions(t, env, "unconfirmed ", "reparented cell1-0000000101", "cooldown ")
+	if len(env.reparenter.reparents) != 1 {
+		t.Errorf("unexpected reparents: %v", env.reparenter.reparents)
+	}
+
+	// The cooldown is kept in the shard record, and survives
+	// a restart.
+	env.newDetector()
+	env.masterHealth(errors.New("healthcheck timed out"))
+	env.check(time.Minute)
+	checkDecisions(t, env, "unconfirmed ", "reparented cell1-0000000101", "cooldown ", "cooldown ")
+	if len(env.reparenter.reparents) != 1 {
+		t.Errorf("unexpected reparents: %v", env.reparenter.reparents)
+	}
+}
+
+func TestDetectorFailed(t *testing.T) {
+	env := newTestEnv(t)
+	env.setSettings(t, &KeyspaceSettings{Enabled: true})
+	env.reparenter.chooseErr = errors.New("no tablet answered")
+	env.masterHealth(errors.New("healthcheck timed out"))
+
+	// The failure is retried at every check, but only
+	// recorded once per cooldown.
+	env.check(time.Minute)
+	env.check(time.Minute)
+	env.check(time.Minute)
+	checkDecisions(t, env, "failed ")
+	env.check(10 * time.Minute)
+	checkDecisions(t, env, "failed ", "failed ")
+	if len(env.reparenter.reparents) != 0 {
+		t.Errorf("unexpected reparents: %v", env.reparenter.reparents)
+	}
+
+	// Once a new master can be chosen, the shard is reparented.
+	env.reparenter.chooseErr = nil
+	env.check(time.Minute)
+	checkDecisions(t, env, "failed ", "failed ", "reparented cell1-0000000101")
+	si, err := env.ts.GetShard(context.Background(), "ks", "0")
+	if err != nil {
+		t.Fatalf("GetShard failed: %v", err)
+	}
+	if got, want := si.FailureDetectorReparentTime, env.now.Unix(); got != want {
+		t.Errorf("FailureDetectorReparentTime: %v, want %v", got, want)
+	}
+}
+
+func TestDetectorSettings(t *testing.T) {
+	env := newTestEnv(t)
+	env.masterHealth(errors.New("healthcheck timed out"))
+
+	// The keyspace is not enabled.
+	env.check(time.Minute)
+	checkDecisions(t, env)
+
+	// Dry-run mode.
+	env.setSettings(t, &KeyspaceSettings{Enabled: true, DryRun: true})
+	env.check(time.Second)
+	checkDecisions(t, env, "dry_run cell1-0000000101")
+	if len(env.reparenter.reparents) != 0 {
+		t.Errorf("unexpected reparents in dry-run mode: %v", env.reparenter.reparents)
+	}
+
+	// No candidate.
+	env.reparenter.newMaster = nil
+	env.check(time.Second)
+	checkDecisions(t, env, "dry_run cell1-0000000101", "no_candidate ")
+
+	// The master recovers, then fails again: decisions are
+	// recorded again.
+	env.masterHealth(nil)
+	env.check(time.Minute)
+	env.masterHealth(errors.New("healthcheck timed out"))
+	env.check(time.Minute)
+	checkDecisions(t, env, "dry_run cell1-0000000101", "no_candidate ", "no_candidate ")
+
+	// A replica is never reparented.
+	env2 := newTestEnv(t)
+	env2.setSettings(t, &KeyspaceSettings{Enabled: true})
+	env2.master = &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: 102},
+		Keyspace: "ks",
+		Shard:    "0",
+		Type:     topodatapb.TabletType_REPLICA,
+	}
+	env2.masterHealth(errors.New("healthcheck timed out"))
+	env2.check(time.Minute)
+	checkDecisions(t, env2)
+
+	if err := SetKeyspaceSettings(context.Background(), env.ts, "unknown", &KeyspaceSettings{Enabled: true}); err == nil {
+		t.Errorf("SetKeyspaceSettings on an unknown keyspace should have failed")
+	}
+}
+
+func TestRecordDecisionLimit(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	for i := 0; i < maxDecisions+10; i++ {
+		if err := recordDecision(ctx, ts, &Decision{Keyspace: "ks", Reason: fmt.Sprintf("%v", i)}); err != nil {
+			t.Fatalf("recordDecision failed: %v", err)
+		}
+	}
+	decisions, err := GetDecisions(ctx, ts, "ks")
+	if err != nil {
+		t.Fatalf("GetDecisions failed: %v", err)
+	}
+	if len(decisions) != maxDecisions || decisions[0].Reason != "10" {
+		t.Errorf("got %v decisions starting with %v, want %v starting with 10", len(decisions), decisions[0].Reason, maxDecisions)
+	}
+}
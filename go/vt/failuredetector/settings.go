@@ -0,0 +1,166 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package failuredetector
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+// This file contains the settings and the decisions of the failure
+// detector, which are saved as JSON files in the global topology.
+
+const (
+	failureDetectorPath = "failure_detector"
+	settingsFilename    = "Settings"
+	decisionsFilename   = "Decisions"
+
+	// maxDecisions is the number of decisions kept per keyspace.
+	maxDecisions = 100
+)
+
+// The actions of a Decision.
+const (
+	// ActionUnconfirmed means the failure of the master was not
+	// confirmed by the other vantage points.
+	ActionUnconfirmed = "unconfirmed"
+	// ActionCooldown means the shard was reparented too recently
+	// to be reparented again.
+	ActionCooldown = "cooldown"
+	// ActionNoCandidate means no tablet can replace the master.
+	ActionNoCandidate = "no_candidate"
+	// ActionDryRun means the shard would have been reparented, if
+	// the failure detector was not in dry-run mode.
+	ActionDryRun = "dry_run"
+	// ActionReparented means the shard was reparented.
+	ActionReparented = "reparented"
+	// ActionFailed means the reparent, or the choice of the new
+	// master, failed.
+	ActionFailed = "failed"
+)
+
+// KeyspaceSettings are the settings of the failure detector for a
+// keyspace. The failure detector ignores the keyspaces without
+// settings.
+type KeyspaceSettings struct {
+	// Enabled is true if the failure detector watches the masters
+	// of the keyspace.
+	Enabled bool
+	// DryRun is true if the failure detector only records the
+	// reparents it would do.
+	DryRun bool
+}
+
+// Decision is a decision of the failure detector about a failed master.
+type Decision struct {
+	// Time is when the decision was taken.
+	Time time.Time
+	// Keyspace, Shard and Master identify the failed master.
+	Keyspace string
+	Shard    string
+	Master   string
+	// Action is one of the Action constants.
+	Action string
+	// NewMaster is the chosen new master, if any.
+	NewMaster string
+	// Reason explains the decision.
+	Reason string
+}
+
+func pathForSettings(keyspace string) string {
+	return path.Join(failureDetectorPath, keyspace, settingsFilename)
+}
+
+func pathForDecisions(keyspace string) string {
+	return path.Join(failureDetectorPath, keyspace, decisionsFilename)
+}
+
+// GetKeyspaceSettings returns the settings of a keyspace. A keyspace
+// without settings is not watched.
+func GetKeyspaceSettings(ctx context.Context, ts topo.Server, keyspace string) (*KeyspaceSettings, error) {
+	settings := &KeyspaceSettings{}
+	contents, _, err := ts.Get(ctx, topo.GlobalCell, pathForSettings(keyspace))
+	switch err {
+	case nil:
+	case topo.ErrNoNode:
+		return settings, nil
+	default:
+		return nil, err
+	}
+	if err := json.Unmarshal(contents, settings); err != nil {
+		return nil, fmt.Errorf("cannot read failure detector settings of %v: %v", keyspace, err)
+	}
+	return settings, nil
+}
+
+// SetKeyspaceSettings saves the settings of a keyspace.
+func SetKeyspaceSettings(ctx context.Context, ts topo.Server, keyspace string, settings *KeyspaceSettings) error {
+	if _, err := ts.GetKeyspace(ctx, keyspace); err != nil {
+		return err
+	}
+	contents, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = ts.Update(ctx, topo.GlobalCell, pathForSettings(keyspace), contents, nil)
+	return err
+}
+
+// GetDecisions returns the last decisions taken for the masters of a
+// keyspace, oldest first.
+func GetDecisions(ctx context.Context, ts topo.Server, keyspace string) ([]*Decision, error) {
+	decisions, _, err := getDecisions(ctx, ts, keyspace)
+	return decisions, err
+}
+
+func getDecisions(ctx context.Context, ts topo.Server, keyspace string) ([]*Decision, topo.Version, error) {
+	contents, version, err := ts.Get(ctx, topo.GlobalCell, pathForDecisions(keyspace))
+	switch err {
+	case nil:
+	case topo.ErrNoNode:
+		return nil, nil, nil
+	default:
+		return nil, nil, err
+	}
+	var decisions []*Decision
+	if err := json.Unmarshal(contents, &decisions); err != nil {
+		return nil, nil, fmt.Errorf("cannot read failure detector decisions of %v: %v", keyspace, err)
+	}
+	return decisions, version, nil
+}
+
+// recordDecision appends a decision to the decisions of its keyspace,
+// and drops the oldest ones.
+func recordDecision(ctx context.Context, ts topo.Server, d *Decision) error {
+	for {
+		decisions, version, err := getDecisions(ctx, ts, d.Keyspace)
+		if err != nil {
+			return err
+		}
+		decisions = append(decisions, d)
+		if len(decisions) > maxDecisions {
+			decisions = decisions[len(decisions)-maxDecisions:]
+		}
+		contents, err := json.MarshalIndent(decisions, "", "  ")
+		if err != nil {
+			return err
+		}
+		if version == nil {
+			_, err = ts.Create(ctx, topo.GlobalCell, pathForDecisions(d.Keyspace), contents)
+		} else {
+			_, err = ts.Update(ctx, topo.GlobalCell, pathForDecisions(d.Keyspace), contents, version)
+		}
+		if err != topo.ErrNodeExists && err != topo.ErrBadVersion {
+			return err
+		}
+		// Someone else updated the file, try again.
+	}
+}
@@ -81,6 +81,11 @@ type FileEntry struct {
 	// Hash is the hash of the final data (transformed and
 	// compressed if specified) stored in the BackupStorage.
 	Hash string
+
+	// Size is the size of the final data stored in the
+	// BackupStorage. It is 0 for the backups taken before it was
+	// recorded.
+	Size int64
 }
 
 func (fe *FileEntry) open(cnf *Mycnf, readOnly bool) (*os.File, error) {
@@ -134,6 +139,11 @@ type BackupManifest struct {
 	// backups that don't have this flag are assumed to be
 	// compressed.
 	SkipCompress bool
+
+	// EncryptionKeyID is the id of the key the backup files were
+	// encrypted with, if any. The key is looked up in the keyfile
+	// at restore time.
+	EncryptionKeyID string
 }
 
 // isDbDir returns true if the given directory contains a DB
@@ -355,6 +365,15 @@ func backupFiles(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger,
 	}
 	logger.Infof("found %v files to backup", len(fes))
 
+	// Get the encryption key, if any.
+	encryptionKeyID, encryptionKey, err := backupEncryptionKey()
+	if err != nil {
+		return err
+	}
+	if encryptionKeyID != "" {
+		logger.Infof("encrypting backup files with key %v", encryptionKeyID)
+	}
+
 	// Backup with the provided concurrency.
 	sema := sync2.NewSemaphore(backupConcurrency, 0)
 	rec := concurrency.AllErrorRecorder{}
@@ -374,7 +393,7 @@ func backupFiles(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger,
 
 			// Backup the individual file.
 			name := fmt.Sprintf("%v", i)
-			rec.RecordError(backupFile(ctx, mysqld, logger, bh, &fes[i], name, hookExtraEnv))
+			rec.RecordError(backupFile(ctx, mysqld, logger, bh, &fes[i], name, encryptionKey, hookExtraEnv))
 		}(i)
 	}
 
@@ -396,10 +415,11 @@ func backupFiles(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger,
 
 	// JSON-encode and write the MANIFEST
 	bm := &BackupManifest{
-		FileEntries:   fes,
-		Position:      replicationPosition,
-		TransformHook: *backupStorageHook,
-		SkipCompress:  !*backupStorageCompress,
+		FileEntries:     fes,
+		Position:        replicationPosition,
+		TransformHook:   *backupStorageHook,
+		SkipCompress:    !*backupStorageCompress,
+		EncryptionKeyID: encryptionKeyID,
 	}
 	data, err := json.MarshalIndent(bm, "", "  ")
 	if err != nil {
@@ -413,7 +433,7 @@ func backupFiles(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger,
 }
 
 // backupFile backs up an individual file.
-func backupFile(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger, bh backupstorage.BackupHandle, fe *FileEntry, name string, hookExtraEnv map[string]string) (err error) {
+func backupFile(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger, bh backupstorage.BackupHandle, fe *FileEntry, name string, encryptionKey []byte, hookExtraEnv map[string]string) (err error) {
 	// Open the source file for reading.
 	var source *os.File
 	source, err = fe.open(mysqld.Cnf(), true)
@@ -441,7 +461,18 @@ func backupFile(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger,
 
 	// Create the hasher and the tee on top.
 	hasher := newHasher()
-	writer := io.MultiWriter(dst, hasher)
+	counter := &countingWriter{}
+	writer := io.MultiWriter(dst, hasher, counter)
+
+	// Create the encryption pipe, if necessary.
+	var encrypter io.WriteCloser
+	if encryptionKey != nil {
+		encrypter, err = newEncryptWriter(writer, encryptionKey)
+		if err != nil {
+			return fmt.Errorf("cannot create encrypter: %v", err)
+		}
+		writer = encrypter
+	}
 
 	// Create the external write pipe, if any.
 	var pipe io.WriteCloser
@@ -467,7 +498,7 @@ func backupFile(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger,
 	}
 
 	// Copy from the source file to writer (optional gzip,
-	// optional pipe, tee, output file and hasher).
+	// optional pipe, optional encryption, tee, output file and hasher).
 	_, err = io.Copy(writer, source)
 	if err != nil {
 		return fmt.Errorf("cannot copy data: %v", err)
@@ -494,13 +525,21 @@ func backupFile(ctx context.Context, mysqld MysqlDaemon, logger logutil.Logger,
 		}
 	}
 
+	// Close the encryption pipe to write the last chunk.
+	if encrypter != nil {
+		if err := encrypter.Close(); err != nil {
+			return fmt.Errorf("cannot close encrypter: %v", err)
+		}
+	}
+
 	// Flush the buffer to finish writing on destination.
 	if err = dst.Flush(); err != nil {
 		return fmt.Errorf("cannot flush dst: %v", err)
 	}
 
-	// Save the hash.
+	// Save the hash and the size.
 	fe.Hash = hasher.HashString()
+	fe.Size = counter.count
 	return nil
 }
 
@@ -543,7 +582,7 @@ func checkNoDB(ctx context.Context, mysqld MysqlDaemon, dbName string) (bool, er
 
 // restoreFiles will copy all the files from the BackupStorage to the
 // right place.
-func restoreFiles(ctx context.Context, cnf *Mycnf, bh backupstorage.BackupHandle, fes []FileEntry, transformHook string, compress bool, restoreConcurrency int, hookExtraEnv map[string]string) error {
+func restoreFiles(ctx context.Context, cnf *Mycnf, bh backupstorage.BackupHandle, fes []FileEntry, transformHook string, compress bool, encryptionKey []byte, restoreConcurrency int, hookExtraEnv map[string]string) error {
 	sema := sync2.NewSemaphore(restoreConcurrency, 0)
 	rec := concurrency.AllErrorRecorder{}
 	wg := sync.WaitGroup{}
@@ -562,7 +601,7 @@ func restoreFiles(ctx context.Context, cnf *Mycnf, bh backupstorage.BackupHandle
 
 			// And restore the file.
 			name := fmt.Sprintf("%v", i)
-			rec.RecordError(restoreFile(ctx, cnf, bh, &fes[i], transformHook, compress, name, hookExtraEnv))
+			rec.RecordError(restoreFile(ctx, cnf, bh, &fes[i], transformHook, compress, encryptionKey, name, hookExtraEnv))
 		}(i)
 	}
 	wg.Wait()
@@ -570,7 +609,7 @@ func restoreFiles(ctx context.Context, cnf *Mycnf, bh backupstorage.BackupHandle
 }
 
 // restoreFile restores an individual file.
-func restoreFile(ctx context.Context, cnf *Mycnf, bh backupstorage.BackupHandle, fe *FileEntry, transformHook string, compress bool, name string, hookExtraEnv map[string]string) (err error) {
+func restoreFile(ctx context.Context, cnf *Mycnf, bh backupstorage.BackupHandle, fe *FileEntry, transformHook string, compress bool, encryptionKey []byte, name string, hookExtraEnv map[string]string) (err error) {
 	// Open the source file for reading.
 	var source io.ReadCloser
 	source, err = bh.ReadFile(ctx, name)
@@ -605,6 +644,14 @@ func restoreFile(ctx context.Context, cnf *Mycnf, bh backupstorage.BackupHandle,
 	// and into the gunziper.
 	reader := io.TeeReader(source, hasher)
 
+	// Create the decryption pipe, if needed.
+	if encryptionKey != nil {
+		reader, err = newDecryptReader(reader, encryptionKey)
+		if err != nil {
+			return fmt.Errorf("cannot create decrypter for %v: %v", fe.Name, err)
+		}
+	}
+
 	// Create the external read pipe, if any.
 	var wait hook.WaitFunc
 	if transformHook != "" {
@@ -774,6 +821,12 @@ func Restore(
 		return replication.Position{}, errors.New("backup(s) found but none could be read, unsafe to start up empty, restart to retry restore")
 	}
 
+	// Find the encryption key before changing anything.
+	encryptionKey, err := restoreEncryptionKey(bm.EncryptionKeyID)
+	if err != nil {
+		return replication.Position{}, err
+	}
+
 	if !deleteBeforeRestore {
 		logger.Infof("Restore: checking no existing data is present")
 		ok, err := checkNoDB(ctx, mysqld, dbName)
@@ -810,7 +863,7 @@ func Restore(
 	}
 
 	logger.Infof("Restore: copying all files")
-	if err := restoreFiles(context.Background(), mysqld.Cnf(), bh, bm.FileEntries, bm.TransformHook, !bm.SkipCompress, restoreConcurrency, hookExtraEnv); err != nil {
+	if err := restoreFiles(context.Background(), mysqld.Cnf(), bh, bm.FileEntries, bm.TransformHook, !bm.SkipCompress, encryptionKey, restoreConcurrency, hookExtraEnv); err != nil {
 		return replication.Position{}, err
 	}
 
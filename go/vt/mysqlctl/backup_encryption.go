@@ -0,0 +1,317 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlctl
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// This file contains the encryption of the backup files. The files
+// are encrypted with AES-256-GCM, in chunks, so they can be streamed.
+// An encrypted file is:
+// - the magic header encryptionMagic.
+// - a random nonce prefix of encryptionNoncePrefixSize bytes.
+// - the sealed chunks. Each chunk but the last one has
+//   encryptionChunkSize bytes of data. The last one has less, possibly
+//   none, so truncated files are detected.
+// The nonce of a chunk is the nonce prefix followed by the big endian
+// index of the chunk, and its additional data says if it is the last
+// one, so chunks cannot be reordered, dropped or appended.
+
+var (
+	// backupStorageEncryptionKeyfile is the file containing the
+	// encryption keys. If not set, the backups are not encrypted.
+	// It is also used at restore time, to find the key of an
+	// encrypted backup, by the key id saved in its manifest.
+	backupStorageEncryptionKeyfile = flag.String("backup_storage_encryption_keyfile", "", "if set, the backup files are encrypted with a key of this file. Each line of the file is a key id followed by a hex encoded 32 bytes AES key. Restores look for the key used by the backup in this file, so old keys should be kept after a rotation.")
+
+	// backupStorageEncryptionKeyID is the id of the key used for
+	// new backups.
+	backupStorageEncryptionKeyID = flag.String("backup_storage_encryption_key_id", "", "id of the key of -backup_storage_encryption_keyfile used to encrypt new backups. Defaults to the first key of the file.")
+)
+
+const (
+	encryptionMagic           = "VTBACKUPENC1"
+	encryptionNoncePrefixSize = 8
+	encryptionChunkSize       = 64 * 1024
+	encryptionKeySize         = 32
+)
+
+// encryptionKeys are the keys of a keyfile, by id.
+type encryptionKeys struct {
+	// first is the id of the first key of the file.
+	first string
+	keys  map[string][]byte
+}
+
+// readEncryptionKeys reads a keyfile. Empty lines and lines starting
+// with # are ignored.
+func readEncryptionKeys(filename string) (*encryptionKeys, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open encryption keyfile: %v", err)
+	}
+	defer f.Close()
+
+	ek := &encryptionKeys{
+		keys: make(map[string][]byte),
+	}
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid line %v of encryption keyfile %v: expected <key id> <hex key>", lineNumber, filename)
+		}
+		key, err := hex.DecodeString(fields[1])
+		if err != nil || len(key) != encryptionKeySize {
+			return nil, fmt.Errorf("invalid key %v in encryption keyfile %v: expected %v hex encoded bytes", fields[0], filename, encryptionKeySize)
+		}
+		if _, ok := ek.keys[fields[0]]; ok {
+			return nil, fmt.Errorf("duplicate key %v in encryption keyfile %v", fields[0], filename)
+		}
+		if ek.first == "" {
+			ek.first = fields[0]
+		}
+		ek.keys[fields[0]] = key
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read encryption keyfile %v: %v", filename, err)
+	}
+	if ek.first == "" {
+		return nil, fmt.Errorf("no key in encryption keyfile %v", filename)
+	}
+	return ek, nil
+}
+
+// backupEncryptionKey returns the id and the key to encrypt a new
+// backup with. It returns an empty id if the backups are not encrypted.
+// The keyfile is read every time, so a rotation doesn't need a restart.
+func backupEncryptionKey() (string, []byte, error) {
+	if *backupStorageEncryptionKeyfile == "" {
+		return "", nil, nil
+	}
+	ek, err := readEncryptionKeys(*backupStorageEncryptionKeyfile)
+	if err != nil {
+		return "", nil, err
+	}
+	id := *backupStorageEncryptionKeyID
+	if id == "" {
+		id = ek.first
+	}
+	key, ok := ek.keys[id]
+	if !ok {
+		return "", nil, fmt.Errorf("no key %v in encryption keyfile %v", id, *backupStorageEncryptionKeyfile)
+	}
+	return id, key, nil
+}
+
+// restoreEncryptionKey returns the key to decrypt a backup encrypted
+// with the given key id. It returns nil if the id is empty, as the
+// backup is not encrypted.
+func restoreEncryptionKey(id string) ([]byte, error) {
+	if id == "" {
+		return nil, nil
+	}
+	if *backupStorageEncryptionKeyfile == "" {
+		return nil, fmt.Errorf("backup is encrypted with key %v, but -backup_storage_encryption_keyfile is not set", id)
+	}
+	ek, err := readEncryptionKeys(*backupStorageEncryptionKeyfile)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := ek.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("backup is encrypted with key %v, which is not in encryption keyfile %v", id, *backupStorageEncryptionKeyfile)
+	}
+	return key, nil
+}
+
+// newGCM returns the AES-GCM cipher of a key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of a chunk.
+func chunkNonce(prefix []byte, index uint32) []byte {
+	nonce := make([]byte, encryptionNoncePrefixSize+4)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[encryptionNoncePrefixSize:], index)
+	return nonce
+}
+
+// chunkAdditionalData returns the additional data of a chunk.
+func chunkAdditionalData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// encryptWriter encrypts the data written to it, and writes it to the
+// underlying writer. Close must be called to write the last chunk. It
+// doesn't close the underlying writer.
+type encryptWriter struct {
+	w      io.Writer
+	gcm    cipher.AEAD
+	prefix []byte
+	index  uint32
+	buf    []byte
+}
+
+// newEncryptWriter writes the header of an encrypted file to w, and
+// returns the writer to send the data to encrypt to.
+func newEncryptWriter(w io.Writer, key []byte) (io.WriteCloser, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, encryptionNoncePrefixSize)
+	if _, err := io.ReadFull(rand.Reader, prefix); err != nil {
+		return nil, fmt.Errorf("cannot generate nonce: %v", err)
+	}
+	if _, err := io.WriteString(w, encryptionMagic); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(prefix); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		w:      w,
+		gcm:    gcm,
+		prefix: prefix,
+		buf:    make([]byte, 0, encryptionChunkSize),
+	}, nil
+}
+
+// Write is part of the io.Writer interface.
+func (ew *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := encryptionChunkSize - len(ew.buf)
+		if n > len(p) {
+			n = len(p)
+		}
+		ew.buf = append(ew.buf, p[:n]...)
+		p = p[n:]
+		written += n
+		if len(ew.buf) == encryptionChunkSize {
+			if err := ew.writeChunk(false); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close writes the last chunk. It is part of the io.Closer interface.
+func (ew *encryptWriter) Close() error {
+	return ew.writeChunk(true)
+}
+
+func (ew *encryptWriter) writeChunk(last bool) error {
+	sealed := ew.gcm.Seal(nil, chunkNonce(ew.prefix, ew.index), ew.buf, chunkAdditionalData(last))
+	ew.index++
+	ew.buf = ew.buf[:0]
+	_, err := ew.w.Write(sealed)
+	return err
+}
+
+// decryptReader decrypts the data read from the underlying reader.
+type decryptReader struct {
+	r      io.Reader
+	gcm    cipher.AEAD
+	prefix []byte
+	index  uint32
+	// sealed is the buffer to read a chunk into.
+	sealed []byte
+	// plain is the decrypted data not returned yet.
+	plain []byte
+	// done is set once the last chunk was read.
+	done bool
+}
+
+// newDecryptReader reads the header of an encrypted file from r, and
+// returns the reader of the decrypted data.
+func newDecryptReader(r io.Reader, key []byte) (io.Reader, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, len(encryptionMagic)+encryptionNoncePrefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("cannot read encryption header: %v", err)
+	}
+	if !bytes.Equal(header[:len(encryptionMagic)], []byte(encryptionMagic)) {
+		return nil, errors.New("invalid encryption header, file is not encrypted")
+	}
+	return &decryptReader{
+		r:      r,
+		gcm:    gcm,
+		prefix: header[len(encryptionMagic):],
+		sealed: make([]byte, encryptionChunkSize+gcm.Overhead()),
+	}, nil
+}
+
+// Read is part of the io.Reader interface.
+func (dr *decryptReader) Read(p []byte) (int, error) {
+	for len(dr.plain) == 0 {
+		if dr.done {
+			return 0, io.EOF
+		}
+		if err := dr.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, dr.plain)
+	dr.plain = dr.plain[n:]
+	return n, nil
+}
+
+func (dr *decryptReader) readChunk() error {
+	// A full chunk is never the last one.
+	n, err := io.ReadFull(dr.r, dr.sealed)
+	last := false
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		last = true
+	default:
+		return err
+	}
+	if last && n < dr.gcm.Overhead() {
+		return errors.New("encrypted file is truncated")
+	}
+	plain, err := dr.gcm.Open(dr.sealed[:0], chunkNonce(dr.prefix, dr.index), dr.sealed[:n], chunkAdditionalData(last))
+	if err != nil {
+		if last {
+			return fmt.Errorf("cannot decrypt last chunk %v, the file may be truncated or the key wrong: %v", dr.index, err)
+		}
+		return fmt.Errorf("cannot decrypt chunk %v, the file may be corrupted or the key wrong: %v", dr.index, err)
+	}
+	dr.index++
+	dr.plain = plain
+	dr.done = last
+	return nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlctl
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/mysqlctl/filebackupstorage"
+)
+
+var (
+	testKey1 = bytes.Repeat([]byte{1}, encryptionKeySize)
+	testKey2 = bytes.Repeat([]byte{2}, encryptionKeySize)
+)
+
+func encrypt(t *testing.T, data, key []byte) []byte {
+	buf := &bytes.Buffer{}
+	ew, err := newEncryptWriter(buf, key)
+	if err != nil {
+		t.Fatalf("newEncryptWriter failed: %v", err)
+	}
+	// Write in uneven pieces, to cross the chunk boundaries.
+	for len(data) > 0 {
+		n := 1000
+		if n > len(data) {
+			n = len(data)
+		}
+		if _, err := ew.Write(data[:n]); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		data = data[n:]
+	}
+	if err := ew.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func decrypt(encrypted, key []byte) ([]byte, error) {
+	dr, err := newDecryptReader(bytes.NewReader(encrypted), key)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(dr)
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, encryptionChunkSize - 1, encryptionChunkSize, encryptionChunkSize + 1, 3*encryptionChunkSize + 10} {
+		data := make([]byte, size)
+		rand.Read(data)
+		encrypted := encrypt(t, data, testKey1)
+		if bytes.Contains(encrypted, data) && size > 0 {
+			t.Errorf("size %v: encrypted data contains the clear data", size)
+		}
+		got, err := decrypt(encrypted, testKey1)
+		if err != nil {
+			t.Errorf("size %v: decrypt failed: %v", size, err)
+			continue
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("size %v: decrypted data differs", size)
+		}
+	}
+}
+
+func TestEncryptionTampering(t *testing.T) {
+	data := make([]byte, 2*encryptionChunkSize+100)
+	rand.Read(data)
+	encrypted := encrypt(t, data, testKey1)
+	header := len(encryptionMagic) + encryptionNoncePrefixSize
+	sealedChunk := encryptionChunkSize + 16
+
+	flipped := append([]byte(nil), encrypted...)
+	flipped[header+10] ^= 1
+	testcases := []struct {
+		name      string
+		encrypted []byte
+		key       []byte
+		err       string
+	}{{
+		name:      "wrong key",
+		encrypted: encrypted,
+		key:       testKey2,
+		err:       "cannot decrypt chunk 0",
+	}, {
+		name:      "flipped bit",
+		encrypted: flipped,
+		key:       testKey1,
+		err:       "cannot decrypt chunk 0",
+	}, {
+		name:      "truncated at chunk boundary",
+		encrypted: encrypted[:header+2*sealedChunk],
+		key:       testKey1,
+		err:       "truncated",
+	}, {
+		name:      "truncated last chunk",
+		encrypted: encrypted[:len(encrypted)-1],
+		key:       testKey1,
+		err:       "cannot decrypt last chunk 2",
+	}, {
+		name:      "dropped chunk",
+		encrypted: append(append([]byte(nil), encrypted[:header+sealedChunk]...), encrypted[header+2*sealedChunk:]...),
+		key:       testKey1,
+		err:       "cannot decrypt last chunk 1",
+	}, {
+		name:      "not encrypted",
+		encrypted: data,
+		key:       testKey1,
+		err:       "not encrypted",
+	}}
+	for _, tc := range testcases {
+		if _, err := decrypt(tc.encrypted, tc.key); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%v: got error %v, want %v", tc.name, err, tc.err)
+		}
+	}
+}
+
+func TestEncryptionKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encryptiontest")
+	if err != nil {
+		t.Fatalf("TempDir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	keyfile := path.Join(dir, "keys")
+	defer func() {
+		*backupStorageEncryptionKeyfile = ""
+		*backupStorageEncryptionKeyID = ""
+	}()
+
+	// No keyfile: no encryption.
+	if id, key, err := backupEncryptionKey(); id != "" || key != nil || err != nil {
+		t.Errorf("backupEncryptionKey without keyfile: %v %v %v", id, key, err)
+	}
+	if _, err := restoreEncryptionKey("key1"); err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Errorf("restoreEncryptionKey without keyfile: %v", err)
+	}
+
+	// After a rotation, the new key is first, and the old one kept.
+	contents := "# rotated 2017-03-01\n" +
+		"key2 " + strings.Repeat("02", encryptionKeySize) + "\n" +
+		"\n" +
+		"key1 " + strings.Repeat("01", encryptionKeySize) + "\n"
+	if err := ioutil.WriteFile(keyfile, []byte(contents), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	*backupStorageEncryptionKeyfile = keyfile
+	if id, key, err := backupEncryptionKey(); id != "key2" || !bytes.Equal(key, testKey2) || err != nil {
+		t.Errorf("backupEncryptionKey: %v %v %v, want key2", id, key, err)
+	}
+	*backupStorageEncryptionKeyID = "key1"
+	if id, key, err := backupEncryptionKey(); id != "key1" || !bytes.Equal(key, testKey1) || err != nil {
+		t.Errorf("backupEncryptionKey with key id: %v %v %v, want key1", id, key, err)
+	}
+	*backupStorageEncryptionKeyID = "key3"
+	if _, _, err := backupEncryptionKey(); err == nil || !strings.Contains(err.Error(), "no key key3") {
+		t.Errorf("backupEncryptionKey with unknown key id: %v", err)
+	}
+	if key, err := restoreEncryptionKey("key1"); !bytes.Equal(key, testKey1) || err != nil {
+		t.Errorf("restoreEncryptionKey(key1): %v %v", key, err)
+	}
+	if key, err := restoreEncryptionKey(""); key != nil || err != nil {
+		t.Errorf("restoreEncryptionKey of an unencrypted backup: %v %v", key, err)
+	}
+	if _, err := restoreEncryptionKey("key3"); err == nil || !strings.Contains(err.Error(), "not in encryption keyfile") {
+		t.Errorf("restoreEncryptionKey(key3): %v", err)
+	}
+
+	for _, contents := range []string{
+		"",
+		"key1\n",
+		"key1 0102\n",
+		"key1 " + strings.Repeat("zz", encryptionKeySize) + "\n",
+		"key1 " + strings.Repeat("01", encryptionKeySize) + "\nkey1 " + strings.Repeat("02", encryptionKeySize) + "\n",
+	} {
+		if err := ioutil.WriteFile(keyfile, []byte(contents), 0600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if _, err := readEncryptionKeys(keyfile); err == nil {
+			t.Errorf("readEncryptionKeys(%q) should have failed", contents)
+		}
+	}
+}
+
+func TestEncryptedBackupFile(t *testing.T) {
+	root, err := ioutil.TempDir("", "encryptiontest")
+	if err != nil {
+		t.Fatalf("TempDir failed: %v", err)
+	}
+	defer os.RemoveAll(root)
+	for _, dir := range []string{"backups", "data", "restored"} {
+		if err := os.Mkdir(path.Join(root, dir), os.ModePerm); err != nil {
+			t.Fatalf("Mkdir failed: %v", err)
+		}
+	}
+	*filebackupstorage.FileBackupStorageRoot = path.Join(root, "backups")
+	fbs := &filebackupstorage.FileBackupStorage{}
+	ctx := context.Background()
+
+	data := bytes.Repeat([]byte("some table data "), 10000)
+	if err := ioutil.WriteFile(path.Join(root, "data", "t.ibd"), data, os.ModePerm); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	mysqld := NewFakeMysqlDaemon(nil)
+	mysqld.Mycnf = &Mycnf{DataDir: path.Join(root, "data")}
+	fe := &FileEntry{Base: backupData, Name: "t.ibd"}
+
+	bh, err := fbs.StartBackup(ctx, "ks/0", "backup")
+	if err != nil {
+		t.Fatalf("StartBackup failed: %v", err)
+	}
+	if err := backupFile(ctx, mysqld, logutil.NewMemoryLogger(), bh, fe, "0", testKey1, nil); err != nil {
+		t.Fatalf("backupFile failed: %v", err)
+	}
+	stored, err := ioutil.ReadFile(path.Join(root, "backups", "ks/0", "backup", "0"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !bytes.HasPrefix(stored, []byte(encryptionMagic)) || int64(len(stored)) != fe.Size || len(stored) >= len(data) {
+		t.Errorf("stored file is not compressed and encrypted: %v bytes, size %v", len(stored), fe.Size)
+	}
+
+	bhs, err := fbs.ListBackups(ctx, "ks/0")
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	cnf := &Mycnf{DataDir: path.Join(root, "restored")}
+	if err := restoreFile(ctx, cnf, bhs[0], fe, "", true, testKey2, "0", nil); err == nil {
+		t.Errorf("restoreFile with the wrong key should have failed")
+	}
+	if err := restoreFile(ctx, cnf, bhs[0], fe, "", true, testKey1, "0", nil); err != nil {
+		t.Fatalf("restoreFile failed: %v", err)
+	}
+	restored, err := ioutil.ReadFile(path.Join(root, "restored", "t.ibd"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !bytes.Equal(restored, data) {
+		t.Errorf("restored file differs from the original one")
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/mysqlconn/replication"
+	"github.com/gitql/vitess/go/sync2"
+	"github.com/gitql/vitess/go/vt/concurrency"
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/mysqlctl/backupstorage"
+)
+
+// This file contains the code to manage the existing backups: list
+// them with their details, verify them, and prune them.
+
+// BackupTimestampFormat is the format of the UTC timestamp the backup
+// names start with.
+const BackupTimestampFormat = "2006-01-02.150405"
+
+// BackupInfo describes a backup stored in the BackupStorage.
+type BackupInfo struct {
+	// Directory and Name identify the backup in the BackupStorage.
+	Directory string
+	Name      string
+
+	// Time is the time the backup was started, from its name. It
+	// is the zero time if the name doesn't start with a timestamp.
+	Time time.Time
+
+	// Complete is true if the MANIFEST of the backup could be
+	// read. Otherwise, the backup is either still running, or was
+	// not finished, and Error says why the MANIFEST can't be read.
+	Complete bool
+	Error    string
+
+	// Position, FileCount, Size and EncryptionKeyID come from the
+	// MANIFEST. Size is 0 if the backup doesn't record the size of
+	// its files.
+	Position        replication.Position
+	FileCount       int
+	Size            int64
+	EncryptionKeyID string
+}
+
+// backupTime returns the time a backup was started, from its name.
+func backupTime(name string) (time.Time, bool) {
+	if len(name) < len(BackupTimestampFormat) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(BackupTimestampFormat, name[:len(BackupTimestampFormat)])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ReadBackupManifest reads and decodes the MANIFEST of a backup.
+func ReadBackupManifest(ctx context.Context, bh backupstorage.BackupHandle) (*BackupManifest, error) {
+	rc, err := bh.ReadFile(ctx, backupManifest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %v: %v", backupManifest, err)
+	}
+	defer rc.Close()
+
+	bm := &BackupManifest{}
+	if err := json.NewDecoder(rc).Decode(bm); err != nil {
+		return nil, fmt.Errorf("cannot JSON decode %v: %v", backupManifest, err)
+	}
+	return bm, nil
+}
+
+// GetBackupInfo returns the details of a backup. A backup whose
+// MANIFEST can't be read is not an error, it is an incomplete backup.
+func GetBackupInfo(ctx context.Context, bh backupstorage.BackupHandle) *BackupInfo {
+	bi := &BackupInfo{
+		Directory: bh.Directory(),
+		Name:      bh.Name(),
+	}
+	bi.Time, _ = backupTime(bh.Name())
+
+	bm, err := ReadBackupManifest(ctx, bh)
+	if err != nil {
+		bi.Error = err.Error()
+		return bi
+	}
+	bi.Complete = true
+	bi.Position = bm.Position
+	bi.FileCount = len(bm.FileEntries)
+	bi.EncryptionKeyID = bm.EncryptionKeyID
+	for _, fe := range bm.FileEntries {
+		bi.Size += fe.Size
+	}
+	return bi
+}
+
+// ListBackupInfos returns the details of the backups of a directory of
+// the BackupStorage, oldest first.
+func ListBackupInfos(ctx context.Context, bs backupstorage.BackupStorage, dir string) ([]*BackupInfo, error) {
+	bhs, err := bs.ListBackups(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("ListBackups failed: %v", err)
+	}
+	result := make([]*BackupInfo, len(bhs))
+	for i, bh := range bhs {
+		result[i] = GetBackupInfo(ctx, bh)
+	}
+	return result, nil
+}
+
+// VerifyBackup re-reads all the files of a backup, and checks them
+// against the hash, and the size if recorded, of the MANIFEST. The
+// files are neither transformed nor uncompressed, as the hash is
+// computed on the stored data. It returns the number of verified
+// files, and an error listing all the corrupted ones.
+func VerifyBackup(ctx context.Context, bh backupstorage.BackupHandle, verifyConcurrency int, logger logutil.Logger) (int, error) {
+	bm, err := ReadBackupManifest(ctx, bh)
+	if err != nil {
+		return 0, err
+	}
+	logger.Infof("verifying %v files of backup %v/%v", len(bm.FileEntries), bh.Directory(), bh.Name())
+
+	sema := sync2.NewSemaphore(verifyConcurrency, 0)
+	rec := concurrency.AllErrorRecorder{}
+	wg := sync.WaitGroup{}
+	for i := range bm.FileEntries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			sema.Acquire()
+			defer sema.Release()
+			if ctx.Err() != nil {
+				rec.RecordError(ctx.Err())
+				return
+			}
+
+			name := fmt.Sprintf("%v", i)
+			rec.RecordError(verifyFile(ctx, bh, &bm.FileEntries[i], name))
+		}(i)
+	}
+	wg.Wait()
+	return len(bm.FileEntries), rec.Error()
+}
+
+// verifyFile reads a backup file, and checks its hash and size.
+func verifyFile(ctx context.Context, bh backupstorage.BackupHandle, fe *FileEntry, name string) error {
+	source, err := bh.ReadFile(ctx, name)
+	if err != nil {
+		return fmt.Errorf("cannot read file %v (%v/%v): %v", name, fe.Base, fe.Name, err)
+	}
+	defer source.Close()
+
+	hasher := newHasher()
+	counter := &countingWriter{}
+	if _, err := io.Copy(io.MultiWriter(hasher, counter), source); err != nil {
+		return fmt.Errorf("cannot read file %v (%v/%v): %v", name, fe.Base, fe.Name, err)
+	}
+	if hash := hasher.HashString(); hash != fe.Hash {
+		return fmt.Errorf("hash mismatch for file %v (%v/%v), got %v expected %v", name, fe.Base, fe.Name, hash, fe.Hash)
+	}
+	if fe.Size != 0 && counter.count != fe.Size {
+		return fmt.Errorf("size mismatch for file %v (%v/%v), got %v expected %v", name, fe.Base, fe.Name, counter.count, fe.Size)
+	}
+	return nil
+}
+
+// RetentionPolicy describes which backups of a directory to keep. A
+// backup is kept if any of the rules keeps it. In any case, the most
+// recent complete backup, and the backups whose name has no timestamp,
+// are kept.
+type RetentionPolicy struct {
+	// Keep is the number of most recent complete backups to keep.
+	Keep int
+
+	// KeepNewerThan keeps the backups, complete or not, started
+	// less than this duration ago.
+	KeepNewerThan time.Duration
+
+	// KeepDaily keeps the most recent complete backup of each of
+	// the last KeepDaily days which have one, in UTC.
+	KeepDaily int
+
+	// KeepWeekly keeps the most recent complete backup of each of
+	// the last KeepWeekly ISO weeks which have one.
+	KeepWeekly int
+}
+
+// IsZero returns true if the policy has no rule. Such a policy would
+// only keep the most recent backup.
+func (p RetentionPolicy) IsZero() bool {
+	return p.Keep <= 0 && p.KeepNewerThan <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0
+}
+
+// BackupsToRemove returns the backups the policy doesn't keep, in the
+// same order. The backups must be sorted oldest first, as returned by
+// ListBackupInfos. The incomplete backups are removed if a more recent
+// complete backup exists, as they may still be running otherwise.
+func (p RetentionPolicy) BackupsToRemove(backups []*BackupInfo, now time.Time) []*BackupInfo {
+	keep := make(map[*BackupInfo]bool)
+	completeCount := 0
+	days := make(map[string]bool)
+	weeks := make(map[string]bool)
+	newerComplete := false
+	for i := len(backups) - 1; i >= 0; i-- {
+		bi := backups[i]
+		if bi.Time.IsZero() {
+			// We don't know when it was taken, so it is
+			// not counted by any rule.
+			keep[bi] = true
+			continue
+		}
+		if p.KeepNewerThan > 0 && now.Sub(bi.Time) < p.KeepNewerThan {
+			keep[bi] = true
+		}
+		if !bi.Complete {
+			if !newerComplete {
+				keep[bi] = true
+			}
+			continue
+		}
+
+		if !newerComplete {
+			// This is the most recent complete backup.
+			keep[bi] = true
+			newerComplete = true
+		}
+		if completeCount < p.Keep {
+			keep[bi] = true
+		}
+		completeCount++
+		day := bi.Time.UTC().Format("2006-01-02")
+		if !days[day] && len(days) < p.KeepDaily {
+			days[day] = true
+			keep[bi] = true
+		}
+		year, week := bi.Time.UTC().ISOWeek()
+		weekKey := fmt.Sprintf("%v-%v", year, week)
+		if !weeks[weekKey] && len(weeks) < p.KeepWeekly {
+			weeks[weekKey] = true
+			keep[bi] = true
+		}
+	}
+
+	var result []*BackupInfo
+	for _, bi := range backups {
+		if !keep[bi] {
+			result = append(result, bi)
+		}
+	}
+	return result
+}
+
+// PruneBackups removes the backups of a directory of the BackupStorage
+// which are not kept by the policy. With dryRun, nothing is removed.
+// It returns the removed backups, oldest first.
+func PruneBackups(ctx context.Context, bs backupstorage.BackupStorage, dir string, policy RetentionPolicy, dryRun bool, logger logutil.Logger) ([]*BackupInfo, error) {
+	if policy.IsZero() {
+		return nil, fmt.Errorf("the retention policy of %v has no rule", dir)
+	}
+	backups, err := ListBackupInfos(ctx, bs, dir)
+	if err != nil {
+		return nil, err
+	}
+	toRemove := policy.BackupsToRemove(backups, time.Now())
+	if dryRun {
+		return toRemove, nil
+	}
+
+	var removed []*BackupInfo
+	rec := concurrency.AllErrorRecorder{}
+	for _, bi := range toRemove {
+		logger.Infof("removing backup %v/%v", dir, bi.Name)
+		if err := bs.RemoveBackup(ctx, dir, bi.Name); err != nil {
+			rec.RecordError(fmt.Errorf("cannot remove backup %v: %v", bi.Name, err))
+			continue
+		}
+		removed = append(removed, bi)
+	}
+	return removed, rec.Error()
+}
@@ -0,0 +1,210 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/mysqlctl/filebackupstorage"
+)
+
+// writeTestBackup stores a backup of the given files, with a MANIFEST
+// unless incomplete is set.
+func writeTestBackup(t *testing.T, fbs *filebackupstorage.FileBackupStorage, dir, name string, files []string, incomplete bool) {
+	ctx := context.Background()
+	bh, err := fbs.StartBackup(ctx, dir, name)
+	if err != nil {
+		t.Fatalf("StartBackup failed: %v", err)
+	}
+	bm := &BackupManifest{}
+	for i, contents := range files {
+		wc, err := bh.AddFile(ctx, fmt.Sprintf("%v", i))
+		if err != nil {
+			t.Fatalf("AddFile failed: %v", err)
+		}
+		if _, err := wc.Write([]byte(contents)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		wc.Close()
+		hasher := newHasher()
+		hasher.Write([]byte(contents))
+		bm.FileEntries = append(bm.FileEntries, FileEntry{
+			Base: backupData,
+			Name: fmt.Sprintf("file%v", i),
+			Hash: hasher.HashString(),
+			Size: int64(len(contents)),
+		})
+	}
+	if !incomplete {
+		wc, err := bh.AddFile(ctx, backupManifest)
+		if err != nil {
+			t.Fatalf("AddFile failed: %v", err)
+		}
+		if err := json.NewEncoder(wc).Encode(bm); err != nil {
+			t.Fatalf("cannot write MANIFEST: %v", err)
+		}
+		wc.Close()
+	}
+	if err := bh.EndBackup(ctx); err != nil {
+		t.Fatalf("EndBackup failed: %v", err)
+	}
+}
+
+func TestListAndVerifyBackups(t *testing.T) {
+	root, err := ioutil.TempDir("", "backupmanagementtest")
+	if err != nil {
+		t.Fatalf("TempDir failed: %v", err)
+	}
+	defer os.RemoveAll(root)
+	*filebackupstorage.FileBackupStorageRoot = root
+	fbs := &filebackupstorage.FileBackupStorage{}
+	ctx := context.Background()
+
+	writeTestBackup(t, fbs, "ks/0", "2017-03-01.120000.cell1-0000000100", []string{"abc", "defgh"}, false)
+	writeTestBackup(t, fbs, "ks/0", "2017-03-02.120000.cell1-0000000100", []string{"abc"}, true)
+
+	backups, err := ListBackupInfos(ctx, fbs, "ks/0")
+	if err != nil {
+		t.Fatalf("ListBackupInfos failed: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("ListBackupInfos returned %v backups, want 2", len(backups))
+	}
+	if bi := backups[0]; !bi.Complete || bi.FileCount != 2 || bi.Size != 8 || !bi.Time.Equal(time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected complete backup: %+v", bi)
+	}
+	if bi := backups[1]; bi.Complete || !strings.Contains(bi.Error, "MANIFEST") {
+		t.Errorf("unexpected incomplete backup: %+v", bi)
+	}
+
+	bhs, err := fbs.ListBackups(ctx, "ks/0")
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	logger := logutil.NewMemoryLogger()
+	if n, err := VerifyBackup(ctx, bhs[0], 2, logger); err != nil || n != 2 {
+		t.Errorf("VerifyBackup: %v %v, want 2 verified files", n, err)
+	}
+	if _, err := VerifyBackup(ctx, bhs[1], 2, logger); err == nil || !strings.Contains(err.Error(), "cannot read MANIFEST") {
+		t.Errorf("VerifyBackup of an incomplete backup: %v", err)
+	}
+
+	// Corrupt the second file.
+	if err := ioutil.WriteFile(path.Join(root, "ks/0", bhs[0].Name(), "1"), []byte("degfh"), os.ModePerm); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := VerifyBackup(ctx, bhs[0], 2, logger); err == nil || !strings.Contains(err.Error(), "hash mismatch for file 1 (Data/file1)") {
+		t.Errorf("VerifyBackup of a corrupted backup: %v", err)
+	}
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	now := time.Date(2017, 3, 15, 12, 0, 0, 0, time.UTC)
+	backup := func(name string, complete bool) *BackupInfo {
+		bi := &BackupInfo{Name: name, Complete: complete}
+		bi.Time, _ = backupTime(name)
+		return bi
+	}
+	backups := []*BackupInfo{
+		backup("2017-02-20.100000.cell1-0000000100", true),
+		backup("2017-02-27.100000.cell1-0000000100", true),
+		backup("2017-03-05.100000.cell1-0000000100", true),
+		backup("2017-03-13.080000.cell1-0000000100", true),
+		backup("2017-03-13.200000.cell1-0000000100", true),
+		backup("2017-03-14.080000.cell1-0000000100", false),
+		backup("2017-03-14.100000.cell1-0000000100", true),
+		backup("2017-03-15.100000.cell1-0000000100", true),
+		backup("2017-03-15.113000.cell1-0000000100", false),
+		backup("old-backup", true),
+	}
+
+	testcases := []struct {
+		policy RetentionPolicy
+		remove []string
+	}{{
+		// Only the most recent complete backup, and the running one.
+		policy: RetentionPolicy{Keep: 1},
+		remove: []string{
+			"2017-02-20.100000.cell1-0000000100",
+			"2017-02-27.100000.cell1-0000000100",
+			"2017-03-05.100000.cell1-0000000100",
+			"2017-03-13.080000.cell1-0000000100",
+			"2017-03-13.200000.cell1-0000000100",
+			"2017-03-14.080000.cell1-0000000100",
+			"2017-03-14.100000.cell1-0000000100",
+		},
+	}, {
+		policy: RetentionPolicy{Keep: 3},
+		remove: []string{
+			"2017-02-20.100000.cell1-0000000100",
+			"2017-02-27.100000.cell1-0000000100",
+			"2017-03-05.100000.cell1-0000000100",
+			"2017-03-13.080000.cell1-0000000100",
+			"2017-03-14.080000.cell1-0000000100",
+		},
+	}, {
+		// The incomplete backup is kept by its age.
+		policy: RetentionPolicy{KeepNewerThan: 36 * time.Hour},
+		remove: []string{
+			"2017-02-20.100000.cell1-0000000100",
+			"2017-02-27.100000.cell1-0000000100",
+			"2017-03-05.100000.cell1-0000000100",
+			"2017-03-13.080000.cell1-0000000100",
+			"2017-03-13.200000.cell1-0000000100",
+		},
+	}, {
+		policy: RetentionPolicy{KeepDaily: 3},
+		remove: []string{
+			"2017-02-20.100000.cell1-0000000100",
+			"2017-02-27.100000.cell1-0000000100",
+			"2017-03-05.100000.cell1-0000000100",
+			"2017-03-13.080000.cell1-0000000100",
+			"2017-03-14.080000.cell1-0000000100",
+		},
+	}, {
+		// 2017-03-05 is a Sunday, in the same ISO week as 2017-02-27.
+		policy: RetentionPolicy{KeepWeekly: 3},
+		remove: []string{
+			"2017-02-27.100000.cell1-0000000100",
+			"2017-03-13.080000.cell1-0000000100",
+			"2017-03-13.200000.cell1-0000000100",
+			"2017-03-14.080000.cell1-0000000100",
+			"2017-03-14.100000.cell1-0000000100",
+		},
+	}, {
+		policy: RetentionPolicy{Keep: 2, KeepWeekly: 2},
+		remove: []string{
+			"2017-02-20.100000.cell1-0000000100",
+			"2017-02-27.100000.cell1-0000000100",
+			"2017-03-13.080000.cell1-0000000100",
+			"2017-03-13.200000.cell1-0000000100",
+			"2017-03-14.080000.cell1-0000000100",
+		},
+	}}
+	for _, tc := range testcases {
+		var got []string
+		for _, bi := range tc.policy.BackupsToRemove(backups, now) {
+			got = append(got, bi.Name)
+		}
+		if !reflect.DeepEqual(got, tc.remove) {
+			t.Errorf("BackupsToRemove(%+v):\n%v\nwant:\n%v", tc.policy, strings.Join(got, "\n"), strings.Join(tc.remove, "\n"))
+		}
+	}
+
+	if !(RetentionPolicy{}).IsZero() {
+		t.Errorf("empty policy should be zero")
+	}
+}
@@ -62,3 +62,13 @@ func newHasher() *hasher {
 func (h *hasher) HashString() string {
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	count int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.count += int64(len(p))
+	return len(p), nil
+}
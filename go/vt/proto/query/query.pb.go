@@ -67,6 +67,7 @@ It has these top-level messages:
 	UpdateStreamRequest
 	UpdateStreamResponse
 	TransactionMetadata
+	TableSchema
 */
 package query
 
@@ -427,6 +428,44 @@ func (ExecuteOptions_IncludedFields) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor0, []int{6, 0}
 }
 
+type ExecuteOptions_TransactionIsolation int32
+
+const (
+	// DEFAULT uses the isolation level configured in MySQL.
+	ExecuteOptions_DEFAULT          ExecuteOptions_TransactionIsolation = 0
+	ExecuteOptions_REPEATABLE_READ  ExecuteOptions_TransactionIsolation = 1
+	ExecuteOptions_READ_COMMITTED   ExecuteOptions_TransactionIsolation = 2
+	ExecuteOptions_READ_UNCOMMITTED ExecuteOptions_TransactionIsolation = 3
+	ExecuteOptions_SERIALIZABLE     ExecuteOptions_TransactionIsolation = 4
+	// CONSISTENT_SNAPSHOT_READ_ONLY starts a read-only transaction
+	// with a consistent snapshot (REPEATABLE_READ).
+	ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY ExecuteOptions_TransactionIsolation = 5
+)
+
+var ExecuteOptions_TransactionIsolation_name = map[int32]string{
+	0: "DEFAULT",
+	1: "REPEATABLE_READ",
+	2: "READ_COMMITTED",
+	3: "READ_UNCOMMITTED",
+	4: "SERIALIZABLE",
+	5: "CONSISTENT_SNAPSHOT_READ_ONLY",
+}
+var ExecuteOptions_TransactionIsolation_value = map[string]int32{
+	"DEFAULT":                       0,
+	"REPEATABLE_READ":               1,
+	"READ_COMMITTED":                2,
+	"READ_UNCOMMITTED":              3,
+	"SERIALIZABLE":                  4,
+	"CONSISTENT_SNAPSHOT_READ_ONLY": 5,
+}
+
+func (x ExecuteOptions_TransactionIsolation) String() string {
+	return proto.EnumName(ExecuteOptions_TransactionIsolation_name, int32(x))
+}
+func (ExecuteOptions_TransactionIsolation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{6, 1}
+}
+
 // The category of one statement.
 type StreamEvent_Statement_Category int32
 
@@ -459,15 +498,18 @@ type SplitQueryRequest_Algorithm int32
 const (
 	SplitQueryRequest_EQUAL_SPLITS SplitQueryRequest_Algorithm = 0
 	SplitQueryRequest_FULL_SCAN    SplitQueryRequest_Algorithm = 1
+	SplitQueryRequest_SAMPLING     SplitQueryRequest_Algorithm = 2
 )
 
 var SplitQueryRequest_Algorithm_name = map[int32]string{
 	0: "EQUAL_SPLITS",
 	1: "FULL_SCAN",
+	2: "SAMPLING",
 }
 var SplitQueryRequest_Algorithm_value = map[string]int32{
 	"EQUAL_SPLITS": 0,
 	"FULL_SCAN":    1,
+	"SAMPLING":     2,
 }
 
 func (x SplitQueryRequest_Algorithm) String() string {
@@ -590,6 +632,23 @@ type ExecuteOptions struct {
 	// field name, table name, etc. This is an optimization for high-QPS queries where
 	// the client knows what it's getting
 	IncludedFields ExecuteOptions_IncludedFields `protobuf:"varint,4,opt,name=included_fields,json=includedFields,enum=query.ExecuteOptions_IncludedFields" json:"included_fields,omitempty"`
+	// If set, a master tablet will return its current replication
+	// position in the ResultExtras, after the query has been executed.
+	// If set when starting a transaction, the position is returned
+	// in the CommitResponse instead.
+	IncludePosition bool `protobuf:"varint,5,opt,name=include_position,json=includePosition" json:"include_position,omitempty"`
+	// If set, a non-master tablet will wait until it has replicated
+	// up to this position before executing the query. If the position
+	// cannot be reached in time, the query fails with QUERY_NOT_SERVED.
+	// The format is the one returned in ResultExtras.position.
+	WaitForPosition string `protobuf:"bytes,6,opt,name=wait_for_position,json=waitForPosition" json:"wait_for_position,omitempty"`
+	// transaction_isolation is used by Begin and BeginExecute
+	// to start the transaction. StreamExecute also uses
+	// CONSISTENT_SNAPSHOT_READ_ONLY: the query then reads a consistent
+	// snapshot of its only table, outside of the transaction pool, and
+	// the first result carries the position of the snapshot.
+	// It's ignored by the other calls.
+	TransactionIsolation ExecuteOptions_TransactionIsolation `protobuf:"varint,7,opt,name=transaction_isolation,json=transactionIsolation,enum=query.ExecuteOptions_TransactionIsolation" json:"transaction_isolation,omitempty"`
 }
 
 func (m *ExecuteOptions) Reset()                    { *m = ExecuteOptions{} }
@@ -657,6 +716,11 @@ type ResultExtras struct {
 	// If set, it means the data returned with this result is fresher
 	// than the compare_token passed in the ExecuteOptions.
 	Fresher bool `protobuf:"varint,2,opt,name=fresher" json:"fresher,omitempty"`
+	// position is populated if the include_position flag is set
+	// in ExecuteOptions, and the query was executed by a master.
+	// It's also the position of the snapshot in the first result of
+	// a CONSISTENT_SNAPSHOT_READ_ONLY stream, whatever the tablet type.
+	Position string `protobuf:"bytes,3,opt,name=position" json:"position,omitempty"`
 }
 
 func (m *ResultExtras) Reset()                    { *m = ResultExtras{} }
@@ -1009,6 +1073,7 @@ type BeginRequest struct {
 	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id,json=effectiveCallerId" json:"effective_caller_id,omitempty"`
 	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
 	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	Options           *ExecuteOptions `protobuf:"bytes,4,opt,name=options" json:"options,omitempty"`
 }
 
 func (m *BeginRequest) Reset()                    { *m = BeginRequest{} }
@@ -1037,6 +1102,13 @@ func (m *BeginRequest) GetTarget() *Target {
 	return nil
 }
 
+func (m *BeginRequest) GetOptions() *ExecuteOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 // BeginResponse is the returned value from Begin
 type BeginResponse struct {
 	TransactionId int64 `protobuf:"varint,1,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
@@ -1083,6 +1155,9 @@ func (m *CommitRequest) GetTarget() *Target {
 
 // CommitResponse is the returned value from Commit
 type CommitResponse struct {
+	// position is populated if the transaction was started with
+	// include_position set, and it was committed by a master.
+	Position string `protobuf:"bytes,1,opt,name=position" json:"position,omitempty"`
 }
 
 func (m *CommitResponse) Reset()                    { *m = CommitResponse{} }
@@ -1918,6 +1993,11 @@ type StreamHealthResponse struct {
 	TabletExternallyReparentedTimestamp int64 `protobuf:"varint,3,opt,name=tablet_externally_reparented_timestamp,json=tabletExternallyReparentedTimestamp" json:"tablet_externally_reparented_timestamp,omitempty"`
 	// realtime_stats contains information about the tablet status
 	RealtimeStats *RealtimeStats `protobuf:"bytes,4,opt,name=realtime_stats,json=realtimeStats" json:"realtime_stats,omitempty"`
+	// table_schemas contains the columns of every table known to the
+	// tablet. It is only populated if vttablet runs with
+	// -queryserver-config-publish-schema. vtgate uses it to expand
+	// '*' expressions and to resolve unqualified columns in joins.
+	TableSchemas []*TableSchema `protobuf:"bytes,5,rep,name=table_schemas,json=tableSchemas" json:"table_schemas,omitempty"`
 }
 
 func (m *StreamHealthResponse) Reset()                    { *m = StreamHealthResponse{} }
@@ -1939,6 +2019,13 @@ func (m *StreamHealthResponse) GetRealtimeStats() *RealtimeStats {
 	return nil
 }
 
+func (m *StreamHealthResponse) GetTableSchemas() []*TableSchema {
+	if m != nil {
+		return m.TableSchemas
+	}
+	return nil
+}
+
 // UpdateStreamRequest is the payload for UpdateStream. At most one of
 // position and timestamp can be set. If neither is set, we will start
 // streaming from the current binlog position.
@@ -2017,6 +2104,24 @@ func (m *TransactionMetadata) GetParticipants() []*Target {
 	return nil
 }
 
+// TableSchema describes the columns of a table, as seen by vttablet.
+type TableSchema struct {
+	Name    string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Columns []*Field `protobuf:"bytes,2,rep,name=columns" json:"columns,omitempty"`
+}
+
+func (m *TableSchema) Reset()                    { *m = TableSchema{} }
+func (m *TableSchema) String() string            { return proto.CompactTextString(m) }
+func (*TableSchema) ProtoMessage()               {}
+func (*TableSchema) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *TableSchema) GetColumns() []*Field {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Target)(nil), "query.Target")
 	proto.RegisterType((*VTGateCallerID)(nil), "query.VTGateCallerID")
@@ -2077,11 +2182,13 @@ func init() {
 	proto.RegisterType((*UpdateStreamRequest)(nil), "query.UpdateStreamRequest")
 	proto.RegisterType((*UpdateStreamResponse)(nil), "query.UpdateStreamResponse")
 	proto.RegisterType((*TransactionMetadata)(nil), "query.TransactionMetadata")
+	proto.RegisterType((*TableSchema)(nil), "query.TableSchema")
 	proto.RegisterEnum("query.MySqlFlag", MySqlFlag_name, MySqlFlag_value)
 	proto.RegisterEnum("query.Flag", Flag_name, Flag_value)
 	proto.RegisterEnum("query.Type", Type_name, Type_value)
 	proto.RegisterEnum("query.TransactionState", TransactionState_name, TransactionState_value)
 	proto.RegisterEnum("query.ExecuteOptions_IncludedFields", ExecuteOptions_IncludedFields_name, ExecuteOptions_IncludedFields_value)
+	proto.RegisterEnum("query.ExecuteOptions_TransactionIsolation", ExecuteOptions_TransactionIsolation_name, ExecuteOptions_TransactionIsolation_value)
 	proto.RegisterEnum("query.StreamEvent_Statement_Category", StreamEvent_Statement_Category_name, StreamEvent_Statement_Category_value)
 	proto.RegisterEnum("query.SplitQueryRequest_Algorithm", SplitQueryRequest_Algorithm_name, SplitQueryRequest_Algorithm_value)
 }
@@ -2089,180 +2196,194 @@ func init() {
 func init() { proto.RegisterFile("query.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2797 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x3a, 0x49, 0x73, 0x1b, 0xc7,
-	0xd5, 0x1a, 0x2c, 0x24, 0xf0, 0x40, 0x80, 0xcd, 0x06, 0x69, 0xc1, 0x94, 0x17, 0x7e, 0x63, 0xcb,
-	0xd6, 0x27, 0x3b, 0x8c, 0x4c, 0x29, 0x8a, 0xcb, 0xce, 0xa2, 0x21, 0x38, 0x94, 0x61, 0x61, 0x53,
-	0x63, 0x20, 0x87, 0x2e, 0x57, 0x4d, 0x0d, 0x81, 0x16, 0x39, 0x45, 0x00, 0x03, 0xcd, 0x34, 0x28,
-	0xe3, 0xa6, 0xc4, 0xd9, 0x9c, 0xd5, 0x59, 0x1c, 0x67, 0xa9, 0x38, 0xa9, 0xca, 0x3d, 0xff, 0x20,
-	0x55, 0xa9, 0xfc, 0x80, 0xdc, 0x72, 0x48, 0x72, 0xc8, 0x29, 0x95, 0xca, 0x2d, 0xa7, 0x1c, 0x72,
-	0x48, 0xa5, 0x7a, 0x99, 0xc1, 0x80, 0x84, 0x2d, 0x59, 0xc9, 0x85, 0x92, 0x4f, 0x98, 0xb7, 0xcc,
-	0xdb, 0xdf, 0xeb, 0x9e, 0x6e, 0x40, 0xee, 0xd6, 0x88, 0xfa, 0xe3, 0xf5, 0xa1, 0xef, 0x31, 0x0f,
-	0xa7, 0x05, 0xb0, 0x5a, 0x60, 0xde, 0xd0, 0xeb, 0x3a, 0xcc, 0x91, 0xe8, 0xd5, 0xdc, 0x21, 0xf3,
-	0x87, 0x1d, 0x09, 0xe8, 0xb7, 0x60, 0xce, 0x72, 0xfc, 0x3d, 0xca, 0xf0, 0x2a, 0x64, 0x0e, 0xe8,
-	0x38, 0x18, 0x3a, 0x1d, 0x5a, 0xd2, 0xd6, 0xb4, 0x73, 0x59, 0x12, 0xc1, 0x78, 0x19, 0xd2, 0xc1,
-	0xbe, 0xe3, 0x77, 0x4b, 0x09, 0x41, 0x90, 0x00, 0xfe, 0x14, 0xe4, 0x98, 0xb3, 0xdb, 0xa3, 0xcc,
-	0x66, 0xe3, 0x21, 0x2d, 0x25, 0xd7, 0xb4, 0x73, 0x85, 0x8d, 0xe5, 0xf5, 0x48, 0x9d, 0x25, 0x88,
-	0xd6, 0x78, 0x48, 0x09, 0xb0, 0xe8, 0x59, 0x7f, 0x1e, 0x0a, 0x37, 0xac, 0xab, 0x0e, 0xa3, 0x65,
-	0xa7, 0xd7, 0xa3, 0x7e, 0x65, 0x8b, 0xab, 0x1e, 0x05, 0xd4, 0x1f, 0x38, 0xfd, 0x48, 0x75, 0x08,
-	0xeb, 0x6f, 0x00, 0x98, 0x87, 0x74, 0xc0, 0x2c, 0xef, 0x80, 0x0e, 0xf0, 0x63, 0x90, 0x65, 0x6e,
-	0x9f, 0x06, 0xcc, 0xe9, 0x0f, 0x05, 0x6b, 0x92, 0x4c, 0x10, 0x1f, 0x60, 0xe6, 0x2a, 0x64, 0x86,
-	0x5e, 0xe0, 0x32, 0xd7, 0x1b, 0x08, 0x1b, 0xb3, 0x24, 0x82, 0xf5, 0xcf, 0x41, 0xfa, 0x86, 0xd3,
-	0x1b, 0x51, 0xfc, 0x24, 0xa4, 0x84, 0x13, 0x9a, 0x70, 0x22, 0xb7, 0x2e, 0xe3, 0x28, 0x6c, 0x17,
-	0x04, 0x2e, 0xfb, 0x90, 0x73, 0x0a, 0xd9, 0x0b, 0x44, 0x02, 0xfa, 0x01, 0x2c, 0x6c, 0xba, 0x83,
-	0xee, 0x0d, 0xc7, 0x77, 0xb9, 0x83, 0xf7, 0x29, 0x06, 0x3f, 0x0d, 0x73, 0xe2, 0x21, 0x28, 0x25,
-	0xd7, 0x92, 0xe7, 0x72, 0x1b, 0x0b, 0xea, 0x45, 0x61, 0x1b, 0x51, 0x34, 0xfd, 0x77, 0x1a, 0xc0,
-	0xa6, 0x37, 0x1a, 0x74, 0xaf, 0x73, 0x22, 0x46, 0x90, 0x0c, 0x6e, 0xf5, 0x54, 0xc0, 0xf8, 0x23,
-	0xbe, 0x06, 0x85, 0x5d, 0x77, 0xd0, 0xb5, 0x0f, 0x95, 0x39, 0x41, 0x29, 0x21, 0xc4, 0x3d, 0xad,
-	0xc4, 0x4d, 0x5e, 0x5e, 0x8f, 0x5b, 0x1d, 0x98, 0x03, 0xe6, 0x8f, 0x49, 0x7e, 0x37, 0x8e, 0x5b,
-	0x6d, 0x03, 0x3e, 0xce, 0xc4, 0x95, 0x1e, 0xd0, 0x71, 0xa8, 0xf4, 0x80, 0x8e, 0xf1, 0xff, 0xc7,
-	0x3d, 0xca, 0x6d, 0x14, 0x43, 0x5d, 0xb1, 0x77, 0x95, 0x9b, 0x2f, 0x25, 0x5e, 0xd4, 0xf4, 0x77,
-	0x13, 0x50, 0x30, 0xdf, 0xa4, 0x9d, 0x11, 0xa3, 0x8d, 0x21, 0xcf, 0x41, 0x80, 0xd7, 0xa1, 0xe8,
-	0x0e, 0x3a, 0xbd, 0x51, 0x97, 0xda, 0x94, 0xa7, 0xda, 0x66, 0x3c, 0xd7, 0x42, 0x5e, 0x86, 0x2c,
-	0x29, 0x52, 0xac, 0x08, 0x0c, 0x28, 0x76, 0xbc, 0xfe, 0xd0, 0xf1, 0xa7, 0xf9, 0x93, 0x42, 0xff,
-	0x92, 0xd2, 0x3f, 0xe1, 0x27, 0x4b, 0x8a, 0x3b, 0x26, 0xa2, 0x06, 0x8b, 0x4a, 0x6e, 0xd7, 0xbe,
-	0xe9, 0xd2, 0x5e, 0x37, 0x28, 0xa5, 0x44, 0xca, 0xc2, 0x50, 0x4d, 0x9b, 0xb8, 0x5e, 0x51, 0xcc,
-	0xdb, 0x82, 0x97, 0x14, 0xdc, 0x29, 0x58, 0x7f, 0x19, 0x0a, 0xd3, 0x1c, 0x78, 0x09, 0xf2, 0xd6,
-	0x4e, 0xd3, 0xb4, 0x8d, 0xfa, 0x96, 0x5d, 0x37, 0x6a, 0x26, 0x3a, 0x85, 0xf3, 0x90, 0x15, 0xa8,
-	0x46, 0xbd, 0xba, 0x83, 0x34, 0x3c, 0x0f, 0x49, 0xa3, 0x5a, 0x45, 0x89, 0x57, 0x53, 0x19, 0x0d,
-	0x25, 0xf4, 0xf7, 0x12, 0x90, 0x16, 0xef, 0x62, 0x0c, 0xa9, 0x58, 0x27, 0x88, 0xe7, 0xa8, 0xae,
-	0x12, 0x1f, 0x52, 0x57, 0xa2, 0xc5, 0x54, 0x85, 0x4b, 0x00, 0x9f, 0x81, 0xac, 0xe7, 0xef, 0xd9,
-	0x92, 0x92, 0x92, 0xb5, 0xef, 0xf9, 0x7b, 0xa2, 0x31, 0x79, 0x5f, 0xf0, 0x36, 0xdd, 0x75, 0x02,
-	0x5a, 0x4a, 0x4b, 0x5a, 0x08, 0xe3, 0x47, 0x81, 0xf3, 0xd9, 0xc2, 0x8e, 0x39, 0x41, 0x9b, 0xf7,
-	0xfc, 0xbd, 0x3a, 0x37, 0xe5, 0x29, 0xc8, 0x77, 0xbc, 0xde, 0xa8, 0x3f, 0xb0, 0x7b, 0x74, 0xb0,
-	0xc7, 0xf6, 0x4b, 0xf3, 0x6b, 0xda, 0xb9, 0x3c, 0x59, 0x90, 0xc8, 0xaa, 0xc0, 0xe1, 0x12, 0xcc,
-	0x77, 0xf6, 0x1d, 0x3f, 0xa0, 0xac, 0x94, 0x11, 0xe4, 0x10, 0x14, 0x5a, 0x69, 0xc7, 0xed, 0x3b,
-	0xbd, 0xa0, 0x94, 0x15, 0xa4, 0x08, 0xe6, 0x4e, 0xdc, 0xec, 0x39, 0x7b, 0x41, 0x09, 0x04, 0x41,
-	0x02, 0xfa, 0xa7, 0x21, 0x49, 0xbc, 0xdb, 0x5c, 0xa4, 0x54, 0x18, 0x94, 0xb4, 0xb5, 0xe4, 0x39,
-	0x4c, 0x42, 0x10, 0x3f, 0x12, 0x75, 0x8f, 0x6c, 0xaa, 0xb0, 0x5f, 0xde, 0x80, 0x05, 0x42, 0x83,
-	0x51, 0x8f, 0x99, 0x6f, 0x32, 0xdf, 0x09, 0xf0, 0x06, 0xe4, 0xe2, 0xf5, 0xa2, 0x7d, 0x50, 0xbd,
-	0x00, 0x9d, 0x14, 0x4a, 0x09, 0xe6, 0x6f, 0xfa, 0x34, 0xd8, 0xa7, 0xbe, 0xaa, 0xc7, 0x10, 0xe4,
-	0xdd, 0x98, 0x13, 0xbd, 0x24, 0x75, 0xf0, 0x1e, 0x56, 0x95, 0xa4, 0x4d, 0xf5, 0xb0, 0x48, 0x2a,
-	0x51, 0x34, 0x1e, 0x3d, 0xdf, 0xbb, 0x1d, 0xd8, 0xce, 0xcd, 0x9b, 0xb4, 0xc3, 0xa8, 0x1c, 0x55,
-	0x29, 0xb2, 0xc0, 0x91, 0x86, 0xc2, 0xf1, 0xb4, 0xb9, 0x83, 0x80, 0xfa, 0xcc, 0x76, 0xbb, 0x22,
-	0xa1, 0x29, 0x92, 0x91, 0x88, 0x4a, 0x17, 0x3f, 0x01, 0x29, 0xce, 0x5c, 0x4a, 0x09, 0x2d, 0xa0,
-	0xb4, 0x10, 0xef, 0x36, 0x11, 0x78, 0xfc, 0x1c, 0xcc, 0x51, 0xe1, 0xaf, 0x48, 0xea, 0xa4, 0x21,
-	0xe3, 0xa1, 0x20, 0x8a, 0x45, 0xff, 0x65, 0x12, 0x72, 0x2d, 0xe6, 0x53, 0xa7, 0x2f, 0xfc, 0xc7,
-	0x9f, 0x01, 0x08, 0x98, 0xc3, 0x68, 0x9f, 0x0e, 0x58, 0xe8, 0xc8, 0x63, 0x4a, 0x40, 0x8c, 0x6f,
-	0xbd, 0x15, 0x32, 0x91, 0x18, 0xff, 0xd1, 0x00, 0x27, 0xee, 0x21, 0xc0, 0xab, 0xef, 0x27, 0x20,
-	0x1b, 0x49, 0xc3, 0x06, 0x64, 0x3a, 0x0e, 0xa3, 0x7b, 0x9e, 0x3f, 0x56, 0x33, 0xf4, 0xec, 0x87,
-	0x69, 0x5f, 0x2f, 0x2b, 0x66, 0x12, 0xbd, 0x86, 0x1f, 0x07, 0xb9, 0xd8, 0xc8, 0xe2, 0x95, 0x2b,
-	0x41, 0x56, 0x60, 0x44, 0xf9, 0xbe, 0x04, 0x78, 0xe8, 0xbb, 0x7d, 0xc7, 0x1f, 0xdb, 0x07, 0x74,
-	0x1c, 0x36, 0x7f, 0x72, 0x46, 0xca, 0x90, 0xe2, 0xbb, 0x46, 0xc7, 0xaa, 0xa9, 0x5f, 0x9c, 0x7e,
-	0x57, 0x15, 0xdd, 0xf1, 0x44, 0xc4, 0xde, 0x14, 0x13, 0x3c, 0x08, 0x67, 0x75, 0x5a, 0xd4, 0x27,
-	0x7f, 0xd4, 0x9f, 0x85, 0x4c, 0x68, 0x3c, 0xce, 0x42, 0xda, 0xf4, 0x7d, 0xcf, 0x47, 0xa7, 0xf8,
-	0x54, 0xd8, 0xaa, 0x55, 0xe5, 0x78, 0xd8, 0xda, 0xaa, 0xa2, 0x84, 0xfe, 0xdb, 0xc9, 0xc0, 0x24,
-	0xf4, 0xd6, 0x88, 0x06, 0x0c, 0x7f, 0x1e, 0x8a, 0x54, 0xd4, 0x8a, 0x7b, 0x48, 0xed, 0x8e, 0x58,
-	0x45, 0x79, 0xa5, 0xc8, 0x82, 0x5e, 0x5c, 0x97, 0xeb, 0x7b, 0xb8, 0xba, 0x92, 0xa5, 0x88, 0x57,
-	0xa1, 0xba, 0xd8, 0x84, 0xa2, 0xdb, 0xef, 0xd3, 0xae, 0xeb, 0xb0, 0xb8, 0x00, 0x99, 0xb0, 0x95,
-	0x70, 0xf1, 0x99, 0x5a, 0xa4, 0xc9, 0x52, 0xf4, 0x46, 0x24, 0xe6, 0x2c, 0xcc, 0x31, 0xb1, 0x79,
-	0x50, 0xb3, 0x37, 0x1f, 0xce, 0x25, 0x81, 0x24, 0x8a, 0x88, 0x9f, 0x05, 0xb9, 0x13, 0x11, 0x13,
-	0x68, 0x52, 0x10, 0x93, 0xd5, 0x88, 0x48, 0x3a, 0x3e, 0x0b, 0x05, 0xe6, 0x3b, 0x83, 0xc0, 0xe9,
-	0xf0, 0xa9, 0xcb, 0x2d, 0x4a, 0x8b, 0x25, 0x3e, 0x1f, 0xc3, 0x56, 0xba, 0xf8, 0x93, 0x30, 0xef,
-	0xc9, 0xb9, 0x2c, 0x66, 0xd3, 0xc4, 0xe2, 0xe9, 0xa1, 0x4d, 0x42, 0x2e, 0xfd, 0xb3, 0xb0, 0x18,
-	0x45, 0x30, 0x18, 0x7a, 0x83, 0x80, 0xe2, 0xf3, 0x30, 0xe7, 0x8b, 0x86, 0x50, 0x51, 0xc3, 0x4a,
-	0x44, 0xac, 0xa3, 0x89, 0xe2, 0xd0, 0xbb, 0xb0, 0x28, 0x31, 0xaf, 0xb9, 0x6c, 0x5f, 0x24, 0x0a,
-	0x9f, 0x85, 0x34, 0xe5, 0x0f, 0x47, 0x62, 0x4e, 0x9a, 0x65, 0x41, 0x27, 0x92, 0x1a, 0xd3, 0x92,
-	0xb8, 0xab, 0x96, 0x7f, 0x24, 0xa0, 0xa8, 0xac, 0xdc, 0x74, 0x58, 0x67, 0xff, 0x84, 0x26, 0xfb,
-	0x39, 0x98, 0xe7, 0x78, 0x37, 0x6a, 0x8c, 0x19, 0xe9, 0x0e, 0x39, 0x78, 0xc2, 0x9d, 0xc0, 0x8e,
-	0x65, 0x57, 0x24, 0x3c, 0x43, 0xf2, 0x4e, 0x60, 0x4d, 0x90, 0x33, 0xea, 0x62, 0xee, 0x2e, 0x75,
-	0x31, 0x7f, 0x4f, 0x75, 0xb1, 0x05, 0xcb, 0xd3, 0x11, 0x57, 0xc5, 0xf1, 0x3c, 0xcc, 0xcb, 0xa4,
-	0x84, 0x23, 0x70, 0x56, 0xde, 0x42, 0x16, 0xfd, 0x17, 0x09, 0x58, 0x56, 0xd3, 0xe9, 0xe1, 0x68,
-	0xd3, 0x58, 0x9c, 0xd3, 0xf7, 0x14, 0xe7, 0x32, 0xac, 0x1c, 0x09, 0xd0, 0x7d, 0x74, 0xe1, 0x6f,
-	0x34, 0x58, 0xd8, 0xa4, 0x7b, 0xee, 0xe0, 0x64, 0x86, 0x57, 0xbf, 0x0c, 0x79, 0x65, 0xbe, 0x72,
-	0xfe, 0x78, 0x55, 0x6b, 0x33, 0xaa, 0x5a, 0xff, 0xab, 0x06, 0xf9, 0xb2, 0xd7, 0xef, 0xbb, 0xec,
-	0x84, 0xd6, 0xd5, 0x71, 0x3f, 0x53, 0xb3, 0xfc, 0x44, 0x50, 0x08, 0xdd, 0x94, 0x01, 0xd2, 0xff,
-	0xa6, 0xc1, 0x22, 0xf1, 0x7a, 0xbd, 0x5d, 0xa7, 0x73, 0xf0, 0x60, 0xfb, 0x8e, 0x01, 0x4d, 0x1c,
-	0x55, 0xde, 0xff, 0x4b, 0x83, 0x42, 0xd3, 0xa7, 0xfc, 0xc3, 0xe5, 0x81, 0x76, 0x9e, 0x7f, 0xef,
-	0x74, 0x99, 0x5a, 0xeb, 0xb3, 0x44, 0x3c, 0xeb, 0x4b, 0xb0, 0x18, 0xf9, 0xae, 0xe2, 0xf1, 0x27,
-	0x0d, 0x56, 0x64, 0x81, 0x28, 0x4a, 0xf7, 0x84, 0x86, 0x25, 0xf4, 0x37, 0x15, 0xf3, 0xb7, 0x04,
-	0x8f, 0x1c, 0xf5, 0x4d, 0xb9, 0xfd, 0x56, 0x02, 0x4e, 0x87, 0xb5, 0x71, 0xc2, 0x1d, 0xff, 0x2f,
-	0xea, 0x61, 0x15, 0x4a, 0xc7, 0x83, 0xa0, 0x22, 0xf4, 0x4e, 0x02, 0x4a, 0x65, 0x9f, 0x3a, 0x8c,
-	0xc6, 0xf6, 0x0c, 0x0f, 0x4e, 0x6d, 0xe0, 0x17, 0x60, 0x61, 0xe8, 0xf8, 0xcc, 0xed, 0xb8, 0x43,
-	0x87, 0x7f, 0x95, 0xa5, 0xc5, 0x96, 0xe4, 0x88, 0x80, 0x29, 0x16, 0xfd, 0x0c, 0x3c, 0x3a, 0x23,
-	0x22, 0x2a, 0x5e, 0xff, 0xd6, 0x00, 0xb7, 0x98, 0xe3, 0xb3, 0x87, 0x60, 0x55, 0x99, 0x59, 0x4c,
-	0x2b, 0x50, 0x9c, 0xf2, 0x3f, 0x1e, 0x17, 0xca, 0x1e, 0x8a, 0x15, 0xe7, 0x03, 0xe3, 0x12, 0xf7,
-	0x5f, 0xc5, 0xe5, 0x2f, 0x1a, 0xac, 0x96, 0x3d, 0x79, 0xbc, 0xf5, 0x40, 0x76, 0x98, 0xfe, 0x38,
-	0x9c, 0x99, 0xe9, 0xa0, 0x0a, 0xc0, 0x9f, 0x35, 0x78, 0x84, 0x50, 0xa7, 0xfb, 0x60, 0x3a, 0x7f,
-	0x1d, 0x4e, 0x1f, 0x73, 0x4e, 0xed, 0x50, 0x2f, 0x43, 0xa6, 0x4f, 0x99, 0xd3, 0x75, 0x98, 0xa3,
-	0x5c, 0x5a, 0x0d, 0xe5, 0x4e, 0xb8, 0x6b, 0x8a, 0x83, 0x44, 0xbc, 0xfa, 0xfb, 0x09, 0x28, 0x8a,
-	0xbd, 0xee, 0xc7, 0x1f, 0x44, 0xb3, 0x3f, 0x88, 0xde, 0xd1, 0x60, 0x79, 0x3a, 0x40, 0xd1, 0x37,
-	0xc1, 0xff, 0xfa, 0x5c, 0x61, 0xc6, 0x40, 0x48, 0xce, 0xda, 0x82, 0xfe, 0x3e, 0x01, 0xa5, 0xb8,
-	0x49, 0x1f, 0x9f, 0x41, 0x4c, 0x9f, 0x41, 0x7c, 0xe4, 0x43, 0xa7, 0xf7, 0x34, 0x78, 0x74, 0x46,
-	0x40, 0x3f, 0x5a, 0xa2, 0x63, 0x27, 0x11, 0x89, 0xbb, 0x9e, 0x44, 0xdc, 0x6b, 0xaa, 0xff, 0xa8,
-	0xc1, 0x72, 0x8d, 0x06, 0x81, 0xb3, 0x47, 0xe5, 0x67, 0xf9, 0xc9, 0x9d, 0x66, 0xe2, 0x8c, 0x37,
-	0x35, 0xb9, 0x28, 0xd1, 0xcb, 0xb0, 0x72, 0xc4, 0xb5, 0xfb, 0x38, 0x6a, 0xf8, 0xa7, 0x06, 0x4b,
-	0x4a, 0x8a, 0x71, 0x62, 0x37, 0x02, 0x33, 0xa2, 0x83, 0x9f, 0x80, 0xa4, 0xdb, 0x0d, 0x77, 0x90,
-	0xd3, 0x97, 0x8c, 0x9c, 0xa0, 0x5f, 0x01, 0x1c, 0xf7, 0xfb, 0x3e, 0x42, 0xf7, 0x87, 0x24, 0x2c,
-	0xb5, 0x86, 0x3d, 0x97, 0x29, 0xe2, 0x83, 0x3d, 0xf8, 0xff, 0x0f, 0x16, 0x02, 0xee, 0xac, 0x2d,
-	0x2f, 0xbf, 0x44, 0x60, 0xb3, 0x24, 0x27, 0x70, 0x65, 0x81, 0xc2, 0x4f, 0x42, 0x2e, 0x64, 0x19,
-	0x0d, 0x98, 0x3a, 0xb8, 0x04, 0xc5, 0x31, 0x1a, 0x30, 0x7c, 0x09, 0x4e, 0x0f, 0x46, 0x7d, 0x5b,
-	0xdc, 0x0a, 0x0d, 0xa9, 0x6f, 0x0b, 0xc9, 0x36, 0xdf, 0xce, 0x8b, 0xab, 0xb3, 0x24, 0x29, 0x0e,
-	0x46, 0x7d, 0xe2, 0xdd, 0x0e, 0x9a, 0xd4, 0x17, 0xca, 0x9b, 0x8e, 0xcf, 0xf0, 0x15, 0xc8, 0x3a,
-	0xbd, 0x3d, 0xcf, 0x77, 0xd9, 0x7e, 0x5f, 0xdc, 0xa3, 0x15, 0x36, 0xf4, 0xf0, 0xa6, 0xe4, 0x68,
-	0xf8, 0xd7, 0x8d, 0x90, 0x93, 0x4c, 0x5e, 0xd2, 0x9f, 0x87, 0x6c, 0x84, 0xc7, 0x08, 0x16, 0xcc,
-	0xeb, 0x6d, 0xa3, 0x6a, 0xb7, 0x9a, 0xd5, 0x8a, 0xd5, 0x92, 0xb7, 0x95, 0xdb, 0xed, 0x6a, 0xd5,
-	0x6e, 0x95, 0x8d, 0x3a, 0xd2, 0x74, 0x02, 0x20, 0x44, 0x0a, 0xe1, 0x93, 0x00, 0x69, 0x77, 0x09,
-	0xd0, 0x19, 0xc8, 0xfa, 0xde, 0x6d, 0xe5, 0x7b, 0x42, 0xb8, 0x93, 0xf1, 0xbd, 0xdb, 0xc2, 0x73,
-	0xdd, 0x00, 0x1c, 0xb7, 0x55, 0x55, 0x5b, 0x6c, 0x78, 0x6b, 0x53, 0xc3, 0x7b, 0xa2, 0x3f, 0x1a,
-	0xde, 0x72, 0x2b, 0xcf, 0xfb, 0xfc, 0x15, 0xea, 0xf4, 0x58, 0xb8, 0x5e, 0xe9, 0xbf, 0x4a, 0x40,
-	0x9e, 0x70, 0x8c, 0xdb, 0xa7, 0x2d, 0xe6, 0xb0, 0x80, 0x67, 0x6a, 0x5f, 0xb0, 0xd8, 0x93, 0xb1,
-	0x9b, 0x25, 0x39, 0x89, 0x93, 0x67, 0xfa, 0x1b, 0xb0, 0x12, 0xd0, 0x8e, 0x37, 0xe8, 0x06, 0xf6,
-	0x2e, 0xdd, 0x77, 0x07, 0x5d, 0xbb, 0xef, 0x04, 0x4c, 0x5d, 0xfc, 0xe5, 0x49, 0x51, 0x11, 0x37,
-	0x05, 0xad, 0x26, 0x48, 0xf8, 0x02, 0x2c, 0xef, 0xba, 0x83, 0x9e, 0xb7, 0x67, 0x0f, 0x7b, 0xce,
-	0x98, 0xfa, 0x81, 0x72, 0x95, 0x97, 0x57, 0x9a, 0x60, 0x49, 0x6b, 0x4a, 0x92, 0x4c, 0xf7, 0xeb,
-	0x70, 0x7e, 0xa6, 0x16, 0xfb, 0xa6, 0xdb, 0x63, 0xd4, 0xa7, 0x5d, 0xdb, 0xa7, 0xc3, 0x9e, 0xdb,
-	0x71, 0xc4, 0x52, 0x24, 0xf7, 0xee, 0xcf, 0xcc, 0x50, 0xbd, 0xad, 0xd8, 0xc9, 0x84, 0x9b, 0x47,
-	0xbb, 0x33, 0x1c, 0xd9, 0x23, 0xde, 0xc0, 0x62, 0x15, 0xd3, 0x48, 0xa6, 0x33, 0x1c, 0xb5, 0x39,
-	0x8c, 0x11, 0x24, 0x6f, 0x0d, 0xe5, 0xe2, 0xa5, 0x11, 0xfe, 0xa8, 0xff, 0x5d, 0x0b, 0x0f, 0xae,
-	0xc3, 0xe8, 0x45, 0x8b, 0x53, 0xd8, 0x26, 0xda, 0x87, 0xb5, 0x49, 0x09, 0xe6, 0x03, 0xea, 0x1f,
-	0xba, 0x83, 0xbd, 0xf0, 0x6e, 0x54, 0x81, 0xb8, 0x05, 0xcf, 0xa8, 0x7f, 0x86, 0xd0, 0x37, 0x19,
-	0xf5, 0x07, 0x4e, 0xaf, 0x37, 0xb6, 0xe5, 0x77, 0xfb, 0x80, 0xd1, 0xae, 0x3d, 0xf9, 0x0f, 0x87,
-	0x5c, 0xa0, 0x9e, 0x92, 0xdc, 0x66, 0xc4, 0x4c, 0x22, 0x5e, 0x2b, 0xfa, 0x77, 0xc7, 0xcb, 0x50,
-	0xf0, 0x55, 0x4e, 0xed, 0x80, 0x27, 0x55, 0xb5, 0xe7, 0x72, 0x74, 0xc1, 0x19, 0x4b, 0x38, 0xc9,
-	0xfb, 0x71, 0x90, 0x7f, 0xdc, 0x15, 0xdb, 0xc3, 0xae, 0xc3, 0x4e, 0xf6, 0x92, 0x17, 0xff, 0x2f,
-	0x4b, 0x6a, 0xfa, 0xbf, 0x2c, 0xd3, 0xff, 0x8d, 0x49, 0x1f, 0xf9, 0x6f, 0x8c, 0x7e, 0x05, 0x96,
-	0xa7, 0xfd, 0x57, 0xb9, 0x3e, 0x07, 0x69, 0x71, 0x1b, 0x7b, 0x64, 0xb6, 0xc7, 0xae, 0x5b, 0x89,
-	0x64, 0xd0, 0x7f, 0xad, 0x41, 0x71, 0xc6, 0xbe, 0x3f, 0xfa, 0xa8, 0xd0, 0x62, 0x67, 0x16, 0x9f,
-	0x80, 0xb4, 0xb8, 0x17, 0x56, 0x7f, 0x58, 0x38, 0x7d, 0xfc, 0xb3, 0x41, 0xdc, 0xe1, 0x12, 0xc9,
-	0xc5, 0xbb, 0x53, 0xa4, 0xb5, 0x23, 0x0e, 0x2d, 0xc2, 0x6d, 0x4b, 0x8e, 0xe3, 0xe4, 0x39, 0xc6,
-	0xf1, 0x53, 0x90, 0xd4, 0x5d, 0x4f, 0x41, 0xce, 0x7f, 0x3f, 0x09, 0xd9, 0xda, 0xb8, 0x75, 0xab,
-	0xb7, 0xdd, 0x73, 0xf6, 0xc4, 0x25, 0x6b, 0xad, 0x69, 0xed, 0xa0, 0x53, 0x78, 0x09, 0xf2, 0xf5,
-	0x86, 0x65, 0xd7, 0xf9, 0x7c, 0xdb, 0xae, 0x1a, 0x57, 0x91, 0xc6, 0x07, 0x60, 0x93, 0x54, 0xec,
-	0x6b, 0xe6, 0x8e, 0xc4, 0x24, 0x70, 0x11, 0x16, 0xdb, 0xf5, 0xca, 0xf5, 0xb6, 0x39, 0x41, 0xa6,
-	0xf0, 0x0a, 0x2c, 0xd5, 0xda, 0x55, 0xab, 0xd2, 0xac, 0xc6, 0xd0, 0x19, 0x3e, 0x2c, 0x37, 0xab,
-	0x8d, 0x4d, 0x09, 0x22, 0x2e, 0xbf, 0x5d, 0x6f, 0x55, 0xae, 0xd6, 0xcd, 0x2d, 0x89, 0x5a, 0xe3,
-	0xa8, 0xd7, 0x4d, 0xd2, 0xd8, 0xae, 0x84, 0x2a, 0xaf, 0x60, 0x04, 0xb9, 0xcd, 0x4a, 0xdd, 0x20,
-	0x4a, 0xca, 0x1d, 0x0d, 0x17, 0x20, 0x6b, 0xd6, 0xdb, 0x35, 0x05, 0x27, 0x70, 0x09, 0x8a, 0x46,
-	0xdb, 0x6a, 0xd8, 0x95, 0x7a, 0x99, 0x98, 0x35, 0xb3, 0x6e, 0x29, 0x4a, 0x0a, 0x17, 0xa1, 0x60,
-	0x55, 0x6a, 0x66, 0xcb, 0x32, 0x6a, 0x4d, 0x85, 0xe4, 0x56, 0x64, 0x5a, 0x66, 0xc8, 0x83, 0xf0,
-	0x2a, 0xac, 0xd4, 0x1b, 0xf6, 0x96, 0xb9, 0x6d, 0xb4, 0xab, 0x96, 0x7d, 0xc3, 0xa8, 0xb6, 0x4d,
-	0x45, 0x5b, 0xc3, 0xa7, 0x01, 0x37, 0xea, 0x76, 0xbb, 0xb9, 0x65, 0x58, 0xa6, 0x5d, 0x6f, 0xbc,
-	0xa6, 0x08, 0x57, 0x70, 0x01, 0x32, 0x13, 0x0b, 0xee, 0xf0, 0x28, 0xe4, 0x9b, 0x06, 0xb1, 0x26,
-	0xce, 0xde, 0xb9, 0xc3, 0x83, 0x05, 0x57, 0x49, 0xa3, 0xdd, 0x9c, 0xb0, 0x2d, 0x41, 0x4e, 0x05,
-	0x4b, 0xa1, 0x52, 0x1c, 0xb5, 0x59, 0xa9, 0x97, 0x23, 0xfb, 0xee, 0x64, 0x56, 0x13, 0x48, 0x3b,
-	0x7f, 0x00, 0x29, 0x91, 0x8e, 0x0c, 0xa4, 0xea, 0x8d, 0xba, 0x89, 0x4e, 0xe1, 0x45, 0x80, 0x4a,
-	0xab, 0x52, 0xb7, 0xcc, 0xab, 0xc4, 0xa8, 0x72, 0xb7, 0x05, 0x22, 0x0c, 0x20, 0xf7, 0x76, 0x01,
-	0xe6, 0x2b, 0xad, 0xed, 0x6a, 0xc3, 0xb0, 0x94, 0x9b, 0x95, 0xd6, 0xf5, 0x76, 0xc3, 0xe2, 0x44,
-	0x84, 0x73, 0x30, 0x57, 0x69, 0x59, 0xe6, 0x17, 0x2c, 0xee, 0x97, 0xa0, 0xc9, 0xa8, 0xa2, 0x3b,
-	0x57, 0xce, 0xbf, 0x9d, 0x84, 0x94, 0x35, 0x1e, 0x52, 0x9e, 0x20, 0x91, 0x6d, 0x6b, 0xa7, 0xc9,
-	0x55, 0x66, 0x21, 0x55, 0xa9, 0x5b, 0x2f, 0xa2, 0x2f, 0x26, 0x30, 0x40, 0xba, 0x2d, 0x9e, 0xbf,
-	0x34, 0xc7, 0x9f, 0x2b, 0x75, 0xeb, 0x85, 0xcb, 0xe8, 0xad, 0x04, 0x17, 0xdb, 0x96, 0xc0, 0x97,
-	0x43, 0xc2, 0xc6, 0x25, 0xf4, 0x95, 0x88, 0xb0, 0x71, 0x09, 0x7d, 0x35, 0x24, 0x5c, 0xdc, 0x40,
-	0x5f, 0x8b, 0x08, 0x17, 0x37, 0xd0, 0xd7, 0x43, 0xc2, 0xe5, 0x4b, 0xe8, 0xed, 0x88, 0x70, 0xf9,
-	0x12, 0xfa, 0xc6, 0x1c, 0xf7, 0x45, 0x78, 0x72, 0x71, 0x03, 0x7d, 0x33, 0x13, 0x41, 0x97, 0x2f,
-	0xa1, 0x6f, 0x65, 0x78, 0xfe, 0xa3, 0xac, 0xa2, 0x6f, 0x23, 0x6e, 0x26, 0x4f, 0x10, 0xfa, 0x8e,
-	0x78, 0xe4, 0x24, 0xf4, 0x5d, 0xc4, 0x7d, 0xe4, 0x58, 0x01, 0xbe, 0x23, 0x28, 0x3b, 0xa6, 0x41,
-	0xd0, 0xf7, 0xe6, 0x70, 0x0e, 0xe6, 0xb7, 0xcc, 0x72, 0xa5, 0x66, 0x54, 0x11, 0x16, 0x6f, 0xf0,
-	0xa8, 0xfc, 0xe0, 0x02, 0x7f, 0xe4, 0xe5, 0x89, 0x7e, 0xd8, 0xe4, 0x0a, 0x6f, 0x18, 0xa4, 0xfc,
-	0x8a, 0x41, 0xd0, 0xbb, 0x17, 0xb8, 0xc2, 0x1b, 0x06, 0x51, 0xf1, 0xfa, 0x51, 0x93, 0x33, 0x0a,
-	0xd2, 0x7b, 0x17, 0xb8, 0xd1, 0x0a, 0xff, 0xe3, 0x26, 0xce, 0x40, 0x72, 0xb3, 0x62, 0xa1, 0x9f,
-	0x08, 0x6d, 0xbc, 0x44, 0xd1, 0x4f, 0x11, 0x47, 0xb6, 0x4c, 0x0b, 0xfd, 0x8c, 0x23, 0xd3, 0x56,
-	0xbb, 0x59, 0x35, 0xd1, 0x63, 0xdc, 0xb8, 0xab, 0x66, 0xa3, 0x66, 0x5a, 0x64, 0x07, 0xfd, 0x5c,
-	0xb0, 0xbf, 0xda, 0x6a, 0xd4, 0xd1, 0xfb, 0xe8, 0xfc, 0x36, 0xa0, 0xa3, 0xed, 0xcf, 0x0d, 0x6e,
-	0xd7, 0xaf, 0xd5, 0x1b, 0xaf, 0xd5, 0xd1, 0x29, 0x0e, 0x34, 0x89, 0xd9, 0x34, 0x88, 0x89, 0x34,
-	0x0c, 0x30, 0x57, 0x6e, 0xd4, 0x6a, 0x15, 0x0b, 0x25, 0xf0, 0x02, 0x64, 0x48, 0xa3, 0x5a, 0xdd,
-	0x34, 0xca, 0xd7, 0x50, 0x72, 0x73, 0x15, 0x4a, 0x1d, 0xaf, 0xbf, 0x3e, 0xf6, 0x46, 0x6c, 0xb4,
-	0x4b, 0xd7, 0x0f, 0x5d, 0x46, 0x83, 0x40, 0xfe, 0x9b, 0x71, 0x77, 0x4e, 0xfc, 0x5c, 0xfc, 0x4f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x96, 0x99, 0x99, 0x5c, 0x07, 0x29, 0x00, 0x00,
+	// 3017 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x5a, 0xcb, 0x73, 0x1b, 0xc7,
+	0xd1, 0xd7, 0xe2, 0x41, 0x02, 0x0d, 0x02, 0x5c, 0x0e, 0x48, 0x0b, 0xa6, 0xfc, 0x90, 0xd7, 0x96,
+	0x2d, 0xd3, 0xfe, 0xf8, 0xc9, 0x94, 0x3e, 0xd9, 0x65, 0x7f, 0x49, 0xb4, 0x04, 0x97, 0xf2, 0x5a,
+	0xc0, 0x02, 0x1a, 0x2c, 0xe4, 0xc8, 0x95, 0xaa, 0xad, 0x25, 0x30, 0x22, 0xb7, 0x08, 0x60, 0xa1,
+	0xdd, 0x81, 0x28, 0xdc, 0x94, 0x38, 0x2f, 0xe7, 0xe9, 0x3c, 0x9d, 0x47, 0xc5, 0x49, 0x25, 0xf7,
+	0xfc, 0x0d, 0x29, 0xff, 0x01, 0xb9, 0x27, 0x39, 0xe4, 0x90, 0x4a, 0xf9, 0x96, 0xca, 0x29, 0x87,
+	0x1c, 0x52, 0xa9, 0x79, 0xec, 0x62, 0x41, 0xc2, 0x92, 0xac, 0xe4, 0x42, 0xd9, 0x27, 0xec, 0x74,
+	0x37, 0xa6, 0xbb, 0x7f, 0xdd, 0xd3, 0x3d, 0x3b, 0xb3, 0x50, 0xb8, 0x39, 0x22, 0xc1, 0x78, 0x7d,
+	0x18, 0xf8, 0xd4, 0x47, 0x59, 0x3e, 0x58, 0x2d, 0x51, 0x7f, 0xe8, 0x77, 0x5d, 0xea, 0x0a, 0xf2,
+	0x6a, 0xe1, 0x16, 0x0d, 0x86, 0x1d, 0x31, 0xd0, 0x6e, 0xc2, 0x9c, 0xed, 0x06, 0xbb, 0x84, 0xa2,
+	0x55, 0xc8, 0xed, 0x93, 0x71, 0x38, 0x74, 0x3b, 0xa4, 0xa2, 0x9c, 0x56, 0xce, 0xe6, 0x71, 0x3c,
+	0x46, 0xcb, 0x90, 0x0d, 0xf7, 0xdc, 0xa0, 0x5b, 0x49, 0x71, 0x86, 0x18, 0xa0, 0xff, 0x83, 0x02,
+	0x75, 0x77, 0x7a, 0x84, 0x3a, 0x74, 0x3c, 0x24, 0x95, 0xf4, 0x69, 0xe5, 0x6c, 0x69, 0x63, 0x79,
+	0x3d, 0x56, 0x67, 0x73, 0xa6, 0x3d, 0x1e, 0x12, 0x0c, 0x34, 0x7e, 0xd6, 0x5e, 0x84, 0xd2, 0x35,
+	0xfb, 0xb2, 0x4b, 0x49, 0xd5, 0xed, 0xf5, 0x48, 0x60, 0x6e, 0x31, 0xd5, 0xa3, 0x90, 0x04, 0x03,
+	0xb7, 0x1f, 0xab, 0x8e, 0xc6, 0xda, 0x17, 0x00, 0x8c, 0x5b, 0x64, 0x40, 0x6d, 0x7f, 0x9f, 0x0c,
+	0xd0, 0x63, 0x90, 0xa7, 0x5e, 0x9f, 0x84, 0xd4, 0xed, 0x0f, 0xb9, 0x68, 0x1a, 0x4f, 0x08, 0x1f,
+	0x61, 0xe6, 0x2a, 0xe4, 0x86, 0x7e, 0xe8, 0x51, 0xcf, 0x1f, 0x70, 0x1b, 0xf3, 0x38, 0x1e, 0x6b,
+	0x9f, 0x85, 0xec, 0x35, 0xb7, 0x37, 0x22, 0xe8, 0x49, 0xc8, 0x70, 0x27, 0x14, 0xee, 0x44, 0x61,
+	0x5d, 0xe0, 0xc8, 0x6d, 0xe7, 0x0c, 0x36, 0xf7, 0x2d, 0x26, 0xc9, 0xe7, 0x5e, 0xc0, 0x62, 0xa0,
+	0xed, 0xc3, 0xc2, 0xa6, 0x37, 0xe8, 0x5e, 0x73, 0x03, 0x8f, 0x39, 0xf8, 0x80, 0xd3, 0xa0, 0x67,
+	0x60, 0x8e, 0x3f, 0x84, 0x95, 0xf4, 0xe9, 0xf4, 0xd9, 0xc2, 0xc6, 0x82, 0xfc, 0x23, 0xb7, 0x0d,
+	0x4b, 0x9e, 0xf6, 0x81, 0x02, 0xb0, 0xe9, 0x8f, 0x06, 0xdd, 0xab, 0x8c, 0x89, 0x54, 0x48, 0x87,
+	0x37, 0x7b, 0x12, 0x30, 0xf6, 0x88, 0xae, 0x40, 0x69, 0xc7, 0x1b, 0x74, 0x9d, 0x5b, 0xd2, 0x9c,
+	0xb0, 0x92, 0xe2, 0xd3, 0x3d, 0x23, 0xa7, 0x9b, 0xfc, 0x79, 0x3d, 0x69, 0x75, 0x68, 0x0c, 0x68,
+	0x30, 0xc6, 0xc5, 0x9d, 0x24, 0x6d, 0xb5, 0x0d, 0xe8, 0xa8, 0x10, 0x53, 0xba, 0x4f, 0xc6, 0x91,
+	0xd2, 0x7d, 0x32, 0x46, 0xcf, 0x27, 0x3d, 0x2a, 0x6c, 0x94, 0x23, 0x5d, 0x89, 0xff, 0x4a, 0x37,
+	0x5f, 0x4d, 0xbd, 0xa2, 0x68, 0x1f, 0x66, 0xa0, 0x64, 0xdc, 0x26, 0x9d, 0x11, 0x25, 0x8d, 0x21,
+	0x8b, 0x41, 0x88, 0xd6, 0xa1, 0xec, 0x0d, 0x3a, 0xbd, 0x51, 0x97, 0x38, 0x84, 0x85, 0xda, 0xa1,
+	0x2c, 0xd6, 0x7c, 0xbe, 0x1c, 0x5e, 0x92, 0xac, 0x44, 0x12, 0xe8, 0x50, 0xee, 0xf8, 0xfd, 0xa1,
+	0x1b, 0x4c, 0xcb, 0xa7, 0xb9, 0xfe, 0x25, 0xa9, 0x7f, 0x22, 0x8f, 0x97, 0xa4, 0x74, 0x62, 0x8a,
+	0x3a, 0x2c, 0xca, 0x79, 0xbb, 0xce, 0x0d, 0x8f, 0xf4, 0xba, 0x61, 0x25, 0xc3, 0x43, 0x16, 0x41,
+	0x35, 0x6d, 0xe2, 0xba, 0x29, 0x85, 0xb7, 0xb9, 0x2c, 0x2e, 0x79, 0x53, 0x63, 0xf4, 0x3c, 0xa8,
+	0x91, 0x07, 0x71, 0xaa, 0x65, 0xb9, 0xf9, 0x91, 0x9a, 0xa6, 0x24, 0xa3, 0x35, 0x58, 0x3a, 0x70,
+	0x3d, 0xea, 0xdc, 0xf0, 0x83, 0x89, 0xec, 0x1c, 0x87, 0x73, 0x91, 0x31, 0xb6, 0xfd, 0x20, 0x96,
+	0x75, 0x60, 0x85, 0x06, 0xee, 0x20, 0x74, 0x3b, 0x6c, 0xe8, 0x78, 0xa1, 0xdf, 0x73, 0xb9, 0xfc,
+	0x3c, 0xb7, 0x75, 0x6d, 0xb6, 0xad, 0xf6, 0xe4, 0x2f, 0x66, 0xf4, 0x0f, 0xbc, 0x4c, 0x67, 0x50,
+	0xb5, 0xd7, 0xa0, 0x34, 0xed, 0x19, 0x5a, 0x82, 0xa2, 0x7d, 0xbd, 0x69, 0x38, 0xba, 0xb5, 0xe5,
+	0x58, 0x7a, 0xdd, 0x50, 0x4f, 0xa0, 0x22, 0xe4, 0x39, 0xa9, 0x61, 0xd5, 0xae, 0xab, 0x0a, 0x9a,
+	0x87, 0xb4, 0x5e, 0xab, 0xa9, 0x29, 0xed, 0x3d, 0x05, 0x96, 0x67, 0xe9, 0x42, 0x05, 0x98, 0xdf,
+	0x32, 0xb6, 0xf5, 0x76, 0xcd, 0x56, 0x4f, 0xa0, 0x32, 0x2c, 0x62, 0xa3, 0x69, 0xe8, 0xb6, 0xbe,
+	0x59, 0x33, 0x1c, 0x6c, 0xe8, 0x5b, 0xaa, 0x82, 0x10, 0x94, 0xd8, 0x93, 0x53, 0x6d, 0xd4, 0xeb,
+	0xa6, 0x6d, 0x1b, 0x5b, 0x6a, 0x0a, 0x2d, 0x83, 0xca, 0x69, 0x6d, 0x6b, 0x42, 0x4d, 0x23, 0x15,
+	0x16, 0x5a, 0x06, 0x36, 0xf5, 0x9a, 0xf9, 0x16, 0x9b, 0x40, 0xcd, 0xa0, 0xa7, 0xe0, 0xf1, 0x6a,
+	0xc3, 0x6a, 0x99, 0x2d, 0xdb, 0xb0, 0x6c, 0xa7, 0x65, 0xe9, 0xcd, 0xd6, 0xeb, 0x0d, 0x9b, 0xcf,
+	0x2c, 0x4c, 0xcc, 0xbe, 0x91, 0xc9, 0x29, 0xcc, 0xbe, 0x14, 0x64, 0xb9, 0x57, 0x08, 0x41, 0x26,
+	0x51, 0x5b, 0xf8, 0x73, 0xbc, 0x52, 0x53, 0x77, 0x59, 0xa9, 0xbc, 0x68, 0xc9, 0x9a, 0x21, 0x06,
+	0xe8, 0x14, 0xe4, 0xfd, 0x60, 0xd7, 0x11, 0x9c, 0x8c, 0xa8, 0x26, 0x7e, 0xb0, 0xcb, 0x4b, 0x1d,
+	0xab, 0x34, 0xac, 0xf0, 0xed, 0xb8, 0x21, 0xe1, 0xe1, 0xcf, 0xe3, 0x78, 0x8c, 0x1e, 0x05, 0x26,
+	0xe7, 0x70, 0x3b, 0x44, 0xb8, 0xe7, 0xfd, 0x60, 0xd7, 0x62, 0xa6, 0x3c, 0x0d, 0xc5, 0x8e, 0xdf,
+	0x1b, 0xf5, 0x07, 0x4e, 0x8f, 0x0c, 0x76, 0xe9, 0x1e, 0x0f, 0x6f, 0x11, 0x2f, 0x08, 0x62, 0x8d,
+	0xd3, 0x50, 0x05, 0xe6, 0x3b, 0x7b, 0x6e, 0x10, 0x12, 0x5a, 0xc9, 0x71, 0x76, 0x34, 0xe4, 0x5a,
+	0x49, 0xc7, 0xeb, 0xbb, 0xbd, 0xb0, 0x92, 0xe7, 0xac, 0x78, 0xcc, 0x9c, 0xb8, 0xd1, 0x73, 0x77,
+	0xc3, 0x0a, 0x70, 0x86, 0x18, 0x68, 0x2f, 0x43, 0x1a, 0xfb, 0x07, 0x6c, 0x4a, 0xa1, 0x30, 0xac,
+	0x28, 0xa7, 0xd3, 0x67, 0x11, 0x8e, 0x86, 0xe8, 0x91, 0xb8, 0x1e, 0x89, 0x32, 0x15, 0x55, 0xa0,
+	0xdb, 0xb0, 0x80, 0x49, 0x38, 0xea, 0x51, 0xe3, 0x36, 0x0d, 0xdc, 0x10, 0x6d, 0x40, 0x21, 0xb9,
+	0x02, 0x95, 0x8f, 0x5a, 0x81, 0x40, 0x26, 0x4b, 0xaf, 0x02, 0xf3, 0x37, 0x02, 0x12, 0xee, 0x91,
+	0x40, 0xae, 0xf0, 0x68, 0x78, 0xd7, 0x42, 0xfd, 0x81, 0x02, 0x05, 0x5e, 0xb9, 0x84, 0x7e, 0x56,
+	0x31, 0xe5, 0xba, 0x55, 0xa6, 0x2a, 0x26, 0x0f, 0x38, 0x96, 0x3c, 0x86, 0x6c, 0xe0, 0x1f, 0x84,
+	0x8e, 0x7b, 0xe3, 0x06, 0xe9, 0x50, 0x22, 0x1a, 0x43, 0x06, 0x2f, 0x30, 0xa2, 0x2e, 0x69, 0x2c,
+	0xa4, 0xde, 0x20, 0x24, 0x01, 0x75, 0xbc, 0x2e, 0xd7, 0x9b, 0xc1, 0x39, 0x41, 0x30, 0xbb, 0xe8,
+	0x09, 0xc8, 0x30, 0xe1, 0x4a, 0x86, 0x6b, 0x01, 0xa9, 0x05, 0xfb, 0x07, 0x98, 0xd3, 0xd1, 0x0b,
+	0x30, 0x47, 0x38, 0x16, 0x3c, 0xe0, 0x93, 0xf2, 0x97, 0x84, 0x09, 0x4b, 0x11, 0xed, 0x57, 0x69,
+	0x28, 0xb4, 0x68, 0x40, 0xdc, 0x3e, 0xc7, 0x06, 0xfd, 0x3f, 0x40, 0x48, 0x5d, 0x4a, 0xfa, 0x64,
+	0x40, 0x23, 0x47, 0x1e, 0x93, 0x13, 0x24, 0xe4, 0xd6, 0x5b, 0x91, 0x10, 0x4e, 0xc8, 0x1f, 0x06,
+	0x3f, 0x75, 0x1f, 0xe0, 0xaf, 0xbe, 0x9f, 0x82, 0x7c, 0x3c, 0x1b, 0xd2, 0x21, 0xd7, 0x71, 0x29,
+	0xd9, 0xf5, 0x83, 0xb1, 0xec, 0x58, 0x67, 0xee, 0xa6, 0x7d, 0xbd, 0x2a, 0x85, 0x71, 0xfc, 0x37,
+	0xf4, 0x38, 0x88, 0xd6, 0x2e, 0x12, 0x5b, 0xf4, 0xdd, 0x3c, 0xa7, 0xf0, 0xd4, 0x7e, 0x15, 0xd0,
+	0x30, 0xf0, 0xfa, 0x6e, 0x30, 0x76, 0xf6, 0xc9, 0x38, 0x2a, 0xb5, 0xe9, 0x19, 0x21, 0x53, 0xa5,
+	0xdc, 0x15, 0x32, 0x96, 0xa5, 0xe8, 0x95, 0xe9, 0xff, 0xca, 0x84, 0x3c, 0x1a, 0x88, 0xc4, 0x3f,
+	0x79, 0xbf, 0x0c, 0xa3, 0xce, 0x98, 0xe5, 0xb9, 0xcb, 0x1e, 0xb5, 0xe7, 0x20, 0x17, 0x19, 0x8f,
+	0xf2, 0x90, 0x35, 0x82, 0xc0, 0x0f, 0xd4, 0x13, 0xac, 0x96, 0x6d, 0xd5, 0x6b, 0xa2, 0xa8, 0x6d,
+	0x6d, 0xb1, 0xa2, 0xf6, 0xbb, 0x54, 0xdc, 0x9e, 0x30, 0xb9, 0x39, 0x22, 0x21, 0x45, 0x9f, 0x83,
+	0x32, 0xe1, 0xb9, 0xe2, 0xdd, 0x22, 0x4e, 0x87, 0xef, 0x59, 0x58, 0xa6, 0x88, 0x64, 0x5f, 0x5c,
+	0x17, 0xbb, 0xa9, 0x68, 0x2f, 0x83, 0x97, 0x62, 0x59, 0x49, 0xea, 0x22, 0x03, 0xca, 0x5e, 0xbf,
+	0x4f, 0xba, 0x9e, 0x4b, 0x93, 0x13, 0x88, 0x80, 0xad, 0x44, 0xad, 0x7e, 0x6a, 0x4b, 0x84, 0x97,
+	0xe2, 0x7f, 0xc4, 0xd3, 0x9c, 0x81, 0x39, 0xca, 0xb7, 0x6a, 0xb2, 0xd3, 0x15, 0xa3, 0x9a, 0xc5,
+	0x89, 0x58, 0x32, 0xd1, 0x73, 0x20, 0xf6, 0x7d, 0xbc, 0x3a, 0x4d, 0x12, 0x62, 0xd2, 0xfb, 0xb1,
+	0xe0, 0xa3, 0x33, 0x50, 0x9a, 0xea, 0x2e, 0x5d, 0x0e, 0x58, 0x1a, 0x17, 0x93, 0xad, 0xa2, 0x8b,
+	0xfe, 0x17, 0xe6, 0x7d, 0xd1, 0x59, 0x78, 0xdd, 0x9a, 0x58, 0x3c, 0xdd, 0x76, 0x70, 0x24, 0xa5,
+	0x7d, 0x06, 0x16, 0x63, 0x04, 0xc3, 0xa1, 0x3f, 0x08, 0x09, 0x5a, 0x83, 0xb9, 0x80, 0x2f, 0x08,
+	0x89, 0x1a, 0x92, 0x53, 0x24, 0x56, 0x34, 0x96, 0x12, 0x5a, 0x17, 0x16, 0x05, 0xe5, 0x4d, 0x8f,
+	0xee, 0xf1, 0x40, 0xa1, 0x33, 0x90, 0x25, 0xec, 0xe1, 0x10, 0xe6, 0xb8, 0x59, 0xe5, 0x7c, 0x2c,
+	0xb8, 0x09, 0x2d, 0xa9, 0x7b, 0x6a, 0xf9, 0x7b, 0x0a, 0xca, 0xd2, 0xca, 0x4d, 0x97, 0x76, 0xf6,
+	0x8e, 0x69, 0xb0, 0x5f, 0x80, 0x79, 0x46, 0xf7, 0xe2, 0x85, 0x31, 0x23, 0xdc, 0x91, 0x04, 0x0b,
+	0xb8, 0x1b, 0x3a, 0x89, 0xe8, 0xca, 0x3d, 0x4a, 0xd1, 0x0d, 0x13, 0x7d, 0x7c, 0x46, 0x5e, 0xcc,
+	0xdd, 0x23, 0x2f, 0xe6, 0xef, 0x2b, 0x2f, 0xb6, 0x60, 0x79, 0x1a, 0x71, 0x99, 0x1c, 0x2f, 0xc2,
+	0xbc, 0x08, 0x4a, 0x54, 0x02, 0x67, 0xc5, 0x2d, 0x12, 0xd1, 0x7e, 0x99, 0x82, 0x65, 0x59, 0x9d,
+	0x3e, 0x19, 0xcb, 0x34, 0x81, 0x73, 0xf6, 0xbe, 0x70, 0xae, 0xc2, 0xca, 0x21, 0x80, 0x1e, 0x60,
+	0x15, 0xfe, 0x4d, 0x81, 0x85, 0x4d, 0xb2, 0xeb, 0x0d, 0x8e, 0x29, 0xbc, 0x09, 0xd4, 0x32, 0xf7,
+	0x85, 0xda, 0x45, 0x28, 0x4a, 0x7f, 0x25, 0x5a, 0x47, 0x97, 0x81, 0x32, 0x63, 0x19, 0x68, 0x7f,
+	0x55, 0xa0, 0x58, 0xf5, 0xfb, 0x7d, 0x8f, 0x1e, 0x53, 0xa4, 0x8e, 0xfa, 0x99, 0x99, 0xe5, 0xe7,
+	0x8b, 0x50, 0x8a, 0xdc, 0x94, 0x00, 0x25, 0xb7, 0x6b, 0xca, 0xa1, 0xed, 0xda, 0x87, 0x0a, 0x2c,
+	0x62, 0xbf, 0xd7, 0xdb, 0x71, 0x3b, 0xfb, 0x0f, 0x37, 0x2e, 0x08, 0xd4, 0x89, 0xa3, 0x02, 0x19,
+	0xed, 0x9f, 0x0a, 0x94, 0x9a, 0x01, 0x61, 0xef, 0x9c, 0x0f, 0xb5, 0xf3, 0xec, 0xc5, 0xaa, 0x4b,
+	0xe5, 0xc6, 0x21, 0x8f, 0xf9, 0xb3, 0xb6, 0x04, 0x8b, 0xb1, 0xef, 0x12, 0x8f, 0x3f, 0x2a, 0xb0,
+	0x22, 0x92, 0x47, 0x72, 0xba, 0xc7, 0x14, 0x96, 0xc8, 0xdf, 0x4c, 0xc2, 0xdf, 0x0a, 0x3c, 0x72,
+	0xd8, 0x37, 0xe9, 0xf6, 0xdb, 0x29, 0x38, 0x19, 0xe5, 0xc6, 0x31, 0x77, 0xfc, 0x3f, 0xc8, 0x87,
+	0x55, 0xa8, 0x1c, 0x05, 0x41, 0x22, 0xf4, 0x6e, 0x0a, 0x2a, 0xd5, 0x80, 0xb8, 0x94, 0x24, 0x36,
+	0x20, 0x0f, 0x4f, 0x6e, 0xa0, 0x97, 0x60, 0x61, 0xe8, 0x06, 0xd4, 0xeb, 0x78, 0x43, 0x97, 0xbd,
+	0xe2, 0x65, 0xf9, 0xfe, 0xe6, 0xd0, 0x04, 0x53, 0x22, 0xda, 0x29, 0x78, 0x74, 0x06, 0x22, 0x12,
+	0xaf, 0x7f, 0x29, 0x80, 0x5a, 0xd4, 0x0d, 0xe8, 0x27, 0xa0, 0xe3, 0xcc, 0x4c, 0xa6, 0x15, 0x28,
+	0x4f, 0xf9, 0x9f, 0xc4, 0x85, 0xd0, 0x4f, 0x44, 0xc7, 0xf9, 0x48, 0x5c, 0x92, 0xfe, 0x4b, 0x5c,
+	0xfe, 0xac, 0xc0, 0x6a, 0xd5, 0x17, 0x27, 0x7c, 0x0f, 0xe5, 0x0a, 0xd3, 0x1e, 0x87, 0x53, 0x33,
+	0x1d, 0x94, 0x00, 0xfc, 0x49, 0x81, 0x47, 0x30, 0x71, 0xbb, 0x0f, 0xa7, 0xf3, 0x57, 0xe1, 0xe4,
+	0x11, 0xe7, 0xe4, 0xe6, 0xec, 0x22, 0xe4, 0xfa, 0x84, 0xba, 0x5d, 0x97, 0xba, 0xd2, 0xa5, 0xd5,
+	0x68, 0xde, 0x89, 0x74, 0x5d, 0x4a, 0xe0, 0x58, 0x56, 0x7b, 0x3f, 0x05, 0x65, 0xbe, 0x0f, 0xfe,
+	0xf4, 0xed, 0x6a, 0xf6, 0x7b, 0xc2, 0xbb, 0x0a, 0x2c, 0x4f, 0x03, 0x14, 0xbf, 0x2f, 0xfc, 0xb7,
+	0x0f, 0x29, 0x66, 0x14, 0x84, 0xf4, 0xac, 0x2d, 0xe8, 0xef, 0x53, 0x50, 0x49, 0x9a, 0xf4, 0xe9,
+	0x81, 0xc6, 0xf4, 0x81, 0xc6, 0xc7, 0x3e, 0xc1, 0x7a, 0x4f, 0x81, 0x47, 0x67, 0x00, 0xfa, 0xf1,
+	0x02, 0x9d, 0x38, 0xd6, 0x48, 0xdd, 0xf3, 0x58, 0xe3, 0x7e, 0x43, 0xfd, 0x07, 0x05, 0x96, 0xeb,
+	0x24, 0x0c, 0xdd, 0x5d, 0x22, 0xde, 0xf1, 0x8f, 0x6f, 0x35, 0xe3, 0x07, 0xc6, 0x99, 0xc9, 0x8d,
+	0x8c, 0x56, 0x85, 0x95, 0x43, 0xae, 0x3d, 0xc0, 0xb9, 0xc5, 0x3f, 0x14, 0x58, 0x92, 0xb3, 0xe8,
+	0xc7, 0x76, 0x23, 0x30, 0x03, 0x1d, 0xf4, 0x04, 0xa4, 0xbd, 0x6e, 0xb4, 0x83, 0x9c, 0xbe, 0x1f,
+	0x66, 0x0c, 0xed, 0x12, 0xa0, 0xa4, 0xdf, 0x0f, 0x00, 0xdd, 0x5f, 0xd2, 0xb0, 0xd4, 0x1a, 0xf6,
+	0x3c, 0x2a, 0x99, 0x0f, 0x77, 0xe1, 0x7f, 0x0a, 0x16, 0x42, 0xe6, 0xac, 0x23, 0x6e, 0xd9, 0x38,
+	0xb0, 0x79, 0x5c, 0xe0, 0xb4, 0x2a, 0x27, 0xa1, 0x27, 0xa1, 0x10, 0x89, 0x8c, 0x06, 0x54, 0x9e,
+	0x82, 0x82, 0x94, 0x18, 0x0d, 0x28, 0xba, 0x00, 0x27, 0x07, 0xa3, 0xbe, 0xc3, 0xaf, 0x98, 0x86,
+	0x24, 0x70, 0xf8, 0xcc, 0x0e, 0xdb, 0xce, 0xf3, 0x3b, 0xba, 0x34, 0x2e, 0x0f, 0x46, 0x7d, 0xec,
+	0x1f, 0x84, 0x4d, 0x12, 0x70, 0xe5, 0x4d, 0x37, 0xa0, 0xe8, 0x12, 0xe4, 0xdd, 0xde, 0xae, 0x1f,
+	0x78, 0x74, 0xaf, 0xcf, 0x2f, 0xec, 0x4a, 0x1b, 0x5a, 0x74, 0xed, 0x72, 0x18, 0xfe, 0x75, 0x3d,
+	0x92, 0xc4, 0x93, 0x3f, 0x69, 0xaf, 0x42, 0x3e, 0xa6, 0x23, 0x15, 0x16, 0x8c, 0xab, 0x6d, 0xbd,
+	0xe6, 0xb4, 0x9a, 0x35, 0xd3, 0x6e, 0x89, 0x0b, 0xdb, 0xed, 0x76, 0xad, 0xe6, 0xb4, 0xaa, 0xba,
+	0xa5, 0x2a, 0x68, 0x01, 0x72, 0x2d, 0xbd, 0xde, 0xac, 0x99, 0xd6, 0x65, 0x35, 0xa5, 0x61, 0x00,
+	0xae, 0x80, 0xab, 0x9a, 0xc0, 0xa5, 0xdc, 0x03, 0xae, 0x53, 0x90, 0x0f, 0xfc, 0x03, 0x89, 0x44,
+	0x8a, 0x3b, 0x97, 0x0b, 0xfc, 0x03, 0x8e, 0x83, 0xa6, 0x03, 0x4a, 0x5a, 0x2e, 0x73, 0x2f, 0x51,
+	0xca, 0x95, 0xa9, 0x52, 0x3e, 0xd1, 0x1f, 0x97, 0x72, 0xb1, 0xb1, 0x67, 0xab, 0xfe, 0x75, 0xe2,
+	0xf6, 0x68, 0xd4, 0xbd, 0xb4, 0xdf, 0xa4, 0xa0, 0x88, 0x19, 0xc5, 0xeb, 0x93, 0x16, 0x75, 0x69,
+	0xc8, 0xe2, 0xb6, 0xc7, 0x45, 0x9c, 0x49, 0x11, 0xce, 0xe3, 0x82, 0xa0, 0x89, 0xeb, 0x82, 0x0d,
+	0x58, 0x09, 0x49, 0xc7, 0x1f, 0x74, 0x43, 0x67, 0x87, 0xec, 0x79, 0x83, 0xae, 0xd3, 0x77, 0x43,
+	0x2a, 0xef, 0x1b, 0x8b, 0xb8, 0x2c, 0x99, 0x9b, 0x9c, 0x57, 0xe7, 0x2c, 0x74, 0x0e, 0x96, 0x77,
+	0xbc, 0x41, 0xcf, 0xdf, 0x75, 0x86, 0x3d, 0x77, 0x4c, 0x82, 0x50, 0xba, 0xca, 0x92, 0x2d, 0x8b,
+	0x91, 0xe0, 0x35, 0x05, 0x4b, 0x04, 0xff, 0x2d, 0x58, 0x9b, 0xa9, 0xc5, 0xb9, 0xe1, 0xf5, 0x28,
+	0x09, 0x48, 0xd7, 0x09, 0xc8, 0xb0, 0xe7, 0x75, 0xc4, 0x8d, 0xbd, 0xd8, 0xc9, 0x3f, 0x3b, 0x43,
+	0xf5, 0xb6, 0x14, 0xc7, 0x13, 0x69, 0x86, 0x76, 0x67, 0x38, 0x72, 0x46, 0x6c, 0x39, 0xf3, 0x9e,
+	0xa6, 0xe0, 0x5c, 0x67, 0x38, 0x6a, 0xb3, 0x31, 0x52, 0x21, 0x7d, 0x73, 0x28, 0x5a, 0x99, 0x82,
+	0xd9, 0xa3, 0xf6, 0xeb, 0xf8, 0x4c, 0x3c, 0x42, 0x2f, 0x6e, 0x55, 0xd1, 0xa2, 0x51, 0xee, 0xb6,
+	0x68, 0x2a, 0x30, 0x1f, 0x92, 0xe0, 0x96, 0x37, 0xd8, 0x8d, 0xae, 0x64, 0xe5, 0x10, 0xb5, 0xe0,
+	0x59, 0xf9, 0x89, 0x0f, 0xb9, 0x4d, 0x49, 0x30, 0x70, 0x7b, 0xbd, 0xb1, 0x23, 0xde, 0xe2, 0x07,
+	0x94, 0x74, 0x9d, 0xc9, 0xc7, 0x38, 0xa2, 0x5d, 0x3d, 0x2d, 0xa4, 0x8d, 0x58, 0x18, 0xc7, 0xb2,
+	0x76, 0xfc, 0x99, 0xce, 0x6b, 0x50, 0x0a, 0x64, 0x4c, 0x9d, 0x90, 0x05, 0x55, 0x2e, 0xd6, 0xe5,
+	0xf8, 0xee, 0x34, 0x11, 0x70, 0x5c, 0x0c, 0xa6, 0xe2, 0xff, 0x32, 0x14, 0xc5, 0x85, 0x63, 0xd8,
+	0xd9, 0x23, 0x7d, 0x37, 0xaa, 0x88, 0x28, 0xf6, 0x6c, 0xa7, 0x47, 0x5a, 0x9c, 0x85, 0x17, 0xe8,
+	0x64, 0x10, 0xb2, 0x77, 0xc4, 0x72, 0x7b, 0xd8, 0x75, 0xe9, 0xf1, 0xee, 0x9c, 0xc9, 0x53, 0xd7,
+	0xcc, 0xf4, 0xa9, 0xeb, 0xf4, 0xd7, 0x51, 0xd9, 0x43, 0x5f, 0x47, 0x69, 0x97, 0x60, 0x79, 0xda,
+	0x7f, 0x99, 0x24, 0x67, 0x21, 0xcb, 0x6f, 0x88, 0x0f, 0xb5, 0x88, 0xc4, 0x15, 0x30, 0x16, 0x02,
+	0xda, 0x6f, 0x15, 0x28, 0xcf, 0x78, 0x7d, 0x88, 0xdf, 0x4d, 0x94, 0xc4, 0xd1, 0xc7, 0xff, 0x40,
+	0x96, 0xdf, 0x55, 0xcb, 0x0f, 0x2c, 0x4e, 0x1e, 0x7d, 0xfb, 0xe0, 0xf7, 0xca, 0x58, 0x48, 0xb1,
+	0x65, 0xcd, 0xf3, 0xa1, 0xc3, 0xcf, 0x3e, 0xa2, 0xdd, 0x4f, 0x81, 0xd1, 0xc4, 0x71, 0xc8, 0xd1,
+	0xc3, 0x94, 0xcc, 0xbd, 0x0f, 0x53, 0x4c, 0x28, 0x24, 0x12, 0x62, 0xe6, 0x77, 0x20, 0xcf, 0xc2,
+	0xbc, 0xe8, 0x00, 0xd1, 0x36, 0x6d, 0xfa, 0x5a, 0x3a, 0x62, 0xae, 0x7d, 0x3f, 0x0d, 0xf9, 0xfa,
+	0xb8, 0x75, 0xb3, 0xb7, 0xdd, 0x73, 0x77, 0xf9, 0x1d, 0x72, 0xbd, 0x69, 0x5f, 0x57, 0x4f, 0xa0,
+	0x25, 0x28, 0x5a, 0x0d, 0xdb, 0xb1, 0x58, 0xc5, 0xdd, 0xae, 0xe9, 0x97, 0x55, 0x85, 0x95, 0xe4,
+	0x26, 0x36, 0x9d, 0x2b, 0xc6, 0x75, 0x41, 0x49, 0xa1, 0x32, 0x2c, 0xb6, 0x2d, 0xf3, 0x6a, 0xdb,
+	0x98, 0x10, 0x33, 0x68, 0x05, 0x96, 0xea, 0xed, 0x9a, 0x6d, 0x36, 0x6b, 0x09, 0x72, 0x8e, 0x95,
+	0xef, 0xcd, 0x5a, 0x63, 0x53, 0x0c, 0x55, 0x36, 0x7f, 0xdb, 0x6a, 0x99, 0x97, 0x2d, 0x63, 0x4b,
+	0x90, 0x4e, 0x33, 0xd2, 0x5b, 0x06, 0x6e, 0x6c, 0x9b, 0x91, 0xca, 0x4b, 0x48, 0x85, 0xc2, 0xa6,
+	0x69, 0xe9, 0x58, 0xce, 0x72, 0x47, 0x41, 0x25, 0xc8, 0x1b, 0x56, 0xbb, 0x2e, 0xc7, 0x29, 0x54,
+	0x81, 0xb2, 0xde, 0xb6, 0x1b, 0x8e, 0x69, 0x55, 0xb1, 0x51, 0x37, 0x2c, 0x5b, 0x72, 0x32, 0xa8,
+	0x0c, 0x25, 0xdb, 0xac, 0x1b, 0x2d, 0x5b, 0xaf, 0x37, 0x25, 0x91, 0x59, 0x91, 0x6b, 0x19, 0x91,
+	0x8c, 0x8a, 0x56, 0x61, 0xc5, 0x6a, 0x38, 0xf2, 0xb3, 0x1e, 0xe7, 0x9a, 0x5e, 0x6b, 0x1b, 0x92,
+	0x77, 0x1a, 0x9d, 0x04, 0xd4, 0xb0, 0x9c, 0x76, 0x73, 0x4b, 0xb7, 0x0d, 0xc7, 0x6a, 0xbc, 0x29,
+	0x19, 0x97, 0x50, 0x09, 0x72, 0x13, 0x0b, 0xee, 0x30, 0x14, 0x8a, 0x4d, 0x1d, 0xdb, 0x13, 0x67,
+	0xef, 0xdc, 0x61, 0x60, 0xc1, 0x65, 0xdc, 0x68, 0x37, 0x27, 0x62, 0x4b, 0x50, 0x90, 0x60, 0x49,
+	0x52, 0x86, 0x91, 0x36, 0x4d, 0xab, 0x1a, 0xdb, 0x77, 0x27, 0xb7, 0x9a, 0x52, 0x95, 0xb5, 0x7d,
+	0xc8, 0xf0, 0x70, 0xe4, 0x20, 0x63, 0x35, 0x2c, 0x43, 0x3d, 0x81, 0x16, 0x01, 0xcc, 0x96, 0x69,
+	0xd9, 0xc6, 0x65, 0xac, 0xd7, 0x98, 0xdb, 0x9c, 0x10, 0x01, 0xc8, 0xbc, 0x5d, 0x80, 0x79, 0xb3,
+	0xb5, 0x5d, 0x6b, 0xe8, 0xb6, 0x74, 0xd3, 0x6c, 0x5d, 0x6d, 0x37, 0x6c, 0xc6, 0x54, 0x51, 0x01,
+	0xe6, 0xcc, 0x96, 0x6d, 0x7c, 0xde, 0x66, 0x7e, 0x71, 0x9e, 0x40, 0x55, 0xbd, 0x73, 0x69, 0xed,
+	0x9d, 0x34, 0x64, 0xec, 0xf1, 0x90, 0xb0, 0x00, 0xf1, 0x68, 0xdb, 0xd7, 0x9b, 0x4c, 0x65, 0x1e,
+	0x32, 0xa6, 0x65, 0xbf, 0xa2, 0x7e, 0x31, 0x85, 0x00, 0xb2, 0x6d, 0xfe, 0xfc, 0xa5, 0x39, 0xf6,
+	0x6c, 0x5a, 0xf6, 0x4b, 0x17, 0xd5, 0xb7, 0x53, 0x6c, 0xda, 0xb6, 0x18, 0x7c, 0x39, 0x62, 0x6c,
+	0x5c, 0x50, 0xbf, 0x12, 0x33, 0x36, 0x2e, 0xa8, 0x5f, 0x8d, 0x18, 0xe7, 0x37, 0xd4, 0xaf, 0xc5,
+	0x8c, 0xf3, 0x1b, 0xea, 0xd7, 0x23, 0xc6, 0xc5, 0x0b, 0xea, 0x3b, 0x31, 0xe3, 0xe2, 0x05, 0xf5,
+	0x1b, 0x73, 0xcc, 0x17, 0xee, 0xc9, 0xf9, 0x0d, 0xf5, 0x9b, 0xb9, 0x78, 0x74, 0xf1, 0x82, 0xfa,
+	0xad, 0x1c, 0x8b, 0x7f, 0x1c, 0x55, 0xf5, 0xdb, 0x2a, 0x33, 0x93, 0x05, 0x48, 0xfd, 0x0e, 0x7f,
+	0x64, 0x2c, 0xf5, 0xbb, 0x2a, 0xf3, 0x91, 0x51, 0xf9, 0xf0, 0x5d, 0xce, 0xb9, 0x6e, 0xe8, 0x58,
+	0xfd, 0xde, 0x9c, 0xf8, 0x8a, 0xab, 0x6a, 0xd6, 0xf5, 0x9a, 0x8a, 0xf8, 0x3f, 0x18, 0x2a, 0x3f,
+	0x38, 0xc7, 0x1e, 0x59, 0x7a, 0xaa, 0x3f, 0x6c, 0x32, 0x85, 0xd7, 0x74, 0x5c, 0x7d, 0x5d, 0xc7,
+	0xea, 0x8f, 0xce, 0x31, 0x85, 0xd7, 0x74, 0x2c, 0xf1, 0xfa, 0x71, 0x93, 0x09, 0x72, 0xd6, 0x7b,
+	0xe7, 0x98, 0xd1, 0x92, 0xfe, 0x93, 0x26, 0xca, 0x41, 0x7a, 0xd3, 0xb4, 0xd5, 0x9f, 0x72, 0x6d,
+	0x2c, 0x45, 0xd5, 0x9f, 0xa9, 0x8c, 0xd8, 0x32, 0x6c, 0xf5, 0xe7, 0x8c, 0x98, 0xb5, 0xdb, 0xcd,
+	0x9a, 0xa1, 0x3e, 0xc6, 0x8c, 0xbb, 0x6c, 0x34, 0xea, 0x86, 0x8d, 0xaf, 0xab, 0xbf, 0xe0, 0xe2,
+	0x6f, 0xb4, 0x1a, 0x96, 0xfa, 0xbe, 0xba, 0xb6, 0x0d, 0xea, 0xe1, 0x4a, 0xc2, 0x0c, 0x6e, 0x5b,
+	0x57, 0xac, 0xc6, 0x9b, 0x96, 0x7a, 0x82, 0x0d, 0x9a, 0xd8, 0x68, 0xea, 0xd8, 0x50, 0x15, 0x04,
+	0x30, 0x27, 0xbe, 0x29, 0x53, 0x53, 0x6c, 0x37, 0x84, 0x1b, 0xb5, 0xda, 0xa6, 0x5e, 0xbd, 0xa2,
+	0xa6, 0x37, 0x57, 0xa1, 0xd2, 0xf1, 0xfb, 0xeb, 0x63, 0x7f, 0x44, 0x47, 0x3b, 0x64, 0xfd, 0x96,
+	0x47, 0x49, 0x18, 0x8a, 0x4f, 0x63, 0x77, 0xe6, 0xf8, 0xcf, 0xf9, 0x7f, 0x07, 0x00, 0x00, 0xff,
+	0xff, 0x05, 0xa8, 0xa9, 0x9f, 0x54, 0x2b, 0x00, 0x00,
 }
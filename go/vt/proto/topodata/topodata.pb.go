@@ -247,6 +247,11 @@ type Shard struct {
 	// tablet_controls has at most one entry per TabletType.
 	// The keyspace lock is always taken when changing this.
 	TabletControls []*Shard_TabletControl `protobuf:"bytes,6,rep,name=tablet_controls,json=tabletControls" json:"tablet_controls,omitempty"`
+	// failure_detector_reparent_time is the time, in seconds since the
+	// epoch, of the last reparent of the shard by the failure detector.
+	// It is used to enforce the cooldown between two reparents.
+	// No lock is necessary to update this field.
+	FailureDetectorReparentTime int64 `protobuf:"varint,7,opt,name=failure_detector_reparent_time,json=failureDetectorReparentTime" json:"failure_detector_reparent_time,omitempty"`
 }
 
 func (m *Shard) Reset()                    { *m = Shard{} }
@@ -547,74 +552,77 @@ func init() {
 func init() { proto.RegisterFile("topodata.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1096 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x56, 0x6f, 0x6f, 0xe3, 0xc4,
-	0x13, 0xfe, 0xd9, 0x71, 0xd2, 0x64, 0x9c, 0xe6, 0x7c, 0xfb, 0xbb, 0x43, 0x96, 0x11, 0xa2, 0x8a,
-	0x84, 0xa8, 0x0e, 0x11, 0x50, 0x8e, 0x83, 0xea, 0x24, 0xa4, 0xa6, 0xa9, 0x0f, 0xd2, 0x3f, 0x69,
-	0xd8, 0xa4, 0x82, 0xbe, 0xb2, 0x9c, 0x78, 0xdb, 0xb3, 0xea, 0x64, 0xcd, 0xee, 0xa6, 0x52, 0x3e,
-	0xc3, 0xbd, 0xe0, 0x5e, 0xf3, 0x65, 0x78, 0xc9, 0xa7, 0x42, 0x42, 0xbb, 0x6b, 0x27, 0x4e, 0x4a,
-	0x4b, 0x0f, 0xf5, 0x55, 0x67, 0xbc, 0x33, 0xb3, 0xf3, 0x3c, 0xf3, 0xcc, 0x36, 0xd0, 0x10, 0x34,
-	0xa5, 0x51, 0x28, 0xc2, 0x56, 0xca, 0xa8, 0xa0, 0xa8, 0x9a, 0xfb, 0xcd, 0x36, 0x54, 0x8f, 0xc9,
-	0x02, 0x87, 0xb3, 0x2b, 0x82, 0x9e, 0x41, 0x99, 0x8b, 0x90, 0x09, 0xd7, 0xd8, 0x31, 0x76, 0xeb,
-	0x58, 0x3b, 0xc8, 0x81, 0x12, 0x99, 0x45, 0xae, 0xa9, 0xbe, 0x49, 0xb3, 0xf9, 0x12, 0xec, 0x51,
-	0x38, 0x4e, 0x88, 0xe8, 0x24, 0x71, 0xc8, 0x11, 0x02, 0x6b, 0x42, 0x92, 0x44, 0x65, 0xd5, 0xb0,
-	0xb2, 0x65, 0xd2, 0x3c, 0xd6, 0x49, 0xdb, 0x58, 0x9a, 0xcd, 0xbf, 0x4a, 0x50, 0xd1, 0x59, 0xe8,
-	0x0b, 0x28, 0x87, 0x32, 0x53, 0x65, 0xd8, 0xed, 0xe7, 0xad, 0x65, 0x77, 0x85, 0xb2, 0x58, 0xc7,
-	0x20, 0x0f, 0xaa, 0x6f, 0x29, 0x17, 0xb3, 0x70, 0x4a, 0x54, 0xb9, 0x1a, 0x5e, 0xfa, 0xa8, 0x01,
-	0x66, 0x9c, 0xba, 0x25, 0xf5, 0xd5, 0x8c, 0x53, 0xb4, 0x07, 0xd5, 0x94, 0x32, 0x11, 0x4c, 0xc3,
-	0xd4, 0xb5, 0x76, 0x4a, 0xbb, 0x76, 0xfb, 0x93, 0xcd, 0xda, 0xad, 0x01, 0x65, 0xe2, 0x34, 0x4c,
-	0xfd, 0x99, 0x60, 0x0b, 0xbc, 0x95, 0x6a, 0x4f, 0xde, 0x72, 0x4d, 0x16, 0x3c, 0x0d, 0x27, 0xc4,
-	0x2d, 0xeb, 0x5b, 0x72, 0x5f, 0xd1, 0xf2, 0x36, 0x64, 0x91, 0x5b, 0x51, 0x07, 0xda, 0x41, 0x5f,
-	0x41, 0xed, 0x9a, 0x2c, 0x02, 0x26, 0x99, 0x73, 0xb7, 0x14, 0x10, 0xb4, 0xba, 0x2c, 0xe7, 0x54,
-	0x95, 0xd1, 0xec, 0xee, 0x82, 0x25, 0x16, 0x29, 0x71, 0xab, 0x3b, 0xc6, 0x6e, 0xa3, 0xfd, 0x6c,
-	0xb3, 0xb1, 0xd1, 0x22, 0x25, 0x58, 0x45, 0xa0, 0x5d, 0x70, 0xa2, 0x71, 0x20, 0x11, 0x06, 0xf4,
-	0x86, 0x30, 0x16, 0x47, 0xc4, 0xad, 0xa9, 0xbb, 0x1b, 0xd1, 0xb8, 0x1f, 0x4e, 0xc9, 0x59, 0xf6,
-	0x15, 0xb5, 0xc0, 0x12, 0xe1, 0x15, 0x77, 0x41, 0x81, 0xf5, 0x6e, 0x81, 0x1d, 0x85, 0x57, 0x5c,
-	0x23, 0x55, 0x71, 0xde, 0x6b, 0xa8, 0x17, 0xf1, 0xcb, 0x31, 0x5d, 0x93, 0x45, 0x36, 0x39, 0x69,
-	0x4a, 0xb0, 0x37, 0x61, 0x32, 0xd7, 0x5c, 0x97, 0xb1, 0x76, 0x5e, 0x9b, 0x7b, 0x86, 0xf7, 0x1d,
-	0xd4, 0x96, 0xe5, 0xfe, 0x2d, 0xb1, 0x56, 0x48, 0x3c, 0xb2, 0xaa, 0xb6, 0x53, 0x6f, 0xbe, 0xab,
-	0x40, 0x79, 0xa8, 0x98, 0xdb, 0x83, 0xfa, 0x34, 0xe4, 0x82, 0xb0, 0xe0, 0x01, 0x2a, 0xb0, 0x75,
-	0xa8, 0x56, 0xda, 0x1a, 0xe7, 0xe6, 0x03, 0x38, 0xff, 0x1e, 0xea, 0x9c, 0xb0, 0x1b, 0x12, 0x05,
-	0x92, 0x58, 0xee, 0x96, 0x36, 0x79, 0x52, 0x1d, 0xb5, 0x86, 0x2a, 0x46, 0x4d, 0xc0, 0xe6, 0x4b,
-	0x9b, 0xa3, 0x7d, 0xd8, 0xe6, 0x74, 0xce, 0x26, 0x24, 0x50, 0x33, 0xe7, 0x99, 0xa8, 0x3e, 0xbe,
-	0x95, 0xaf, 0x82, 0x94, 0x8d, 0xeb, 0x7c, 0xe5, 0x70, 0xc9, 0x8a, 0xdc, 0x07, 0xee, 0x96, 0x77,
-	0x4a, 0x92, 0x15, 0xe5, 0xa0, 0x37, 0xf0, 0x44, 0x28, 0x8c, 0xc1, 0x84, 0xce, 0x04, 0xa3, 0x09,
-	0x77, 0x2b, 0x9b, 0x72, 0xd5, 0x95, 0x35, 0x15, 0x5d, 0x1d, 0x85, 0x1b, 0xa2, 0xe8, 0x72, 0xef,
-	0x02, 0x60, 0xd5, 0x3a, 0x7a, 0x05, 0x76, 0x56, 0x55, 0xe9, 0xcc, 0xb8, 0x47, 0x67, 0x20, 0x96,
-	0xf6, 0xaa, 0x45, 0xb3, 0xd0, 0xa2, 0xf7, 0xbb, 0x01, 0x76, 0x01, 0x56, 0xbe, 0xd0, 0xc6, 0x72,
-	0xa1, 0xd7, 0x56, 0xc6, 0xbc, 0x6b, 0x65, 0x4a, 0x77, 0xae, 0x8c, 0xf5, 0x80, 0xf1, 0x7d, 0x04,
-	0x15, 0xd5, 0x68, 0x4e, 0x5f, 0xe6, 0x79, 0x7f, 0x18, 0xb0, 0xbd, 0xc6, 0xcc, 0xa3, 0x62, 0x47,
-	0x6d, 0x78, 0x1e, 0xc5, 0x5c, 0x46, 0x05, 0xbf, 0xce, 0x09, 0x5b, 0x04, 0x52, 0x13, 0xf1, 0x84,
-	0x28, 0x34, 0x55, 0xfc, 0xff, 0xec, 0xf0, 0x27, 0x79, 0x36, 0xd4, 0x47, 0xe8, 0x4b, 0x40, 0xe3,
-	0x24, 0x9c, 0x5c, 0x27, 0x31, 0x17, 0x52, 0x6e, 0xba, 0x6d, 0x4b, 0x95, 0x7d, 0x5a, 0x38, 0x51,
-	0x8d, 0xf0, 0xe6, 0x9f, 0xa6, 0x7a, 0x77, 0x35, 0x5b, 0x5f, 0xc3, 0x33, 0x45, 0x50, 0x3c, 0xbb,
-	0x0a, 0x26, 0x34, 0x99, 0x4f, 0x67, 0x6a, 0xf9, 0xb3, 0xed, 0x42, 0xf9, 0x59, 0x57, 0x1d, 0xc9,
-	0xfd, 0x47, 0x47, 0xb7, 0x33, 0x14, 0x6e, 0x53, 0xe1, 0x76, 0xd7, 0x48, 0x55, 0x77, 0xf4, 0xb4,
-	0xba, 0x37, 0x6a, 0x29, 0x0e, 0xf6, 0x97, 0x3b, 0x72, 0xc9, 0xe8, 0x94, 0xdf, 0x7e, 0x38, 0xf3,
-	0x1a, 0xd9, 0x9a, 0xbc, 0x61, 0x74, 0x9a, 0xaf, 0x89, 0xb4, 0xb9, 0x37, 0xcf, 0x65, 0x28, 0xdd,
-	0xc7, 0x1d, 0x45, 0x51, 0x64, 0xa5, 0x75, 0x91, 0x1d, 0x59, 0xd5, 0x92, 0x63, 0x35, 0xdf, 0x19,
-	0xe0, 0xe8, 0xcd, 0x23, 0x69, 0x12, 0x4f, 0x42, 0x11, 0xd3, 0x19, 0x7a, 0x05, 0xe5, 0x19, 0x8d,
-	0x88, 0x7c, 0x5b, 0x24, 0x98, 0x4f, 0x37, 0xd6, 0xaa, 0x10, 0xda, 0xea, 0xd3, 0x88, 0x60, 0x1d,
-	0xed, 0xed, 0x83, 0x25, 0x5d, 0xf9, 0x42, 0x65, 0x10, 0x1e, 0xf2, 0x42, 0x89, 0x95, 0xd3, 0x3c,
-	0x87, 0x46, 0x76, 0xc3, 0x25, 0x61, 0x64, 0x36, 0x21, 0xf2, 0xbf, 0x63, 0x61, 0x98, 0xca, 0xfe,
-	0xe0, 0x77, 0xac, 0xf9, 0xde, 0x02, 0x7b, 0xc8, 0x6e, 0x96, 0x8a, 0xf9, 0x01, 0x20, 0x0d, 0x99,
-	0x88, 0x25, 0x82, 0x1c, 0xe4, 0xe7, 0x05, 0x90, 0xab, 0xd0, 0xe5, 0xf4, 0x06, 0x79, 0x3c, 0x2e,
-	0xa4, 0xde, 0x29, 0x3d, 0xf3, 0x83, 0xa5, 0x57, 0xfa, 0x0f, 0xd2, 0xeb, 0x80, 0x5d, 0x90, 0x5e,
-	0xa6, 0xbc, 0x9d, 0x7f, 0xc6, 0x51, 0x10, 0x1f, 0xac, 0xc4, 0xe7, 0xfd, 0x66, 0xc0, 0xd3, 0x5b,
-	0x10, 0xa5, 0x06, 0x0b, 0xef, 0xfe, 0xfd, 0x1a, 0x5c, 0x3d, 0xf8, 0xa8, 0x0b, 0x8e, 0xea, 0x32,
-	0x60, 0xf9, 0xf8, 0xb4, 0x1c, 0xed, 0x22, 0xae, 0xf5, 0xf9, 0xe2, 0x27, 0x7c, 0xcd, 0xe7, 0x5e,
-	0xf0, 0x18, 0xdb, 0x70, 0xcf, 0xe3, 0x7a, 0x64, 0x55, 0xcb, 0x4e, 0xa5, 0xe9, 0x43, 0xb5, 0x4b,
-	0x92, 0xa4, 0x37, 0xbb, 0xa4, 0xe8, 0x33, 0x68, 0x28, 0x14, 0x2c, 0x08, 0xa3, 0x88, 0x11, 0xce,
-	0x33, 0xb5, 0x6d, 0xeb, 0xaf, 0x1d, 0xfd, 0x51, 0x4a, 0x91, 0x51, 0x2a, 0xb2, 0x82, 0xca, 0x7e,
-	0xd1, 0x86, 0xc6, 0xfa, 0xa0, 0x50, 0x0d, 0xca, 0xe7, 0xfd, 0xa1, 0x3f, 0x72, 0xfe, 0x87, 0x00,
-	0x2a, 0xe7, 0xbd, 0xfe, 0xe8, 0xdb, 0x6f, 0x1c, 0x43, 0x7e, 0x3e, 0xb8, 0x18, 0xf9, 0x43, 0xc7,
-	0x7c, 0xf1, 0xde, 0x00, 0x58, 0xf5, 0x8d, 0x6c, 0xd8, 0x3a, 0xef, 0x1f, 0xf7, 0xcf, 0x7e, 0xee,
-	0xeb, 0x94, 0xd3, 0xce, 0x70, 0xe4, 0x63, 0xc7, 0x90, 0x07, 0xd8, 0x1f, 0x9c, 0xf4, 0xba, 0x1d,
-	0xc7, 0x94, 0x07, 0xf8, 0xf0, 0xac, 0x7f, 0x72, 0xe1, 0x94, 0x54, 0xad, 0xce, 0xa8, 0xfb, 0xa3,
-	0x36, 0x87, 0x83, 0x0e, 0xf6, 0x1d, 0x0b, 0x39, 0x50, 0xf7, 0x7f, 0x19, 0xf8, 0xb8, 0x77, 0xea,
-	0xf7, 0x47, 0x9d, 0x13, 0xa7, 0x2c, 0x73, 0x0e, 0x3a, 0xdd, 0xe3, 0xf3, 0x81, 0x53, 0xd1, 0xc5,
-	0x86, 0xa3, 0x33, 0xec, 0x3b, 0x5b, 0xd2, 0x39, 0xc4, 0x9d, 0x5e, 0xdf, 0x3f, 0x74, 0xaa, 0x9e,
-	0xe9, 0x18, 0x07, 0x1e, 0xb8, 0x13, 0x3a, 0x6d, 0x2d, 0xe8, 0x5c, 0xcc, 0xc7, 0xa4, 0x75, 0x13,
-	0x0b, 0xc2, 0xb9, 0xfe, 0xb1, 0x3b, 0xae, 0xa8, 0x3f, 0x2f, 0xff, 0x0e, 0x00, 0x00, 0xff, 0xff,
-	0x06, 0xa6, 0x11, 0xb5, 0x05, 0x0b, 0x00, 0x00,
+	// 1137 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x56, 0xdf, 0x6f, 0xe3, 0xc4,
+	0x13, 0xff, 0xfa, 0x47, 0xd2, 0x64, 0x9c, 0xe6, 0x7c, 0xfb, 0xbd, 0x43, 0x96, 0x4f, 0x40, 0x15,
+	0x09, 0x51, 0x1d, 0x22, 0xa0, 0x1c, 0x07, 0xa7, 0x93, 0x90, 0x9a, 0xa6, 0x3e, 0x48, 0x7f, 0xa4,
+	0x61, 0x93, 0x0a, 0xfa, 0x64, 0x39, 0xf1, 0xb6, 0x67, 0xd5, 0xb1, 0xcd, 0xee, 0xa6, 0x52, 0xfe,
+	0x06, 0x1e, 0xb8, 0x67, 0xfe, 0x19, 0x1e, 0x79, 0xe5, 0x1f, 0x42, 0x42, 0xbb, 0x6b, 0x27, 0x4e,
+	0x4a, 0x4b, 0x0f, 0xf5, 0x29, 0x33, 0xbb, 0x33, 0xb3, 0xf3, 0x99, 0xf9, 0xcc, 0x38, 0xd0, 0xe4,
+	0x69, 0x96, 0x86, 0x01, 0x0f, 0xda, 0x19, 0x4d, 0x79, 0x8a, 0x6a, 0x85, 0xde, 0xea, 0x40, 0xed,
+	0x88, 0x2c, 0x70, 0x90, 0x5c, 0x12, 0xf4, 0x04, 0x2a, 0x8c, 0x07, 0x94, 0x3b, 0xda, 0x8e, 0xb6,
+	0xdb, 0xc0, 0x4a, 0x41, 0x36, 0x18, 0x24, 0x09, 0x1d, 0x5d, 0x9e, 0x09, 0xb1, 0xf5, 0x02, 0xac,
+	0x71, 0x30, 0x89, 0x09, 0xef, 0xc6, 0x51, 0xc0, 0x10, 0x02, 0x73, 0x4a, 0xe2, 0x58, 0x7a, 0xd5,
+	0xb1, 0x94, 0x85, 0xd3, 0x3c, 0x52, 0x4e, 0xdb, 0x58, 0x88, 0xad, 0xbf, 0x0c, 0xa8, 0x2a, 0x2f,
+	0xf4, 0x19, 0x54, 0x02, 0xe1, 0x29, 0x3d, 0xac, 0xce, 0xd3, 0xf6, 0x32, 0xbb, 0x52, 0x58, 0xac,
+	0x6c, 0x90, 0x0b, 0xb5, 0xb7, 0x29, 0xe3, 0x49, 0x30, 0x23, 0x32, 0x5c, 0x1d, 0x2f, 0x75, 0xd4,
+	0x04, 0x3d, 0xca, 0x1c, 0x43, 0x9e, 0xea, 0x51, 0x86, 0x5e, 0x41, 0x2d, 0x4b, 0x29, 0xf7, 0x67,
+	0x41, 0xe6, 0x98, 0x3b, 0xc6, 0xae, 0xd5, 0xf9, 0x70, 0x33, 0x76, 0x7b, 0x98, 0x52, 0x7e, 0x12,
+	0x64, 0x5e, 0xc2, 0xe9, 0x02, 0x6f, 0x65, 0x4a, 0x13, 0xaf, 0x5c, 0x91, 0x05, 0xcb, 0x82, 0x29,
+	0x71, 0x2a, 0xea, 0x95, 0x42, 0x97, 0x65, 0x79, 0x1b, 0xd0, 0xd0, 0xa9, 0xca, 0x0b, 0xa5, 0xa0,
+	0x2f, 0xa0, 0x7e, 0x45, 0x16, 0x3e, 0x15, 0x95, 0x73, 0xb6, 0x24, 0x10, 0xb4, 0x7a, 0xac, 0xa8,
+	0xa9, 0x0c, 0xa3, 0xaa, 0xbb, 0x0b, 0x26, 0x5f, 0x64, 0xc4, 0xa9, 0xed, 0x68, 0xbb, 0xcd, 0xce,
+	0x93, 0xcd, 0xc4, 0xc6, 0x8b, 0x8c, 0x60, 0x69, 0x81, 0x76, 0xc1, 0x0e, 0x27, 0xbe, 0x40, 0xe8,
+	0xa7, 0xd7, 0x84, 0xd2, 0x28, 0x24, 0x4e, 0x5d, 0xbe, 0xdd, 0x0c, 0x27, 0x83, 0x60, 0x46, 0x4e,
+	0xf3, 0x53, 0xd4, 0x06, 0x93, 0x07, 0x97, 0xcc, 0x01, 0x09, 0xd6, 0xbd, 0x01, 0x76, 0x1c, 0x5c,
+	0x32, 0x85, 0x54, 0xda, 0xb9, 0xaf, 0xa1, 0x51, 0xc6, 0x2f, 0xda, 0x74, 0x45, 0x16, 0x79, 0xe7,
+	0x84, 0x28, 0xc0, 0x5e, 0x07, 0xf1, 0x5c, 0xd5, 0xba, 0x82, 0x95, 0xf2, 0x5a, 0x7f, 0xa5, 0xb9,
+	0xdf, 0x40, 0x7d, 0x19, 0xee, 0xdf, 0x1c, 0xeb, 0x25, 0xc7, 0x43, 0xb3, 0x66, 0xd9, 0x8d, 0xd6,
+	0x9f, 0x55, 0xa8, 0x8c, 0x64, 0xe5, 0x5e, 0x41, 0x63, 0x16, 0x30, 0x4e, 0xa8, 0x7f, 0x0f, 0x16,
+	0x58, 0xca, 0x54, 0x31, 0x6d, 0xad, 0xe6, 0xfa, 0x3d, 0x6a, 0xfe, 0x2d, 0x34, 0x18, 0xa1, 0xd7,
+	0x24, 0xf4, 0x45, 0x61, 0x99, 0x63, 0x6c, 0xd6, 0x49, 0x66, 0xd4, 0x1e, 0x49, 0x1b, 0xd9, 0x01,
+	0x8b, 0x2d, 0x65, 0x86, 0xf6, 0x60, 0x9b, 0xa5, 0x73, 0x3a, 0x25, 0xbe, 0xec, 0x39, 0xcb, 0x49,
+	0xf5, 0xec, 0x86, 0xbf, 0x34, 0x92, 0x32, 0x6e, 0xb0, 0x95, 0xc2, 0x44, 0x55, 0xc4, 0x3c, 0x30,
+	0xa7, 0xb2, 0x63, 0x88, 0xaa, 0x48, 0x05, 0xbd, 0x81, 0x47, 0x5c, 0x62, 0xf4, 0xa7, 0x69, 0xc2,
+	0x69, 0x1a, 0x33, 0xa7, 0xba, 0x49, 0x57, 0x15, 0x59, 0x95, 0xa2, 0xa7, 0xac, 0x70, 0x93, 0x97,
+	0x55, 0x86, 0x7a, 0xf0, 0xd1, 0x45, 0x10, 0xc5, 0x73, 0x4a, 0xfc, 0x90, 0x70, 0x32, 0xe5, 0x29,
+	0xf5, 0x29, 0xc9, 0x02, 0x4a, 0x12, 0xee, 0xf3, 0x68, 0xa6, 0x88, 0x69, 0xe0, 0x67, 0xb9, 0xd5,
+	0x41, 0x6e, 0x84, 0x73, 0x9b, 0x71, 0x34, 0x23, 0xee, 0x39, 0xc0, 0x0a, 0x3f, 0x7a, 0x09, 0x56,
+	0x9e, 0x9a, 0x24, 0xab, 0x76, 0x07, 0x59, 0x81, 0x2f, 0xe5, 0x15, 0x4e, 0xbd, 0x84, 0xd3, 0xfd,
+	0x4d, 0x03, 0xab, 0x54, 0x9b, 0x62, 0x2b, 0x68, 0xcb, 0xad, 0xb0, 0x36, 0x77, 0xfa, 0x6d, 0x73,
+	0x67, 0xdc, 0x3a, 0x77, 0xe6, 0x3d, 0x38, 0xf0, 0x01, 0x54, 0x65, 0xa2, 0x45, 0x0f, 0x72, 0xcd,
+	0xfd, 0x5d, 0x83, 0xed, 0xb5, 0xf2, 0x3e, 0x28, 0x76, 0xd4, 0x81, 0xa7, 0x61, 0xc4, 0x84, 0x95,
+	0xff, 0xf3, 0x9c, 0xd0, 0x85, 0x2f, 0x88, 0x15, 0x4d, 0x89, 0x44, 0x53, 0xc3, 0xff, 0xcf, 0x2f,
+	0x7f, 0x10, 0x77, 0x23, 0x75, 0x85, 0x3e, 0x07, 0x34, 0x89, 0x83, 0xe9, 0x55, 0x1c, 0x31, 0x2e,
+	0x38, 0xab, 0xd2, 0x36, 0x65, 0xd8, 0xc7, 0xa5, 0x1b, 0x99, 0x08, 0x6b, 0xfd, 0xa1, 0xcb, 0xe5,
+	0xad, 0xaa, 0xf5, 0x25, 0x3c, 0x91, 0x05, 0x8a, 0x92, 0x4b, 0x7f, 0x9a, 0xc6, 0xf3, 0x59, 0x22,
+	0x37, 0x48, 0x3e, 0xa2, 0xa8, 0xb8, 0xeb, 0xc9, 0x2b, 0xb1, 0x44, 0xd0, 0xe1, 0x4d, 0x0f, 0x89,
+	0x5b, 0x97, 0xb8, 0x9d, 0xb5, 0xa2, 0xca, 0x37, 0xfa, 0x6a, 0x44, 0x36, 0x62, 0xc9, 0x1a, 0xec,
+	0x2d, 0x07, 0xed, 0x82, 0xa6, 0x33, 0x76, 0x73, 0xfb, 0x16, 0x31, 0xf2, 0x59, 0x7b, 0x43, 0xd3,
+	0x59, 0x31, 0x6b, 0x42, 0x66, 0xee, 0xbc, 0xa0, 0xa1, 0x50, 0x1f, 0xb6, 0x15, 0x65, 0x92, 0x19,
+	0xeb, 0x24, 0x3b, 0x34, 0x6b, 0x86, 0x6d, 0xb6, 0x7e, 0xd1, 0xc0, 0x56, 0xe3, 0x4b, 0xb2, 0x38,
+	0x9a, 0x06, 0x3c, 0x4a, 0x13, 0xf4, 0x12, 0x2a, 0x49, 0x1a, 0x12, 0xb1, 0xa0, 0x04, 0x98, 0x8f,
+	0x37, 0x66, 0xb3, 0x64, 0xda, 0x1e, 0xa4, 0x21, 0xc1, 0xca, 0xda, 0xdd, 0x03, 0x53, 0xa8, 0x62,
+	0xcd, 0xe5, 0x10, 0xee, 0xb3, 0xe6, 0xf8, 0x4a, 0x69, 0x9d, 0x41, 0x33, 0x7f, 0xe1, 0x82, 0x50,
+	0x92, 0x4c, 0x89, 0xf8, 0xc4, 0x96, 0x9a, 0x29, 0xe5, 0xf7, 0x5e, 0x86, 0xad, 0x77, 0x26, 0x58,
+	0x23, 0x7a, 0xbd, 0x64, 0xcc, 0x77, 0x00, 0x59, 0x40, 0x79, 0x24, 0x10, 0x14, 0x20, 0x3f, 0x2d,
+	0x81, 0x5c, 0x99, 0x2e, 0xbb, 0x37, 0x2c, 0xec, 0x71, 0xc9, 0xf5, 0x56, 0xea, 0xe9, 0xef, 0x4d,
+	0x3d, 0xe3, 0x3f, 0x50, 0xaf, 0x0b, 0x56, 0x89, 0x7a, 0x39, 0xf3, 0x76, 0xfe, 0x19, 0x47, 0x89,
+	0x7c, 0xb0, 0x22, 0x9f, 0xfb, 0xab, 0x06, 0x8f, 0x6f, 0x40, 0x14, 0x1c, 0x2c, 0x7d, 0x3c, 0xee,
+	0xe6, 0xe0, 0xea, 0xab, 0x81, 0x7a, 0x60, 0xcb, 0x2c, 0x7d, 0x5a, 0xb4, 0x4f, 0xd1, 0xd1, 0x2a,
+	0xe3, 0x5a, 0xef, 0x2f, 0x7e, 0xc4, 0xd6, 0x74, 0xe6, 0xfa, 0x0f, 0x31, 0x0d, 0x77, 0x2c, 0xd7,
+	0x43, 0xb3, 0x56, 0xb1, 0xab, 0x2d, 0x0f, 0x6a, 0x3d, 0x12, 0xc7, 0xfd, 0xe4, 0x22, 0x45, 0x9f,
+	0x40, 0x53, 0xa2, 0xa0, 0x7e, 0x10, 0x86, 0x94, 0x30, 0x96, 0xb3, 0x6d, 0x5b, 0x9d, 0x76, 0xd5,
+	0xa1, 0xa0, 0x22, 0x4d, 0x53, 0x9e, 0x07, 0x94, 0xf2, 0xf3, 0x0e, 0x34, 0xd7, 0x1b, 0x85, 0xea,
+	0x50, 0x39, 0x1b, 0x8c, 0xbc, 0xb1, 0xfd, 0x3f, 0x04, 0x50, 0x3d, 0xeb, 0x0f, 0xc6, 0x5f, 0x7f,
+	0x65, 0x6b, 0xe2, 0x78, 0xff, 0x7c, 0xec, 0x8d, 0x6c, 0xfd, 0xf9, 0x3b, 0x0d, 0x60, 0x95, 0x37,
+	0xb2, 0x60, 0xeb, 0x6c, 0x70, 0x34, 0x38, 0xfd, 0x71, 0xa0, 0x5c, 0x4e, 0xba, 0xa3, 0xb1, 0x87,
+	0x6d, 0x4d, 0x5c, 0x60, 0x6f, 0x78, 0xdc, 0xef, 0x75, 0x6d, 0x5d, 0x5c, 0xe0, 0x83, 0xd3, 0xc1,
+	0xf1, 0xb9, 0x6d, 0xc8, 0x58, 0xdd, 0x71, 0xef, 0x7b, 0x25, 0x8e, 0x86, 0x5d, 0xec, 0xd9, 0x26,
+	0xb2, 0xa1, 0xe1, 0xfd, 0x34, 0xf4, 0x70, 0xff, 0xc4, 0x1b, 0x8c, 0xbb, 0xc7, 0x76, 0x45, 0xf8,
+	0xec, 0x77, 0x7b, 0x47, 0x67, 0x43, 0xbb, 0xaa, 0x82, 0x8d, 0xc6, 0xa7, 0xd8, 0xb3, 0xb7, 0x84,
+	0x72, 0x80, 0xbb, 0xfd, 0x81, 0x77, 0x60, 0xd7, 0x5c, 0xdd, 0xd6, 0xf6, 0x5d, 0x70, 0xa6, 0xe9,
+	0xac, 0xbd, 0x48, 0xe7, 0x7c, 0x3e, 0x21, 0xed, 0xeb, 0x88, 0x13, 0xc6, 0xd4, 0x3f, 0xe6, 0x49,
+	0x55, 0xfe, 0xbc, 0xf8, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x64, 0x10, 0x66, 0x7e, 0x4a, 0x0b, 0x00,
+	0x00,
 }
@@ -82,6 +82,21 @@ type Session struct {
 	// single_db specifies if the transaction should be restricted
 	// to a single database.
 	SingleDb bool `protobuf:"varint,3,opt,name=single_db,json=singleDb" json:"single_db,omitempty"`
+	// read_after_write makes vtgate remember the replication position
+	// of the masters it wrote to, and wait for it on subsequent reads
+	// sent to replica or rdonly tablets of the same shards.
+	// Atomic (2PC) commits don't record positions, and streaming
+	// queries, which don't carry a session, never wait.
+	ReadAfterWrite bool `protobuf:"varint,4,opt,name=read_after_write,json=readAfterWrite" json:"read_after_write,omitempty"`
+	// shard_positions is maintained by vtgate if read_after_write is set.
+	ShardPositions []*Session_ShardPosition `protobuf:"bytes,5,rep,name=shard_positions,json=shardPositions" json:"shard_positions,omitempty"`
+	// transaction_isolation is used to begin the transaction
+	// on every shard that joins it.
+	TransactionIsolation query.ExecuteOptions_TransactionIsolation `protobuf:"varint,6,opt,name=transaction_isolation,json=transactionIsolation,enum=query.ExecuteOptions_TransactionIsolation" json:"transaction_isolation,omitempty"`
+	// savepoints is the list of active savepoints, in the order
+	// they were set. vtgate sets them on the shards that join the
+	// transaction after they were created.
+	Savepoints []string `protobuf:"bytes,7,rep,name=savepoints" json:"savepoints,omitempty"`
 }
 
 func (m *Session) Reset()                    { *m = Session{} }
@@ -96,6 +111,13 @@ func (m *Session) GetShardSessions() []*Session_ShardSession {
 	return nil
 }
 
+func (m *Session) GetShardPositions() []*Session_ShardPosition {
+	if m != nil {
+		return m.ShardPositions
+	}
+	return nil
+}
+
 type Session_ShardSession struct {
 	Target        *query.Target `protobuf:"bytes,1,opt,name=target" json:"target,omitempty"`
 	TransactionId int64         `protobuf:"varint,2,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
@@ -113,6 +135,18 @@ func (m *Session_ShardSession) GetTarget() *query.Target {
 	return nil
 }
 
+type Session_ShardPosition struct {
+	Keyspace string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	Shard    string `protobuf:"bytes,2,opt,name=shard" json:"shard,omitempty"`
+	// position is the master position after the last write.
+	Position string `protobuf:"bytes,3,opt,name=position" json:"position,omitempty"`
+}
+
+func (m *Session_ShardPosition) Reset()                    { *m = Session_ShardPosition{} }
+func (m *Session_ShardPosition) String() string            { return proto.CompactTextString(m) }
+func (*Session_ShardPosition) ProtoMessage()               {}
+func (*Session_ShardPosition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 1} }
+
 // ExecuteRequest is the payload to Execute.
 type ExecuteRequest struct {
 	// caller_id identifies the caller. This is the effective caller ID,
@@ -1208,6 +1242,13 @@ type BeginRequest struct {
 	// single_db specifies if the transaction should be restricted
 	// to a single database.
 	SingleDb bool `protobuf:"varint,2,opt,name=single_db,json=singleDb" json:"single_db,omitempty"`
+	// transaction_isolation is the isolation level of the transaction.
+	TransactionIsolation query.ExecuteOptions_TransactionIsolation `protobuf:"varint,3,opt,name=transaction_isolation,json=transactionIsolation,enum=query.ExecuteOptions_TransactionIsolation" json:"transaction_isolation,omitempty"`
+	// read_after_write is copied to the returned session. The session
+	// returned by Commit then carries the positions of the committed
+	// writes, and reads sent with it to replica or rdonly tablets
+	// wait for them.
+	ReadAfterWrite bool `protobuf:"varint,4,opt,name=read_after_write,json=readAfterWrite" json:"read_after_write,omitempty"`
 }
 
 func (m *BeginRequest) Reset()                    { *m = BeginRequest{} }
@@ -1273,6 +1314,10 @@ func (m *CommitRequest) GetSession() *Session {
 
 // CommitResponse is the returned value from Commit.
 type CommitResponse struct {
+	// session is the committed session. It is only useful if
+	// read_after_write was set, as it then carries the positions
+	// of the committed writes.
+	Session *Session `protobuf:"bytes,1,opt,name=session" json:"session,omitempty"`
 }
 
 func (m *CommitResponse) Reset()                    { *m = CommitResponse{} }
@@ -1280,6 +1325,13 @@ func (m *CommitResponse) String() string            { return proto.CompactTextSt
 func (*CommitResponse) ProtoMessage()               {}
 func (*CommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
+func (m *CommitResponse) GetSession() *Session {
+	if m != nil {
+		return m.Session
+	}
+	return nil
+}
+
 // RollbackRequest is the payload to Rollback.
 type RollbackRequest struct {
 	// caller_id identifies the caller. This is the effective caller ID,
@@ -1470,7 +1522,7 @@ type SplitQueryRequest struct {
 	// The algorithm to use to split the query. The split algorithm is performed
 	// on each database shard in parallel. The lists of query-parts generated
 	// by the shards are merged and returned to the caller.
-	// Two algorithms are supported:
+	// Three algorithms are supported:
 	//  EQUAL_SPLITS
 	//    If this algorithm is selected then only the first 'split_column' given
 	//    is used (or the first primary key column if the 'split_column' field is
@@ -1496,6 +1548,14 @@ type SplitQueryRequest struct {
 	//    located between two successive boundary rows.
 	//    This algorithm supports multiple split_column's of any type,
 	//    but is slower than EQUAL_SPLITS.
+	//  SAMPLING
+	//    If this algorithm is used then the split_column must be a prefix of
+	//    the primary key columns (in order).
+	//    Like FULL_SCAN, this algorithm walks the index of the split columns
+	//    to get boundary rows that are num_rows_per_query_part apart, but it
+	//    stops after split_count-1 boundary rows: the last query-part gets
+	//    the remaining rows.
+	//    This algorithm supports multiple split_column's of any type.
 	Algorithm query.SplitQueryRequest_Algorithm `protobuf:"varint,7,opt,name=algorithm,enum=query.SplitQueryRequest_Algorithm" json:"algorithm,omitempty"`
 	// TODO(erez): This field is no longer used by the server code.
 	// Remove this field after this new server code is released to prod.
@@ -1720,6 +1780,7 @@ func (m *UpdateStreamResponse) GetEvent() *query.StreamEvent {
 func init() {
 	proto.RegisterType((*Session)(nil), "vtgate.Session")
 	proto.RegisterType((*Session_ShardSession)(nil), "vtgate.Session.ShardSession")
+	proto.RegisterType((*Session_ShardPosition)(nil), "vtgate.Session.ShardPosition")
 	proto.RegisterType((*ExecuteRequest)(nil), "vtgate.ExecuteRequest")
 	proto.RegisterType((*ExecuteResponse)(nil), "vtgate.ExecuteResponse")
 	proto.RegisterType((*ExecuteShardsRequest)(nil), "vtgate.ExecuteShardsRequest")
@@ -1771,109 +1832,117 @@ func init() {
 func init() { proto.RegisterFile("vtgate.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1652 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x5a, 0xcb, 0x6e, 0x1b, 0x47,
-	0x16, 0x45, 0x77, 0xf3, 0x79, 0x49, 0x51, 0x52, 0x89, 0x92, 0x69, 0x5a, 0x63, 0xc9, 0x8d, 0x11,
-	0x4c, 0x8f, 0x05, 0x7a, 0x2c, 0xcf, 0x0b, 0xb3, 0x99, 0xb1, 0x64, 0x61, 0x20, 0x78, 0xec, 0x38,
-	0x25, 0xc5, 0x49, 0x80, 0x18, 0x8d, 0x16, 0x59, 0x90, 0x3a, 0x24, 0xbb, 0xe9, 0xae, 0x6a, 0x3a,
-	0xca, 0x22, 0xf0, 0x1f, 0x18, 0x59, 0x04, 0x08, 0x8c, 0x00, 0x41, 0x80, 0x6c, 0xb3, 0x0d, 0x10,
-	0x64, 0x93, 0x45, 0x90, 0x7c, 0x42, 0xf6, 0xf9, 0x81, 0x3c, 0xbe, 0x20, 0xe8, 0xaa, 0xea, 0x07,
-	0x5b, 0x22, 0x45, 0x51, 0xa2, 0x41, 0xaf, 0x54, 0xcf, 0x5b, 0xa7, 0xce, 0x3d, 0x75, 0xeb, 0xb2,
-	0x5a, 0x50, 0xec, 0xb1, 0x03, 0x93, 0x91, 0x7a, 0xd7, 0x75, 0x98, 0x83, 0x32, 0xa2, 0x56, 0x2d,
-	0x3c, 0xf5, 0x88, 0x7b, 0x24, 0x1a, 0xab, 0x25, 0xe6, 0x74, 0x9d, 0xa6, 0xc9, 0x4c, 0x59, 0x2f,
-	0xf4, 0x98, 0xdb, 0x6d, 0x88, 0x8a, 0xfe, 0xab, 0x02, 0xd9, 0x5d, 0x42, 0xa9, 0xe5, 0xd8, 0x68,
-	0x0d, 0x4a, 0x96, 0x6d, 0x30, 0xd7, 0xb4, 0xa9, 0xd9, 0x60, 0x96, 0x63, 0x57, 0x94, 0x55, 0xa5,
-	0x96, 0xc3, 0x33, 0x96, 0xbd, 0x17, 0x35, 0xa2, 0x2d, 0x28, 0xd1, 0x43, 0xd3, 0x6d, 0x1a, 0x54,
-	0xcc, 0xa3, 0x15, 0x75, 0x55, 0xab, 0x15, 0x36, 0x96, 0xeb, 0x12, 0x8b, 0xb4, 0x57, 0xdf, 0xf5,
-	0x47, 0xc9, 0x0a, 0x9e, 0xa1, 0xb1, 0x1a, 0x45, 0x57, 0x20, 0x4f, 0x2d, 0xfb, 0xa0, 0x4d, 0x8c,
-	0xe6, 0x7e, 0x45, 0xe3, 0xcb, 0xe4, 0x44, 0xc3, 0xbd, 0xfd, 0xea, 0x7b, 0x50, 0x8c, 0xcf, 0x45,
-	0x6b, 0x90, 0x61, 0xa6, 0x7b, 0x40, 0x18, 0x07, 0x54, 0xd8, 0x98, 0xa9, 0x8b, 0xfd, 0xed, 0xf1,
-	0x46, 0x2c, 0x3b, 0x7d, 0xfc, 0x31, 0xf0, 0x86, 0xd5, 0xac, 0xa8, 0xab, 0x4a, 0x4d, 0xc3, 0x33,
-	0xb1, 0xd6, 0x9d, 0xa6, 0xfe, 0xbd, 0x0a, 0xa5, 0xed, 0x0f, 0x48, 0xc3, 0x63, 0x04, 0x93, 0xa7,
-	0x1e, 0xa1, 0x0c, 0xad, 0x43, 0xbe, 0x61, 0xb6, 0xdb, 0xc4, 0xf5, 0x27, 0x89, 0x35, 0x66, 0xeb,
-	0x82, 0xa6, 0x2d, 0xde, 0xbe, 0x73, 0x0f, 0xe7, 0xc4, 0x88, 0x9d, 0x26, 0xba, 0x01, 0x59, 0xb9,
-	0x75, 0xbe, 0x80, 0x18, 0x1b, 0xdf, 0x39, 0x0e, 0xfa, 0xd1, 0x75, 0x48, 0x73, 0xa8, 0x7c, 0x8b,
-	0x85, 0x8d, 0x79, 0x09, 0x7c, 0xd3, 0xf1, 0xec, 0xe6, 0x9b, 0x7e, 0x11, 0x8b, 0x7e, 0xf4, 0x77,
-	0x28, 0x30, 0x73, 0xbf, 0x4d, 0x98, 0xc1, 0x8e, 0xba, 0xa4, 0x92, 0x5a, 0x55, 0x6a, 0xa5, 0x8d,
-	0x72, 0x3d, 0x74, 0xdd, 0x1e, 0xef, 0xdc, 0x3b, 0xea, 0x12, 0x0c, 0x2c, 0x2c, 0xa3, 0x75, 0x40,
-	0xb6, 0xc3, 0x8c, 0x84, 0xdb, 0xd2, 0x9c, 0xcf, 0x39, 0xdb, 0x61, 0x3b, 0x7d, 0x9e, 0xab, 0x42,
-	0xae, 0x45, 0x8e, 0x68, 0xd7, 0x6c, 0x90, 0x4a, 0x66, 0x55, 0xa9, 0xe5, 0x71, 0x58, 0x47, 0xb7,
-	0x20, 0xeb, 0x74, 0x19, 0x77, 0x67, 0x96, 0x63, 0x5d, 0x94, 0x58, 0x25, 0x55, 0x6f, 0x88, 0x4e,
-	0x1c, 0x8c, 0xd2, 0x5f, 0x28, 0x30, 0x1b, 0xd2, 0x48, 0xbb, 0x8e, 0x4d, 0x09, 0x5a, 0x83, 0x34,
-	0x71, 0x5d, 0xc7, 0x4d, 0x70, 0x88, 0x1f, 0x6d, 0x6d, 0xfb, 0xcd, 0x58, 0xf4, 0x9e, 0x85, 0xc0,
-	0xbf, 0x40, 0xc6, 0x25, 0xd4, 0x6b, 0x33, 0xc9, 0x20, 0x92, 0xa8, 0x04, 0x79, 0xbc, 0x07, 0xcb,
-	0x11, 0xfa, 0xcf, 0x2a, 0x94, 0x25, 0x22, 0x2e, 0x1f, 0x3a, 0x3d, 0xee, 0x8d, 0x33, 0x9f, 0x4a,
-	0x30, 0xbf, 0x04, 0x19, 0x7e, 0x36, 0x68, 0x25, 0xbd, 0xaa, 0xd5, 0xf2, 0x58, 0xd6, 0x92, 0x92,
-	0xc8, 0x9c, 0x4b, 0x12, 0xd9, 0x01, 0x92, 0x88, 0xb9, 0x3d, 0x37, 0x92, 0xdb, 0x3f, 0x51, 0x60,
-	0x31, 0x41, 0xf2, 0x54, 0x38, 0xff, 0x77, 0x15, 0x2e, 0x4b, 0x5c, 0xf7, 0x25, 0xb3, 0x3b, 0xaf,
-	0x8b, 0x02, 0xae, 0x41, 0x31, 0x28, 0x1b, 0x96, 0xd4, 0x41, 0x11, 0x17, 0x5a, 0xd1, 0x3e, 0xa6,
-	0x54, 0x0c, 0x2f, 0x15, 0xa8, 0x9e, 0x44, 0xfa, 0x54, 0x28, 0xe2, 0xb9, 0x06, 0x97, 0x22, 0x70,
-	0xd8, 0xb4, 0x0f, 0xc8, 0x6b, 0xa2, 0x87, 0xdb, 0x00, 0x2d, 0x72, 0x64, 0xb8, 0x1c, 0x32, 0x57,
-	0x83, 0xbf, 0xd3, 0xd0, 0xd7, 0xc1, 0x6e, 0x70, 0xbe, 0x15, 0xec, 0x6b, 0x4a, 0xf5, 0xf1, 0xa9,
-	0x02, 0x95, 0xe3, 0x2e, 0x98, 0x0a, 0x75, 0x7c, 0x93, 0x0a, 0xd5, 0xb1, 0x6d, 0x33, 0x8b, 0x1d,
-	0xbd, 0x36, 0xd1, 0x62, 0x1d, 0x10, 0xe1, 0x88, 0x8d, 0x86, 0xd3, 0xf6, 0x3a, 0xb6, 0x61, 0x9b,
-	0x1d, 0xc2, 0xef, 0xfc, 0x3c, 0x9e, 0x13, 0x3d, 0x5b, 0xbc, 0xe3, 0xa1, 0xd9, 0x21, 0xe8, 0x1d,
-	0x58, 0x90, 0xa3, 0xfb, 0x42, 0x4c, 0x86, 0x8b, 0xaa, 0x16, 0x20, 0x1d, 0xc0, 0x44, 0x3d, 0x68,
-	0xc0, 0xf3, 0xc2, 0xc8, 0xfd, 0xc1, 0x21, 0x29, 0x7b, 0x2e, 0xc9, 0xe5, 0x4e, 0x97, 0x5c, 0x7e,
-	0x14, 0xc9, 0x55, 0xf7, 0x21, 0x17, 0x80, 0x46, 0x2b, 0x90, 0xe2, 0xd0, 0x14, 0x0e, 0xad, 0x10,
-	0x64, 0x8d, 0x3e, 0x22, 0xde, 0x81, 0xca, 0x90, 0xee, 0x99, 0x6d, 0x8f, 0x70, 0xc7, 0x15, 0xb1,
-	0xa8, 0xa0, 0x15, 0x28, 0xc4, 0xb8, 0xe2, 0xbe, 0x2a, 0x62, 0x88, 0xa2, 0x71, 0x5c, 0xd6, 0x31,
-	0xc6, 0xa6, 0x42, 0xd6, 0x3f, 0xa8, 0xb0, 0x20, 0xa1, 0x6d, 0x9a, 0xac, 0x71, 0x38, 0x71, 0x49,
-	0xdf, 0x84, 0xac, 0x8f, 0xc6, 0x22, 0xb4, 0xa2, 0x71, 0x4d, 0x9d, 0x20, 0xea, 0x60, 0xc4, 0xb8,
-	0x59, 0xee, 0x1a, 0x94, 0x4c, 0x7a, 0x42, 0x86, 0x3b, 0x63, 0xd2, 0x89, 0xa5, 0xb7, 0x2f, 0x95,
-	0x30, 0x99, 0x94, 0x44, 0x4e, 0xcc, 0xbf, 0x7f, 0x85, 0xac, 0xf0, 0x5e, 0x40, 0xe1, 0x92, 0xc4,
-	0x26, 0x7c, 0xfb, 0xb6, 0xc5, 0x0e, 0x85, 0xe9, 0x60, 0x98, 0x6e, 0xc3, 0x2c, 0xa7, 0x97, 0x67,
-	0x60, 0x9c, 0xe3, 0x28, 0xb4, 0x28, 0x67, 0x08, 0x2d, 0xea, 0xc0, 0x54, 0x54, 0x8b, 0xa7, 0xa2,
-	0xfa, 0xd7, 0x51, 0x72, 0xc5, 0xc9, 0x78, 0x45, 0xe9, 0xf5, 0xed, 0xa4, 0xb6, 0x2e, 0x05, 0x43,
-	0x13, 0xbb, 0x7f, 0x55, 0x0a, 0x8b, 0xa9, 0x28, 0x33, 0x92, 0x8a, 0x3e, 0x8b, 0x12, 0xa4, 0x3e,
-	0xe2, 0x26, 0xa6, 0xa5, 0xf5, 0xa4, 0x96, 0x4e, 0x0a, 0x16, 0xa1, 0x8e, 0x3e, 0x82, 0x32, 0x67,
-	0x32, 0x0a, 0xeb, 0x17, 0x28, 0xa6, 0x64, 0x56, 0xab, 0x1d, 0xcb, 0x6a, 0xf5, 0xef, 0x54, 0xb8,
-	0x1a, 0xa7, 0xe7, 0x55, 0x66, 0xee, 0xff, 0x48, 0x8a, 0x6b, 0xb9, 0x4f, 0x5c, 0x09, 0x4a, 0xa6,
-	0x56, 0x61, 0x5f, 0x28, 0xb0, 0x32, 0x90, 0xc2, 0x29, 0x91, 0xd9, 0x6f, 0x0a, 0x94, 0x77, 0x99,
-	0x4b, 0xcc, 0xce, 0xb9, 0xde, 0x5d, 0x42, 0x55, 0xaa, 0x67, 0x7b, 0x4c, 0xd1, 0x46, 0x74, 0xd1,
-	0xb0, 0xa4, 0x2b, 0xe6, 0x97, 0xf4, 0x48, 0x7e, 0xd9, 0x82, 0xc5, 0xc4, 0x96, 0xa5, 0x33, 0xa2,
-	0xdb, 0x5c, 0x39, 0xf5, 0x36, 0x7f, 0xa1, 0x42, 0xb5, 0xcf, 0xca, 0x79, 0x02, 0xef, 0xc8, 0xf4,
-	0xc5, 0x79, 0xd0, 0x06, 0xde, 0x10, 0xa9, 0x61, 0x8f, 0x15, 0xe9, 0x11, 0x29, 0x3f, 0xb3, 0xdc,
-	0x77, 0xe0, 0xca, 0x89, 0x84, 0x8c, 0x41, 0xee, 0xe7, 0x2a, 0xac, 0xf4, 0xd9, 0x3a, 0x77, 0xf4,
-	0xb9, 0x10, 0x86, 0x93, 0x61, 0x33, 0x75, 0xea, 0x63, 0xc0, 0xc4, 0xc8, 0x7e, 0x08, 0xab, 0x83,
-	0x09, 0x1a, 0x83, 0xf1, 0xaf, 0x54, 0xf8, 0x53, 0xd2, 0xe0, 0x79, 0x7e, 0x97, 0x5f, 0x08, 0xdf,
-	0xfd, 0x3f, 0xb6, 0x53, 0x63, 0xfc, 0xd8, 0x9e, 0x18, 0xff, 0xff, 0x87, 0xab, 0x83, 0xe8, 0x1a,
-	0x83, 0xfd, 0x77, 0xa1, 0xb8, 0x49, 0x0e, 0x2c, 0x7b, 0x3c, 0xae, 0xfb, 0x1e, 0xec, 0xd5, 0xfe,
-	0x07, 0x7b, 0xfd, 0xdf, 0x30, 0x23, 0x4d, 0x4b, 0x5c, 0xb1, 0xab, 0x44, 0x19, 0x7e, 0x95, 0xe8,
-	0xcf, 0x15, 0x98, 0xd9, 0x72, 0x3a, 0x1d, 0x8b, 0x4d, 0xfc, 0xca, 0x5f, 0x82, 0x8c, 0xc9, 0x9c,
-	0x8e, 0xd5, 0x90, 0x5f, 0x1c, 0x64, 0x4d, 0x9f, 0x83, 0x52, 0x80, 0x40, 0xe0, 0xd7, 0xdf, 0x87,
-	0x59, 0xec, 0xb4, 0xdb, 0xfb, 0x66, 0xa3, 0x35, 0x69, 0x54, 0x3a, 0x82, 0xb9, 0x68, 0x2d, 0xb9,
-	0xfe, 0x13, 0xb8, 0x8c, 0x09, 0x75, 0xda, 0x3d, 0x12, 0x4b, 0x0e, 0xc6, 0x43, 0x82, 0x20, 0xd5,
-	0x64, 0xf2, 0x5b, 0x48, 0x1e, 0xf3, 0xb2, 0xfe, 0xad, 0x02, 0xe5, 0x07, 0x84, 0x52, 0xf3, 0x80,
-	0x08, 0x81, 0x8d, 0x67, 0x7a, 0x58, 0xf6, 0x57, 0x86, 0x34, 0xbf, 0x1a, 0xe4, 0x79, 0x13, 0x15,
-	0x74, 0x0b, 0xf2, 0xe1, 0x61, 0xe3, 0x77, 0xec, 0xc9, 0x67, 0x2d, 0x17, 0x9c, 0x35, 0x1f, 0x7d,
-	0xec, 0x79, 0x83, 0x97, 0xf5, 0x8f, 0x15, 0x98, 0x97, 0xe8, 0xef, 0x8e, 0xeb, 0x9f, 0x61, 0xd0,
-	0x83, 0x35, 0xb5, 0x68, 0x4d, 0x74, 0x15, 0xb4, 0x20, 0x18, 0x17, 0x36, 0x8a, 0xf2, 0x94, 0x3d,
-	0x36, 0xdb, 0x1e, 0xc1, 0x7e, 0x87, 0xbe, 0x0c, 0xd5, 0x93, 0x1c, 0x26, 0xdd, 0xf9, 0x8b, 0x0a,
-	0xf3, 0xbb, 0xdd, 0xb6, 0xc5, 0xe4, 0xb9, 0xbc, 0x68, 0xc4, 0x23, 0xbf, 0x2b, 0x5d, 0x83, 0x22,
-	0xf5, 0x71, 0xc8, 0xa7, 0x23, 0x79, 0x89, 0x17, 0x78, 0x9b, 0x78, 0x34, 0x42, 0x2b, 0x50, 0x08,
-	0x86, 0x78, 0x36, 0xe3, 0xc4, 0x6b, 0x18, 0xe4, 0x08, 0xcf, 0x66, 0xe8, 0x6f, 0x70, 0xc9, 0xf6,
-	0x3a, 0x86, 0xeb, 0x3c, 0xa3, 0x46, 0x97, 0xb8, 0x06, 0xb7, 0x6c, 0x74, 0x4d, 0x97, 0xf1, 0xb0,
-	0xa6, 0xe1, 0x05, 0xdb, 0xeb, 0x60, 0xe7, 0x19, 0x7d, 0x44, 0x5c, 0xbe, 0xf8, 0x23, 0xd3, 0x65,
-	0xe8, 0xbf, 0x90, 0x37, 0xdb, 0x07, 0x8e, 0x6b, 0xb1, 0xc3, 0x8e, 0x7c, 0x2b, 0xd2, 0x25, 0xcc,
-	0x63, 0xcc, 0xd4, 0xef, 0x06, 0x23, 0x71, 0x34, 0x09, 0xdd, 0x04, 0xe4, 0x51, 0x62, 0x08, 0x70,
-	0x62, 0xd1, 0xde, 0x86, 0x7c, 0x38, 0x9a, 0xf5, 0x28, 0x89, 0xcc, 0x3c, 0xde, 0xd0, 0x7f, 0xd4,
-	0x00, 0xc5, 0xed, 0xca, 0xb8, 0xf4, 0x4f, 0xc8, 0xf0, 0xf9, 0xb4, 0xa2, 0x70, 0x4f, 0xae, 0x84,
-	0xa7, 0xf2, 0xd8, 0xd8, 0xba, 0x0f, 0x1b, 0xcb, 0xe1, 0xd5, 0x27, 0x50, 0x0c, 0xd4, 0xc9, 0xb7,
-	0x13, 0xf7, 0x86, 0x32, 0xf4, 0x46, 0x51, 0x47, 0xb8, 0x51, 0xaa, 0xff, 0x81, 0x3c, 0xcf, 0x64,
-	0x4e, 0xb5, 0x1d, 0xe5, 0x5f, 0x6a, 0x3c, 0xff, 0xaa, 0xfe, 0xa4, 0x40, 0x8a, 0x4f, 0x1e, 0xf9,
-	0xa7, 0xdb, 0x03, 0x28, 0x85, 0x28, 0x85, 0xf7, 0x44, 0xa0, 0xba, 0x3e, 0x84, 0x92, 0x38, 0x05,
-	0xb8, 0xd8, 0x8a, 0x13, 0xb2, 0x05, 0x20, 0xbe, 0x0a, 0x73, 0x53, 0x42, 0x87, 0x7f, 0x1e, 0x62,
-	0x2a, 0xdc, 0x2e, 0xce, 0xd3, 0x70, 0xe7, 0x08, 0x52, 0xd4, 0xfa, 0x50, 0x44, 0x06, 0x0d, 0xf3,
-	0xb2, 0x7e, 0x07, 0x16, 0xff, 0x47, 0xd8, 0xae, 0xdb, 0x0b, 0xb2, 0x8f, 0xe0, 0xf8, 0x0c, 0xa1,
-	0x49, 0xc7, 0xb0, 0x94, 0x9c, 0x24, 0x15, 0xf0, 0x2f, 0x28, 0x52, 0xb7, 0x67, 0xf4, 0xcd, 0xf4,
-	0x6f, 0xe2, 0xd0, 0x3d, 0xf1, 0x49, 0x05, 0x1a, 0x55, 0xf4, 0x2f, 0x55, 0x58, 0x78, 0xab, 0xdb,
-	0x34, 0xd9, 0xb4, 0xc7, 0xcc, 0x31, 0xd3, 0x93, 0x65, 0xc8, 0x33, 0xab, 0x43, 0x28, 0x33, 0x3b,
-	0x5d, 0x79, 0x92, 0xa3, 0x06, 0x5f, 0x57, 0xa4, 0x47, 0x6c, 0x26, 0x9f, 0xcf, 0x02, 0x5d, 0x6d,
-	0xfb, 0x6d, 0x7b, 0x4e, 0x8b, 0xd8, 0x58, 0xf4, 0xeb, 0x2d, 0x28, 0xf7, 0xb3, 0x24, 0x89, 0xaf,
-	0x05, 0x06, 0xfa, 0x33, 0x15, 0x99, 0xe0, 0xf8, 0x3d, 0xd2, 0x02, 0xba, 0x01, 0x73, 0x7e, 0xca,
-	0xd2, 0x21, 0x46, 0x84, 0x47, 0x7c, 0xc9, 0x9f, 0x15, 0xed, 0x7b, 0x41, 0xf3, 0x66, 0x15, 0x2a,
-	0x0d, 0xa7, 0x53, 0x3f, 0x72, 0x3c, 0xe6, 0xed, 0x93, 0x7a, 0xcf, 0x62, 0x84, 0x52, 0xf1, 0xaf,
-	0x0d, 0xfb, 0x19, 0xfe, 0xe7, 0xce, 0x1f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xcc, 0x69, 0x78, 0xfc,
-	0x23, 0x21, 0x00, 0x00,
+	// 1782 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x5a, 0x5d, 0x6f, 0x1b, 0x4d,
+	0x15, 0xd6, 0xee, 0x3a, 0xfe, 0x38, 0xfe, 0x48, 0x32, 0x71, 0x52, 0xbf, 0x7e, 0xd3, 0x24, 0x5d,
+	0x11, 0xd5, 0x6d, 0x23, 0x97, 0xba, 0x7c, 0x09, 0x2e, 0xa0, 0x49, 0x03, 0x8a, 0x4a, 0x4b, 0x98,
+	0x84, 0x96, 0x0b, 0xaa, 0xd5, 0xc6, 0x1e, 0x92, 0xc5, 0xf6, 0xae, 0xbb, 0x33, 0xeb, 0x12, 0x2e,
+	0x50, 0xff, 0x41, 0xc5, 0x05, 0x12, 0xaa, 0x90, 0x10, 0x12, 0xb7, 0xdc, 0x22, 0x21, 0x6e, 0x10,
+	0x42, 0xf0, 0x13, 0xb8, 0x47, 0xdc, 0x23, 0xf8, 0x05, 0xaf, 0x76, 0x66, 0xf6, 0xc3, 0x1b, 0xdb,
+	0x71, 0x9c, 0xb8, 0x72, 0xaf, 0xb2, 0x73, 0x66, 0xe6, 0xec, 0x33, 0xcf, 0x79, 0xf6, 0xcc, 0xf1,
+	0x4c, 0xa0, 0xd0, 0x67, 0xa7, 0x26, 0x23, 0xf5, 0x9e, 0xeb, 0x30, 0x07, 0xa5, 0x45, 0xab, 0x9a,
+	0x7f, 0xe3, 0x11, 0xf7, 0x5c, 0x18, 0xab, 0x25, 0xe6, 0xf4, 0x9c, 0x96, 0xc9, 0x4c, 0xd9, 0xce,
+	0xf7, 0x99, 0xdb, 0x6b, 0x8a, 0x86, 0xfe, 0xb7, 0x14, 0x64, 0x8e, 0x08, 0xa5, 0x96, 0x63, 0xa3,
+	0x6d, 0x28, 0x59, 0xb6, 0xc1, 0x5c, 0xd3, 0xa6, 0x66, 0x93, 0x59, 0x8e, 0x5d, 0x51, 0xb6, 0x94,
+	0x5a, 0x16, 0x17, 0x2d, 0xfb, 0x38, 0x32, 0xa2, 0x3d, 0x28, 0xd1, 0x33, 0xd3, 0x6d, 0x19, 0x54,
+	0xcc, 0xa3, 0x15, 0x75, 0x4b, 0xab, 0xe5, 0x1b, 0xeb, 0x75, 0x89, 0x45, 0xfa, 0xab, 0x1f, 0xf9,
+	0xa3, 0x64, 0x03, 0x17, 0x69, 0xac, 0x45, 0xd1, 0xe7, 0x90, 0xa3, 0x96, 0x7d, 0xda, 0x21, 0x46,
+	0xeb, 0xa4, 0xa2, 0xf1, 0xd7, 0x64, 0x85, 0xe1, 0xe9, 0x09, 0xaa, 0xc1, 0x92, 0x4b, 0xcc, 0x96,
+	0x61, 0xfe, 0x94, 0x11, 0xd7, 0x78, 0xeb, 0x5a, 0x8c, 0x54, 0x52, 0x7c, 0x4c, 0xc9, 0xb7, 0x3f,
+	0xf1, 0xcd, 0xaf, 0x7c, 0x2b, 0xfa, 0x2e, 0x2c, 0x0a, 0x2c, 0x3d, 0x87, 0x5a, 0x8c, 0x83, 0x59,
+	0xe0, 0x60, 0x6e, 0x0f, 0x05, 0x73, 0x28, 0x47, 0x61, 0xb1, 0x82, 0xa0, 0x49, 0x91, 0x01, 0xab,
+	0xb1, 0x75, 0x1b, 0x16, 0x75, 0x3a, 0x26, 0x67, 0x20, 0xbd, 0xa5, 0xd4, 0x4a, 0x8d, 0xfb, 0x75,
+	0x41, 0xe8, 0xfe, 0xcf, 0x49, 0xd3, 0x63, 0xe4, 0x07, 0x3d, 0x3e, 0xab, 0x1e, 0x63, 0xe5, 0x20,
+	0x98, 0x81, 0xcb, 0x6c, 0x88, 0x15, 0x6d, 0x00, 0x50, 0xb3, 0x4f, 0x7a, 0x8e, 0x65, 0x33, 0x5a,
+	0xc9, 0x6c, 0x69, 0xb5, 0x1c, 0x8e, 0x59, 0xaa, 0x3f, 0x81, 0x42, 0x9c, 0x2e, 0xb4, 0x0d, 0x69,
+	0x66, 0xba, 0xa7, 0x84, 0xf1, 0x18, 0xe4, 0x1b, 0x45, 0x89, 0xe0, 0x98, 0x1b, 0xb1, 0xec, 0xf4,
+	0x43, 0x36, 0x80, 0xbb, 0x55, 0x51, 0xb7, 0x94, 0x9a, 0x86, 0x8b, 0x71, 0x10, 0xad, 0xea, 0x6b,
+	0x28, 0x0e, 0xac, 0x1f, 0x55, 0x21, 0xdb, 0x26, 0xe7, 0xb4, 0x67, 0x36, 0x09, 0x7f, 0x41, 0x0e,
+	0x87, 0x6d, 0x54, 0x86, 0x05, 0xce, 0x0e, 0x77, 0x95, 0xc3, 0xa2, 0xe1, 0xcf, 0x08, 0x38, 0xe6,
+	0xf1, 0xca, 0xe1, 0xb0, 0xad, 0xff, 0x5d, 0x85, 0x92, 0xa4, 0x06, 0x93, 0x37, 0x1e, 0xa1, 0x0c,
+	0xed, 0x40, 0xae, 0x69, 0x76, 0x3a, 0xc4, 0xf5, 0x31, 0x89, 0x25, 0x2c, 0xd6, 0x85, 0xf0, 0xf6,
+	0xb8, 0xfd, 0xe0, 0x29, 0xce, 0x8a, 0x11, 0x07, 0x2d, 0x74, 0x0f, 0x32, 0x52, 0x4c, 0xfc, 0xa5,
+	0x62, 0x6c, 0x3c, 0x7c, 0x38, 0xe8, 0x47, 0x77, 0x61, 0x81, 0x33, 0xc1, 0x41, 0xe4, 0x1b, 0xcb,
+	0x92, 0x97, 0x5d, 0xc7, 0xb3, 0x5b, 0x3f, 0xf4, 0x1f, 0xb1, 0xe8, 0x47, 0x5f, 0x85, 0x3c, 0x33,
+	0x4f, 0x3a, 0x84, 0x19, 0xec, 0xbc, 0x27, 0xf4, 0x53, 0x6a, 0x94, 0xeb, 0xe1, 0xc7, 0x70, 0xcc,
+	0x3b, 0x8f, 0xcf, 0x7b, 0x04, 0x03, 0x0b, 0x9f, 0xd1, 0x0e, 0x20, 0xdb, 0x61, 0x46, 0xe2, 0x43,
+	0x58, 0xe0, 0xea, 0x5b, 0xb2, 0x1d, 0x76, 0x30, 0xf0, 0x2d, 0xc4, 0x79, 0x4c, 0x27, 0x78, 0x7c,
+	0x08, 0x19, 0x47, 0x08, 0xa5, 0x92, 0xe1, 0x58, 0x57, 0x87, 0xaa, 0x08, 0x07, 0xa3, 0xf4, 0xf7,
+	0x0a, 0x2c, 0x86, 0x34, 0xd2, 0x9e, 0x63, 0x53, 0x82, 0xb6, 0x61, 0x81, 0xb8, 0xae, 0xe3, 0x26,
+	0x38, 0xc4, 0x87, 0x7b, 0xfb, 0xbe, 0x19, 0x8b, 0xde, 0xab, 0x10, 0x78, 0x1f, 0xd2, 0x2e, 0xa1,
+	0x5e, 0x87, 0x49, 0x06, 0x91, 0x44, 0x25, 0xc8, 0xe3, 0x3d, 0x58, 0x8e, 0xd0, 0xff, 0xad, 0x42,
+	0x59, 0x22, 0xe2, 0xfa, 0xa1, 0xf3, 0x13, 0xde, 0x38, 0xf3, 0xa9, 0x04, 0xf3, 0x6b, 0x90, 0xe6,
+	0xa2, 0x15, 0xc9, 0x20, 0x87, 0x65, 0x2b, 0x29, 0x89, 0xf4, 0xb5, 0x24, 0x91, 0x19, 0x21, 0x89,
+	0x58, 0xd8, 0xb3, 0x13, 0x85, 0xfd, 0xd7, 0x0a, 0xac, 0x26, 0x48, 0x9e, 0x8b, 0xe0, 0xff, 0x5f,
+	0x85, 0xcf, 0x24, 0xae, 0x67, 0x92, 0xd9, 0x83, 0x4f, 0x45, 0x01, 0x77, 0xa0, 0x10, 0x3c, 0x1b,
+	0x96, 0xd4, 0x41, 0x01, 0xe7, 0xdb, 0xd1, 0x3a, 0xe6, 0x54, 0x0c, 0x1f, 0x14, 0xa8, 0x0e, 0x23,
+	0x7d, 0x2e, 0x14, 0xf1, 0x4e, 0x83, 0x5b, 0x11, 0x38, 0x6c, 0xda, 0xa7, 0xe4, 0x13, 0xd1, 0xc3,
+	0x23, 0x80, 0x36, 0x39, 0x37, 0x5c, 0x0e, 0x59, 0x96, 0x08, 0x28, 0x8a, 0x75, 0xb0, 0x1a, 0x9c,
+	0x6b, 0x07, 0xeb, 0x9a, 0x53, 0x7d, 0xfc, 0x46, 0x81, 0xca, 0xc5, 0x10, 0xcc, 0x85, 0x3a, 0xfe,
+	0x9c, 0x0a, 0xd5, 0xb1, 0x6f, 0x33, 0x8b, 0x9d, 0x7f, 0x32, 0xd9, 0x62, 0x07, 0x10, 0xe1, 0x88,
+	0x8d, 0xa6, 0xd3, 0xf1, 0xba, 0xb6, 0x61, 0x9b, 0x5d, 0xc2, 0xf7, 0xfc, 0x1c, 0x5e, 0x12, 0x3d,
+	0x7b, 0xbc, 0xe3, 0x85, 0xd9, 0x25, 0xe8, 0xc7, 0xb0, 0x22, 0x47, 0x0f, 0xa4, 0x98, 0x34, 0x17,
+	0x55, 0x2d, 0x40, 0x3a, 0x82, 0x89, 0x7a, 0x60, 0xc0, 0xcb, 0xc2, 0xc9, 0xb3, 0xd1, 0x29, 0x29,
+	0x73, 0x2d, 0xc9, 0x65, 0x2f, 0x97, 0x5c, 0x6e, 0x12, 0xc9, 0x55, 0x4f, 0x20, 0x1b, 0x80, 0x46,
+	0x9b, 0x90, 0xe2, 0xd0, 0x14, 0x0e, 0x2d, 0x1f, 0x14, 0xa5, 0x3e, 0x22, 0xde, 0xe1, 0x17, 0x8f,
+	0x7d, 0xb3, 0xe3, 0x11, 0x1e, 0xb8, 0x02, 0x16, 0x0d, 0xb4, 0x09, 0xf9, 0x18, 0x57, 0x3c, 0x56,
+	0x05, 0x0c, 0x51, 0x36, 0x8e, 0xcb, 0x3a, 0xc6, 0xd8, 0x5c, 0xc8, 0xfa, 0x1f, 0x2a, 0xac, 0x48,
+	0x68, 0xbb, 0x26, 0x6b, 0x9e, 0xcd, 0x5c, 0xd2, 0x0f, 0x20, 0xe3, 0xa3, 0xb1, 0x08, 0xad, 0x68,
+	0x5c, 0x53, 0x43, 0x44, 0x1d, 0x8c, 0x98, 0xb6, 0xca, 0xdd, 0x86, 0x92, 0x49, 0x87, 0x54, 0xb8,
+	0x45, 0x93, 0xce, 0xac, 0xbc, 0xfd, 0xa0, 0x84, 0xc5, 0xa4, 0x24, 0x72, 0x66, 0xf1, 0xfd, 0x32,
+	0x64, 0x44, 0xf4, 0x02, 0x0a, 0xd7, 0x24, 0x36, 0x11, 0xdb, 0x57, 0x16, 0x3b, 0x13, 0xae, 0x83,
+	0x61, 0xba, 0x0d, 0x8b, 0x9c, 0x5e, 0x5e, 0x81, 0x71, 0x8e, 0xa3, 0xd4, 0xa2, 0x5c, 0x21, 0xb5,
+	0xa8, 0x23, 0x4b, 0x51, 0x2d, 0x5e, 0x8a, 0xea, 0x7f, 0x8a, 0x8a, 0x2b, 0x4e, 0xc6, 0x47, 0x2a,
+	0xaf, 0x1f, 0x25, 0xb5, 0x75, 0x2b, 0x18, 0x9a, 0x58, 0xfd, 0xc7, 0x52, 0x58, 0x4c, 0x45, 0xe9,
+	0x89, 0x54, 0xf4, 0xdb, 0xa8, 0x40, 0x1a, 0x20, 0x6e, 0x66, 0x5a, 0xda, 0x49, 0x6a, 0x69, 0x58,
+	0xb2, 0x08, 0x75, 0xf4, 0x4b, 0x28, 0x73, 0x26, 0xa3, 0xb4, 0x7e, 0x83, 0x62, 0x4a, 0x56, 0xb5,
+	0xda, 0x85, 0xaa, 0x56, 0xff, 0xab, 0x0a, 0x1b, 0x71, 0x7a, 0x3e, 0x66, 0xe5, 0xfe, 0xb5, 0xa4,
+	0xb8, 0xd6, 0x07, 0xc4, 0x95, 0xa0, 0x64, 0x6e, 0x15, 0xf6, 0x7b, 0x05, 0x36, 0x47, 0x52, 0x38,
+	0x27, 0x32, 0xfb, 0x9f, 0x02, 0xe5, 0x23, 0xe6, 0x12, 0xb3, 0x7b, 0xad, 0x73, 0x97, 0x50, 0x95,
+	0xea, 0xd5, 0x0e, 0x53, 0xb4, 0x09, 0x43, 0x34, 0xae, 0xe8, 0x8a, 0xc5, 0x65, 0x61, 0xa2, 0xb8,
+	0xec, 0xc1, 0x6a, 0x62, 0xc9, 0x32, 0x18, 0xd1, 0x6e, 0xae, 0x5c, 0xba, 0x9b, 0xbf, 0x57, 0xa1,
+	0x3a, 0xe0, 0xe5, 0x3a, 0x89, 0x77, 0x62, 0xfa, 0xe2, 0x3c, 0x68, 0x23, 0x77, 0x88, 0xd4, 0xb8,
+	0xc3, 0x8a, 0x85, 0x09, 0x29, 0xbf, 0xb2, 0xdc, 0x0f, 0xe0, 0xf3, 0xa1, 0x84, 0x4c, 0x41, 0xee,
+	0xef, 0x54, 0xd8, 0x1c, 0xf0, 0x75, 0xed, 0xec, 0x73, 0x23, 0x0c, 0x27, 0xd3, 0x66, 0xea, 0xd2,
+	0xc3, 0x80, 0x99, 0x91, 0xfd, 0x02, 0xb6, 0x46, 0x13, 0x34, 0x05, 0xe3, 0x7f, 0x54, 0xe1, 0x76,
+	0xd2, 0xe1, 0x75, 0x7e, 0x97, 0xdf, 0x08, 0xdf, 0x83, 0x3f, 0xb6, 0x53, 0x53, 0xfc, 0xd8, 0x9e,
+	0x19, 0xff, 0xdf, 0x87, 0x8d, 0x51, 0x74, 0x4d, 0xc1, 0xfe, 0x7f, 0x14, 0x28, 0xec, 0x92, 0x53,
+	0xcb, 0x9e, 0x8e, 0xec, 0x81, 0x3b, 0x10, 0x35, 0x71, 0x07, 0x32, 0xf2, 0x46, 0x42, 0xbb, 0xa1,
+	0x1b, 0x89, 0x89, 0x2f, 0x59, 0xf4, 0x6f, 0x42, 0x51, 0xae, 0x52, 0x72, 0x14, 0xdb, 0xd6, 0x94,
+	0xf1, 0xdb, 0x9a, 0xfe, 0x4e, 0x81, 0xe2, 0x9e, 0xd3, 0xed, 0x5a, 0x6c, 0xe6, 0xe5, 0xc7, 0x1a,
+	0xa4, 0x4d, 0xe6, 0x74, 0xad, 0xa6, 0xbc, 0x4f, 0x92, 0x2d, 0xfd, 0x5b, 0x50, 0x0a, 0x10, 0x5c,
+	0x1d, 0xff, 0xcf, 0x60, 0x11, 0x3b, 0x9d, 0xce, 0x89, 0xd9, 0x6c, 0xcf, 0x7a, 0x01, 0x3a, 0x82,
+	0xa5, 0xe8, 0x5d, 0x02, 0xaa, 0xfe, 0x1a, 0x3e, 0xc3, 0x84, 0x3a, 0x9d, 0x3e, 0x89, 0x85, 0x76,
+	0x3a, 0x24, 0x08, 0x52, 0x2d, 0x66, 0x05, 0xd7, 0x3a, 0xfc, 0x59, 0xff, 0x8b, 0x02, 0xe5, 0xe7,
+	0x84, 0x52, 0xf3, 0x94, 0x88, 0xef, 0x62, 0x3a, 0xd7, 0xe3, 0x8a, 0xd6, 0xf0, 0x3a, 0x49, 0x8b,
+	0x5f, 0x27, 0x3d, 0x84, 0x5c, 0x98, 0x23, 0xb8, 0xec, 0x86, 0xa7, 0x88, 0x6c, 0x90, 0x22, 0x7c,
+	0xf4, 0xb1, 0x53, 0x19, 0xfe, 0xac, 0xff, 0x4a, 0x81, 0x65, 0x89, 0xfe, 0xc9, 0xb4, 0xf1, 0x19,
+	0x07, 0x3d, 0x78, 0xa7, 0x16, 0xbd, 0x13, 0x6d, 0x80, 0x16, 0xec, 0x21, 0xf9, 0x46, 0x41, 0x7e,
+	0x85, 0x2f, 0xcd, 0x8e, 0x47, 0xb0, 0xdf, 0xa1, 0xaf, 0x43, 0x75, 0x58, 0xc0, 0x64, 0x38, 0xff,
+	0xab, 0xc2, 0xf2, 0x51, 0xaf, 0x63, 0x31, 0x99, 0x4e, 0x6e, 0x1a, 0xf1, 0xc4, 0xc7, 0x61, 0x77,
+	0xa0, 0x40, 0x7d, 0x1c, 0xf2, 0xc4, 0x4b, 0xd6, 0x1e, 0x79, 0x6e, 0x13, 0x67, 0x5d, 0x68, 0x13,
+	0xf2, 0xc1, 0x10, 0xcf, 0x66, 0x9c, 0x78, 0x0d, 0x83, 0x1c, 0xe1, 0xd9, 0x0c, 0x7d, 0x05, 0x6e,
+	0xd9, 0x5e, 0xd7, 0x70, 0x9d, 0xb7, 0xd4, 0xe8, 0x11, 0xd7, 0xe0, 0x9e, 0x8d, 0x9e, 0xe9, 0x32,
+	0x9e, 0x8d, 0x35, 0xbc, 0x62, 0x7b, 0x5d, 0xec, 0xbc, 0xa5, 0x87, 0xc4, 0xe5, 0x2f, 0x3f, 0x34,
+	0x5d, 0x86, 0xbe, 0x03, 0x39, 0xb3, 0x73, 0xea, 0xb8, 0x16, 0x3b, 0xeb, 0xca, 0x23, 0x2e, 0x5d,
+	0xc2, 0xbc, 0xc0, 0x4c, 0xfd, 0x49, 0x30, 0x12, 0x47, 0x93, 0xd0, 0x03, 0x40, 0x1e, 0x25, 0x86,
+	0x00, 0x27, 0x5e, 0xda, 0x6f, 0xc8, 0xf3, 0xae, 0x45, 0x8f, 0x92, 0xc8, 0xcd, 0xcb, 0x86, 0xfe,
+	0x4f, 0x0d, 0x50, 0xdc, 0xaf, 0x4c, 0x01, 0x5f, 0x87, 0x34, 0x9f, 0x4f, 0x2b, 0x0a, 0x8f, 0xe4,
+	0x66, 0xf8, 0x55, 0x5e, 0x18, 0x5b, 0xf7, 0x61, 0x63, 0x39, 0xbc, 0xfa, 0x1a, 0x0a, 0x81, 0x3a,
+	0xf9, 0x72, 0xc6, 0xdd, 0xa4, 0x0e, 0x6e, 0x84, 0xea, 0x04, 0x1b, 0x61, 0xf5, 0xdb, 0x90, 0x13,
+	0x37, 0xb5, 0x97, 0xf9, 0x8e, 0xca, 0x46, 0x35, 0x5e, 0x36, 0x56, 0xff, 0xa5, 0x40, 0x8a, 0x4f,
+	0x9e, 0xf8, 0x17, 0xe7, 0x73, 0x28, 0x85, 0x28, 0x45, 0xf4, 0x44, 0xa2, 0xba, 0x3b, 0x86, 0x92,
+	0x38, 0x05, 0xb8, 0xd0, 0x8e, 0x13, 0xb2, 0x07, 0x20, 0xaf, 0xe4, 0x7d, 0x57, 0x42, 0x87, 0x5f,
+	0x1a, 0xe3, 0x2a, 0x5c, 0x2e, 0xce, 0xd1, 0x70, 0xe5, 0x08, 0x52, 0xd4, 0xfa, 0x85, 0xc8, 0x0c,
+	0x1a, 0xe6, 0xcf, 0xfa, 0x63, 0x58, 0xfd, 0x1e, 0x61, 0x47, 0x6e, 0x3f, 0x28, 0x9a, 0x82, 0xcf,
+	0x67, 0x0c, 0x4d, 0x3a, 0x86, 0xb5, 0xe4, 0x24, 0xa9, 0x80, 0x6f, 0x40, 0x81, 0xba, 0x7d, 0x63,
+	0x60, 0xa6, 0x5f, 0x40, 0x84, 0xe1, 0x89, 0x4f, 0xca, 0xd3, 0xa8, 0xa1, 0xff, 0x41, 0x85, 0x95,
+	0x1f, 0xf5, 0x5a, 0x26, 0x9b, 0xf7, 0x9c, 0x39, 0x65, 0x55, 0xb5, 0x0e, 0x39, 0x66, 0x75, 0x09,
+	0x65, 0x66, 0xb7, 0x27, 0xbf, 0xe4, 0xc8, 0xe0, 0xeb, 0x8a, 0xf4, 0x89, 0xcd, 0xe4, 0xa9, 0x5f,
+	0xa0, 0xab, 0x7d, 0xdf, 0x76, 0xec, 0xb4, 0x89, 0x8d, 0x45, 0xbf, 0xde, 0x86, 0xf2, 0x20, 0x4b,
+	0x92, 0xf8, 0x5a, 0xe0, 0x60, 0xb0, 0xc0, 0x92, 0x75, 0x99, 0xdf, 0x23, 0x3d, 0xa0, 0x7b, 0x7e,
+	0x89, 0x42, 0xbd, 0x2e, 0x31, 0x22, 0x3c, 0xe2, 0xff, 0x1b, 0x16, 0x85, 0xfd, 0x38, 0x30, 0xef,
+	0x56, 0xa1, 0xd2, 0x74, 0xba, 0xf5, 0x73, 0xc7, 0x63, 0xde, 0x09, 0xa9, 0xf7, 0x2d, 0x46, 0x28,
+	0x15, 0xff, 0xe3, 0x72, 0x92, 0xe6, 0x7f, 0x1e, 0x7f, 0x11, 0x00, 0x00, 0xff, 0xff, 0x5d, 0xc3,
+	0x81, 0x43, 0x2c, 0x23, 0x00, 0x00,
 }
@@ -9,6 +9,7 @@ package schema
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gitql/vitess/go/sqltypes"
 	"github.com/gitql/vitess/go/sync2"
@@ -48,6 +49,15 @@ type Table struct {
 	PKColumns []int
 	Type      int
 
+	// RowCache is set for tables that opted into the row cache
+	// of vttablet with a vitess_rowcache comment.
+	RowCache bool
+
+	// QueryBudget is the execution time budget of the queries
+	// on the table, set with a vt_query_budget comment. Zero
+	// means that the table doesn't impose a budget.
+	QueryBudget time.Duration
+
 	// These vars can be accessed concurrently.
 	TableRows     sync2.AtomicInt64
 	DataLength    sync2.AtomicInt64
@@ -0,0 +1,354 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemamanager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gitql/vitess/go/vt/mysqlctl/tmutils"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// SchemaChanges are the statements that turn the schema of
+// a keyspace into a desired schema. Drops lists the tables
+// and the columns they drop, as dropping them loses data.
+type SchemaChanges struct {
+	SQL   []string
+	Drops []string
+}
+
+// Controller returns a Controller that feeds the
+// changes to an Executor for the keyspace.
+func (sc *SchemaChanges) Controller(keyspace string) Controller {
+	return &PlainController{
+		sqls:     sc.SQL,
+		keyspace: keyspace,
+	}
+}
+
+// LoadSchemaDir reads the CREATE TABLE statements of the .sql
+// files in dir, and returns them as a SchemaDefinition.
+func LoadSchemaDir(dir string) (*tabletmanagerdatapb.SchemaDefinition, error) {
+	files, err := filepath.Glob(path.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	sd := &tabletmanagerdatapb.SchemaDefinition{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, sql := range SplitStatements(string(data)) {
+			ddl, err := parseCreateTable(sql)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", file, err)
+			}
+			name := ddl.NewName.String()
+			if _, ok := tmutils.SchemaDefinitionGetTable(sd, name); ok {
+				return nil, fmt.Errorf("%v: table %v is defined twice", file, name)
+			}
+			sd.TableDefinitions = append(sd.TableDefinitions, &tabletmanagerdatapb.TableDefinition{
+				Name:   name,
+				Schema: sql,
+				Type:   tmutils.TableBaseTable,
+			})
+		}
+	}
+	sort.Sort(tableDefinitionsByName{sd.TableDefinitions})
+	return sd, nil
+}
+
+// tableDefinitionsByName sorts TableDefinitions like GetSchema does.
+type tableDefinitionsByName struct {
+	tmutils.TableDefinitions
+}
+
+func (tds tableDefinitionsByName) Less(i, j int) bool {
+	return tds.TableDefinitions[i].Name < tds.TableDefinitions[j].Name
+}
+
+// SplitStatements splits sql on the semicolons that are not
+// in a string or a comment. Empty statements are dropped.
+func SplitStatements(sql string) []string {
+	var statements []string
+	tokenizer := sqlparser.NewStringTokenizer(sql)
+	start := 0
+	empty := true
+	for {
+		typ, _ := tokenizer.Scan()
+		switch typ {
+		case 0, ';':
+			// The position is one byte past the token.
+			end := len(sql)
+			if typ == ';' {
+				end = tokenizer.Position - 2
+			}
+			if !empty {
+				statements = append(statements, strings.TrimSpace(sql[start:end]))
+			}
+			if typ == 0 {
+				return statements
+			}
+			start = end + 1
+			empty = true
+		case sqlparser.COMMENT:
+		default:
+			empty = false
+		}
+	}
+}
+
+func parseCreateTable(sql string) (*sqlparser.DDL, error) {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sql: %s, got error: %v", sql, err)
+	}
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.Action != sqlparser.CreateStr || ddl.TableSpec == nil {
+		return nil, fmt.Errorf("only CREATE TABLE statements with a table definition are supported, got: %s", sql)
+	}
+	return ddl, nil
+}
+
+// DiffSchemaToChanges returns the statements that turn the current
+// schema of a keyspace, as returned by GetSchema, into the desired
+// one: CREATE TABLE for the new tables, ALTER TABLE for the tables
+// whose definition is different, and DROP TABLE for the tables that
+// are not in the desired schema. The tables are compared as they are
+// printed by SHOW CREATE TABLE, so the desired schema should use the
+// same form. Views are ignored.
+func DiffSchemaToChanges(current, desired *tabletmanagerdatapb.SchemaDefinition) (*SchemaChanges, error) {
+	changes := &SchemaChanges{}
+	current, err := tmutils.FilterTables(current, nil, nil, false /* includeViews */)
+	if err != nil {
+		return nil, err
+	}
+	// Both schemas are for the same database.
+	desired = &tabletmanagerdatapb.SchemaDefinition{
+		DatabaseSchema:   current.DatabaseSchema,
+		TableDefinitions: desired.TableDefinitions,
+	}
+	if len(tmutils.DiffSchemaToArray("current", current, "desired", desired)) == 0 {
+		return changes, nil
+	}
+
+	var alters []string
+	for _, td := range desired.TableDefinitions {
+		ctd, ok := tmutils.SchemaDefinitionGetTable(current, td.Name)
+		if !ok {
+			changes.SQL = append(changes.SQL, td.Schema)
+			continue
+		}
+		if ctd.Schema == td.Schema {
+			continue
+		}
+		alter, drops, err := diffTable(ctd.Schema, td.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("cannot diff table %v: %v", td.Name, err)
+		}
+		if alter != nil {
+			alters = append(alters, sqlparser.String(alter))
+			changes.Drops = append(changes.Drops, drops...)
+		}
+	}
+	changes.SQL = append(changes.SQL, alters...)
+	for _, ctd := range current.TableDefinitions {
+		if _, ok := tmutils.SchemaDefinitionGetTable(desired, ctd.Name); ok {
+			continue
+		}
+		drop := &sqlparser.DDL{Action: sqlparser.DropStr, Table: sqlparser.NewTableIdent(ctd.Name)}
+		changes.SQL = append(changes.SQL, sqlparser.String(drop))
+		changes.Drops = append(changes.Drops, ctd.Name)
+	}
+	return changes, nil
+}
+
+// diffTable returns the ALTER TABLE that turns the current table into
+// the desired one, or nil if they only differ by the auto-increment
+// value or the order of their columns. It also returns the columns
+// it drops.
+func diffTable(currentSQL, desiredSQL string) (*sqlparser.DDL, []string, error) {
+	current, err := parseCreateTable(currentSQL)
+	if err != nil {
+		return nil, nil, err
+	}
+	desired, err := parseCreateTable(desiredSQL)
+	if err != nil {
+		return nil, nil, err
+	}
+	table := current.NewName
+	cur := current.TableSpec
+	des := desired.TableSpec
+
+	// The operations are ordered as MySQL requires:
+	// the drops first, and the partitioning last.
+	var drops, adds []*sqlparser.AlterSpec
+	var droppedColumns []string
+
+	// Columns.
+	desiredColumns := make(map[string]*sqlparser.ColumnDefinition)
+	for _, col := range des.Columns {
+		desiredColumns[col.Name.Lowered()] = col
+	}
+	currentColumns := make(map[string]*sqlparser.ColumnDefinition)
+	for _, col := range cur.Columns {
+		currentColumns[col.Name.Lowered()] = col
+		if _, ok := desiredColumns[col.Name.Lowered()]; !ok {
+			drops = append(drops, &sqlparser.AlterSpec{Action: sqlparser.DropColumnStr, Name: col.Name})
+			droppedColumns = append(droppedColumns, fmt.Sprintf("%v.%v", table, col.Name))
+		}
+	}
+	var previous sqlparser.ColIdent
+	for _, col := range des.Columns {
+		curCol, ok := currentColumns[col.Name.Lowered()]
+		switch {
+		case !ok:
+			spec := &sqlparser.AlterSpec{
+				Action:  sqlparser.AddColumnStr,
+				Columns: []*sqlparser.ColumnDefinition{col},
+				After:   previous,
+			}
+			spec.First = previous.IsEmpty()
+			adds = append(adds, spec)
+		case sqlparser.String(curCol.Type) != sqlparser.String(col.Type):
+			adds = append(adds, &sqlparser.AlterSpec{
+				Action:  sqlparser.ModifyColumnStr,
+				Columns: []*sqlparser.ColumnDefinition{col},
+			})
+		}
+		previous = col.Name
+	}
+
+	// Indexes. A changed index is dropped and added again.
+	desiredIndexes := make(map[string]*sqlparser.IndexDefinition)
+	for _, idx := range des.Indexes {
+		desiredIndexes[indexKey(idx)] = idx
+	}
+	currentIndexes := make(map[string]*sqlparser.IndexDefinition)
+	for _, idx := range cur.Indexes {
+		currentIndexes[indexKey(idx)] = idx
+		if desIdx, ok := desiredIndexes[indexKey(idx)]; ok && sqlparser.String(desIdx) == sqlparser.String(idx) {
+			continue
+		}
+		if idx.Type == sqlparser.IndexTypePrimary {
+			drops = append(drops, &sqlparser.AlterSpec{Action: sqlparser.DropPrimaryKeyStr})
+		} else {
+			drops = append(drops, &sqlparser.AlterSpec{Action: sqlparser.DropIndexStr, Name: idx.Name})
+		}
+	}
+	for _, idx := range des.Indexes {
+		if curIdx, ok := currentIndexes[indexKey(idx)]; ok && sqlparser.String(curIdx) == sqlparser.String(idx) {
+			continue
+		}
+		adds = append(adds, &sqlparser.AlterSpec{Action: sqlparser.AddIndexStr, Index: idx})
+	}
+
+	// Constraints. MySQL names the foreign keys that don't have
+	// a name, so they are matched on their definition.
+	desiredConstraints := make(map[string]bool)
+	for _, c := range des.Constraints {
+		desiredConstraints[constraintKey(c, des.Constraints)] = true
+	}
+	currentConstraints := make(map[string]bool)
+	for _, c := range cur.Constraints {
+		key := constraintKey(c, des.Constraints)
+		currentConstraints[key] = true
+		if desiredConstraints[key] {
+			continue
+		}
+		if c.Type != sqlparser.ConstraintForeignKey {
+			return nil, nil, fmt.Errorf("changing constraint %v is not supported", sqlparser.String(c))
+		}
+		drops = append(drops, &sqlparser.AlterSpec{Action: sqlparser.DropForeignKeyStr, Name: c.Name})
+	}
+	for _, c := range des.Constraints {
+		if !currentConstraints[constraintKey(c, des.Constraints)] {
+			adds = append(adds, &sqlparser.AlterSpec{Action: sqlparser.AddConstraintStr, Constraint: c})
+		}
+	}
+
+	// Table options. The options that are not in
+	// the desired schema are left as they are.
+	var options sqlparser.TableOptions
+	for _, opt := range des.Options {
+		name := tableOptionName(opt.Name)
+		if name == "auto_increment" {
+			continue
+		}
+		value, ok := "", false
+		for _, curOpt := range cur.Options {
+			if tableOptionName(curOpt.Name) == name {
+				value, ok = curOpt.Value, true
+			}
+		}
+		if !ok || !strings.EqualFold(value, opt.Value) {
+			options = append(options, opt)
+		}
+	}
+	if options != nil {
+		adds = append(adds, &sqlparser.AlterSpec{Action: sqlparser.TableOptionsStr, Options: options})
+	}
+
+	// Partitioning.
+	if cur.Partitions != des.Partitions {
+		partitions := des.Partitions
+		if partitions == "" {
+			partitions = "remove partitioning"
+		}
+		adds = append(adds, &sqlparser.AlterSpec{Action: sqlparser.PartitionStr, Partitions: partitions})
+	}
+
+	if len(drops)+len(adds) == 0 {
+		return nil, nil, nil
+	}
+	return &sqlparser.DDL{
+		Action:     sqlparser.AlterStr,
+		Table:      table,
+		NewName:    table,
+		AlterSpecs: append(drops, adds...),
+	}, droppedColumns, nil
+}
+
+// indexKey identifies an index of a table.
+func indexKey(idx *sqlparser.IndexDefinition) string {
+	if idx.Type == sqlparser.IndexTypePrimary {
+		return sqlparser.IndexTypePrimary
+	}
+	return idx.Name.Lowered()
+}
+
+// constraintKey identifies a constraint of a table. The name is
+// only part of it if the desired constraints name their constraints.
+func constraintKey(c *sqlparser.ConstraintDefinition, desired []*sqlparser.ConstraintDefinition) string {
+	named := false
+	for _, d := range desired {
+		if d.Type == c.Type && d.Name.Equal(c.Name) {
+			named = true
+		}
+	}
+	if named {
+		return sqlparser.String(c)
+	}
+	unnamed := *c
+	unnamed.Name = sqlparser.ColIdent{}
+	return sqlparser.String(&unnamed)
+}
+
+// tableOptionName returns the canonical name of a table option.
+func tableOptionName(name string) string {
+	name = strings.TrimPrefix(name, "default ")
+	if name == "character set" {
+		return "charset"
+	}
+	return name
+}
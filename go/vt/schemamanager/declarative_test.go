@@ -0,0 +1,174 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemamanager
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gitql/vitess/go/vt/mysqlctl/tmutils"
+
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func schemaDefinition(tables ...string) *tabletmanagerdatapb.SchemaDefinition {
+	sd := &tabletmanagerdatapb.SchemaDefinition{
+		DatabaseSchema: "CREATE DATABASE `{{.DatabaseName}}`",
+	}
+	for _, sql := range tables {
+		ddl, err := parseCreateTable(sql)
+		if err != nil {
+			panic(err)
+		}
+		sd.TableDefinitions = append(sd.TableDefinitions, &tabletmanagerdatapb.TableDefinition{
+			Name:   ddl.NewName.String(),
+			Schema: sql,
+			Type:   tmutils.TableBaseTable,
+		})
+	}
+	return sd
+}
+
+func TestDiffSchemaToChanges(t *testing.T) {
+	current := schemaDefinition(
+		"CREATE TABLE `a` (\n"+
+			"  `id` bigint(20) NOT NULL AUTO_INCREMENT,\n"+
+			"  `name` varchar(64) DEFAULT NULL,\n"+
+			"  `old` int(11) NOT NULL,\n"+
+			"  `b_id` bigint(20) NOT NULL,\n"+
+			"  PRIMARY KEY (`id`),\n"+
+			"  KEY `name` (`name`),\n"+
+			"  KEY `b_id` (`b_id`),\n"+
+			"  CONSTRAINT `a_ibfk_1` FOREIGN KEY (`b_id`) REFERENCES `b` (`id`)\n"+
+			") ENGINE=InnoDB AUTO_INCREMENT=1234 DEFAULT CHARSET=utf8",
+		"CREATE TABLE `b` (\n"+
+			"  `id` bigint(20) NOT NULL,\n"+
+			"  PRIMARY KEY (`id`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8",
+		"CREATE TABLE `c` (\n"+
+			"  `id` bigint(20) NOT NULL,\n"+
+			"  PRIMARY KEY (`id`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8",
+	)
+	desired := schemaDefinition(
+		"CREATE TABLE `a` (\n"+
+			"  `id` bigint(20) NOT NULL AUTO_INCREMENT,\n"+
+			"  `email` varchar(128) NOT NULL,\n"+
+			"  `name` varchar(128) DEFAULT NULL,\n"+
+			"  `b_id` bigint(20) NOT NULL,\n"+
+			"  PRIMARY KEY (`id`),\n"+
+			"  KEY `name` (`name`,`email`),\n"+
+			"  KEY `b_id` (`b_id`),\n"+
+			"  FOREIGN KEY (`b_id`) REFERENCES `b` (`id`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+		"CREATE TABLE `b` (\n"+
+			"  `id` bigint(20) NOT NULL,\n"+
+			"  PRIMARY KEY (`id`)\n"+
+			") ENGINE=InnoDB AUTO_INCREMENT=5 DEFAULT CHARSET=utf8",
+		"CREATE TABLE `d` (\n"+
+			"  `id` bigint(20) NOT NULL,\n"+
+			"  PRIMARY KEY (`id`)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8",
+	)
+
+	changes, err := DiffSchemaToChanges(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &SchemaChanges{
+		SQL: []string{
+			desired.TableDefinitions[2].Schema,
+			"alter table a drop column old, drop index name, " +
+				"add column email varchar(128) not null after id, " +
+				"modify column name varchar(128) default null, " +
+				"add key name (name, email), " +
+				"default charset=utf8mb4",
+			"drop table c",
+		},
+		Drops: []string{"a.old", "c"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("DiffSchemaToChanges:\n%#v, want\n%#v", changes, want)
+	}
+
+	// The changes are valid DDLs.
+	if _, err := parseDDLs(changes.SQL); err != nil {
+		t.Error(err)
+	}
+
+	// There is nothing to do once the schema matches.
+	changes, err = DiffSchemaToChanges(desired, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes.SQL) != 0 || len(changes.Drops) != 0 {
+		t.Errorf("DiffSchemaToChanges(desired, desired): %v, want no change", changes)
+	}
+}
+
+func TestDiffTablePartitions(t *testing.T) {
+	current := "CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=latin1\n" +
+		"/*!50100 PARTITION BY HASH (id)\n" +
+		"PARTITIONS 4 */"
+	desired := "CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=latin1"
+	alter, drops, err := diffTable(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := alter.AlterSpecs[0].Partitions, "remove partitioning"; len(alter.AlterSpecs) != 1 || got != want || drops != nil {
+		t.Errorf("diffTable: %v %v, want alter table t %v", alter.AlterSpecs, drops, want)
+	}
+}
+
+func TestLoadSchemaDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestLoadSchemaDir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"b.sql": "-- the b table; with a comment\n" +
+			"CREATE TABLE `b` (\n" +
+			"  `id` int(11) NOT NULL COMMENT 'a;b',\n" +
+			"  PRIMARY KEY (`id`)\n" +
+			") ENGINE=InnoDB;\n\n" +
+			"CREATE TABLE `a` (`id` int(11) NOT NULL) ENGINE=InnoDB;\n",
+		"ignored.txt": "not sql",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(path.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sd, err := LoadSchemaDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sd.TableDefinitions) != 2 || sd.TableDefinitions[0].Name != "a" || sd.TableDefinitions[1].Name != "b" {
+		t.Fatalf("LoadSchemaDir: %v, want tables a and b", sd)
+	}
+	if got, want := sd.TableDefinitions[1].Schema, "-- the b table; with a comment\nCREATE TABLE `b` (\n  `id` int(11) NOT NULL COMMENT 'a;b',\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB"; got != want {
+		t.Errorf("LoadSchemaDir: %q, want %q", got, want)
+	}
+
+	// Only CREATE TABLE statements are allowed.
+	if err := ioutil.WriteFile(path.Join(dir, "c.sql"), []byte("drop table a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSchemaDir(dir); err == nil || !strings.Contains(err.Error(), "only CREATE TABLE statements") {
+		t.Errorf("LoadSchemaDir: %v, want only CREATE TABLE statements", err)
+	}
+}
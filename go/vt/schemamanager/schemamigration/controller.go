@@ -0,0 +1,204 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemamigration
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/sync2"
+	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/tabletmanager/tmclient"
+	"github.com/gitql/vitess/go/vt/tabletserver/queryservice"
+	"github.com/gitql/vitess/go/vt/tabletserver/tabletconn"
+	"github.com/gitql/vitess/go/vt/throttler"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+	"github.com/gitql/vitess/go/vt/wrangler"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+const (
+	// remoteActionsTimeout bounds the connection to the master.
+	remoteActionsTimeout = 30 * time.Second
+	// healthCheckRetryDelay, healthCheckTimeout and
+	// healthCheckTopologyRefresh configure the health check
+	// used to monitor the replication lag of the replicas.
+	healthCheckRetryDelay      = 5 * time.Second
+	healthCheckTimeout         = time.Minute
+	healthCheckTopologyRefresh = 30 * time.Second
+)
+
+// tabletController is the shardController talking to the master
+// of the shard. Its throttler monitors the replicas of the shard.
+type tabletController struct {
+	wr     *wrangler.Wrangler
+	si     *topo.ShardInfo
+	master *topodatapb.Tablet
+	conn   queryservice.QueryService
+
+	// healthCheck and watchers feed the throttler with the
+	// replication lag of the replicas.
+	healthCheck discovery.HealthCheck
+	watchers    []*discovery.TopologyWatcher
+
+	// throttlerMu protects throttler, as StatsUpdate and
+	// Throttle may race with Close.
+	throttlerMu sync.Mutex
+	throttler   *throttler.Throttler
+}
+
+func newTabletController(ctx context.Context, ts topo.Server, keyspace, shard string, maxRate, maxReplicationLag int64) (shardController, error) {
+	wr := wrangler.New(logutil.NewConsoleLogger(), ts, tmclient.NewTabletManagerClient())
+	si, err := ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get shard info, keyspace: %s, shard: %s, error: %v", keyspace, shard, err)
+	}
+	if !si.HasMaster() {
+		return nil, fmt.Errorf("shard: %s does not have a master", shard)
+	}
+	ti, err := ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get master tablet info, keyspace: %s, shard: %s, error: %v", keyspace, shard, err)
+	}
+	conn, err := tabletconn.GetDialer()(ti.Tablet, remoteActionsTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to master tablet %v: %v", ti.AliasString(), err)
+	}
+	keyspaceAndShard := topoproto.KeyspaceShardString(keyspace, shard)
+	t, err := throttler.NewThrottler(fmt.Sprintf("SchemaMigration(%v)", keyspaceAndShard), "chunks", 1, maxRate, maxReplicationLag)
+	if err != nil {
+		conn.Close(ctx)
+		return nil, fmt.Errorf("cannot instantiate throttler: %v", err)
+	}
+
+	tc := &tabletController{
+		wr:        wr,
+		si:        si,
+		master:    ti.Tablet,
+		conn:      conn,
+		throttler: t,
+	}
+	if maxReplicationLag != throttler.ReplicationLagModuleDisabled {
+		tc.healthCheck = discovery.NewHealthCheck(remoteActionsTimeout, healthCheckRetryDelay, healthCheckTimeout)
+		tc.healthCheck.SetListener(tc, false /* sendDownEvents */)
+		for _, cell := range si.Cells {
+			tc.watchers = append(tc.watchers, discovery.NewShardReplicationWatcher(ts, tc.healthCheck, cell, keyspace, shard, healthCheckTopologyRefresh, discovery.DefaultTopoReadConcurrency))
+		}
+	}
+	return tc, nil
+}
+
+// StatsUpdate is part of the discovery.HealthCheckStatsListener interface.
+func (tc *tabletController) StatsUpdate(ts *discovery.TabletStats) {
+	if ts.Target.TabletType == topodatapb.TabletType_MASTER {
+		return
+	}
+	tc.throttlerMu.Lock()
+	defer tc.throttlerMu.Unlock()
+	if tc.throttler != nil {
+		tc.throttler.RecordReplicationLag(time.Now(), ts)
+	}
+}
+
+// ExecuteFetch is part of the shardController interface.
+func (tc *tabletController) ExecuteFetch(ctx context.Context, query string, maxRows int) (*sqltypes.Result, error) {
+	// The statements are replicated, so the replicas
+	// end up with the new table too.
+	qr, err := tc.wr.TabletManagerClient().ExecuteFetchAsDba(ctx, tc.master, false /* usePool */, []byte(query), maxRows, false /* disableBinlogs */, false /* reloadSchema */)
+	if err != nil {
+		return nil, err
+	}
+	return sqltypes.Proto3ToResult(qr), nil
+}
+
+// TableDefinition is part of the shardController interface.
+func (tc *tabletController) TableDefinition(ctx context.Context, table string) (*tabletmanagerdatapb.TableDefinition, error) {
+	sd, err := tc.wr.TabletManagerClient().GetSchema(ctx, tc.master, []string{table}, nil, false /* includeViews */)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the schema of table %v, error: %v", table, err)
+	}
+	for _, td := range sd.TableDefinitions {
+		if td.Name == table {
+			return td, nil
+		}
+	}
+	return nil, fmt.Errorf("table %v does not exist on the master of shard %v", table, tc.si.ShardName())
+}
+
+// MasterPosition is part of the shardController interface.
+func (tc *tabletController) MasterPosition(ctx context.Context) (string, error) {
+	return tc.wr.TabletManagerClient().MasterPosition(ctx, tc.master)
+}
+
+// StreamChanges is part of the shardController interface.
+func (tc *tabletController) StreamChanges(ctx context.Context, position string, callback func(*querypb.StreamEvent) error) error {
+	target := &querypb.Target{
+		Keyspace:   tc.si.Keyspace(),
+		Shard:      tc.si.ShardName(),
+		TabletType: topodatapb.TabletType_MASTER,
+	}
+	return tc.conn.UpdateStream(ctx, target, position, 0, callback)
+}
+
+// Throttle is part of the shardController interface.
+func (tc *tabletController) Throttle(ctx context.Context) error {
+	for {
+		tc.throttlerMu.Lock()
+		if tc.throttler == nil {
+			tc.throttlerMu.Unlock()
+			return errors.New("the shard controller is closed")
+		}
+		backoff := tc.throttler.Throttle(0 /* threadID */)
+		tc.throttlerMu.Unlock()
+		if backoff == throttler.NotThrottled {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// BlacklistTable is part of the shardController interface.
+func (tc *tabletController) BlacklistTable(ctx context.Context, table string, blacklisted bool) error {
+	cells := []string{tc.master.Alias.Cell}
+	if err := tc.wr.SetShardTabletControl(ctx, tc.si.Keyspace(), tc.si.ShardName(), topodatapb.TabletType_MASTER, cells, !blacklisted, false /* disableQueryService */, []string{table}); err != nil {
+		return err
+	}
+	return tc.wr.TabletManagerClient().RefreshState(ctx, tc.master)
+}
+
+// ReloadSchema is part of the shardController interface.
+func (tc *tabletController) ReloadSchema(ctx context.Context, position string) {
+	tc.wr.ReloadSchemaShard(ctx, tc.si.Keyspace(), tc.si.ShardName(), position, sync2.NewSemaphore(10, 0), true /* includeMaster */)
+}
+
+// Close is part of the shardController interface.
+func (tc *tabletController) Close() {
+	for _, watcher := range tc.watchers {
+		watcher.Stop()
+	}
+	if tc.healthCheck != nil {
+		tc.healthCheck.Close()
+	}
+	tc.conn.Close(context.Background())
+
+	tc.throttlerMu.Lock()
+	defer tc.throttlerMu.Unlock()
+	tc.throttler.Close()
+	tc.throttler = nil
+}
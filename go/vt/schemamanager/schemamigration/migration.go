@@ -0,0 +1,587 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemamigration
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/mysqlconn/replication"
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// This file contains the online migration of one table on one shard.
+//
+// The migration creates a shadow table with the new schema, and copies
+// the rows of the original table into it in chunks ordered by primary
+// key. The rows changed while the copy is running are found in the
+// update stream of the master, and copied again from the original
+// table. Once the shadow table has caught up, the table is blacklisted
+// on the master, the last changes are applied, and both tables are
+// swapped with a single RENAME TABLE statement. The original table is
+// kept as _<table>_old.
+
+var (
+	cutOverThreshold = flag.Duration("schema_migration_cut_over_threshold", time.Second, "A schema migration cuts over to the new table once catching up with the update stream takes less than this duration, which bounds how long the table is blacklisted on the master")
+)
+
+const (
+	// The phases of a shard migration, in order.
+	phaseCreate   = ""
+	phaseCopy     = "copy"
+	phaseCatchUp  = "catchup"
+	phaseCutOver  = "cutover"
+	phaseDone     = "done"
+	phaseCanceled = "canceled"
+
+	// checkpointInterval is the number of chunks copied, or events
+	// applied, between two checkpoints of the progress.
+	checkpointInterval = 10
+)
+
+var errCanceled = errors.New("schema migration canceled")
+
+// shardController is the subset of the tablet and topology
+// operations a shardMigration needs. The migration sees only the
+// master of its shard. It is an interface so it can be faked in tests.
+type shardController interface {
+	// ExecuteFetch runs a query as the dba on the master.
+	ExecuteFetch(ctx context.Context, query string, maxRows int) (*sqltypes.Result, error)
+
+	// TableDefinition returns the definition of a table of the master.
+	TableDefinition(ctx context.Context, table string) (*tabletmanagerdatapb.TableDefinition, error)
+
+	// MasterPosition returns the current replication position of the master.
+	MasterPosition(ctx context.Context) (string, error)
+
+	// StreamChanges streams the update stream of the master,
+	// starting at position, until callback returns an error.
+	StreamChanges(ctx context.Context, position string, callback func(*querypb.StreamEvent) error) error
+
+	// Throttle blocks until the next chunk can be copied.
+	Throttle(ctx context.Context) error
+
+	// BlacklistTable stops (or resumes) serving the table on the master.
+	BlacklistTable(ctx context.Context, table string, blacklisted bool) error
+
+	// ReloadSchema reloads the schema of all the tablets of the shard,
+	// once they have reached position.
+	ReloadSchema(ctx context.Context, position string)
+
+	// Close releases the resources of the controller.
+	Close()
+}
+
+// ShardProgress is the progress of the migration of one shard.
+// It is checkpointed in the workflow data.
+type ShardProgress struct {
+	// Phase is the current phase of the migration.
+	Phase string
+
+	// Columns are the columns copied from the original table, which
+	// are the columns it has in common with the shadow table.
+	Columns []string
+
+	// PKColumns are the primary key columns of the original table.
+	PKColumns []string
+
+	// LastPK is the primary key of the last copied row,
+	// each value encoded as a SQL literal.
+	LastPK []string
+
+	// Position is the position in the update stream of the master up
+	// to which the changes are applied to the shadow table.
+	Position string
+
+	// RowCount is the approximate number of rows of the table.
+	RowCount uint64
+
+	// RowsCopied and ChangesApplied count the work done so far.
+	RowsCopied     uint64
+	ChangesApplied uint64
+
+	// Error is the last error of the shard, if any.
+	Error string
+}
+
+// shardMigration migrates the table on one shard.
+type shardMigration struct {
+	parent *Migration
+	shard  string
+	ctrl   shardController
+}
+
+// shadowTableName and oldTableName return the names of the
+// tables created by the migration of table.
+func shadowTableName(table string) string {
+	return "_" + table + "_new"
+}
+
+func oldTableName(table string) string {
+	return "_" + table + "_old"
+}
+
+// alterTableRE matches the beginning of an ALTER TABLE statement, up to
+// and including the table name.
+var alterTableRE = regexp.MustCompile("(?is)^\\s*alter\\s+(ignore\\s+)?table\\s+(`[^`]+`|[^\\s`]+)")
+
+// parseAlterTable checks sql is an ALTER TABLE statement,
+// and returns the name of the altered table.
+func parseAlterTable(sql string) (string, error) {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse sql: %s, got error: %v", sql, err)
+	}
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.Action != sqlparser.AlterStr || !alterTableRE.MatchString(sql) {
+		return "", fmt.Errorf("online schema migrations only support ALTER TABLE statements: %s", sql)
+	}
+	return ddl.Table.String(), nil
+}
+
+// rewriteAlterTable returns the ALTER TABLE statement sql
+// applied to table instead.
+func rewriteAlterTable(sql, table string) string {
+	loc := alterTableRE.FindStringSubmatchIndex(sql)
+	// loc[4:6] is the position of the table name.
+	return sql[:loc[4]] + sqlparser.Backtick(table) + sql[loc[5]:]
+}
+
+func (sm *shardMigration) table() string {
+	return sm.parent.data.Table
+}
+
+// run runs the migration of the shard from its current phase,
+// until it is done.
+func (sm *shardMigration) run(ctx context.Context) error {
+	for {
+		var err error
+		switch phase := sm.progress().Phase; phase {
+		case phaseCreate:
+			err = sm.createShadowTable(ctx)
+		case phaseCopy:
+			err = sm.copyRows(ctx)
+		case phaseCatchUp:
+			err = sm.catchUp(ctx)
+		case phaseCutOver:
+			err = sm.cutOver(ctx)
+		case phaseDone:
+			return nil
+		default:
+			return fmt.Errorf("unexpected phase %q for shard %v", phase, sm.shard)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// progress returns a copy of the progress of the shard.
+func (sm *shardMigration) progress() ShardProgress {
+	sm.parent.mu.Lock()
+	defer sm.parent.mu.Unlock()
+	return *sm.parent.data.Shards[sm.shard]
+}
+
+// update changes the progress of the shard, and checkpoints it if
+// asked to. Entering a new phase always checkpoints, and fails once
+// the migration has been canceled.
+func (sm *shardMigration) update(ctx context.Context, checkpoint bool, f func(p *ShardProgress)) error {
+	return sm.parent.updateShard(ctx, sm.shard, checkpoint, f)
+}
+
+// createShadowTable creates the shadow table, and records the position
+// from which the changes will have to be applied to it.
+func (sm *shardMigration) createShadowTable(ctx context.Context) error {
+	table := sm.table()
+	shadow := shadowTableName(table)
+	queries := []string{
+		// The shadow table may be left over from an interrupted run.
+		fmt.Sprintf("DROP TABLE IF EXISTS %v", sqlparser.Backtick(shadow)),
+		fmt.Sprintf("CREATE TABLE %v LIKE %v", sqlparser.Backtick(shadow), sqlparser.Backtick(table)),
+		rewriteAlterTable(sm.parent.data.SQL, shadow),
+	}
+	for _, query := range queries {
+		if _, err := sm.ctrl.ExecuteFetch(ctx, query, 0); err != nil {
+			return fmt.Errorf("cannot create shadow table %v: %v", shadow, err)
+		}
+	}
+
+	// Every change after this position is applied to the shadow
+	// table, so it has to be read before the copy starts.
+	position, err := sm.ctrl.MasterPosition(ctx)
+	if err != nil {
+		return err
+	}
+	original, err := sm.ctrl.TableDefinition(ctx, table)
+	if err != nil {
+		return err
+	}
+	altered, err := sm.ctrl.TableDefinition(ctx, shadow)
+	if err != nil {
+		return err
+	}
+	if len(original.PrimaryKeyColumns) == 0 {
+		return fmt.Errorf("table %v has no primary key", table)
+	}
+	alteredColumns := make(map[string]bool)
+	for _, column := range altered.Columns {
+		alteredColumns[column] = true
+	}
+	for _, column := range original.PrimaryKeyColumns {
+		if !alteredColumns[column] {
+			return fmt.Errorf("the schema change cannot drop primary key column %v of table %v", column, table)
+		}
+	}
+	var columns []string
+	for _, column := range original.Columns {
+		if alteredColumns[column] {
+			columns = append(columns, column)
+		}
+	}
+
+	sm.parent.logger.Infof("Shard %v: created %v, copying %v rows", sm.shard, shadow, original.RowCount)
+	return sm.update(ctx, true, func(p *ShardProgress) {
+		p.Phase = phaseCopy
+		p.Columns = columns
+		p.PKColumns = original.PrimaryKeyColumns
+		p.LastPK = nil
+		p.Position = position
+		p.RowCount = original.RowCount
+	})
+}
+
+// copyRows copies the rows of the table to the shadow table in chunks.
+// It resumes after the last copied primary key.
+func (sm *shardMigration) copyRows(ctx context.Context) error {
+	table := sm.table()
+	chunkSize := sm.parent.data.ChunkSize
+	for chunk := 1; ; chunk++ {
+		if err := sm.parent.waitIfPaused(ctx); err != nil {
+			return err
+		}
+		if err := sm.ctrl.Throttle(ctx); err != nil {
+			return err
+		}
+
+		p := sm.progress()
+		pk := columnList(p.PKColumns)
+		var where string
+		if p.LastPK != nil {
+			where = fmt.Sprintf(" WHERE %v > %v", pk, valueList(p.LastPK))
+		}
+		qr, err := sm.ctrl.ExecuteFetch(ctx, fmt.Sprintf("SELECT %v FROM %v%v ORDER BY %v LIMIT %v", strings.Join(escapeNames(p.PKColumns), ", "), sqlparser.Backtick(table), where, strings.Join(escapeNames(p.PKColumns), ", "), chunkSize), chunkSize)
+		if err != nil {
+			return err
+		}
+		if len(qr.Rows) == 0 {
+			sm.parent.logger.Infof("Shard %v: copied %v rows", sm.shard, p.RowsCopied)
+			return sm.update(ctx, true, func(p *ShardProgress) {
+				p.Phase = phaseCatchUp
+			})
+		}
+		lastPK := encodeValues(qr.Rows[len(qr.Rows)-1])
+
+		// The copy is bounded by primary key values, which makes
+		// it deterministic for statement based replication.
+		// Rows already applied from the update stream are skipped.
+		columns := strings.Join(escapeNames(p.Columns), ", ")
+		cond := fmt.Sprintf("%v <= %v", pk, valueList(lastPK))
+		if p.LastPK != nil {
+			cond = fmt.Sprintf("%v > %v AND %v", pk, valueList(p.LastPK), cond)
+		}
+		qr, err = sm.ctrl.ExecuteFetch(ctx, fmt.Sprintf("INSERT IGNORE INTO %v (%v) SELECT %v FROM %v WHERE %v", sqlparser.Backtick(shadowTableName(table)), columns, columns, sqlparser.Backtick(table), cond), 0)
+		if err != nil {
+			return err
+		}
+		rowsAffected := qr.RowsAffected
+		if err := sm.update(ctx, chunk%checkpointInterval == 0, func(p *ShardProgress) {
+			p.LastPK = lastPK
+			p.RowsCopied += rowsAffected
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// catchUp applies the changes from the update stream to the shadow
+// table until doing so is fast enough to cut over.
+func (sm *shardMigration) catchUp(ctx context.Context) error {
+	table := sm.table()
+	for {
+		target, err := sm.ctrl.MasterPosition(ctx)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+		if err := sm.replay(ctx, target, table, shadowTableName(table), true /* pausable */); err != nil {
+			return err
+		}
+		if elapsed := time.Since(start); elapsed < *cutOverThreshold {
+			sm.parent.logger.Infof("Shard %v: caught up with the update stream in %v, cutting over", sm.shard, elapsed)
+			return sm.update(ctx, true, func(p *ShardProgress) {
+				p.Phase = phaseCutOver
+			})
+		}
+	}
+}
+
+// cutOver swaps the shadow table with the original table, while the
+// table is blacklisted on the master.
+func (sm *shardMigration) cutOver(ctx context.Context) (err error) {
+	table := sm.table()
+	shadow := shadowTableName(table)
+	old := oldTableName(table)
+
+	if err := sm.ctrl.BlacklistTable(ctx, table, true); err != nil {
+		return err
+	}
+	defer func() {
+		// The table has to be served again even if the
+		// migration was canceled in the meantime.
+		unblacklistCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if unblacklistErr := sm.ctrl.BlacklistTable(unblacklistCtx, table, false); unblacklistErr != nil && err == nil {
+			err = unblacklistErr
+		}
+	}()
+
+	// The rename may already have happened, if a previous
+	// run was interrupted after it.
+	renamed, err := sm.tableMissing(ctx, shadow)
+	if err != nil {
+		return err
+	}
+	if !renamed {
+		target, err := sm.ctrl.MasterPosition(ctx)
+		if err != nil {
+			return err
+		}
+		if err := sm.replay(ctx, target, table, shadow, false /* pausable */); err != nil {
+			return err
+		}
+		if _, err := sm.ctrl.ExecuteFetch(ctx, sm.renameSQL(), 0); err != nil {
+			return fmt.Errorf("cannot swap %v and %v: %v", table, shadow, err)
+		}
+	}
+
+	// Transactions that wrote to the table before it was blacklisted
+	// may have committed since, the rename waits for them. Their
+	// changes are now in the old table.
+	target, err := sm.ctrl.MasterPosition(ctx)
+	if err != nil {
+		return err
+	}
+	if err := sm.replay(ctx, target, old, table, false /* pausable */); err != nil {
+		return err
+	}
+	sm.ctrl.ReloadSchema(ctx, target)
+
+	sm.parent.logger.Infof("Shard %v: %v has the new schema, the original table is kept as %v", sm.shard, table, old)
+	return sm.update(ctx, true, func(p *ShardProgress) {
+		p.Phase = phaseDone
+	})
+}
+
+// renameSQL returns the statement swapping the shadow table with the
+// original table.
+func (sm *shardMigration) renameSQL() string {
+	table := sm.table()
+	return fmt.Sprintf("RENAME TABLE %v TO %v, %v TO %v", sqlparser.Backtick(table), sqlparser.Backtick(oldTableName(table)), sqlparser.Backtick(shadowTableName(table)), sqlparser.Backtick(table))
+}
+
+// tableMissing returns true if the table does not exist on the master.
+func (sm *shardMigration) tableMissing(ctx context.Context, table string) (bool, error) {
+	qr, err := sm.ctrl.ExecuteFetch(ctx, fmt.Sprintf("SELECT 1 FROM information_schema.tables WHERE table_schema = database() AND table_name = '%v'", table), 1)
+	if err != nil {
+		return false, err
+	}
+	return len(qr.Rows) == 0, nil
+}
+
+// errCaughtUp stops the update stream once the target is reached.
+var errCaughtUp = errors.New("caught up")
+
+// replay applies the changes made to the table since the position of
+// the shard, until target is reached: the rows changed in the table
+// are copied again from source to dest.
+func (sm *shardMigration) replay(ctx context.Context, target, source, dest string, pausable bool) error {
+	targetPosition, err := replication.DecodePosition(target)
+	if err != nil {
+		return err
+	}
+	p := sm.progress()
+	position, err := replication.DecodePosition(p.Position)
+	if err != nil {
+		return err
+	}
+	if position.AtLeast(targetPosition) {
+		return nil
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := 0
+	caughtUp := false
+	err = sm.ctrl.StreamChanges(streamCtx, p.Position, func(event *querypb.StreamEvent) error {
+		if pausable {
+			if err := sm.parent.waitIfPaused(ctx); err != nil {
+				return err
+			}
+		}
+		changes, err := sm.applyEvent(ctx, event, p.Columns, source, dest)
+		if err != nil {
+			return err
+		}
+		if event.EventToken == nil || event.EventToken.Position == "" {
+			return nil
+		}
+		eventPosition, err := replication.DecodePosition(event.EventToken.Position)
+		if err != nil {
+			return err
+		}
+		events++
+		caughtUp = eventPosition.AtLeast(targetPosition)
+		if err := sm.update(ctx, caughtUp || events%checkpointInterval == 0, func(p *ShardProgress) {
+			p.Position = event.EventToken.Position
+			p.ChangesApplied += changes
+		}); err != nil {
+			return err
+		}
+		if caughtUp {
+			return errCaughtUp
+		}
+		return nil
+	})
+	if caughtUp {
+		return nil
+	}
+	if err == nil {
+		err = fmt.Errorf("update stream ended before reaching %v", target)
+	}
+	return err
+}
+
+// applyEvent applies the changes of one event. It returns the number
+// of rows copied again.
+func (sm *shardMigration) applyEvent(ctx context.Context, event *querypb.StreamEvent, columns []string, source, dest string) (uint64, error) {
+	table := sm.table()
+	var changes uint64
+	for _, stmt := range event.Statements {
+		switch stmt.Category {
+		case querypb.StreamEvent_Statement_DML:
+			if stmt.TableName != table {
+				continue
+			}
+			for _, row := range stmt.PrimaryKeyValues {
+				values := sqltypes.MakeRowTrusted(stmt.PrimaryKeyFields, row)
+				if err := sm.copyRow(ctx, stmt.PrimaryKeyFields, values, columns, source, dest); err != nil {
+					return changes, err
+				}
+				changes++
+			}
+		case querypb.StreamEvent_Statement_DDL, querypb.StreamEvent_Statement_Error:
+			// Statements without the primary keys of the
+			// changed rows cannot be applied.
+			if string(stmt.Sql) == sm.renameSQL() {
+				continue
+			}
+			if changesTable(string(stmt.Sql), table) {
+				return changes, fmt.Errorf("cannot apply statement to the shadow table of %v: %s", table, stmt.Sql)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// copyRow replaces the row of dest with its current value in source.
+// The row is only deleted from dest if it's gone from source.
+func (sm *shardMigration) copyRow(ctx context.Context, fields []*querypb.Field, values []sqltypes.Value, columns []string, source, dest string) error {
+	conds := make([]string, len(fields))
+	for i, field := range fields {
+		buf := &bytes.Buffer{}
+		values[i].EncodeSQL(buf)
+		conds[i] = fmt.Sprintf("%v = %v", sqlparser.Backtick(field.Name), buf.String())
+	}
+	where := strings.Join(conds, " AND ")
+	list := strings.Join(escapeNames(columns), ", ")
+	queries := []string{
+		fmt.Sprintf("DELETE FROM %v WHERE %v", sqlparser.Backtick(dest), where),
+		fmt.Sprintf("INSERT INTO %v (%v) SELECT %v FROM %v WHERE %v", sqlparser.Backtick(dest), list, list, sqlparser.Backtick(source), where),
+	}
+	for _, query := range queries {
+		if _, err := sm.ctrl.ExecuteFetch(ctx, query, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changesTable returns true if the statement may change the table.
+// Statements that cannot be parsed are suspicious if they mention it.
+func changesTable(sql, table string) bool {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return strings.Contains(strings.ToLower(sql), strings.ToLower(table))
+	}
+	switch stmt := stmt.(type) {
+	case *sqlparser.Insert:
+		return stmt.Table.Name.String() == table
+	case *sqlparser.Update:
+		return sqlparser.GetTableName(stmt.Table.Expr).String() == table
+	case *sqlparser.Delete:
+		return stmt.Table.Name.String() == table
+	case *sqlparser.DDL:
+		return stmt.Table.String() == table || stmt.NewName.String() == table
+	}
+	return false
+}
+
+func escapeNames(names []string) []string {
+	escaped := make([]string, len(names))
+	for i, name := range names {
+		escaped[i] = sqlparser.Backtick(name)
+	}
+	return escaped
+}
+
+// columnList and valueList format a (possibly composite)
+// primary key, as an operand of a comparison.
+func columnList(names []string) string {
+	return tuple(escapeNames(names))
+}
+
+func valueList(values []string) string {
+	return tuple(values)
+}
+
+func tuple(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return "(" + strings.Join(items, ", ") + ")"
+}
+
+// encodeValues encodes the values as SQL literals.
+func encodeValues(row []sqltypes.Value) []string {
+	encoded := make([]string, len(row))
+	for i, v := range row {
+		buf := &bytes.Buffer{}
+		v.EncodeSQL(buf)
+		encoded[i] = buf.String()
+	}
+	return encoded
+}
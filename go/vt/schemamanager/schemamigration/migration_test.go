@@ -0,0 +1,352 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemamigration
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/mysqlconn/replication"
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/memorytopo"
+	"github.com/gitql/vitess/go/vt/workflow"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+	workflowpb "github.com/gitql/vitess/go/vt/proto/workflow"
+)
+
+func init() {
+	RegisterWorkflowFactory()
+}
+
+func testPosition(n int) string {
+	return fmt.Sprintf("MySQL56/00010203-0405-0607-0809-0a0b0c0d0e0f:1-%d", n)
+}
+
+// fakeController is a shardController recording the queries
+// it executes, and returning scripted results.
+type fakeController struct {
+	mu sync.Mutex
+	// queries are the executed queries.
+	queries []string
+	// chunks are the results of the primary key queries.
+	chunks [][][]sqltypes.Value
+	// positions are the results of MasterPosition.
+	positions []string
+	// events are streamed by StreamChanges.
+	events []*querypb.StreamEvent
+	// blacklisted records the BlacklistTable calls.
+	blacklisted []bool
+	// throttled is notified when Throttle is called, if not nil.
+	// Throttle then blocks until ctx is done.
+	throttled chan struct{}
+}
+
+func (fc *fakeController) ExecuteFetch(ctx context.Context, query string, maxRows int) (*sqltypes.Result, error) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.queries = append(fc.queries, query)
+	switch {
+	case strings.HasPrefix(query, "SELECT `id` FROM `t`"):
+		if len(fc.chunks) == 0 {
+			return &sqltypes.Result{}, nil
+		}
+		rows := fc.chunks[0]
+		fc.chunks = fc.chunks[1:]
+		return &sqltypes.Result{Rows: rows}, nil
+	case strings.Contains(query, "information_schema"):
+		return &sqltypes.Result{Rows: [][]sqltypes.Value{{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))}}}, nil
+	}
+	return &sqltypes.Result{RowsAffected: 1}, nil
+}
+
+func (fc *fakeController) TableDefinition(ctx context.Context, table string) (*tabletmanagerdatapb.TableDefinition, error) {
+	switch table {
+	case "t":
+		return &tabletmanagerdatapb.TableDefinition{
+			Name:              "t",
+			Columns:           []string{"id", "name", "extra"},
+			PrimaryKeyColumns: []string{"id"},
+			RowCount:          3,
+		}, nil
+	case "_t_new":
+		return &tabletmanagerdatapb.TableDefinition{
+			Name:              "_t_new",
+			Columns:           []string{"id", "name"},
+			PrimaryKeyColumns: []string{"id"},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown table %v", table)
+}
+
+func (fc *fakeController) MasterPosition(ctx context.Context) (string, error) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	position := fc.positions[0]
+	if len(fc.positions) > 1 {
+		fc.positions = fc.positions[1:]
+	}
+	return position, nil
+}
+
+func (fc *fakeController) StreamChanges(ctx context.Context, position string, callback func(*querypb.StreamEvent) error) error {
+	start, err := replication.DecodePosition(position)
+	if err != nil {
+		return err
+	}
+	for _, event := range fc.events {
+		eventPosition, err := replication.DecodePosition(event.EventToken.Position)
+		if err != nil {
+			return err
+		}
+		if start.AtLeast(eventPosition) {
+			continue
+		}
+		if err := callback(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fc *fakeController) Throttle(ctx context.Context) error {
+	if fc.throttled == nil {
+		return nil
+	}
+	fc.throttled <- struct{}{}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (fc *fakeController) BlacklistTable(ctx context.Context, table string, blacklisted bool) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.blacklisted = append(fc.blacklisted, blacklisted)
+	return nil
+}
+
+func (fc *fakeController) ReloadSchema(ctx context.Context, position string) {}
+
+func (fc *fakeController) Close() {}
+
+func setupMigration(t *testing.T, fc *fakeController) (topo.Server, *workflow.Manager, string, context.CancelFunc) {
+	newShardController = func(ctx context.Context, ts topo.Server, keyspace, shard string, maxRate, maxReplicationLag int64) (shardController, error) {
+		return fc, nil
+	}
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	if err := ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	if err := ts.CreateShard(ctx, "ks", "0"); err != nil {
+		t.Fatalf("CreateShard failed: %v", err)
+	}
+
+	m := workflow.NewManager(ts)
+	managerCtx, cancel := context.WithCancel(ctx)
+	go m.Run(managerCtx)
+	// Wait for the manager to start: until then, Start fails
+	// before looking for the workflow. Workflows created before
+	// the manager runs would be loaded twice.
+	for i := 0; ; i++ {
+		err := m.Start(ctx, "unknown")
+		if err != nil && !strings.Contains(err.Error(), "manager not running") {
+			break
+		}
+		if i == 1000 {
+			t.Fatalf("failed to wait for running manager")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	uuid, err := m.Create(ctx, workflowFactoryName, []string{"-keyspace", "ks", "-sql", "alter table t drop column extra", "-chunk_size", "2"})
+	if err != nil {
+		t.Fatalf("cannot create schema migration workflow: %v", err)
+	}
+	return ts, m, uuid, cancel
+}
+
+func TestMigration(t *testing.T) {
+	fc := &fakeController{
+		chunks: [][][]sqltypes.Value{
+			{{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))}, {sqltypes.MakeTrusted(sqltypes.Int64, []byte("2"))}},
+			{{sqltypes.MakeTrusted(sqltypes.Int64, []byte("3"))}},
+		},
+		positions: []string{testPosition(5), testPosition(7), testPosition(7), testPosition(8)},
+		events: []*querypb.StreamEvent{{
+			Statements: []*querypb.StreamEvent_Statement{{
+				Category:         querypb.StreamEvent_Statement_DML,
+				TableName:        "t",
+				PrimaryKeyFields: []*querypb.Field{{Name: "id", Type: sqltypes.Int64}},
+				PrimaryKeyValues: sqltypes.RowsToProto3([][]sqltypes.Value{{sqltypes.MakeTrusted(sqltypes.Int64, []byte("2"))}}),
+			}, {
+				// Changes to other tables are ignored.
+				Category:  querypb.StreamEvent_Statement_DML,
+				TableName: "other",
+			}},
+			EventToken: &querypb.EventToken{Position: testPosition(6)},
+		}, {
+			EventToken: &querypb.EventToken{Position: testPosition(7)},
+		}, {
+			Statements: []*querypb.StreamEvent_Statement{{
+				Category: querypb.StreamEvent_Statement_DDL,
+				Sql:      []byte("RENAME TABLE `t` TO `_t_old`, `_t_new` TO `t`"),
+			}},
+			EventToken: &querypb.EventToken{Position: testPosition(8)},
+		}},
+	}
+	ts, m, uuid, cancel := setupMigration(t, fc)
+	defer cancel()
+
+	ctx := context.Background()
+	if err := m.Start(ctx, uuid); err != nil {
+		t.Fatalf("cannot start schema migration workflow: %v", err)
+	}
+	if err := m.Wait(ctx, uuid); err != nil {
+		t.Fatalf("schema migration workflow failed: %v", err)
+	}
+
+	want := []string{
+		"DROP TABLE IF EXISTS `_t_new`",
+		"CREATE TABLE `_t_new` LIKE `t`",
+		"alter table `_t_new` drop column extra",
+		"SELECT `id` FROM `t` ORDER BY `id` LIMIT 2",
+		"INSERT IGNORE INTO `_t_new` (`id`, `name`) SELECT `id`, `name` FROM `t` WHERE `id` <= 2",
+		"SELECT `id` FROM `t` WHERE `id` > 2 ORDER BY `id` LIMIT 2",
+		"INSERT IGNORE INTO `_t_new` (`id`, `name`) SELECT `id`, `name` FROM `t` WHERE `id` > 2 AND `id` <= 3",
+		"SELECT `id` FROM `t` WHERE `id` > 3 ORDER BY `id` LIMIT 2",
+		"DELETE FROM `_t_new` WHERE `id` = 2",
+		"INSERT INTO `_t_new` (`id`, `name`) SELECT `id`, `name` FROM `t` WHERE `id` = 2",
+		"SELECT 1 FROM information_schema.tables WHERE table_schema = database() AND table_name = '_t_new'",
+		"RENAME TABLE `t` TO `_t_old`, `_t_new` TO `t`",
+	}
+	if got := strings.Join(fc.queries, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("queries:\n%v\nwant:\n%v", got, strings.Join(want, "\n"))
+	}
+	if len(fc.blacklisted) != 2 || !fc.blacklisted[0] || fc.blacklisted[1] {
+		t.Errorf("BlacklistTable calls: %v, want [true false]", fc.blacklisted)
+	}
+
+	wi, err := ts.GetWorkflow(ctx, uuid)
+	if err != nil {
+		t.Fatalf("cannot read workflow %v: %v", uuid, err)
+	}
+	if wi.State != workflowpb.WorkflowState_Done || wi.Error != "" {
+		t.Errorf("workflow state: %v %v, want Done without error", wi.State, wi.Error)
+	}
+	for _, s := range []string{`"Phase":"done"`, `"RowsCopied":2`, `"ChangesApplied":1`, testPosition(8)} {
+		if !strings.Contains(string(wi.Data), s) {
+			t.Errorf("workflow data: %s, want it to contain %s", wi.Data, s)
+		}
+	}
+}
+
+func TestMigrationCancel(t *testing.T) {
+	fc := &fakeController{
+		positions: []string{testPosition(5)},
+		throttled: make(chan struct{}, 1),
+	}
+	ts, m, uuid, cancel := setupMigration(t, fc)
+	defer cancel()
+
+	ctx := context.Background()
+	if err := m.Start(ctx, uuid); err != nil {
+		t.Fatalf("cannot start schema migration workflow: %v", err)
+	}
+	<-fc.throttled
+
+	path := "/" + uuid
+	for _, action := range []string{pauseAction, resumeAction, cancelAction} {
+		if err := m.NodeManager().Action(ctx, &workflow.ActionParameters{Path: path, Name: action}); err != nil {
+			t.Fatalf("%v failed: %v", action, err)
+		}
+	}
+	if err := m.NodeManager().Action(ctx, &workflow.ActionParameters{Path: path, Name: pauseAction}); err == nil {
+		t.Errorf("Pause after Cancel should have failed")
+	}
+	if err := m.Wait(ctx, uuid); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+
+	// The shadow table is dropped.
+	if got := fc.queries[len(fc.queries)-1]; got != "DROP TABLE IF EXISTS `_t_new`" {
+		t.Errorf("last query: %v, want the shadow table to be dropped", got)
+	}
+	wi, err := ts.GetWorkflow(ctx, uuid)
+	if err != nil {
+		t.Fatalf("cannot read workflow %v: %v", uuid, err)
+	}
+	if !strings.Contains(wi.Error, "canceled") {
+		t.Errorf("workflow error: %v, want canceled", wi.Error)
+	}
+	if !strings.Contains(string(wi.Data), `"Phase":"canceled"`) {
+		t.Errorf("workflow data: %s, want the shard to be canceled", wi.Data)
+	}
+}
+
+func TestAlterTable(t *testing.T) {
+	testcases := []struct {
+		sql, table, rewritten, err string
+	}{{
+		sql:       "alter table t add column c int",
+		table:     "t",
+		rewritten: "alter table `_t_new` add column c int",
+	}, {
+		sql:       "ALTER IGNORE TABLE `t` ADD INDEX (c)",
+		table:     "t",
+		rewritten: "ALTER IGNORE TABLE `_t_new` ADD INDEX (c)",
+	}, {
+		sql: "create table t (id int)",
+		err: "only support ALTER TABLE",
+	}, {
+		sql: "alter view v as select 1",
+		err: "only support ALTER TABLE",
+	}}
+	for _, tc := range testcases {
+		table, err := parseAlterTable(tc.sql)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("parseAlterTable(%q): %v, want %v", tc.sql, err, tc.err)
+			}
+			continue
+		}
+		if err != nil || table != tc.table {
+			t.Errorf("parseAlterTable(%q): %v %v, want %v", tc.sql, table, err, tc.table)
+			continue
+		}
+		if got := rewriteAlterTable(tc.sql, "_t_new"); got != tc.rewritten {
+			t.Errorf("rewriteAlterTable(%q): %v, want %v", tc.sql, got, tc.rewritten)
+		}
+	}
+}
+
+func TestChangesTable(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want bool
+	}{
+		{"insert into t values (1)", true},
+		{"update t set a = 1", true},
+		{"delete from t where id = 1", true},
+		{"alter table t add column c int", true},
+		{"insert into _t_new select * from t", false},
+		{"delete from other", false},
+		{"some unparsable statement on t", true},
+	}
+	for _, tc := range testcases {
+		if got := changesTable(tc.sql, "t"); got != tc.want {
+			t.Errorf("changesTable(%q): %v, want %v", tc.sql, got, tc.want)
+		}
+	}
+}
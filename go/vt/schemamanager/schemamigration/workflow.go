@@ -0,0 +1,528 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package schemamigration contains a workflow that applies an ALTER
+// TABLE statement to all the shards of a keyspace without blocking
+// writes to the table: the rows are copied to a shadow table with the
+// new schema, which then replaces the original table.
+package schemamigration
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/concurrency"
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+	"github.com/gitql/vitess/go/vt/throttler"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/workflow"
+
+	workflowpb "github.com/gitql/vitess/go/vt/proto/workflow"
+)
+
+const (
+	workflowFactoryName = "schema_migration"
+
+	pauseAction  = "Pause"
+	resumeAction = "Resume"
+	cancelAction = "Cancel"
+)
+
+// MigrationData is the data structure serialized as JSON in Workflow.Data.
+type MigrationData struct {
+	// Keyspace, Table and SQL describe the schema change.
+	Keyspace string
+	Table    string
+	SQL      string
+
+	// ChunkSize is the number of rows copied at once.
+	ChunkSize int
+	// MaxRate is the maximum number of chunks copied per second, per shard.
+	MaxRate int64
+	// MaxReplicationLag is the replication lag, in seconds, above
+	// which the copy is throttled.
+	MaxReplicationLag int64
+
+	// Paused is true if the shards should not be making any progress.
+	Paused bool
+	// Canceled is true once the migration has been canceled.
+	Canceled bool
+
+	// Shards is the progress of each shard. It is nil until the
+	// workflow starts running.
+	Shards map[string]*ShardProgress
+}
+
+// Migration implements the Workflow interface. It runs the migration of
+// the table on all the shards in parallel, and can be paused, resumed
+// and canceled from the UI. A shard that fails pauses the workflow,
+// resuming it retries the shard from its last checkpoint.
+type Migration struct {
+	// mu protects the fields below.
+	// We need it as both Run and Action can be called at the same time.
+	mu sync.Mutex
+
+	// data is the current state.
+	data *MigrationData
+
+	// manager is the current Manager.
+	manager *workflow.Manager
+
+	// wi is the topo.WorkflowInfo.
+	wi *topo.WorkflowInfo
+
+	// rootUINode is the root node representing the workflow in the UI,
+	// shardUINodes has one child node per shard.
+	rootUINode   *workflow.Node
+	shardUINodes map[string]*workflow.Node
+
+	// logger is the logger we export UI logs from.
+	logger *logutil.MemoryLogger
+
+	// resumeChan is closed when the workflow is resumed.
+	// It is nil if the workflow is not paused.
+	resumeChan chan struct{}
+
+	// cancel stops the running shard migrations.
+	cancel context.CancelFunc
+}
+
+// newShardController creates the shardController of a shard. Tests
+// replace it with a fake.
+var newShardController = newTabletController
+
+// Run is part of the workflow.Workflow interface.
+func (m *Migration) Run(ctx context.Context, manager *workflow.Manager, wi *topo.WorkflowInfo) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m.mu.Lock()
+	m.manager = manager
+	m.wi = wi
+	m.cancel = cancel
+	m.rootUINode.Listener = m
+	m.rootUINode.Display = workflow.NodeDisplayDeterminate
+	m.rootUINode.Actions = []*workflow.Action{
+		{
+			Name:  pauseAction,
+			State: workflow.ActionStateEnabled,
+			Style: workflow.ActionStyleNormal,
+		},
+		{
+			Name:  resumeAction,
+			State: workflow.ActionStateDisabled,
+			Style: workflow.ActionStyleNormal,
+		},
+		{
+			Name:  cancelAction,
+			State: workflow.ActionStateEnabled,
+			Style: workflow.ActionStyleWaiting,
+		},
+	}
+	canceled := m.data.Canceled
+	m.mu.Unlock()
+
+	if canceled {
+		// The workflow was restarted while cleaning up.
+		return m.cleanUp(ctx)
+	}
+	if err := m.initShards(ctx); err != nil {
+		return err
+	}
+
+	ec := concurrency.AllErrorRecorder{}
+	wg := sync.WaitGroup{}
+	for shard := range m.shardUINodes {
+		wg.Add(1)
+		go func(shard string) {
+			defer wg.Done()
+			ec.RecordError(m.runShard(runCtx, shard))
+		}(shard)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	canceled = m.data.Canceled
+	m.mu.Unlock()
+	if canceled {
+		return m.cleanUp(ctx)
+	}
+	if err := ec.Error(); err != nil {
+		return err
+	}
+	m.setUIMessage(fmt.Sprintf("Schema migration of %v on keyspace %v: finished successfully. The original tables can be dropped once validated.", m.data.Table, m.data.Keyspace))
+	return nil
+}
+
+// initShards creates the progress of every shard on the first run,
+// and the UI nodes of the shards.
+func (m *Migration) initShards(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.data.Shards == nil {
+		shards, err := m.manager.TopoServer().GetShardNames(ctx, m.data.Keyspace)
+		if err != nil {
+			return fmt.Errorf("cannot get the shards of keyspace %v: %v", m.data.Keyspace, err)
+		}
+		if len(shards) == 0 {
+			return fmt.Errorf("keyspace %v has no shards", m.data.Keyspace)
+		}
+		m.data.Shards = make(map[string]*ShardProgress)
+		for _, shard := range shards {
+			m.data.Shards[shard] = &ShardProgress{}
+		}
+		if err := m.checkpointLocked(ctx); err != nil {
+			return err
+		}
+	}
+
+	var shards []string
+	for shard := range m.data.Shards {
+		shards = append(shards, shard)
+	}
+	sort.Strings(shards)
+	m.rootUINode.Children = nil
+	m.shardUINodes = make(map[string]*workflow.Node)
+	for _, shard := range shards {
+		node := &workflow.Node{
+			Name:     "Shard " + shard,
+			PathName: "shard_" + shard,
+			Display:  workflow.NodeDisplayDeterminate,
+		}
+		m.shardUINodes[shard] = node
+		m.rootUINode.Children = append(m.rootUINode.Children, node)
+	}
+	m.uiUpdateLocked()
+	m.rootUINode.BroadcastChanges(true /* updateChildren */)
+	return nil
+}
+
+// runShard runs the migration of a shard. If it fails, the workflow is
+// paused and the migration is retried once it's resumed.
+func (m *Migration) runShard(ctx context.Context, shard string) error {
+	for {
+		err := m.runShardOnce(ctx, shard)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		m.mu.Lock()
+		m.data.Shards[shard].Error = err.Error()
+		m.logger.Errorf("Shard %v failed, pausing the migration: %v", shard, err)
+		if !m.data.Paused {
+			m.data.Paused = true
+			m.resumeChan = make(chan struct{})
+		}
+		m.uiUpdateLocked()
+		m.rootUINode.BroadcastChanges(true /* updateChildren */)
+		if err := m.checkpointLocked(ctx); err != nil {
+			m.mu.Unlock()
+			return err
+		}
+		m.mu.Unlock()
+
+		if err := m.waitIfPaused(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *Migration) runShardOnce(ctx context.Context, shard string) error {
+	ctrl, err := newShardController(ctx, m.manager.TopoServer(), m.data.Keyspace, shard, m.data.MaxRate, m.data.MaxReplicationLag)
+	if err != nil {
+		return err
+	}
+	defer ctrl.Close()
+
+	sm := &shardMigration{
+		parent: m,
+		shard:  shard,
+		ctrl:   ctrl,
+	}
+	return sm.run(ctx)
+}
+
+// cleanUp drops the shadow tables of a canceled migration.
+func (m *Migration) cleanUp(ctx context.Context) error {
+	m.mu.Lock()
+	var shards []string
+	for shard, p := range m.data.Shards {
+		if p.Phase != phaseCanceled {
+			shards = append(shards, shard)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, shard := range shards {
+		ctrl, err := newShardController(ctx, m.manager.TopoServer(), m.data.Keyspace, shard, throttler.MaxRateModuleDisabled, throttler.ReplicationLagModuleDisabled)
+		if err != nil {
+			return err
+		}
+		_, err = ctrl.ExecuteFetch(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %v", sqlparser.Backtick(shadowTableName(m.data.Table))), 0)
+		ctrl.Close()
+		if err != nil {
+			return fmt.Errorf("cannot drop the shadow table of shard %v: %v", shard, err)
+		}
+
+		m.mu.Lock()
+		m.data.Shards[shard].Phase = phaseCanceled
+		m.uiUpdateLocked()
+		m.rootUINode.BroadcastChanges(true /* updateChildren */)
+		err = m.checkpointLocked(ctx)
+		m.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	m.setUIMessage(fmt.Sprintf("Schema migration of %v on keyspace %v: canceled.", m.data.Table, m.data.Keyspace))
+	return errCanceled
+}
+
+// updateShard changes the progress of a shard, see shardMigration.update.
+func (m *Migration) updateShard(ctx context.Context, shard string, checkpoint bool, f func(p *ShardProgress)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := m.data.Shards[shard]
+	phase := p.Phase
+	if m.data.Canceled && phase != phaseCutOver {
+		return errCanceled
+	}
+	f(p)
+	p.Error = ""
+	m.uiUpdateLocked()
+	m.rootUINode.BroadcastChanges(true /* updateChildren */)
+	if checkpoint || p.Phase != phase {
+		return m.checkpointLocked(ctx)
+	}
+	return nil
+}
+
+// waitIfPaused blocks while the workflow is paused.
+func (m *Migration) waitIfPaused(ctx context.Context) error {
+	m.mu.Lock()
+	resumeChan := m.resumeChan
+	m.mu.Unlock()
+	if resumeChan == nil {
+		return nil
+	}
+	select {
+	case <-resumeChan:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Action is part of the workflow.ActionListener interface.
+func (m *Migration) Action(ctx context.Context, path, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	log.Infof("Migration.Action(%v) called.", name)
+	if m.data.Canceled {
+		return fmt.Errorf("schema migration is canceled")
+	}
+	switch name {
+	case pauseAction:
+		if m.data.Paused {
+			return nil
+		}
+		m.data.Paused = true
+		m.resumeChan = make(chan struct{})
+		m.logger.Infof("Paused")
+	case resumeAction:
+		if !m.data.Paused {
+			return nil
+		}
+		m.data.Paused = false
+		close(m.resumeChan)
+		m.resumeChan = nil
+		m.logger.Infof("Resumed")
+	case cancelAction:
+		// Shards that started cutting over can't be rolled back.
+		for shard, p := range m.data.Shards {
+			if p.Phase == phaseCutOver || p.Phase == phaseDone {
+				return fmt.Errorf("cannot cancel the schema migration, shard %v is already cutting over", shard)
+			}
+		}
+		m.data.Canceled = true
+		if m.data.Paused {
+			m.data.Paused = false
+			close(m.resumeChan)
+			m.resumeChan = nil
+		}
+		m.logger.Infof("Canceled")
+		if m.cancel != nil {
+			m.cancel()
+		}
+	default:
+		m.logger.Errorf("Unknown action %v called", name)
+		return fmt.Errorf("unknown action %v", name)
+	}
+
+	// UI update and Checkpoint.
+	m.uiUpdateLocked()
+	m.rootUINode.BroadcastChanges(true /* updateChildren */)
+	return m.checkpointLocked(ctx)
+}
+
+// uiUpdateLocked updates the computed parts of the Nodes, based on the
+// current state. Needs to be called with the lock.
+func (m *Migration) uiUpdateLocked() {
+	total := 0
+	for shard, node := range m.shardUINodes {
+		p := m.data.Shards[shard]
+		switch p.Phase {
+		case phaseCreate:
+			node.Progress = 0
+			node.ProgressMessage = "creating the shadow table"
+		case phaseCopy:
+			node.Progress = 0
+			if p.RowCount > 0 {
+				node.Progress = int(100 * p.RowsCopied / p.RowCount)
+			}
+			if node.Progress > 99 {
+				node.Progress = 99
+			}
+			node.ProgressMessage = fmt.Sprintf("copying: %v/~%v rows", p.RowsCopied, p.RowCount)
+		case phaseCatchUp:
+			node.Progress = 99
+			node.ProgressMessage = fmt.Sprintf("catching up: %v changes applied", p.ChangesApplied)
+		case phaseCutOver:
+			node.Progress = 99
+			node.ProgressMessage = "cutting over"
+		case phaseDone:
+			node.Progress = 100
+			node.ProgressMessage = "done"
+		case phaseCanceled:
+			node.Progress = 0
+			node.ProgressMessage = "canceled"
+		}
+		node.Message = p.Error
+		total += node.Progress
+	}
+	if len(m.shardUINodes) > 0 {
+		m.rootUINode.Progress = total / len(m.shardUINodes)
+	}
+	m.rootUINode.ProgressMessage = fmt.Sprintf("%v%%", m.rootUINode.Progress)
+	m.rootUINode.Log = m.logger.String()
+
+	if len(m.rootUINode.Actions) == 0 {
+		return
+	}
+	switch {
+	case m.data.Canceled:
+		m.rootUINode.ProgressMessage += " (canceled)"
+		m.rootUINode.Actions[0].State = workflow.ActionStateDisabled
+		m.rootUINode.Actions[1].State = workflow.ActionStateDisabled
+		m.rootUINode.Actions[2].State = workflow.ActionStateDisabled
+	case m.data.Paused:
+		m.rootUINode.ProgressMessage += " (paused)"
+		m.rootUINode.Actions[0].State = workflow.ActionStateDisabled
+		m.rootUINode.Actions[1].State = workflow.ActionStateEnabled
+	default:
+		m.rootUINode.Actions[0].State = workflow.ActionStateEnabled
+		m.rootUINode.Actions[1].State = workflow.ActionStateDisabled
+	}
+}
+
+func (m *Migration) setUIMessage(message string) {
+	log.Infof("Schema migration of %v on keyspace %v: %v", m.data.Table, m.data.Keyspace, message)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rootUINode.Log = m.logger.String()
+	m.rootUINode.Message = message
+	m.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
+
+// checkpointLocked saves a checkpoint in topo server.
+// Needs to be called with the lock.
+func (m *Migration) checkpointLocked(ctx context.Context) error {
+	var err error
+	m.wi.Data, err = json.Marshal(m.data)
+	if err != nil {
+		return err
+	}
+	err = m.manager.TopoServer().SaveWorkflow(ctx, m.wi)
+	if err != nil {
+		m.logger.Errorf("SaveWorkflow failed: %v", err)
+	}
+	return err
+}
+
+// WorkflowFactory is the factory to register the schema migration workflow.
+type WorkflowFactory struct{}
+
+// RegisterWorkflowFactory registers schema_migration as a valid factory
+// in the workflow framework.
+func RegisterWorkflowFactory() {
+	workflow.Register(workflowFactoryName, &WorkflowFactory{})
+}
+
+// Init is part of the workflow.Factory interface.
+func (*WorkflowFactory) Init(workflowProto *workflowpb.Workflow, args []string) error {
+	subFlags := flag.NewFlagSet(workflowFactoryName, flag.ContinueOnError)
+	keyspace := subFlags.String("keyspace", "", "Name of the keyspace to migrate")
+	sql := subFlags.String("sql", "", "The ALTER TABLE statement to apply")
+	chunkSize := subFlags.Int("chunk_size", 1000, "Number of rows copied at once")
+	maxRate := subFlags.Int64("max_rate", throttler.MaxRateModuleDisabled, "Maximum number of chunks copied per second, per shard")
+	maxReplicationLag := subFlags.Int64("max_replication_lag", 10, "Replication lag of the replicas, in seconds, above which the copy is throttled")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if *keyspace == "" || *sql == "" {
+		return fmt.Errorf("Keyspace name and SQL query must be provided for schema migration")
+	}
+	if *chunkSize <= 0 {
+		return fmt.Errorf("chunk_size must be positive")
+	}
+	table, err := parseAlterTable(*sql)
+	if err != nil {
+		return err
+	}
+
+	workflowProto.Name = fmt.Sprintf("Schema migration of %v on keyspace %v", table, *keyspace)
+	data := &MigrationData{
+		Keyspace:          *keyspace,
+		Table:             table,
+		SQL:               *sql,
+		ChunkSize:         *chunkSize,
+		MaxRate:           *maxRate,
+		MaxReplicationLag: *maxReplicationLag,
+	}
+	workflowProto.Data, err = json.Marshal(data)
+	return err
+}
+
+// Instantiate is part of the workflow.Factory interface.
+func (*WorkflowFactory) Instantiate(workflowProto *workflowpb.Workflow, rootNode *workflow.Node) (workflow.Workflow, error) {
+	data := &MigrationData{}
+	if err := json.Unmarshal(workflowProto.Data, data); err != nil {
+		return nil, err
+	}
+	rootNode.Message = fmt.Sprintf("Online schema migration of %v on keyspace %v: %v", data.Table, data.Keyspace, data.SQL)
+
+	m := &Migration{
+		data:       data,
+		rootUINode: rootNode,
+		logger:     logutil.NewMemoryLogger(),
+	}
+	if data.Paused {
+		m.resumeChan = make(chan struct{})
+	}
+	return m, nil
+}
+
+// Compile time interface check.
+var _ workflow.Factory = (*WorkflowFactory)(nil)
+var _ workflow.Workflow = (*Migration)(nil)
@@ -0,0 +1,124 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemaqueue
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/schemamanager"
+	"github.com/gitql/vitess/go/vt/tabletmanager/tmclient"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/wrangler"
+)
+
+// shardController is the schemamanager.Controller of a change on a
+// single shard. It records the result of the execution.
+type shardController struct {
+	keyspace string
+	sqls     []string
+	result   *schemamanager.ExecuteResult
+}
+
+// Open is part of the schemamanager.Controller interface.
+func (c *shardController) Open(ctx context.Context) error {
+	return nil
+}
+
+// Read is part of the schemamanager.Controller interface.
+func (c *shardController) Read(ctx context.Context) ([]string, error) {
+	return c.sqls, nil
+}
+
+// Close is part of the schemamanager.Controller interface.
+func (c *shardController) Close() {}
+
+// Keyspace is part of the schemamanager.Controller interface.
+func (c *shardController) Keyspace() string {
+	return c.keyspace
+}
+
+// OnReadSuccess is part of the schemamanager.Controller interface.
+func (c *shardController) OnReadSuccess(ctx context.Context) error {
+	return nil
+}
+
+// OnReadFail is part of the schemamanager.Controller interface.
+func (c *shardController) OnReadFail(ctx context.Context, err error) error {
+	return err
+}
+
+// OnValidationSuccess is part of the schemamanager.Controller interface.
+func (c *shardController) OnValidationSuccess(ctx context.Context) error {
+	return nil
+}
+
+// OnValidationFail is part of the schemamanager.Controller interface.
+func (c *shardController) OnValidationFail(ctx context.Context, err error) error {
+	return err
+}
+
+// OnExecutorComplete is part of the schemamanager.Controller interface.
+func (c *shardController) OnExecutorComplete(ctx context.Context, result *schemamanager.ExecuteResult) error {
+	c.result = result
+	return nil
+}
+
+// applied returns the number of statements applied on the shard.
+func (c *shardController) applied() int {
+	switch {
+	case c.result == nil:
+		return 0
+	case len(c.result.FailedShards) > 0:
+		// The statement at CurSQLIndex failed.
+		return c.result.CurSQLIndex
+	case len(c.result.SuccessShards) > 0:
+		return c.result.CurSQLIndex + 1
+	}
+	return 0
+}
+
+// err returns the error of the execution, if any.
+func (c *shardController) err() error {
+	switch {
+	case c.result == nil:
+		return nil
+	case len(c.result.FailedShards) > 0:
+		return fmt.Errorf("statement %q failed: %v", c.sqls[c.result.CurSQLIndex], c.result.FailedShards[0].Err)
+	case c.result.ExecutorErr != "":
+		return errors.New(c.result.ExecutorErr)
+	}
+	return nil
+}
+
+// applyShardWithExecutor applies the statements on a shard with a
+// schemamanager.TabletExecutor.
+func applyShardWithExecutor(ctx context.Context, ts topo.Server, logger logutil.Logger, keyspace, shard string, sqls []string, allowLongUnavailability bool, waitSlaveTimeout time.Duration) (int, error) {
+	wr := wrangler.New(logger, ts, tmclient.NewTabletManagerClient())
+	executor := schemamanager.NewTabletExecutor(wr, waitSlaveTimeout)
+	if allowLongUnavailability {
+		executor.AllowBigSchemaChange()
+	}
+	executor.SetShards([]string{shard})
+
+	controller := &shardController{
+		keyspace: keyspace,
+		sqls:     sqls,
+	}
+	if err := schemamanager.Run(ctx, controller, executor); err != nil {
+		// Prefer the shorter error of the execution result.
+		if resultErr := controller.err(); resultErr != nil {
+			return controller.applied(), resultErr
+		}
+		return controller.applied(), err
+	}
+	return controller.applied(), nil
+}
+
+var _ schemamanager.Controller = (*shardController)(nil)
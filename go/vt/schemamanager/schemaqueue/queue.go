@@ -0,0 +1,314 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package schemaqueue contains a persistent queue of schema changes.
+// Each schema change is a workflow saved in the topology. The changes
+// of a keyspace are applied one at a time, in submission order, one
+// shard after the other, and only during their maintenance windows.
+// The finished workflows are the history of the schema changes.
+package schemaqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/workflow"
+
+	workflowpb "github.com/gitql/vitess/go/vt/proto/workflow"
+)
+
+// The states of a schema change, and of each of its shards.
+const (
+	// StateQueued is the state of a change, or a shard, that did not start yet.
+	StateQueued = "queued"
+	// StateRunning is the state of a change, or a shard, being applied.
+	StateRunning = "running"
+	// StateComplete is the state of a change, or a shard, successfully applied.
+	StateComplete = "complete"
+	// StateFailed is the state of a change, or a shard, that failed.
+	// A running change with failed shards waits until they are retried,
+	// or until it is canceled.
+	StateFailed = "failed"
+	// StateCanceled is the state of a change that was canceled.
+	StateCanceled = "canceled"
+)
+
+// ChangeData is the data structure serialized as JSON in Workflow.Data.
+type ChangeData struct {
+	// Keyspace is the keyspace to change.
+	Keyspace string
+	// SQL are the statements to apply, in order.
+	SQL []string
+	// MaintenanceWindow restricts when the shards are changed, see
+	// parseMaintenanceWindows. Empty means at any time.
+	MaintenanceWindow string
+	// AllowLongUnavailability allows the changes affecting a large
+	// number of rows.
+	AllowLongUnavailability bool
+	// WaitSlaveTimeout is how long to wait for the replicas to
+	// apply the change.
+	WaitSlaveTimeout time.Duration
+	// SubmitTime is the submission time, in nanoseconds since the
+	// epoch. It orders the changes of a keyspace.
+	SubmitTime int64
+
+	// Canceled is true once the change has been canceled.
+	Canceled bool
+
+	// Shards is the status of each shard. It is nil until the
+	// workflow starts running.
+	Shards map[string]*ShardStatus
+}
+
+// ShardStatus is the status of the change on a shard.
+type ShardStatus struct {
+	// State is one of StateQueued, StateRunning, StateComplete
+	// or StateFailed.
+	State string
+	// Applied is the number of statements applied on the shard. A
+	// retry starts from the first statement not applied.
+	Applied int
+	// Attempts is the number of times the change was started on
+	// the shard.
+	Attempts int
+	// Error is the error of the last attempt, if it failed.
+	Error string
+	// StartTime and EndTime are the times of the last attempt, in
+	// seconds since the epoch.
+	StartTime int64
+	EndTime   int64
+}
+
+// SchemaChange describes a schema change of the queue.
+type SchemaChange struct {
+	// UUID is the uuid of the workflow.
+	UUID string
+	// State is the overall state of the change.
+	State string
+	// Error is the error the workflow finished with, if any.
+	Error string
+	// StartTime and EndTime are the times the workflow started
+	// and finished, in seconds since the epoch.
+	StartTime int64
+	EndTime   int64
+
+	*ChangeData
+
+	// running is true while the workflow is running.
+	running bool
+}
+
+// changeState returns the overall state of a change, based on its
+// workflow state and on the state of its shards.
+func changeState(w *workflowpb.Workflow, data *ChangeData) string {
+	switch w.State {
+	case workflowpb.WorkflowState_NotStarted:
+		return StateQueued
+	case workflowpb.WorkflowState_Done:
+		switch {
+		case w.Error == "":
+			return StateComplete
+		case data.Canceled:
+			return StateCanceled
+		default:
+			return StateFailed
+		}
+	}
+	state := StateQueued
+	for _, s := range data.Shards {
+		switch s.State {
+		case StateFailed:
+			return StateFailed
+		case StateRunning, StateComplete:
+			state = StateRunning
+		}
+	}
+	return state
+}
+
+// ListSchemaChanges returns the schema changes of a keyspace, in
+// submission order. It includes the finished ones.
+func ListSchemaChanges(ctx context.Context, ts topo.Server, keyspace string) ([]*SchemaChange, error) {
+	uuids, err := ts.GetWorkflowNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var changes []*SchemaChange
+	for _, uuid := range uuids {
+		wi, err := ts.GetWorkflow(ctx, uuid)
+		if err == topo.ErrNoNode {
+			// Deleted since we listed it.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if wi.FactoryName != workflowFactoryName {
+			continue
+		}
+		data := &ChangeData{}
+		if err := json.Unmarshal(wi.Data, data); err != nil {
+			return nil, fmt.Errorf("cannot read schema change %v: %v", uuid, err)
+		}
+		if data.Keyspace != keyspace {
+			continue
+		}
+		changes = append(changes, &SchemaChange{
+			UUID:       uuid,
+			State:      changeState(wi.Workflow, data),
+			Error:      wi.Error,
+			StartTime:  wi.StartTime,
+			EndTime:    wi.EndTime,
+			ChangeData: data,
+			running:    wi.State == workflowpb.WorkflowState_Running,
+		})
+	}
+	sort.Sort(bySubmitTime(changes))
+	return changes, nil
+}
+
+// bySubmitTime sorts the changes in submission order.
+type bySubmitTime []*SchemaChange
+
+func (s bySubmitTime) Len() int      { return len(s) }
+func (s bySubmitTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s bySubmitTime) Less(i, j int) bool {
+	if s[i].SubmitTime != s[j].SubmitTime {
+		return s[i].SubmitTime < s[j].SubmitTime
+	}
+	return s[i].UUID < s[j].UUID
+}
+
+// pendingChangesBefore returns the uuids of the running changes of the
+// keyspace submitted before the given one. The changes which were
+// not started do not block the queue.
+func pendingChangesBefore(ctx context.Context, ts topo.Server, keyspace, uuid string, submitTime int64) ([]string, error) {
+	changes, err := ListSchemaChanges(ctx, ts, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	this := &SchemaChange{
+		UUID:       uuid,
+		ChangeData: &ChangeData{SubmitTime: submitTime},
+	}
+	var pending []string
+	for _, c := range changes {
+		if !bySubmitTime([]*SchemaChange{c, this}).Less(0, 1) {
+			break
+		}
+		if c.running {
+			pending = append(pending, c.UUID)
+		}
+	}
+	return pending, nil
+}
+
+// Submit creates and starts the workflow of a schema change, and
+// returns its uuid. The change waits for the changes of the keyspace
+// submitted before it.
+func Submit(ctx context.Context, manager *workflow.Manager, keyspace, sql, maintenanceWindow string, allowLongUnavailability bool, waitSlaveTimeout time.Duration) (string, error) {
+	args := []string{
+		"-keyspace", keyspace,
+		"-sql", sql,
+		"-maintenance_window", maintenanceWindow,
+		"-wait_slave_timeout", waitSlaveTimeout.String(),
+	}
+	if allowLongUnavailability {
+		args = append(args, "-allow_long_unavailability")
+	}
+	uuid, err := manager.Create(ctx, workflowFactoryName, args)
+	if err != nil {
+		return "", err
+	}
+	return uuid, manager.Start(ctx, uuid)
+}
+
+// maintenanceWindow is a daily time range, in UTC. It is expressed as
+// offsets since midnight. If end is before start, the window spans
+// midnight.
+type maintenanceWindow struct {
+	start, end time.Duration
+}
+
+// parseMaintenanceWindows parses a comma separated list of daily UTC
+// time ranges, like "02:00-04:00,22:30-23:30".
+func parseMaintenanceWindows(s string) ([]maintenanceWindow, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var windows []maintenanceWindow
+	for _, w := range strings.Split(s, ",") {
+		parts := strings.Split(strings.TrimSpace(w), "-")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid maintenance window %q, expected HH:MM-HH:MM", w)
+		}
+		start, err := parseTimeOfDay(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %v", w, err)
+		}
+		end, err := parseTimeOfDay(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %v", w, err)
+		}
+		if start == end {
+			return nil, fmt.Errorf("invalid maintenance window %q: empty time range", w)
+		}
+		windows = append(windows, maintenanceWindow{start: start, end: end})
+	}
+	return windows, nil
+}
+
+// parseTimeOfDay parses HH:MM into an offset since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// timeToWindow returns how long to wait for one of the windows to
+// open. It is 0 if t is within a window, or if there is no window.
+func timeToWindow(windows []maintenanceWindow, t time.Time) time.Duration {
+	if len(windows) == 0 {
+		return 0
+	}
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	now := t.Sub(midnight)
+	const day = 24 * time.Hour
+	wait := day
+	for _, w := range windows {
+		if w.start < w.end {
+			if now >= w.start && now < w.end {
+				return 0
+			}
+		} else if now >= w.start || now < w.end {
+			return 0
+		}
+		d := w.start - now
+		if d < 0 {
+			d += day
+		}
+		if d < wait {
+			wait = d
+		}
+	}
+	return wait
+}
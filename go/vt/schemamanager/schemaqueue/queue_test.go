@@ -0,0 +1,279 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemaqueue
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/memorytopo"
+	"github.com/gitql/vitess/go/vt/workflow"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+func init() {
+	RegisterWorkflowFactory()
+	queuePollInterval = 10 * time.Millisecond
+}
+
+// fakeApplier records the statements applied on each shard, and
+// fails or blocks the shards as scripted.
+type fakeApplier struct {
+	mu sync.Mutex
+	// applied are the applied statements, as "shard: sql".
+	applied []string
+	// failures maps a shard to the number of statements it applies
+	// before failing, the next time it's run.
+	failures map[string]int
+	// block, if set, is read before applying on a shard.
+	block chan struct{}
+}
+
+func (fa *fakeApplier) apply(ctx context.Context, ts topo.Server, logger logutil.Logger, keyspace, shard string, sqls []string, allowLongUnavailability bool, waitSlaveTimeout time.Duration) (int, error) {
+	if fa.block != nil {
+		<-fa.block
+	}
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	for i, sql := range sqls {
+		if n, ok := fa.failures[shard]; ok && n == i {
+			delete(fa.failures, shard)
+			return i, fmt.Errorf("cannot apply %v", sql)
+		}
+		fa.applied = append(fa.applied, shard+": "+sql)
+	}
+	return len(sqls), nil
+}
+
+func (fa *fakeApplier) appliedStatements() string {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	return strings.Join(fa.applied, "\n")
+}
+
+func setupQueue(t *testing.T, fa *fakeApplier) (topo.Server, *workflow.Manager, context.CancelFunc) {
+	applyShard = fa.apply
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	if err := ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	for _, shard := range []string{"-80", "80-"} {
+		if err := ts.CreateShard(ctx, "ks", shard); err != nil {
+			t.Fatalf("CreateShard failed: %v", err)
+		}
+	}
+
+	m := workflow.NewManager(ts)
+	managerCtx, cancel := context.WithCancel(ctx)
+	go m.Run(managerCtx)
+	// Wait for the manager to start: until then, Start fails
+	// before looking for the workflow.
+	for i := 0; ; i++ {
+		err := m.Start(ctx, "unknown")
+		if err != nil && !strings.Contains(err.Error(), "manager not running") {
+			break
+		}
+		if i == 1000 {
+			t.Fatalf("failed to wait for running manager")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return ts, m, cancel
+}
+
+func submit(t *testing.T, m *workflow.Manager, sql string) string {
+	uuid, err := Submit(context.Background(), m, "ks", sql, "", false, time.Second)
+	if err != nil {
+		t.Fatalf("cannot submit schema change: %v", err)
+	}
+	return uuid
+}
+
+// waitForState waits until the change reaches the given state.
+func waitForState(t *testing.T, ts topo.Server, uuid, state string) *SchemaChange {
+	ctx := context.Background()
+	for i := 0; i < 1000; i++ {
+		changes, err := ListSchemaChanges(ctx, ts, "ks")
+		if err != nil {
+			t.Fatalf("ListSchemaChanges failed: %v", err)
+		}
+		for _, c := range changes {
+			if c.UUID == uuid && c.State == state {
+				return c
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("schema change %v did not reach state %v", uuid, state)
+	return nil
+}
+
+func TestSchemaChangeRetry(t *testing.T) {
+	fa := &fakeApplier{
+		failures: map[string]int{"80-": 1},
+	}
+	ts, m, cancel := setupQueue(t, fa)
+	defer cancel()
+
+	uuid := submit(t, m, "create table a (id int); alter table b add column c int")
+	c := waitForState(t, ts, uuid, StateFailed)
+	if s := c.Shards["-80"]; s.State != StateComplete || s.Applied != 2 {
+		t.Errorf("shard -80: %+v, want complete", s)
+	}
+	if s := c.Shards["80-"]; s.State != StateFailed || s.Applied != 1 || s.Attempts != 1 || !strings.Contains(s.Error, "cannot apply") {
+		t.Errorf("shard 80-: %+v, want failed after one statement", s)
+	}
+
+	// The retry only applies the statements not applied yet.
+	ctx := context.Background()
+	if err := m.NodeManager().Action(ctx, &workflow.ActionParameters{Path: "/" + uuid, Name: RetryAction}); err != nil {
+		t.Fatalf("Retry failed: %v", err)
+	}
+	if err := m.Wait(ctx, uuid); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	c = waitForState(t, ts, uuid, StateComplete)
+	if s := c.Shards["80-"]; s.State != StateComplete || s.Applied != 2 || s.Attempts != 2 {
+		t.Errorf("shard 80-: %+v, want complete after two attempts", s)
+	}
+	want := []string{
+		"-80: create table a (id int)",
+		"-80: alter table b add column c int",
+		"80-: create table a (id int)",
+		"80-: alter table b add column c int",
+	}
+	if got := fa.appliedStatements(); got != strings.Join(want, "\n") {
+		t.Errorf("applied statements:\n%v\nwant:\n%v", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestSchemaChangeQueue(t *testing.T) {
+	fa := &fakeApplier{
+		block: make(chan struct{}),
+	}
+	ts, m, cancel := setupQueue(t, fa)
+	defer cancel()
+
+	first := submit(t, m, "alter table a add column b int")
+	second := submit(t, m, "alter table a add column c int")
+	third := submit(t, m, "alter table a add column d int")
+
+	// The first change is running, and the others wait for it.
+	waitForState(t, ts, first, StateRunning)
+	waitForState(t, ts, second, StateQueued)
+
+	// Cancel the third one while it is waiting.
+	ctx := context.Background()
+	if err := m.NodeManager().Action(ctx, &workflow.ActionParameters{Path: "/" + third, Name: CancelAction}); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if err := m.NodeManager().Action(ctx, &workflow.ActionParameters{Path: "/" + third, Name: RetryAction}); err == nil {
+		t.Errorf("Retry after Cancel should have failed")
+	}
+	waitForState(t, ts, third, StateCanceled)
+
+	// Let the shards go.
+	close(fa.block)
+	if err := m.Wait(ctx, second); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	want := []string{
+		"-80: alter table a add column b int",
+		"80-: alter table a add column b int",
+		"-80: alter table a add column c int",
+		"80-: alter table a add column c int",
+	}
+	if got := fa.appliedStatements(); got != strings.Join(want, "\n") {
+		t.Errorf("applied statements:\n%v\nwant:\n%v", got, strings.Join(want, "\n"))
+	}
+
+	// The history lists all the changes.
+	changes, err := ListSchemaChanges(ctx, ts, "ks")
+	if err != nil {
+		t.Fatalf("ListSchemaChanges failed: %v", err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.UUID+" "+c.State)
+	}
+	wantHistory := []string{first + " complete", second + " complete", third + " canceled"}
+	if strings.Join(got, ",") != strings.Join(wantHistory, ",") {
+		t.Errorf("ListSchemaChanges: %v, want %v", got, wantHistory)
+	}
+}
+
+func TestSchemaChangeInit(t *testing.T) {
+	_, m, cancel := setupQueue(t, &fakeApplier{})
+	defer cancel()
+
+	testcases := []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"-keyspace", "ks"},
+		err:  "must be provided",
+	}, {
+		args: []string{"-keyspace", "ks", "-sql", "select 1 from t"},
+		err:  "DDLs only",
+	}, {
+		args: []string{"-keyspace", "ks", "-sql", " ; "},
+		err:  "no SQL statement",
+	}, {
+		args: []string{"-keyspace", "ks", "-sql", "alter table t add column c int", "-maintenance_window", "2:00"},
+		err:  "invalid maintenance window",
+	}}
+	for _, tc := range testcases {
+		if _, err := m.Create(context.Background(), workflowFactoryName, tc.args); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("Create(%v): %v, want %v", tc.args, err, tc.err)
+		}
+	}
+}
+
+func TestMaintenanceWindows(t *testing.T) {
+	windows, err := parseMaintenanceWindows("02:00-04:00, 22:30-00:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testcases := []struct {
+		time string
+		wait time.Duration
+	}{
+		{"01:00", time.Hour},
+		{"02:00", 0},
+		{"03:59", 0},
+		{"04:00", 18*time.Hour + 30*time.Minute},
+		{"23:00", 0},
+		{"00:15", 0},
+		{"00:30", 90 * time.Minute},
+	}
+	for _, tc := range testcases {
+		now, err := time.Parse("2006-01-02 15:04", "2017-03-01 "+tc.time)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := timeToWindow(windows, now); got != tc.wait {
+			t.Errorf("timeToWindow(%v): %v, want %v", tc.time, got, tc.wait)
+		}
+	}
+	if got := timeToWindow(nil, time.Now()); got != 0 {
+		t.Errorf("timeToWindow without windows: %v, want 0", got)
+	}
+
+	for _, s := range []string{"02:00", "2:00-25:00", "02:00-02:00", "a:00-b:00"} {
+		if _, err := parseMaintenanceWindows(s); err == nil {
+			t.Errorf("parseMaintenanceWindows(%q) should have failed", s)
+		}
+	}
+}
@@ -0,0 +1,494 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemaqueue
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/schemamanager"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/workflow"
+	"github.com/gitql/vitess/go/vt/wrangler"
+
+	workflowpb "github.com/gitql/vitess/go/vt/proto/workflow"
+)
+
+const (
+	workflowFactoryName = "schema_change"
+
+	// RetryAction is the action that retries the failed shards.
+	RetryAction = "Retry"
+	// CancelAction is the action that cancels the change. The shard
+	// being changed, if any, is not interrupted.
+	CancelAction = "Cancel"
+)
+
+var (
+	// queuePollInterval is how often a change checks whether the
+	// changes submitted before it are done.
+	queuePollInterval = 30 * time.Second
+
+	errCanceled = errors.New("schema change canceled")
+)
+
+// ChangeWorkflow implements the Workflow interface. It waits for the
+// changes of the keyspace submitted before it, then applies the change
+// to each shard in turn, during the maintenance windows. The failed
+// shards are retried from the UI.
+type ChangeWorkflow struct {
+	// mu protects the fields below.
+	// We need it as both Run and Action can be called at the same time.
+	mu sync.Mutex
+
+	// data is the current state.
+	data *ChangeData
+
+	// manager is the current Manager.
+	manager *workflow.Manager
+
+	// wi is the topo.WorkflowInfo.
+	wi *topo.WorkflowInfo
+
+	// rootUINode is the root node representing the workflow in the UI,
+	// shardUINodes has one child node per shard.
+	rootUINode   *workflow.Node
+	shardUINodes map[string]*workflow.Node
+
+	// logger is the logger we export UI logs from.
+	logger *logutil.MemoryLogger
+
+	// actionChan is notified when an action is taken, to wake up
+	// the waiting Run.
+	actionChan chan struct{}
+}
+
+// applyShard applies the statements on the master of a shard, and
+// returns how many of them were applied. Tests replace it with a fake.
+var applyShard = applyShardWithExecutor
+
+// Run is part of the workflow.Workflow interface.
+func (c *ChangeWorkflow) Run(ctx context.Context, manager *workflow.Manager, wi *topo.WorkflowInfo) error {
+	c.mu.Lock()
+	c.manager = manager
+	c.wi = wi
+	c.rootUINode.Listener = c
+	c.rootUINode.Display = workflow.NodeDisplayDeterminate
+	c.rootUINode.Actions = []*workflow.Action{
+		{
+			Name:  RetryAction,
+			State: workflow.ActionStateDisabled,
+			Style: workflow.ActionStyleNormal,
+		},
+		{
+			Name:  CancelAction,
+			State: workflow.ActionStateEnabled,
+			Style: workflow.ActionStyleWaiting,
+		},
+	}
+	c.mu.Unlock()
+
+	windows, err := parseMaintenanceWindows(c.data.MaintenanceWindow)
+	if err != nil {
+		return err
+	}
+	if err := c.initShards(ctx); err != nil {
+		return err
+	}
+	if err := c.waitForTurn(ctx); err != nil {
+		return err
+	}
+
+	for {
+		c.mu.Lock()
+		canceled := c.data.Canceled
+		shard, failed := c.nextShardLocked()
+		c.mu.Unlock()
+
+		switch {
+		case canceled:
+			c.setUIMessage("Canceled.")
+			return errCanceled
+		case shard == "" && !failed:
+			c.setUIMessage("Schema change applied on all the shards.")
+			return nil
+		case shard == "":
+			c.setUIMessage("Some shards failed. Retry them, or cancel the schema change.")
+			if err := c.waitForAction(ctx, 0); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if wait := timeToWindow(windows, time.Now()); wait > 0 {
+			c.setUIMessage(fmt.Sprintf("Waiting for the maintenance window %v, in %v.", c.data.MaintenanceWindow, wait))
+			if err := c.waitForAction(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.runShard(ctx, shard); err != nil {
+			return err
+		}
+	}
+}
+
+// initShards creates the status of every shard on the first run,
+// and the UI nodes of the shards.
+func (c *ChangeWorkflow) initShards(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data.Shards == nil {
+		shards, err := c.manager.TopoServer().GetShardNames(ctx, c.data.Keyspace)
+		if err != nil {
+			return fmt.Errorf("cannot get the shards of keyspace %v: %v", c.data.Keyspace, err)
+		}
+		if len(shards) == 0 {
+			return fmt.Errorf("keyspace %v has no shards", c.data.Keyspace)
+		}
+		c.data.Shards = make(map[string]*ShardStatus)
+		for _, shard := range shards {
+			c.data.Shards[shard] = &ShardStatus{State: StateQueued}
+		}
+	}
+	// A shard left running was interrupted by a restart. We
+	// don't know how far it went, so it has to be checked
+	// and retried manually.
+	for shard, s := range c.data.Shards {
+		if s.State == StateRunning {
+			s.State = StateFailed
+			s.Error = "interrupted while running, check the schema of the shard before retrying"
+			c.logger.Errorf("Shard %v was interrupted while running", shard)
+		}
+	}
+	if err := c.checkpointLocked(ctx); err != nil {
+		return err
+	}
+
+	var shards []string
+	for shard := range c.data.Shards {
+		shards = append(shards, shard)
+	}
+	sort.Strings(shards)
+	c.rootUINode.Children = nil
+	c.shardUINodes = make(map[string]*workflow.Node)
+	for _, shard := range shards {
+		node := &workflow.Node{
+			Name:     "Shard " + shard,
+			PathName: "shard_" + shard,
+			Display:  workflow.NodeDisplayDeterminate,
+		}
+		c.shardUINodes[shard] = node
+		c.rootUINode.Children = append(c.rootUINode.Children, node)
+	}
+	c.uiUpdateLocked()
+	c.rootUINode.BroadcastChanges(true /* updateChildren */)
+	return nil
+}
+
+// waitForTurn waits until the changes of the keyspace submitted
+// before this one are done.
+func (c *ChangeWorkflow) waitForTurn(ctx context.Context) error {
+	for {
+		pending, err := pendingChangesBefore(ctx, c.manager.TopoServer(), c.data.Keyspace, c.wi.Uuid, c.data.SubmitTime)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		c.setUIMessage(fmt.Sprintf("Waiting for the schema changes submitted before on keyspace %v: %v", c.data.Keyspace, strings.Join(pending, ", ")))
+		if err := c.waitForAction(ctx, queuePollInterval); err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		canceled := c.data.Canceled
+		c.mu.Unlock()
+		if canceled {
+			c.setUIMessage("Canceled.")
+			return errCanceled
+		}
+	}
+}
+
+// waitForAction blocks until an action is taken, or the timeout
+// expires. A zero timeout waits for an action only.
+func (c *ChangeWorkflow) waitForAction(ctx context.Context, timeout time.Duration) error {
+	var timer <-chan time.Time
+	if timeout > 0 {
+		timer = time.After(timeout)
+	}
+	select {
+	case <-c.actionChan:
+	case <-timer:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// nextShardLocked returns the next queued shard, or "" if there is
+// none, and whether some shards failed. Needs to be called with the
+// lock.
+func (c *ChangeWorkflow) nextShardLocked() (string, bool) {
+	var shards []string
+	failed := false
+	for shard, s := range c.data.Shards {
+		switch s.State {
+		case StateQueued:
+			shards = append(shards, shard)
+		case StateFailed:
+			failed = true
+		}
+	}
+	if len(shards) == 0 {
+		return "", failed
+	}
+	sort.Strings(shards)
+	return shards[0], failed
+}
+
+// runShard applies the statements not yet applied on a shard.
+// Only the checkpoint errors are returned, the shard records its
+// own failure.
+func (c *ChangeWorkflow) runShard(ctx context.Context, shard string) error {
+	c.mu.Lock()
+	s := c.data.Shards[shard]
+	s.State = StateRunning
+	s.Attempts++
+	s.Error = ""
+	s.StartTime = time.Now().Unix()
+	s.EndTime = 0
+	sqls := c.data.SQL[s.Applied:]
+	c.logger.Infof("Applying the schema change on shard %v", shard)
+	c.uiUpdateLocked()
+	c.rootUINode.BroadcastChanges(true /* updateChildren */)
+	err := c.checkpointLocked(ctx)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	applied, err := applyShard(ctx, c.manager.TopoServer(), c.logger, c.data.Keyspace, shard, sqls, c.data.AllowLongUnavailability, c.data.WaitSlaveTimeout)
+	if err != nil && ctx.Err() != nil {
+		// We are shutting down, the shard is
+		// marked as interrupted on the next run.
+		return ctx.Err()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s.Applied += applied
+	s.EndTime = time.Now().Unix()
+	if err != nil {
+		s.State = StateFailed
+		s.Error = err.Error()
+		c.logger.Errorf("Shard %v failed after %v of %v statements: %v", shard, s.Applied, len(c.data.SQL), err)
+	} else {
+		s.State = StateComplete
+		c.logger.Infof("Shard %v complete", shard)
+	}
+	c.uiUpdateLocked()
+	c.rootUINode.BroadcastChanges(true /* updateChildren */)
+	return c.checkpointLocked(ctx)
+}
+
+// Action is part of the workflow.ActionListener interface.
+func (c *ChangeWorkflow) Action(ctx context.Context, path, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	log.Infof("ChangeWorkflow.Action(%v) called.", name)
+	if c.data.Canceled {
+		return fmt.Errorf("schema change is canceled")
+	}
+	switch name {
+	case RetryAction:
+		retried := 0
+		for shard, s := range c.data.Shards {
+			if s.State == StateFailed {
+				s.State = StateQueued
+				s.Error = ""
+				retried++
+				c.logger.Infof("Retrying shard %v", shard)
+			}
+		}
+		if retried == 0 {
+			return fmt.Errorf("no failed shard to retry")
+		}
+	case CancelAction:
+		c.data.Canceled = true
+		c.logger.Infof("Canceled")
+	default:
+		c.logger.Errorf("Unknown action %v called", name)
+		return fmt.Errorf("unknown action %v", name)
+	}
+
+	// Wake up Run.
+	select {
+	case c.actionChan <- struct{}{}:
+	default:
+	}
+
+	// UI update and Checkpoint.
+	c.uiUpdateLocked()
+	c.rootUINode.BroadcastChanges(true /* updateChildren */)
+	return c.checkpointLocked(ctx)
+}
+
+// uiUpdateLocked updates the computed parts of the Nodes, based on the
+// current state. Needs to be called with the lock.
+func (c *ChangeWorkflow) uiUpdateLocked() {
+	complete := 0
+	failed := 0
+	for shard, node := range c.shardUINodes {
+		s := c.data.Shards[shard]
+		node.Progress = 0
+		switch s.State {
+		case StateComplete:
+			node.Progress = 100
+			complete++
+		case StateFailed:
+			failed++
+		}
+		node.ProgressMessage = s.State
+		if s.Attempts > 1 {
+			node.ProgressMessage += fmt.Sprintf(" (attempt %v)", s.Attempts)
+		}
+		node.Message = s.Error
+	}
+	if len(c.shardUINodes) > 0 {
+		c.rootUINode.Progress = 100 * complete / len(c.shardUINodes)
+	}
+	c.rootUINode.ProgressMessage = fmt.Sprintf("%v/%v shards complete", complete, len(c.shardUINodes))
+	c.rootUINode.Log = c.logger.String()
+
+	if len(c.rootUINode.Actions) == 0 {
+		return
+	}
+	if c.data.Canceled {
+		c.rootUINode.ProgressMessage += " (canceled)"
+		c.rootUINode.Actions[0].State = workflow.ActionStateDisabled
+		c.rootUINode.Actions[1].State = workflow.ActionStateDisabled
+		return
+	}
+	if failed > 0 {
+		c.rootUINode.ProgressMessage += fmt.Sprintf(", %v failed", failed)
+		c.rootUINode.Actions[0].State = workflow.ActionStateEnabled
+	} else {
+		c.rootUINode.Actions[0].State = workflow.ActionStateDisabled
+	}
+}
+
+func (c *ChangeWorkflow) setUIMessage(message string) {
+	log.Infof("Schema change on keyspace %v: %v", c.data.Keyspace, message)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rootUINode.Log = c.logger.String()
+	c.rootUINode.Message = message
+	c.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
+
+// checkpointLocked saves a checkpoint in topo server.
+// Needs to be called with the lock.
+func (c *ChangeWorkflow) checkpointLocked(ctx context.Context) error {
+	var err error
+	c.wi.Data, err = json.Marshal(c.data)
+	if err != nil {
+		return err
+	}
+	err = c.manager.TopoServer().SaveWorkflow(ctx, c.wi)
+	if err != nil {
+		c.logger.Errorf("SaveWorkflow failed: %v", err)
+	}
+	return err
+}
+
+// WorkflowFactory is the factory to register the schema change workflow.
+type WorkflowFactory struct{}
+
+// RegisterWorkflowFactory registers schema_change as a valid factory
+// in the workflow framework.
+func RegisterWorkflowFactory() {
+	workflow.Register(workflowFactoryName, &WorkflowFactory{})
+}
+
+// Init is part of the workflow.Factory interface.
+func (*WorkflowFactory) Init(workflowProto *workflowpb.Workflow, args []string) error {
+	subFlags := flag.NewFlagSet(workflowFactoryName, flag.ContinueOnError)
+	keyspace := subFlags.String("keyspace", "", "Name of the keyspace to change")
+	sql := subFlags.String("sql", "", "A list of semicolon-delimited DDL statements")
+	maintenanceWindow := subFlags.String("maintenance_window", "", "Comma separated list of daily UTC time ranges, like 02:00-04:00, during which the shards can be changed. Empty means at any time")
+	allowLongUnavailability := subFlags.Bool("allow_long_unavailability", false, "Allow large schema changes which incur a longer unavailability of the database")
+	waitSlaveTimeout := subFlags.Duration("wait_slave_timeout", wrangler.DefaultWaitSlaveTimeout, "The amount of time to wait for slaves to receive the schema change via replication")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if *keyspace == "" || *sql == "" {
+		return fmt.Errorf("Keyspace name and SQL statements must be provided for schema change")
+	}
+	if _, err := parseMaintenanceWindows(*maintenanceWindow); err != nil {
+		return err
+	}
+	sqls := schemamanager.SplitStatements(*sql)
+	if len(sqls) == 0 {
+		return fmt.Errorf("no SQL statement in %q", *sql)
+	}
+	for _, s := range sqls {
+		stmt, err := sqlparser.Parse(s)
+		if err != nil {
+			return fmt.Errorf("failed to parse sql: %s, got error: %v", s, err)
+		}
+		if _, ok := stmt.(*sqlparser.DDL); !ok {
+			return fmt.Errorf("schema change works for DDLs only, but got non DDL statement: %s", s)
+		}
+	}
+
+	workflowProto.Name = fmt.Sprintf("Schema change on keyspace %v", *keyspace)
+	data := &ChangeData{
+		Keyspace:                *keyspace,
+		SQL:                     sqls,
+		MaintenanceWindow:       *maintenanceWindow,
+		AllowLongUnavailability: *allowLongUnavailability,
+		WaitSlaveTimeout:        *waitSlaveTimeout,
+		SubmitTime:              time.Now().UnixNano(),
+	}
+	var err error
+	workflowProto.Data, err = json.Marshal(data)
+	return err
+}
+
+// Instantiate is part of the workflow.Factory interface.
+func (*WorkflowFactory) Instantiate(workflowProto *workflowpb.Workflow, rootNode *workflow.Node) (workflow.Workflow, error) {
+	data := &ChangeData{}
+	if err := json.Unmarshal(workflowProto.Data, data); err != nil {
+		return nil, err
+	}
+	rootNode.Message = fmt.Sprintf("Schema change on keyspace %v: %v", data.Keyspace, strings.Join(data.SQL, "; "))
+
+	return &ChangeWorkflow{
+		data:       data,
+		rootUINode: rootNode,
+		logger:     logutil.NewMemoryLogger(),
+		actionChan: make(chan struct{}, 1),
+	}, nil
+}
+
+// Compile time interface check.
+var _ workflow.Factory = (*WorkflowFactory)(nil)
+var _ workflow.Workflow = (*ChangeWorkflow)(nil)
@@ -28,6 +28,7 @@ type TabletExecutor struct {
 	isClosed             bool
 	allowBigSchemaChange bool
 	keyspace             string
+	shards               []string
 	waitSlaveTimeout     time.Duration
 }
 
@@ -53,6 +54,12 @@ func (exec *TabletExecutor) DisallowBigSchemaChange() {
 	exec.allowBigSchemaChange = false
 }
 
+// SetShards restricts the schema changes to the given shards of the
+// keyspace. By default, they are applied to all the shards.
+func (exec *TabletExecutor) SetShards(shards []string) {
+	exec.shards = shards
+}
+
 // Open opens a connection to the master for every shard.
 func (exec *TabletExecutor) Open(ctx context.Context, keyspace string) error {
 	if !exec.isClosed {
@@ -63,6 +70,12 @@ func (exec *TabletExecutor) Open(ctx context.Context, keyspace string) error {
 	if err != nil {
 		return fmt.Errorf("unable to get shard names for keyspace: %s, error: %v", keyspace, err)
 	}
+	if exec.shards != nil {
+		shardNames, err = selectShards(shardNames, exec.shards)
+		if err != nil {
+			return fmt.Errorf("invalid shards for keyspace: %s, error: %v", keyspace, err)
+		}
+	}
 	exec.tablets = make([]*topodatapb.Tablet, len(shardNames))
 	for i, shardName := range shardNames {
 		shardInfo, err := exec.wr.TopoServer().GetShard(ctx, keyspace, shardName)
@@ -86,6 +99,21 @@ func (exec *TabletExecutor) Open(ctx context.Context, keyspace string) error {
 	return nil
 }
 
+// selectShards returns the shards in wanted, checking they are all in
+// shardNames.
+func selectShards(shardNames, wanted []string) ([]string, error) {
+	known := make(map[string]bool, len(shardNames))
+	for _, shardName := range shardNames {
+		known[shardName] = true
+	}
+	for _, shardName := range wanted {
+		if !known[shardName] {
+			return nil, fmt.Errorf("shard %s does not exist", shardName)
+		}
+	}
+	return wanted, nil
+}
+
 func parseDDLs(sqls []string) ([]*sqlparser.DDL, error) {
 	parsedDDLs := make([]*sqlparser.DDL, len(sqls))
 	for i, sql := range sqls {
@@ -147,11 +175,11 @@ func (exec *TabletExecutor) detectBigSchemaChanges(ctx context.Context, parsedDD
 		if rowCount, ok := tableWithCount[tableName]; ok {
 			if rowCount > 100000 && ddl.Action == sqlparser.AlterStr {
 				return true, fmt.Errorf(
-					"big schema change detected. Disable check with -allow_long_unavailability. ddl: %v alters a table with more than 100 thousand rows", ddl)
+					"big schema change detected. Disable check with -allow_long_unavailability, or apply it with the schema_migration workflow. ddl: %v alters a table with more than 100 thousand rows", ddl)
 			}
 			if rowCount > 2000000 {
 				return true, fmt.Errorf(
-					"big schema change detected. Disable check with -allow_long_unavailability. ddl: %v changes a table with more than 2 million rows", ddl)
+					"big schema change detected. Disable check with -allow_long_unavailability, or apply it with the schema_migration workflow. ddl: %v changes a table with more than 2 million rows", ddl)
 			}
 		}
 	}
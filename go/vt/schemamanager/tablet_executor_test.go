@@ -35,6 +35,26 @@ func TestTabletExecutorOpen(t *testing.T) {
 	}
 }
 
+func TestTabletExecutorOpenWithShards(t *testing.T) {
+	executor := newFakeExecutor()
+	executor.SetShards([]string{"1"})
+	ctx := context.Background()
+
+	if err := executor.Open(ctx, "test_keyspace"); err != nil {
+		t.Fatalf("executor.Open should succeed: %v", err)
+	}
+	if len(executor.tablets) != 1 {
+		t.Errorf("executor.Open: %v, want only the master of shard 1", executor.tablets)
+	}
+	executor.Close()
+
+	executor.SetShards([]string{"1", "3"})
+	if err := executor.Open(ctx, "test_keyspace"); err == nil {
+		t.Fatalf("executor.Open() = nil, want error for an unknown shard")
+	}
+	executor.Close()
+}
+
 func TestTabletExecutorOpenWithEmptyMasterAlias(t *testing.T) {
 	ft := newFakeTopo()
 	ft.Impl.(*fakeTopo).WithEmptyMasterAlias = true
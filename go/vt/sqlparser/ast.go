@@ -29,8 +29,16 @@ import (
 // Parse parses the sql and returns a Statement, which
 // is the AST representation of the query.
 func Parse(sql string) (Statement, error) {
+	if stmt := ParseSavepoint(sql); stmt != nil {
+		return stmt, nil
+	}
 	tokenizer := NewStringTokenizer(sql)
 	if yyParse(tokenizer) != 0 {
+		// For a CREATE TABLE or an ALTER TABLE whose
+		// table names are known, return just the names.
+		if tokenizer.partialDDL != nil {
+			return tokenizer.partialDDL, nil
+		}
 		return nil, errors.New(tokenizer.LastError)
 	}
 	return tokenizer.ParseTree, nil
@@ -110,6 +118,8 @@ func (*Set) iStatement()    {}
 func (*DDL) iStatement()    {}
 func (*Other) iStatement()  {}
 
+func (*Savepoint) iStatement() {}
+
 // SelectStatement any SELECT statement.
 type SelectStatement interface {
 	iSelectStatement()
@@ -390,11 +400,17 @@ func (node *Set) WalkSubtree(visit Visit) error {
 // DDL represents a CREATE, ALTER, DROP or RENAME statement.
 // Table is set for AlterStr, DropStr, RenameStr.
 // NewName is set for AlterStr, CreateStr, RenameStr.
+// TableSpec is set for a CREATE TABLE that has a table body,
+// and AlterSpecs for an ALTER TABLE whose operations could
+// all be parsed. Otherwise, only the table names are known.
 type DDL struct {
-	Action   string
-	Table    TableIdent
-	NewName  TableIdent
-	IfExists bool
+	Action      string
+	Table       TableIdent
+	NewName     TableIdent
+	IfExists    bool
+	IfNotExists bool
+	TableSpec   *TableSpec
+	AlterSpecs  []*AlterSpec
 }
 
 // DDL strings.
@@ -409,7 +425,15 @@ const (
 func (node *DDL) Format(buf *TrackedBuffer) {
 	switch node.Action {
 	case CreateStr:
-		buf.Myprintf("%s table %v", node.Action, node.NewName)
+		if node.TableSpec == nil {
+			buf.Myprintf("%s table %v", node.Action, node.NewName)
+			return
+		}
+		notExists := ""
+		if node.IfNotExists {
+			notExists = " if not exists"
+		}
+		buf.Myprintf("%s table%s %v %v", node.Action, notExists, node.NewName, node.TableSpec)
 	case DropStr:
 		exists := ""
 		if node.IfExists {
@@ -420,10 +444,18 @@ func (node *DDL) Format(buf *TrackedBuffer) {
 		buf.Myprintf("%s table %v %v", node.Action, node.Table, node.NewName)
 	default:
 		buf.Myprintf("%s table %v", node.Action, node.Table)
+		prefix := " "
+		for _, spec := range node.AlterSpecs {
+			buf.Myprintf("%s%v", prefix, spec)
+			prefix = ", "
+		}
 	}
 }
 
 // WalkSubtree walks the nodes of the subtree.
+// The table definitions are not walked: their
+// values belong to the schema and must not be
+// replaced by bind variables.
 func (node *DDL) WalkSubtree(visit Visit) error {
 	if node == nil {
 		return nil
@@ -435,6 +467,556 @@ func (node *DDL) WalkSubtree(visit Visit) error {
 	)
 }
 
+// TableSpec describes the body of a CREATE TABLE statement.
+// Partitions is the partitioning clause, kept as written.
+type TableSpec struct {
+	Columns     []*ColumnDefinition
+	Indexes     []*IndexDefinition
+	Constraints []*ConstraintDefinition
+	Options     TableOptions
+	Partitions  string
+}
+
+// Format formats the node.
+func (node *TableSpec) Format(buf *TrackedBuffer) {
+	prefix := "(\n\t"
+	for _, col := range node.Columns {
+		buf.Myprintf("%s%v", prefix, col)
+		prefix = ",\n\t"
+	}
+	for _, idx := range node.Indexes {
+		buf.Myprintf("%s%v", prefix, idx)
+		prefix = ",\n\t"
+	}
+	for _, c := range node.Constraints {
+		buf.Myprintf("%s%v", prefix, c)
+		prefix = ",\n\t"
+	}
+	buf.Myprintf("\n)")
+	if len(node.Options) != 0 {
+		buf.Myprintf(" %v", node.Options)
+	}
+	if node.Partitions != "" {
+		buf.Myprintf(" %s", node.Partitions)
+	}
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *TableSpec) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	for _, n := range node.Columns {
+		if err := Walk(visit, n); err != nil {
+			return err
+		}
+	}
+	for _, n := range node.Indexes {
+		if err := Walk(visit, n); err != nil {
+			return err
+		}
+	}
+	for _, n := range node.Constraints {
+		if err := Walk(visit, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ColumnDefinition describes a column in a CREATE TABLE
+// or ALTER TABLE statement.
+type ColumnDefinition struct {
+	Name ColIdent
+	Type *ColumnType
+}
+
+// Format formats the node.
+func (node *ColumnDefinition) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%v %v", node.Name, node.Type)
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *ColumnDefinition) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	return Walk(
+		visit,
+		node.Name,
+		node.Type,
+	)
+}
+
+// ColumnType represents the type of a column and its attributes.
+// Type is the lower-cased type name. Null is set if NULL was
+// explicitly specified. As is the expression of a generated
+// column. KeyOpt is one of the ColumnKey constants.
+type ColumnType struct {
+	Type          string
+	Length        *SQLVal
+	Scale         *SQLVal
+	EnumValues    []string
+	Unsigned      bool
+	Zerofill      bool
+	Charset       string
+	Collate       string
+	As            Expr
+	Stored        bool
+	NotNull       bool
+	Null          bool
+	Default       Expr
+	OnUpdate      Expr
+	Autoincrement bool
+	KeyOpt        string
+	Comment       *SQLVal
+}
+
+// ColumnType.KeyOpt
+const (
+	ColumnKeyPrimary = "primary key"
+	ColumnKeyUnique  = "unique key"
+	ColumnKey        = "key"
+)
+
+// Format formats the node.
+func (node *ColumnType) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%s", node.Type)
+	if node.Length != nil {
+		buf.Myprintf("(%v", node.Length)
+		if node.Scale != nil {
+			buf.Myprintf(", %v", node.Scale)
+		}
+		buf.Myprintf(")")
+	}
+	if node.EnumValues != nil {
+		prefix := "("
+		for _, val := range node.EnumValues {
+			buf.Myprintf("%s%v", prefix, NewStrVal([]byte(val)))
+			prefix = ", "
+		}
+		buf.Myprintf(")")
+	}
+	if node.Unsigned {
+		buf.Myprintf(" unsigned")
+	}
+	if node.Zerofill {
+		buf.Myprintf(" zerofill")
+	}
+	if node.Charset != "" {
+		buf.Myprintf("%s %s", CharacterSetStr, node.Charset)
+	}
+	if node.Collate != "" {
+		buf.Myprintf(" collate %s", node.Collate)
+	}
+	if node.As != nil {
+		stored := " virtual"
+		if node.Stored {
+			stored = " stored"
+		}
+		buf.Myprintf(" as (%v)%s", node.As, stored)
+	}
+	if node.NotNull {
+		buf.Myprintf(" not null")
+	} else if node.Null {
+		buf.Myprintf(" null")
+	}
+	if node.Default != nil {
+		buf.Myprintf(" default ")
+		formatColumnValue(buf, node.Default)
+	}
+	if node.OnUpdate != nil {
+		buf.Myprintf(" on update %v", node.OnUpdate)
+	}
+	if node.Autoincrement {
+		buf.Myprintf(" auto_increment")
+	}
+	if node.KeyOpt != "" {
+		buf.Myprintf(" %s", node.KeyOpt)
+	}
+	if node.Comment != nil {
+		buf.Myprintf(" comment %v", node.Comment)
+	}
+}
+
+// formatColumnValue formats a default value. Values that
+// are not literals must be enclosed in parenthesis.
+func formatColumnValue(buf *TrackedBuffer, expr Expr) {
+	switch expr := expr.(type) {
+	case *SQLVal, *NullVal, BoolVal:
+		buf.Myprintf("%v", expr)
+		return
+	case *UnaryExpr:
+		if _, ok := expr.Expr.(*SQLVal); ok {
+			buf.Myprintf("%v", expr)
+			return
+		}
+	case *FuncExpr:
+		// CURRENT_TIMESTAMP and its synonyms.
+		if len(expr.Exprs) <= 1 {
+			switch expr.Name.Lowered() {
+			case "current_timestamp", "now", "localtime", "localtimestamp":
+				buf.Myprintf("%v", expr)
+				return
+			}
+		}
+	}
+	buf.Myprintf("(%v)", expr)
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *ColumnType) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	return Walk(
+		visit,
+		node.As,
+		node.Default,
+		node.OnUpdate,
+	)
+}
+
+// IndexDefinition describes an index in a CREATE TABLE
+// or ALTER TABLE statement. Type is one of the IndexType
+// constants. Using is the index type given by USING.
+type IndexDefinition struct {
+	Type    string
+	Name    ColIdent
+	Columns []*IndexColumn
+	Using   string
+	Options []*IndexOption
+}
+
+// IndexDefinition.Type
+const (
+	IndexTypePrimary  = "primary key"
+	IndexTypeUnique   = "unique key"
+	IndexTypeKey      = "key"
+	IndexTypeFulltext = "fulltext key"
+	IndexTypeSpatial  = "spatial key"
+)
+
+// Format formats the node.
+func (node *IndexDefinition) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%s", node.Type)
+	if !node.Name.IsEmpty() {
+		buf.Myprintf(" %v", node.Name)
+	}
+	prefix := " ("
+	for _, col := range node.Columns {
+		buf.Myprintf("%s%v", prefix, col)
+		prefix = ", "
+	}
+	buf.Myprintf(")")
+	if node.Using != "" {
+		buf.Myprintf(" using %s", node.Using)
+	}
+	for _, opt := range node.Options {
+		buf.Myprintf(" %v", opt)
+	}
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *IndexDefinition) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	if err := Walk(visit, node.Name); err != nil {
+		return err
+	}
+	for _, n := range node.Columns {
+		if err := Walk(visit, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexColumn is a column of an index, with
+// an optional prefix length.
+type IndexColumn struct {
+	Column ColIdent
+	Length *SQLVal
+	Order  string
+}
+
+// Format formats the node.
+func (node *IndexColumn) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%v", node.Column)
+	if node.Length != nil {
+		buf.Myprintf("(%v)", node.Length)
+	}
+	if node.Order != "" {
+		buf.Myprintf(" %s", node.Order)
+	}
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *IndexColumn) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	return Walk(visit, node.Column)
+}
+
+// IndexOption is an option of an index, like
+// KEY_BLOCK_SIZE or COMMENT. Value is kept as written.
+type IndexOption struct {
+	Name  string
+	Value string
+}
+
+// Format formats the node.
+func (node *IndexOption) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%s", node.Name)
+	if node.Value != "" {
+		buf.Myprintf(" %s", node.Value)
+	}
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *IndexOption) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+// ConstraintDefinition describes a foreign key or a check
+// constraint. Type is one of the ConstraintType constants.
+// For a foreign key, OnDelete and OnUpdate hold the
+// referential actions.
+type ConstraintDefinition struct {
+	Name              ColIdent
+	Type              string
+	IndexName         ColIdent
+	Columns           Columns
+	ReferencedTable   *TableName
+	ReferencedColumns Columns
+	Match             string
+	OnDelete          string
+	OnUpdate          string
+	Check             Expr
+	NotEnforced       bool
+}
+
+// ConstraintDefinition.Type
+const (
+	ConstraintForeignKey = "foreign key"
+	ConstraintCheck      = "check"
+)
+
+// Format formats the node.
+func (node *ConstraintDefinition) Format(buf *TrackedBuffer) {
+	if !node.Name.IsEmpty() {
+		buf.Myprintf("constraint %v ", node.Name)
+	}
+	if node.Type == ConstraintCheck {
+		buf.Myprintf("check (%v)", node.Check)
+		if node.NotEnforced {
+			buf.Myprintf(" not enforced")
+		}
+		return
+	}
+	buf.Myprintf("%s", node.Type)
+	if !node.IndexName.IsEmpty() {
+		buf.Myprintf(" %v", node.IndexName)
+	}
+	buf.Myprintf(" %v references %v %v", node.Columns, node.ReferencedTable, node.ReferencedColumns)
+	if node.Match != "" {
+		buf.Myprintf(" match %s", node.Match)
+	}
+	if node.OnDelete != "" {
+		buf.Myprintf(" on delete %s", node.OnDelete)
+	}
+	if node.OnUpdate != "" {
+		buf.Myprintf(" on update %s", node.OnUpdate)
+	}
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *ConstraintDefinition) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	return Walk(
+		visit,
+		node.Name,
+		node.IndexName,
+		node.Columns,
+		node.ReferencedTable,
+		node.ReferencedColumns,
+		node.Check,
+	)
+}
+
+// TableOptions represents the options of a table.
+type TableOptions []*TableOption
+
+// Format formats the node.
+func (node TableOptions) Format(buf *TrackedBuffer) {
+	prefix := ""
+	for _, opt := range node {
+		buf.Myprintf("%s%v", prefix, opt)
+		prefix = " "
+	}
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node TableOptions) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+// TableOption is a table option like ENGINE or
+// DEFAULT CHARSET. Name is lower-cased, and Value
+// is kept as written.
+type TableOption struct {
+	Name  string
+	Value string
+}
+
+// Format formats the node.
+func (node *TableOption) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%s=%s", node.Name, node.Value)
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *TableOption) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+// AlterSpec is one of the operations of an ALTER TABLE.
+// Columns is set for AddColumnStr, ChangeColumnStr and
+// ModifyColumnStr, and can be positioned with First or After.
+// Name is the column, index or foreign key the operation
+// applies to, and NewName its new name. Default is the new
+// default of an AlterColumnStr, nil if it is dropped.
+// Options is set for TableOptionsStr and ConvertStr.
+type AlterSpec struct {
+	Action     string
+	Columns    []*ColumnDefinition
+	Index      *IndexDefinition
+	Constraint *ConstraintDefinition
+	Name       ColIdent
+	NewName    ColIdent
+	NewTable   TableIdent
+	First      bool
+	After      ColIdent
+	Default    Expr
+	Options    TableOptions
+	OrderBy    Columns
+	Partitions string
+}
+
+// AlterSpec.Action
+const (
+	AddColumnStr         = "add column"
+	AddIndexStr          = "add index"
+	AddConstraintStr     = "add constraint"
+	AlterColumnStr       = "alter column"
+	ChangeColumnStr      = "change column"
+	ModifyColumnStr      = "modify column"
+	DropColumnStr        = "drop column"
+	DropIndexStr         = "drop index"
+	DropPrimaryKeyStr    = "drop primary key"
+	DropForeignKeyStr    = "drop foreign key"
+	RenameTableStr       = "rename to"
+	RenameIndexStr       = "rename index"
+	RenameColumnStr      = "rename column"
+	ConvertStr           = "convert to"
+	TableOptionsStr      = "table options"
+	EnableKeysStr        = "enable keys"
+	DisableKeysStr       = "disable keys"
+	DiscardTablespaceStr = "discard tablespace"
+	ImportTablespaceStr  = "import tablespace"
+	ForceRebuildStr      = "force"
+	OrderByStr           = "order by"
+	PartitionStr         = "partition"
+)
+
+// Format formats the node.
+func (node *AlterSpec) Format(buf *TrackedBuffer) {
+	switch node.Action {
+	case AddColumnStr:
+		if len(node.Columns) == 1 {
+			buf.Myprintf("%s %v", node.Action, node.Columns[0])
+			break
+		}
+		prefix := " ("
+		buf.Myprintf("%s", node.Action)
+		for _, col := range node.Columns {
+			buf.Myprintf("%s%v", prefix, col)
+			prefix = ", "
+		}
+		buf.Myprintf(")")
+	case AddIndexStr:
+		buf.Myprintf("add %v", node.Index)
+	case AddConstraintStr:
+		buf.Myprintf("add %v", node.Constraint)
+	case AlterColumnStr:
+		if node.Default == nil {
+			buf.Myprintf("%s %v drop default", node.Action, node.Name)
+			break
+		}
+		buf.Myprintf("%s %v set default ", node.Action, node.Name)
+		formatColumnValue(buf, node.Default)
+	case ChangeColumnStr:
+		buf.Myprintf("%s %v %v", node.Action, node.Name, node.Columns[0])
+	case ModifyColumnStr:
+		buf.Myprintf("%s %v", node.Action, node.Columns[0])
+	case DropColumnStr, DropIndexStr, DropForeignKeyStr:
+		buf.Myprintf("%s %v", node.Action, node.Name)
+	case RenameTableStr:
+		buf.Myprintf("%s %v", node.Action, node.NewTable)
+	case RenameIndexStr, RenameColumnStr:
+		buf.Myprintf("%s %v to %v", node.Action, node.Name, node.NewName)
+	case ConvertStr:
+		buf.Myprintf("%s", node.Action)
+		for _, opt := range node.Options {
+			buf.Myprintf(" %s %s", opt.Name, opt.Value)
+		}
+	case TableOptionsStr:
+		buf.Myprintf("%v", node.Options)
+	case OrderByStr:
+		buf.Myprintf("%s", node.Action)
+		prefix := " "
+		for _, col := range node.OrderBy {
+			buf.Myprintf("%s%v", prefix, col)
+			prefix = ", "
+		}
+	case PartitionStr:
+		buf.Myprintf("%s", node.Partitions)
+	default:
+		buf.Myprintf("%s", node.Action)
+	}
+	if node.First {
+		buf.Myprintf(" first")
+	} else if !node.After.IsEmpty() {
+		buf.Myprintf(" after %v", node.After)
+	}
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *AlterSpec) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	for _, n := range node.Columns {
+		if err := Walk(visit, n); err != nil {
+			return err
+		}
+	}
+	return Walk(
+		visit,
+		node.Index,
+		node.Constraint,
+		node.Name,
+		node.NewName,
+		node.NewTable,
+		node.After,
+		node.Default,
+		node.OrderBy,
+	)
+}
+
 // Other represents a SHOW, DESCRIBE, or EXPLAIN statement.
 // It should be used only as an indicator. It does not contain
 // the full AST for the statement.
@@ -450,6 +1032,33 @@ func (node *Other) WalkSubtree(visit Visit) error {
 	return nil
 }
 
+// Savepoint represents a SAVEPOINT, ROLLBACK TO SAVEPOINT
+// or RELEASE SAVEPOINT statement.
+type Savepoint struct {
+	Action string
+	Name   ColIdent
+}
+
+// Savepoint.Action
+const (
+	SavepointStr  = "savepoint"
+	RollbackToStr = "rollback to savepoint"
+	ReleaseStr    = "release savepoint"
+)
+
+// Format formats the node.
+func (node *Savepoint) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%s %v", node.Action, node.Name)
+}
+
+// WalkSubtree walks the nodes of the subtree.
+func (node *Savepoint) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	return Walk(visit, node.Name)
+}
+
 // Comments represents a list of comments.
 type Comments [][]byte
 
@@ -1145,6 +1754,7 @@ const (
 	HexNum
 	HexVal
 	ValArg
+	BitVal
 )
 
 // SQLVal represents a single value.
@@ -1178,6 +1788,12 @@ func NewHexVal(in []byte) *SQLVal {
 	return &SQLVal{Type: HexVal, Val: in}
 }
 
+// NewBitVal builds a new BitVal, which represents a b'...'
+// value. It can only appear in table definitions.
+func NewBitVal(in []byte) *SQLVal {
+	return &SQLVal{Type: BitVal, Val: in}
+}
+
 // NewValArg builds a new ValArg.
 func NewValArg(in []byte) *SQLVal {
 	return &SQLVal{Type: ValArg, Val: in}
@@ -1195,6 +1811,8 @@ func (node *SQLVal) Format(buf *TrackedBuffer) {
 		buf.Myprintf("X'%s'", []byte(node.Val))
 	case ValArg:
 		buf.WriteArg(string(node.Val))
+	case BitVal:
+		buf.Myprintf("B'%s'", []byte(node.Val))
 	default:
 		panic("unexpected")
 	}
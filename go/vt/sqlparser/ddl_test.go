@@ -0,0 +1,249 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCreateTable(t *testing.T) {
+	testcases := []struct {
+		input  string
+		output string
+	}{{
+		// SHOW CREATE TABLE output.
+		input: "CREATE TABLE `user` (\n" +
+			"  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,\n" +
+			"  `name` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '',\n" +
+			"  `email` varchar(128) DEFAULT NULL COMMENT 'primary email',\n" +
+			"  `status` enum('active','disabled','it''s') NOT NULL DEFAULT 'active',\n" +
+			"  `balance` decimal(10,2) NOT NULL DEFAULT '0.00',\n" +
+			"  `flags` bit(1) NOT NULL DEFAULT b'0',\n" +
+			"  `score` double NOT NULL DEFAULT -1,\n" +
+			"  `created` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+			"  `updated` timestamp(6) NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP(6),\n" +
+			"  `data` json DEFAULT NULL,\n" +
+			"  `name_length` int(11) GENERATED ALWAYS AS (char_length(`name`)) VIRTUAL,\n" +
+			"  PRIMARY KEY (`id`),\n" +
+			"  UNIQUE KEY `email` (`email`),\n" +
+			"  KEY `name_created` (`name`(10),`created`) USING BTREE COMMENT 'lookup',\n" +
+			"  FULLTEXT KEY `ft_name` (`name`)\n" +
+			") ENGINE=InnoDB AUTO_INCREMENT=42 DEFAULT CHARSET=utf8 COMMENT='users'",
+		output: "create table user (\n" +
+			"\tid bigint(20) unsigned not null auto_increment,\n" +
+			"\tname varchar(255) character set utf8mb4 collate utf8mb4_bin not null default '',\n" +
+			"\temail varchar(128) default null comment 'primary email',\n" +
+			"\tstatus enum('active', 'disabled', 'it\\'s') not null default 'active',\n" +
+			"\tbalance decimal(10, 2) not null default '0.00',\n" +
+			"\tflags bit(1) not null default B'0',\n" +
+			"\tscore double not null default -1,\n" +
+			"\tcreated timestamp not null default current_timestamp(),\n" +
+			"\tupdated timestamp(6) null default null on update current_timestamp(6),\n" +
+			"\tdata json default null,\n" +
+			"\tname_length int(11) as (char_length(name)) virtual,\n" +
+			"\tprimary key (id),\n" +
+			"\tunique key email (email),\n" +
+			"\tkey name_created (name(10), created) using btree comment 'lookup',\n" +
+			"\tfulltext key ft_name (name)\n" +
+			") engine=InnoDB auto_increment=42 default charset=utf8 comment='users'",
+	}, {
+		// mysqldump output, with foreign keys and partitions.
+		input: "CREATE TABLE `orders` (\n" +
+			"  `id` int(11) NOT NULL,\n" +
+			"  `user_id` bigint(20) unsigned NOT NULL,\n" +
+			"  `created` date NOT NULL,\n" +
+			"  PRIMARY KEY (`id`,`created`),\n" +
+			"  KEY `fk_user` (`user_id`),\n" +
+			"  CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION\n" +
+			") ENGINE=InnoDB DEFAULT CHARSET=latin1\n" +
+			"/*!50100 PARTITION BY RANGE (year(created))\n" +
+			"(PARTITION p0 VALUES LESS THAN (2016) ENGINE = InnoDB,\n" +
+			" PARTITION p1 VALUES LESS THAN MAXVALUE ENGINE = InnoDB) */;",
+		output: "create table orders (\n" +
+			"\tid int(11) not null,\n" +
+			"\tuser_id bigint(20) unsigned not null,\n" +
+			"\tcreated date not null,\n" +
+			"\tprimary key (id, created),\n" +
+			"\tkey fk_user (user_id),\n" +
+			"\tconstraint fk_user foreign key (user_id) references user (id) on delete cascade on update no action\n" +
+			") engine=InnoDB default charset=latin1 PARTITION BY RANGE (year(created))\n" +
+			"(PARTITION p0 VALUES LESS THAN (2016) ENGINE = InnoDB,\n" +
+			" PARTITION p1 VALUES LESS THAN MAXVALUE ENGINE = InnoDB)",
+	}, {
+		input: "create table if not exists t (\n" +
+			"  a int primary key,\n" +
+			"  b varchar(10) not null unique,\n" +
+			"  c int default (a + 1),\n" +
+			"  d float(7, 4) zerofill,\n" +
+			"  e set('x', 'y'),\n" +
+			"  f double precision,\n" +
+			"  constraint u unique (b, c),\n" +
+			"  spatial index g (d),\n" +
+			"  constraint chk check (a > 0),\n" +
+			"  foreign key (c) references db.p (id)\n" +
+			") engine innodb, row_format = compressed, key_block_size 8",
+		output: "create table if not exists t (\n" +
+			"\ta int primary key,\n" +
+			"\tb varchar(10) not null unique key,\n" +
+			"\tc int default (a + 1),\n" +
+			"\td float(7, 4) zerofill,\n" +
+			"\te set('x', 'y'),\n" +
+			"\tf double precision,\n" +
+			"\tunique key u (b, c),\n" +
+			"\tspatial key g (d),\n" +
+			"\tconstraint chk check (a > 0),\n" +
+			"\tforeign key (c) references db.p (id)\n" +
+			") engine=innodb row_format=compressed key_block_size=8",
+	}}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Errorf("input: %s, err: %v", tcase.input, err)
+			continue
+		}
+		ddl, ok := tree.(*DDL)
+		if !ok || ddl.TableSpec == nil {
+			t.Errorf("input: %s, got %#v, want a DDL with a TableSpec", tcase.input, tree)
+			continue
+		}
+		out := String(tree)
+		if out != tcase.output {
+			t.Errorf("Parse(%s):\n%s, want\n%s", tcase.input, out, tcase.output)
+			continue
+		}
+		// The output must parse to the same statement.
+		tree, err = Parse(out)
+		if err != nil {
+			t.Errorf("input: %s, err: %v", out, err)
+			continue
+		}
+		if got := String(tree); got != out {
+			t.Errorf("Parse(%s):\n%s, want the same", out, got)
+		}
+	}
+}
+
+func TestCreateTableSpec(t *testing.T) {
+	tree, err := Parse("create table t (id int(10) unsigned not null auto_increment comment 'c', name varchar(64) default 'x', primary key (id), key name (name desc)) engine=InnoDB default charset=utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &DDL{
+		Action:  CreateStr,
+		NewName: NewTableIdent("t"),
+		TableSpec: &TableSpec{
+			Columns: []*ColumnDefinition{{
+				Name: NewColIdent("id"),
+				Type: &ColumnType{
+					Type:          "int",
+					Length:        NewIntVal([]byte("10")),
+					Unsigned:      true,
+					NotNull:       true,
+					Autoincrement: true,
+					Comment:       NewStrVal([]byte("c")),
+				},
+			}, {
+				Name: NewColIdent("name"),
+				Type: &ColumnType{
+					Type:    "varchar",
+					Length:  NewIntVal([]byte("64")),
+					Default: NewStrVal([]byte("x")),
+				},
+			}},
+			Indexes: []*IndexDefinition{{
+				Type:    IndexTypePrimary,
+				Columns: []*IndexColumn{{Column: NewColIdent("id")}},
+			}, {
+				Type:    IndexTypeKey,
+				Name:    NewColIdent("name"),
+				Columns: []*IndexColumn{{Column: NewColIdent("name"), Order: DescScr}},
+			}},
+			Options: TableOptions{
+				{Name: "engine", Value: "InnoDB"},
+				{Name: "default charset", Value: "utf8"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("Parse:\n%#v, want\n%#v", tree, want)
+	}
+}
+
+func TestAlterTableSpecs(t *testing.T) {
+	tree, err := Parse("alter table a add column b int after c, drop index d, rename to e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &DDL{
+		Action:  AlterStr,
+		Table:   NewTableIdent("a"),
+		NewName: NewTableIdent("e"),
+		AlterSpecs: []*AlterSpec{{
+			Action: AddColumnStr,
+			Columns: []*ColumnDefinition{{
+				Name: NewColIdent("b"),
+				Type: &ColumnType{Type: "int"},
+			}},
+			After: NewColIdent("c"),
+		}, {
+			Action: DropIndexStr,
+			Name:   NewColIdent("d"),
+		}, {
+			Action:   RenameTableStr,
+			NewTable: NewTableIdent("e"),
+		}},
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("Parse:\n%#v, want\n%#v", tree, want)
+	}
+}
+
+func TestParseDDLTableNames(t *testing.T) {
+	// Only the table names of these statements are parsed.
+	testcases := []struct {
+		input  string
+		output string
+	}{{
+		input:  "create table a like b",
+		output: "create table a",
+	}, {
+		input:  "create table a (b int) select * from c",
+		output: "create table a",
+	}, {
+		input:  "create table a (b int",
+		output: "create table a",
+	}, {
+		input:  "create table a (b int) engine",
+		output: "create table a",
+	}, {
+		input:  "alter table a foo",
+		output: "alter table a",
+	}, {
+		input:  "alter table a modify b int c",
+		output: "alter table a",
+	}, {
+		input:  "alter table a add column b int c",
+		output: "alter table a",
+	}, {
+		input:  "alter table a add column b int, rename to c, foo",
+		output: "alter table a",
+	}}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Errorf("input: %s, err: %v", tcase.input, err)
+			continue
+		}
+		ddl, ok := tree.(*DDL)
+		if !ok || ddl.TableSpec != nil || ddl.AlterSpecs != nil {
+			t.Errorf("input: %s, got %#v, want a DDL with only the table names", tcase.input, tree)
+			continue
+		}
+		if out := String(tree); out != tcase.output {
+			t.Errorf("Parse(%s): %s, want %s", tcase.input, out, tcase.output)
+		}
+	}
+}
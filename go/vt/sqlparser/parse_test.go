@@ -583,6 +583,61 @@ func TestValid(t *testing.T) {
 	}, {
 		input:  "alter table a import foo",
 		output: "alter table a",
+	}, {
+		input: "alter table a add column b int(11) not null default '0' after c",
+	}, {
+		input:  "alter table a add b varchar(10) first, add c int",
+		output: "alter table a add column b varchar(10) first, add column c int",
+	}, {
+		input: "alter table a add column (b int, c text)",
+	}, {
+		input:  "alter table a add index b (c, d(10))",
+		output: "alter table a add key b (c, d(10))",
+	}, {
+		input:  "alter table a add unique key b using btree (c)",
+		output: "alter table a add unique key b (c) using btree",
+	}, {
+		input: "alter table a add primary key (b)",
+	}, {
+		input: "alter table a add constraint fk foreign key (b) references c (d) on delete cascade",
+	}, {
+		input:  "alter table a alter column b set default 1, alter c drop default",
+		output: "alter table a alter column b set default 1, alter column c drop default",
+	}, {
+		input:  "alter table a change b c bigint(20) unsigned not null",
+		output: "alter table a change column b c bigint(20) unsigned not null",
+	}, {
+		input: "alter table a modify column b varchar(255) character set utf8mb4 collate utf8mb4_bin",
+	}, {
+		input:  "alter table a drop column b, drop key c, drop primary key, drop foreign key d",
+		output: "alter table a drop column b, drop index c, drop primary key, drop foreign key d",
+	}, {
+		input: "alter table a rename index b to c, rename column d to e",
+	}, {
+		input: "alter table a add column b int, rename to c",
+	}, {
+		input: "alter table a convert to character set utf8mb4 collate utf8mb4_unicode_ci",
+	}, {
+		input:  "alter table a engine = InnoDB, algorithm = inplace, lock = none",
+		output: "alter table a engine=InnoDB, algorithm=inplace, lock=none",
+	}, {
+		input:  "alter table a default charset utf8 comment 'b'",
+		output: "alter table a default charset=utf8 comment='b'",
+	}, {
+		input: "alter table a enable keys, disable keys, discard tablespace, import tablespace, force",
+	}, {
+		input: "alter table a order by b, c",
+	}, {
+		input: "alter table a add column b int, drop partition p0, p1",
+	}, {
+		input: "alter table a partition by hash (b) partitions 4",
+	}, {
+		input: "alter table a TRUNCATE PARTITION p0",
+	}, {
+		input:  "alter table a /*!50100 remove partitioning */;",
+		output: "alter table a remove partitioning",
+	}, {
+		input: "alter table comment add column first int after action",
 	}, {
 		input:  "alter table a rename b",
 		output: "rename table a b",
@@ -702,6 +757,19 @@ func TestValid(t *testing.T) {
 		input: "select name, group_concat(score) from t group by name",
 	}, {
 		input: "select name, group_concat(distinct id, score order by id desc separator ':') from t group by name",
+	}, {
+		input: "savepoint a",
+	}, {
+		input:  "SAVEPOINT `b c`;",
+		output: "savepoint `b c`",
+	}, {
+		input:  "rollback to a",
+		output: "rollback to savepoint a",
+	}, {
+		input:  "/* comment */ rollback work to savepoint a",
+		output: "rollback to savepoint a",
+	}, {
+		input: "release savepoint a",
 	}}
 
 	for _, tcase := range validSQL {
@@ -727,6 +795,22 @@ func TestValid(t *testing.T) {
 	}
 }
 
+func TestInvalidSavepoint(t *testing.T) {
+	invalidSQL := []string{
+		"savepoint",
+		"savepoint a b",
+		"savepoint a; select 1",
+		"release a",
+		"rollback a",
+		"rollback to savepoint",
+	}
+	for _, sql := range invalidSQL {
+		if tree, err := Parse(sql); err == nil {
+			t.Errorf("Parse(%s): %v, want error", sql, String(tree))
+		}
+	}
+}
+
 func TestCaseSensitivity(t *testing.T) {
 	validSQL := []struct {
 		input  string
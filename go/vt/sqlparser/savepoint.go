@@ -0,0 +1,61 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import "strings"
+
+// ParseSavepoint recognizes the savepoint statements, which are
+// 'SAVEPOINT name', 'ROLLBACK [WORK] TO [SAVEPOINT] name' and
+// 'RELEASE SAVEPOINT name'. It returns nil if sql is not one of
+// them. The statements are recognized outside of the grammar
+// because they don't interact with any other construct.
+func ParseSavepoint(sql string) *Savepoint {
+	var words []string
+	var name string
+	tokenizer := NewStringTokenizer(sql)
+scan:
+	for {
+		typ, val := tokenizer.Scan()
+		switch typ {
+		case 0:
+			break scan
+		case COMMENT:
+		case ';':
+			// Only a trailing semicolon is allowed.
+			if typ, _ := tokenizer.Scan(); typ != 0 {
+				return nil
+			}
+			break scan
+		case ID, TO, UNUSED:
+			if len(words) == 5 {
+				return nil
+			}
+			name = string(val)
+			words = append(words, strings.ToLower(name))
+		default:
+			return nil
+		}
+	}
+	if len(words) < 2 || words[len(words)-1] == "savepoint" {
+		return nil
+	}
+
+	// The last word is the name, the others define the action.
+	var action string
+	switch strings.Join(words[:len(words)-1], " ") {
+	case "savepoint":
+		action = SavepointStr
+	case "rollback to", "rollback work to", "rollback to savepoint", "rollback work to savepoint":
+		action = RollbackToStr
+	case "release savepoint":
+		action = ReleaseStr
+	default:
+		return nil
+	}
+	return &Savepoint{
+		Action: action,
+		Name:   NewColIdent(name),
+	}
+}
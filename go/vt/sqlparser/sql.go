@@ -4,6 +4,8 @@ package sqlparser
 import __yyfmt__ "fmt"
 
 //line ./go/vt/sqlparser/sql.y:6
+import "strings"
+
 func setParseTree(yylex interface{}, stmt Statement) {
 	yylex.(*Tokenizer).ParseTree = stmt
 }
@@ -28,45 +30,101 @@ func forceEOF(yylex interface{}) {
 	yylex.(*Tokenizer).ForceEOF = true
 }
 
-//line ./go/vt/sqlparser/sql.y:34
+func setDDL(yylex interface{}, ddl *DDL) {
+	yylex.(*Tokenizer).partialDDL = ddl
+}
+
+func skipToEnd(yylex interface{}, n int) string {
+	return yylex.(*Tokenizer).skipToEnd(n)
+}
+
+// tableOptionNames are the table options named by an identifier.
+// ALGORITHM is an ALTER TABLE clause, but it shares their syntax.
+var tableOptionNames = map[string]bool{
+	"algorithm":          true,
+	"avg_row_length":     true,
+	"checksum":           true,
+	"compression":        true,
+	"connection":         true,
+	"delay_key_write":    true,
+	"encryption":         true,
+	"engine":             true,
+	"insert_method":      true,
+	"max_rows":           true,
+	"min_rows":           true,
+	"pack_keys":          true,
+	"password":           true,
+	"row_format":         true,
+	"stats_auto_recalc":  true,
+	"stats_persistent":   true,
+	"stats_sample_pages": true,
+	"tablespace":         true,
+}
+
+// partitionOperations are the ALTER TABLE operations on
+// partitions that start with an identifier.
+var partitionOperations = map[string]bool{
+	"coalesce":   true,
+	"exchange":   true,
+	"rebuild":    true,
+	"reorganize": true,
+	"repair":     true,
+	"truncate":   true,
+}
+
+//line ./go/vt/sqlparser/sql.y:78
 type yySymType struct {
-	yys              int
-	empty            struct{}
-	statement        Statement
-	selStmt          SelectStatement
-	byt              byte
-	bytes            []byte
-	bytes2           [][]byte
-	str              string
-	selectExprs      SelectExprs
-	selectExpr       SelectExpr
-	columns          Columns
-	colName          *ColName
-	tableExprs       TableExprs
-	tableExpr        TableExpr
-	tableName        *TableName
-	indexHints       *IndexHints
-	expr             Expr
-	exprs            Exprs
-	boolVal          BoolVal
-	colTuple         ColTuple
-	values           Values
-	valTuple         ValTuple
-	subquery         *Subquery
-	caseExpr         *CaseExpr
-	whens            []*When
-	when             *When
-	orderBy          OrderBy
-	order            *Order
-	limit            *Limit
-	insRows          InsertRows
-	updateExprs      UpdateExprs
-	updateExpr       *UpdateExpr
-	colIdent         ColIdent
-	colIdents        []ColIdent
-	tableIdent       TableIdent
-	convertType      *ConvertType
-	aliasedTableName *AliasedTableExpr
+	yys                  int
+	empty                struct{}
+	statement            Statement
+	selStmt              SelectStatement
+	byt                  byte
+	bytes                []byte
+	bytes2               [][]byte
+	str                  string
+	selectExprs          SelectExprs
+	selectExpr           SelectExpr
+	columns              Columns
+	colName              *ColName
+	tableExprs           TableExprs
+	tableExpr            TableExpr
+	tableName            *TableName
+	indexHints           *IndexHints
+	expr                 Expr
+	exprs                Exprs
+	boolVal              BoolVal
+	colTuple             ColTuple
+	values               Values
+	valTuple             ValTuple
+	subquery             *Subquery
+	caseExpr             *CaseExpr
+	whens                []*When
+	when                 *When
+	orderBy              OrderBy
+	order                *Order
+	limit                *Limit
+	insRows              InsertRows
+	updateExprs          UpdateExprs
+	updateExpr           *UpdateExpr
+	colIdent             ColIdent
+	colIdents            []ColIdent
+	tableIdent           TableIdent
+	convertType          *ConvertType
+	aliasedTableName     *AliasedTableExpr
+	ddl                  *DDL
+	tableSpec            *TableSpec
+	columnDefinition     *ColumnDefinition
+	columnDefinitions    []*ColumnDefinition
+	columnType           *ColumnType
+	indexDefinition      *IndexDefinition
+	indexColumn          *IndexColumn
+	indexColumns         []*IndexColumn
+	constraintDefinition *ConstraintDefinition
+	tableOption          *TableOption
+	tableOptions         TableOptions
+	alterSpec            *AlterSpec
+	alterSpecs           []*AlterSpec
+	strs                 []string
 }
 
 const LEX_ERROR = 57346
@@ -92,36 +150,36 @@ const ASC = 57365
 const DESC = 57366
 const INTO = 57367
 const DUPLICATE = 57368
-const KEY = 57369
-const DEFAULT = 57370
-const SET = 57371
-const LOCK = 57372
-const VALUES = 57373
-const LAST_INSERT_ID = 57374
-const NEXT = 57375
-const VALUE = 57376
-const SQL_NO_CACHE = 57377
-const SQL_CACHE = 57378
-const JOIN = 57379
-const STRAIGHT_JOIN = 57380
-const LEFT = 57381
-const RIGHT = 57382
-const INNER = 57383
-const OUTER = 57384
-const CROSS = 57385
-const NATURAL = 57386
-const USE = 57387
-const FORCE = 57388
-const ON = 57389
-const ID = 57390
-const HEX = 57391
-const STRING = 57392
-const INTEGRAL = 57393
-const FLOAT = 57394
-const HEXNUM = 57395
-const VALUE_ARG = 57396
-const LIST_ARG = 57397
-const COMMENT = 57398
+const DEFAULT = 57369
+const SET = 57370
+const LOCK = 57371
+const VALUES = 57372
+const LAST_INSERT_ID = 57373
+const NEXT = 57374
+const VALUE = 57375
+const SQL_NO_CACHE = 57376
+const SQL_CACHE = 57377
+const JOIN = 57378
+const STRAIGHT_JOIN = 57379
+const LEFT = 57380
+const RIGHT = 57381
+const INNER = 57382
+const OUTER = 57383
+const CROSS = 57384
+const NATURAL = 57385
+const USE = 57386
+const FORCE = 57387
+const ON = 57388
+const ID = 57389
+const HEX = 57390
+const STRING = 57391
+const INTEGRAL = 57392
+const FLOAT = 57393
+const HEXNUM = 57394
+const VALUE_ARG = 57395
+const LIST_ARG = 57396
+const COMMENT = 57397
+const BIT_LITERAL = 57398
 const NULL = 57399
 const TRUE = 57400
 const FALSE = 57401
@@ -150,51 +208,95 @@ const UNARY = 57423
 const COLLATE = 57424
 const BINARY = 57425
 const INTERVAL = 57426
-const JSON_EXTRACT_OP = 57427
-const JSON_UNQUOTE_EXTRACT_OP = 57428
-const CREATE = 57429
-const ALTER = 57430
-const DROP = 57431
-const RENAME = 57432
-const ANALYZE = 57433
-const TABLE = 57434
-const INDEX = 57435
-const VIEW = 57436
-const TO = 57437
-const IGNORE = 57438
-const IF = 57439
-const UNIQUE = 57440
-const USING = 57441
-const SHOW = 57442
-const DESCRIBE = 57443
-const EXPLAIN = 57444
-const INTEGER = 57445
-const CHARACTER = 57446
-const CURRENT_TIMESTAMP = 57447
-const DATABASE = 57448
-const CURRENT_DATE = 57449
-const UNIX_TIMESTAMP = 57450
-const CURRENT_TIME = 57451
-const LOCALTIME = 57452
-const LOCALTIMESTAMP = 57453
-const UTC_DATE = 57454
-const UTC_TIME = 57455
-const UTC_TIMESTAMP = 57456
-const REPLACE = 57457
-const CONVERT = 57458
-const CAST = 57459
-const GROUP_CONCAT = 57460
-const SEPARATOR = 57461
-const MATCH = 57462
-const AGAINST = 57463
-const BOOLEAN = 57464
-const MODE = 57465
-const LANGUAGE = 57466
-const WITH = 57467
-const QUERY = 57468
-const EXPANSION = 57469
-const SHARE = 57470
-const UNUSED = 57471
+const UNIQUE = 57427
+const KEY = 57428
+const JSON_EXTRACT_OP = 57429
+const JSON_UNQUOTE_EXTRACT_OP = 57430
+const CREATE = 57431
+const ALTER = 57432
+const DROP = 57433
+const RENAME = 57434
+const ANALYZE = 57435
+const TABLE = 57436
+const INDEX = 57437
+const VIEW = 57438
+const TO = 57439
+const IGNORE = 57440
+const IF = 57441
+const USING = 57442
+const SHOW = 57443
+const DESCRIBE = 57444
+const EXPLAIN = 57445
+const ADD = 57446
+const CHANGE = 57447
+const COLUMN = 57448
+const CONSTRAINT = 57449
+const PRIMARY = 57450
+const FOREIGN = 57451
+const FULLTEXT = 57452
+const SPATIAL = 57453
+const KEYS = 57454
+const REFERENCES = 57455
+const RESTRICT = 57456
+const CASCADE = 57457
+const CHECK = 57458
+const GENERATED = 57459
+const VIRTUAL = 57460
+const STORED = 57461
+const ZEROFILL = 57462
+const PRECISION = 57463
+const PARTITION = 57464
+const OPTIMIZE = 57465
+const DATA_TYPE = 57466
+const ACTION = 57467
+const AFTER = 57468
+const ALWAYS = 57469
+const AUTO_INCREMENT = 57470
+const CHARSET = 57471
+const COMMENT_KEYWORD = 57472
+const DIRECTORY = 57473
+const DISABLE = 57474
+const DISCARD = 57475
+const ENABLE = 57476
+const ENFORCED = 57477
+const FIRST = 57478
+const IMPORT = 57479
+const INVISIBLE = 57480
+const KEY_BLOCK_SIZE = 57481
+const MODIFY = 57482
+const NO = 57483
+const PARSER = 57484
+const PARTITIONING = 57485
+const SIGNED = 57486
+const UNSIGNED = 57487
+const VISIBLE = 57488
+const INTEGER = 57489
+const CHARACTER = 57490
+const CURRENT_TIMESTAMP = 57491
+const DATABASE = 57492
+const CURRENT_DATE = 57493
+const UNIX_TIMESTAMP = 57494
+const CURRENT_TIME = 57495
+const LOCALTIME = 57496
+const LOCALTIMESTAMP = 57497
+const UTC_DATE = 57498
+const UTC_TIME = 57499
+const UTC_TIMESTAMP = 57500
+const REPLACE = 57501
+const CONVERT = 57502
+const CAST = 57503
+const GROUP_CONCAT = 57504
+const SEPARATOR = 57505
+const MATCH = 57506
+const AGAINST = 57507
+const BOOLEAN = 57508
+const MODE = 57509
+const LANGUAGE = 57510
+const WITH = 57511
+const QUERY = 57512
+const EXPANSION = 57513
+const SHARE = 57514
+const UNUSED = 57515
 
 var yyToknames = [...]string{
 	"$end",
@@ -223,7 +325,6 @@ var yyToknames = [...]string{
 	"DESC",
 	"INTO",
 	"DUPLICATE",
-	"KEY",
 	"DEFAULT",
 	"SET",
 	"LOCK",
@@ -256,6 +357,7 @@ var yyToknames = [...]string{
 	"VALUE_ARG",
 	"LIST_ARG",
 	"COMMENT",
+	"BIT_LITERAL",
 	"NULL",
 	"TRUE",
 	"FALSE",
@@ -298,6 +400,8 @@ var yyToknames = [...]string{
 	"BINARY",
 	"INTERVAL",
 	"'.'",
+	"UNIQUE",
+	"KEY",
 	"JSON_EXTRACT_OP",
 	"JSON_UNQUOTE_EXTRACT_OP",
 	"CREATE",
@@ -311,11 +415,53 @@ var yyToknames = [...]string{
 	"TO",
 	"IGNORE",
 	"IF",
-	"UNIQUE",
 	"USING",
 	"SHOW",
 	"DESCRIBE",
 	"EXPLAIN",
+	"ADD",
+	"CHANGE",
+	"COLUMN",
+	"CONSTRAINT",
+	"PRIMARY",
+	"FOREIGN",
+	"FULLTEXT",
+	"SPATIAL",
+	"KEYS",
+	"REFERENCES",
+	"RESTRICT",
+	"CASCADE",
+	"CHECK",
+	"GENERATED",
+	"VIRTUAL",
+	"STORED",
+	"ZEROFILL",
+	"PRECISION",
+	"PARTITION",
+	"OPTIMIZE",
+	"DATA_TYPE",
+	"ACTION",
+	"AFTER",
+	"ALWAYS",
+	"AUTO_INCREMENT",
+	"CHARSET",
+	"COMMENT_KEYWORD",
+	"DIRECTORY",
+	"DISABLE",
+	"DISCARD",
+	"ENABLE",
+	"ENFORCED",
+	"FIRST",
+	"IMPORT",
+	"INVISIBLE",
+	"KEY_BLOCK_SIZE",
+	"MODIFY",
+	"NO",
+	"PARSER",
+	"PARTITIONING",
+	"SIGNED",
+	"UNSIGNED",
+	"VISIBLE",
 	"INTEGER",
 	"CHARACTER",
 	"CURRENT_TIMESTAMP",
@@ -356,418 +502,646 @@ var yyExca = [...]int{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 70,
-	101, 295,
-	-2, 294,
-	-1, 153,
-	48, 206,
-	-2, 178,
+	-1, 51,
+	102, 118,
+	125, 118,
+	126, 118,
+	133, 118,
+	139, 204,
+	-2, 211,
+	-1, 110,
+	101, 471,
+	-2, 470,
+	-1, 347,
+	47, 387,
+	-2, 359,
 }
 
-const yyNprod = 299
+const yyNprod = 501
 const yyPrivate = 57344
 
 var yyTokenNames []string
 var yyStates []string
 
-const yyLast = 1408
+const yyLast = 2109
 
 var yyAct = [...]int{
 
-	299, 205, 307, 137, 453, 141, 65, 222, 273, 392,
-	422, 452, 319, 371, 373, 287, 372, 202, 386, 131,
-	203, 364, 84, 140, 138, 28, 49, 61, 536, 542,
-	535, 541, 528, 540, 534, 539, 538, 293, 533, 456,
-	527, 487, 67, 37, 168, 39, 343, 190, 344, 40,
-	80, 72, 50, 51, 126, 128, 42, 143, 43, 517,
-	236, 235, 245, 246, 238, 239, 240, 241, 242, 243,
-	244, 237, 150, 43, 247, 282, 196, 14, 15, 16,
-	17, 272, 3, 45, 46, 47, 247, 90, 221, 108,
-	194, 220, 98, 407, 345, 219, 73, 67, 124, 76,
-	18, 105, 134, 48, 107, 67, 44, 226, 225, 68,
-	100, 52, 74, 198, 437, 77, 523, 123, 518, 257,
-	258, 434, 127, 302, 227, 291, 67, 215, 277, 187,
-	101, 97, 66, 237, 224, 526, 247, 530, 228, 201,
-	122, 75, 70, 343, 218, 344, 227, 458, 259, 260,
-	261, 262, 263, 264, 365, 112, 226, 225, 116, 103,
-	408, 343, 169, 344, 68, 93, 225, 193, 195, 192,
-	87, 274, 68, 227, 185, 19, 20, 22, 21, 23,
-	478, 379, 227, 365, 326, 415, 276, 94, 24, 25,
-	26, 345, 524, 68, 217, 507, 506, 255, 324, 325,
-	323, 214, 240, 241, 242, 243, 244, 237, 197, 345,
-	247, 70, 489, 492, 226, 225, 75, 63, 280, 96,
-	63, 460, 394, 395, 396, 104, 303, 310, 311, 446,
-	447, 227, 133, 318, 81, 292, 327, 328, 329, 330,
-	331, 332, 333, 334, 335, 336, 337, 338, 339, 340,
-	341, 121, 342, 298, 317, 122, 75, 99, 296, 117,
-	129, 122, 118, 279, 63, 322, 349, 30, 353, 226,
-	225, 356, 357, 360, 362, 320, 443, 75, 289, 290,
-	301, 346, 347, 350, 361, 354, 227, 285, 321, 352,
-	238, 239, 240, 241, 242, 243, 244, 237, 444, 274,
-	247, 89, 256, 369, 378, 370, 385, 63, 442, 96,
-	390, 393, 301, 265, 266, 267, 514, 301, 268, 269,
-	270, 271, 226, 225, 388, 389, 284, 63, 398, 399,
-	400, 420, 301, 313, 315, 316, 397, 348, 314, 227,
-	294, 355, 99, 376, 359, 284, 301, 439, 301, 420,
-	366, 402, 411, 301, 382, 405, 406, 409, 132, 410,
-	122, 289, 79, 412, 185, 288, 223, 416, 320, 403,
-	404, 361, 301, 383, 500, 384, 297, 387, 387, 387,
-	14, 321, 304, 305, 306, 414, 294, 438, 278, 440,
-	441, 433, 300, 301, 417, 75, 99, 290, 111, 226,
-	225, 284, 114, 503, 450, 449, 472, 226, 225, 120,
-	82, 473, 451, 470, 502, 469, 227, 459, 471, 468,
-	454, 455, 122, 41, 227, 63, 375, 436, 122, 474,
-	211, 428, 429, 210, 477, 376, 465, 464, 467, 466,
-	531, 475, 56, 57, 482, 483, 393, 86, 377, 14,
-	508, 520, 485, 488, 484, 493, 494, 59, 491, 91,
-	85, 60, 496, 521, 498, 274, 69, 435, 381, 497,
-	188, 110, 499, 432, 213, 53, 54, 86, 376, 376,
-	376, 376, 308, 463, 457, 67, 504, 505, 162, 161,
-	163, 164, 165, 166, 309, 223, 167, 209, 462, 509,
-	510, 62, 511, 512, 31, 208, 419, 366, 513, 132,
-	480, 78, 481, 515, 64, 83, 529, 525, 522, 479,
-	33, 34, 35, 36, 14, 532, 62, 30, 95, 32,
-	27, 62, 1, 431, 92, 286, 537, 189, 102, 38,
-	377, 281, 191, 106, 71, 297, 109, 424, 427, 428,
-	429, 425, 68, 426, 430, 207, 62, 501, 119, 216,
-	358, 95, 519, 445, 130, 391, 153, 152, 186, 461,
-	424, 427, 428, 429, 425, 199, 426, 430, 200, 418,
-	206, 413, 275, 377, 377, 377, 377, 363, 154, 142,
-	351, 295, 139, 312, 115, 212, 229, 135, 125, 88,
-	423, 155, 421, 374, 283, 486, 75, 516, 55, 175,
-	113, 29, 58, 13, 12, 11, 490, 10, 122, 495,
-	301, 70, 162, 161, 163, 164, 165, 166, 9, 8,
-	167, 159, 160, 7, 6, 136, 148, 5, 184, 236,
-	235, 245, 246, 238, 239, 240, 241, 242, 243, 244,
-	237, 4, 2, 247, 0, 0, 0, 0, 145, 146,
-	204, 0, 0, 0, 173, 0, 147, 0, 0, 144,
-	149, 0, 0, 0, 62, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 170, 0, 0, 0, 448, 0,
-	0, 0, 176, 171, 177, 172, 178, 182, 183, 181,
-	180, 179, 174, 156, 157, 151, 0, 158, 236, 235,
-	245, 246, 238, 239, 240, 241, 242, 243, 244, 237,
-	0, 0, 247, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 206, 0, 206, 245, 246, 238, 239, 240,
-	241, 242, 243, 244, 237, 367, 0, 247, 368, 0,
-	0, 206, 62, 0, 139, 0, 0, 0, 380, 0,
-	0, 0, 0, 155, 0, 0, 0, 0, 0, 0,
-	0, 175, 0, 0, 0, 0, 0, 0, 0, 0,
-	122, 401, 301, 70, 162, 161, 163, 164, 165, 166,
-	0, 0, 167, 159, 160, 0, 0, 136, 148, 0,
-	184, 236, 235, 245, 246, 238, 239, 240, 241, 242,
-	243, 244, 237, 0, 0, 247, 0, 0, 206, 206,
-	145, 146, 204, 0, 0, 0, 173, 0, 147, 0,
-	0, 144, 149, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 62, 0, 170, 0, 0, 0,
-	0, 0, 0, 0, 176, 171, 177, 172, 178, 182,
-	183, 181, 180, 179, 174, 156, 157, 151, 0, 158,
-	0, 0, 0, 0, 139, 0, 0, 0, 0, 0,
-	0, 0, 0, 155, 0, 0, 0, 62, 62, 62,
-	62, 175, 0, 0, 0, 0, 0, 0, 476, 0,
-	122, 0, 0, 70, 162, 161, 163, 164, 165, 166,
-	0, 0, 167, 159, 160, 0, 0, 136, 148, 0,
-	184, 236, 235, 245, 246, 238, 239, 240, 241, 242,
-	243, 244, 237, 0, 0, 247, 0, 0, 0, 0,
-	145, 146, 204, 0, 0, 0, 173, 0, 147, 0,
-	0, 144, 149, 0, 235, 245, 246, 238, 239, 240,
-	241, 242, 243, 244, 237, 0, 170, 247, 0, 0,
-	0, 0, 14, 0, 176, 171, 177, 172, 178, 182,
-	183, 181, 180, 179, 174, 156, 157, 151, 139, 158,
-	0, 0, 0, 0, 0, 0, 0, 155, 0, 0,
-	0, 0, 0, 0, 0, 175, 0, 0, 0, 0,
-	0, 0, 0, 0, 122, 0, 0, 70, 162, 161,
-	163, 164, 165, 166, 0, 0, 167, 159, 160, 0,
-	0, 136, 148, 0, 184, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 145, 146, 0, 0, 0, 0,
-	173, 0, 147, 0, 0, 144, 149, 0, 0, 0,
+	344, 450, 222, 105, 617, 263, 335, 530, 479, 725,
+	766, 705, 796, 629, 362, 797, 704, 459, 671, 204,
+	706, 719, 598, 604, 115, 325, 363, 417, 67, 460,
+	89, 451, 430, 588, 114, 101, 251, 249, 541, 108,
+	106, 118, 119, 120, 118, 107, 40, 529, 3, 113,
+	213, 225, 30, 891, 897, 889, 896, 881, 90, 91,
+	895, 888, 894, 112, 893, 887, 553, 332, 150, 347,
+	800, 836, 232, 880, 390, 195, 337, 278, 432, 92,
+	334, 227, 118, 444, 394, 445, 157, 48, 431, 230,
+	226, 234, 176, 279, 49, 183, 277, 229, 544, 163,
+	166, 156, 184, 550, 677, 536, 196, 869, 493, 492,
+	502, 503, 495, 496, 497, 498, 499, 500, 501, 494,
+	228, 118, 504, 193, 118, 41, 178, 415, 444, 847,
+	445, 173, 446, 172, 158, 210, 427, 200, 83, 41,
+	218, 152, 194, 243, 478, 250, 320, 322, 254, 246,
+	118, 81, 197, 83, 441, 118, 118, 440, 303, 253,
+	118, 118, 118, 118, 118, 252, 252, 118, 118, 171,
+	180, 477, 245, 247, 255, 242, 476, 446, 233, 264,
+	248, 256, 266, 257, 267, 268, 462, 270, 271, 149,
+	147, 274, 275, 88, 259, 84, 779, 237, 876, 148,
+	276, 542, 543, 307, 563, 435, 311, 231, 76, 244,
+	870, 259, 108, 879, 76, 318, 294, 321, 107, 265,
+	108, 562, 379, 422, 118, 269, 107, 301, 545, 302,
+	80, 420, 75, 183, 740, 316, 80, 273, 75, 272,
+	259, 261, 838, 841, 118, 317, 241, 781, 262, 483,
+	482, 383, 118, 177, 118, 514, 515, 538, 386, 177,
+	327, 884, 778, 414, 612, 323, 484, 118, 418, 118,
+	284, 602, 592, 385, 118, 423, 250, 534, 250, 290,
+	291, 292, 293, 421, 295, 436, 297, 384, 238, 424,
+	252, 425, 221, 280, 443, 281, 282, 283, 439, 181,
+	217, 79, 438, 741, 147, 504, 494, 79, 180, 504,
+	589, 239, 240, 148, 199, 71, 108, 474, 124, 472,
+	182, 71, 107, 110, 158, 123, 483, 482, 121, 122,
+	434, 484, 437, 85, 86, 87, 45, 259, 458, 444,
+	183, 445, 285, 484, 475, 44, 802, 43, 72, 77,
+	73, 482, 220, 672, 72, 77, 73, 260, 827, 74,
+	513, 552, 471, 712, 672, 74, 748, 484, 78, 207,
+	877, 522, 523, 524, 78, 147, 525, 526, 527, 528,
+	858, 676, 447, 448, 148, 757, 483, 482, 446, 109,
+	452, 692, 453, 804, 455, 162, 286, 164, 165, 170,
+	857, 287, 202, 484, 787, 758, 124, 328, 559, 312,
+	512, 537, 313, 742, 675, 548, 549, 41, 564, 568,
+	681, 682, 118, 118, 636, 389, 102, 632, 577, 551,
+	118, 110, 546, 118, 560, 786, 581, 224, 634, 635,
+	633, 118, 118, 209, 224, 572, 250, 250, 755, 224,
+	483, 482, 590, 753, 578, 483, 482, 579, 587, 573,
+	574, 567, 565, 600, 214, 585, 586, 484, 103, 124,
+	584, 601, 484, 124, 198, 556, 610, 558, 203, 103,
+	41, 147, 539, 103, 613, 607, 540, 14, 554, 102,
+	148, 215, 169, 103, 102, 216, 609, 608, 790, 791,
+	603, 167, 630, 614, 615, 616, 497, 498, 499, 500,
+	501, 494, 419, 481, 504, 429, 224, 485, 599, 103,
+	652, 216, 655, 331, 391, 656, 662, 660, 41, 666,
+	387, 103, 667, 669, 219, 673, 866, 224, 483, 482,
+	657, 258, 661, 659, 727, 728, 729, 124, 593, 601,
+	531, 623, 625, 626, 627, 484, 624, 201, 258, 224,
+	483, 482, 559, 678, 631, 533, 175, 674, 687, 689,
+	118, 764, 224, 483, 482, 595, 224, 484, 694, 783,
+	224, 118, 174, 559, 668, 683, 684, 258, 560, 304,
+	484, 744, 224, 700, 418, 653, 654, 691, 788, 102,
+	600, 693, 480, 379, 215, 699, 764, 324, 695, 560,
+	575, 380, 716, 717, 326, 720, 720, 720, 718, 259,
+	702, 698, 711, 710, 701, 703, 155, 32, 686, 556,
+	715, 558, 690, 224, 259, 259, 595, 721, 722, 688,
+	224, 605, 554, 219, 696, 668, 224, 730, 630, 697,
+	556, 219, 558, 356, 355, 357, 358, 359, 360, 429,
+	735, 153, 361, 554, 738, 739, 611, 224, 743, 620,
+	621, 224, 41, 709, 591, 224, 736, 737, 851, 159,
+	160, 161, 605, 751, 258, 596, 752, 754, 756, 747,
+	580, 224, 576, 456, 118, 759, 457, 179, 463, 760,
+	306, 768, 771, 772, 773, 769, 750, 770, 774, 315,
+	631, 852, 821, 663, 664, 569, 224, 822, 264, 223,
+	224, 309, 782, 595, 784, 785, 761, 777, 41, 819,
+	780, 708, 854, 823, 820, 772, 773, 710, 680, 794,
+	853, 818, 608, 817, 885, 801, 795, 685, 96, 97,
+	206, 468, 467, 82, 798, 799, 806, 14, 807, 859,
+	808, 547, 205, 809, 442, 299, 211, 191, 100, 583,
+	185, 186, 187, 188, 189, 190, 813, 192, 815, 673,
+	826, 470, 829, 582, 830, 824, 814, 709, 816, 99,
+	714, 710, 710, 710, 710, 531, 873, 428, 833, 837,
+	776, 842, 843, 570, 571, 723, 726, 874, 846, 305,
+	679, 93, 94, 840, 206, 466, 618, 812, 619, 848,
+	850, 154, 480, 465, 839, 811, 763, 844, 326, 108,
+	393, 392, 855, 104, 883, 107, 14, 828, 856, 561,
+	32, 709, 709, 709, 709, 34, 29, 1, 775, 861,
+	212, 124, 597, 862, 102, 745, 65, 47, 46, 749,
+	63, 865, 516, 517, 518, 519, 520, 521, 381, 382,
+	117, 878, 118, 416, 116, 867, 566, 882, 288, 535,
+	886, 875, 289, 449, 236, 890, 235, 433, 871, 296,
+	259, 298, 33, 300, 111, 39, 264, 21, 19, 426,
+	42, 892, 168, 151, 147, 464, 314, 473, 35, 36,
+	37, 38, 463, 148, 463, 665, 495, 496, 497, 498,
+	499, 500, 501, 494, 388, 123, 504, 872, 121, 122,
+	789, 724, 346, 810, 762, 803, 746, 805, 532, 670,
+	348, 336, 606, 125, 126, 127, 128, 129, 130, 131,
+	132, 133, 134, 135, 136, 137, 138, 139, 140, 141,
+	142, 143, 144, 145, 146, 622, 258, 310, 469, 486,
+	329, 319, 208, 831, 832, 726, 767, 765, 707, 594,
+	835, 258, 258, 868, 95, 463, 102, 308, 31, 98,
+	13, 845, 713, 12, 11, 10, 9, 454, 849, 531,
+	502, 503, 495, 496, 497, 498, 499, 500, 501, 494,
+	628, 8, 504, 637, 638, 639, 640, 641, 642, 643,
+	644, 645, 646, 647, 648, 649, 650, 651, 7, 6,
+	5, 4, 2, 658, 0, 333, 0, 0, 863, 864,
+	0, 0, 0, 349, 0, 0, 0, 0, 463, 463,
+	0, 369, 768, 771, 772, 773, 769, 0, 770, 774,
+	41, 0, 224, 110, 356, 355, 357, 358, 359, 360,
+	0, 0, 0, 361, 353, 354, 0, 0, 330, 342,
+	0, 378, 492, 502, 503, 495, 496, 497, 498, 499,
+	500, 501, 494, 0, 0, 504, 14, 15, 16, 17,
+	102, 339, 340, 461, 0, 0, 0, 367, 0, 341,
+	0, 0, 338, 343, 0, 0, 0, 0, 18, 0,
+	0, 0, 0, 0, 0, 0, 0, 41, 0, 364,
+	124, 356, 355, 357, 358, 359, 360, 0, 0, 555,
+	361, 353, 354, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 102, 102, 102, 102, 731, 732,
+	733, 0, 0, 0, 0, 825, 0, 0, 0, 557,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 370,
+	365, 371, 366, 372, 376, 377, 375, 374, 373, 368,
+	350, 351, 345, 0, 352, 333, 20, 22, 24, 23,
+	25, 0, 0, 349, 0, 0, 0, 0, 26, 27,
+	28, 369, 0, 0, 0, 0, 0, 0, 0, 0,
+	41, 0, 224, 110, 356, 355, 357, 358, 359, 360,
+	0, 0, 0, 361, 353, 354, 0, 258, 330, 342,
+	0, 378, 0, 0, 0, 0, 370, 0, 371, 0,
+	372, 376, 377, 375, 374, 373, 792, 0, 793, 0,
+	0, 339, 340, 461, 0, 0, 0, 367, 0, 341,
+	0, 0, 338, 343, 0, 0, 493, 492, 502, 503,
+	495, 496, 497, 498, 499, 500, 501, 494, 0, 364,
+	504, 0, 333, 0, 0, 0, 0, 0, 0, 0,
+	349, 0, 0, 0, 0, 0, 0, 0, 369, 0,
+	0, 0, 0, 0, 0, 0, 834, 41, 0, 0,
+	110, 356, 355, 357, 358, 359, 360, 0, 0, 0,
+	361, 353, 354, 0, 0, 330, 342, 0, 378, 370,
+	365, 371, 366, 372, 376, 377, 375, 374, 373, 368,
+	350, 351, 345, 0, 352, 0, 0, 0, 339, 340,
+	461, 0, 0, 0, 367, 0, 341, 0, 860, 338,
+	343, 14, 0, 0, 493, 492, 502, 503, 495, 496,
+	497, 498, 499, 500, 501, 494, 364, 333, 504, 0,
+	0, 0, 0, 0, 0, 349, 0, 0, 0, 0,
+	0, 0, 0, 369, 0, 0, 0, 0, 0, 0,
+	0, 0, 41, 0, 0, 110, 356, 355, 357, 358,
+	359, 360, 0, 0, 0, 361, 353, 354, 0, 0,
+	330, 342, 124, 378, 0, 0, 370, 365, 371, 366,
+	372, 376, 377, 375, 374, 373, 368, 350, 351, 345,
+	0, 352, 0, 339, 340, 0, 0, 0, 0, 367,
+	0, 341, 0, 0, 338, 343, 493, 492, 502, 503,
+	495, 496, 497, 498, 499, 500, 501, 494, 0, 0,
+	504, 364, 333, 0, 0, 0, 0, 0, 0, 0,
+	349, 0, 0, 0, 0, 0, 0, 0, 369, 0,
+	0, 0, 0, 0, 0, 0, 0, 41, 0, 0,
+	110, 356, 355, 357, 358, 359, 360, 0, 0, 0,
+	361, 353, 354, 0, 0, 330, 342, 0, 378, 0,
+	0, 370, 365, 371, 366, 372, 376, 377, 375, 374,
+	373, 368, 350, 351, 345, 0, 352, 0, 339, 340,
+	0, 0, 0, 0, 367, 0, 341, 0, 0, 338,
+	343, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 364, 0, 0, 0,
+	0, 0, 0, 0, 349, 0, 0, 0, 0, 0,
+	0, 0, 369, 0, 0, 0, 0, 0, 0, 0,
+	0, 41, 0, 0, 110, 356, 355, 357, 358, 359,
+	360, 0, 0, 0, 361, 353, 354, 0, 0, 0,
+	342, 0, 378, 0, 0, 0, 370, 365, 371, 366,
+	372, 376, 377, 375, 374, 373, 368, 350, 351, 345,
+	0, 352, 339, 340, 0, 0, 0, 0, 367, 0,
+	341, 0, 0, 338, 343, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	170, 0, 0, 0, 0, 0, 0, 0, 176, 171,
-	177, 172, 178, 182, 183, 181, 180, 179, 174, 156,
-	157, 151, 139, 158, 0, 0, 0, 0, 0, 0,
-	0, 155, 0, 0, 0, 0, 0, 0, 0, 175,
-	0, 0, 0, 0, 0, 0, 0, 0, 122, 0,
-	0, 70, 162, 161, 163, 164, 165, 166, 0, 0,
-	167, 159, 160, 0, 0, 136, 148, 0, 184, 0,
+	364, 0, 0, 0, 0, 0, 0, 0, 349, 0,
+	0, 0, 0, 0, 0, 0, 369, 0, 0, 0,
+	0, 0, 0, 41, 0, 41, 124, 0, 110, 356,
+	355, 357, 358, 359, 360, 0, 0, 0, 361, 0,
+	0, 0, 0, 0, 342, 0, 378, 0, 0, 0,
+	370, 365, 371, 366, 372, 376, 377, 375, 374, 373,
+	368, 350, 351, 345, 0, 352, 339, 340, 0, 0,
+	0, 76, 367, 0, 341, 0, 734, 338, 343, 0,
+	50, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 80, 364, 75, 493, 492, 502, 503,
+	495, 496, 497, 498, 499, 500, 501, 494, 0, 0,
+	504, 62, 0, 0, 0, 0, 66, 0, 125, 126,
+	127, 128, 129, 130, 131, 132, 133, 134, 135, 136,
+	137, 138, 139, 140, 141, 142, 143, 144, 145, 146,
+	0, 0, 0, 0, 370, 365, 371, 366, 372, 376,
+	377, 375, 374, 373, 368, 350, 351, 345, 124, 352,
+	0, 0, 0, 0, 79, 0, 0, 0, 0, 0,
+	0, 0, 0, 52, 55, 56, 68, 0, 71, 0,
+	103, 0, 0, 0, 0, 0, 0, 51, 53, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 69,
+	0, 0, 0, 0, 0, 64, 70, 0, 0, 0,
+	0, 72, 77, 73, 0, 59, 60, 58, 0, 0,
+	61, 0, 74, 54, 0, 0, 0, 0, 0, 0,
+	0, 78, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 57, 0, 0, 0, 0, 0, 0,
+	125, 126, 127, 128, 129, 130, 131, 132, 133, 134,
+	135, 136, 137, 138, 139, 140, 141, 142, 143, 144,
+	145, 146, 125, 126, 127, 128, 129, 130, 131, 132,
+	133, 134, 135, 136, 137, 138, 139, 140, 141, 142,
+	143, 144, 145, 146, 402, 0, 0, 0, 0, 0,
+	407, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 408,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 145, 146,
-	0, 0, 0, 0, 173, 0, 147, 0, 0, 144,
-	149, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 170, 0, 0, 0, 0, 0,
-	0, 0, 176, 171, 177, 172, 178, 182, 183, 181,
-	180, 179, 174, 156, 157, 151, 155, 158, 0, 0,
-	0, 0, 0, 0, 175, 0, 0, 0, 0, 0,
-	0, 0, 0, 122, 0, 0, 70, 162, 161, 163,
-	164, 165, 166, 0, 0, 167, 159, 160, 0, 0,
-	0, 148, 0, 184, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 155,
-	0, 0, 0, 145, 146, 0, 0, 175, 0, 173,
-	0, 147, 0, 0, 144, 149, 122, 0, 0, 70,
-	162, 161, 163, 164, 165, 166, 0, 0, 167, 170,
-	0, 0, 0, 0, 148, 0, 184, 176, 171, 177,
-	172, 178, 182, 183, 181, 180, 179, 174, 156, 157,
-	151, 0, 158, 0, 0, 0, 145, 146, 0, 0,
-	0, 0, 173, 0, 147, 0, 0, 144, 149, 0,
+	0, 0, 488, 406, 491, 0, 0, 0, 405, 0,
+	505, 506, 507, 508, 509, 510, 511, 0, 489, 490,
+	487, 493, 492, 502, 503, 495, 496, 497, 498, 499,
+	500, 501, 494, 0, 0, 504, 0, 0, 0, 0,
+	0, 400, 0, 0, 0, 411, 412, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 170, 0, 0, 0, 0, 0, 0, 0,
-	176, 171, 177, 172, 178, 182, 183, 181, 180, 179,
-	174, 156, 157, 151, 231, 158, 234, 0, 0, 0,
-	0, 0, 248, 249, 250, 251, 252, 253, 254, 0,
-	232, 233, 230, 236, 235, 245, 246, 238, 239, 240,
-	241, 242, 243, 244, 237, 0, 0, 247,
+	0, 0, 0, 0, 0, 0, 0, 0, 410, 0,
+	0, 0, 0, 0, 0, 0, 0, 401, 403, 404,
+	397, 0, 0, 0, 0, 0, 0, 0, 409, 399,
+	413, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 396, 395, 0, 0, 398,
 }
 var yyPact = [...]int{
 
-	71, -1000, -122, 522, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -66,
-	-55, -3, -26, -6, -1000, -1000, -1000, -1000, -1000, 518,
-	456, 407, -1000, -40, 169, 504, 160, -63, -14, 165,
-	-1000, -10, 165, -1000, 169, -64, 183, -64, 169, -1000,
-	-1000, -1000, -1000, -1000, -1000, 427, -1000, -1000, 111, 276,
-	430, 166, 30, -1000, 169, 208, -1000, 37, -1000, 29,
-	-1000, 169, 94, 174, -1000, -1000, 169, -1000, -23, 169,
-	449, 351, 165, -1000, 364, 205, -1000, -1000, 380, 169,
-	-1000, 160, 9, -1000, 256, -1000, -1000, 169, 498, 160,
-	1080, 160, -1000, 448, -69, -1000, 62, -1000, 169, -1000,
-	-1000, 169, -1000, 852, -1000, 487, -1000, 402, 399, 443,
-	160, 165, -1000, -1000, 347, -1000, -15, -19, -22, -1000,
-	-1000, 481, 1080, -1000, 93, -1000, 1080, 1309, -1000, 312,
-	-1000, 17, -1000, -1000, 1238, 1238, 1238, 1238, 1238, 1238,
-	312, 312, 312, -1000, -1000, 312, 312, 312, 312, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 966,
+	1090, -1000, -139, 835, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 625,
+	234, 1736, 38, 84, 222, 82, -1000, -1000, -1000, -1000,
+	-1000, 830, 792, 714, -1000, 23, 429, 823, 381, -1000,
+	801, -1000, 77, 1778, 25, -1000, -1000, 613, -1000, -1000,
+	806, 201, 11, 11, 11, 272, 378, 55, 4, 2,
+	532, 516, -1000, 203, -1000, -13, 160, -1000, -1000, -1000,
+	-1000, -46, 267, 267, 267, 267, 267, 267, 739, 267,
+	-23, 1778, 41, -1000, 429, 21, 507, 21, 429, -1000,
+	-1000, -1000, -1000, -1000, -1000, 730, -1000, -1000, 311, 418,
+	738, 443, 199, -1000, 429, 486, -1000, 279, -1000, 191,
+	-1000, 671, -1000, -66, 26, 147, 92, 47, -1000, -1000,
+	1778, 87, 87, 1778, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 1080, -1000, 27, -1000, -1000, 341,
-	165, -1000, -37, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 277, -1000, -1000, 344, 24, 169, -1000, -1000,
-	-1000, -1000, 339, 312, 522, 293, 343, 22, 481, 312,
-	312, 312, 466, 479, 93, 1080, 1080, 273, 66, 1185,
-	207, 117, 1238, 1238, 1238, 1238, 1238, 1238, 1238, 1238,
-	1238, 1238, 1238, 1238, 1238, 1238, 1238, 110, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 518, 436, 436, -12,
-	-12, -12, -12, -12, 555, 570, 457, 732, 165, 1080,
-	1080, 165, 262, 322, 93, 85, 93, 165, 169, -1000,
-	-1000, 169, -1000, 498, 852, 213, -1000, -1000, 226, -1000,
-	-1000, 91, -1000, -1000, 442, 305, -1000, 1080, -1000, -1000,
-	165, -1000, 165, 466, 165, 165, 165, -1000, 1080, 1080,
-	66, 102, -1000, -1000, 162, -1000, -1000, -1000, 837, -1000,
-	-1000, -1000, -1000, 207, 1238, 1238, 1238, 837, 837, 717,
-	649, 869, -12, 112, 112, 38, 38, 38, 38, 38,
-	202, 202, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	296, 852, 852, -1000, 296, 230, 44, 336, 303, -1000,
-	-1000, 1080, -1000, 114, -1000, 1080, -1000, -1000, -1000, 494,
-	-1000, 300, 533, -1000, -1000, -1000, 452, 374, -1000, -1000,
-	20, 440, 312, 13, -1000, -1000, 298, -1000, 298, 298,
-	259, 249, -1000, 206, -1000, -1000, -1000, -1000, 837, 837,
-	624, 1238, -1000, 296, 352, -1000, -1000, 110, 110, 110,
-	-99, 165, 93, 75, -1000, 1080, 151, -1000, 485, 468,
-	213, 213, 213, 213, -1000, 382, 378, -1000, 376, 369,
-	392, 169, -1000, 282, 90, 511, -1000, 165, -1000, 165,
-	-1000, -1000, 1080, 1080, 1080, -1000, -1000, -1000, 1238, 837,
-	-1000, -95, 230, 92, 230, 230, 312, -1000, -1000, 93,
-	1080, 481, 1080, 1080, 533, 327, 510, -1000, -1000, -1000,
-	-1000, 377, -1000, 366, -1000, -1000, -1000, -1000, -1000, 160,
-	-1000, -1000, 93, 93, -1000, 837, 230, 143, -1000, -1000,
-	141, -1000, 421, -1000, -1000, 1238, 93, 466, 93, 235,
-	1080, 1080, -1000, -1000, 208, -1000, -1000, 267, 110, -24,
-	433, 93, 93, -5, 138, -1000, 230, -4, -111, -1000,
-	508, 54, -1000, 411, 230, -1000, -102, -107, -114, -1000,
-	-117, 110, -1000, -1000, -104, -1000, -105, -1000, -109, -1000,
-	-112, -115, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 1778,
+	-1000, 1800, 292, 1736, 1778, 1646, -66, 26, -1000, 1778,
+	1778, 1778, 1778, 1778, 136, 134, 1778, 1778, 1800, -1000,
+	-1000, -69, -1000, -1000, -1000, -1000, -1000, 22, -1000, 243,
+	267, -1000, -1000, -1000, 267, 243, 243, 243, 243, 625,
+	243, 267, 243, 267, 737, 267, -1000, 1800, 44, 429,
+	787, 654, 423, -1000, 684, 356, -1000, -1000, 681, 429,
+	-1000, 381, 102, -1000, 469, -1000, -1000, 429, 817, 381,
+	1460, 381, 203, 801, -1000, 480, 267, 373, -85, -1000,
+	-1000, 474, 822, -1000, -68, 1943, 625, -1000, -11, -1000,
+	-1000, -1000, -1000, 1778, 462, 128, 87, 120, 625, -1000,
+	-1000, 1778, -1000, 1778, -1000, 19, -1000, -1000, -1000, -1000,
+	775, -1000, -1000, 611, -1000, -65, 1778, 177, 1778, -65,
+	-1000, -1000, -1000, 1778, 43, 40, -1000, 736, 289, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 243, 243,
+	-1000, -1000, -1000, -1000, 1800, -1000, 243, -1000, 243, 267,
+	243, -1000, -1000, 429, -1000, -1000, 429, -1000, 1270, -1000,
+	805, -1000, 722, 721, 751, 381, 423, -1000, 603, -1000,
+	64, 59, 32, -1000, -1000, 808, 1460, -1000, 263, -1000,
+	1460, 1937, -1000, 625, -1000, 151, -1000, -1000, 1648, 1648,
+	1648, 1648, 1648, 1648, 625, 625, 625, -1000, -1000, 625,
+	625, 625, 625, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 1365, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 1460, -1000,
+	176, -34, 209, -1000, -1000, -66, 26, -1000, 243, -1000,
+	436, -1000, 70, 70, -1000, -1000, -1000, -1000, 733, 289,
+	289, -41, 625, -1000, -1000, 301, -1000, 1080, 831, -1000,
+	118, 101, -1000, 366, 409, -1000, 667, 780, 625, -1000,
+	-1000, 1778, 1778, 1460, -1000, -1000, 646, 423, -1000, 1778,
+	-1000, -1000, 1778, 642, -1000, 756, 742, -65, -1000, -1000,
+	1778, 1778, 289, 212, -1000, -1000, -1000, -1000, -1000, 626,
+	-1000, 171, -1000, -1000, 243, -1000, -1000, -1000, -1000, 675,
+	-1000, -1000, 497, 170, 429, -1000, -1000, -1000, -1000, 636,
+	625, 835, 595, 618, 163, 808, 625, 625, 625, 800,
+	803, 263, 1460, 1460, 491, 251, 1554, 370, 357, 1648,
+	1648, 1648, 1648, 1648, 1648, 1648, 1648, 1648, 1648, 1648,
+	1648, 1648, 1648, 1648, 289, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 830, 602, 602, 207, 207, 207, 207,
+	207, 1382, 1013, 794, 1173, 423, 1460, 1460, 423, 622,
+	597, 263, 284, 263, 423, -1000, -1000, -1000, 203, -1000,
+	-1000, -1000, -1000, -1000, 354, -38, -1000, 289, -1000, -1000,
+	789, 1460, -1000, -1000, -1000, -1000, -1000, 367, 625, 625,
+	1460, 1080, -1000, -1000, -1000, 591, 584, -1000, -1000, 1778,
+	-1000, -1000, 338, -1000, 625, 510, 1800, -1000, -1000, -1000,
+	1778, -1000, 1080, -1000, -1000, -1000, -1000, 212, -1000, 289,
+	-1000, 1800, 1800, -1000, 817, 1270, 433, -1000, -1000, 419,
+	-1000, -1000, 273, -1000, -1000, 764, 582, -1000, 1460, -1000,
+	-1000, 423, 423, 800, 423, 423, 423, -1000, 1460, 1460,
+	251, 287, -1000, -1000, 484, -1000, -1000, -1000, 1290, -1000,
+	-1000, -1000, -1000, 370, 1648, 1648, 1648, 1290, 1290, 1682,
+	914, 997, 207, 416, 416, 211, 211, 211, 211, 211,
+	828, 828, -1000, -1000, -1000, -1000, -1000, 527, 1270, 1270,
+	-1000, 527, 388, 186, 392, 543, -1000, -1000, 1460, -1000,
+	295, -1000, 1460, -1000, -1000, -1000, -1000, -1000, -1000, 625,
+	510, -1000, -1000, 400, 395, 510, -1000, -1000, 332, -1000,
+	353, 780, 388, 1778, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, 814, -1000, 558, 1016, -1000, -1000, -1000, 779,
+	481, -1000, -1000, 161, 93, 625, 146, -1000, -1000, 531,
+	-1000, 531, 531, 387, 550, -1000, 475, -1000, -1000, -1000,
+	-1000, 1290, 1290, 1192, 1648, -1000, 527, 588, -1000, -1000,
+	289, 289, 289, -112, 423, 263, 274, -1000, 1460, 323,
+	1460, -1000, -1000, 388, -1000, 388, -1000, 388, -1000, -1000,
+	467, -1000, 812, 802, 433, 433, 433, 433, -1000, 707,
+	705, -1000, 693, 676, 697, 429, -1000, 523, 268, 829,
+	-1000, 423, -1000, 423, -1000, -1000, 1460, 1460, 1460, -1000,
+	-1000, -1000, 1648, 1290, -1000, -109, 388, 78, 388, 388,
+	625, -1000, -1000, 263, 1460, 510, -1000, -1000, -1000, -1,
+	808, 1460, 1460, 1016, 632, 665, -1000, -1000, -1000, -1000,
+	704, -1000, 696, -1000, -1000, -1000, -1000, -1000, 381, -1000,
+	-1000, 263, 263, -1000, 1290, 388, 348, -1000, -1000, 327,
+	-1000, 731, -1000, -1000, 1648, 263, -1000, 1800, 800, 263,
+	536, 1460, 1460, -1000, -1000, 486, -1000, -1000, 488, 289,
+	24, 625, 778, 263, 263, 33, 317, -1000, 388, 30,
+	-130, 1778, -1000, 826, 178, -1000, 716, 388, -1000, -119,
+	-124, -133, 467, -1000, -136, 289, -1000, -1000, -120, -1000,
+	-1000, -122, -1000, -126, -1000, -131, -134, -1000,
 }
 var yyPgo = [...]int{
 
-	0, 652, 81, 651, 637, 634, 633, 629, 628, 617,
-	615, 614, 613, 504, 612, 611, 22, 610, 608, 607,
-	605, 17, 20, 1, 604, 13, 16, 14, 603, 602,
-	10, 600, 27, 599, 426, 598, 18, 19, 597, 24,
-	596, 595, 23, 3, 594, 593, 12, 8, 591, 44,
-	589, 57, 5, 588, 587, 21, 582, 581, 579, 569,
-	567, 566, 7, 565, 9, 563, 2, 562, 560, 559,
-	558, 37, 6, 132, 555, 423, 362, 544, 542, 541,
-	539, 537, 72, 15, 535, 466, 165, 534, 533, 26,
-	4, 11, 532, 530, 529, 162, 0,
+	0, 1032, 47, 1031, 1030, 1029, 1028, 1011, 996, 995,
+	994, 993, 990, 892, 989, 988, 19, 987, 984, 983,
+	980, 17, 29, 186, 979, 16, 11, 20, 978, 977,
+	10, 976, 35, 972, 731, 971, 21, 25, 970, 67,
+	969, 968, 80, 523, 967, 965, 13, 7, 942, 14,
+	941, 76, 6, 940, 939, 18, 938, 936, 934, 933,
+	932, 69, 8, 931, 9, 930, 4, 927, 915, 907,
+	906, 23, 3, 40, 905, 753, 314, 903, 902, 900,
+	899, 626, 43, 36, 697, 898, 897, 895, 894, 63,
+	887, 886, 884, 33, 93, 883, 879, 876, 66, 49,
+	874, 27, 873, 870, 37, 24, 34, 38, 28, 869,
+	868, 860, 94, 87, 32, 858, 857, 856, 5, 31,
+	1, 42, 0, 22, 852, 389, 50, 850, 848, 30,
+	15, 12, 847, 846, 26, 2, 845,
 }
 var yyR1 = [...]int{
 
-	0, 92, 93, 93, 1, 1, 1, 1, 1, 1,
+	0, 132, 133, 133, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 2, 2, 2, 3, 3,
-	4, 5, 6, 7, 7, 7, 8, 8, 8, 9,
-	10, 10, 10, 11, 12, 12, 12, 94, 13, 14,
-	14, 15, 15, 15, 18, 18, 18, 16, 16, 17,
-	17, 21, 21, 22, 22, 22, 22, 84, 84, 84,
-	83, 83, 24, 24, 25, 25, 26, 26, 27, 27,
-	27, 34, 28, 28, 28, 28, 88, 88, 87, 87,
-	87, 86, 86, 29, 29, 29, 29, 30, 30, 30,
-	30, 31, 31, 33, 33, 32, 32, 35, 35, 35,
-	35, 36, 36, 37, 37, 23, 23, 23, 23, 23,
-	23, 39, 39, 38, 38, 38, 38, 38, 38, 38,
-	38, 38, 38, 38, 38, 45, 45, 45, 45, 45,
-	45, 40, 40, 40, 40, 40, 40, 40, 46, 46,
-	46, 51, 47, 47, 90, 90, 90, 43, 43, 43,
+	4, 5, 6, 7, 7, 7, 85, 8, 8, 86,
+	9, 10, 10, 10, 11, 12, 12, 12, 87, 88,
+	88, 88, 88, 88, 88, 89, 90, 90, 91, 91,
+	91, 91, 91, 91, 91, 91, 91, 91, 91, 91,
+	91, 91, 91, 91, 91, 91, 91, 91, 91, 91,
+	91, 91, 92, 92, 92, 92, 92, 92, 92, 97,
+	97, 98, 98, 98, 98, 98, 98, 98, 98, 98,
+	98, 98, 99, 99, 99, 99, 99, 99, 99, 100,
+	100, 100, 100, 100, 100, 102, 102, 101, 101, 101,
+	101, 106, 106, 106, 106, 106, 106, 106, 103, 103,
+	103, 107, 107, 107, 107, 107, 104, 104, 82, 82,
+	83, 83, 118, 118, 120, 120, 110, 110, 109, 109,
+	109, 111, 111, 108, 108, 108, 108, 108, 108, 108,
+	108, 108, 108, 108, 108, 108, 108, 94, 94, 94,
+	94, 94, 94, 94, 94, 95, 95, 84, 84, 96,
+	96, 115, 115, 115, 116, 116, 113, 112, 112, 112,
+	112, 112, 112, 112, 112, 112, 112, 112, 112, 112,
+	112, 112, 112, 112, 112, 112, 112, 112, 112, 112,
+	112, 112, 112, 112, 117, 117, 117, 117, 117, 117,
+	117, 81, 81, 114, 114, 114, 93, 93, 136, 13,
+	14, 14, 15, 15, 15, 18, 18, 18, 16, 16,
+	17, 17, 21, 21, 22, 22, 22, 22, 124, 124,
+	124, 123, 123, 24, 24, 25, 25, 26, 26, 27,
+	27, 27, 34, 28, 28, 28, 28, 128, 128, 127,
+	127, 127, 126, 126, 29, 29, 29, 29, 30, 30,
+	30, 30, 31, 31, 33, 33, 32, 32, 35, 35,
+	35, 35, 36, 36, 37, 37, 23, 23, 23, 23,
+	23, 23, 39, 39, 38, 38, 38, 38, 38, 38,
+	38, 38, 38, 38, 38, 38, 45, 45, 45, 45,
+	45, 45, 40, 40, 40, 40, 40, 40, 40, 46,
+	46, 46, 51, 47, 47, 130, 130, 130, 43, 43,
 	43, 43, 43, 43, 43, 43, 43, 43, 43, 43,
 	43, 43, 43, 43, 43, 43, 43, 43, 43, 43,
 	43, 43, 43, 43, 43, 43, 43, 43, 43, 43,
-	43, 43, 43, 43, 43, 19, 19, 19, 19, 19,
-	91, 91, 91, 91, 91, 91, 91, 91, 61, 61,
-	61, 61, 61, 61, 61, 61, 60, 60, 60, 60,
-	60, 60, 60, 53, 56, 56, 20, 20, 54, 54,
-	55, 57, 57, 52, 52, 52, 42, 42, 42, 42,
-	42, 42, 42, 44, 44, 44, 58, 58, 59, 59,
-	62, 62, 63, 63, 64, 65, 65, 65, 66, 66,
-	66, 66, 67, 67, 67, 68, 68, 70, 70, 69,
-	69, 69, 69, 71, 71, 41, 41, 48, 48, 49,
-	50, 72, 72, 73, 74, 74, 76, 76, 77, 77,
-	75, 75, 78, 78, 78, 78, 78, 78, 79, 79,
-	80, 80, 81, 81, 82, 85, 95, 96, 89,
+	43, 43, 43, 43, 43, 43, 19, 19, 19, 19,
+	19, 131, 131, 131, 131, 131, 131, 131, 131, 61,
+	61, 61, 61, 61, 61, 61, 61, 60, 60, 60,
+	60, 60, 60, 60, 53, 56, 56, 20, 20, 54,
+	54, 55, 57, 57, 52, 52, 52, 42, 42, 42,
+	42, 42, 42, 42, 44, 44, 44, 58, 58, 59,
+	59, 62, 62, 63, 63, 64, 65, 65, 65, 66,
+	66, 66, 66, 67, 67, 67, 68, 68, 70, 70,
+	69, 69, 69, 69, 71, 71, 41, 41, 48, 48,
+	49, 50, 72, 72, 73, 74, 74, 76, 76, 77,
+	77, 75, 75, 78, 78, 78, 79, 79, 80, 80,
+	122, 125, 105, 105, 119, 119, 121, 121, 121, 121,
+	121, 121, 121, 121, 121, 121, 121, 121, 121, 121,
+	121, 121, 121, 121, 121, 121, 121, 121, 134, 135,
+	129,
 }
 var yyR2 = [...]int{
 
 	0, 2, 0, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 13, 7, 3, 7, 7,
-	8, 7, 3, 5, 8, 4, 6, 7, 4, 5,
-	4, 5, 5, 3, 2, 2, 2, 0, 2, 0,
-	2, 1, 2, 2, 0, 1, 1, 0, 1, 0,
-	1, 1, 3, 1, 2, 3, 5, 0, 1, 2,
-	1, 1, 0, 2, 1, 3, 1, 1, 1, 3,
-	3, 3, 3, 5, 5, 3, 0, 1, 0, 1,
-	2, 1, 1, 1, 2, 2, 1, 2, 3, 2,
-	3, 2, 2, 2, 1, 1, 3, 0, 5, 5,
-	5, 1, 3, 0, 2, 1, 3, 3, 2, 3,
-	1, 1, 1, 1, 3, 3, 3, 4, 3, 4,
-	3, 4, 5, 6, 2, 1, 2, 1, 2, 1,
-	2, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 3, 1, 3, 1, 1, 1, 1, 1, 1,
-	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 2, 2, 2, 2,
-	2, 3, 3, 4, 5, 7, 3, 4, 1, 1,
-	4, 6, 6, 6, 9, 0, 3, 4, 7, 3,
-	1, 2, 4, 5, 7, 2, 4, 6, 1, 1,
+	8, 7, 3, 2, 8, 4, 4, 2, 4, 4,
+	5, 4, 5, 5, 3, 2, 2, 2, 5, 1,
+	1, 1, 3, 3, 3, 2, 1, 3, 1, 4,
+	6, 4, 2, 2, 2, 4, 3, 3, 7, 5,
+	2, 2, 3, 2, 3, 4, 2, 3, 2, 3,
+	2, 3, 1, 1, 2, 1, 1, 1, 1, 1,
+	3, 1, 1, 1, 2, 2, 1, 3, 4, 3,
+	4, 3, 4, 3, 4, 3, 4, 2, 2, 3,
+	4, 2, 3, 3, 3, 1, 3, 1, 4, 2,
+	2, 12, 5, 3, 4, 4, 2, 3, 0, 1,
+	2, 1, 1, 2, 2, 2, 0, 1, 1, 1,
+	0, 1, 1, 3, 1, 3, 0, 1, 1, 2,
+	3, 1, 2, 3, 4, 4, 3, 3, 3, 3,
+	5, 3, 4, 3, 4, 5, 4, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 3, 0, 1, 0,
+	1, 1, 1, 3, 1, 3, 3, 4, 5, 2,
+	2, 6, 5, 5, 4, 3, 3, 3, 4, 5,
+	5, 3, 6, 5, 2, 2, 2, 2, 1, 1,
+	1, 2, 2, 2, 1, 1, 1, 1, 1, 1,
+	1, 0, 1, 0, 1, 2, 0, 2, 0, 2,
+	0, 2, 1, 2, 2, 0, 1, 1, 0, 1,
+	0, 1, 1, 3, 1, 2, 3, 5, 0, 1,
+	2, 1, 1, 0, 2, 1, 3, 1, 1, 1,
+	3, 3, 3, 3, 5, 5, 3, 0, 1, 0,
+	1, 2, 1, 1, 1, 2, 2, 1, 2, 3,
+	2, 3, 2, 2, 2, 1, 1, 3, 0, 5,
+	5, 5, 1, 3, 0, 2, 1, 3, 3, 2,
+	3, 1, 1, 1, 1, 3, 3, 3, 4, 3,
+	4, 3, 4, 5, 6, 2, 1, 2, 1, 2,
+	1, 2, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 3, 1, 3, 1, 1, 1, 1, 1,
+	1, 1, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 2, 2, 2,
+	2, 2, 3, 3, 4, 5, 7, 3, 4, 1,
+	1, 4, 6, 6, 6, 9, 0, 3, 4, 7,
+	3, 1, 2, 4, 5, 7, 2, 4, 6, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 5, 0, 1, 0, 2, 1,
+	2, 4, 0, 2, 1, 3, 5, 1, 1, 1,
+	1, 1, 1, 1, 1, 2, 2, 0, 3, 0,
+	2, 0, 3, 1, 3, 2, 0, 1, 1, 0,
+	2, 4, 4, 0, 2, 4, 1, 3, 0, 3,
+	1, 3, 3, 5, 0, 5, 2, 1, 1, 3,
+	3, 1, 1, 3, 3, 1, 1, 0, 2, 0,
+	3, 0, 1, 0, 1, 1, 0, 1, 0, 2,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 5, 0, 1, 0, 2, 1, 2,
-	4, 0, 2, 1, 3, 5, 1, 1, 1, 1,
-	1, 1, 1, 1, 2, 2, 0, 3, 0, 2,
-	0, 3, 1, 3, 2, 0, 1, 1, 0, 2,
-	4, 4, 0, 2, 4, 1, 3, 0, 3, 1,
-	3, 3, 5, 0, 5, 2, 1, 1, 3, 3,
-	1, 1, 3, 3, 1, 1, 0, 2, 0, 3,
-	0, 1, 1, 1, 1, 1, 1, 1, 0, 1,
-	0, 1, 0, 2, 1, 1, 1, 1, 0,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	0,
 }
 var yyChk = [...]int{
 
-	-1000, -92, -1, -2, -3, -4, -5, -6, -7, -8,
-	-9, -10, -11, -12, 6, 7, 8, 9, 29, 104,
-	105, 107, 106, 108, 117, 118, 119, -93, 147, -15,
-	5, -13, -94, -13, -13, -13, -13, 109, -80, 111,
-	115, -75, 111, 113, 109, 109, 110, 111, 109, -89,
-	-89, -89, -2, 19, 20, -18, 35, 36, -14, -75,
-	-34, -32, -85, 51, 10, -72, -73, -52, -82, -85,
-	51, -77, 114, 110, -82, 51, 109, -82, -85, -76,
-	114, 51, -76, -85, -16, 33, 20, 59, -33, 25,
-	-32, 29, -87, -86, 21, -85, 53, 101, -32, 49,
-	73, 101, -85, 65, 51, -89, -85, -89, 112, -85,
-	22, 47, -82, -17, 38, -44, -82, 54, 57, -70,
-	29, -95, 48, -32, -72, -35, 45, 113, 46, -86,
-	-85, -37, 11, -73, -23, -38, 65, -43, -39, 22,
-	-42, -52, -50, -51, 99, 88, 89, 96, 66, 100,
-	-82, 135, -60, -61, -53, 31, 133, 134, 137, 61,
-	62, 53, 52, 54, 55, 56, 57, 60, -49, -95,
-	114, 123, 125, 94, 132, 39, 122, 124, 126, 131,
-	130, 129, 127, 128, 68, -82, -85, -89, 22, -81,
-	116, -78, 107, 105, 28, 106, 14, 146, 51, -85,
-	-85, -89, -21, -22, 90, -23, -85, -74, 18, 10,
-	31, 31, -41, 31, -2, -72, -69, -82, -37, 110,
-	110, 110, -62, 14, -23, 64, 63, 80, -23, -40,
-	83, 65, 81, 82, 67, 85, 84, 95, 88, 89,
-	90, 91, 92, 93, 94, 86, 87, 98, 73, 74,
-	75, 76, 77, 78, 79, -51, -95, 102, 103, -43,
-	-43, -43, -43, -43, -43, -95, -95, -95, -95, -95,
-	-95, -95, -2, -47, -23, -56, -23, 101, 47, -82,
-	-89, -79, 112, -24, 49, 10, -84, -83, 21, -82,
-	53, 101, -32, -71, 47, -48, -49, -95, -71, -96,
-	49, 50, 101, -62, -95, -95, -95, -66, 16, 15,
+	-1000, -132, -1, -2, -3, -4, -5, -6, -7, -8,
+	-9, -10, -11, -12, 6, 7, 8, 9, 28, -85,
+	106, -86, 107, 109, 108, 110, 118, 119, 120, -133,
+	191, -15, 5, -13, -136, -13, -13, -13, -13, -87,
+	-134, 47, -79, 113, 111, 102, -115, -116, -113, -112,
+	14, 121, 107, 122, 157, 108, 109, 177, 151, 149,
+	150, 154, 45, -111, 139, -117, 50, -108, 110, 133,
+	140, 112, 145, 147, 156, 29, 5, 146, 165, 98,
+	27, 113, -75, 115, 111, 111, 112, 113, 111, -129,
+	-129, -129, -2, 19, 20, -18, 34, 35, -14, -75,
+	-34, -32, -125, 50, 10, -72, -73, -52, -122, -125,
+	50, -88, -89, -99, -106, -105, -100, -103, -122, -121,
+	-82, 127, 128, 124, 50, 142, 143, 144, 145, 146,
+	147, 148, 149, 150, 151, 152, 153, 154, 155, 156,
+	157, 158, 159, 160, 161, 162, 163, 103, 112, 112,
+	-105, -77, 116, 48, 15, -81, -99, -106, 123, -81,
+	-81, -81, 123, -82, 125, 126, -82, 123, -78, 114,
+	21, 114, 129, 129, 50, 50, -108, 50, 139, -84,
+	148, 139, 160, 73, 148, -84, -84, -84, -84, -84,
+	-84, 28, -84, 146, 165, 98, -105, 111, -125, -76,
+	116, 50, -76, -125, -16, 32, 20, 58, -33, 25,
+	-32, 28, -127, -126, 21, -125, 52, 101, -32, 48,
+	73, 101, -135, 48, 49, 117, 156, 147, 186, 163,
+	155, 181, 46, 152, 65, -91, -92, 50, 141, 164,
+	165, 99, 28, -134, 117, 125, 102, 126, 133, -104,
+	-105, -83, -82, -83, -105, -105, -129, -119, -125, -121,
+	65, -113, -112, -118, -105, -89, -134, -105, -105, -89,
+	-105, -105, 103, 103, -105, -105, -119, 165, 146, -94,
+	50, 52, 53, 54, 27, 99, 153, 158, -84, -84,
+	-94, -94, -94, -94, -134, -94, -84, -94, -84, 28,
+	-84, -129, -119, 114, -125, 22, 46, -122, -17, 37,
+	-44, -122, 53, 56, -70, 28, -134, -32, -72, -35,
+	44, 115, 45, -126, -125, -37, 11, -73, -23, -38,
+	65, -43, -39, 22, -42, -52, -50, -51, 99, 88,
+	89, 96, 66, 100, -122, 179, -60, -61, -53, 30,
+	177, 178, 181, 61, 62, 52, 51, 53, 54, 55,
+	56, 60, -49, -134, 116, 167, 169, 94, 176, 38,
+	166, 168, 170, 175, 174, 173, 171, 172, 68, -122,
+	-125, -110, -109, -108, -89, -99, -106, 50, -84, 52,
+	159, 50, 9, 8, 152, 162, 161, 137, 165, 146,
+	98, 134, 21, 135, 136, 65, 60, 27, 46, 145,
+	125, 102, 103, 147, -134, 138, -102, -101, -105, 50,
+	103, -83, 103, -134, -104, -104, -80, 117, 22, 48,
+	-114, 153, 143, -90, -89, 28, 108, -89, -114, -105,
+	114, 114, 28, -130, 50, 52, 99, -94, -94, -95,
+	-120, -119, -94, -94, -84, -94, -125, -125, -129, -21,
+	-22, 90, -23, -125, -74, 18, 10, 30, 30, -41,
+	30, -2, -72, -69, -122, -37, 112, 112, 112, -62,
+	14, -23, 64, 63, 80, -23, -40, 83, 65, 81,
+	82, 67, 85, 84, 95, 88, 89, 90, 91, 92,
+	93, 94, 86, 87, 98, 73, 74, 75, 76, 77,
+	78, 79, -51, -134, 104, 105, -43, -43, -43, -43,
+	-43, -43, -134, -134, -134, -134, -134, -134, -134, -2,
+	-47, -23, -56, -23, 101, -96, 139, -108, 48, -94,
+	50, -107, 131, 132, 28, 158, -107, 28, -130, -130,
+	144, -134, 60, -98, -42, 59, -39, 89, -61, -122,
+	-134, 8, 103, 103, 52, 53, -97, 52, -135, 48,
+	23, 24, -134, -104, -104, -23, 46, -122, -105, -105,
+	48, -135, 27, 27, -114, -105, -105, -130, -93, 98,
+	-135, 48, 101, -94, -24, 48, 10, -124, -123, 21,
+	-122, 52, 101, -32, -71, 46, -48, -49, -134, -71,
+	-135, 48, 101, -62, -134, -134, -134, -66, 16, 15,
 	-23, -23, -45, 60, 65, 61, 62, -39, -43, -46,
-	-49, -51, 58, 83, 81, 82, 67, -43, -43, -43,
+	-49, -51, 57, 83, 81, 82, 67, -43, -43, -43,
 	-43, -43, -43, -43, -43, -43, -43, -43, -43, -43,
-	-43, -43, -90, 51, 53, 99, -42, -42, -82, -96,
-	-21, 20, -16, -96, -21, -82, -23, -23, -68, -82,
-	-96, 49, -96, -54, -55, 69, -82, -85, -85, -37,
-	-22, -25, -26, -27, -28, -34, -51, -95, -83, 90,
-	-85, 26, 49, -82, -82, -66, -36, -82, -36, -36,
-	-23, -63, -64, -23, 60, 61, 62, -46, -43, -43,
-	-43, 64, -96, -21, -21, -96, -96, 49, 116, 21,
-	-96, 49, -23, -57, -55, 71, -23, -89, -58, 12,
-	49, -29, -30, -31, 37, 41, 43, 38, 39, 40,
-	44, -88, 21, -25, 101, 27, -49, 101, -96, 49,
-	-96, -96, 49, 17, 49, -65, 23, 24, 64, -43,
-	-96, -62, -91, -90, -91, -91, 138, -82, 72, -23,
-	70, -59, 13, 15, -26, -27, -26, -27, 37, 37,
-	37, 42, 37, 42, 37, -30, -85, -96, 90, 8,
-	-82, -82, -23, -23, -64, -43, -20, 136, -96, 120,
-	-95, -90, 121, -96, -96, -95, -23, -62, -23, -47,
-	47, 47, 37, 37, -72, -96, 53, 54, 29, -43,
-	-66, -23, -23, -96, 49, -90, -19, 83, 142, -67,
-	18, 30, -90, 121, 54, -96, 139, 44, 143, 8,
-	83, 29, -96, 140, 141, 144, 145, -90, 140, 140,
-	142, 143, 144,
+	-43, -43, -130, -42, -42, -122, -135, -21, 20, -16,
+	-135, -21, -122, -23, -23, -68, -122, -135, 48, -135,
+	-54, -55, 69, -122, -108, 60, 27, 142, -130, 21,
+	-23, 53, 54, -134, -134, -23, -98, -135, 48, -135,
+	48, -101, 53, -134, -135, -119, -89, -98, -93, -130,
+	-120, -119, -37, -22, -25, -26, -27, -28, -34, -51,
+	-134, -123, 90, -125, 26, 48, -122, -122, -66, -36,
+	-122, -36, -36, -23, -63, -64, -23, 60, 61, 62,
+	-46, -43, -43, -43, 64, -135, -21, -21, -135, -135,
+	48, 117, 21, -135, 48, -23, -57, -55, 71, -23,
+	-134, -135, -135, 53, -135, 53, -135, 53, 52, -135,
+	-118, -129, -58, 12, 48, -29, -30, -31, 36, 40,
+	42, 37, 38, 39, 43, -128, 21, -25, 101, 103,
+	-49, 101, -135, 48, -135, -135, 48, 17, 48, -65,
+	23, 24, 64, -43, -135, -62, -131, -130, -131, -131,
+	182, -122, 72, -23, 70, -23, -135, -135, -135, -135,
+	-59, 13, 15, -26, -27, -26, -27, 36, 36, 36,
+	41, 36, 41, 36, -30, -125, -135, 90, 8, -122,
+	-122, -23, -23, -64, -43, -20, 180, -135, 164, -134,
+	-130, 165, -135, -135, -134, -23, -135, 130, -62, -23,
+	-47, 46, 46, 36, 36, -72, -135, 52, 53, 28,
+	-43, -120, -66, -23, -23, -135, 48, -130, -19, 83,
+	186, -134, -67, 18, 29, -130, 165, 53, -135, 183,
+	43, 187, -118, 8, 83, 28, -135, 184, 185, 188,
+	-135, 189, -130, 184, 184, 186, 187, 188,
 }
 var yyDef = [...]int{
 
 	0, -2, 2, 4, 5, 6, 7, 8, 9, 10,
-	11, 12, 13, 14, 37, 37, 37, 37, 37, 290,
-	280, 0, 0, 0, 298, 298, 298, 1, 3, 0,
-	41, 44, 39, 280, 0, 0, 0, 278, 0, 0,
-	291, 0, 0, 281, 0, 276, 0, 276, 0, 34,
-	35, 36, 17, 42, 43, 47, 45, 46, 38, 0,
-	0, 78, 95, 295, 0, 22, 271, 0, 223, 0,
-	-2, 0, 0, 0, 298, 294, 0, 298, 0, 0,
-	0, 0, 0, 33, 49, 0, 48, 40, 257, 0,
-	94, 0, 97, 79, 0, 81, 82, 0, 103, 0,
-	0, 0, 298, 0, 292, 25, 0, 28, 0, 30,
-	277, 0, 298, 0, 50, 0, 233, 0, 0, 0,
-	0, 0, 296, 93, 103, 71, 0, 0, 0, 80,
-	96, 240, 0, 272, 273, 105, 0, 110, 113, 0,
-	147, 148, 149, 150, 0, 0, 0, 0, 0, 0,
-	223, 0, 0, -2, 179, 0, 0, 0, 0, 111,
-	112, 226, 227, 228, 229, 230, 231, 232, 270, 0,
-	207, 208, 209, 210, 211, 212, 198, 199, 200, 201,
-	202, 203, 204, 205, 214, 224, 0, 23, 279, 0,
-	0, 298, 288, 282, 283, 284, 285, 286, 287, 29,
-	31, 32, 62, 51, 53, 57, 0, 0, 274, 275,
-	234, 235, 263, 0, 266, 263, 0, 259, 240, 0,
-	0, 0, 248, 0, 104, 0, 0, 0, 108, 0,
+	11, 12, 13, 14, 218, 218, 218, 218, 218, 0,
+	466, 0, 461, 0, 0, 0, 500, 500, 500, 1,
+	3, 0, 222, 225, 220, 461, 0, 0, 0, 23,
+	118, 498, 0, 0, 459, 467, 27, 171, 172, 174,
+	0, -2, 211, 211, 211, 205, 463, 0, 0, 0,
+	206, 207, 198, 199, 200, 0, 167, 141, 208, 209,
+	210, 0, 167, 167, 167, 167, 167, 167, 0, 167,
+	0, 0, 0, 462, 0, 457, 0, 457, 0, 35,
+	36, 37, 17, 223, 224, 228, 226, 227, 219, 0,
+	0, 259, 276, 471, 0, 22, 452, 0, 404, 0,
+	-2, 0, 39, 40, 41, 0, 0, 0, 472, 473,
+	126, 130, 130, 119, 470, 476, 477, 478, 479, 480,
+	481, 482, 483, 484, 485, 486, 487, 488, 489, 490,
+	491, 492, 493, 494, 495, 496, 497, 128, 129, 0,
+	500, 0, 0, 0, 0, 0, 179, 180, 212, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 464,
+	465, 0, 194, 195, 196, 197, 142, 167, 201, 0,
+	167, 202, 203, 168, 167, 0, 0, 0, 0, 0,
+	0, 167, 0, 167, 0, 167, 500, 0, 0, 0,
+	0, 0, 0, 34, 230, 0, 229, 221, 438, 0,
+	275, 0, 278, 260, 0, 262, 263, 0, 284, 0,
+	0, 0, 136, 118, 499, 0, 167, 0, 0, 97,
+	98, 0, 0, 116, 0, 45, 48, 72, 73, 75,
+	76, 77, 78, 0, 0, 0, 130, 0, 0, 101,
+	127, 126, 131, 126, 120, 468, 25, 26, 474, 475,
+	0, 173, 175, 176, 132, 213, 0, 0, 0, 213,
+	185, 186, 187, 0, 0, 0, 191, 0, 0, 143,
+	157, 158, 159, 160, 161, 162, 163, 164, 0, 0,
+	146, 147, 148, 149, 0, 151, 0, 153, 0, 167,
+	0, 28, 29, 0, 31, 458, 0, 500, 0, 231,
+	0, 414, 0, 0, 0, 0, 0, 274, 284, 252,
+	0, 0, 0, 261, 277, 421, 0, 453, 454, 286,
+	0, 291, 294, 0, 328, 329, 330, 331, 0, 0,
+	0, 0, 0, 0, 404, 0, 0, -2, 360, 0,
+	0, 0, 0, 292, 293, 407, 408, 409, 410, 411,
+	412, 413, 451, 0, 388, 389, 390, 391, 392, 393,
+	379, 380, 381, 382, 383, 384, 385, 386, 395, 405,
+	0, 169, 137, 138, 42, 43, 44, 93, 0, 95,
+	0, 113, 0, 0, 117, 52, 53, 54, 0, 0,
+	0, 0, 0, 60, 61, 0, 63, 0, 0, 66,
+	0, 68, 70, 0, 0, 74, 0, 105, 107, 104,
+	99, 126, 126, 0, 102, 103, 0, 0, 460, 0,
+	177, 214, 0, 0, 46, 0, 0, 213, 184, 188,
+	0, 0, 0, 216, 325, 326, 327, 144, 145, 0,
+	165, 134, 152, 154, 0, 156, 30, 32, 33, 243,
+	232, 234, 238, 0, 0, 455, 456, 415, 416, 444,
+	0, 447, 444, 0, 440, 421, 0, 0, 0, 429,
+	0, 285, 0, 0, 0, 289, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 131, 132,
-	133, 134, 135, 136, 137, 124, 0, 0, 0, 166,
-	167, 168, 169, 170, 0, 0, 47, 0, 0, 0,
-	0, 0, 0, 0, 142, 0, 215, 0, 0, 293,
-	26, 0, 289, 103, 0, 0, 54, 58, 0, 60,
-	61, 0, 16, 18, 0, 265, 267, 0, 19, 258,
-	0, 297, 0, 248, 0, 0, 0, 21, 0, 0,
-	106, 107, 109, 125, 0, 127, 129, 114, 115, 116,
-	138, 139, 140, 0, 0, 0, 0, 118, 120, 0,
-	151, 152, 153, 154, 155, 156, 157, 158, 159, 160,
-	161, 162, 165, 144, 145, 146, 163, 164, 171, 172,
-	0, 0, 0, 176, 0, 0, 0, 0, 0, 255,
-	141, 0, 269, 221, 218, 0, 225, 298, 27, 236,
-	52, 63, 64, 66, 67, 68, 76, 0, 59, 55,
-	0, 0, 0, 261, 260, 20, 0, 101, 0, 0,
-	249, 241, 242, 245, 126, 128, 130, 117, 119, 121,
-	0, 0, 173, 0, 240, 177, 180, 0, 0, 0,
-	0, 0, 143, 0, 219, 0, 0, 24, 238, 0,
-	0, 0, 0, 0, 83, 0, 0, 86, 0, 0,
-	0, 0, 77, 0, 0, 0, 268, 0, 98, 0,
-	99, 100, 0, 0, 0, 244, 246, 247, 0, 122,
-	174, 216, 0, 190, 0, 0, 0, 256, 213, 222,
-	0, 240, 0, 0, 65, 72, 0, 75, 84, 85,
-	87, 0, 89, 0, 91, 92, 69, 70, 56, 0,
-	262, 102, 250, 251, 243, 123, 0, 0, 181, 191,
-	0, 195, 0, 182, 183, 0, 220, 248, 239, 237,
-	0, 0, 88, 90, 264, 175, 217, 0, 0, 185,
-	252, 73, 74, 192, 0, 196, 0, 0, 0, 15,
-	0, 0, 193, 0, 0, 184, 0, 0, 0, 253,
-	0, 0, 197, 186, 0, 189, 0, 194, 187, 254,
-	0, 0, 188,
+	0, 0, 0, 0, 0, 312, 313, 314, 315, 316,
+	317, 318, 305, 0, 0, 0, 347, 348, 349, 350,
+	351, 0, 0, 228, 0, 0, 0, 0, 0, 0,
+	0, 323, 0, 396, 0, 38, 170, 139, 0, 94,
+	96, 114, 121, 122, 0, 0, 115, 0, 56, 57,
+	0, 0, 62, 64, 81, 82, 83, 0, 86, 0,
+	0, 0, 67, 69, 71, 0, 0, 79, 92, 0,
+	109, 110, 0, 100, 0, 0, 0, 469, 133, 215,
+	0, 178, 0, 182, 183, 189, 190, 216, 193, 0,
+	150, 0, 0, 155, 284, 0, 0, 235, 239, 0,
+	241, 242, 0, 16, 18, 0, 446, 448, 0, 19,
+	439, 0, 0, 429, 0, 0, 0, 21, 0, 0,
+	287, 288, 290, 306, 0, 308, 310, 295, 296, 297,
+	319, 320, 321, 0, 0, 0, 0, 299, 301, 0,
+	332, 333, 334, 335, 336, 337, 338, 339, 340, 341,
+	342, 343, 346, 344, 345, 352, 353, 0, 0, 0,
+	357, 0, 0, 0, 0, 0, 436, 322, 0, 450,
+	402, 399, 0, 406, 140, 123, 124, 125, 55, 0,
+	0, 84, 85, 0, 0, 0, 65, 49, 0, 51,
+	0, 106, 0, 0, 112, 500, 47, 181, 192, 217,
+	166, 135, 417, 233, 244, 245, 247, 248, 249, 257,
+	0, 240, 236, 0, 0, 0, 442, 441, 20, 0,
+	282, 0, 0, 430, 422, 423, 426, 307, 309, 311,
+	298, 300, 302, 0, 0, 354, 0, 421, 358, 361,
+	0, 0, 0, 0, 0, 324, 0, 400, 0, 0,
+	0, 59, 87, 0, 89, 0, 91, 0, 80, 108,
+	0, 24, 419, 0, 0, 0, 0, 0, 264, 0,
+	0, 267, 0, 0, 0, 0, 258, 0, 0, 0,
+	449, 0, 279, 0, 280, 281, 0, 0, 0, 425,
+	427, 428, 0, 303, 355, 397, 0, 371, 0, 0,
+	0, 437, 394, 403, 0, 0, 88, 90, 50, 0,
+	421, 0, 0, 246, 253, 0, 256, 265, 266, 268,
+	0, 270, 0, 272, 273, 250, 251, 237, 0, 443,
+	283, 431, 432, 424, 304, 0, 0, 362, 372, 0,
+	376, 0, 363, 364, 0, 401, 58, 0, 429, 420,
+	418, 0, 0, 269, 271, 445, 356, 398, 0, 0,
+	366, 0, 433, 254, 255, 373, 0, 377, 0, 0,
+	0, 0, 15, 0, 0, 374, 0, 0, 365, 0,
+	0, 0, 0, 434, 0, 0, 378, 367, 0, 370,
+	111, 0, 375, 368, 435, 0, 0, 369,
 }
 var yyTok1 = [...]int{
 
@@ -775,8 +1149,8 @@ var yyTok1 = [...]int{
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 66, 3, 3, 3, 93, 85, 3,
-	48, 50, 90, 88, 49, 89, 101, 91, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 147,
+	47, 49, 90, 88, 48, 89, 101, 91, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 191,
 	74, 73, 75, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
@@ -791,7 +1165,7 @@ var yyTok2 = [...]int{
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
 	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
-	42, 43, 44, 45, 46, 47, 51, 52, 53, 54,
+	42, 43, 44, 45, 46, 50, 51, 52, 53, 54,
 	55, 56, 57, 58, 59, 60, 61, 62, 63, 64,
 	65, 67, 68, 69, 70, 71, 72, 76, 77, 78,
 	79, 80, 81, 82, 83, 86, 87, 92, 94, 97,
@@ -799,7 +1173,12 @@ var yyTok2 = [...]int{
 	109, 110, 111, 112, 113, 114, 115, 116, 117, 118,
 	119, 120, 121, 122, 123, 124, 125, 126, 127, 128,
 	129, 130, 131, 132, 133, 134, 135, 136, 137, 138,
-	139, 140, 141, 142, 143, 144, 145, 146,
+	139, 140, 141, 142, 143, 144, 145, 146, 147, 148,
+	149, 150, 151, 152, 153, 154, 155, 156, 157, 158,
+	159, 160, 161, 162, 163, 164, 165, 166, 167, 168,
+	169, 170, 171, 172, 173, 174, 175, 176, 177, 178,
+	179, 180, 181, 182, 183, 184, 185, 186, 187, 188,
+	189, 190,
 }
 var yyTok3 = [...]int{
 	0,
@@ -1144,53 +1523,53 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:202
+		//line ./go/vt/sqlparser/sql.y:300
 		{
 			setParseTree(yylex, yyDollar[1].statement)
 		}
 	case 2:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:207
+		//line ./go/vt/sqlparser/sql.y:305
 		{
 		}
 	case 3:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:208
+		//line ./go/vt/sqlparser/sql.y:306
 		{
 		}
 	case 4:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:212
+		//line ./go/vt/sqlparser/sql.y:310
 		{
 			yyVAL.statement = yyDollar[1].selStmt
 		}
 	case 15:
 		yyDollar = yyS[yypt-13 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:228
+		//line ./go/vt/sqlparser/sql.y:326
 		{
 			yyVAL.selStmt = &Select{Comments: Comments(yyDollar[2].bytes2), Cache: yyDollar[3].str, Distinct: yyDollar[4].str, Hints: yyDollar[5].str, SelectExprs: yyDollar[6].selectExprs, From: yyDollar[7].tableExprs, Where: NewWhere(WhereStr, yyDollar[8].expr), GroupBy: GroupBy(yyDollar[9].exprs), Having: NewWhere(HavingStr, yyDollar[10].expr), OrderBy: yyDollar[11].orderBy, Limit: yyDollar[12].limit, Lock: yyDollar[13].str}
 		}
 	case 16:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:232
+		//line ./go/vt/sqlparser/sql.y:330
 		{
 			yyVAL.selStmt = &Select{Comments: Comments(yyDollar[2].bytes2), Cache: yyDollar[3].str, SelectExprs: SelectExprs{Nextval{Expr: yyDollar[5].expr}}, From: TableExprs{&AliasedTableExpr{Expr: yyDollar[7].tableName}}}
 		}
 	case 17:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:236
+		//line ./go/vt/sqlparser/sql.y:334
 		{
 			yyVAL.selStmt = &Union{Type: yyDollar[2].str, Left: yyDollar[1].selStmt, Right: yyDollar[3].selStmt}
 		}
 	case 18:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:242
+		//line ./go/vt/sqlparser/sql.y:340
 		{
 			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[4].tableName, Columns: yyDollar[5].columns, Rows: yyDollar[6].insRows, OnDup: OnDup(yyDollar[7].updateExprs)}
 		}
 	case 19:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:246
+		//line ./go/vt/sqlparser/sql.y:344
 		{
 			cols := make(Columns, 0, len(yyDollar[6].updateExprs))
 			vals := make(ValTuple, 0, len(yyDollar[7].updateExprs))
@@ -1202,69 +1581,87 @@ yydefault:
 		}
 	case 20:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:258
+		//line ./go/vt/sqlparser/sql.y:356
 		{
 			yyVAL.statement = &Update{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[3].aliasedTableName, Exprs: yyDollar[5].updateExprs, Where: NewWhere(WhereStr, yyDollar[6].expr), OrderBy: yyDollar[7].orderBy, Limit: yyDollar[8].limit}
 		}
 	case 21:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:264
+		//line ./go/vt/sqlparser/sql.y:362
 		{
 			yyVAL.statement = &Delete{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Where: NewWhere(WhereStr, yyDollar[5].expr), OrderBy: yyDollar[6].orderBy, Limit: yyDollar[7].limit}
 		}
 	case 22:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:270
+		//line ./go/vt/sqlparser/sql.y:368
 		{
 			yyVAL.statement = &Set{Comments: Comments(yyDollar[2].bytes2), Exprs: yyDollar[3].updateExprs}
 		}
 	case 23:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:276
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:374
 		{
-			yyVAL.statement = &DDL{Action: CreateStr, NewName: yyDollar[4].tableIdent}
+			yyVAL.statement = &DDL{Action: CreateStr, NewName: yyDollar[1].ddl.NewName, IfNotExists: yyDollar[1].ddl.IfNotExists, TableSpec: yyDollar[2].tableSpec}
 		}
 	case 24:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:280
+		//line ./go/vt/sqlparser/sql.y:378
 		{
 			// Change this to an alter statement
 			yyVAL.statement = &DDL{Action: AlterStr, Table: yyDollar[7].tableIdent, NewName: yyDollar[7].tableIdent}
 		}
 	case 25:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:285
+		//line ./go/vt/sqlparser/sql.y:383
 		{
 			yyVAL.statement = &DDL{Action: CreateStr, NewName: NewTableIdent(yyDollar[3].colIdent.Lowered())}
 		}
 	case 26:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:291
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:392
 		{
-			yyVAL.statement = &DDL{Action: AlterStr, Table: yyDollar[4].tableIdent, NewName: yyDollar[4].tableIdent}
+			yyVAL.ddl = &DDL{Action: CreateStr, NewName: yyDollar[4].tableIdent, IfNotExists: yyDollar[3].byt != 0}
+			setDDL(yylex, yyVAL.ddl)
 		}
 	case 27:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:295
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:399
 		{
-			// Change this to a rename statement
-			yyVAL.statement = &DDL{Action: RenameStr, Table: yyDollar[4].tableIdent, NewName: yyDollar[7].tableIdent}
+			if len(yyDollar[2].alterSpecs) == 1 && yyDollar[2].alterSpecs[0].Action == RenameTableStr {
+				// Change this to a rename statement
+				yyVAL.statement = &DDL{Action: RenameStr, Table: yyDollar[1].ddl.Table, NewName: yyDollar[2].alterSpecs[0].NewTable}
+			} else {
+				ddl := &DDL{Action: AlterStr, Table: yyDollar[1].ddl.Table, NewName: yyDollar[1].ddl.NewName, AlterSpecs: yyDollar[2].alterSpecs}
+				for _, spec := range yyDollar[2].alterSpecs {
+					if spec.Action == RenameTableStr {
+						ddl.NewName = spec.NewTable
+					}
+				}
+				yyVAL.statement = ddl
+			}
 		}
 	case 28:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:300
+		//line ./go/vt/sqlparser/sql.y:414
 		{
 			yyVAL.statement = &DDL{Action: AlterStr, Table: NewTableIdent(yyDollar[3].colIdent.Lowered()), NewName: NewTableIdent(yyDollar[3].colIdent.Lowered())}
 		}
 	case 29:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:423
+		{
+			yyVAL.ddl = &DDL{Action: AlterStr, Table: yyDollar[4].tableIdent, NewName: yyDollar[4].tableIdent}
+			setDDL(yylex, yyVAL.ddl)
+		}
+	case 30:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:306
+		//line ./go/vt/sqlparser/sql.y:430
 		{
 			yyVAL.statement = &DDL{Action: RenameStr, Table: yyDollar[3].tableIdent, NewName: yyDollar[5].tableIdent}
 		}
-	case 30:
+	case 31:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:312
+		//line ./go/vt/sqlparser/sql.y:436
 		{
 			var exists bool
 			if yyDollar[3].byt != 0 {
@@ -1272,16 +1669,16 @@ yydefault:
 			}
 			yyVAL.statement = &DDL{Action: DropStr, Table: yyDollar[4].tableIdent, IfExists: exists}
 		}
-	case 31:
+	case 32:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:320
+		//line ./go/vt/sqlparser/sql.y:444
 		{
 			// Change this to an alter statement
 			yyVAL.statement = &DDL{Action: AlterStr, Table: yyDollar[5].tableIdent, NewName: yyDollar[5].tableIdent}
 		}
-	case 32:
+	case 33:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:325
+		//line ./go/vt/sqlparser/sql.y:449
 		{
 			var exists bool
 			if yyDollar[3].byt != 0 {
@@ -1289,794 +1686,1932 @@ yydefault:
 			}
 			yyVAL.statement = &DDL{Action: DropStr, Table: NewTableIdent(yyDollar[4].colIdent.Lowered()), IfExists: exists}
 		}
-	case 33:
+	case 34:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:335
+		//line ./go/vt/sqlparser/sql.y:459
 		{
 			yyVAL.statement = &DDL{Action: AlterStr, Table: yyDollar[3].tableIdent, NewName: yyDollar[3].tableIdent}
 		}
-	case 34:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:341
-		{
-			yyVAL.statement = &Other{}
-		}
 	case 35:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:345
+		//line ./go/vt/sqlparser/sql.y:465
 		{
 			yyVAL.statement = &Other{}
 		}
 	case 36:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:349
+		//line ./go/vt/sqlparser/sql.y:469
 		{
 			yyVAL.statement = &Other{}
 		}
 	case 37:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:354
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:473
 		{
-			setAllowComments(yylex, true)
+			yyVAL.statement = &Other{}
 		}
 	case 38:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:358
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:479
 		{
-			yyVAL.bytes2 = yyDollar[2].bytes2
-			setAllowComments(yylex, false)
+			yyVAL.tableSpec = yyDollar[2].tableSpec
+			yyVAL.tableSpec.Options = yyDollar[4].tableOptions
+			yyVAL.tableSpec.Partitions = yyDollar[5].str
 		}
 	case 39:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:364
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:487
 		{
-			yyVAL.bytes2 = nil
+			yyVAL.tableSpec = &TableSpec{Columns: []*ColumnDefinition{yyDollar[1].columnDefinition}}
 		}
 	case 40:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:368
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:491
 		{
-			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
+			yyVAL.tableSpec = &TableSpec{Indexes: []*IndexDefinition{yyDollar[1].indexDefinition}}
 		}
 	case 41:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:374
+		//line ./go/vt/sqlparser/sql.y:495
 		{
-			yyVAL.str = UnionStr
+			yyVAL.tableSpec = &TableSpec{Constraints: []*ConstraintDefinition{yyDollar[1].constraintDefinition}}
 		}
 	case 42:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:378
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:499
 		{
-			yyVAL.str = UnionAllStr
+			yyVAL.tableSpec = yyDollar[1].tableSpec
+			yyVAL.tableSpec.Columns = append(yyVAL.tableSpec.Columns, yyDollar[3].columnDefinition)
 		}
 	case 43:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:382
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:504
 		{
-			yyVAL.str = UnionDistinctStr
+			yyVAL.tableSpec = yyDollar[1].tableSpec
+			yyVAL.tableSpec.Indexes = append(yyVAL.tableSpec.Indexes, yyDollar[3].indexDefinition)
 		}
 	case 44:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:387
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:509
 		{
-			yyVAL.str = ""
+			yyVAL.tableSpec = yyDollar[1].tableSpec
+			yyVAL.tableSpec.Constraints = append(yyVAL.tableSpec.Constraints, yyDollar[3].constraintDefinition)
 		}
 	case 45:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:391
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:516
 		{
-			yyVAL.str = SQLNoCacheStr
+			yyVAL.columnDefinition = &ColumnDefinition{Name: yyDollar[1].colIdent, Type: yyDollar[2].columnType}
 		}
 	case 46:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:395
+		//line ./go/vt/sqlparser/sql.y:522
 		{
-			yyVAL.str = SQLCacheStr
+			yyVAL.columnDefinitions = []*ColumnDefinition{yyDollar[1].columnDefinition}
 		}
 	case 47:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:400
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:526
 		{
-			yyVAL.str = ""
+			yyVAL.columnDefinitions = append(yyDollar[1].columnDefinitions, yyDollar[3].columnDefinition)
 		}
 	case 48:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:404
+		//line ./go/vt/sqlparser/sql.y:533
 		{
-			yyVAL.str = DistinctStr
+			yyVAL.columnType = &ColumnType{Type: yyDollar[1].str}
 		}
 	case 49:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:409
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:537
 		{
-			yyVAL.str = ""
+			yyVAL.columnType = &ColumnType{Type: yyDollar[1].str, Length: NewIntVal(yyDollar[3].bytes)}
 		}
 	case 50:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:413
+		yyDollar = yyS[yypt-6 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:541
 		{
-			yyVAL.str = StraightJoinHint
+			yyVAL.columnType = &ColumnType{Type: yyDollar[1].str, Length: NewIntVal(yyDollar[3].bytes), Scale: NewIntVal(yyDollar[5].bytes)}
 		}
 	case 51:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:419
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:545
 		{
-			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
+			yyVAL.columnType = &ColumnType{Type: yyDollar[1].str, EnumValues: yyDollar[3].strs}
 		}
 	case 52:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:423
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:549
 		{
-			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Unsigned = true
 		}
 	case 53:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:429
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:554
 		{
-			yyVAL.selectExpr = &StarExpr{}
+			yyVAL.columnType = yyDollar[1].columnType
 		}
 	case 54:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:433
+		//line ./go/vt/sqlparser/sql.y:558
 		{
-			yyVAL.selectExpr = &NonStarExpr{Expr: yyDollar[1].expr, As: yyDollar[2].colIdent}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Zerofill = true
 		}
 	case 55:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:437
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:563
 		{
-			yyVAL.selectExpr = &StarExpr{TableName: &TableName{Name: yyDollar[1].tableIdent}}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Charset = yyDollar[4].str
 		}
 	case 56:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:441
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:568
 		{
-			yyVAL.selectExpr = &StarExpr{TableName: &TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent}}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Charset = yyDollar[3].str
 		}
 	case 57:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:446
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:573
 		{
-			yyVAL.colIdent = ColIdent{}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Collate = yyDollar[3].str
 		}
 	case 58:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:450
+		yyDollar = yyS[yypt-7 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:578
 		{
-			yyVAL.colIdent = yyDollar[1].colIdent
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.As = yyDollar[6].expr
 		}
 	case 59:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:583
+		{
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.As = yyDollar[4].expr
+		}
+	case 60:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:454
+		//line ./go/vt/sqlparser/sql.y:588
 		{
-			yyVAL.colIdent = yyDollar[2].colIdent
+			yyVAL.columnType = yyDollar[1].columnType
 		}
 	case 61:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:461
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:592
 		{
-			yyVAL.colIdent = NewColIdent(string(yyDollar[1].bytes))
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Stored = true
 		}
 	case 62:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:466
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:597
 		{
-			yyVAL.tableExprs = TableExprs{&AliasedTableExpr{Expr: &TableName{Name: NewTableIdent("dual")}}}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.NotNull = true
 		}
 	case 63:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:470
+		//line ./go/vt/sqlparser/sql.y:602
 		{
-			yyVAL.tableExprs = yyDollar[2].tableExprs
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Null = true
 		}
 	case 64:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:476
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:607
 		{
-			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Default = yyDollar[3].expr
 		}
 	case 65:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:612
+		{
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.OnUpdate = yyDollar[4].expr
+		}
+	case 66:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:617
+		{
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Autoincrement = true
+		}
+	case 67:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:480
+		//line ./go/vt/sqlparser/sql.y:622
 		{
-			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.KeyOpt = ColumnKeyPrimary
 		}
 	case 68:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:490
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:627
 		{
-			yyVAL.tableExpr = yyDollar[1].aliasedTableName
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.KeyOpt = ColumnKeyUnique
 		}
 	case 69:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:494
+		//line ./go/vt/sqlparser/sql.y:632
 		{
-			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].subquery, As: yyDollar[3].tableIdent}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.KeyOpt = ColumnKeyUnique
 		}
 	case 70:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:498
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:637
 		{
-			yyVAL.tableExpr = &ParenTableExpr{Exprs: yyDollar[2].tableExprs}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.KeyOpt = ColumnKey
 		}
 	case 71:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:504
+		//line ./go/vt/sqlparser/sql.y:642
 		{
-			yyVAL.aliasedTableName = &AliasedTableExpr{Expr: yyDollar[1].tableName, As: yyDollar[2].tableIdent, Hints: yyDollar[3].indexHints}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Comment = NewStrVal(yyDollar[3].bytes)
 		}
 	case 72:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:517
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:649
 		{
-			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
+			yyVAL.str = strings.ToLower(string(yyDollar[1].bytes))
 		}
 	case 73:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:521
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:653
 		{
-			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].expr}
+			yyVAL.str = string(yyDollar[1].bytes)
 		}
 	case 74:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:525
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:657
 		{
-			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].expr}
+			yyVAL.str = string(yyDollar[1].bytes) + " precision"
 		}
 	case 75:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:529
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:661
 		{
-			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
+			yyVAL.str = "integer"
 		}
 	case 76:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:534
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:665
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.str = "character"
 		}
 	case 77:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:536
+		//line ./go/vt/sqlparser/sql.y:669
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.str = "binary"
 		}
 	case 78:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:539
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:673
 		{
-			yyVAL.tableIdent = NewTableIdent("")
+			yyVAL.str = "set"
 		}
 	case 79:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:543
+		//line ./go/vt/sqlparser/sql.y:679
 		{
-			yyVAL.tableIdent = yyDollar[1].tableIdent
+			yyVAL.strs = []string{string(yyDollar[1].bytes)}
 		}
 	case 80:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:547
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:683
 		{
-			yyVAL.tableIdent = yyDollar[2].tableIdent
+			yyVAL.strs = append(yyDollar[1].strs, string(yyDollar[3].bytes))
 		}
 	case 82:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:554
+		//line ./go/vt/sqlparser/sql.y:692
 		{
-			yyVAL.tableIdent = NewTableIdent(string(yyDollar[1].bytes))
+			yyVAL.expr = NewBitVal(yyDollar[1].bytes)
 		}
 	case 83:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:560
+		//line ./go/vt/sqlparser/sql.y:696
 		{
-			yyVAL.str = JoinStr
+			yyVAL.expr = yyDollar[1].boolVal
 		}
 	case 84:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:564
+		//line ./go/vt/sqlparser/sql.y:700
 		{
-			yyVAL.str = JoinStr
+			yyVAL.expr = NewIntVal(append([]byte("-"), yyDollar[2].bytes...))
 		}
 	case 85:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:568
+		//line ./go/vt/sqlparser/sql.y:704
 		{
-			yyVAL.str = JoinStr
+			yyVAL.expr = NewFloatVal(append([]byte("-"), yyDollar[2].bytes...))
 		}
 	case 86:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:572
+		//line ./go/vt/sqlparser/sql.y:708
 		{
-			yyVAL.str = StraightJoinStr
+			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent}
 		}
 	case 87:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:578
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:712
 		{
-			yyVAL.str = LeftJoinStr
+			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent}
 		}
 	case 88:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:582
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:716
 		{
-			yyVAL.str = LeftJoinStr
+			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent, Exprs: SelectExprs{&NonStarExpr{Expr: NewIntVal(yyDollar[3].bytes)}}}
 		}
 	case 89:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:586
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:720
 		{
-			yyVAL.str = RightJoinStr
+			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent}
 		}
 	case 90:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:590
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:724
 		{
-			yyVAL.str = RightJoinStr
+			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent, Exprs: SelectExprs{&NonStarExpr{Expr: NewIntVal(yyDollar[3].bytes)}}}
 		}
 	case 91:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:596
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:728
 		{
-			yyVAL.str = NaturalJoinStr
+			yyVAL.expr = yyDollar[2].expr
 		}
 	case 92:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:600
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:734
 		{
-			if yyDollar[2].str == LeftJoinStr {
-				yyVAL.str = NaturalLeftJoinStr
-			} else {
-				yyVAL.str = NaturalRightJoinStr
-			}
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+			yyVAL.indexDefinition.Columns = yyDollar[3].indexColumns
 		}
 	case 93:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:610
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:739
 		{
-			yyVAL.tableName = yyDollar[2].tableName
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+			yyVAL.indexDefinition.Using = strings.ToLower(string(yyDollar[3].bytes))
 		}
 	case 94:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:614
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:744
 		{
-			yyVAL.tableName = yyDollar[1].tableName
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+			yyVAL.indexDefinition.Options = append(yyVAL.indexDefinition.Options, &IndexOption{Name: "key_block_size", Value: yyDollar[4].str})
 		}
 	case 95:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:620
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:749
 		{
-			yyVAL.tableName = &TableName{Name: yyDollar[1].tableIdent}
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+			yyVAL.indexDefinition.Options = append(yyVAL.indexDefinition.Options, &IndexOption{Name: "comment", Value: String(NewStrVal(yyDollar[3].bytes))})
 		}
 	case 96:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:624
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:754
 		{
-			yyVAL.tableName = &TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent}
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+			yyVAL.indexDefinition.Options = append(yyVAL.indexDefinition.Options, &IndexOption{Name: "with parser", Value: string(yyDollar[4].bytes)})
 		}
 	case 97:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:629
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:759
 		{
-			yyVAL.indexHints = nil
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+			yyVAL.indexDefinition.Options = append(yyVAL.indexDefinition.Options, &IndexOption{Name: "visible"})
 		}
 	case 98:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:633
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:764
 		{
-			yyVAL.indexHints = &IndexHints{Type: UseStr, Indexes: yyDollar[4].colIdents}
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+			yyVAL.indexDefinition.Options = append(yyVAL.indexDefinition.Options, &IndexOption{Name: "invisible"})
 		}
 	case 99:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:637
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:771
 		{
-			yyVAL.indexHints = &IndexHints{Type: IgnoreStr, Indexes: yyDollar[4].colIdents}
+			yyVAL.indexDefinition = &IndexDefinition{Type: IndexTypePrimary}
 		}
 	case 100:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:641
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:775
 		{
-			yyVAL.indexHints = &IndexHints{Type: ForceStr, Indexes: yyDollar[4].colIdents}
+			// The constraint names the index if it doesn't have a name.
+			name := yyDollar[4].colIdent
+			if name.IsEmpty() {
+				name = yyDollar[1].colIdent
+			}
+			yyVAL.indexDefinition = &IndexDefinition{Type: IndexTypeUnique, Name: name}
 		}
 	case 101:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:647
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:784
 		{
-			yyVAL.colIdents = []ColIdent{yyDollar[1].colIdent}
+			yyVAL.indexDefinition = &IndexDefinition{Type: IndexTypeKey, Name: yyDollar[2].colIdent}
 		}
 	case 102:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:651
+		//line ./go/vt/sqlparser/sql.y:788
 		{
-			yyVAL.colIdents = append(yyDollar[1].colIdents, yyDollar[3].colIdent)
+			yyVAL.indexDefinition = &IndexDefinition{Type: IndexTypeFulltext, Name: yyDollar[3].colIdent}
 		}
 	case 103:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:656
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:792
 		{
-			yyVAL.expr = nil
+			yyVAL.indexDefinition = &IndexDefinition{Type: IndexTypeSpatial, Name: yyDollar[3].colIdent}
 		}
 	case 104:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:660
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:796
 		{
-			yyVAL.expr = yyDollar[2].expr
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+			yyVAL.indexDefinition.Using = strings.ToLower(string(yyDollar[3].bytes))
 		}
 	case 105:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:666
+		//line ./go/vt/sqlparser/sql.y:803
 		{
-			yyVAL.expr = yyDollar[1].expr
+			yyVAL.indexColumns = []*IndexColumn{yyDollar[1].indexColumn}
 		}
 	case 106:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:670
+		//line ./go/vt/sqlparser/sql.y:807
 		{
-			yyVAL.expr = &AndExpr{Left: yyDollar[1].expr, Right: yyDollar[3].expr}
+			yyVAL.indexColumns = append(yyDollar[1].indexColumns, yyDollar[3].indexColumn)
 		}
 	case 107:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:674
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:813
+		{
+			yyVAL.indexColumn = &IndexColumn{Column: yyDollar[1].colIdent}
+		}
+	case 108:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:817
+		{
+			yyVAL.indexColumn = &IndexColumn{Column: yyDollar[1].colIdent, Length: NewIntVal(yyDollar[3].bytes)}
+		}
+	case 109:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:821
+		{
+			yyVAL.indexColumn = yyDollar[1].indexColumn
+			yyVAL.indexColumn.Order = AscScr
+		}
+	case 110:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:826
+		{
+			yyVAL.indexColumn = yyDollar[1].indexColumn
+			yyVAL.indexColumn.Order = DescScr
+		}
+	case 111:
+		yyDollar = yyS[yypt-12 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:833
+		{
+			yyVAL.constraintDefinition = &ConstraintDefinition{Name: yyDollar[1].colIdent, Type: ConstraintForeignKey, IndexName: yyDollar[4].colIdent, Columns: yyDollar[6].columns, ReferencedTable: yyDollar[9].tableName, ReferencedColumns: yyDollar[11].columns}
+		}
+	case 112:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:837
+		{
+			yyVAL.constraintDefinition = &ConstraintDefinition{Name: yyDollar[1].colIdent, Type: ConstraintCheck, Check: yyDollar[4].expr}
+		}
+	case 113:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:841
+		{
+			yyVAL.constraintDefinition = yyDollar[1].constraintDefinition
+			yyVAL.constraintDefinition.Match = strings.ToLower(string(yyDollar[3].bytes))
+		}
+	case 114:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:846
+		{
+			yyVAL.constraintDefinition = yyDollar[1].constraintDefinition
+			yyVAL.constraintDefinition.OnDelete = yyDollar[4].str
+		}
+	case 115:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:851
+		{
+			yyVAL.constraintDefinition = yyDollar[1].constraintDefinition
+			yyVAL.constraintDefinition.OnUpdate = yyDollar[4].str
+		}
+	case 116:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:856
+		{
+			yyVAL.constraintDefinition = yyDollar[1].constraintDefinition
+		}
+	case 117:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:860
+		{
+			yyVAL.constraintDefinition = yyDollar[1].constraintDefinition
+			yyVAL.constraintDefinition.NotEnforced = true
+		}
+	case 118:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:866
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 119:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:870
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 120:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:874
+		{
+			yyVAL.colIdent = yyDollar[2].colIdent
+		}
+	case 121:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:880
+		{
+			yyVAL.str = "restrict"
+		}
+	case 122:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:884
+		{
+			yyVAL.str = "cascade"
+		}
+	case 123:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:888
+		{
+			yyVAL.str = "set null"
+		}
+	case 124:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:892
+		{
+			yyVAL.str = "set default"
+		}
+	case 125:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:896
+		{
+			yyVAL.str = "no action"
+		}
+	case 126:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:901
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 128:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:908
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 129:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:910
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 130:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:913
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 131:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:915
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 132:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:919
+		{
+			yyVAL.columns = Columns{yyDollar[1].colIdent}
+		}
+	case 133:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:923
+		{
+			yyVAL.columns = append(yyDollar[1].columns, yyDollar[3].colIdent)
+		}
+	case 134:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:929
+		{
+			yyVAL.tableName = &TableName{Name: yyDollar[1].tableIdent}
+		}
+	case 135:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:933
+		{
+			yyVAL.tableName = &TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent}
+		}
+	case 136:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:938
+		{
+			yyVAL.tableOptions = nil
+		}
+	case 138:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:946
+		{
+			yyVAL.tableOptions = TableOptions{yyDollar[1].tableOption}
+		}
+	case 139:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:950
+		{
+			yyVAL.tableOptions = append(yyDollar[1].tableOptions, yyDollar[2].tableOption)
+		}
+	case 140:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:954
+		{
+			yyVAL.tableOptions = append(yyDollar[1].tableOptions, yyDollar[3].tableOption)
+		}
+	case 141:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:960
+		{
+			yyVAL.tableOptions = TableOptions{yyDollar[1].tableOption}
+		}
+	case 142:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:964
+		{
+			yyVAL.tableOptions = append(yyDollar[1].tableOptions, yyDollar[2].tableOption)
+		}
+	case 143:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:970
+		{
+			name := strings.ToLower(string(yyDollar[1].bytes))
+			if !tableOptionNames[name] {
+				yylex.Error("syntax error")
+				return 1
+			}
+			yyVAL.tableOption = &TableOption{Name: name, Value: yyDollar[3].str}
+		}
+	case 144:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:979
+		{
+			if strings.ToLower(string(yyDollar[1].bytes)) != "data" {
+				yylex.Error("syntax error")
+				return 1
+			}
+			yyVAL.tableOption = &TableOption{Name: "data directory", Value: yyDollar[4].str}
+		}
+	case 145:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:987
+		{
+			yyVAL.tableOption = &TableOption{Name: "index directory", Value: yyDollar[4].str}
+		}
+	case 146:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:991
+		{
+			yyVAL.tableOption = &TableOption{Name: "auto_increment", Value: yyDollar[3].str}
+		}
+	case 147:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:995
+		{
+			yyVAL.tableOption = &TableOption{Name: "comment", Value: yyDollar[3].str}
+		}
+	case 148:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:999
+		{
+			yyVAL.tableOption = &TableOption{Name: "key_block_size", Value: yyDollar[3].str}
+		}
+	case 149:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1003
+		{
+			yyVAL.tableOption = &TableOption{Name: "lock", Value: yyDollar[3].str}
+		}
+	case 150:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1007
+		{
+			yyVAL.tableOption = &TableOption{Name: "union", Value: "(" + yyDollar[4].str + ")"}
+		}
+	case 151:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1011
+		{
+			yyVAL.tableOption = &TableOption{Name: "charset", Value: yyDollar[3].str}
+		}
+	case 152:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1015
+		{
+			yyVAL.tableOption = &TableOption{Name: "character set", Value: yyDollar[4].str}
+		}
+	case 153:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1019
+		{
+			yyVAL.tableOption = &TableOption{Name: "collate", Value: yyDollar[3].str}
+		}
+	case 154:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1023
+		{
+			yyVAL.tableOption = &TableOption{Name: "default charset", Value: yyDollar[4].str}
+		}
+	case 155:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1027
+		{
+			yyVAL.tableOption = &TableOption{Name: "default character set", Value: yyDollar[5].str}
+		}
+	case 156:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1031
+		{
+			yyVAL.tableOption = &TableOption{Name: "default collate", Value: yyDollar[4].str}
+		}
+	case 157:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1039
+		{
+			yyVAL.str = string(yyDollar[1].bytes)
+		}
+	case 158:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1043
+		{
+			yyVAL.str = String(NewStrVal(yyDollar[1].bytes))
+		}
+	case 159:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1047
+		{
+			yyVAL.str = string(yyDollar[1].bytes)
+		}
+	case 160:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1051
+		{
+			yyVAL.str = string(yyDollar[1].bytes)
+		}
+	case 161:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1055
+		{
+			yyVAL.str = "default"
+		}
+	case 162:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1059
+		{
+			yyVAL.str = "binary"
+		}
+	case 163:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1063
+		{
+			yyVAL.str = "first"
+		}
+	case 164:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1067
+		{
+			yyVAL.str = "no"
+		}
+	case 165:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1073
+		{
+			yyVAL.str = String(yyDollar[1].tableName)
+		}
+	case 166:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1077
+		{
+			yyVAL.str = yyDollar[1].str + ", " + String(yyDollar[3].tableName)
+		}
+	case 167:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1082
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 168:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1084
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 169:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1088
+		{
+			yyVAL.str = ""
+		}
+	case 170:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1092
+		{
+			yyVAL.str = skipToEnd(yylex, 1)
+		}
+	case 172:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1101
+		{
+			yyVAL.alterSpecs = []*AlterSpec{yyDollar[1].alterSpec}
+		}
+	case 173:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1105
+		{
+			yyVAL.alterSpecs = append(yyDollar[1].alterSpecs, yyDollar[3].alterSpec)
+		}
+	case 174:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1111
+		{
+			yyVAL.alterSpecs = []*AlterSpec{yyDollar[1].alterSpec}
+		}
+	case 175:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1115
+		{
+			yyVAL.alterSpecs = append(yyDollar[1].alterSpecs, yyDollar[3].alterSpec)
+		}
+	case 176:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1121
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: OrderByStr, OrderBy: yyDollar[3].columns}
+		}
+	case 177:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1127
+		{
+			yyVAL.alterSpec = yyDollar[4].alterSpec
+			yyVAL.alterSpec.Action = AddColumnStr
+			yyVAL.alterSpec.Columns = []*ColumnDefinition{yyDollar[3].columnDefinition}
+		}
+	case 178:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1133
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AddColumnStr, Columns: yyDollar[4].columnDefinitions}
+		}
+	case 179:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1137
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AddIndexStr, Index: yyDollar[2].indexDefinition}
+		}
+	case 180:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1141
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AddConstraintStr, Constraint: yyDollar[2].constraintDefinition}
+		}
+	case 181:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1145
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AlterColumnStr, Name: yyDollar[3].colIdent, Default: yyDollar[6].expr}
+		}
+	case 182:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1149
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AlterColumnStr, Name: yyDollar[3].colIdent}
+		}
+	case 183:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1153
+		{
+			yyVAL.alterSpec = yyDollar[5].alterSpec
+			yyVAL.alterSpec.Action = ChangeColumnStr
+			yyVAL.alterSpec.Name = yyDollar[3].colIdent
+			yyVAL.alterSpec.Columns = []*ColumnDefinition{yyDollar[4].columnDefinition}
+		}
+	case 184:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1160
+		{
+			yyVAL.alterSpec = yyDollar[4].alterSpec
+			yyVAL.alterSpec.Action = ModifyColumnStr
+			yyVAL.alterSpec.Columns = []*ColumnDefinition{yyDollar[3].columnDefinition}
+		}
+	case 185:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1166
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: DropColumnStr, Name: yyDollar[3].colIdent}
+		}
+	case 186:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1170
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: DropIndexStr, Name: yyDollar[3].colIdent}
+		}
+	case 187:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1174
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: DropPrimaryKeyStr}
+		}
+	case 188:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1178
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: DropForeignKeyStr, Name: yyDollar[4].colIdent}
+		}
+	case 189:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1182
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: RenameIndexStr, Name: yyDollar[3].colIdent, NewName: yyDollar[5].colIdent}
+		}
+	case 190:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1186
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: RenameColumnStr, Name: yyDollar[3].colIdent, NewName: yyDollar[5].colIdent}
+		}
+	case 191:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1190
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: RenameTableStr, NewTable: yyDollar[3].tableIdent}
+		}
+	case 192:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1194
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: ConvertStr, Options: TableOptions{{Name: "character set", Value: yyDollar[5].str}}}
+			if yyDollar[6].str != "" {
+				yyVAL.alterSpec.Options = append(yyVAL.alterSpec.Options, &TableOption{Name: "collate", Value: yyDollar[6].str})
+			}
+		}
+	case 193:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1201
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: ConvertStr, Options: TableOptions{{Name: "character set", Value: yyDollar[4].str}}}
+			if yyDollar[5].str != "" {
+				yyVAL.alterSpec.Options = append(yyVAL.alterSpec.Options, &TableOption{Name: "collate", Value: yyDollar[5].str})
+			}
+		}
+	case 194:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1208
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: EnableKeysStr}
+		}
+	case 195:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1212
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: DisableKeysStr}
+		}
+	case 196:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1216
+		{
+			if strings.ToLower(string(yyDollar[2].bytes)) != "tablespace" {
+				yylex.Error("syntax error")
+				return 1
+			}
+			yyVAL.alterSpec = &AlterSpec{Action: DiscardTablespaceStr}
+		}
+	case 197:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1224
+		{
+			if strings.ToLower(string(yyDollar[2].bytes)) != "tablespace" {
+				yylex.Error("syntax error")
+				return 1
+			}
+			yyVAL.alterSpec = &AlterSpec{Action: ImportTablespaceStr}
+		}
+	case 198:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1232
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: ForceRebuildStr}
+		}
+	case 199:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1236
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: TableOptionsStr, Options: yyDollar[1].tableOptions}
+		}
+	case 200:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1240
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: PartitionStr, Partitions: skipToEnd(yylex, 1)}
+		}
+	case 201:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1244
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: PartitionStr, Partitions: skipToEnd(yylex, 2)}
+		}
+	case 202:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1248
+		{
+			if !partitionOperations[strings.ToLower(string(yyDollar[1].bytes))] {
+				yylex.Error("syntax error")
+				return 1
+			}
+			yyVAL.alterSpec = &AlterSpec{Action: PartitionStr, Partitions: skipToEnd(yylex, 2)}
+		}
+	case 203:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1256
+		{
+			switch strings.ToLower(string(yyDollar[1].bytes)) {
+			case "remove", "upgrade":
+			default:
+				yylex.Error("syntax error")
+				return 1
+			}
+			yyVAL.alterSpec = &AlterSpec{Action: PartitionStr, Partitions: skipToEnd(yylex, 2)}
+		}
+	case 204:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1269
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 205:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1271
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 206:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1273
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 207:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1275
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 208:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1277
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 209:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1279
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 210:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1281
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 211:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1284
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 212:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1286
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 213:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1289
+		{
+			yyVAL.alterSpec = &AlterSpec{}
+		}
+	case 214:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1293
+		{
+			yyVAL.alterSpec = &AlterSpec{First: true}
+		}
+	case 215:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1297
+		{
+			yyVAL.alterSpec = &AlterSpec{After: yyDollar[2].colIdent}
+		}
+	case 216:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1302
+		{
+			yyVAL.str = ""
+		}
+	case 217:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1306
+		{
+			yyVAL.str = yyDollar[2].str
+		}
+	case 218:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1311
+		{
+			setAllowComments(yylex, true)
+		}
+	case 219:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1315
+		{
+			yyVAL.bytes2 = yyDollar[2].bytes2
+			setAllowComments(yylex, false)
+		}
+	case 220:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1321
+		{
+			yyVAL.bytes2 = nil
+		}
+	case 221:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1325
+		{
+			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
+		}
+	case 222:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1331
+		{
+			yyVAL.str = UnionStr
+		}
+	case 223:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1335
+		{
+			yyVAL.str = UnionAllStr
+		}
+	case 224:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1339
+		{
+			yyVAL.str = UnionDistinctStr
+		}
+	case 225:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1344
+		{
+			yyVAL.str = ""
+		}
+	case 226:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1348
+		{
+			yyVAL.str = SQLNoCacheStr
+		}
+	case 227:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1352
+		{
+			yyVAL.str = SQLCacheStr
+		}
+	case 228:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1357
+		{
+			yyVAL.str = ""
+		}
+	case 229:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1361
+		{
+			yyVAL.str = DistinctStr
+		}
+	case 230:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1366
+		{
+			yyVAL.str = ""
+		}
+	case 231:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1370
+		{
+			yyVAL.str = StraightJoinHint
+		}
+	case 232:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1376
+		{
+			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
+		}
+	case 233:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1380
+		{
+			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
+		}
+	case 234:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1386
+		{
+			yyVAL.selectExpr = &StarExpr{}
+		}
+	case 235:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1390
+		{
+			yyVAL.selectExpr = &NonStarExpr{Expr: yyDollar[1].expr, As: yyDollar[2].colIdent}
+		}
+	case 236:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1394
+		{
+			yyVAL.selectExpr = &StarExpr{TableName: &TableName{Name: yyDollar[1].tableIdent}}
+		}
+	case 237:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1398
+		{
+			yyVAL.selectExpr = &StarExpr{TableName: &TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent}}
+		}
+	case 238:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1403
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 239:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1407
+		{
+			yyVAL.colIdent = yyDollar[1].colIdent
+		}
+	case 240:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1411
+		{
+			yyVAL.colIdent = yyDollar[2].colIdent
+		}
+	case 242:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1418
+		{
+			yyVAL.colIdent = NewColIdent(string(yyDollar[1].bytes))
+		}
+	case 243:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1423
+		{
+			yyVAL.tableExprs = TableExprs{&AliasedTableExpr{Expr: &TableName{Name: NewTableIdent("dual")}}}
+		}
+	case 244:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1427
+		{
+			yyVAL.tableExprs = yyDollar[2].tableExprs
+		}
+	case 245:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1433
+		{
+			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
+		}
+	case 246:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1437
+		{
+			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
+		}
+	case 249:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1447
+		{
+			yyVAL.tableExpr = yyDollar[1].aliasedTableName
+		}
+	case 250:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1451
+		{
+			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].subquery, As: yyDollar[3].tableIdent}
+		}
+	case 251:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1455
+		{
+			yyVAL.tableExpr = &ParenTableExpr{Exprs: yyDollar[2].tableExprs}
+		}
+	case 252:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1461
+		{
+			yyVAL.aliasedTableName = &AliasedTableExpr{Expr: yyDollar[1].tableName, As: yyDollar[2].tableIdent, Hints: yyDollar[3].indexHints}
+		}
+	case 253:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1474
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
+		}
+	case 254:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1478
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].expr}
+		}
+	case 255:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1482
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].expr}
+		}
+	case 256:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1486
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
+		}
+	case 257:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1491
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 258:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1493
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 259:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1496
+		{
+			yyVAL.tableIdent = NewTableIdent("")
+		}
+	case 260:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1500
+		{
+			yyVAL.tableIdent = yyDollar[1].tableIdent
+		}
+	case 261:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1504
+		{
+			yyVAL.tableIdent = yyDollar[2].tableIdent
+		}
+	case 263:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1511
+		{
+			yyVAL.tableIdent = NewTableIdent(string(yyDollar[1].bytes))
+		}
+	case 264:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1517
+		{
+			yyVAL.str = JoinStr
+		}
+	case 265:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1521
+		{
+			yyVAL.str = JoinStr
+		}
+	case 266:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1525
+		{
+			yyVAL.str = JoinStr
+		}
+	case 267:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1529
+		{
+			yyVAL.str = StraightJoinStr
+		}
+	case 268:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1535
+		{
+			yyVAL.str = LeftJoinStr
+		}
+	case 269:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1539
+		{
+			yyVAL.str = LeftJoinStr
+		}
+	case 270:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1543
+		{
+			yyVAL.str = RightJoinStr
+		}
+	case 271:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1547
+		{
+			yyVAL.str = RightJoinStr
+		}
+	case 272:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1553
+		{
+			yyVAL.str = NaturalJoinStr
+		}
+	case 273:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1557
+		{
+			if yyDollar[2].str == LeftJoinStr {
+				yyVAL.str = NaturalLeftJoinStr
+			} else {
+				yyVAL.str = NaturalRightJoinStr
+			}
+		}
+	case 274:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1567
+		{
+			yyVAL.tableName = yyDollar[2].tableName
+		}
+	case 275:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1571
+		{
+			yyVAL.tableName = yyDollar[1].tableName
+		}
+	case 276:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1577
+		{
+			yyVAL.tableName = &TableName{Name: yyDollar[1].tableIdent}
+		}
+	case 277:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1581
+		{
+			yyVAL.tableName = &TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent}
+		}
+	case 278:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1586
+		{
+			yyVAL.indexHints = nil
+		}
+	case 279:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1590
+		{
+			yyVAL.indexHints = &IndexHints{Type: UseStr, Indexes: yyDollar[4].colIdents}
+		}
+	case 280:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1594
+		{
+			yyVAL.indexHints = &IndexHints{Type: IgnoreStr, Indexes: yyDollar[4].colIdents}
+		}
+	case 281:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1598
+		{
+			yyVAL.indexHints = &IndexHints{Type: ForceStr, Indexes: yyDollar[4].colIdents}
+		}
+	case 282:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1604
+		{
+			yyVAL.colIdents = []ColIdent{yyDollar[1].colIdent}
+		}
+	case 283:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1608
+		{
+			yyVAL.colIdents = append(yyDollar[1].colIdents, yyDollar[3].colIdent)
+		}
+	case 284:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1613
+		{
+			yyVAL.expr = nil
+		}
+	case 285:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1617
+		{
+			yyVAL.expr = yyDollar[2].expr
+		}
+	case 286:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1623
+		{
+			yyVAL.expr = yyDollar[1].expr
+		}
+	case 287:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1627
+		{
+			yyVAL.expr = &AndExpr{Left: yyDollar[1].expr, Right: yyDollar[3].expr}
+		}
+	case 288:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:1631
 		{
 			yyVAL.expr = &OrExpr{Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
-	case 108:
+	case 289:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:678
+		//line ./go/vt/sqlparser/sql.y:1635
 		{
 			yyVAL.expr = &NotExpr{Expr: yyDollar[2].expr}
 		}
-	case 109:
+	case 290:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:682
+		//line ./go/vt/sqlparser/sql.y:1639
 		{
 			yyVAL.expr = &IsExpr{Operator: yyDollar[3].str, Expr: yyDollar[1].expr}
 		}
-	case 110:
+	case 291:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:686
+		//line ./go/vt/sqlparser/sql.y:1643
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 111:
+	case 292:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:692
+		//line ./go/vt/sqlparser/sql.y:1649
 		{
 			yyVAL.boolVal = BoolVal(true)
 		}
-	case 112:
+	case 293:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:696
+		//line ./go/vt/sqlparser/sql.y:1653
 		{
 			yyVAL.boolVal = BoolVal(false)
 		}
-	case 113:
+	case 294:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:702
+		//line ./go/vt/sqlparser/sql.y:1659
 		{
 			yyVAL.expr = yyDollar[1].boolVal
 		}
-	case 114:
+	case 295:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:706
+		//line ./go/vt/sqlparser/sql.y:1663
 		{
 			yyVAL.expr = &ComparisonExpr{Left: yyDollar[1].expr, Operator: yyDollar[2].str, Right: yyDollar[3].boolVal}
 		}
-	case 115:
+	case 296:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:710
+		//line ./go/vt/sqlparser/sql.y:1667
 		{
 			yyVAL.expr = &ComparisonExpr{Left: yyDollar[1].expr, Operator: yyDollar[2].str, Right: yyDollar[3].expr}
 		}
-	case 116:
+	case 297:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:714
+		//line ./go/vt/sqlparser/sql.y:1671
 		{
 			yyVAL.expr = &ComparisonExpr{Left: yyDollar[1].expr, Operator: InStr, Right: yyDollar[3].colTuple}
 		}
-	case 117:
+	case 298:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:718
+		//line ./go/vt/sqlparser/sql.y:1675
 		{
 			yyVAL.expr = &ComparisonExpr{Left: yyDollar[1].expr, Operator: NotInStr, Right: yyDollar[4].colTuple}
 		}
-	case 118:
+	case 299:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:722
+		//line ./go/vt/sqlparser/sql.y:1679
 		{
 			yyVAL.expr = &ComparisonExpr{Left: yyDollar[1].expr, Operator: LikeStr, Right: yyDollar[3].expr}
 		}
-	case 119:
+	case 300:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:726
+		//line ./go/vt/sqlparser/sql.y:1683
 		{
 			yyVAL.expr = &ComparisonExpr{Left: yyDollar[1].expr, Operator: NotLikeStr, Right: yyDollar[4].expr}
 		}
-	case 120:
+	case 301:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:730
+		//line ./go/vt/sqlparser/sql.y:1687
 		{
 			yyVAL.expr = &ComparisonExpr{Left: yyDollar[1].expr, Operator: RegexpStr, Right: yyDollar[3].expr}
 		}
-	case 121:
+	case 302:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:734
+		//line ./go/vt/sqlparser/sql.y:1691
 		{
 			yyVAL.expr = &ComparisonExpr{Left: yyDollar[1].expr, Operator: NotRegexpStr, Right: yyDollar[4].expr}
 		}
-	case 122:
+	case 303:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:738
+		//line ./go/vt/sqlparser/sql.y:1695
 		{
 			yyVAL.expr = &RangeCond{Left: yyDollar[1].expr, Operator: BetweenStr, From: yyDollar[3].expr, To: yyDollar[5].expr}
 		}
-	case 123:
+	case 304:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:742
+		//line ./go/vt/sqlparser/sql.y:1699
 		{
 			yyVAL.expr = &RangeCond{Left: yyDollar[1].expr, Operator: NotBetweenStr, From: yyDollar[4].expr, To: yyDollar[6].expr}
 		}
-	case 124:
+	case 305:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:746
+		//line ./go/vt/sqlparser/sql.y:1703
 		{
 			yyVAL.expr = &ExistsExpr{Subquery: yyDollar[2].subquery}
 		}
-	case 125:
+	case 306:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:752
+		//line ./go/vt/sqlparser/sql.y:1709
 		{
 			yyVAL.str = IsNullStr
 		}
-	case 126:
+	case 307:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:756
+		//line ./go/vt/sqlparser/sql.y:1713
 		{
 			yyVAL.str = IsNotNullStr
 		}
-	case 127:
+	case 308:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:760
+		//line ./go/vt/sqlparser/sql.y:1717
 		{
 			yyVAL.str = IsTrueStr
 		}
-	case 128:
+	case 309:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:764
+		//line ./go/vt/sqlparser/sql.y:1721
 		{
 			yyVAL.str = IsNotTrueStr
 		}
-	case 129:
+	case 310:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:768
+		//line ./go/vt/sqlparser/sql.y:1725
 		{
 			yyVAL.str = IsFalseStr
 		}
-	case 130:
+	case 311:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:772
+		//line ./go/vt/sqlparser/sql.y:1729
 		{
 			yyVAL.str = IsNotFalseStr
 		}
-	case 131:
+	case 312:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:778
+		//line ./go/vt/sqlparser/sql.y:1735
 		{
 			yyVAL.str = EqualStr
 		}
-	case 132:
+	case 313:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:782
+		//line ./go/vt/sqlparser/sql.y:1739
 		{
 			yyVAL.str = LessThanStr
 		}
-	case 133:
+	case 314:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:786
+		//line ./go/vt/sqlparser/sql.y:1743
 		{
 			yyVAL.str = GreaterThanStr
 		}
-	case 134:
+	case 315:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:790
+		//line ./go/vt/sqlparser/sql.y:1747
 		{
 			yyVAL.str = LessEqualStr
 		}
-	case 135:
+	case 316:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:794
+		//line ./go/vt/sqlparser/sql.y:1751
 		{
 			yyVAL.str = GreaterEqualStr
 		}
-	case 136:
+	case 317:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:798
+		//line ./go/vt/sqlparser/sql.y:1755
 		{
 			yyVAL.str = NotEqualStr
 		}
-	case 137:
+	case 318:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:802
+		//line ./go/vt/sqlparser/sql.y:1759
 		{
 			yyVAL.str = NullSafeEqualStr
 		}
-	case 138:
+	case 319:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:808
+		//line ./go/vt/sqlparser/sql.y:1765
 		{
 			yyVAL.colTuple = yyDollar[1].valTuple
 		}
-	case 139:
+	case 320:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:812
+		//line ./go/vt/sqlparser/sql.y:1769
 		{
 			yyVAL.colTuple = yyDollar[1].subquery
 		}
-	case 140:
+	case 321:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:816
+		//line ./go/vt/sqlparser/sql.y:1773
 		{
 			yyVAL.colTuple = ListArg(yyDollar[1].bytes)
 		}
-	case 141:
+	case 322:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:822
+		//line ./go/vt/sqlparser/sql.y:1779
 		{
 			yyVAL.subquery = &Subquery{yyDollar[2].selStmt}
 		}
-	case 142:
+	case 323:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:828
+		//line ./go/vt/sqlparser/sql.y:1785
 		{
 			yyVAL.exprs = Exprs{yyDollar[1].expr}
 		}
-	case 143:
+	case 324:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:832
+		//line ./go/vt/sqlparser/sql.y:1789
 		{
 			yyVAL.exprs = append(yyDollar[1].exprs, yyDollar[3].expr)
 		}
-	case 144:
+	case 325:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:838
+		//line ./go/vt/sqlparser/sql.y:1795
 		{
 			yyVAL.str = string(yyDollar[1].bytes)
 		}
-	case 145:
+	case 326:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:842
+		//line ./go/vt/sqlparser/sql.y:1799
 		{
 			yyVAL.str = string(yyDollar[1].bytes)
 		}
-	case 146:
+	case 327:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:846
+		//line ./go/vt/sqlparser/sql.y:1803
 		{
 			yyVAL.str = string("binary")
 		}
-	case 147:
+	case 328:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:852
+		//line ./go/vt/sqlparser/sql.y:1809
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 148:
+	case 329:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:856
+		//line ./go/vt/sqlparser/sql.y:1813
 		{
 			yyVAL.expr = yyDollar[1].colName
 		}
-	case 149:
+	case 330:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:860
+		//line ./go/vt/sqlparser/sql.y:1817
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 150:
+	case 331:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:864
+		//line ./go/vt/sqlparser/sql.y:1821
 		{
 			yyVAL.expr = yyDollar[1].subquery
 		}
-	case 151:
+	case 332:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:868
+		//line ./go/vt/sqlparser/sql.y:1825
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: BitAndStr, Right: yyDollar[3].expr}
 		}
-	case 152:
+	case 333:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:872
+		//line ./go/vt/sqlparser/sql.y:1829
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: BitOrStr, Right: yyDollar[3].expr}
 		}
-	case 153:
+	case 334:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:876
+		//line ./go/vt/sqlparser/sql.y:1833
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: BitXorStr, Right: yyDollar[3].expr}
 		}
-	case 154:
+	case 335:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:880
+		//line ./go/vt/sqlparser/sql.y:1837
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: PlusStr, Right: yyDollar[3].expr}
 		}
-	case 155:
+	case 336:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:884
+		//line ./go/vt/sqlparser/sql.y:1841
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: MinusStr, Right: yyDollar[3].expr}
 		}
-	case 156:
+	case 337:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:888
+		//line ./go/vt/sqlparser/sql.y:1845
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: MultStr, Right: yyDollar[3].expr}
 		}
-	case 157:
+	case 338:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:892
+		//line ./go/vt/sqlparser/sql.y:1849
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: DivStr, Right: yyDollar[3].expr}
 		}
-	case 158:
+	case 339:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:896
+		//line ./go/vt/sqlparser/sql.y:1853
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: IntDivStr, Right: yyDollar[3].expr}
 		}
-	case 159:
+	case 340:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:900
+		//line ./go/vt/sqlparser/sql.y:1857
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: ModStr, Right: yyDollar[3].expr}
 		}
-	case 160:
+	case 341:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:904
+		//line ./go/vt/sqlparser/sql.y:1861
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: ModStr, Right: yyDollar[3].expr}
 		}
-	case 161:
+	case 342:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:908
+		//line ./go/vt/sqlparser/sql.y:1865
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: ShiftLeftStr, Right: yyDollar[3].expr}
 		}
-	case 162:
+	case 343:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:912
+		//line ./go/vt/sqlparser/sql.y:1869
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].expr, Operator: ShiftRightStr, Right: yyDollar[3].expr}
 		}
-	case 163:
+	case 344:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:916
+		//line ./go/vt/sqlparser/sql.y:1873
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].colName, Operator: JSONExtractOp, Right: yyDollar[3].expr}
 		}
-	case 164:
+	case 345:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:920
+		//line ./go/vt/sqlparser/sql.y:1877
 		{
 			yyVAL.expr = &BinaryExpr{Left: yyDollar[1].colName, Operator: JSONUnquoteExtractOp, Right: yyDollar[3].expr}
 		}
-	case 165:
+	case 346:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:924
+		//line ./go/vt/sqlparser/sql.y:1881
 		{
 			yyVAL.expr = &CollateExpr{Expr: yyDollar[1].expr, Charset: yyDollar[3].str}
 		}
-	case 166:
+	case 347:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:928
+		//line ./go/vt/sqlparser/sql.y:1885
 		{
 			yyVAL.expr = &UnaryExpr{Operator: BinaryStr, Expr: yyDollar[2].expr}
 		}
-	case 167:
+	case 348:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:932
+		//line ./go/vt/sqlparser/sql.y:1889
 		{
 			if num, ok := yyDollar[2].expr.(*SQLVal); ok && num.Type == IntVal {
 				yyVAL.expr = num
@@ -2084,9 +3619,9 @@ yydefault:
 				yyVAL.expr = &UnaryExpr{Operator: UPlusStr, Expr: yyDollar[2].expr}
 			}
 		}
-	case 168:
+	case 349:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:940
+		//line ./go/vt/sqlparser/sql.y:1897
 		{
 			if num, ok := yyDollar[2].expr.(*SQLVal); ok && num.Type == IntVal {
 				// Handle double negative
@@ -2100,21 +3635,21 @@ yydefault:
 				yyVAL.expr = &UnaryExpr{Operator: UMinusStr, Expr: yyDollar[2].expr}
 			}
 		}
-	case 169:
+	case 350:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:954
+		//line ./go/vt/sqlparser/sql.y:1911
 		{
 			yyVAL.expr = &UnaryExpr{Operator: TildaStr, Expr: yyDollar[2].expr}
 		}
-	case 170:
+	case 351:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:958
+		//line ./go/vt/sqlparser/sql.y:1915
 		{
 			yyVAL.expr = &UnaryExpr{Operator: BangStr, Expr: yyDollar[2].expr}
 		}
-	case 171:
+	case 352:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:962
+		//line ./go/vt/sqlparser/sql.y:1919
 		{
 			// This rule prevents the usage of INTERVAL
 			// as a function. If support is needed for that,
@@ -2122,369 +3657,369 @@ yydefault:
 			// will be non-trivial because of grammar conflicts.
 			yyVAL.expr = &IntervalExpr{Expr: yyDollar[2].expr, Unit: yyDollar[3].colIdent}
 		}
-	case 172:
+	case 353:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:970
+		//line ./go/vt/sqlparser/sql.y:1927
 		{
 			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent}
 		}
-	case 173:
+	case 354:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:974
+		//line ./go/vt/sqlparser/sql.y:1931
 		{
 			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent, Exprs: yyDollar[3].selectExprs}
 		}
-	case 174:
+	case 355:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:978
+		//line ./go/vt/sqlparser/sql.y:1935
 		{
 			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent, Distinct: true, Exprs: yyDollar[4].selectExprs}
 		}
-	case 175:
+	case 356:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:982
+		//line ./go/vt/sqlparser/sql.y:1939
 		{
 			yyVAL.expr = &GroupConcatExpr{Distinct: yyDollar[3].str, Exprs: yyDollar[4].selectExprs, OrderBy: yyDollar[5].orderBy, Separator: yyDollar[6].str}
 		}
-	case 176:
+	case 357:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:986
+		//line ./go/vt/sqlparser/sql.y:1943
 		{
 			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent}
 		}
-	case 177:
+	case 358:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:990
+		//line ./go/vt/sqlparser/sql.y:1947
 		{
 			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent, Exprs: yyDollar[3].selectExprs}
 		}
-	case 178:
+	case 359:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:994
+		//line ./go/vt/sqlparser/sql.y:1951
 		{
 			yyVAL.expr = &FuncExpr{Name: yyDollar[1].colIdent}
 		}
-	case 179:
+	case 360:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:998
+		//line ./go/vt/sqlparser/sql.y:1955
 		{
 			yyVAL.expr = yyDollar[1].caseExpr
 		}
-	case 180:
+	case 361:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1002
+		//line ./go/vt/sqlparser/sql.y:1959
 		{
 			yyVAL.expr = &ValuesFuncExpr{Name: yyDollar[3].colIdent}
 		}
-	case 181:
+	case 362:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1006
+		//line ./go/vt/sqlparser/sql.y:1963
 		{
 			yyVAL.expr = &ConvertExpr{Expr: yyDollar[3].expr, Type: yyDollar[5].convertType}
 		}
-	case 182:
+	case 363:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1010
+		//line ./go/vt/sqlparser/sql.y:1967
 		{
 			yyVAL.expr = &ConvertExpr{Expr: yyDollar[3].expr, Type: yyDollar[5].convertType}
 		}
-	case 183:
+	case 364:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1014
+		//line ./go/vt/sqlparser/sql.y:1971
 		{
 			yyVAL.expr = &ConvertExpr{Expr: yyDollar[3].expr, Type: yyDollar[5].convertType}
 		}
-	case 184:
+	case 365:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1018
+		//line ./go/vt/sqlparser/sql.y:1975
 		{
 			yyVAL.expr = &MatchExpr{Columns: yyDollar[3].columns, Expr: yyDollar[7].expr, Option: yyDollar[8].str}
 		}
-	case 185:
+	case 366:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1024
+		//line ./go/vt/sqlparser/sql.y:1981
 		{
 			yyVAL.str = ""
 		}
-	case 186:
+	case 367:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1028
+		//line ./go/vt/sqlparser/sql.y:1985
 		{
 			yyVAL.str = BooleanModeStr
 		}
-	case 187:
+	case 368:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1032
+		//line ./go/vt/sqlparser/sql.y:1989
 		{
 			yyVAL.str = NaturalLanguageModeStr
 		}
-	case 188:
+	case 369:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1036
+		//line ./go/vt/sqlparser/sql.y:1993
 		{
 			yyVAL.str = NaturalLanguageModeWithQueryExpansionStr
 		}
-	case 189:
+	case 370:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1040
+		//line ./go/vt/sqlparser/sql.y:1997
 		{
 			yyVAL.str = QueryExpansionStr
 		}
-	case 190:
+	case 371:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1047
+		//line ./go/vt/sqlparser/sql.y:2004
 		{
 			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str}
 		}
-	case 191:
+	case 372:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1051
+		//line ./go/vt/sqlparser/sql.y:2008
 		{
 			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str}
 		}
-	case 192:
+	case 373:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1055
+		//line ./go/vt/sqlparser/sql.y:2012
 		{
 			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str, Length: NewIntVal(yyDollar[3].bytes)}
 		}
-	case 193:
+	case 374:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1059
+		//line ./go/vt/sqlparser/sql.y:2016
 		{
 			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str, Length: NewIntVal(yyDollar[3].bytes), Charset: yyDollar[5].str}
 		}
-	case 194:
+	case 375:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1063
+		//line ./go/vt/sqlparser/sql.y:2020
 		{
 			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str, Length: NewIntVal(yyDollar[3].bytes), Charset: yyDollar[7].str, Operator: CharacterSetStr}
 		}
-	case 195:
+	case 376:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1067
+		//line ./go/vt/sqlparser/sql.y:2024
 		{
 			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str, Charset: yyDollar[2].str}
 		}
-	case 196:
+	case 377:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1071
+		//line ./go/vt/sqlparser/sql.y:2028
 		{
 			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str, Charset: yyDollar[4].str, Operator: CharacterSetStr}
 		}
-	case 197:
+	case 378:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1075
+		//line ./go/vt/sqlparser/sql.y:2032
 		{
 			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str, Length: NewIntVal(yyDollar[3].bytes), Scale: NewIntVal(yyDollar[5].bytes)}
 		}
-	case 198:
+	case 379:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1083
+		//line ./go/vt/sqlparser/sql.y:2040
 		{
 			yyVAL.colIdent = NewColIdent("current_timestamp")
 		}
-	case 199:
+	case 380:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1087
+		//line ./go/vt/sqlparser/sql.y:2044
 		{
 			yyVAL.colIdent = NewColIdent("current_date")
 		}
-	case 200:
+	case 381:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1091
+		//line ./go/vt/sqlparser/sql.y:2048
 		{
 			yyVAL.colIdent = NewColIdent("current_time")
 		}
-	case 201:
+	case 382:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1095
+		//line ./go/vt/sqlparser/sql.y:2052
 		{
 			yyVAL.colIdent = NewColIdent("utc_timestamp")
 		}
-	case 202:
+	case 383:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1099
+		//line ./go/vt/sqlparser/sql.y:2056
 		{
 			yyVAL.colIdent = NewColIdent("utc_time")
 		}
-	case 203:
+	case 384:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1103
+		//line ./go/vt/sqlparser/sql.y:2060
 		{
 			yyVAL.colIdent = NewColIdent("utc_date")
 		}
-	case 204:
+	case 385:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1107
+		//line ./go/vt/sqlparser/sql.y:2064
 		{
 			yyVAL.colIdent = NewColIdent("localtime")
 		}
-	case 205:
+	case 386:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1111
+		//line ./go/vt/sqlparser/sql.y:2068
 		{
 			yyVAL.colIdent = NewColIdent("localtimestamp")
 		}
-	case 207:
+	case 388:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1120
+		//line ./go/vt/sqlparser/sql.y:2077
 		{
 			yyVAL.colIdent = NewColIdent("if")
 		}
-	case 208:
+	case 389:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1124
+		//line ./go/vt/sqlparser/sql.y:2081
 		{
 			yyVAL.colIdent = NewColIdent("database")
 		}
-	case 209:
+	case 390:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1128
+		//line ./go/vt/sqlparser/sql.y:2085
 		{
 			yyVAL.colIdent = NewColIdent("unix_timestamp")
 		}
-	case 210:
+	case 391:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1132
+		//line ./go/vt/sqlparser/sql.y:2089
 		{
 			yyVAL.colIdent = NewColIdent("mod")
 		}
-	case 211:
+	case 392:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1136
+		//line ./go/vt/sqlparser/sql.y:2093
 		{
 			yyVAL.colIdent = NewColIdent("replace")
 		}
-	case 212:
+	case 393:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1140
+		//line ./go/vt/sqlparser/sql.y:2097
 		{
 			yyVAL.colIdent = NewColIdent("left")
 		}
-	case 213:
+	case 394:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1146
+		//line ./go/vt/sqlparser/sql.y:2103
 		{
 			yyVAL.caseExpr = &CaseExpr{Expr: yyDollar[2].expr, Whens: yyDollar[3].whens, Else: yyDollar[4].expr}
 		}
-	case 214:
+	case 395:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1151
+		//line ./go/vt/sqlparser/sql.y:2108
 		{
 			yyVAL.expr = nil
 		}
-	case 215:
+	case 396:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1155
+		//line ./go/vt/sqlparser/sql.y:2112
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 216:
+	case 397:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1160
+		//line ./go/vt/sqlparser/sql.y:2117
 		{
 			yyVAL.str = string("")
 		}
-	case 217:
+	case 398:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1164
+		//line ./go/vt/sqlparser/sql.y:2121
 		{
 			yyVAL.str = " separator '" + string(yyDollar[2].bytes) + "'"
 		}
-	case 218:
+	case 399:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1170
+		//line ./go/vt/sqlparser/sql.y:2127
 		{
 			yyVAL.whens = []*When{yyDollar[1].when}
 		}
-	case 219:
+	case 400:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1174
+		//line ./go/vt/sqlparser/sql.y:2131
 		{
 			yyVAL.whens = append(yyDollar[1].whens, yyDollar[2].when)
 		}
-	case 220:
+	case 401:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1180
+		//line ./go/vt/sqlparser/sql.y:2137
 		{
 			yyVAL.when = &When{Cond: yyDollar[2].expr, Val: yyDollar[4].expr}
 		}
-	case 221:
+	case 402:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1185
+		//line ./go/vt/sqlparser/sql.y:2142
 		{
 			yyVAL.expr = nil
 		}
-	case 222:
+	case 403:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1189
+		//line ./go/vt/sqlparser/sql.y:2146
 		{
 			yyVAL.expr = yyDollar[2].expr
 		}
-	case 223:
+	case 404:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1195
+		//line ./go/vt/sqlparser/sql.y:2152
 		{
 			yyVAL.colName = &ColName{Name: yyDollar[1].colIdent}
 		}
-	case 224:
+	case 405:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1199
+		//line ./go/vt/sqlparser/sql.y:2156
 		{
 			yyVAL.colName = &ColName{Qualifier: &TableName{Name: yyDollar[1].tableIdent}, Name: yyDollar[3].colIdent}
 		}
-	case 225:
+	case 406:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1203
+		//line ./go/vt/sqlparser/sql.y:2160
 		{
 			yyVAL.colName = &ColName{Qualifier: &TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent}, Name: yyDollar[5].colIdent}
 		}
-	case 226:
+	case 407:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1209
+		//line ./go/vt/sqlparser/sql.y:2166
 		{
 			yyVAL.expr = NewStrVal(yyDollar[1].bytes)
 		}
-	case 227:
+	case 408:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1213
+		//line ./go/vt/sqlparser/sql.y:2170
 		{
 			yyVAL.expr = NewHexVal(yyDollar[1].bytes)
 		}
-	case 228:
+	case 409:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1217
+		//line ./go/vt/sqlparser/sql.y:2174
 		{
 			yyVAL.expr = NewIntVal(yyDollar[1].bytes)
 		}
-	case 229:
+	case 410:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1221
+		//line ./go/vt/sqlparser/sql.y:2178
 		{
 			yyVAL.expr = NewFloatVal(yyDollar[1].bytes)
 		}
-	case 230:
+	case 411:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1225
+		//line ./go/vt/sqlparser/sql.y:2182
 		{
 			yyVAL.expr = NewHexNum(yyDollar[1].bytes)
 		}
-	case 231:
+	case 412:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1229
+		//line ./go/vt/sqlparser/sql.y:2186
 		{
 			yyVAL.expr = NewValArg(yyDollar[1].bytes)
 		}
-	case 232:
+	case 413:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1233
+		//line ./go/vt/sqlparser/sql.y:2190
 		{
 			yyVAL.expr = &NullVal{}
 		}
-	case 233:
+	case 414:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1239
+		//line ./go/vt/sqlparser/sql.y:2196
 		{
 			// TODO(sougou): Deprecate this construct.
 			if yyDollar[1].colIdent.Lowered() != "value" {
@@ -2493,225 +4028,225 @@ yydefault:
 			}
 			yyVAL.expr = NewIntVal([]byte("1"))
 		}
-	case 234:
+	case 415:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1248
+		//line ./go/vt/sqlparser/sql.y:2205
 		{
 			yyVAL.expr = NewIntVal(yyDollar[1].bytes)
 		}
-	case 235:
+	case 416:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1252
+		//line ./go/vt/sqlparser/sql.y:2209
 		{
 			yyVAL.expr = NewValArg(yyDollar[1].bytes)
 		}
-	case 236:
+	case 417:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1257
+		//line ./go/vt/sqlparser/sql.y:2214
 		{
 			yyVAL.exprs = nil
 		}
-	case 237:
+	case 418:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1261
+		//line ./go/vt/sqlparser/sql.y:2218
 		{
 			yyVAL.exprs = yyDollar[3].exprs
 		}
-	case 238:
+	case 419:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1266
+		//line ./go/vt/sqlparser/sql.y:2223
 		{
 			yyVAL.expr = nil
 		}
-	case 239:
+	case 420:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1270
+		//line ./go/vt/sqlparser/sql.y:2227
 		{
 			yyVAL.expr = yyDollar[2].expr
 		}
-	case 240:
+	case 421:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1275
+		//line ./go/vt/sqlparser/sql.y:2232
 		{
 			yyVAL.orderBy = nil
 		}
-	case 241:
+	case 422:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1279
+		//line ./go/vt/sqlparser/sql.y:2236
 		{
 			yyVAL.orderBy = yyDollar[3].orderBy
 		}
-	case 242:
+	case 423:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1285
+		//line ./go/vt/sqlparser/sql.y:2242
 		{
 			yyVAL.orderBy = OrderBy{yyDollar[1].order}
 		}
-	case 243:
+	case 424:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1289
+		//line ./go/vt/sqlparser/sql.y:2246
 		{
 			yyVAL.orderBy = append(yyDollar[1].orderBy, yyDollar[3].order)
 		}
-	case 244:
+	case 425:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1295
+		//line ./go/vt/sqlparser/sql.y:2252
 		{
 			yyVAL.order = &Order{Expr: yyDollar[1].expr, Direction: yyDollar[2].str}
 		}
-	case 245:
+	case 426:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1300
+		//line ./go/vt/sqlparser/sql.y:2257
 		{
 			yyVAL.str = AscScr
 		}
-	case 246:
+	case 427:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1304
+		//line ./go/vt/sqlparser/sql.y:2261
 		{
 			yyVAL.str = AscScr
 		}
-	case 247:
+	case 428:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1308
+		//line ./go/vt/sqlparser/sql.y:2265
 		{
 			yyVAL.str = DescScr
 		}
-	case 248:
+	case 429:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1313
+		//line ./go/vt/sqlparser/sql.y:2270
 		{
 			yyVAL.limit = nil
 		}
-	case 249:
+	case 430:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1317
+		//line ./go/vt/sqlparser/sql.y:2274
 		{
 			yyVAL.limit = &Limit{Rowcount: yyDollar[2].expr}
 		}
-	case 250:
+	case 431:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1321
+		//line ./go/vt/sqlparser/sql.y:2278
 		{
 			yyVAL.limit = &Limit{Offset: yyDollar[2].expr, Rowcount: yyDollar[4].expr}
 		}
-	case 251:
+	case 432:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1325
+		//line ./go/vt/sqlparser/sql.y:2282
 		{
 			yyVAL.limit = &Limit{Offset: yyDollar[4].expr, Rowcount: yyDollar[2].expr}
 		}
-	case 252:
+	case 433:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1330
+		//line ./go/vt/sqlparser/sql.y:2287
 		{
 			yyVAL.str = ""
 		}
-	case 253:
+	case 434:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1334
+		//line ./go/vt/sqlparser/sql.y:2291
 		{
 			yyVAL.str = ForUpdateStr
 		}
-	case 254:
+	case 435:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1338
+		//line ./go/vt/sqlparser/sql.y:2295
 		{
 			yyVAL.str = ShareModeStr
 		}
-	case 255:
+	case 436:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1344
+		//line ./go/vt/sqlparser/sql.y:2301
 		{
 			yyVAL.columns = Columns{yyDollar[1].colIdent}
 		}
-	case 256:
+	case 437:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1348
+		//line ./go/vt/sqlparser/sql.y:2305
 		{
 			yyVAL.columns = append(yyVAL.columns, yyDollar[3].colIdent)
 		}
-	case 257:
+	case 438:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1353
+		//line ./go/vt/sqlparser/sql.y:2310
 		{
 			yyVAL.columns = nil
 		}
-	case 258:
+	case 439:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1357
+		//line ./go/vt/sqlparser/sql.y:2314
 		{
 			yyVAL.columns = yyDollar[2].columns
 		}
-	case 259:
+	case 440:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1363
+		//line ./go/vt/sqlparser/sql.y:2320
 		{
 			yyVAL.columns = Columns{yyDollar[1].colIdent}
 		}
-	case 260:
+	case 441:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1367
+		//line ./go/vt/sqlparser/sql.y:2324
 		{
 			yyVAL.columns = Columns{yyDollar[3].colIdent}
 		}
-	case 261:
+	case 442:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1371
+		//line ./go/vt/sqlparser/sql.y:2328
 		{
 			yyVAL.columns = append(yyVAL.columns, yyDollar[3].colIdent)
 		}
-	case 262:
+	case 443:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1375
+		//line ./go/vt/sqlparser/sql.y:2332
 		{
 			yyVAL.columns = append(yyVAL.columns, yyDollar[5].colIdent)
 		}
-	case 263:
+	case 444:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1380
+		//line ./go/vt/sqlparser/sql.y:2337
 		{
 			yyVAL.updateExprs = nil
 		}
-	case 264:
+	case 445:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1384
+		//line ./go/vt/sqlparser/sql.y:2341
 		{
 			yyVAL.updateExprs = yyDollar[5].updateExprs
 		}
-	case 265:
+	case 446:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1390
+		//line ./go/vt/sqlparser/sql.y:2347
 		{
 			yyVAL.insRows = yyDollar[2].values
 		}
-	case 266:
+	case 447:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1394
+		//line ./go/vt/sqlparser/sql.y:2351
 		{
 			yyVAL.insRows = yyDollar[1].selStmt
 		}
-	case 267:
+	case 448:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1400
+		//line ./go/vt/sqlparser/sql.y:2357
 		{
 			yyVAL.values = Values{yyDollar[1].valTuple}
 		}
-	case 268:
+	case 449:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1404
+		//line ./go/vt/sqlparser/sql.y:2361
 		{
 			yyVAL.values = append(yyDollar[1].values, yyDollar[3].valTuple)
 		}
-	case 269:
+	case 450:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1410
+		//line ./go/vt/sqlparser/sql.y:2367
 		{
 			yyVAL.valTuple = ValTuple(yyDollar[2].exprs)
 		}
-	case 270:
+	case 451:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1416
+		//line ./go/vt/sqlparser/sql.y:2373
 		{
 			if len(yyDollar[1].valTuple) == 1 {
 				yyVAL.expr = &ParenExpr{yyDollar[1].valTuple[0]}
@@ -2719,162 +4254,144 @@ yydefault:
 				yyVAL.expr = yyDollar[1].valTuple
 			}
 		}
-	case 271:
+	case 452:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1426
+		//line ./go/vt/sqlparser/sql.y:2383
 		{
 			yyVAL.updateExprs = UpdateExprs{yyDollar[1].updateExpr}
 		}
-	case 272:
+	case 453:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1430
+		//line ./go/vt/sqlparser/sql.y:2387
 		{
 			yyVAL.updateExprs = append(yyDollar[1].updateExprs, yyDollar[3].updateExpr)
 		}
-	case 273:
+	case 454:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1436
+		//line ./go/vt/sqlparser/sql.y:2393
 		{
 			yyVAL.updateExpr = &UpdateExpr{Name: yyDollar[1].colName, Expr: yyDollar[3].expr}
 		}
-	case 276:
+	case 457:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1445
+		//line ./go/vt/sqlparser/sql.y:2402
 		{
 			yyVAL.byt = 0
 		}
-	case 277:
+	case 458:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1447
+		//line ./go/vt/sqlparser/sql.y:2404
 		{
 			yyVAL.byt = 1
 		}
-	case 278:
+	case 459:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1450
+		//line ./go/vt/sqlparser/sql.y:2407
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.byt = 0
 		}
-	case 279:
+	case 460:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1452
+		//line ./go/vt/sqlparser/sql.y:2409
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.byt = 1
 		}
-	case 280:
+	case 461:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1455
+		//line ./go/vt/sqlparser/sql.y:2412
 		{
 			yyVAL.str = ""
 		}
-	case 281:
+	case 462:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1457
+		//line ./go/vt/sqlparser/sql.y:2414
 		{
 			yyVAL.str = IgnoreStr
 		}
-	case 282:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1461
-		{
-			yyVAL.empty = struct{}{}
-		}
-	case 283:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1463
-		{
-			yyVAL.empty = struct{}{}
-		}
-	case 284:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1465
-		{
-			yyVAL.empty = struct{}{}
-		}
-	case 285:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1467
+	case 463:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:2417
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 286:
+	case 464:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1469
+		//line ./go/vt/sqlparser/sql.y:2419
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 287:
+	case 465:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1471
+		//line ./go/vt/sqlparser/sql.y:2421
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 288:
+	case 466:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1474
+		//line ./go/vt/sqlparser/sql.y:2424
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 289:
+	case 467:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1476
+		//line ./go/vt/sqlparser/sql.y:2426
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 290:
+	case 468:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1479
+		//line ./go/vt/sqlparser/sql.y:2429
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 291:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1481
+	case 469:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:2431
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 292:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1484
+	case 470:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:2435
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.colIdent = NewColIdent(string(yyDollar[1].bytes))
 		}
-	case 293:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1486
+	case 471:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line ./go/vt/sqlparser/sql.y:2441
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.tableIdent = NewTableIdent(string(yyDollar[1].bytes))
 		}
-	case 294:
+	case 473:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1490
+		//line ./go/vt/sqlparser/sql.y:2450
 		{
 			yyVAL.colIdent = NewColIdent(string(yyDollar[1].bytes))
 		}
-	case 295:
+	case 475:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1496
+		//line ./go/vt/sqlparser/sql.y:2457
 		{
 			yyVAL.tableIdent = NewTableIdent(string(yyDollar[1].bytes))
 		}
-	case 296:
+	case 498:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1502
+		//line ./go/vt/sqlparser/sql.y:2487
 		{
 			if incNesting(yylex) {
 				yylex.Error("max nesting level reached")
 				return 1
 			}
 		}
-	case 297:
+	case 499:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1511
+		//line ./go/vt/sqlparser/sql.y:2496
 		{
 			decNesting(yylex)
 		}
-	case 298:
+	case 500:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line ./go/vt/sqlparser/sql.y:1516
+		//line ./go/vt/sqlparser/sql.y:2501
 		{
 			forceEOF(yylex)
 		}
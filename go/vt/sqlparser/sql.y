@@ -5,6 +5,8 @@
 %{
 package sqlparser
 
+import "strings"
+
 func setParseTree(yylex interface{}, stmt Statement) {
   yylex.(*Tokenizer).ParseTree = stmt
 }
@@ -29,6 +31,48 @@ func forceEOF(yylex interface{}) {
   yylex.(*Tokenizer).ForceEOF = true
 }
 
+func setDDL(yylex interface{}, ddl *DDL) {
+  yylex.(*Tokenizer).partialDDL = ddl
+}
+
+func skipToEnd(yylex interface{}, n int) string {
+  return yylex.(*Tokenizer).skipToEnd(n)
+}
+
+// tableOptionNames are the table options named by an identifier.
+// ALGORITHM is an ALTER TABLE clause, but it shares their syntax.
+var tableOptionNames = map[string]bool{
+  "algorithm":          true,
+  "avg_row_length":     true,
+  "checksum":           true,
+  "compression":        true,
+  "connection":         true,
+  "delay_key_write":    true,
+  "encryption":         true,
+  "engine":             true,
+  "insert_method":      true,
+  "max_rows":           true,
+  "min_rows":           true,
+  "pack_keys":          true,
+  "password":           true,
+  "row_format":         true,
+  "stats_auto_recalc":  true,
+  "stats_persistent":   true,
+  "stats_sample_pages": true,
+  "tablespace":         true,
+}
+
+// partitionOperations are the ALTER TABLE operations on
+// partitions that start with an identifier.
+var partitionOperations = map[string]bool{
+  "coalesce":   true,
+  "exchange":   true,
+  "rebuild":    true,
+  "reorganize": true,
+  "repair":     true,
+  "truncate":   true,
+}
+
 %}
 
 %union {
@@ -68,19 +112,33 @@ func forceEOF(yylex interface{}) {
   tableIdent  TableIdent
   convertType *ConvertType
   aliasedTableName *AliasedTableExpr
+  ddl         *DDL
+  tableSpec   *TableSpec
+  columnDefinition  *ColumnDefinition
+  columnDefinitions []*ColumnDefinition
+  columnType  *ColumnType
+  indexDefinition *IndexDefinition
+  indexColumn  *IndexColumn
+  indexColumns []*IndexColumn
+  constraintDefinition *ConstraintDefinition
+  tableOption  *TableOption
+  tableOptions TableOptions
+  alterSpec    *AlterSpec
+  alterSpecs   []*AlterSpec
+  strs         []string
 }
 
 %token LEX_ERROR
 %left <empty> UNION
 %token <empty> SELECT INSERT UPDATE DELETE FROM WHERE GROUP HAVING ORDER BY LIMIT OFFSET FOR
-%token <empty> ALL DISTINCT AS EXISTS ASC DESC INTO DUPLICATE KEY DEFAULT SET LOCK
+%token <empty> ALL DISTINCT AS EXISTS ASC DESC INTO DUPLICATE DEFAULT SET LOCK
 %token <empty> VALUES LAST_INSERT_ID
 %token <empty> NEXT VALUE
 %token <empty> SQL_NO_CACHE SQL_CACHE
 %left <empty> JOIN STRAIGHT_JOIN LEFT RIGHT INNER OUTER CROSS NATURAL USE FORCE
 %left <empty> ON
 %token <empty> '(' ',' ')'
-%token <bytes> ID HEX STRING INTEGRAL FLOAT HEXNUM VALUE_ARG LIST_ARG COMMENT
+%token <bytes> ID HEX STRING INTEGRAL FLOAT HEXNUM VALUE_ARG LIST_ARG COMMENT BIT_LITERAL
 %token <empty> NULL TRUE FALSE
 
 // Precedence dictated by mysql. But the vitess grammar is simplified.
@@ -104,6 +162,11 @@ func forceEOF(yylex interface{}) {
 %right <empty> INTERVAL
 %nonassoc <empty> '.'
 
+// In the column attributes, UNIQUE KEY is a single
+// attribute rather than UNIQUE followed by KEY.
+%nonassoc <empty> UNIQUE
+%nonassoc <empty> KEY
+
 // There is no need to define precedence for the JSON
 // operators because the syntax is restricted enough that
 // they don't cause conflicts.
@@ -111,8 +174,20 @@ func forceEOF(yylex interface{}) {
 
 // DDL Tokens
 %token <empty> CREATE ALTER DROP RENAME ANALYZE
-%token <empty> TABLE INDEX VIEW TO IGNORE IF UNIQUE USING
+%token <empty> TABLE INDEX VIEW TO IGNORE IF USING
 %token <empty> SHOW DESCRIBE EXPLAIN
+%token <empty> ADD CHANGE COLUMN CONSTRAINT PRIMARY FOREIGN FULLTEXT SPATIAL KEYS
+%token <empty> REFERENCES RESTRICT CASCADE CHECK GENERATED VIRTUAL STORED
+%token <empty> ZEROFILL PRECISION PARTITION OPTIMIZE
+
+// MySQL reserved words that name data types map to this token.
+%token <bytes> DATA_TYPE
+
+// Keywords of CREATE TABLE and ALTER TABLE that MySQL doesn't
+// reserve. The tokenizer only recognizes them in those statements.
+%token <bytes> ACTION AFTER ALWAYS AUTO_INCREMENT CHARSET COMMENT_KEYWORD DIRECTORY
+%token <bytes> DISABLE DISCARD ENABLE ENFORCED FIRST IMPORT INVISIBLE KEY_BLOCK_SIZE
+%token <bytes> MODIFY NO PARSER PARTITIONING SIGNED UNSIGNED VISIBLE
 
 // Convert Type Tokens
 %token <empty> INTEGER CHARACTER
@@ -184,8 +259,31 @@ func forceEOF(yylex interface{}) {
 %type <updateExpr> update_expression
 %type <empty> for_from
 %type <str> ignore_opt
-%type <byt> exists_opt
-%type <empty> not_exists_opt non_rename_operation to_opt constraint_opt using_opt
+%type <byt> exists_opt not_exists_opt
+%type <empty> to_opt constraint_opt using_opt column_opt key_or_index key_or_index_opt equal_opt
+%type <ddl> create_table_prefix alter_table_prefix
+%type <tableSpec> table_spec table_element_list
+%type <columnDefinition> column_definition
+%type <columnDefinitions> column_definition_list
+%type <columnType> column_type
+%type <str> type_name collate_opt table_option_value union_table_list partition_opt
+%type <strs> enum_value_list
+%type <expr> column_default
+%type <indexDefinition> index_definition index_info
+%type <indexColumn> index_column
+%type <indexColumns> index_column_list
+%type <colIdent> constraint_name_opt index_name_opt ddl_id
+%type <constraintDefinition> constraint_definition
+%type <str> reference_option
+%type <tableOption> table_option
+%type <tableOptions> table_option_list table_option_list_opt alter_option_list
+%type <alterSpec> alter_spec alter_order_by column_position_opt
+%type <alterSpecs> alter_specs alter_spec_list
+%type <empty> partition_operation
+%type <columns> ddl_column_list
+%type <tableIdent> ddl_table_id
+%type <tableName> ddl_table_name
+%type <bytes> non_reserved_keyword
 %type <colIdent> sql_id col_alias as_ci_opt
 %type <tableIdent> table_id table_alias as_opt_id
 %type <empty> as_opt
@@ -195,159 +293,1018 @@ func forceEOF(yylex interface{}) {
 
 %start any_command
 
-%%
+%%
+
+any_command:
+  command semicolon_opt
+  {
+    setParseTree(yylex, $1)
+  }
+
+semicolon_opt:
+/*empty*/ {}
+| ';' {}
+
+command:
+  select_statement
+  {
+    $$ = $1
+  }
+| insert_statement
+| update_statement
+| delete_statement
+| set_statement
+| create_statement
+| alter_statement
+| rename_statement
+| drop_statement
+| analyze_statement
+| other_statement
+
+select_statement:
+  SELECT comment_opt cache_opt distinct_opt straight_join_opt select_expression_list from_opt where_expression_opt group_by_opt having_opt order_by_opt limit_opt lock_opt
+  {
+    $$ = &Select{Comments: Comments($2), Cache: $3, Distinct: $4, Hints: $5, SelectExprs: $6, From: $7, Where: NewWhere(WhereStr, $8), GroupBy: GroupBy($9), Having: NewWhere(HavingStr, $10), OrderBy: $11, Limit: $12, Lock: $13}
+  }
+| SELECT comment_opt cache_opt NEXT num_val for_from table_name
+  {
+    $$ = &Select{Comments: Comments($2), Cache: $3, SelectExprs: SelectExprs{Nextval{Expr: $5}}, From: TableExprs{&AliasedTableExpr{Expr: $7}}}
+  }
+| select_statement union_op select_statement %prec UNION
+  {
+    $$ = &Union{Type: $2, Left: $1, Right: $3}
+  }
+
+insert_statement:
+  INSERT comment_opt ignore_opt into_table_name ins_column_list_opt row_list on_dup_opt
+  {
+    $$ = &Insert{Comments: Comments($2), Ignore: $3, Table: $4, Columns: $5, Rows: $6, OnDup: OnDup($7)}
+  }
+| INSERT comment_opt ignore_opt into_table_name SET update_list on_dup_opt
+  {
+    cols := make(Columns, 0, len($6))
+    vals := make(ValTuple, 0, len($7))
+    for _, updateList := range $6 {
+      cols = append(cols, updateList.Name.Name)
+      vals = append(vals, updateList.Expr)
+    }
+    $$ = &Insert{Comments: Comments($2), Ignore: $3, Table: $4, Columns: cols, Rows: Values{vals}, OnDup: OnDup($7)}
+  }
+
+update_statement:
+  UPDATE comment_opt aliased_table_name SET update_list where_expression_opt order_by_opt limit_opt
+  {
+    $$ = &Update{Comments: Comments($2), Table: $3, Exprs: $5, Where: NewWhere(WhereStr, $6), OrderBy: $7, Limit: $8}
+  }
+
+delete_statement:
+  DELETE comment_opt FROM table_name where_expression_opt order_by_opt limit_opt
+  {
+    $$ = &Delete{Comments: Comments($2), Table: $4, Where: NewWhere(WhereStr, $5), OrderBy: $6, Limit: $7}
+  }
+
+set_statement:
+  SET comment_opt update_list
+  {
+    $$ = &Set{Comments: Comments($2), Exprs: $3}
+  }
+
+create_statement:
+  create_table_prefix table_spec
+  {
+    $$ = &DDL{Action: CreateStr, NewName: $1.NewName, IfNotExists: $1.IfNotExists, TableSpec: $2}
+  }
+| CREATE constraint_opt INDEX ddl_id using_opt ON ddl_table_id force_eof
+  {
+    // Change this to an alter statement
+    $$ = &DDL{Action: AlterStr, Table: $7, NewName: $7}
+  }
+| CREATE VIEW ddl_id force_eof
+  {
+    $$ = &DDL{Action: CreateStr, NewName: NewTableIdent($3.Lowered())}
+  }
+
+// The table names are known once the prefix is parsed. If
+// the rest of the statement cannot be parsed, Parse returns
+// the DDL built here, without a TableSpec.
+create_table_prefix:
+  CREATE TABLE not_exists_opt ddl_table_id
+  {
+    $$ = &DDL{Action: CreateStr, NewName: $4, IfNotExists: $3 != 0}
+    setDDL(yylex, $$)
+  }
+
+alter_statement:
+  alter_table_prefix alter_specs
+  {
+    if len($2) == 1 && $2[0].Action == RenameTableStr {
+      // Change this to a rename statement
+      $$ = &DDL{Action: RenameStr, Table: $1.Table, NewName: $2[0].NewTable}
+    } else {
+      ddl := &DDL{Action: AlterStr, Table: $1.Table, NewName: $1.NewName, AlterSpecs: $2}
+      for _, spec := range $2 {
+        if spec.Action == RenameTableStr {
+          ddl.NewName = spec.NewTable
+        }
+      }
+      $$ = ddl
+    }
+  }
+| ALTER VIEW ddl_id force_eof
+  {
+    $$ = &DDL{Action: AlterStr, Table: NewTableIdent($3.Lowered()), NewName: NewTableIdent($3.Lowered())}
+  }
+
+// Like create_table_prefix, Parse returns the DDL
+// built here, without AlterSpecs, if the rest of the
+// statement cannot be parsed.
+alter_table_prefix:
+  ALTER ignore_opt TABLE ddl_table_id
+  {
+    $$ = &DDL{Action: AlterStr, Table: $4, NewName: $4}
+    setDDL(yylex, $$)
+  }
+
+rename_statement:
+  RENAME TABLE table_id TO table_id
+  {
+    $$ = &DDL{Action: RenameStr, Table: $3, NewName: $5}
+  }
+
+drop_statement:
+  DROP TABLE exists_opt table_id
+  {
+    var exists bool
+    if $3 != 0 {
+      exists = true
+    }
+    $$ = &DDL{Action: DropStr, Table: $4, IfExists: exists}
+  }
+| DROP INDEX ID ON table_id
+  {
+    // Change this to an alter statement
+    $$ = &DDL{Action: AlterStr, Table: $5, NewName: $5}
+  }
+| DROP VIEW exists_opt sql_id force_eof
+  {
+    var exists bool
+        if $3 != 0 {
+          exists = true
+        }
+    $$ = &DDL{Action: DropStr, Table: NewTableIdent($4.Lowered()), IfExists: exists}
+  }
+
+analyze_statement:
+  ANALYZE TABLE table_id
+  {
+    $$ = &DDL{Action: AlterStr, Table: $3, NewName: $3}
+  }
+
+other_statement:
+  SHOW force_eof
+  {
+    $$ = &Other{}
+  }
+| DESCRIBE force_eof
+  {
+    $$ = &Other{}
+  }
+| EXPLAIN force_eof
+  {
+    $$ = &Other{}
+  }
+
+table_spec:
+  openb table_element_list closeb table_option_list_opt partition_opt
+  {
+    $$ = $2
+    $$.Options = $4
+    $$.Partitions = $5
+  }
+
+table_element_list:
+  column_definition
+  {
+    $$ = &TableSpec{Columns: []*ColumnDefinition{$1}}
+  }
+| index_definition
+  {
+    $$ = &TableSpec{Indexes: []*IndexDefinition{$1}}
+  }
+| constraint_definition
+  {
+    $$ = &TableSpec{Constraints: []*ConstraintDefinition{$1}}
+  }
+| table_element_list ',' column_definition
+  {
+    $$ = $1
+    $$.Columns = append($$.Columns, $3)
+  }
+| table_element_list ',' index_definition
+  {
+    $$ = $1
+    $$.Indexes = append($$.Indexes, $3)
+  }
+| table_element_list ',' constraint_definition
+  {
+    $$ = $1
+    $$.Constraints = append($$.Constraints, $3)
+  }
+
+column_definition:
+  ddl_id column_type
+  {
+    $$ = &ColumnDefinition{Name: $1, Type: $2}
+  }
+
+column_definition_list:
+  column_definition
+  {
+    $$ = []*ColumnDefinition{$1}
+  }
+| column_definition_list ',' column_definition
+  {
+    $$ = append($1, $3)
+  }
+
+// The attributes of a column can be in any order.
+column_type:
+  type_name
+  {
+    $$ = &ColumnType{Type: $1}
+  }
+| type_name openb INTEGRAL closeb
+  {
+    $$ = &ColumnType{Type: $1, Length: NewIntVal($3)}
+  }
+| type_name openb INTEGRAL ',' INTEGRAL closeb
+  {
+    $$ = &ColumnType{Type: $1, Length: NewIntVal($3), Scale: NewIntVal($5)}
+  }
+| type_name openb enum_value_list closeb
+  {
+    $$ = &ColumnType{Type: $1, EnumValues: $3}
+  }
+| column_type UNSIGNED
+  {
+    $$ = $1
+    $$.Unsigned = true
+  }
+| column_type SIGNED
+  {
+    $$ = $1
+  }
+| column_type ZEROFILL
+  {
+    $$ = $1
+    $$.Zerofill = true
+  }
+| column_type CHARACTER SET charset
+  {
+    $$ = $1
+    $$.Charset = $4
+  }
+| column_type CHARSET charset
+  {
+    $$ = $1
+    $$.Charset = $3
+  }
+| column_type COLLATE charset
+  {
+    $$ = $1
+    $$.Collate = $3
+  }
+| column_type GENERATED ALWAYS AS openb expression closeb
+  {
+    $$ = $1
+    $$.As = $6
+  }
+| column_type AS openb expression closeb
+  {
+    $$ = $1
+    $$.As = $4
+  }
+| column_type VIRTUAL
+  {
+    $$ = $1
+  }
+| column_type STORED
+  {
+    $$ = $1
+    $$.Stored = true
+  }
+| column_type NOT NULL
+  {
+    $$ = $1
+    $$.NotNull = true
+  }
+| column_type NULL
+  {
+    $$ = $1
+    $$.Null = true
+  }
+| column_type DEFAULT column_default
+  {
+    $$ = $1
+    $$.Default = $3
+  }
+| column_type ON UPDATE column_default
+  {
+    $$ = $1
+    $$.OnUpdate = $4
+  }
+| column_type AUTO_INCREMENT
+  {
+    $$ = $1
+    $$.Autoincrement = true
+  }
+| column_type PRIMARY KEY
+  {
+    $$ = $1
+    $$.KeyOpt = ColumnKeyPrimary
+  }
+| column_type UNIQUE
+  {
+    $$ = $1
+    $$.KeyOpt = ColumnKeyUnique
+  }
+| column_type UNIQUE KEY
+  {
+    $$ = $1
+    $$.KeyOpt = ColumnKeyUnique
+  }
+| column_type KEY
+  {
+    $$ = $1
+    $$.KeyOpt = ColumnKey
+  }
+| column_type COMMENT_KEYWORD STRING
+  {
+    $$ = $1
+    $$.Comment = NewStrVal($3)
+  }
+
+type_name:
+  ID
+  {
+    $$ = strings.ToLower(string($1))
+  }
+| DATA_TYPE
+  {
+    $$ = string($1)
+  }
+| DATA_TYPE PRECISION
+  {
+    $$ = string($1) + " precision"
+  }
+| INTEGER
+  {
+    $$ = "integer"
+  }
+| CHARACTER
+  {
+    $$ = "character"
+  }
+| BINARY
+  {
+    $$ = "binary"
+  }
+| SET
+  {
+    $$ = "set"
+  }
+
+enum_value_list:
+  STRING
+  {
+    $$ = []string{string($1)}
+  }
+| enum_value_list ',' STRING
+  {
+    $$ = append($1, string($3))
+  }
+
+// column_default is the value of a DEFAULT or ON UPDATE clause:
+// a literal, CURRENT_TIMESTAMP or an expression in parenthesis.
+column_default:
+  value
+| BIT_LITERAL
+  {
+    $$ = NewBitVal($1)
+  }
+| boolean_value
+  {
+    $$ = $1
+  }
+| '-' INTEGRAL
+  {
+    $$ = NewIntVal(append([]byte("-"), $2...))
+  }
+| '-' FLOAT
+  {
+    $$ = NewFloatVal(append([]byte("-"), $2...))
+  }
+| keyword
+  {
+    $$ = &FuncExpr{Name: $1}
+  }
+| keyword openb closeb
+  {
+    $$ = &FuncExpr{Name: $1}
+  }
+| keyword openb INTEGRAL closeb
+  {
+    $$ = &FuncExpr{Name: $1, Exprs: SelectExprs{&NonStarExpr{Expr: NewIntVal($3)}}}
+  }
+| sql_id openb closeb
+  {
+    $$ = &FuncExpr{Name: $1}
+  }
+| sql_id openb INTEGRAL closeb
+  {
+    $$ = &FuncExpr{Name: $1, Exprs: SelectExprs{&NonStarExpr{Expr: NewIntVal($3)}}}
+  }
+| openb expression closeb
+  {
+    $$ = $2
+  }
+
+index_definition:
+  index_info openb index_column_list closeb
+  {
+    $$ = $1
+    $$.Columns = $3
+  }
+| index_definition USING ID
+  {
+    $$ = $1
+    $$.Using = strings.ToLower(string($3))
+  }
+| index_definition KEY_BLOCK_SIZE equal_opt table_option_value
+  {
+    $$ = $1
+    $$.Options = append($$.Options, &IndexOption{Name: "key_block_size", Value: $4})
+  }
+| index_definition COMMENT_KEYWORD STRING
+  {
+    $$ = $1
+    $$.Options = append($$.Options, &IndexOption{Name: "comment", Value: String(NewStrVal($3))})
+  }
+| index_definition WITH PARSER ID
+  {
+    $$ = $1
+    $$.Options = append($$.Options, &IndexOption{Name: "with parser", Value: string($4)})
+  }
+| index_definition VISIBLE
+  {
+    $$ = $1
+    $$.Options = append($$.Options, &IndexOption{Name: "visible"})
+  }
+| index_definition INVISIBLE
+  {
+    $$ = $1
+    $$.Options = append($$.Options, &IndexOption{Name: "invisible"})
+  }
+
+index_info:
+  constraint_name_opt PRIMARY KEY
+  {
+    $$ = &IndexDefinition{Type: IndexTypePrimary}
+  }
+| constraint_name_opt UNIQUE key_or_index_opt index_name_opt
+  {
+    // The constraint names the index if it doesn't have a name.
+    name := $4
+    if name.IsEmpty() {
+      name = $1
+    }
+    $$ = &IndexDefinition{Type: IndexTypeUnique, Name: name}
+  }
+| key_or_index index_name_opt
+  {
+    $$ = &IndexDefinition{Type: IndexTypeKey, Name: $2}
+  }
+| FULLTEXT key_or_index_opt index_name_opt
+  {
+    $$ = &IndexDefinition{Type: IndexTypeFulltext, Name: $3}
+  }
+| SPATIAL key_or_index_opt index_name_opt
+  {
+    $$ = &IndexDefinition{Type: IndexTypeSpatial, Name: $3}
+  }
+| index_info USING ID
+  {
+    $$ = $1
+    $$.Using = strings.ToLower(string($3))
+  }
+
+index_column_list:
+  index_column
+  {
+    $$ = []*IndexColumn{$1}
+  }
+| index_column_list ',' index_column
+  {
+    $$ = append($1, $3)
+  }
+
+index_column:
+  ddl_id
+  {
+    $$ = &IndexColumn{Column: $1}
+  }
+| ddl_id openb INTEGRAL closeb
+  {
+    $$ = &IndexColumn{Column: $1, Length: NewIntVal($3)}
+  }
+| index_column ASC
+  {
+    $$ = $1
+    $$.Order = AscScr
+  }
+| index_column DESC
+  {
+    $$ = $1
+    $$.Order = DescScr
+  }
+
+constraint_definition:
+  constraint_name_opt FOREIGN KEY index_name_opt openb ddl_column_list closeb REFERENCES ddl_table_name openb ddl_column_list closeb
+  {
+    $$ = &ConstraintDefinition{Name: $1, Type: ConstraintForeignKey, IndexName: $4, Columns: $6, ReferencedTable: $9, ReferencedColumns: $11}
+  }
+| constraint_name_opt CHECK openb expression closeb
+  {
+    $$ = &ConstraintDefinition{Name: $1, Type: ConstraintCheck, Check: $4}
+  }
+| constraint_definition MATCH ID
+  {
+    $$ = $1
+    $$.Match = strings.ToLower(string($3))
+  }
+| constraint_definition ON DELETE reference_option
+  {
+    $$ = $1
+    $$.OnDelete = $4
+  }
+| constraint_definition ON UPDATE reference_option
+  {
+    $$ = $1
+    $$.OnUpdate = $4
+  }
+| constraint_definition ENFORCED
+  {
+    $$ = $1
+  }
+| constraint_definition NOT ENFORCED
+  {
+    $$ = $1
+    $$.NotEnforced = true
+  }
+
+constraint_name_opt:
+  {
+    $$ = ColIdent{}
+  }
+| CONSTRAINT
+  {
+    $$ = ColIdent{}
+  }
+| CONSTRAINT ddl_id
+  {
+    $$ = $2
+  }
+
+reference_option:
+  RESTRICT
+  {
+    $$ = "restrict"
+  }
+| CASCADE
+  {
+    $$ = "cascade"
+  }
+| SET NULL
+  {
+    $$ = "set null"
+  }
+| SET DEFAULT
+  {
+    $$ = "set default"
+  }
+| NO ACTION
+  {
+    $$ = "no action"
+  }
+
+index_name_opt:
+  {
+    $$ = ColIdent{}
+  }
+| ddl_id
+
+key_or_index:
+  KEY
+  { $$ = struct{}{} }
+| INDEX
+  { $$ = struct{}{} }
+
+key_or_index_opt:
+  { $$ = struct{}{} }
+| key_or_index
+  { $$ = struct{}{} }
+
+ddl_column_list:
+  ddl_id
+  {
+    $$ = Columns{$1}
+  }
+| ddl_column_list ',' ddl_id
+  {
+    $$ = append($1, $3)
+  }
+
+ddl_table_name:
+  ddl_table_id
+  {
+    $$ = &TableName{Name: $1}
+  }
+| ddl_table_id '.' ddl_table_id
+  {
+    $$ = &TableName{Qualifier: $1, Name: $3}
+  }
 
-any_command:
-  command semicolon_opt
+table_option_list_opt:
   {
-    setParseTree(yylex, $1)
+    $$ = nil
   }
+| table_option_list
 
-semicolon_opt:
-/*empty*/ {}
-| ';' {}
+// The table options of a CREATE TABLE can be separated by commas.
+table_option_list:
+  table_option
+  {
+    $$ = TableOptions{$1}
+  }
+| table_option_list table_option
+  {
+    $$ = append($1, $2)
+  }
+| table_option_list ',' table_option
+  {
+    $$ = append($1, $3)
+  }
 
-command:
-  select_statement
+alter_option_list:
+  table_option
   {
-    $$ = $1
+    $$ = TableOptions{$1}
+  }
+| alter_option_list table_option
+  {
+    $$ = append($1, $2)
   }
-| insert_statement
-| update_statement
-| delete_statement
-| set_statement
-| create_statement
-| alter_statement
-| rename_statement
-| drop_statement
-| analyze_statement
-| other_statement
 
-select_statement:
-  SELECT comment_opt cache_opt distinct_opt straight_join_opt select_expression_list from_opt where_expression_opt group_by_opt having_opt order_by_opt limit_opt lock_opt
+table_option:
+  ID equal_opt table_option_value
   {
-    $$ = &Select{Comments: Comments($2), Cache: $3, Distinct: $4, Hints: $5, SelectExprs: $6, From: $7, Where: NewWhere(WhereStr, $8), GroupBy: GroupBy($9), Having: NewWhere(HavingStr, $10), OrderBy: $11, Limit: $12, Lock: $13}
+    name := strings.ToLower(string($1))
+    if !tableOptionNames[name] {
+      yylex.Error("syntax error")
+      return 1
+    }
+    $$ = &TableOption{Name: name, Value: $3}
   }
-| SELECT comment_opt cache_opt NEXT num_val for_from table_name
+| ID DIRECTORY equal_opt table_option_value
   {
-    $$ = &Select{Comments: Comments($2), Cache: $3, SelectExprs: SelectExprs{Nextval{Expr: $5}}, From: TableExprs{&AliasedTableExpr{Expr: $7}}}
+    if strings.ToLower(string($1)) != "data" {
+      yylex.Error("syntax error")
+      return 1
+    }
+    $$ = &TableOption{Name: "data directory", Value: $4}
   }
-| select_statement union_op select_statement %prec UNION
+| INDEX DIRECTORY equal_opt table_option_value
   {
-    $$ = &Union{Type: $2, Left: $1, Right: $3}
+    $$ = &TableOption{Name: "index directory", Value: $4}
+  }
+| AUTO_INCREMENT equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "auto_increment", Value: $3}
+  }
+| COMMENT_KEYWORD equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "comment", Value: $3}
+  }
+| KEY_BLOCK_SIZE equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "key_block_size", Value: $3}
+  }
+| LOCK equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "lock", Value: $3}
+  }
+| UNION equal_opt openb union_table_list closeb
+  {
+    $$ = &TableOption{Name: "union", Value: "(" + $4 + ")"}
+  }
+| CHARSET equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "charset", Value: $3}
+  }
+| CHARACTER SET equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "character set", Value: $4}
+  }
+| COLLATE equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "collate", Value: $3}
+  }
+| DEFAULT CHARSET equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "default charset", Value: $4}
+  }
+| DEFAULT CHARACTER SET equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "default character set", Value: $5}
+  }
+| DEFAULT COLLATE equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: "default collate", Value: $4}
   }
 
-insert_statement:
-  INSERT comment_opt ignore_opt into_table_name ins_column_list_opt row_list on_dup_opt
+// table_option_value is a value formatted as it
+// must be written in a table option.
+table_option_value:
+  ID
   {
-    $$ = &Insert{Comments: Comments($2), Ignore: $3, Table: $4, Columns: $5, Rows: $6, OnDup: OnDup($7)}
+    $$ = string($1)
   }
-| INSERT comment_opt ignore_opt into_table_name SET update_list on_dup_opt
+| STRING
   {
-    cols := make(Columns, 0, len($6))
-    vals := make(ValTuple, 0, len($7))
-    for _, updateList := range $6 {
-      cols = append(cols, updateList.Name.Name)
-      vals = append(vals, updateList.Expr)
-    }
-    $$ = &Insert{Comments: Comments($2), Ignore: $3, Table: $4, Columns: cols, Rows: Values{vals}, OnDup: OnDup($7)}
+    $$ = String(NewStrVal($1))
+  }
+| INTEGRAL
+  {
+    $$ = string($1)
+  }
+| FLOAT
+  {
+    $$ = string($1)
+  }
+| DEFAULT
+  {
+    $$ = "default"
+  }
+| BINARY
+  {
+    $$ = "binary"
+  }
+| FIRST
+  {
+    $$ = "first"
+  }
+| NO
+  {
+    $$ = "no"
   }
 
-update_statement:
-  UPDATE comment_opt aliased_table_name SET update_list where_expression_opt order_by_opt limit_opt
+union_table_list:
+  ddl_table_name
   {
-    $$ = &Update{Comments: Comments($2), Table: $3, Exprs: $5, Where: NewWhere(WhereStr, $6), OrderBy: $7, Limit: $8}
+    $$ = String($1)
+  }
+| union_table_list ',' ddl_table_name
+  {
+    $$ = $1 + ", " + String($3)
   }
 
-delete_statement:
-  DELETE comment_opt FROM table_name where_expression_opt order_by_opt limit_opt
+equal_opt:
+  { $$ = struct{}{} }
+| '='
+  { $$ = struct{}{} }
+
+// The partitioning clauses are kept as written.
+partition_opt:
   {
-    $$ = &Delete{Comments: Comments($2), Table: $4, Where: NewWhere(WhereStr, $5), OrderBy: $6, Limit: $7}
+    $$ = ""
+  }
+| PARTITION
+  {
+    $$ = skipToEnd(yylex, 1)
   }
 
-set_statement:
-  SET comment_opt update_list
+// The columns of ORDER BY take the commas that follow
+// them, so it must be the last operation.
+alter_specs:
+  alter_spec_list
+| alter_order_by
   {
-    $$ = &Set{Comments: Comments($2), Exprs: $3}
+    $$ = []*AlterSpec{$1}
+  }
+| alter_spec_list ',' alter_order_by
+  {
+    $$ = append($1, $3)
   }
 
-create_statement:
-  CREATE TABLE not_exists_opt table_id force_eof
+alter_spec_list:
+  alter_spec
   {
-    $$ = &DDL{Action: CreateStr, NewName: $4}
+    $$ = []*AlterSpec{$1}
   }
-| CREATE constraint_opt INDEX ID using_opt ON table_id force_eof
+| alter_spec_list ',' alter_spec
   {
-    // Change this to an alter statement
-    $$ = &DDL{Action: AlterStr, Table: $7, NewName: $7}
+    $$ = append($1, $3)
   }
-| CREATE VIEW sql_id force_eof
+
+alter_order_by:
+  ORDER BY ddl_column_list
   {
-    $$ = &DDL{Action: CreateStr, NewName: NewTableIdent($3.Lowered())}
+    $$ = &AlterSpec{Action: OrderByStr, OrderBy: $3}
   }
 
-alter_statement:
-  ALTER ignore_opt TABLE table_id non_rename_operation force_eof
+alter_spec:
+  ADD column_opt column_definition column_position_opt
   {
-    $$ = &DDL{Action: AlterStr, Table: $4, NewName: $4}
+    $$ = $4
+    $$.Action = AddColumnStr
+    $$.Columns = []*ColumnDefinition{$3}
   }
-| ALTER ignore_opt TABLE table_id RENAME to_opt table_id
+| ADD column_opt openb column_definition_list closeb
   {
-    // Change this to a rename statement
-    $$ = &DDL{Action: RenameStr, Table: $4, NewName: $7}
+    $$ = &AlterSpec{Action: AddColumnStr, Columns: $4}
   }
-| ALTER VIEW sql_id force_eof
+| ADD index_definition
   {
-    $$ = &DDL{Action: AlterStr, Table: NewTableIdent($3.Lowered()), NewName: NewTableIdent($3.Lowered())}
+    $$ = &AlterSpec{Action: AddIndexStr, Index: $2}
   }
-
-rename_statement:
-  RENAME TABLE table_id TO table_id
+| ADD constraint_definition
   {
-    $$ = &DDL{Action: RenameStr, Table: $3, NewName: $5}
+    $$ = &AlterSpec{Action: AddConstraintStr, Constraint: $2}
   }
-
-drop_statement:
-  DROP TABLE exists_opt table_id
+| ALTER column_opt ddl_id SET DEFAULT column_default
   {
-    var exists bool
-    if $3 != 0 {
-      exists = true
+    $$ = &AlterSpec{Action: AlterColumnStr, Name: $3, Default: $6}
+  }
+| ALTER column_opt ddl_id DROP DEFAULT
+  {
+    $$ = &AlterSpec{Action: AlterColumnStr, Name: $3}
+  }
+| CHANGE column_opt ddl_id column_definition column_position_opt
+  {
+    $$ = $5
+    $$.Action = ChangeColumnStr
+    $$.Name = $3
+    $$.Columns = []*ColumnDefinition{$4}
+  }
+| MODIFY column_opt column_definition column_position_opt
+  {
+    $$ = $4
+    $$.Action = ModifyColumnStr
+    $$.Columns = []*ColumnDefinition{$3}
+  }
+| DROP COLUMN ddl_id
+  {
+    $$ = &AlterSpec{Action: DropColumnStr, Name: $3}
+  }
+| DROP key_or_index ddl_id
+  {
+    $$ = &AlterSpec{Action: DropIndexStr, Name: $3}
+  }
+| DROP PRIMARY KEY
+  {
+    $$ = &AlterSpec{Action: DropPrimaryKeyStr}
+  }
+| DROP FOREIGN KEY ddl_id
+  {
+    $$ = &AlterSpec{Action: DropForeignKeyStr, Name: $4}
+  }
+| RENAME key_or_index ddl_id TO ddl_id
+  {
+    $$ = &AlterSpec{Action: RenameIndexStr, Name: $3, NewName: $5}
+  }
+| RENAME COLUMN ddl_id TO ddl_id
+  {
+    $$ = &AlterSpec{Action: RenameColumnStr, Name: $3, NewName: $5}
+  }
+| RENAME to_opt ddl_table_id
+  {
+    $$ = &AlterSpec{Action: RenameTableStr, NewTable: $3}
+  }
+| CONVERT TO CHARACTER SET charset collate_opt
+  {
+    $$ = &AlterSpec{Action: ConvertStr, Options: TableOptions{{Name: "character set", Value: $5}}}
+    if $6 != "" {
+      $$.Options = append($$.Options, &TableOption{Name: "collate", Value: $6})
     }
-    $$ = &DDL{Action: DropStr, Table: $4, IfExists: exists}
   }
-| DROP INDEX ID ON table_id
+| CONVERT TO CHARSET charset collate_opt
   {
-    // Change this to an alter statement
-    $$ = &DDL{Action: AlterStr, Table: $5, NewName: $5}
+    $$ = &AlterSpec{Action: ConvertStr, Options: TableOptions{{Name: "character set", Value: $4}}}
+    if $5 != "" {
+      $$.Options = append($$.Options, &TableOption{Name: "collate", Value: $5})
+    }
   }
-| DROP VIEW exists_opt sql_id force_eof
+| ENABLE KEYS
   {
-    var exists bool
-        if $3 != 0 {
-          exists = true
-        }
-    $$ = &DDL{Action: DropStr, Table: NewTableIdent($4.Lowered()), IfExists: exists}
+    $$ = &AlterSpec{Action: EnableKeysStr}
   }
-
-analyze_statement:
-  ANALYZE TABLE table_id
+| DISABLE KEYS
   {
-    $$ = &DDL{Action: AlterStr, Table: $3, NewName: $3}
+    $$ = &AlterSpec{Action: DisableKeysStr}
+  }
+| DISCARD ID
+  {
+    if strings.ToLower(string($2)) != "tablespace" {
+      yylex.Error("syntax error")
+      return 1
+    }
+    $$ = &AlterSpec{Action: DiscardTablespaceStr}
+  }
+| IMPORT ID
+  {
+    if strings.ToLower(string($2)) != "tablespace" {
+      yylex.Error("syntax error")
+      return 1
+    }
+    $$ = &AlterSpec{Action: ImportTablespaceStr}
+  }
+| FORCE
+  {
+    $$ = &AlterSpec{Action: ForceRebuildStr}
+  }
+| alter_option_list
+  {
+    $$ = &AlterSpec{Action: TableOptionsStr, Options: $1}
+  }
+| PARTITION
+  {
+    $$ = &AlterSpec{Action: PartitionStr, Partitions: skipToEnd(yylex, 1)}
+  }
+| partition_operation PARTITION
+  {
+    $$ = &AlterSpec{Action: PartitionStr, Partitions: skipToEnd(yylex, 2)}
+  }
+| ID PARTITION
+  {
+    if !partitionOperations[strings.ToLower(string($1))] {
+      yylex.Error("syntax error")
+      return 1
+    }
+    $$ = &AlterSpec{Action: PartitionStr, Partitions: skipToEnd(yylex, 2)}
+  }
+| ID PARTITIONING
+  {
+    switch strings.ToLower(string($1)) {
+    case "remove", "upgrade":
+    default:
+      yylex.Error("syntax error")
+      return 1
+    }
+    $$ = &AlterSpec{Action: PartitionStr, Partitions: skipToEnd(yylex, 2)}
   }
 
-other_statement:
-  SHOW force_eof
+// The keywords that start an operation on partitions.
+partition_operation:
+  ADD
+  { $$ = struct{}{} }
+| DROP
+  { $$ = struct{}{} }
+| DISCARD
+  { $$ = struct{}{} }
+| IMPORT
+  { $$ = struct{}{} }
+| ANALYZE
+  { $$ = struct{}{} }
+| CHECK
+  { $$ = struct{}{} }
+| OPTIMIZE
+  { $$ = struct{}{} }
+
+column_opt:
+  { $$ = struct{}{} }
+| COLUMN
+  { $$ = struct{}{} }
+
+column_position_opt:
   {
-    $$ = &Other{}
+    $$ = &AlterSpec{}
   }
-| DESCRIBE force_eof
+| FIRST
   {
-    $$ = &Other{}
+    $$ = &AlterSpec{First: true}
   }
-| EXPLAIN force_eof
+| AFTER ddl_id
   {
-    $$ = &Other{}
+    $$ = &AlterSpec{After: $2}
+  }
+
+collate_opt:
+  {
+    $$ = ""
+  }
+| COLLATE charset
+  {
+    $$ = $2
   }
 
 comment_opt:
@@ -1447,33 +2404,21 @@ exists_opt:
   { $$ = 1 }
 
 not_exists_opt:
-  { $$ = struct{}{} }
+  { $$ = 0 }
 | IF NOT EXISTS
-  { $$ = struct{}{} }
+  { $$ = 1 }
 
 ignore_opt:
   { $$ = "" }
 | IGNORE
   { $$ = IgnoreStr }
 
-non_rename_operation:
-  ALTER
-  { $$ = struct{}{} }
-| DEFAULT
-  { $$ = struct{}{} }
-| DROP
-  { $$ = struct{}{} }
-| ORDER
-  { $$ = struct{}{} }
-| UNUSED
-  { $$ = struct{}{} }
-| ID
-  { $$ = struct{}{} }
-
 to_opt:
   { $$ = struct{}{} }
 | TO
   { $$ = struct{}{} }
+| AS
+  { $$ = struct{}{} }
 
 constraint_opt:
   { $$ = struct{}{} }
@@ -1497,6 +2442,46 @@ table_id:
     $$ = NewTableIdent(string($1))
   }
 
+// The names of the CREATE TABLE and ALTER TABLE
+// statements can also be non-reserved keywords.
+ddl_id:
+  sql_id
+| non_reserved_keyword
+  {
+    $$ = NewColIdent(string($1))
+  }
+
+ddl_table_id:
+  table_id
+| non_reserved_keyword
+  {
+    $$ = NewTableIdent(string($1))
+  }
+
+non_reserved_keyword:
+  ACTION
+| AFTER
+| ALWAYS
+| AUTO_INCREMENT
+| CHARSET
+| COMMENT_KEYWORD
+| DIRECTORY
+| DISABLE
+| DISCARD
+| ENABLE
+| ENFORCED
+| FIRST
+| IMPORT
+| INVISIBLE
+| KEY_BLOCK_SIZE
+| MODIFY
+| NO
+| PARSER
+| PARTITIONING
+| SIGNED
+| UNSIGNED
+| VISIBLE
+
 openb:
   '('
   {
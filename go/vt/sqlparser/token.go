@@ -27,17 +27,37 @@ type Tokenizer struct {
 	posVarIndex   int
 	ParseTree     Statement
 	nesting       int
+
+	// partialDDL is the CREATE TABLE or ALTER TABLE statement
+	// being parsed, with only its table names. It is returned
+	// if the rest of the statement cannot be parsed.
+	partialDDL *DDL
+
+	// sql is the text being tokenized. tokenStart is the offset
+	// in it of the token being scanned, and tokenStarts those
+	// of the last two tokens, the last one first.
+	sql         string
+	tokenStart  int
+	tokenStarts [2]int
+
+	// started is set once the first token of the statement
+	// is scanned. ddl is set if the statement is a CREATE or
+	// an ALTER, which enables ddlKeywords and the tokenizing
+	// of the /*! ... */ comments through specialComment.
+	started        bool
+	ddl            bool
+	specialComment *Tokenizer
 }
 
 // NewStringTokenizer creates a new Tokenizer for the
 // sql string.
 func NewStringTokenizer(sql string) *Tokenizer {
-	return &Tokenizer{InStream: strings.NewReader(sql)}
+	return &Tokenizer{InStream: strings.NewReader(sql), sql: sql}
 }
 
 var keywords = map[string]int{
 	"accessible":          UNUSED,
-	"add":                 UNUSED,
+	"add":                 ADD,
 	"against":             AGAINST,
 	"all":                 ALL,
 	"alter":               ALTER,
@@ -48,23 +68,23 @@ var keywords = map[string]int{
 	"asensitive":          UNUSED,
 	"before":              UNUSED,
 	"between":             BETWEEN,
-	"bigint":              UNUSED,
+	"bigint":              DATA_TYPE,
 	"binary":              BINARY,
-	"blob":                UNUSED,
+	"blob":                DATA_TYPE,
 	"boolean":             BOOLEAN,
 	"both":                UNUSED,
 	"by":                  BY,
 	"call":                UNUSED,
-	"cascade":             UNUSED,
+	"cascade":             CASCADE,
 	"case":                CASE,
 	"cast":                CAST,
-	"change":              UNUSED,
+	"change":              CHANGE,
 	"character":           CHARACTER,
-	"check":               UNUSED,
+	"check":               CHECK,
 	"collate":             COLLATE,
-	"column":              UNUSED,
+	"column":              COLUMN,
 	"condition":           UNUSED,
-	"constraint":          UNUSED,
+	"constraint":          CONSTRAINT,
 	"continue":            UNUSED,
 	"convert":             CONVERT,
 	"create":              CREATE,
@@ -80,7 +100,7 @@ var keywords = map[string]int{
 	"day_microsecond":     UNUSED,
 	"day_minute":          UNUSED,
 	"day_second":          UNUSED,
-	"dec":                 UNUSED,
+	"dec":                 DATA_TYPE,
 	"declare":             UNUSED,
 	"default":             DEFAULT,
 	"delayed":             UNUSED,
@@ -91,7 +111,7 @@ var keywords = map[string]int{
 	"distinct":            DISTINCT,
 	"distinctrow":         UNUSED,
 	"div":                 DIV,
-	"double":              UNUSED,
+	"double":              DATA_TYPE,
 	"drop":                DROP,
 	"duplicate":           DUPLICATE,
 	"each":                UNUSED,
@@ -106,15 +126,15 @@ var keywords = map[string]int{
 	"expansion":           EXPANSION,
 	"false":               FALSE,
 	"fetch":               UNUSED,
-	"float":               UNUSED,
-	"float4":              UNUSED,
-	"float8":              UNUSED,
+	"float":               DATA_TYPE,
+	"float4":              DATA_TYPE,
+	"float8":              DATA_TYPE,
 	"for":                 FOR,
 	"force":               FORCE,
-	"foreign":             UNUSED,
+	"foreign":             FOREIGN,
 	"from":                FROM,
-	"fulltext":            UNUSED,
-	"generated":           UNUSED,
+	"fulltext":            FULLTEXT,
+	"generated":           GENERATED,
 	"get":                 UNUSED,
 	"grant":               UNUSED,
 	"group":               GROUP,
@@ -133,12 +153,12 @@ var keywords = map[string]int{
 	"inner":               INNER,
 	"insensitive":         UNUSED,
 	"insert":              INSERT,
-	"int":                 UNUSED,
-	"int1":                UNUSED,
-	"int2":                UNUSED,
-	"int3":                UNUSED,
-	"int4":                UNUSED,
-	"int8":                UNUSED,
+	"int":                 DATA_TYPE,
+	"int1":                DATA_TYPE,
+	"int2":                DATA_TYPE,
+	"int3":                DATA_TYPE,
+	"int4":                DATA_TYPE,
+	"int8":                DATA_TYPE,
 	"integer":             INTEGER,
 	"interval":            INTERVAL,
 	"into":                INTO,
@@ -147,7 +167,7 @@ var keywords = map[string]int{
 	"iterate":             UNUSED,
 	"join":                JOIN,
 	"key":                 KEY,
-	"keys":                UNUSED,
+	"keys":                KEYS,
 	"kill":                UNUSED,
 	"language":            LANGUAGE,
 	"last_insert_id":      LAST_INSERT_ID,
@@ -162,18 +182,18 @@ var keywords = map[string]int{
 	"localtime":           LOCALTIME,
 	"localtimestamp":      LOCALTIMESTAMP,
 	"lock":                LOCK,
-	"long":                UNUSED,
-	"longblob":            UNUSED,
-	"longtext":            UNUSED,
+	"long":                DATA_TYPE,
+	"longblob":            DATA_TYPE,
+	"longtext":            DATA_TYPE,
 	"loop":                UNUSED,
 	"low_priority":        UNUSED,
 	"master_bind":         UNUSED,
 	"match":               MATCH,
 	"maxvalue":            UNUSED,
-	"mediumblob":          UNUSED,
-	"mediumint":           UNUSED,
-	"mediumtext":          UNUSED,
-	"middleint":           UNUSED,
+	"mediumblob":          DATA_TYPE,
+	"mediumint":           DATA_TYPE,
+	"mediumtext":          DATA_TYPE,
+	"middleint":           DATA_TYPE,
 	"minute_microsecond":  UNUSED,
 	"minute_second":       UNUSED,
 	"mod":                 MOD,
@@ -184,10 +204,10 @@ var keywords = map[string]int{
 	"not":                 NOT,
 	"no_write_to_binlog":  UNUSED,
 	"null":                NULL,
-	"numeric":             UNUSED,
+	"numeric":             DATA_TYPE,
 	"offset":              OFFSET,
 	"on":                  ON,
-	"optimize":            UNUSED,
+	"optimize":            OPTIMIZE,
 	"optimizer_costs":     UNUSED,
 	"option":              UNUSED,
 	"optionally":          UNUSED,
@@ -196,17 +216,17 @@ var keywords = map[string]int{
 	"out":                 UNUSED,
 	"outer":               OUTER,
 	"outfile":             UNUSED,
-	"partition":           UNUSED,
-	"precision":           UNUSED,
-	"primary":             UNUSED,
+	"partition":           PARTITION,
+	"precision":           PRECISION,
+	"primary":             PRIMARY,
 	"procedure":           UNUSED,
 	"query":               QUERY,
 	"range":               UNUSED,
 	"read":                UNUSED,
 	"reads":               UNUSED,
 	"read_write":          UNUSED,
-	"real":                UNUSED,
-	"references":          UNUSED,
+	"real":                DATA_TYPE,
+	"references":          REFERENCES,
 	"regexp":              REGEXP,
 	"release":             UNUSED,
 	"rename":              RENAME,
@@ -214,7 +234,7 @@ var keywords = map[string]int{
 	"replace":             REPLACE,
 	"require":             UNUSED,
 	"resignal":            UNUSED,
-	"restrict":            UNUSED,
+	"restrict":            RESTRICT,
 	"return":              UNUSED,
 	"revoke":              UNUSED,
 	"right":               RIGHT,
@@ -229,8 +249,8 @@ var keywords = map[string]int{
 	"share":               SHARE,
 	"show":                SHOW,
 	"signal":              UNUSED,
-	"smallint":            UNUSED,
-	"spatial":             UNUSED,
+	"smallint":            DATA_TYPE,
+	"spatial":             SPATIAL,
 	"specific":            UNUSED,
 	"sql":                 UNUSED,
 	"sqlexception":        UNUSED,
@@ -243,14 +263,14 @@ var keywords = map[string]int{
 	"sql_small_result":    UNUSED,
 	"ssl":                 UNUSED,
 	"starting":            UNUSED,
-	"stored":              UNUSED,
+	"stored":              STORED,
 	"straight_join":       STRAIGHT_JOIN,
 	"table":               TABLE,
 	"terminated":          UNUSED,
 	"then":                THEN,
-	"tinyblob":            UNUSED,
-	"tinyint":             UNUSED,
-	"tinytext":            UNUSED,
+	"tinyblob":            DATA_TYPE,
+	"tinyint":             DATA_TYPE,
+	"tinytext":            DATA_TYPE,
 	"to":                  TO,
 	"trailing":            UNUSED,
 	"trigger":             UNUSED,
@@ -267,11 +287,11 @@ var keywords = map[string]int{
 	"utc_time":            UTC_TIME,
 	"utc_timestamp":       UTC_TIMESTAMP,
 	"values":              VALUES,
-	"varbinary":           UNUSED,
-	"varchar":             UNUSED,
-	"varcharacter":        UNUSED,
+	"varbinary":           DATA_TYPE,
+	"varchar":             DATA_TYPE,
+	"varcharacter":        DATA_TYPE,
 	"varying":             UNUSED,
-	"virtual":             UNUSED,
+	"virtual":             VIRTUAL,
 	"view":                VIEW,
 	"when":                WHEN,
 	"where":               WHERE,
@@ -280,7 +300,36 @@ var keywords = map[string]int{
 	"write":               UNUSED,
 	"xor":                 UNUSED,
 	"year_month":          UNUSED,
-	"zerofill":            UNUSED,
+	"zerofill":            ZEROFILL,
+}
+
+// ddlKeywords are the keywords of the CREATE TABLE and ALTER
+// TABLE statements that MySQL doesn't reserve. They are only
+// recognized in those statements, where the grammar still
+// accepts them as names.
+var ddlKeywords = map[string]int{
+	"action":         ACTION,
+	"after":          AFTER,
+	"always":         ALWAYS,
+	"auto_increment": AUTO_INCREMENT,
+	"charset":        CHARSET,
+	"comment":        COMMENT_KEYWORD,
+	"directory":      DIRECTORY,
+	"disable":        DISABLE,
+	"discard":        DISCARD,
+	"enable":         ENABLE,
+	"enforced":       ENFORCED,
+	"first":          FIRST,
+	"import":         IMPORT,
+	"invisible":      INVISIBLE,
+	"key_block_size": KEY_BLOCK_SIZE,
+	"modify":         MODIFY,
+	"no":             NO,
+	"parser":         PARSER,
+	"partitioning":   PARTITIONING,
+	"signed":         SIGNED,
+	"unsigned":       UNSIGNED,
+	"visible":        VISIBLE,
 }
 
 // Lex returns the next token form the Tokenizer.
@@ -294,8 +343,13 @@ func (tkn *Tokenizer) Lex(lval *yySymType) int {
 		typ, val = tkn.Scan()
 	}
 	switch typ {
-	case ID, STRING, HEX, INTEGRAL, FLOAT, HEXNUM, VALUE_ARG, LIST_ARG, COMMENT:
+	case ID, STRING, HEX, INTEGRAL, FLOAT, HEXNUM, VALUE_ARG, LIST_ARG, COMMENT, BIT_LITERAL, DATA_TYPE:
 		lval.bytes = val
+	default:
+		// The DDL keywords can be used as names.
+		if _, ok := ddlKeywords[string(val)]; ok {
+			lval.bytes = val
+		}
 	}
 	tkn.lastToken = val
 	return typ
@@ -319,10 +373,77 @@ func (tkn *Tokenizer) Scan() (int, []byte) {
 		return 0, nil
 	}
 
+	if tkn.specialComment != nil {
+		if typ, val := tkn.specialComment.Scan(); typ != 0 {
+			return typ, val
+		}
+		tkn.specialComment = nil
+	}
+	typ, val := tkn.scan()
+	switch {
+	case typ == COMMENT:
+		// MySQL executes the content of the /*! ... */ comments,
+		// which mysqldump wraps around version-specific clauses.
+		if tkn.ddl && bytes.HasPrefix(val, []byte("/*!")) {
+			tkn.specialComment = newSpecialCommentTokenizer(val)
+			return tkn.Scan()
+		}
+		return typ, val
+	case typ == ';':
+		// A new statement starts.
+		tkn.started = false
+	case !tkn.started:
+		tkn.started = true
+		tkn.ddl = typ == CREATE || typ == ALTER
+	}
+	tkn.tokenStarts[1] = tkn.tokenStarts[0]
+	tkn.tokenStarts[0] = tkn.tokenStart
+	return typ, val
+}
+
+// newSpecialCommentTokenizer returns a Tokenizer for the
+// content of a /*!NNNNN ... */ comment, without its version.
+func newSpecialCommentTokenizer(comment []byte) *Tokenizer {
+	content := comment[len("/*!") : len(comment)-len("*/")]
+	for len(content) > 0 && isDigit(uint16(content[0])) {
+		content = content[1:]
+	}
+	tkn := NewStringTokenizer(string(content))
+	tkn.started = true
+	tkn.ddl = true
+	return tkn
+}
+
+// skipToEnd makes the tokenizer return EOF, and returns the
+// rest of the statement as written, from the start of the
+// n-th last token, and without a trailing semicolon.
+func (tkn *Tokenizer) skipToEnd(n int) string {
+	tkn.ForceEOF = true
+	rest := strings.TrimSpace(tkn.remainder(n))
+	return strings.TrimSpace(strings.TrimSuffix(rest, ";"))
+}
+
+// remainder returns the text from the start of the
+// n-th last token to the end of the statement.
+func (tkn *Tokenizer) remainder(n int) string {
+	if tkn.specialComment == nil {
+		return tkn.sql[tkn.tokenStarts[n-1]:]
+	}
+	// The rest of the comment, then the text after it,
+	// which starts at the last char read.
+	rest := tkn.specialComment.remainder(n)
+	if tkn.lastChar == eofChar {
+		return rest
+	}
+	return rest + tkn.sql[tkn.Position-1:]
+}
+
+func (tkn *Tokenizer) scan() (int, []byte) {
 	if tkn.lastChar == 0 {
 		tkn.next()
 	}
 	tkn.skipBlank()
+	tkn.tokenStart = tkn.Position - 1
 	switch ch := tkn.lastChar; {
 	case isLetter(ch):
 		tkn.next()
@@ -332,6 +453,12 @@ func (tkn *Tokenizer) Scan() (int, []byte) {
 				return tkn.scanHex()
 			}
 		}
+		if (ch == 'B' || ch == 'b') && tkn.ddl {
+			if tkn.lastChar == '\'' {
+				tkn.next()
+				return tkn.scanBitLiteral()
+			}
+		}
 		return tkn.scanIdentifier(byte(ch))
 	case isDigit(ch):
 		return tkn.scanNumber(false)
@@ -452,6 +579,9 @@ func (tkn *Tokenizer) scanIdentifier(firstByte byte) (int, []byte) {
 	if keywordID, found := keywords[loweredStr]; found {
 		return keywordID, lowered
 	}
+	if keywordID, found := ddlKeywords[loweredStr]; found && tkn.ddl {
+		return keywordID, lowered
+	}
 	// dual must always be case-insensitive
 	if loweredStr == "dual" {
 		return ID, lowered
@@ -472,6 +602,18 @@ func (tkn *Tokenizer) scanHex() (int, []byte) {
 	return HEX, buffer.Bytes()
 }
 
+func (tkn *Tokenizer) scanBitLiteral() (int, []byte) {
+	buffer := &bytes.Buffer{}
+	for tkn.lastChar == '0' || tkn.lastChar == '1' {
+		tkn.consumeNext(buffer)
+	}
+	if tkn.lastChar != '\'' {
+		return LEX_ERROR, buffer.Bytes()
+	}
+	tkn.next()
+	return BIT_LITERAL, buffer.Bytes()
+}
+
 func (tkn *Tokenizer) scanLiteralIdentifier() (int, []byte) {
 	buffer := &bytes.Buffer{}
 	backTickSeen := false
@@ -46,7 +46,7 @@ func (client *QueryClient) Begin() error {
 	if client.transactionID != 0 {
 		return errors.New("already in transaction")
 	}
-	transactionID, err := client.server.Begin(client.ctx, &client.target)
+	transactionID, err := client.server.Begin(client.ctx, &client.target, nil)
 	if err != nil {
 		return err
 	}
@@ -57,7 +57,8 @@ func (client *QueryClient) Begin() error {
 // Commit commits the current transaction.
 func (client *QueryClient) Commit() error {
 	defer func() { client.transactionID = 0 }()
-	return client.server.Commit(client.ctx, &client.target, client.transactionID)
+	_, err := client.server.Commit(client.ctx, &client.target, client.transactionID)
+	return err
 }
 
 // Rollback rolls back the current transaction.
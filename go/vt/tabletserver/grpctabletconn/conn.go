@@ -199,7 +199,7 @@ func (conn *gRPCQueryClient) StreamExecute(ctx context.Context, target *querypb.
 }
 
 // Begin starts a transaction.
-func (conn *gRPCQueryClient) Begin(ctx context.Context, target *querypb.Target) (transactionID int64, err error) {
+func (conn *gRPCQueryClient) Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (transactionID int64, err error) {
 	conn.mu.RLock()
 	defer conn.mu.RUnlock()
 	if conn.cc == nil {
@@ -210,6 +210,7 @@ func (conn *gRPCQueryClient) Begin(ctx context.Context, target *querypb.Target)
 		Target:            target,
 		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
 		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Options:           options,
 	}
 	br, err := conn.c.Begin(ctx, req)
 	if err != nil {
@@ -219,11 +220,11 @@ func (conn *gRPCQueryClient) Begin(ctx context.Context, target *querypb.Target)
 }
 
 // Commit commits the ongoing transaction.
-func (conn *gRPCQueryClient) Commit(ctx context.Context, target *querypb.Target, transactionID int64) error {
+func (conn *gRPCQueryClient) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (string, error) {
 	conn.mu.RLock()
 	defer conn.mu.RUnlock()
 	if conn.cc == nil {
-		return tabletconn.ConnClosed
+		return "", tabletconn.ConnClosed
 	}
 
 	req := &querypb.CommitRequest{
@@ -232,11 +233,11 @@ func (conn *gRPCQueryClient) Commit(ctx context.Context, target *querypb.Target,
 		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
 		TransactionId:     transactionID,
 	}
-	_, err := conn.c.Commit(ctx, req)
+	response, err := conn.c.Commit(ctx, req)
 	if err != nil {
-		return tabletconn.TabletErrorFromGRPC(err)
+		return "", tabletconn.TabletErrorFromGRPC(err)
 	}
-	return nil
+	return response.Position, nil
 }
 
 // Rollback rolls back the ongoing transaction.
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	log "github.com/golang/glog"
+	"github.com/gitql/vitess/go/sqltypes"
 	"github.com/gitql/vitess/go/vt/schema"
 	"github.com/gitql/vitess/go/vt/sqlparser"
 )
@@ -152,10 +153,78 @@ func analyzeSelect(sel *sqlparser.Select, getTable TableGetter) (plan *ExecPlan,
 		plan.PKValues = []interface{}{v}
 		plan.FieldQuery = nil
 		plan.FullQuery = nil
+		return plan, nil
+	}
+
+	if plan.PlanID == PlanPassSelect && tableInfo.RowCache {
+		analyzeRowCacheSelect(plan, sel, tableInfo)
 	}
 	return plan, nil
 }
 
+// analyzeRowCacheSelect sets PKValues, ColumnNumbers and OuterQuery
+// if sel reads a single row of a row cache table by its primary key,
+// and only returns plain columns of that row. The row cache is only
+// used for integral primary keys: their values can be compared
+// byte for byte, unlike strings which depend on the collation.
+func analyzeRowCacheSelect(plan *ExecPlan, sel *sqlparser.Select, tableInfo *schema.Table) {
+	if sel.Distinct != "" || sel.Where == nil || sel.GroupBy != nil || sel.Having != nil || sel.OrderBy != nil || sel.Limit != nil {
+		return
+	}
+	if len(tableInfo.PKColumns) == 0 {
+		return
+	}
+	for _, col := range tableInfo.PKColumns {
+		if !sqltypes.IsIntegral(tableInfo.Columns[col].Type) {
+			return
+		}
+	}
+	columnNumbers := analyzeSelectColumns(sel.SelectExprs, tableInfo)
+	if columnNumbers == nil {
+		return
+	}
+	conditions := analyzeBoolean(sel.Where.Expr)
+	for _, condition := range conditions {
+		if condition.Operator != sqlparser.EqualStr {
+			return
+		}
+	}
+	pkValues := getPKValues(conditions, tableInfo.Indexes[0])
+	if pkValues == nil {
+		return
+	}
+	plan.PKValues = pkValues
+	plan.ColumnNumbers = columnNumbers
+	plan.OuterQuery = GenerateRowCacheQuery(tableInfo)
+}
+
+// analyzeSelectColumns returns the column numbers of the select
+// expressions, or nil if they're not all columns of the table.
+func analyzeSelectColumns(exprs sqlparser.SelectExprs, tableInfo *schema.Table) []int {
+	var columnNumbers []int
+	for _, expr := range exprs {
+		switch expr := expr.(type) {
+		case *sqlparser.StarExpr:
+			for i := range tableInfo.Columns {
+				columnNumbers = append(columnNumbers, i)
+			}
+		case *sqlparser.NonStarExpr:
+			col, ok := expr.Expr.(*sqlparser.ColName)
+			if !ok {
+				return nil
+			}
+			i := tableInfo.FindColumn(col.Name)
+			if i == -1 {
+				return nil
+			}
+			columnNumbers = append(columnNumbers, i)
+		default:
+			return nil
+		}
+	}
+	return columnNumbers
+}
+
 func analyzeFrom(tableExprs sqlparser.TableExprs) sqlparser.TableIdent {
 	if len(tableExprs) > 1 {
 		return sqlparser.NewTableIdent("")
@@ -58,6 +58,9 @@ const (
 	PlanSelectStream
 	// PlanOther is for SHOW, DESCRIBE & EXPLAIN statements
 	PlanOther
+	// PlanSavepoint is for SAVEPOINT, ROLLBACK TO and RELEASE
+	// statements. They're only allowed inside a transaction.
+	PlanSavepoint
 	// NumPlans stores the total number of plans
 	NumPlans
 )
@@ -78,6 +81,7 @@ var planName = []string{
 	"DDL",
 	"SELECT_STREAM",
 	"OTHER",
+	"SAVEPOINT",
 }
 
 func (pt PlanType) String() string {
@@ -126,6 +130,7 @@ var tableACLRoles = map[PlanType]tableacl.Role{
 	PlanDDL:            tableacl.ADMIN,
 	PlanSelectStream:   tableacl.READER,
 	PlanOther:          tableacl.ADMIN,
+	PlanSavepoint:      tableacl.READER,
 	PlanUpsertPK:       tableacl.WRITER,
 	PlanNextval:        tableacl.WRITER,
 }
@@ -202,11 +207,13 @@ type ExecPlan struct {
 	UpsertQuery *sqlparser.ParsedQuery `json:",omitempty"`
 
 	// PlanInsertSubquery: columns to be inserted.
+	// PlanPassSelect: selected columns, if the row cache can be used.
 	ColumnNumbers []int `json:",omitempty"`
 
 	// PlanDMLPK: where clause values.
 	// PlanInsertPK: values clause.
 	// PlanNextVal: increment.
+	// PlanPassSelect: where clause values, if the row cache can be used.
 	PKValues []interface{} `json:",omitempty"`
 
 	// For update: set clause if pk is changing.
@@ -258,6 +265,11 @@ func GetExecPlan(sql string, getTable TableGetter) (plan *ExecPlan, err error) {
 		return analyzeDDL(stmt, getTable), nil
 	case *sqlparser.Other:
 		return &ExecPlan{PlanID: PlanOther}, nil
+	case *sqlparser.Savepoint:
+		return &ExecPlan{
+			PlanID:    PlanSavepoint,
+			FullQuery: GenerateFullQuery(stmt),
+		}, nil
 	}
 	return nil, errors.New("invalid SQL")
 }
@@ -65,6 +65,20 @@ func GenerateLoadMessagesQuery(ins *sqlparser.Insert) *sqlparser.ParsedQuery {
 	return buf.ParsedQuery()
 }
 
+// GenerateRowCacheQuery generates the query that reads full rows
+// of a table by primary key, to fill the row cache.
+func GenerateRowCacheQuery(tableInfo *schema.Table) *sqlparser.ParsedQuery {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.WriteString("select ")
+	prefix := ""
+	for _, col := range tableInfo.Columns {
+		buf.Myprintf("%s%v", prefix, col.Name)
+		prefix = ", "
+	}
+	buf.Myprintf(" from %v where %a", tableInfo.Name, ":#pk")
+	return buf.ParsedQuery()
+}
+
 // GenerateUpdateOuterQuery generates the outer query for updates.
 func GenerateUpdateOuterQuery(upd *sqlparser.Update) *sqlparser.ParsedQuery {
 	buf := sqlparser.NewTrackedBuffer(nil)
@@ -44,6 +44,8 @@ type QueryEngine struct {
 	// Services
 	consolidator *sync2.Consolidator
 	streamQList  *QueryList
+	queryList    *QueryList
+	rowCache     *RowCache
 
 	// Vars
 	strictMode       sync2.AtomicInt64
@@ -100,6 +102,8 @@ func NewQueryEngine(checker MySQLChecker) *QueryEngine {
 	qe.consolidator = sync2.NewConsolidator()
 	http.Handle(tabletenv.Config.DebugURLPrefix+"/consolidations", qe.consolidator)
 	qe.streamQList = NewQueryList()
+	qe.queryList = NewQueryList()
+	qe.rowCache = NewRowCache()
 
 	if tabletenv.Config.StrictMode {
 		qe.strictMode.Set(1)
@@ -156,6 +160,10 @@ func (qe *QueryEngine) Open(dbconfigs dbconfigs.DBConfigs) error {
 
 	qe.conns.Open(&qe.dbconfigs.App, &qe.dbconfigs.Dba)
 	qe.streamConns.Open(&qe.dbconfigs.App, &qe.dbconfigs.Dba)
+	// The row cache is optional: queries can be served without it.
+	if err := qe.rowCache.Open(); err != nil {
+		log.Errorf("Row cache not available: %v", err)
+	}
 	return nil
 }
 
@@ -178,6 +186,7 @@ func (qe *QueryEngine) IsMySQLReachable() bool {
 // before calling Close.
 func (qe *QueryEngine) Close() {
 	// Close in reverse order of Open.
+	qe.rowCache.Close()
 	qe.streamConns.Close()
 	qe.conns.Close()
 	qe.schemaInfo.Close()
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	log "github.com/golang/glog"
 	"golang.org/x/net/context"
 
 	"github.com/gitql/vitess/go/hack"
@@ -39,6 +40,7 @@ type QueryExecutor struct {
 	qe            *QueryEngine
 	te            *TxEngine
 	messager      *MessagerEngine
+	budget        queryBudget
 }
 
 var sequenceFields = []*querypb.Field{
@@ -108,6 +110,10 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 			return qre.execUpsertPK(conn)
 		case planbuilder.PlanSet:
 			return qre.txFetch(conn, qre.plan.FullQuery, qre.bindVars, nil, false, true)
+		case planbuilder.PlanSavepoint:
+			// Savepoints are recorded with the other statements,
+			// so a prepared transaction can be replayed.
+			return qre.txFetch(conn, qre.plan.FullQuery, qre.bindVars, nil, false, true)
 		default:
 			return qre.execDirect(conn)
 		}
@@ -115,7 +121,7 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		switch qre.plan.PlanID {
 		case planbuilder.PlanPassSelect:
 			return qre.execSelect()
-		case planbuilder.PlanSelectLock:
+		case planbuilder.PlanSelectLock, planbuilder.PlanSavepoint:
 			return nil, tabletenv.NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "Disallowed outside transaction")
 		case planbuilder.PlanSet:
 			return qre.execSet()
@@ -137,6 +143,20 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 
 // Stream performs a streaming query execution.
 func (qre *QueryExecutor) Stream(includedFields querypb.ExecuteOptions_IncludedFields, callback func(*sqltypes.Result) error) error {
+	return qre.stream(nil, includedFields, callback)
+}
+
+// StreamSnapshot is like Stream, but the query reads a consistent
+// snapshot of its table. The table is locked for writes while the
+// snapshot is started, and the replication position returned by
+// position at that time is sent in the extras of the first result.
+// The snapshot transaction runs on the stream connection: it does not
+// use the transaction pool, and it is rolled back at the end.
+func (qre *QueryExecutor) StreamSnapshot(position func() (string, error), includedFields querypb.ExecuteOptions_IncludedFields, callback func(*sqltypes.Result) error) error {
+	return qre.stream(position, includedFields, callback)
+}
+
+func (qre *QueryExecutor) stream(position func() (string, error), includedFields querypb.ExecuteOptions_IncludedFields, callback func(*sqltypes.Result) error) error {
 	qre.logStats.OriginalSQL = qre.query
 	qre.logStats.PlanType = qre.plan.PlanID.String()
 
@@ -148,6 +168,9 @@ func (qre *QueryExecutor) Stream(includedFields querypb.ExecuteOptions_IncludedF
 	if err := qre.checkPermissions(); err != nil {
 		return err
 	}
+	if position != nil && qre.plan.TableName.IsEmpty() {
+		return tabletenv.NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "a consistent snapshot can only be streamed from a single table")
+	}
 
 	conn, err := qre.getConn(qre.qe.streamConns)
 	if err != nil {
@@ -155,11 +178,86 @@ func (qre *QueryExecutor) Stream(includedFields querypb.ExecuteOptions_IncludedF
 	}
 	defer conn.Recycle()
 
+	if position != nil {
+		defer qre.endSnapshot(conn)
+		pos, err := qre.startSnapshot(conn, position)
+		if err != nil {
+			return err
+		}
+		callback = addSnapshotPosition(pos, callback)
+	}
+
 	qd := NewQueryDetail(qre.logStats.Ctx, conn)
+	if qre.budget.limit != 0 {
+		var cancel context.CancelFunc
+		qre.ctx, cancel = context.WithCancel(qre.ctx)
+		defer cancel()
+		qd.setBudget(qre.budget, cancel)
+	}
 	qre.qe.streamQList.Add(qd)
-	defer qre.qe.streamQList.Remove(qd)
+	if position != nil {
+		// A reconnect would silently leave the snapshot: don't retry.
+		err = qre.streamFetchOnce(conn, qre.plan.FullQuery, qre.bindVars, includedFields, callback)
+	} else {
+		err = qre.streamFetch(conn, qre.plan.FullQuery, qre.bindVars, nil, includedFields, callback)
+	}
+	qre.qe.streamQList.Remove(qd)
+	if qd.BudgetExceeded() {
+		return qre.budgetExceeded()
+	}
+	return err
+}
+
+// startSnapshot starts a consistent snapshot transaction on conn, and
+// returns the replication position it was taken at. The table is locked
+// on another connection meanwhile, so no write to it can happen between
+// the snapshot and the position.
+func (qre *QueryExecutor) startSnapshot(conn *connpool.DBConn, position func() (string, error)) (string, error) {
+	lockConn, err := qre.getConn(qre.qe.conns)
+	if err != nil {
+		return "", err
+	}
+	defer lockConn.Recycle()
+	if _, err := qre.execSQL(lockConn, fmt.Sprintf("lock tables %s read", sqlparser.String(qre.plan.TableName)), false); err != nil {
+		return "", err
+	}
+	defer func() {
+		if _, err := lockConn.ExecOnce(qre.ctx, "unlock tables", 1, false); err != nil {
+			// A closed connection releases its locks.
+			log.Warningf("Cannot unlock table %v, closing the connection: %v", qre.plan.TableName, err)
+			lockConn.Close()
+		}
+	}()
+
+	if _, err := conn.ExecOnce(qre.ctx, "start transaction with consistent snapshot, read only", 1, false); err != nil {
+		return "", tabletenv.NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err)
+	}
+	return position()
+}
+
+// endSnapshot rolls back the snapshot transaction of conn. The
+// connection is closed if that fails, so the transaction doesn't
+// leak to its next user.
+func (qre *QueryExecutor) endSnapshot(conn *connpool.DBConn) {
+	if _, err := conn.ExecOnce(qre.ctx, "rollback", 1, false); err != nil {
+		conn.Close()
+	}
+}
 
-	return qre.streamFetch(conn, qre.plan.FullQuery, qre.bindVars, nil, includedFields, callback)
+// addSnapshotPosition returns a callback that adds the snapshot
+// position to the extras of the first result.
+func addSnapshotPosition(position string, callback func(*sqltypes.Result) error) func(*sqltypes.Result) error {
+	sent := false
+	return func(result *sqltypes.Result) error {
+		if !sent {
+			sent = true
+			if result.Extras == nil {
+				result.Extras = &querypb.ResultExtras{}
+			}
+			result.Extras.Position = position
+		}
+		return callback(result)
+	}
 }
 
 func (qre *QueryExecutor) execDmlAutoCommit() (reply *sqltypes.Result, err error) {
@@ -212,6 +310,32 @@ func (qre *QueryExecutor) execAsTransaction(f func(conn *TxConnection) (*sqltype
 	return reply, nil
 }
 
+// getBudget returns the execution time budget of the query. A budget
+// set by a query rule takes precedence over the one of the table.
+func (qre *QueryExecutor) getBudget() queryBudget {
+	remoteAddr := ""
+	username := ""
+	ci, ok := callinfo.FromContext(qre.ctx)
+	if ok {
+		remoteAddr = ci.RemoteAddr()
+		username = ci.Username()
+	}
+	if limit, name := qre.plan.Rules.getBudget(remoteAddr, username, qre.bindVars); limit != 0 {
+		return queryBudget{limit: limit, source: "rule " + name}
+	}
+	if qre.plan.TableInfo != nil && qre.plan.TableInfo.QueryBudget != 0 {
+		return queryBudget{limit: qre.plan.TableInfo.QueryBudget, source: "table " + qre.plan.TableInfo.Name.String()}
+	}
+	return queryBudget{}
+}
+
+// budgetExceeded records that the query was killed for exceeding
+// its budget, and returns the corresponding error.
+func (qre *QueryExecutor) budgetExceeded() error {
+	qre.logStats.BudgetExceeded = qre.budget.String()
+	return tabletenv.NewTabletError(vtrpcpb.ErrorCode_DEADLINE_EXCEEDED, "query exceeded its budget of %v", qre.budget)
+}
+
 // checkPermissions
 func (qre *QueryExecutor) checkPermissions() error {
 	// Skip permissions check if the context is local.
@@ -397,6 +521,9 @@ func (qre *QueryExecutor) execDirect(conn *TxConnection) (*sqltypes.Result, erro
 // execSelect sends a query to mysql only if another identical query is not running. Otherwise, it waits and
 // reuses the result. If the plan is missng field info, it sends the query to mysql requesting full info.
 func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
+	if qre.plan.Fields != nil && qre.plan.OuterQuery != nil && qre.qe.rowCache.IsOpen() {
+		return qre.execRowCacheSelect()
+	}
 	if qre.plan.Fields != nil {
 		result, err := qre.qFetch(qre.logStats, qre.plan.FullQuery, qre.bindVars)
 		if err != nil {
@@ -415,6 +542,48 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 	return qre.dbConnFetch(conn, qre.plan.FullQuery, qre.bindVars, nil, true)
 }
 
+// execRowCacheSelect serves a primary key point select from the row cache.
+// On a miss, it reads the full row from mysql and caches it.
+func (qre *QueryExecutor) execRowCacheSelect() (*sqltypes.Result, error) {
+	tableInfo := qre.plan.TableInfo
+	pkRows, err := buildValueList(tableInfo, qre.plan.PKValues, qre.bindVars)
+	if err != nil {
+		return nil, err
+	}
+	rowCache := qre.qe.rowCache
+	row := rowCache.Get(tableInfo, pkRows[0])
+	if row != nil {
+		qre.logStats.QuerySources |= tabletenv.QuerySourceRowCache
+	} else {
+		generation := rowCache.Generation(tableInfo)
+		bindVars := map[string]interface{}{
+			"#pk": sqlparser.TupleEqualityList{
+				Columns: tableInfo.Indexes[0].Columns,
+				Rows:    pkRows,
+			},
+		}
+		// result is read-only, like for execSelect.
+		result, err := qre.qFetch(qre.logStats, qre.plan.OuterQuery, bindVars)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Rows) == 0 {
+			return &sqltypes.Result{Fields: qre.plan.Fields}, nil
+		}
+		row = result.Rows[0]
+		rowCache.Set(tableInfo, pkRows[0], generation, row)
+	}
+	values := make([]sqltypes.Value, len(qre.plan.ColumnNumbers))
+	for i, col := range qre.plan.ColumnNumbers {
+		values[i] = row[col]
+	}
+	return &sqltypes.Result{
+		Fields:       qre.plan.Fields,
+		Rows:         [][]sqltypes.Value{values},
+		RowsAffected: 1,
+	}, nil
+}
+
 func (qre *QueryExecutor) execInsertPK(conn *TxConnection) (*sqltypes.Result, error) {
 	pkRows, err := buildValueList(qre.plan.TableInfo, qre.plan.PKValues, qre.bindVars)
 	if err != nil {
@@ -668,6 +837,20 @@ func (qre *QueryExecutor) streamFetch(conn *connpool.DBConn, parsedQuery *sqlpar
 	return qre.execStreamSQL(conn, sql, includedFields, callback)
 }
 
+func (qre *QueryExecutor) streamFetchOnce(conn *connpool.DBConn, parsedQuery *sqlparser.ParsedQuery, bindVars map[string]interface{}, includedFields querypb.ExecuteOptions_IncludedFields, callback func(*sqltypes.Result) error) error {
+	sql, err := qre.generateFinalSQL(parsedQuery, bindVars, nil)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = conn.StreamOnce(qre.ctx, sql, callback, int(qre.qe.streamBufferSize.Get()), includedFields)
+	qre.logStats.AddRewrittenSQL(sql, start)
+	if err != nil {
+		return tabletenv.NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err)
+	}
+	return nil
+}
+
 func (qre *QueryExecutor) generateFinalSQL(parsedQuery *sqlparser.ParsedQuery, bindVars map[string]interface{}, buildStreamComment []byte) (string, error) {
 	bindVars["#maxLimit"] = qre.qe.maxResultSize.Get() + 1
 	sql, err := parsedQuery.GenerateQuery(bindVars)
@@ -688,7 +871,22 @@ type poolConn interface {
 
 func (qre *QueryExecutor) execSQL(conn poolConn, sql string, wantfields bool) (*sqltypes.Result, error) {
 	defer qre.logStats.AddRewrittenSQL(sql, time.Now())
-	return conn.Exec(qre.ctx, sql, int(qre.qe.maxResultSize.Get()), wantfields)
+	kconn, ok := conn.(killable)
+	if !ok || qre.budget.limit == 0 {
+		return conn.Exec(qre.ctx, sql, int(qre.qe.maxResultSize.Get()), wantfields)
+	}
+
+	ctx, cancel := context.WithCancel(qre.ctx)
+	defer cancel()
+	qd := NewQueryDetail(qre.logStats.Ctx, kconn)
+	qd.setBudget(qre.budget, cancel)
+	qre.qe.queryList.Add(qd)
+	result, err := conn.Exec(ctx, sql, int(qre.qe.maxResultSize.Get()), wantfields)
+	qre.qe.queryList.Remove(qd)
+	if qd.BudgetExceeded() {
+		return nil, qre.budgetExceeded()
+	}
+	return result, err
 }
 
 func (qre *QueryExecutor) execStreamSQL(conn *connpool.DBConn, sql string, includedFields querypb.ExecuteOptions_IncludedFields, callback func(*sqltypes.Result) error) error {
@@ -21,6 +21,7 @@ import (
 	"github.com/gitql/vitess/go/vt/callinfo"
 	"github.com/gitql/vitess/go/vt/callinfo/fakecallinfo"
 	"github.com/gitql/vitess/go/vt/schema"
+	"github.com/gitql/vitess/go/vt/sqlparser"
 	"github.com/gitql/vitess/go/vt/tableacl"
 	"github.com/gitql/vitess/go/vt/tableacl/simpleacl"
 	"github.com/gitql/vitess/go/vt/tabletserver/planbuilder"
@@ -727,6 +728,56 @@ func TestQueryExecutorPlanPassSelect(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorPlanPassSelectRowCache(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	rowQuery := "select pk, name, addr from test_table where pk in (1)"
+	db.AddQuery(rowQuery, &sqltypes.Result{
+		Fields:       getTestTableFields(),
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
+		}},
+	})
+	db.AddQuery("select addr, pk from test_table where 1 != 1", &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "addr", Type: sqltypes.Int32},
+			{Name: "pk", Type: sqltypes.Int32},
+		},
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableStrict, db)
+	defer tsv.StopService()
+	tsv.qe.schemaInfo.GetTable(sqlparser.NewTableIdent("test_table")).RowCache = true
+	rowCache, _ := newTestRowCache(t)
+	tsv.qe.rowCache = rowCache
+
+	query := "select addr, pk from test_table where pk = 1"
+	wantRows := [][]sqltypes.Value{{
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+	}}
+	for i := 0; i < 2; i++ {
+		qre := newTestQueryExecutor(ctx, tsv, query, 0)
+		checkPlanID(t, planbuilder.PlanPassSelect, qre.plan.PlanID)
+		got, err := qre.Execute()
+		if err != nil {
+			t.Fatalf("qre.Execute() = %v, want nil", err)
+		}
+		if !reflect.DeepEqual(got.Rows, wantRows) {
+			t.Errorf("Execute %d: %v, want %v", i, got.Rows, wantRows)
+		}
+		if i == 1 && qre.logStats.QuerySources&tabletenv.QuerySourceRowCache == 0 {
+			t.Errorf("QuerySources: %v, want rowcache", qre.logStats.FmtQuerySources())
+		}
+	}
+	if n := db.GetQueryCalledNum(rowQuery); n != 1 {
+		t.Errorf("GetQueryCalledNum(%s): %d, want 1", rowQuery, n)
+	}
+}
+
 func TestQueryExecutorPlanSet(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -1352,7 +1403,7 @@ func newTestTabletServer(ctx context.Context, flags executorFlags, db *fakesqldb
 }
 
 func newTransaction(tsv *TabletServer) int64 {
-	transactionID, err := tsv.Begin(context.Background(), &tsv.target)
+	transactionID, err := tsv.Begin(context.Background(), &tsv.target, nil)
 	if err != nil {
 		panic(fmt.Errorf("failed to start a transaction: %v", err))
 	}
@@ -1379,7 +1430,7 @@ func newTestQueryExecutor(ctx context.Context, tsv *TabletServer, sql string, tx
 }
 
 func testCommitHelper(t *testing.T, tsv *TabletServer, queryExecutor *QueryExecutor) {
-	if err := tsv.Commit(queryExecutor.ctx, &tsv.target, queryExecutor.transactionID); err != nil {
+	if _, err := tsv.Commit(queryExecutor.ctx, &tsv.target, queryExecutor.transactionID); err != nil {
 		t.Fatalf("failed to commit transaction: %d, err: %v", queryExecutor.transactionID, err)
 	}
 }
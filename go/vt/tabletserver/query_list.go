@@ -7,7 +7,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gitql/vitess/go/sync2"
 	"github.com/gitql/vitess/go/vt/callinfo"
+	"github.com/gitql/vitess/go/vt/tabletserver/tabletenv"
 	"golang.org/x/net/context"
 )
 
@@ -17,6 +19,25 @@ type QueryDetail struct {
 	conn   killable
 	connID int64
 	start  time.Time
+
+	// budget, if set, is enforced by the QueryList
+	// the QueryDetail is added to.
+	budget queryBudget
+	cancel context.CancelFunc
+	timer  *time.Timer
+	// exceeded is 1 once the query was killed for exceeding its budget.
+	exceeded sync2.AtomicInt32
+}
+
+// queryBudget is an execution time budget, along with
+// the query rule or table that imposes it.
+type queryBudget struct {
+	limit  time.Duration
+	source string
+}
+
+func (qb queryBudget) String() string {
+	return fmt.Sprintf("%v (%s)", qb.limit, qb.source)
 }
 
 type killable interface {
@@ -30,6 +51,20 @@ func NewQueryDetail(ctx context.Context, conn killable) *QueryDetail {
 	return &QueryDetail{ctx: ctx, conn: conn, connID: conn.ID(), start: time.Now()}
 }
 
+// setBudget makes the query subject to the budget. If the query
+// exceeds it, the connection is killed and cancel is called, which
+// prevents the query from being retried on a new connection.
+func (qd *QueryDetail) setBudget(budget queryBudget, cancel context.CancelFunc) {
+	qd.budget = budget
+	qd.cancel = cancel
+}
+
+// BudgetExceeded returns true if the query was killed
+// for exceeding its budget.
+func (qd *QueryDetail) BudgetExceeded() bool {
+	return qd.exceeded.Get() == 1
+}
+
 // QueryList holds a thread safe list of QueryDetails
 type QueryList struct {
 	mu           sync.Mutex
@@ -46,15 +81,41 @@ func (ql *QueryList) Add(qd *QueryDetail) {
 	ql.mu.Lock()
 	defer ql.mu.Unlock()
 	ql.queryDetails[qd.connID] = qd
+	if qd.budget.limit != 0 {
+		qd.timer = time.AfterFunc(qd.budget.limit, func() {
+			ql.killOverBudget(qd)
+		})
+	}
 }
 
 // Remove removes a QueryDetail from QueryList
 func (ql *QueryList) Remove(qd *QueryDetail) {
 	ql.mu.Lock()
 	defer ql.mu.Unlock()
+	if qd.timer != nil {
+		qd.timer.Stop()
+	}
 	delete(ql.queryDetails, qd.connID)
 }
 
+// killOverBudget kills the query of qd if it's still running.
+// The kill is issued after releasing the lock, because it
+// talks to MySQL and must not block Add and Remove.
+func (ql *QueryList) killOverBudget(qd *QueryDetail) {
+	ql.mu.Lock()
+	running := ql.queryDetails[qd.connID] == qd
+	ql.mu.Unlock()
+	if !running {
+		return
+	}
+	qd.exceeded.Set(1)
+	tabletenv.BudgetKills.Add(qd.budget.source, 1)
+	qd.conn.Kill(fmt.Sprintf("QueryList budget %v", qd.budget))
+	if qd.cancel != nil {
+		qd.cancel()
+	}
+}
+
 // Terminate updates the query status and kills the connection
 func (ql *QueryList) Terminate(connID int64) error {
 	ql.mu.Lock()
@@ -84,6 +145,7 @@ type QueryDetailzRow struct {
 	Duration          time.Duration
 	ConnID            int64
 	State             string
+	Budget            string
 	ShowTerminateLink bool
 }
 
@@ -105,6 +167,9 @@ func (ql *QueryList) GetQueryzRows() []QueryDetailzRow {
 			Duration:    time.Now().Sub(qd.start),
 			ConnID:      qd.connID,
 		}
+		if qd.budget.limit != 0 {
+			row.Budget = qd.budget.String()
+		}
 		rows = append(rows, row)
 	}
 	ql.mu.Unlock()
@@ -2,14 +2,16 @@ package tabletserver
 
 import (
 	"testing"
+	"time"
 
+	"github.com/gitql/vitess/go/sync2"
 	"golang.org/x/net/context"
 )
 
 type testConn struct {
 	id     int64
 	query  string
-	killed bool
+	killed sync2.AtomicInt32
 }
 
 func (tc *testConn) Current() string { return tc.query }
@@ -17,12 +19,12 @@ func (tc *testConn) Current() string { return tc.query }
 func (tc *testConn) ID() int64 { return tc.id }
 
 func (tc *testConn) Kill(string) error {
-	tc.killed = true
+	tc.killed.Set(1)
 	return nil
 }
 
 func (tc *testConn) IsKilled() bool {
-	return tc.killed
+	return tc.killed.Get() == 1
 }
 
 func TestQueryList(t *testing.T) {
@@ -49,3 +51,39 @@ func TestQueryList(t *testing.T) {
 		t.Errorf("failed to remove from QueryList")
 	}
 }
+
+func TestQueryListBudget(t *testing.T) {
+	ql := NewQueryList()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn := &testConn{id: 1}
+	qd := NewQueryDetail(ctx, conn)
+	qd.setBudget(queryBudget{limit: 10 * time.Millisecond, source: "table a"}, cancel)
+	ql.Add(qd)
+
+	rows := ql.GetQueryzRows()
+	if len(rows) != 1 || rows[0].Budget != "10ms (table a)" {
+		t.Errorf("wrong rows returned %v", rows)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("query was not killed after exceeding its budget")
+	}
+	if !conn.IsKilled() || !qd.BudgetExceeded() {
+		t.Errorf("IsKilled, BudgetExceeded: %v, %v, want true, true", conn.IsKilled(), qd.BudgetExceeded())
+	}
+	ql.Remove(qd)
+
+	// A query that completes within its budget must not be killed.
+	conn = &testConn{id: 2}
+	qd = NewQueryDetail(context.Background(), conn)
+	qd.setBudget(queryBudget{limit: 10 * time.Millisecond, source: "table a"}, nil)
+	ql.Add(qd)
+	ql.Remove(qd)
+	time.Sleep(20 * time.Millisecond)
+	if conn.IsKilled() || qd.BudgetExceeded() {
+		t.Errorf("IsKilled, BudgetExceeded: %v, %v, want false, false", conn.IsKilled(), qd.BudgetExceeded())
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/gitql/vitess/go/sqltypes"
 	"github.com/gitql/vitess/go/vt/key"
@@ -145,6 +146,18 @@ func (qrs *QueryRules) getAction(ip, user string, bindVars map[string]interface{
 	return QRContinue, ""
 }
 
+// getBudget returns the execution time budget of the first matching
+// rule that has one, along with the name of that rule. A zero budget
+// means that no rule imposes one.
+func (qrs *QueryRules) getBudget(ip, user string, bindVars map[string]interface{}) (budget time.Duration, name string) {
+	for _, qr := range qrs.rules {
+		if qr.budget != 0 && qr.matches(ip, user, bindVars) {
+			return qr.budget, qr.Name
+		}
+	}
+	return 0, ""
+}
+
 //-----------------------------------------------
 
 // QueryRule represents one rule (conditions-action).
@@ -175,6 +188,10 @@ type QueryRule struct {
 
 	// Action to be performed on trigger
 	act Action
+
+	// Execution time budget of the matching queries. Queries
+	// that run longer are killed by the query killer.
+	budget time.Duration
 }
 
 type namedRegexp struct {
@@ -202,6 +219,7 @@ func (qr *QueryRule) Copy() (newqr *QueryRule) {
 		user:        qr.user,
 		query:       qr.query,
 		act:         qr.act,
+		budget:      qr.budget,
 	}
 	if qr.plans != nil {
 		newqr.plans = make([]planbuilder.PlanType, len(qr.plans))
@@ -244,10 +262,19 @@ func (qr *QueryRule) MarshalJSON() ([]byte, error) {
 	if qr.act != QRContinue {
 		safeEncode(b, `,"Action":`, qr.act)
 	}
+	if qr.budget != 0 {
+		safeEncode(b, `,"Budget":`, qr.budget.String())
+	}
 	_, _ = b.WriteString("}")
 	return b.Bytes(), nil
 }
 
+// SetBudget sets the execution time budget for the queries
+// matched by the rule.
+func (qr *QueryRule) SetBudget(budget time.Duration) {
+	qr.budget = budget
+}
+
 // SetIPCond adds a regular expression condition for the client IP.
 // It has to be a full match (not substring).
 func (qr *QueryRule) SetIPCond(pattern string) (err error) {
@@ -377,18 +404,27 @@ func (qr *QueryRule) filterByPlan(query string, planid planbuilder.PlanType, tab
 }
 
 func (qr *QueryRule) getAction(ip, user string, bindVars map[string]interface{}) Action {
-	if !reMatch(qr.requestIP.Regexp, ip) {
+	if !qr.matches(ip, user, bindVars) {
 		return QRContinue
 	}
+	return qr.act
+}
+
+// matches returns true if the request specific conditions
+// of the rule are satisfied.
+func (qr *QueryRule) matches(ip, user string, bindVars map[string]interface{}) bool {
+	if !reMatch(qr.requestIP.Regexp, ip) {
+		return false
+	}
 	if !reMatch(qr.user.Regexp, user) {
-		return QRContinue
+		return false
 	}
 	for _, bvcond := range qr.bindVarConds {
 		if !bvMatch(bvcond, bindVars) {
-			return QRContinue
+			return false
 		}
 	}
-	return qr.act
+	return true
 }
 
 func reMatch(re *regexp.Regexp, val string) bool {
@@ -891,12 +927,13 @@ func MapStrOperator(strop string) (op Operator, err error) {
 // BuildQueryRule builds a query rule from a ruleInfo.
 func BuildQueryRule(ruleInfo map[string]interface{}) (qr *QueryRule, err error) {
 	qr = NewQueryRule("", "", QRFail)
+	_, hasAction := ruleInfo["Action"]
 	for k, v := range ruleInfo {
 		var sv string
 		var lv []interface{}
 		var ok bool
 		switch k {
-		case "Name", "Description", "RequestIP", "User", "Query", "Action":
+		case "Name", "Description", "RequestIP", "User", "Query", "Action", "Budget":
 			sv, ok = v.(string)
 			if !ok {
 				return nil, tabletenv.NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "want string for %s", k)
@@ -969,8 +1006,19 @@ func BuildQueryRule(ruleInfo map[string]interface{}) (qr *QueryRule, err error)
 			default:
 				return nil, tabletenv.NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "invalid Action %s", sv)
 			}
+		case "Budget":
+			budget, err := time.ParseDuration(sv)
+			if err != nil || budget <= 0 {
+				return nil, tabletenv.NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "invalid Budget %s", sv)
+			}
+			qr.SetBudget(budget)
 		}
 	}
+	// A rule that only specifies a budget limits the matching
+	// queries instead of failing them.
+	if qr.budget != 0 && !hasAction {
+		qr.act = QRContinue
+	}
 	return qr, nil
 }
 
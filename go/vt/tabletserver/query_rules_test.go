@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gitql/vitess/go/vt/key"
 	"github.com/gitql/vitess/go/vt/tabletserver/planbuilder"
@@ -619,6 +620,41 @@ func TestAction(t *testing.T) {
 	}
 }
 
+func TestBudget(t *testing.T) {
+	qrs := NewQueryRules()
+
+	qr1 := NewQueryRule("rule 1", "r1", QRContinue)
+	qr1.SetUserCond("olap")
+	qr1.SetBudget(10 * time.Minute)
+
+	qr2 := NewQueryRule("rule 2", "r2", QRFail)
+	qr2.SetIPCond("123")
+
+	qr3 := NewQueryRule("rule 3", "r3", QRContinue)
+	qr3.SetBudget(100 * time.Millisecond)
+
+	qrs.Add(qr1)
+	qrs.Add(qr2)
+	qrs.Add(qr3)
+
+	budget, name := qrs.getBudget("123", "olap", nil)
+	if budget != 10*time.Minute || name != "r1" {
+		t.Errorf("getBudget: %v, %s, want 10m0s, r1", budget, name)
+	}
+	budget, name = qrs.getBudget("123", "user1", nil)
+	if budget != 100*time.Millisecond || name != "r3" {
+		t.Errorf("getBudget: %v, %s, want 100ms, r3", budget, name)
+	}
+	if action, _ := qrs.getAction("1234", "olap", nil); action != QRContinue {
+		t.Errorf("getAction: %v, want continue", action)
+	}
+
+	budget, _ = NewQueryRules().getBudget("123", "olap", nil)
+	if budget != 0 {
+		t.Errorf("getBudget: %v, want 0", budget)
+	}
+}
+
 func TestImport(t *testing.T) {
 	var qrs = NewQueryRules()
 	jsondata := `[{
@@ -645,6 +681,11 @@ func TestImport(t *testing.T) {
 		"Description": "desc2",
 		"Name": "name2",
 		"Action": "FAIL"
+	},{
+		"Description": "desc3",
+		"Name": "name3",
+		"User": "olap",
+		"Budget": "10m0s"
 	}]`
 	err := qrs.UnmarshalJSON([]byte(jsondata))
 	if err != nil {
@@ -775,6 +816,8 @@ var invalidjsons = []InvalidJSONCase{
 	{`[{"BindVarConds": [{"Name": "a", "OnAbsent": true, "OnMismatch": true, "Operator": "NOMATCH", "Value": "["}]}]`, "processing [: error parsing regexp: missing closing ]: `[$`"},
 	{`[{"Action": 1 }]`, "want string for Action"},
 	{`[{"Action": "foo" }]`, "invalid Action foo"},
+	{`[{"Budget": "foo" }]`, "invalid Budget foo"},
+	{`[{"Budget": "-1s" }]`, "invalid Budget -1s"},
 }
 
 func TestInvalidJSON(t *testing.T) {
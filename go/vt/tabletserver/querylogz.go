@@ -39,6 +39,7 @@ var (
 				<th>RowsAffected</th>
 				<th>Response Size</th>
 				<th>Transaction ID</th>
+				<th>Budget Exceeded</th>
 				<th>Error</th>
 			</tr>
 		</thead>
@@ -66,6 +67,7 @@ var (
 			<td>{{.RowsAffected}}</td>
 			<td>{{.SizeOfResponse}}</td>
 			<td>{{.TransactionID}}</td>
+			<td>{{.BudgetExceeded}}</td>
 			<td>{{.ErrorStr}}</td>
 		</tr>
 	`))
@@ -68,6 +68,7 @@ func TestQuerylogzHandler(t *testing.T) {
 		`<td>0</td>`,
 		`<td>131</td>`,
 		`<td></td>`,
+		`<td></td>`,
 	}
 	logStats.EndTime = logStats.StartTime.Add(1 * time.Millisecond)
 	response := httptest.NewRecorder()
@@ -98,6 +99,7 @@ func TestQuerylogzHandler(t *testing.T) {
 		`<td>0</td>`,
 		`<td>131</td>`,
 		`<td></td>`,
+		`<td></td>`,
 	}
 	logStats.EndTime = logStats.StartTime.Add(20 * time.Millisecond)
 	response = httptest.NewRecorder()
@@ -128,6 +130,7 @@ func TestQuerylogzHandler(t *testing.T) {
 		`<td>0</td>`,
 		`<td>131</td>`,
 		`<td></td>`,
+		`<td></td>`,
 	}
 	logStats.EndTime = logStats.StartTime.Add(500 * time.Millisecond)
 	ch = make(chan interface{}, 1)
@@ -136,6 +139,19 @@ func TestQuerylogzHandler(t *testing.T) {
 	close(ch)
 	body, _ = ioutil.ReadAll(response.Body)
 	checkQuerylogzHasStats(t, slowQueryPattern, logStats, body)
+
+	// query killed for exceeding its budget
+	killedQueryPattern := []string{
+		`<td>131</td>`,
+		`<td>100ms \(table test_table\)</td>`,
+	}
+	logStats.BudgetExceeded = "100ms (table test_table)"
+	ch = make(chan interface{}, 1)
+	ch <- logStats
+	querylogzHandler(ch, response, req)
+	close(ch)
+	body, _ = ioutil.ReadAll(response.Body)
+	checkQuerylogzHasStats(t, killedQueryPattern, logStats, body)
 }
 
 func checkQuerylogzHasStats(t *testing.T, pattern []string, logStats *tabletenv.LogStats, page []byte) {
@@ -28,11 +28,14 @@ import (
 type QueryService interface {
 	// Transaction management
 
-	// Begin returns the transaction id to use for further operations
-	Begin(ctx context.Context, target *querypb.Target) (int64, error)
-
-	// Commit commits the current transaction
-	Commit(ctx context.Context, target *querypb.Target, transactionID int64) error
+	// Begin returns the transaction id to use for further operations.
+	// The transaction_isolation of the options is used to start it.
+	Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (int64, error)
+
+	// Commit commits the current transaction. If the transaction
+	// was started with include_position, the position of the master
+	// after the commit is returned.
+	Commit(ctx context.Context, target *querypb.Target, transactionID int64) (string, error)
 
 	// Rollback aborts the current transaction
 	Rollback(ctx context.Context, target *querypb.Target, transactionID int64) error
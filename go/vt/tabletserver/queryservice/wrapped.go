@@ -45,19 +45,23 @@ type wrappedService struct {
 	wrapper WrapperFunc
 }
 
-func (ws *wrappedService) Begin(ctx context.Context, target *querypb.Target) (transactionID int64, err error) {
+func (ws *wrappedService) Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (transactionID int64, err error) {
 	err = ws.wrapper(ctx, target, ws.impl, "Begin", false, false, func(ctx context.Context, target *querypb.Target, conn QueryService) error {
 		var innerErr error
-		transactionID, innerErr = conn.Begin(ctx, target)
+		transactionID, innerErr = conn.Begin(ctx, target, options)
 		return innerErr
 	})
 	return transactionID, err
 }
 
-func (ws *wrappedService) Commit(ctx context.Context, target *querypb.Target, transactionID int64) error {
-	return ws.wrapper(ctx, target, ws.impl, "Commit", true, false, func(ctx context.Context, target *querypb.Target, conn QueryService) error {
-		return conn.Commit(ctx, target, transactionID)
+func (ws *wrappedService) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (string, error) {
+	var position string
+	err := ws.wrapper(ctx, target, ws.impl, "Commit", true, false, func(ctx context.Context, target *querypb.Target, conn QueryService) error {
+		var innerErr error
+		position, innerErr = conn.Commit(ctx, target, transactionID)
+		return innerErr
 	})
+	return position, err
 }
 
 func (ws *wrappedService) Rollback(ctx context.Context, target *querypb.Target, transactionID int64) error {
@@ -90,13 +90,27 @@ func (rpw *ReplicationWatcher) Close() {
 func (rpw *ReplicationWatcher) Process(ctx context.Context, dbconfigs dbconfigs.DBConfigs, mysqld mysqlctl.MysqlDaemon) {
 	defer rpw.wg.Done()
 	for {
-		log.Infof("Starting a binlog Streamer from current replication position to monitor binlogs")
-		streamer := binlog.NewStreamer(dbconfigs.App.DbName, mysqld, nil /*clientCharset*/, replication.Position{}, 0 /*timestamp*/, func(trans *binlogdatapb.BinlogTransaction) error {
+		var startPos replication.Position
+		if rpw.qe.rowCache.IsOpen() {
+			// Stream from a known position, and flush the row cache after
+			// obtaining it: the rows cached from now on will be
+			// invalidated by the stream.
+			pos, err := mysqld.MasterPosition()
+			if err != nil {
+				log.Warningf("Cannot get the replication position, streaming from the current one: %v", err)
+			}
+			startPos = pos
+			rpw.qe.rowCache.Resume()
+		}
+		log.Infof("Starting a binlog Streamer from replication position %v to monitor binlogs", startPos)
+		streamer := binlog.NewStreamer(dbconfigs.App.DbName, mysqld, nil /*clientCharset*/, startPos, 0 /*timestamp*/, func(trans *binlogdatapb.BinlogTransaction) error {
 			// Save the event token.
 			rpw.mu.Lock()
 			rpw.eventToken = trans.EventToken
 			rpw.mu.Unlock()
 
+			rpw.qe.rowCache.InvalidateTransaction(trans)
+
 			// If it's a DDL, trigger a schema reload.
 			for _, statement := range trans.Statements {
 				if statement.Category != binlogdatapb.BinlogTransaction_Statement_BL_DDL {
@@ -110,7 +124,10 @@ func (rpw *ReplicationWatcher) Process(ctx context.Context, dbconfigs dbconfigs.
 			return nil
 		})
 
-		if err := streamer.Stream(ctx); err != nil {
+		err := streamer.Stream(ctx)
+		// Invalidations may be missed until the stream restarts.
+		rpw.qe.rowCache.Pause()
+		if err != nil {
 			log.Infof("Streamer stopped: %v", err)
 		}
 
@@ -0,0 +1,335 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/gitql/vitess/go/cacheservice"
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/binlog"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+	"github.com/gitql/vitess/go/vt/tabletserver/tabletenv"
+
+	// The in-process cache service is the default one.
+	_ "github.com/gitql/vitess/go/cacheservice/localcache"
+
+	binlogdatapb "github.com/gitql/vitess/go/vt/proto/binlogdata"
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+)
+
+// RowCache caches the full rows of the tables that have vitess_rowcache
+// in their comment, by primary key. It is used for the point selects
+// outside of transactions. The ReplicationWatcher invalidates it using
+// the _stream comments vttablet adds to the DMLs, so the cache stays
+// consistent with the local MySQL. Since the replication watcher only
+// runs on non-master tablets, so does the row cache.
+type RowCache struct {
+	enabled bool
+	service string
+	config  cacheservice.Config
+
+	// mu protects the fields below, and serializes the calls to the
+	// cache service, which doesn't have to be thread safe.
+	mu sync.Mutex
+	cs cacheservice.CacheService
+	// streaming is true while the replication stream is watched.
+	// Rows are neither read nor cached otherwise.
+	streaming bool
+	// versions is part of the keys of a table. Changing it
+	// invalidates all the cached rows of the table.
+	versions map[string]int64
+	// generations counts the invalidations of each table, and flushes
+	// counts the invalidations of all tables. A row read from MySQL
+	// is only cached if its generation didn't change during the read.
+	// Otherwise, the row may have been invalidated before it was cached.
+	generations map[string]int64
+	flushes     int64
+	// flushVersion is part of all keys. It changes if the
+	// cache service fails to flush.
+	flushVersion int64
+}
+
+// NewRowCache creates a new RowCache.
+func NewRowCache() *RowCache {
+	return &RowCache{
+		enabled: tabletenv.Config.EnableRowCache,
+		service: tabletenv.Config.RowCacheService,
+		config: cacheservice.Config{
+			Address:  tabletenv.Config.RowCacheAddress,
+			Timeout:  time.Duration(tabletenv.Config.QueryTimeout * 1e9),
+			Capacity: tabletenv.Config.RowCacheCapacity,
+		},
+		versions:    make(map[string]int64),
+		generations: make(map[string]int64),
+	}
+}
+
+// Open connects to the cache service, if the row cache is enabled.
+// The row cache can't be used without the replication watcher,
+// because nothing would invalidate it.
+func (rc *RowCache) Open() error {
+	if !rc.enabled {
+		return nil
+	}
+	if !tabletenv.Config.WatchReplication {
+		log.Warningf("Row cache disabled: it requires -watch_replication_stream")
+		return nil
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.cs != nil {
+		return nil
+	}
+	cs, err := cacheservice.ConnectByName(rc.service, rc.config)
+	if err != nil {
+		return fmt.Errorf("could not connect to row cache service %v: %v", rc.service, err)
+	}
+	if err := cs.FlushAll(); err != nil {
+		cs.Close()
+		return fmt.Errorf("could not flush row cache: %v", err)
+	}
+	rc.cs = cs
+	return nil
+}
+
+// Close closes the connection to the cache service.
+func (rc *RowCache) Close() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.cs == nil {
+		return
+	}
+	rc.cs.Close()
+	rc.cs = nil
+	rc.streaming = false
+}
+
+// Resume flushes the cache, and starts using it. It must be called
+// by the replication watcher when it starts streaming from a position
+// obtained before the call.
+func (rc *RowCache) Resume() {
+	rc.Flush()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.streaming = true
+}
+
+// Pause stops using the cache. It must be called by the replication
+// watcher when it stops streaming: invalidations could be missed.
+func (rc *RowCache) Pause() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.streaming = false
+}
+
+// IsOpen returns true if the row cache is enabled and connected.
+func (rc *RowCache) IsOpen() bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.cs != nil
+}
+
+// Generation returns the current generation of a table, to be passed
+// to Set for the rows read from MySQL after this call.
+func (rc *RowCache) Generation(tableInfo *TableInfo) int64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	// Both counters only increase: the sum changes if any of them does.
+	return rc.flushes + rc.generations[tableInfo.Name.String()]
+}
+
+// Get returns the cached row for the primary key, or nil.
+func (rc *RowCache) Get(tableInfo *TableInfo, pk []sqltypes.Value) []sqltypes.Value {
+	tableName := tableInfo.Name.String()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.cs == nil || !rc.streaming {
+		return nil
+	}
+	key, ok := rc.key(tableName, pk)
+	if !ok {
+		return nil
+	}
+	results, err := rc.cs.Get(key)
+	if err != nil {
+		tabletenv.InternalErrors.Add("RowCache", 1)
+		log.Warningf("Row cache get %s failed: %v", key, err)
+		return nil
+	}
+	if len(results) == 0 {
+		tabletenv.RowCacheStats.Add([]string{tableName, "Misses"}, 1)
+		return nil
+	}
+	pbrow := &querypb.Row{}
+	if err := proto.Unmarshal(results[0].Value, pbrow); err != nil || len(pbrow.Lengths) != len(tableInfo.Columns) {
+		tabletenv.RowCacheStats.Add([]string{tableName, "Misses"}, 1)
+		return nil
+	}
+	tabletenv.RowCacheStats.Add([]string{tableName, "Hits"}, 1)
+	fields := make([]*querypb.Field, len(tableInfo.Columns))
+	for i, col := range tableInfo.Columns {
+		fields[i] = &querypb.Field{Type: col.Type}
+	}
+	return sqltypes.MakeRowTrusted(fields, pbrow)
+}
+
+// Set caches a full row read from MySQL, unless its table was
+// invalidated since generation was obtained.
+func (rc *RowCache) Set(tableInfo *TableInfo, pk []sqltypes.Value, generation int64, row []sqltypes.Value) {
+	value, err := proto.Marshal(sqltypes.RowsToProto3([][]sqltypes.Value{row})[0])
+	if err != nil {
+		return
+	}
+	tableName := tableInfo.Name.String()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.cs == nil || !rc.streaming || rc.flushes+rc.generations[tableName] != generation {
+		return
+	}
+	key, ok := rc.key(tableName, pk)
+	if !ok {
+		return
+	}
+	if _, err := rc.cs.Set(key, 0, 0, value); err != nil {
+		tabletenv.InternalErrors.Add("RowCache", 1)
+		log.Warningf("Row cache set %s failed: %v", key, err)
+	}
+}
+
+// InvalidateTransaction invalidates the rows changed by a transaction
+// of the replication stream. The DMLs are expected to have a _stream
+// comment. If they don't, all the rows of their table are invalidated.
+// The statements that can't be analyzed invalidate the whole cache.
+func (rc *RowCache) InvalidateTransaction(trans *binlogdatapb.BinlogTransaction) {
+	if !rc.IsOpen() {
+		return
+	}
+	for _, stmt := range trans.Statements {
+		switch stmt.Category {
+		case binlogdatapb.BinlogTransaction_Statement_BL_INSERT,
+			binlogdatapb.BinlogTransaction_Statement_BL_UPDATE,
+			binlogdatapb.BinlogTransaction_Statement_BL_DELETE:
+			rc.invalidateDML(string(stmt.Sql))
+		case binlogdatapb.BinlogTransaction_Statement_BL_DDL,
+			binlogdatapb.BinlogTransaction_Statement_BL_UNRECOGNIZED:
+			rc.Flush()
+		}
+	}
+}
+
+// invalidateDML invalidates the rows changed by a DML.
+// Auto-increment values are not resolved: rows that didn't exist
+// before the insert can't be in the cache.
+func (rc *RowCache) invalidateDML(sql string) {
+	dml, _, err := binlog.ParseStreamComment(sql, 0)
+	if err != nil {
+		tableName := dmlTableName(sql)
+		if tableName == "" {
+			rc.Flush()
+			return
+		}
+		rc.invalidateTable(tableName)
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.cs == nil {
+		return
+	}
+	rc.generations[dml.TableName]++
+	for _, pbrow := range dml.PrimaryKeyValues {
+		pk := sqltypes.MakeRowTrusted(dml.PrimaryKeyFields, pbrow)
+		key, ok := rc.key(dml.TableName, pk)
+		if !ok {
+			// Such a row can't be cached, but let's be safe.
+			rc.versions[dml.TableName]++
+			tabletenv.RowCacheStats.Add([]string{dml.TableName, "TableInvalidations"}, 1)
+			return
+		}
+		if _, err := rc.cs.Delete(key); err != nil {
+			tabletenv.InternalErrors.Add("RowCache", 1)
+			log.Warningf("Row cache delete %s failed: %v, invalidating the table", key, err)
+			rc.versions[dml.TableName]++
+			return
+		}
+		tabletenv.RowCacheStats.Add([]string{dml.TableName, "Invalidations"}, 1)
+	}
+}
+
+// invalidateTable invalidates all the rows of a table.
+func (rc *RowCache) invalidateTable(tableName string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generations[tableName]++
+	rc.versions[tableName]++
+	tabletenv.RowCacheStats.Add([]string{tableName, "TableInvalidations"}, 1)
+}
+
+// Flush invalidates all the rows.
+func (rc *RowCache) Flush() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.flushes++
+	if rc.cs == nil {
+		return
+	}
+	if err := rc.cs.FlushAll(); err != nil {
+		// The rows can't be reached any more if the keys change.
+		tabletenv.InternalErrors.Add("RowCache", 1)
+		log.Warningf("Row cache flush failed: %v, invalidating all tables", err)
+		rc.flushVersion++
+	}
+}
+
+// key returns the cache key of a row. It returns false if the primary
+// key is not integral. The values are normalized, so that '0x10' and '16'
+// have the same key. rc.mu must be held.
+func (rc *RowCache) key(tableName string, pk []sqltypes.Value) (string, bool) {
+	parts := []string{
+		tableName,
+		strconv.FormatInt(rc.flushVersion, 10),
+		strconv.FormatInt(rc.versions[tableName], 10),
+	}
+	for _, v := range pk {
+		raw := v.String()
+		if i, err := strconv.ParseInt(raw, 0, 64); err == nil {
+			parts = append(parts, strconv.FormatInt(i, 10))
+			continue
+		}
+		if u, err := strconv.ParseUint(raw, 0, 64); err == nil {
+			parts = append(parts, strconv.FormatUint(u, 10))
+			continue
+		}
+		return "", false
+	}
+	return strings.Join(parts, "."), true
+}
+
+// dmlTableName returns the name of the table changed by a DML,
+// or "" if it can't be parsed.
+func dmlTableName(sql string) string {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return ""
+	}
+	switch stmt := stmt.(type) {
+	case *sqlparser.Insert:
+		return stmt.Table.Name.String()
+	case *sqlparser.Update:
+		return sqlparser.GetTableName(stmt.Table.Expr).String()
+	case *sqlparser.Delete:
+		return stmt.Table.Name.String()
+	}
+	return ""
+}
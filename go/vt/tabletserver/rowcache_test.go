@@ -0,0 +1,182 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/schema"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+	"github.com/gitql/vitess/go/vt/tabletserver/tabletenv"
+
+	binlogdatapb "github.com/gitql/vitess/go/vt/proto/binlogdata"
+)
+
+func newTestRowCache(t *testing.T) (*RowCache, *TableInfo) {
+	config := tabletenv.Config
+	defer func() { tabletenv.Config = config }()
+	tabletenv.Config.EnableRowCache = true
+	tabletenv.Config.WatchReplication = true
+	tabletenv.Config.RowCacheService = "localcache"
+	rc := NewRowCache()
+	if err := rc.Open(); err != nil {
+		t.Fatal(err)
+	}
+	rc.Resume()
+
+	tableInfo := &TableInfo{Table: &schema.Table{
+		Name: sqlparser.NewTableIdent("rc"),
+		Columns: []schema.TableColumn{
+			{Name: sqlparser.NewColIdent("id"), Type: sqltypes.Int64},
+			{Name: sqlparser.NewColIdent("name"), Type: sqltypes.VarChar},
+		},
+		PKColumns: []int{0},
+		RowCache:  true,
+	}}
+	return rc, tableInfo
+}
+
+func rowCacheDML(category binlogdatapb.BinlogTransaction_Statement_Category, sql string) *binlogdatapb.BinlogTransaction {
+	return &binlogdatapb.BinlogTransaction{
+		Statements: []*binlogdatapb.BinlogTransaction_Statement{{
+			Category: category,
+			Sql:      []byte(sql),
+		}},
+	}
+}
+
+func TestRowCacheGetSet(t *testing.T) {
+	rc, tableInfo := newTestRowCache(t)
+	defer rc.Close()
+
+	pk := []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))}
+	row := []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		sqltypes.MakeTrusted(sqltypes.VarChar, []byte("a")),
+	}
+	if got := rc.Get(tableInfo, pk); got != nil {
+		t.Errorf("Get: %v, want nil", got)
+	}
+	rc.Set(tableInfo, pk, rc.Generation(tableInfo), row)
+	if got := rc.Get(tableInfo, pk); !reflect.DeepEqual(got, row) {
+		t.Errorf("Get: %v, want %v", got, row)
+	}
+	// Equivalent integral values have the same key.
+	hexPK := []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("0x1"))}
+	if got := rc.Get(tableInfo, hexPK); !reflect.DeepEqual(got, row) {
+		t.Errorf("Get(0x1): %v, want %v", got, row)
+	}
+
+	// The stream comment invalidates the row.
+	rc.InvalidateTransaction(rowCacheDML(
+		binlogdatapb.BinlogTransaction_Statement_BL_UPDATE,
+		"update rc set name = 'b' where id in (1) /* _stream rc (id ) (1 ); */",
+	))
+	if got := rc.Get(tableInfo, pk); got != nil {
+		t.Errorf("Get after invalidation: %v, want nil", got)
+	}
+
+	// A row read before an invalidation is not cached.
+	generation := rc.Generation(tableInfo)
+	rc.InvalidateTransaction(rowCacheDML(
+		binlogdatapb.BinlogTransaction_Statement_BL_DELETE,
+		"delete from rc where id in (2) /* _stream rc (id ) (2 ); */",
+	))
+	rc.Set(tableInfo, pk, generation, row)
+	if got := rc.Get(tableInfo, pk); got != nil {
+		t.Errorf("Get after stale Set: %v, want nil", got)
+	}
+}
+
+func TestRowCacheInvalidateWithoutComment(t *testing.T) {
+	rc, tableInfo := newTestRowCache(t)
+	defer rc.Close()
+
+	pk := []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))}
+	row := []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		sqltypes.MakeTrusted(sqltypes.VarChar, []byte("a")),
+	}
+	testCases := []struct {
+		category binlogdatapb.BinlogTransaction_Statement_Category
+		sql      string
+	}{{
+		category: binlogdatapb.BinlogTransaction_Statement_BL_UPDATE,
+		sql:      "update rc set name = 'b' where name = 'a'",
+	}, {
+		category: binlogdatapb.BinlogTransaction_Statement_BL_INSERT,
+		sql:      "insert into rc values (1, 'b')",
+	}, {
+		category: binlogdatapb.BinlogTransaction_Statement_BL_DELETE,
+		sql:      "not a dml",
+	}, {
+		category: binlogdatapb.BinlogTransaction_Statement_BL_DDL,
+		sql:      "alter table rc add column foo int",
+	}}
+	for _, tcase := range testCases {
+		rc.Set(tableInfo, pk, rc.Generation(tableInfo), row)
+		if got := rc.Get(tableInfo, pk); got == nil {
+			t.Fatalf("Get: nil, want %v", row)
+		}
+		rc.InvalidateTransaction(rowCacheDML(tcase.category, tcase.sql))
+		if got := rc.Get(tableInfo, pk); got != nil {
+			t.Errorf("Get after %s: %v, want nil", tcase.sql, got)
+		}
+	}
+
+	// DMLs on other tables don't invalidate the row.
+	rc.Set(tableInfo, pk, rc.Generation(tableInfo), row)
+	rc.InvalidateTransaction(rowCacheDML(
+		binlogdatapb.BinlogTransaction_Statement_BL_UPDATE,
+		"update other set name = 'b' where name = 'a'",
+	))
+	if got := rc.Get(tableInfo, pk); got == nil {
+		t.Errorf("Get after other table update: nil, want %v", row)
+	}
+}
+
+func TestRowCachePause(t *testing.T) {
+	rc, tableInfo := newTestRowCache(t)
+	defer rc.Close()
+
+	pk := []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))}
+	row := []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		sqltypes.MakeTrusted(sqltypes.VarChar, []byte("a")),
+	}
+	rc.Set(tableInfo, pk, rc.Generation(tableInfo), row)
+	rc.Pause()
+	if got := rc.Get(tableInfo, pk); got != nil {
+		t.Errorf("Get while paused: %v, want nil", got)
+	}
+	rc.Set(tableInfo, pk, rc.Generation(tableInfo), row)
+	rc.Resume()
+	if got := rc.Get(tableInfo, pk); got != nil {
+		t.Errorf("Get after resume: %v, want nil", got)
+	}
+}
+
+func TestRowCacheNeedsWatcher(t *testing.T) {
+	config := tabletenv.Config
+	defer func() { tabletenv.Config = config }()
+	tabletenv.Config.EnableRowCache = true
+	tabletenv.Config.WatchReplication = false
+	rc := NewRowCache()
+	if err := rc.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if rc.IsOpen() {
+		t.Errorf("IsOpen: true, want false")
+	}
+
+	tabletenv.Config.WatchReplication = true
+	tabletenv.Config.RowCacheService = "nonexistent"
+	rc = NewRowCache()
+	if err := rc.Open(); err == nil {
+		t.Errorf("Open with unknown service: nil, want error")
+	}
+}
@@ -86,6 +86,9 @@ type SandboxConn struct {
 
 	MessageIDs []*querypb.Value
 
+	// CommitPosition is returned by Commit.
+	CommitPosition string
+
 	// transaction id generator
 	TransactionID sync2.AtomicInt64
 }
@@ -253,7 +256,7 @@ func (sbc *SandboxConn) StreamExecute(ctx context.Context, target *querypb.Targe
 }
 
 // Begin is part of the QueryService interface.
-func (sbc *SandboxConn) Begin(ctx context.Context, target *querypb.Target) (int64, error) {
+func (sbc *SandboxConn) Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (int64, error) {
 	sbc.BeginCount.Add(1)
 	err := sbc.getError()
 	if err != nil {
@@ -263,9 +266,12 @@ func (sbc *SandboxConn) Begin(ctx context.Context, target *querypb.Target) (int6
 }
 
 // Commit is part of the QueryService interface.
-func (sbc *SandboxConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) error {
+func (sbc *SandboxConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (string, error) {
 	sbc.CommitCount.Add(1)
-	return sbc.getError()
+	if err := sbc.getError(); err != nil {
+		return "", err
+	}
+	return sbc.CommitPosition, nil
 }
 
 // Rollback is part of the QueryService interface.
@@ -384,7 +390,7 @@ func (sbc *SandboxConn) ReadTransaction(ctx context.Context, target *querypb.Tar
 
 // BeginExecute is part of the QueryService interface.
 func (sbc *SandboxConn) BeginExecute(ctx context.Context, target *querypb.Target, query string, bindVars map[string]interface{}, options *querypb.ExecuteOptions) (*sqltypes.Result, int64, error) {
-	transactionID, err := sbc.Begin(ctx, target)
+	transactionID, err := sbc.Begin(ctx, target, options)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -394,7 +400,7 @@ func (sbc *SandboxConn) BeginExecute(ctx context.Context, target *querypb.Target
 
 // BeginExecuteBatch is part of the QueryService interface.
 func (sbc *SandboxConn) BeginExecuteBatch(ctx context.Context, target *querypb.Target, queries []querytypes.BoundQuery, asTransaction bool, options *querypb.ExecuteOptions) ([]sqltypes.Result, int64, error) {
-	transactionID, err := sbc.Begin(ctx, target)
+	transactionID, err := sbc.Begin(ctx, target, options)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -207,7 +207,14 @@ func buildPrevBindVariableNames(splitColumns []*schema.TableColumn) []string {
 }
 
 func (a *FullScanAlgorithm) executeQuery(boundQuery *querytypes.BoundQuery) (tuple, error) {
-	sqlResult, err := a.sqlExecuter.SQLExecute(
+	return executeBoundaryQuery(a.sqlExecuter, boundQuery, len(a.splitParams.splitColumns))
+}
+
+// executeBoundaryQuery executes a query returning at most one boundary tuple
+// of length 'tupleLength'. It returns nil if the query returned no rows.
+func executeBoundaryQuery(
+	sqlExecuter SQLExecuter, boundQuery *querytypes.BoundQuery, tupleLength int) (tuple, error) {
+	sqlResult, err := sqlExecuter.SQLExecute(
 		boundQuery.Sql,
 		boundQuery.BindVariables)
 	if err != nil {
@@ -217,9 +224,9 @@ func (a *FullScanAlgorithm) executeQuery(boundQuery *querytypes.BoundQuery) (tup
 		return nil, nil
 	}
 	if len(sqlResult.Rows) == 1 {
-		if len(sqlResult.Rows[0]) != len(a.splitParams.splitColumns) {
+		if len(sqlResult.Rows[0]) != tupleLength {
 			panic(fmt.Sprintf("splitquery.executeQuery: expected a tuple of length %v. Got tuple: %v",
-				len(a.splitParams.splitColumns), sqlResult.Rows[0]))
+				tupleLength, sqlResult.Rows[0]))
 		}
 		return sqlResult.Rows[0], nil
 	}
@@ -0,0 +1,113 @@
+package splitquery
+
+import (
+	"fmt"
+
+	"github.com/gitql/vitess/go/vt/schema"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+	"github.com/gitql/vitess/go/vt/tabletserver/querytypes"
+)
+
+// SamplingAlgorithm implements the SplitAlgorithmInterface and represents the sampling algorithm
+// for generating the boundary tuples. Like the full-scan algorithm, it walks the index of the
+// split columns, and works for split columns of any type. Unlike the full-scan algorithm, the
+// split columns may be any prefix of the primary key columns, and the algorithm samples at most
+// splitParams.splitCount-1 boundary tuples which are splitParams.numRowsPerQueryPart rows apart.
+// The last query part gets the remaining rows, since the number of rows of the table is only
+// estimated.
+//
+// The algorithm executes the following query to get the first boundary tuple:
+//
+//	SELECT <split_columns> FROM <table>
+//	                       ORDER BY <split_columns>
+//	                       LIMIT <num_rows_per_query_part>, 1
+//
+// and then the following query to get each subsequent boundary tuple:
+//
+//	SELECT <split_columns> FROM <table>
+//	                       WHERE :prev_boundary < (<split_columns>)
+//	                       ORDER BY <split_columns>
+//	                       LIMIT <num_rows_per_query_part - 1>, 1
+//
+// where 'prev_boundary' is the previous boundary tuple. The comparison is strict, so that the
+// boundary tuples are distinct even if the split columns are not unique. As in the full-scan
+// algorithm, the tuple inequality is re-written to use only scalar comparisons and one bind
+// variable per split column.
+type SamplingAlgorithm struct {
+	splitParams *SplitParams
+	sqlExecuter SQLExecuter
+
+	prevBindVariableNames []string
+	initialQuery          *querytypes.BoundQuery
+	noninitialQuery       *querytypes.BoundQuery
+}
+
+// NewSamplingAlgorithm constructs a new SamplingAlgorithm.
+func NewSamplingAlgorithm(
+	splitParams *SplitParams, sqlExecuter SQLExecuter) (*SamplingAlgorithm, error) {
+
+	if !splitParams.areSplitColumnsPrimaryKeyPrefix() {
+		return nil, fmt.Errorf("using the SAMPLING algorithm requires split columns to be"+
+			" a prefix of the primary key. Got: %+v", splitParams)
+	}
+	if splitParams.splitCount <= 0 {
+		return nil, fmt.Errorf("using the SAMPLING algorithm in SplitQuery requires a positive"+
+			" splitParams.splitCount. Got: %v", splitParams.splitCount)
+	}
+	result := &SamplingAlgorithm{
+		splitParams:           splitParams,
+		sqlExecuter:           sqlExecuter,
+		prevBindVariableNames: buildPrevBindVariableNames(splitParams.splitColumns),
+	}
+	result.initialQuery = buildInitialQuery(splitParams)
+	result.noninitialQuery = buildSamplingNoninitialQuery(splitParams, result.prevBindVariableNames)
+	return result, nil
+}
+
+// getSplitColumns is part of the SplitAlgorithmInterface interface
+func (a *SamplingAlgorithm) getSplitColumns() []*schema.TableColumn {
+	return a.splitParams.splitColumns
+}
+
+func (a *SamplingAlgorithm) generateBoundaries() ([]tuple, error) {
+	result := make([]tuple, 0, a.splitParams.splitCount-1)
+	query := a.initialQuery
+	for int64(len(result)) < a.splitParams.splitCount-1 {
+		boundary, err := executeBoundaryQuery(a.sqlExecuter, query, len(a.splitParams.splitColumns))
+		if err != nil {
+			return nil, err
+		}
+		if boundary == nil {
+			// The table has fewer rows than estimated.
+			break
+		}
+		result = append(result, boundary)
+		for i, tupleElement := range boundary {
+			a.noninitialQuery.BindVariables[a.prevBindVariableNames[i]] = tupleElement.ToNative()
+		}
+		query = a.noninitialQuery
+	}
+	return result, nil
+}
+
+// buildSamplingNoninitialQuery returns the query to execute to get the
+// boundary tuples after the first one. It is like buildNoninitialQuery, except
+// that the tuple inequality is strict and the offset of the LIMIT clause is
+// splitParams.numRowsPerQueryPart - 1, since the previous boundary is excluded.
+// The BindVariables field of the result will contain a deep-copy of splitParams.BindVariables.
+// The new "prev_<sc>" bind variables are not populated yet.
+func buildSamplingNoninitialQuery(
+	splitParams *SplitParams, prevBindVariableNames []string) *querytypes.BoundQuery {
+	resultSelectAST := buildInitialQueryAST(splitParams)
+	resultSelectAST.Limit = buildLimitClause(int64Max(splitParams.numRowsPerQueryPart-1, 0), 1)
+	addAndTermToWhereClause(
+		resultSelectAST,
+		constructTupleInequality(
+			convertBindVariableNamesToExpr(prevBindVariableNames),
+			convertColumnsToExpr(splitParams.splitColumns),
+			true /* strict */))
+	return &querytypes.BoundQuery{
+		Sql:           sqlparser.String(resultSelectAST),
+		BindVariables: cloneBindVariables(splitParams.bindVariables),
+	}
+}
@@ -0,0 +1,144 @@
+package splitquery
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+	"github.com/gitql/vitess/go/vt/tabletserver/querytypes"
+	"github.com/gitql/vitess/go/vt/tabletserver/splitquery/splitquery_testing"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSamplingMultipleBoundaries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	splitParams, err := NewSplitParamsGivenSplitCount(
+		querytypes.BoundQuery{Sql: "select * from test_table where int_col > 5"},
+		[]sqlparser.ColIdent{
+			sqlparser.NewColIdent("id"),
+			sqlparser.NewColIdent("user_id"),
+		}, /* splitColumns */
+		3,
+		getTestSchema(),
+	)
+	if err != nil {
+		t.Fatalf("NewSplitParamsGivenSplitCount failed with: %v", err)
+	}
+	mockSQLExecuter := splitquery_testing.NewMockSQLExecuter(mockCtrl)
+	expectedCall1 := mockSQLExecuter.EXPECT().SQLExecute(
+		"select id, user_id from test_table"+
+			" order by id asc, user_id asc"+
+			" limit 333, 1",
+		map[string]interface{}{})
+	expectedCall1.Return(
+		&sqltypes.Result{
+			Rows: [][]sqltypes.Value{
+				{int64Value(1), int64Value(1)}},
+		},
+		nil)
+	expectedCall2 := mockSQLExecuter.EXPECT().SQLExecute(
+		"select id, user_id from test_table"+
+			" where"+
+			" :_splitquery_prev_id < id or"+
+			" (:_splitquery_prev_id = id and :_splitquery_prev_user_id < user_id)"+
+			" order by id asc, user_id asc"+
+			" limit 332, 1",
+		map[string]interface{}{
+			"_splitquery_prev_id":      int64(1),
+			"_splitquery_prev_user_id": int64(1),
+		})
+	expectedCall2.Return(
+		&sqltypes.Result{
+			Rows: [][]sqltypes.Value{
+				{int64Value(2), int64Value(10)}},
+		},
+		nil)
+	expectedCall2.After(expectedCall1)
+
+	algorithm, err := NewSamplingAlgorithm(splitParams, mockSQLExecuter)
+	if err != nil {
+		t.Fatalf("NewSamplingAlgorithm failed with: %v", err)
+	}
+	// The algorithm must stop after splitCount-1 boundaries.
+	boundaries, err := algorithm.generateBoundaries()
+	if err != nil {
+		t.Fatalf("SamplingAlgorithm.generateBoundaries() failed with: %v", err)
+	}
+	expectedBoundaries := []tuple{
+		{int64Value(1), int64Value(1)},
+		{int64Value(2), int64Value(10)},
+	}
+	if !reflect.DeepEqual(expectedBoundaries, boundaries) {
+		t.Fatalf("expected: %v, got: %v", expectedBoundaries, boundaries)
+	}
+}
+
+func TestSamplingPrimaryKeyPrefix(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	splitParams, err := NewSplitParamsGivenSplitCount(
+		querytypes.BoundQuery{Sql: "select * from test_table where int_col > 5"},
+		[]sqlparser.ColIdent{sqlparser.NewColIdent("id")}, /* splitColumns */
+		4,
+		getTestSchema(),
+	)
+	if err != nil {
+		t.Fatalf("NewSplitParamsGivenSplitCount failed with: %v", err)
+	}
+	mockSQLExecuter := splitquery_testing.NewMockSQLExecuter(mockCtrl)
+	expectedCall1 := mockSQLExecuter.EXPECT().SQLExecute(
+		"select id from test_table"+
+			" order by id asc"+
+			" limit 250, 1",
+		map[string]interface{}{})
+	expectedCall1.Return(
+		&sqltypes.Result{
+			Rows: [][]sqltypes.Value{
+				{int64Value(7)}},
+		},
+		nil)
+	// The table has fewer rows than estimated.
+	expectedCall2 := mockSQLExecuter.EXPECT().SQLExecute(
+		"select id from test_table"+
+			" where :_splitquery_prev_id < id"+
+			" order by id asc"+
+			" limit 249, 1",
+		map[string]interface{}{
+			"_splitquery_prev_id": int64(7),
+		})
+	expectedCall2.Return(
+		&sqltypes.Result{Rows: [][]sqltypes.Value{}}, nil)
+	expectedCall2.After(expectedCall1)
+
+	algorithm, err := NewSamplingAlgorithm(splitParams, mockSQLExecuter)
+	if err != nil {
+		t.Fatalf("NewSamplingAlgorithm failed with: %v", err)
+	}
+	boundaries, err := algorithm.generateBoundaries()
+	if err != nil {
+		t.Fatalf("SamplingAlgorithm.generateBoundaries() failed with: %v", err)
+	}
+	expectedBoundaries := []tuple{
+		{int64Value(7)},
+	}
+	if !reflect.DeepEqual(expectedBoundaries, boundaries) {
+		t.Fatalf("expected: %v, got: %v", expectedBoundaries, boundaries)
+	}
+}
+
+func TestSamplingSplitColumnsNotPrimaryKeyPrefix(t *testing.T) {
+	splitParams, err := NewSplitParamsGivenSplitCount(
+		querytypes.BoundQuery{Sql: "select * from test_table where int_col > 5"},
+		[]sqlparser.ColIdent{sqlparser.NewColIdent("id2")}, /* splitColumns */
+		4,
+		getTestSchema(),
+	)
+	if err != nil {
+		t.Fatalf("NewSplitParamsGivenSplitCount failed with: %v", err)
+	}
+	if _, err := NewSamplingAlgorithm(splitParams, nil /* sqlExecuter */); err == nil {
+		t.Errorf("NewSamplingAlgorithm should have failed for split columns which are not a prefix of the primary key")
+	}
+}
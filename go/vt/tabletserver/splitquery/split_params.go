@@ -256,3 +256,18 @@ func (sp *SplitParams) areSplitColumnsPrimaryKey() bool {
 	}
 	return true
 }
+
+// areSplitColumnsPrimaryKeyPrefix returns true if the splitColumns in
+// 'splitParams' are a prefix of the primary key columns in order.
+func (sp *SplitParams) areSplitColumnsPrimaryKeyPrefix() bool {
+	pkCols := getPrimaryKeyColumns(sp.splitTableSchema)
+	if len(sp.splitColumns) > len(pkCols) {
+		return false
+	}
+	for i := 0; i < len(sp.splitColumns); i++ {
+		if !sp.splitColumns[i].Name.Equal(pkCols[i].Name) {
+			return false
+		}
+	}
+	return true
+}
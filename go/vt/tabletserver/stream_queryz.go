@@ -24,6 +24,7 @@ var (
 			<th>Duration</th>
 			<th>Start</th>
 			<th>ConnectionID</th>
+			<th>Budget</th>
 			<th>Terminate</th>
 		</tr>
         </thead>
@@ -35,6 +36,7 @@ var (
 			<td>{{.Duration}}</td>
 			<td>{{.Start}}</td>
 			<td>{{.ConnID}}</td>
+			<td>{{.Budget}}</td>
 			<td><a href='/streamqueryz/terminate?connID={{.ConnID}}'>Terminate</a></td>
 		</tr>
 	`))
@@ -83,6 +83,15 @@ func NewTableInfo(conn *connpool.DBConn, tableName string, tableType string, com
 			return nil, err
 		}
 		ti.Type = schema.Message
+	case strings.Contains(comment, "vitess_rowcache"):
+		ti.RowCache = true
+	}
+	if sv := commentKeyvals(comment)["vt_query_budget"]; sv != "" {
+		v, err := strconv.ParseFloat(sv, 64)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("invalid vt_query_budget %s for table: %s", sv, tableName)
+		}
+		ti.QueryBudget = time.Duration(v * 1e9)
 	}
 	return ti, nil
 }
@@ -210,16 +219,7 @@ func (ti *TableInfo) loadMessageInfo(comment string) error {
 	ti.MessageInfo = &MessageInfo{
 		Fields: make([]*querypb.Field, 2),
 	}
-	// Extract keyvalues.
-	keyvals := make(map[string]string)
-	inputs := strings.Split(comment, ",")
-	for _, input := range inputs {
-		kv := strings.Split(input, "=")
-		if len(kv) != 2 {
-			continue
-		}
-		keyvals[kv[0]] = kv[1]
-	}
+	keyvals := commentKeyvals(comment)
 	var err error
 	if ti.MessageInfo.AckWaitDuration, err = getDuration(keyvals, "vt_ack_wait"); err != nil {
 		return err
@@ -263,6 +263,21 @@ func (ti *TableInfo) loadMessageInfo(comment string) error {
 	return fmt.Errorf("id column is not part of the primary key for message table: %s", ti.Name.String())
 }
 
+// commentKeyvals extracts the comma separated key=value
+// pairs of a table comment.
+func commentKeyvals(comment string) map[string]string {
+	keyvals := make(map[string]string)
+	inputs := strings.Split(comment, ",")
+	for _, input := range inputs {
+		kv := strings.Split(input, "=")
+		if len(kv) != 2 {
+			continue
+		}
+		keyvals[kv[0]] = kv[1]
+	}
+	return keyvals
+}
+
 func getDuration(in map[string]string, key string) (time.Duration, error) {
 	sv := in[key]
 	if sv == "" {
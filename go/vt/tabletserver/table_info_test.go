@@ -137,6 +137,42 @@ func TestTableInfoSequence(t *testing.T) {
 	}
 }
 
+func TestTableInfoRowCache(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	for query, result := range getTestTableInfoQueries() {
+		db.AddQuery(query, result)
+	}
+	tableInfo, err := newTestTableInfo("USER_TABLE", "vitess_rowcache", db)
+	if err != nil {
+		t.Fatalf("failed to create a test table info")
+	}
+	if !tableInfo.RowCache || tableInfo.Type != schema.NoType {
+		t.Errorf("RowCache, Type: %v, %v, want true, %v", tableInfo.RowCache, tableInfo.Type, schema.NoType)
+	}
+}
+
+func TestTableInfoQueryBudget(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	for query, result := range getTestTableInfoQueries() {
+		db.AddQuery(query, result)
+	}
+	tableInfo, err := newTestTableInfo("USER_TABLE", "vitess_rowcache,vt_query_budget=0.1", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tableInfo.RowCache || tableInfo.QueryBudget != 100*time.Millisecond {
+		t.Errorf("RowCache, QueryBudget: %v, %v, want true, 100ms", tableInfo.RowCache, tableInfo.QueryBudget)
+	}
+
+	_, err = newTestTableInfo("USER_TABLE", "vt_query_budget=abc", db)
+	want := "invalid vt_query_budget abc"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("newTestTableInfo: %v, must contain %s", err, want)
+	}
+}
+
 func TestTableInfoMessage(t *testing.T) {
 	db := fakesqldb.New(t)
 	defer db.Close()
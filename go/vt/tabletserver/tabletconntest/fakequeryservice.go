@@ -82,6 +82,7 @@ var TestExecuteOptions = &querypb.ExecuteOptions{
 		Shard:     "ssss",
 		Position:  "pppp",
 	},
+	TransactionIsolation: querypb.ExecuteOptions_READ_COMMITTED,
 }
 
 const TestAsTransaction bool = true
@@ -113,23 +114,29 @@ func (f *FakeQueryService) checkTargetCallerID(ctx context.Context, name string,
 const BeginTransactionID int64 = 9990
 
 // Begin is part of the queryservice.QueryService interface
-func (f *FakeQueryService) Begin(ctx context.Context, target *querypb.Target) (int64, error) {
+func (f *FakeQueryService) Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (int64, error) {
 	if f.HasBeginError {
 		return 0, f.TabletError
 	}
 	if f.Panics {
 		panic(fmt.Errorf("test-triggered panic"))
 	}
+	if !proto.Equal(options, TestExecuteOptions) {
+		f.t.Errorf("invalid Begin.ExecuteOptions: got %v expected %v", options, TestExecuteOptions)
+	}
 	f.checkTargetCallerID(ctx, "Begin", target)
 	return BeginTransactionID, nil
 }
 
 const CommitTransactionID int64 = 999044
 
+// CommitPosition is the position returned by Commit.
+const CommitPosition = "MariaDB/0-1-999044"
+
 // Commit is part of the queryservice.QueryService interface
-func (f *FakeQueryService) Commit(ctx context.Context, target *querypb.Target, transactionID int64) error {
+func (f *FakeQueryService) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (string, error) {
 	if f.HasError {
-		return f.TabletError
+		return "", f.TabletError
 	}
 	if f.Panics {
 		panic(fmt.Errorf("test-triggered panic"))
@@ -138,7 +145,7 @@ func (f *FakeQueryService) Commit(ctx context.Context, target *querypb.Target, t
 	if transactionID != CommitTransactionID {
 		f.t.Errorf("Commit: invalid TransactionId: got %v expected %v", transactionID, CommitTransactionID)
 	}
-	return nil
+	return CommitPosition, nil
 }
 
 const RollbackTransactionID int64 = 999044
@@ -579,7 +586,7 @@ var SplitQueryQuerySplitList = []querytypes.QuerySplit{
 
 // BeginExecute combines Begin and Execute.
 func (f *FakeQueryService) BeginExecute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, options *querypb.ExecuteOptions) (*sqltypes.Result, int64, error) {
-	transactionID, err := f.Begin(ctx, target)
+	transactionID, err := f.Begin(ctx, target, options)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -590,7 +597,7 @@ func (f *FakeQueryService) BeginExecute(ctx context.Context, target *querypb.Tar
 
 // BeginExecuteBatch combines Begin and ExecuteBatch.
 func (f *FakeQueryService) BeginExecuteBatch(ctx context.Context, target *querypb.Target, queries []querytypes.BoundQuery, asTransaction bool, options *querypb.ExecuteOptions) ([]sqltypes.Result, int64, error) {
-	transactionID, err := f.Begin(ctx, target)
+	transactionID, err := f.Begin(ctx, target, options)
 	if err != nil {
 		return nil, 0, err
 	}
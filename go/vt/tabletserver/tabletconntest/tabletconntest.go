@@ -93,7 +93,7 @@ func testBegin(t *testing.T, conn queryservice.QueryService, f *FakeQueryService
 	t.Log("testBegin")
 	ctx := context.Background()
 	ctx = callerid.NewContext(ctx, TestCallerID, TestVTGateCallerID)
-	transactionID, err := conn.Begin(ctx, TestTarget)
+	transactionID, err := conn.Begin(ctx, TestTarget, TestExecuteOptions)
 	if err != nil {
 		t.Fatalf("Begin failed: %v", err)
 	}
@@ -106,7 +106,7 @@ func testBeginError(t *testing.T, conn queryservice.QueryService, f *FakeQuerySe
 	t.Log("testBeginError")
 	f.HasBeginError = true
 	testErrorHelper(t, f, "Begin", func(ctx context.Context) error {
-		_, err := conn.Begin(ctx, TestTarget)
+		_, err := conn.Begin(ctx, TestTarget, TestExecuteOptions)
 		return err
 	})
 	f.HasBeginError = false
@@ -115,7 +115,7 @@ func testBeginError(t *testing.T, conn queryservice.QueryService, f *FakeQuerySe
 func testBeginPanics(t *testing.T, conn queryservice.QueryService, f *FakeQueryService) {
 	t.Log("testBeginPanics")
 	testPanicHelper(t, f, "Begin", func(ctx context.Context) error {
-		_, err := conn.Begin(ctx, TestTarget)
+		_, err := conn.Begin(ctx, TestTarget, TestExecuteOptions)
 		return err
 	})
 }
@@ -124,17 +124,21 @@ func testCommit(t *testing.T, conn queryservice.QueryService, f *FakeQueryServic
 	t.Log("testCommit")
 	ctx := context.Background()
 	ctx = callerid.NewContext(ctx, TestCallerID, TestVTGateCallerID)
-	err := conn.Commit(ctx, TestTarget, CommitTransactionID)
+	position, err := conn.Commit(ctx, TestTarget, CommitTransactionID)
 	if err != nil {
 		t.Fatalf("Commit failed: %v", err)
 	}
+	if position != CommitPosition {
+		t.Errorf("Unexpected result from Commit: got %v wanted %v", position, CommitPosition)
+	}
 }
 
 func testCommitError(t *testing.T, conn queryservice.QueryService, f *FakeQueryService) {
 	t.Log("testCommitError")
 	f.HasError = true
 	testErrorHelper(t, f, "Commit", func(ctx context.Context) error {
-		return conn.Commit(ctx, TestTarget, CommitTransactionID)
+		_, err := conn.Commit(ctx, TestTarget, CommitTransactionID)
+		return err
 	})
 	f.HasError = false
 }
@@ -142,7 +146,8 @@ func testCommitError(t *testing.T, conn queryservice.QueryService, f *FakeQueryS
 func testCommitPanics(t *testing.T, conn queryservice.QueryService, f *FakeQueryService) {
 	t.Log("testCommitPanics")
 	testPanicHelper(t, f, "Commit", func(ctx context.Context) error {
-		return conn.Commit(ctx, TestTarget, CommitTransactionID)
+		_, err := conn.Commit(ctx, TestTarget, CommitTransactionID)
+		return err
 	})
 }
 
@@ -43,6 +43,7 @@ func init() {
 	flag.Float64Var(&Config.SchemaReloadTime, "queryserver-config-schema-reload-time", DefaultQsConfig.SchemaReloadTime, "query server schema reload time, how often vttablet reloads schemas from underlying MySQL instance in seconds. vttablet keeps table schemas in its own memory and periodically refreshes it from MySQL. This config controls the reload time.")
 	flag.Float64Var(&Config.QueryTimeout, "queryserver-config-query-timeout", DefaultQsConfig.QueryTimeout, "query server query timeout (in seconds), this is the query timeout in vttablet side. If a query takes more than this timeout, it will be killed.")
 	flag.Float64Var(&Config.TxPoolTimeout, "queryserver-config-txpool-timeout", DefaultQsConfig.TxPoolTimeout, "query server transaction pool timeout, it is how long vttablet waits if tx pool is full")
+	flag.Float64Var(&Config.PositionWaitTimeout, "queryserver-config-position-wait-timeout", DefaultQsConfig.PositionWaitTimeout, "query server position wait timeout (in seconds), it is how long a non-master vttablet waits for replication to catch up with the wait_for_position ExecuteOptions before failing the query")
 	flag.Float64Var(&Config.IdleTimeout, "queryserver-config-idle-timeout", DefaultQsConfig.IdleTimeout, "query server idle timeout (in seconds), vttablet manages various mysql connection pools. This config means if a connection has not been used in given idle timeout, this connection will be removed from pool. This effectively manages number of connection objects and optimize the pool performance.")
 	flag.BoolVar(&Config.StrictMode, "queryserver-config-strict-mode", DefaultQsConfig.StrictMode, "allow only predictable DMLs and enforces MySQL's STRICT_TRANS_TABLES")
 	// tableacl related configurations.
@@ -52,6 +53,11 @@ func init() {
 	flag.BoolVar(&Config.TerseErrors, "queryserver-config-terse-errors", DefaultQsConfig.TerseErrors, "prevent bind vars from escaping in returned errors")
 	flag.StringVar(&Config.DebugURLPrefix, "debug-url-prefix", DefaultQsConfig.DebugURLPrefix, "debug url prefix, vttablet will report various system debug pages and this config controls the prefix of these debug urls")
 	flag.StringVar(&Config.PoolNamePrefix, "pool-name-prefix", DefaultQsConfig.PoolNamePrefix, "pool name prefix, vttablet has several pools and each of them has a name. This config specifies the prefix of these pool names")
+	flag.BoolVar(&Config.PublishSchema, "queryserver-config-publish-schema", DefaultQsConfig.PublishSchema, "if the flag is on, vttablet publishes the columns of all its tables in the health stream. vtgate uses this information to expand '*' expressions and resolve unqualified columns in joins.")
+	flag.BoolVar(&Config.EnableRowCache, "queryserver-config-rowcache-enable", DefaultQsConfig.EnableRowCache, "if the flag is on, vttablet caches the rows read by primary key point selects on the tables that have 'vitess_rowcache' in their comment. The cache is invalidated from the replication stream, so it requires -watch_replication_stream.")
+	flag.StringVar(&Config.RowCacheService, "queryserver-config-rowcache-service", DefaultQsConfig.RowCacheService, "the cacheservice implementation used by the row cache.")
+	flag.StringVar(&Config.RowCacheAddress, "queryserver-config-rowcache-address", DefaultQsConfig.RowCacheAddress, "the address of the row cache service, if it runs outside of vttablet.")
+	flag.Int64Var(&Config.RowCacheCapacity, "queryserver-config-rowcache-capacity", DefaultQsConfig.RowCacheCapacity, "the capacity in bytes of the row cache, if it runs inside vttablet.")
 	flag.BoolVar(&Config.WatchReplication, "watch_replication_stream", false, "When enabled, vttablet will stream the MySQL replication stream from the local server, and use it to support the include_event_token ExecuteOptions.")
 	flag.BoolVar(&Config.EnableAutoCommit, "enable-autocommit", DefaultQsConfig.EnableAutoCommit, "if the flag is on, a DML outsides a transaction will be auto committed.")
 	flag.BoolVar(&Config.TwoPCEnable, "twopc_enable", DefaultQsConfig.TwoPCEnable, "if the flag is on, 2pc is enabled. Other 2pc flags must be supplied.")
@@ -83,6 +89,7 @@ type TabletConfig struct {
 	SchemaReloadTime        float64
 	QueryTimeout            float64
 	TxPoolTimeout           float64
+	PositionWaitTimeout     float64
 	IdleTimeout             float64
 	StrictMode              bool
 	StrictTableAcl          bool
@@ -92,7 +99,12 @@ type TabletConfig struct {
 	DebugURLPrefix          string
 	PoolNamePrefix          string
 	TableAclExemptACL       string
+	PublishSchema           bool
 	WatchReplication        bool
+	EnableRowCache          bool
+	RowCacheService         string
+	RowCacheAddress         string
+	RowCacheCapacity        int64
 	TwoPCEnable             bool
 	TwoPCCoordinatorAddress string
 	TwoPCAbandonAge         float64
@@ -122,6 +134,7 @@ var DefaultQsConfig = TabletConfig{
 	SchemaReloadTime:        30 * 60,
 	QueryTimeout:            30,
 	TxPoolTimeout:           1,
+	PositionWaitTimeout:     1,
 	IdleTimeout:             30 * 60,
 	StreamBufferSize:        32 * 1024,
 	StrictMode:              true,
@@ -132,7 +145,12 @@ var DefaultQsConfig = TabletConfig{
 	DebugURLPrefix:          "/debug",
 	PoolNamePrefix:          "",
 	TableAclExemptACL:       "",
+	PublishSchema:           false,
 	WatchReplication:        false,
+	EnableRowCache:          false,
+	RowCacheService:         "localcache",
+	RowCacheAddress:         "",
+	RowCacheCapacity:        64 * 1024 * 1024,
 	TwoPCEnable:             false,
 	TwoPCCoordinatorAddress: "",
 	TwoPCAbandonAge:         0,
@@ -27,6 +27,8 @@ const (
 	QuerySourceConsolidator = 1 << iota
 	// QuerySourceMySQL means query result is returned from MySQL.
 	QuerySourceMySQL
+	// QuerySourceRowCache means query result is found in the row cache.
+	QuerySourceRowCache
 )
 
 // LogStats records the stats for a single query
@@ -48,6 +50,10 @@ type LogStats struct {
 	Rows                 [][]sqltypes.Value
 	TransactionID        int64
 	Error                *TabletError
+
+	// BudgetExceeded describes the budget of the query
+	// if it was killed for exceeding it.
+	BudgetExceeded string
 }
 
 // NewLogStats constructs a new LogStats with supplied Method and ctx
@@ -158,7 +164,7 @@ func (stats *LogStats) FmtQuerySources() string {
 	if stats.QuerySources == 0 {
 		return "none"
 	}
-	sources := make([]string, 2)
+	sources := make([]string, 3)
 	n := 0
 	if stats.QuerySources&QuerySourceMySQL != 0 {
 		sources[n] = "mysql"
@@ -168,6 +174,10 @@ func (stats *LogStats) FmtQuerySources() string {
 		sources[n] = "consolidator"
 		n++
 	}
+	if stats.QuerySources&QuerySourceRowCache != 0 {
+		sources[n] = "rowcache"
+		n++
+	}
 	return strings.Join(sources[:n], ",")
 }
 
@@ -86,6 +86,11 @@ func TestLogStatsFormatQuerySources(t *testing.T) {
 	if !strings.Contains(logStats.FmtQuerySources(), "consolidator") {
 		t.Fatalf("'consolidator' should be in formated query sources")
 	}
+
+	logStats.QuerySources |= QuerySourceRowCache
+	if got, want := logStats.FmtQuerySources(), "mysql,consolidator,rowcache"; got != want {
+		t.Fatalf("FmtQuerySources: %s, want %s", got, want)
+	}
 }
 
 func TestLogStatsContextHTML(t *testing.T) {
@@ -26,6 +26,9 @@ var (
 	WaitStats = stats.NewTimings("Waits")
 	// KillStats shows number of connections being killed.
 	KillStats = stats.NewCounters("Kills", "Transactions", "Queries")
+	// BudgetKills shows the number of queries killed for exceeding
+	// their budget, by the query rule or table that set the budget.
+	BudgetKills = stats.NewCounters("BudgetKills")
 	// InfoErrors shows number of various non critical errors happened.
 	InfoErrors = stats.NewCounters("InfoErrors", "Retry", "DupKey")
 	// ErrorStats shows number of critial erros happened.
@@ -42,6 +45,8 @@ var (
 	UserTransactionCount = stats.NewMultiCounters("UserTransactionCount", []string{"CallerID", "Conclusion"})
 	// UserTransactionTimesNs shows total transaction latency for each CallerID.
 	UserTransactionTimesNs = stats.NewMultiCounters("UserTransactionTimesNs", []string{"CallerID", "Conclusion"})
+	// RowCacheStats tracks the hits, misses and invalidations of the row cache per table.
+	RowCacheStats = stats.NewMultiCounters("RowCacheStats", []string{"TableName", "Stat"})
 	// ResultStats shows the histogram of number of rows returned.
 	ResultStats = stats.NewHistogram("Results", []int64{0, 1, 5, 10, 50, 100, 500, 1000, 5000, 10000})
 	// TableaclAllowed tracks the number allows.
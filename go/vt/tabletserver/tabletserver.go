@@ -9,6 +9,7 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -78,8 +79,9 @@ var stateName = []string{
 
 // TabletServer implements the RPC interface for the query service.
 type TabletServer struct {
-	QueryTimeout sync2.AtomicDuration
-	BeginTimeout sync2.AtomicDuration
+	QueryTimeout        sync2.AtomicDuration
+	BeginTimeout        sync2.AtomicDuration
+	PositionWaitTimeout sync2.AtomicDuration
 
 	// mu is used to access state. The lock should only be held
 	// for short periods. For longer periods, you have to transition
@@ -123,6 +125,7 @@ type TabletServer struct {
 	streamHealthIndex        int
 	streamHealthMap          map[int]chan<- *querypb.StreamHealthResponse
 	lastStreamHealthResponse *querypb.StreamHealthResponse
+	tableSchemas             []*querypb.TableSchema
 
 	// history records changes in state for display on the status page.
 	// It has its own internal mutex.
@@ -157,6 +160,7 @@ func NewTabletServer() *TabletServer {
 	tsv := &TabletServer{
 		QueryTimeout:        sync2.NewAtomicDuration(time.Duration(tabletenv.Config.QueryTimeout * 1e9)),
 		BeginTimeout:        sync2.NewAtomicDuration(time.Duration(tabletenv.Config.TxPoolTimeout * 1e9)),
+		PositionWaitTimeout: sync2.NewAtomicDuration(time.Duration(tabletenv.Config.PositionWaitTimeout * 1e9)),
 		checkMySQLThrottler: sync2.NewSemaphore(1, 0),
 		streamHealthMap:     make(map[int]chan<- *querypb.StreamHealthResponse),
 		history:             history.New(10),
@@ -176,6 +180,7 @@ func NewTabletServer() *TabletServer {
 		}))
 		stats.Publish("QueryTimeout", stats.DurationFunc(tsv.QueryTimeout.Get))
 		stats.Publish("BeginTimeout", stats.DurationFunc(tsv.BeginTimeout.Get))
+		stats.Publish("PositionWaitTimeout", stats.DurationFunc(tsv.PositionWaitTimeout.Get))
 		stats.Publish("TabletStateName", stats.StringFunc(tsv.GetState))
 	})
 	return tsv
@@ -390,6 +395,9 @@ func (tsv *TabletServer) fullStart() (err error) {
 	if err := tsv.qe.Open(tsv.dbconfigs); err != nil {
 		return err
 	}
+	if tabletenv.Config.PublishSchema {
+		tsv.qe.schemaInfo.RegisterNotifier("health", tsv.schemaChanged)
+	}
 	if err := tsv.te.Init(tsv.dbconfigs); err != nil {
 		return err
 	}
@@ -446,6 +454,7 @@ func (tsv *TabletServer) StopService() {
 
 	log.Infof("Executing complete shutdown.")
 	tsv.waitForShutdown()
+	tsv.qe.schemaInfo.UnregisterNotifier("health")
 	tsv.qe.Close()
 	log.Infof("Shutdown complete.")
 	tsv.transition(StateNotConnected)
@@ -474,6 +483,7 @@ func (tsv *TabletServer) closeAll() {
 	tsv.te.Close(true)
 	tsv.watcher.Close()
 	tsv.updateStreamList.Stop()
+	tsv.qe.schemaInfo.UnregisterNotifier("health")
 	tsv.qe.Close()
 	tsv.txThrottler.Close()
 	tsv.transition(StateNotConnected)
@@ -579,7 +589,7 @@ func (tsv *TabletServer) QueryService() queryservice.QueryService {
 }
 
 // Begin starts a new transaction. This is allowed only if the state is StateServing.
-func (tsv *TabletServer) Begin(ctx context.Context, target *querypb.Target) (transactionID int64, err error) {
+func (tsv *TabletServer) Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (transactionID int64, err error) {
 	err = tsv.execRequest(
 		ctx, tsv.BeginTimeout.Get(),
 		"Begin", "begin", nil,
@@ -589,7 +599,7 @@ func (tsv *TabletServer) Begin(ctx context.Context, target *querypb.Target) (tra
 			if tsv.txThrottler.Throttle() {
 				return tabletenv.NewTabletError(vtrpcpb.ErrorCode_TRANSIENT_ERROR, "Transaction throttled")
 			}
-			transactionID, err = tsv.te.txPool.Begin(ctx)
+			transactionID, err = tsv.te.txPool.Begin(ctx, options)
 			logStats.TransactionID = transactionID
 			return err
 		},
@@ -597,18 +607,32 @@ func (tsv *TabletServer) Begin(ctx context.Context, target *querypb.Target) (tra
 	return transactionID, err
 }
 
-// Commit commits the specified transaction.
-func (tsv *TabletServer) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (err error) {
-	return tsv.execRequest(
+// Commit commits the specified transaction. If the transaction was
+// started with IncludePosition, the position of the master after the
+// commit is returned.
+func (tsv *TabletServer) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (position string, err error) {
+	err = tsv.execRequest(
 		ctx, tsv.QueryTimeout.Get(),
 		"Commit", "commit", nil,
 		target, true, true,
 		func(ctx context.Context, logStats *tabletenv.LogStats) error {
 			defer tabletenv.QueryStats.Record("COMMIT", time.Now())
 			logStats.TransactionID = transactionID
-			return tsv.te.txPool.Commit(ctx, transactionID, tsv.messager)
+			conn, err := tsv.te.txPool.Get(transactionID, "for commit")
+			if err != nil {
+				return err
+			}
+			includePosition := conn.IncludePosition
+			if err := tsv.te.txPool.LocalCommit(ctx, conn, tsv.messager); err != nil {
+				return err
+			}
+			if includePosition {
+				position = tsv.masterPosition()
+			}
+			return nil
 		},
 	)
+	return position, err
 }
 
 // Rollback rollsback the specified transaction.
@@ -777,8 +801,10 @@ func (tsv *TabletServer) ReadTransaction(ctx context.Context, target *querypb.Ta
 // Execute executes the query and returns the result as response.
 func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, transactionID int64, options *querypb.ExecuteOptions) (result *sqltypes.Result, err error) {
 	allowOnShutdown := (transactionID != 0)
+	// The timeout is applied by the callback because
+	// the budget of the query can extend it.
 	err = tsv.execRequest(
-		ctx, tsv.QueryTimeout.Get(),
+		ctx, 0,
 		"Execute", sql, bindVariables,
 		target, false, allowOnShutdown,
 		func(ctx context.Context, logStats *tabletenv.LogStats) error {
@@ -786,7 +812,10 @@ func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sq
 				bindVariables = make(map[string]interface{})
 			}
 			sql = stripTrailing(sql, bindVariables)
-			plan, err := tsv.qe.schemaInfo.GetPlan(ctx, logStats, sql)
+			timeout := tsv.QueryTimeout.Get()
+			planCtx, cancel := withTimeout(ctx, timeout)
+			plan, err := tsv.qe.schemaInfo.GetPlan(planCtx, logStats, sql)
+			cancel()
 			if err != nil {
 				return err
 			}
@@ -801,12 +830,27 @@ func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sq
 				te:            tsv.te,
 				messager:      tsv.messager,
 			}
+			qre.budget = qre.getBudget()
+			if qre.budget.limit > timeout {
+				timeout = qre.budget.limit
+			}
+			ctx, cancel = withTimeout(ctx, timeout)
+			defer cancel()
+			qre.ctx = ctx
+			if err := tsv.waitForPosition(ctx, options); err != nil {
+				return err
+			}
 			extras := tsv.watcher.ComputeExtras(options)
 			result, err = qre.Execute()
 			if err != nil {
 				return err
 			}
 			result.Extras = extras
+			// Inside a transaction, the position is only
+			// known once it's committed.
+			if options != nil && options.IncludePosition && transactionID == 0 {
+				tsv.addPosition(result)
+			}
 			result = result.StripMetadata(sqltypes.IncludeFieldsOrDefault(options))
 			return nil
 		},
@@ -814,9 +858,89 @@ func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sq
 	return result, err
 }
 
+// waitForPosition blocks until mysql has replicated up to the
+// wait_for_position of the options, for at most PositionWaitTimeout.
+// It returns a QUERY_NOT_SERVED error if the position is not reached,
+// so the caller can try another tablet. Masters never wait.
+func (tsv *TabletServer) waitForPosition(ctx context.Context, options *querypb.ExecuteOptions) error {
+	if options == nil || options.WaitForPosition == "" {
+		return nil
+	}
+	tsv.mu.Lock()
+	tabletType := tsv.target.TabletType
+	tsv.mu.Unlock()
+	if tabletType == topodatapb.TabletType_MASTER {
+		return nil
+	}
+	pos, err := replication.DecodePosition(options.WaitForPosition)
+	if err != nil {
+		return tabletenv.NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "invalid wait_for_position %v: %v", options.WaitForPosition, err)
+	}
+
+	// If the replication watcher is running, it may already
+	// know we're past the position.
+	if et := tsv.watcher.EventToken(); et != nil {
+		if current, err := replication.DecodePosition(et.Position); err == nil && current.AtLeast(pos) {
+			return nil
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, tsv.PositionWaitTimeout.Get())
+	defer cancel()
+	if err := tsv.mysqld.WaitMasterPos(waitCtx, pos); err != nil {
+		tabletenv.InfoErrors.Add("PositionWait", 1)
+		return tabletenv.NewTabletError(vtrpcpb.ErrorCode_QUERY_NOT_SERVED, "replication position %v not reached: %v", options.WaitForPosition, err)
+	}
+	return nil
+}
+
+// addPosition adds the current replication position of a master to
+// the extras of a result.
+func (tsv *TabletServer) addPosition(result *sqltypes.Result) {
+	position := tsv.masterPosition()
+	if position == "" {
+		return
+	}
+	if result.Extras == nil {
+		result.Extras = &querypb.ResultExtras{}
+	}
+	result.Extras.Position = position
+}
+
+// masterPosition returns the current replication position if the
+// tablet is a master, or "" otherwise. This is best effort: vtgate
+// will just not be able to wait for a write if the position is unknown.
+func (tsv *TabletServer) masterPosition() string {
+	tsv.mu.Lock()
+	tabletType := tsv.target.TabletType
+	tsv.mu.Unlock()
+	if tabletType != topodatapb.TabletType_MASTER {
+		return ""
+	}
+	pos, err := tsv.mysqld.MasterPosition()
+	if err != nil {
+		log.Warningf("Cannot get the master position: %v", err)
+		return ""
+	}
+	return replication.EncodePosition(pos)
+}
+
+// replicationPosition returns the current replication position of the
+// tablet, whatever its type.
+func (tsv *TabletServer) replicationPosition() (string, error) {
+	pos, err := tsv.mysqld.MasterPosition()
+	if err != nil {
+		return "", tabletenv.NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "cannot get the replication position: %v", err)
+	}
+	return replication.EncodePosition(pos), nil
+}
+
 // StreamExecute executes the query and streams the result.
 // The first QueryResult will have Fields set (and Rows nil).
 // The subsequent QueryResult will have Rows set (and Fields nil).
+// With the CONSISTENT_SNAPSHOT_READ_ONLY transaction isolation, the
+// query reads a consistent snapshot of its table, and the first
+// QueryResult carries the replication position of the snapshot.
 func (tsv *TabletServer) StreamExecute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, options *querypb.ExecuteOptions, callback func(*sqltypes.Result) error) (err error) {
 	return tsv.execRequest(
 		ctx, 0,
@@ -826,6 +950,9 @@ func (tsv *TabletServer) StreamExecute(ctx context.Context, target *querypb.Targ
 			if bindVariables == nil {
 				bindVariables = make(map[string]interface{})
 			}
+			if err := tsv.waitForPosition(ctx, options); err != nil {
+				return err
+			}
 			sql = stripTrailing(sql, bindVariables)
 			plan, err := tsv.qe.schemaInfo.GetStreamPlan(sql)
 			if err != nil {
@@ -841,6 +968,10 @@ func (tsv *TabletServer) StreamExecute(ctx context.Context, target *querypb.Targ
 				te:       tsv.te,
 				messager: tsv.messager,
 			}
+			qre.budget = qre.getBudget()
+			if options != nil && options.TransactionIsolation == querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY {
+				return qre.StreamSnapshot(tsv.replicationPosition, sqltypes.IncludeFieldsOrDefault(options), callback)
+			}
 			return qre.Stream(sqltypes.IncludeFieldsOrDefault(options), callback)
 		},
 	)
@@ -866,7 +997,7 @@ func (tsv *TabletServer) ExecuteBatch(ctx context.Context, target *querypb.Targe
 	defer tsv.handlePanicAndSendLogStats("batch", nil, &err, nil)
 
 	if asTransaction {
-		transactionID, err = tsv.Begin(ctx, target)
+		transactionID, err = tsv.Begin(ctx, target, options)
 		if err != nil {
 			return nil, tsv.handleError("batch", nil, err, nil)
 		}
@@ -887,18 +1018,27 @@ func (tsv *TabletServer) ExecuteBatch(ctx context.Context, target *querypb.Targe
 		results = append(results, *localReply)
 	}
 	if asTransaction {
-		if err = tsv.Commit(ctx, target, transactionID); err != nil {
+		var position string
+		if position, err = tsv.Commit(ctx, target, transactionID); err != nil {
 			transactionID = 0
 			return nil, tsv.handleError("batch", nil, err, nil)
 		}
 		transactionID = 0
+		// The position of the commit is returned with the last result.
+		if position != "" {
+			last := &results[len(results)-1]
+			if last.Extras == nil {
+				last.Extras = &querypb.ResultExtras{}
+			}
+			last.Extras.Position = position
+		}
 	}
 	return results, nil
 }
 
 // BeginExecute combines Begin and Execute.
 func (tsv *TabletServer) BeginExecute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, options *querypb.ExecuteOptions) (*sqltypes.Result, int64, error) {
-	transactionID, err := tsv.Begin(ctx, target)
+	transactionID, err := tsv.Begin(ctx, target, options)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -909,7 +1049,7 @@ func (tsv *TabletServer) BeginExecute(ctx context.Context, target *querypb.Targe
 
 // BeginExecuteBatch combines Begin and ExecuteBatch.
 func (tsv *TabletServer) BeginExecuteBatch(ctx context.Context, target *querypb.Target, queries []querytypes.BoundQuery, asTransaction bool, options *querypb.ExecuteOptions) ([]sqltypes.Result, int64, error) {
-	transactionID, err := tsv.Begin(ctx, target)
+	transactionID, err := tsv.Begin(ctx, target, options)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -987,7 +1127,7 @@ func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, qu
 		return 0, tabletenv.NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "%v", err)
 	}
 
-	transactionID, err := tsv.Begin(ctx, target)
+	transactionID, err := tsv.Begin(ctx, target, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -1002,7 +1142,7 @@ func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, qu
 	if err != nil {
 		return 0, err
 	}
-	if err = tsv.Commit(ctx, target, transactionID); err != nil {
+	if _, err = tsv.Commit(ctx, target, transactionID); err != nil {
 		transactionID = 0
 		return 0, err
 	}
@@ -1256,7 +1396,8 @@ func validateSplitQueryParameters(
 			querytypes.QueryAsString(query.Sql, query.BindVariables))
 	}
 	if algorithm != querypb.SplitQueryRequest_EQUAL_SPLITS &&
-		algorithm != querypb.SplitQueryRequest_FULL_SCAN {
+		algorithm != querypb.SplitQueryRequest_FULL_SCAN &&
+		algorithm != querypb.SplitQueryRequest_SAMPLING {
 		return tabletenv.NewTabletError(
 			vtrpcpb.ErrorCode_BAD_INPUT,
 			"splitquery: unsupported algorithm: %v. SQL: %v",
@@ -1357,6 +1498,8 @@ func createSplitQueryAlgorithmObject(
 		return splitquery.NewFullScanAlgorithm(splitParams, sqlExecuter)
 	case querypb.SplitQueryRequest_EQUAL_SPLITS:
 		return splitquery.NewEqualSplitsAlgorithm(splitParams, sqlExecuter)
+	case querypb.SplitQueryRequest_SAMPLING:
+		return splitquery.NewSamplingAlgorithm(splitParams, sqlExecuter)
 	default:
 		panic(fmt.Errorf("Unknown algorithm enum: %+v", algorithm))
 	}
@@ -1438,6 +1581,13 @@ func (tsv *TabletServer) BroadcastHealth(terTimestamp int64, stats *querypb.Real
 
 	tsv.streamHealthMutex.Lock()
 	defer tsv.streamHealthMutex.Unlock()
+	shr.TableSchemas = tsv.tableSchemas
+	tsv.broadcastHealthLocked(shr)
+}
+
+// broadcastHealthLocked sends shr to all listeners and saves it
+// for new ones. It must be called while holding streamHealthMutex.
+func (tsv *TabletServer) broadcastHealthLocked(shr *querypb.StreamHealthResponse) {
 	for _, c := range tsv.streamHealthMap {
 		// Do not block on any write.
 		select {
@@ -1448,6 +1598,43 @@ func (tsv *TabletServer) BroadcastHealth(terTimestamp int64, stats *querypb.Real
 	tsv.lastStreamHealthResponse = shr
 }
 
+// schemaChanged is the SchemaInfo notifier that keeps the table
+// schemas published in the health stream up to date. The last health
+// response is sent again with the new schema, so that vtgates don't
+// have to wait for the next health check to see the change.
+func (tsv *TabletServer) schemaChanged(tables map[string]*TableInfo) {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	schemas := make([]*querypb.TableSchema, 0, len(tables))
+	for _, name := range names {
+		table := tables[name]
+		ts := &querypb.TableSchema{
+			Name:    name,
+			Columns: make([]*querypb.Field, 0, len(table.Columns)),
+		}
+		for _, col := range table.Columns {
+			ts.Columns = append(ts.Columns, &querypb.Field{
+				Name: col.Name.String(),
+				Type: col.Type,
+			})
+		}
+		schemas = append(schemas, ts)
+	}
+
+	tsv.streamHealthMutex.Lock()
+	defer tsv.streamHealthMutex.Unlock()
+	tsv.tableSchemas = schemas
+	if tsv.lastStreamHealthResponse == nil {
+		return
+	}
+	shr := *tsv.lastStreamHealthResponse
+	shr.TableSchemas = schemas
+	tsv.broadcastHealthLocked(&shr)
+}
+
 // UpdateStream streams binlog events.
 func (tsv *TabletServer) UpdateStream(ctx context.Context, target *querypb.Target, position string, timestamp int64, callback func(*querypb.StreamEvent) error) error {
 	// Parse the position if needed.
@@ -21,9 +21,12 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/gitql/vitess/go/mysqlconn"
 	"github.com/gitql/vitess/go/mysqlconn/fakesqldb"
+	"github.com/gitql/vitess/go/mysqlconn/replication"
 	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/mysqlctl"
 	"github.com/gitql/vitess/go/vt/tabletserver/querytypes"
 	"github.com/gitql/vitess/go/vt/tabletserver/tabletenv"
+	"github.com/gitql/vitess/go/vt/vterrors"
 
 	querypb "github.com/gitql/vitess/go/vt/proto/query"
 	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
@@ -555,12 +558,12 @@ func TestTabletServerTarget(t *testing.T) {
 
 	// Disallow tx statements if non-master.
 	tsv.SetServingType(topodatapb.TabletType_REPLICA, true, nil)
-	_, err = tsv.Begin(ctx, &target1)
+	_, err = tsv.Begin(ctx, &target1, nil)
 	want = "transactional statement disallowed on non-master tablet"
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("err: %v, must contain %s", err, want)
 	}
-	err = tsv.Commit(ctx, &target1, 1)
+	_, err = tsv.Commit(ctx, &target1, 1)
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("err: %v, must contain %s", err, want)
 	}
@@ -580,7 +583,7 @@ func TestTabletServerStopWithPrepare(t *testing.T) {
 	defer db.Close()
 	ctx := context.Background()
 	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
-	transactionID, err := tsv.Begin(ctx, &target)
+	transactionID, err := tsv.Begin(ctx, &target, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -623,7 +626,7 @@ func TestTabletServerMasterToReplica(t *testing.T) {
 	defer db.Close()
 	ctx := context.Background()
 	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
-	txid1, err := tsv.Begin(ctx, &target)
+	txid1, err := tsv.Begin(ctx, &target, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -633,7 +636,7 @@ func TestTabletServerMasterToReplica(t *testing.T) {
 	if err = tsv.Prepare(ctx, &target, txid1, "aa"); err != nil {
 		t.Error(err)
 	}
-	txid2, err := tsv.Begin(ctx, &target)
+	txid2, err := tsv.Begin(ctx, &target, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -957,8 +960,8 @@ func TestTabletServerBeginFail(t *testing.T) {
 	defer tsv.StopService()
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Nanosecond)
 	defer cancel()
-	tsv.Begin(ctx, &target)
-	_, err = tsv.Begin(ctx, &target)
+	tsv.Begin(ctx, &target, nil)
+	_, err = tsv.Begin(ctx, &target, nil)
 	want := "tx_pool_full: Transaction pool connection limit exceeded"
 	if err == nil || err.Error() != want {
 		t.Fatalf("Begin err: %v, want %v", err, want)
@@ -991,14 +994,14 @@ func TestTabletServerCommitTransaction(t *testing.T) {
 	}
 	defer tsv.StopService()
 	ctx := context.Background()
-	transactionID, err := tsv.Begin(ctx, &target)
+	transactionID, err := tsv.Begin(ctx, &target, nil)
 	if err != nil {
 		t.Fatalf("call TabletServer.Begin failed: %v", err)
 	}
 	if _, err := tsv.Execute(ctx, &target, executeSQL, nil, transactionID, nil); err != nil {
 		t.Fatalf("failed to execute query: %s: %s", executeSQL, err)
 	}
-	if err := tsv.Commit(ctx, &target, transactionID); err != nil {
+	if _, err := tsv.Commit(ctx, &target, transactionID); err != nil {
 		t.Fatalf("call TabletServer.Commit failed: %v", err)
 	}
 }
@@ -1017,7 +1020,7 @@ func TestTabletServerCommiRollbacktFail(t *testing.T) {
 	}
 	defer tsv.StopService()
 	ctx := context.Background()
-	err = tsv.Commit(ctx, &target, -1)
+	_, err = tsv.Commit(ctx, &target, -1)
 	want := "not_in_tx: Transaction -1: not found"
 	if err == nil || err.Error() != want {
 		t.Fatalf("Commit err: %v, want %v", err, want)
@@ -1054,7 +1057,7 @@ func TestTabletServerRollback(t *testing.T) {
 	}
 	defer tsv.StopService()
 	ctx := context.Background()
-	transactionID, err := tsv.Begin(ctx, &target)
+	transactionID, err := tsv.Begin(ctx, &target, nil)
 	if err != nil {
 		t.Fatalf("call TabletServer.Begin failed: %v", err)
 	}
@@ -1073,7 +1076,7 @@ func TestTabletServerPrepare(t *testing.T) {
 	defer tsv.StopService()
 	ctx := context.Background()
 	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
-	transactionID, err := tsv.Begin(ctx, &target)
+	transactionID, err := tsv.Begin(ctx, &target, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1093,7 +1096,7 @@ func TestTabletServerCommitPrepared(t *testing.T) {
 	defer tsv.StopService()
 	ctx := context.Background()
 	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
-	transactionID, err := tsv.Begin(ctx, &target)
+	transactionID, err := tsv.Begin(ctx, &target, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1116,7 +1119,7 @@ func TestTabletServerRollbackPrepared(t *testing.T) {
 	defer tsv.StopService()
 	ctx := context.Background()
 	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
-	transactionID, err := tsv.Begin(ctx, &target)
+	transactionID, err := tsv.Begin(ctx, &target, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1165,6 +1168,75 @@ func TestTabletServerStreamExecute(t *testing.T) {
 	}
 }
 
+func TestTabletServerStreamExecuteSnapshot(t *testing.T) {
+	db := setUpTabletServerTest(t)
+	defer db.Close()
+	testUtils := newTestUtils()
+	executeSQL := "select * from test_table limit 1000"
+	db.AddQuery(executeSQL, &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Type: sqltypes.VarBinary},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("row01"))},
+		},
+	})
+	snapshotQueries := []string{
+		"lock tables test_table read",
+		"start transaction with consistent snapshot, read only",
+		"unlock tables",
+		"rollback",
+	}
+	for _, query := range snapshotQueries {
+		db.AddQuery(query, &sqltypes.Result{})
+	}
+
+	_ = testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer()
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_RDONLY}
+	err := tsv.StartService(target, dbconfigs, testUtils.newMysqld(&dbconfigs))
+	if err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+	fmd := mysqlctl.NewFakeMysqlDaemon(nil)
+	fmd.CurrentMasterPosition = replication.MustParsePosition("MariaDB", "0-1-100")
+	tsv.mysqld = fmd
+
+	ctx := context.Background()
+	options := &querypb.ExecuteOptions{
+		TransactionIsolation: querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY,
+	}
+	var results []*sqltypes.Result
+	callback := func(result *sqltypes.Result) error {
+		results = append(results, result)
+		return nil
+	}
+	if err := tsv.StreamExecute(ctx, &target, executeSQL, nil, options, callback); err != nil {
+		t.Fatalf("StreamExecute(%v) failed: %v", executeSQL, err)
+	}
+	if len(results) == 0 || results[0].Extras == nil {
+		t.Fatalf("StreamExecute(%v) returned no position: %v", executeSQL, results)
+	}
+	if got, want := results[0].Extras.Position, "MariaDB/0-1-100"; got != want {
+		t.Errorf("snapshot position: %v, want %v", got, want)
+	}
+	for _, query := range snapshotQueries {
+		if got := db.GetQueryCalledNum(query); got != 1 {
+			t.Errorf("%v was executed %v times, want 1", query, got)
+		}
+	}
+
+	// Snapshots need a single table.
+	joinSQL := "select * from test_table, test_table2"
+	err = tsv.StreamExecute(ctx, &target, joinSQL, nil, options, callback)
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_BAD_INPUT {
+		t.Errorf("StreamExecute(%v): %v, want BAD_INPUT", joinSQL, err)
+	}
+}
+
 func TestTabletServerExecuteBatch(t *testing.T) {
 	db := setUpTabletServerTest(t)
 	defer db.Close()
@@ -1684,6 +1756,49 @@ func TestTabletServerSplitQuery(t *testing.T) {
 	}
 }
 
+func TestTabletServerSplitQuerySampling(t *testing.T) {
+	db := setUpTabletServerTest(t)
+	defer db.Close()
+	// The table is estimated to have fewer rows than the split count,
+	// so each query part has a single row.
+	db.AddQuery("select pk from test_table order by pk asc limit 1, 1", &sqltypes.Result{
+		Fields:       []*querypb.Field{{Name: "pk", Type: sqltypes.Int32}},
+		RowsAffected: 1,
+		Rows:         [][]sqltypes.Value{{sqltypes.MakeTrusted(sqltypes.Int32, []byte("2"))}},
+	})
+	db.AddQuery("select pk from test_table where 2 < pk order by pk asc limit 0, 1", &sqltypes.Result{
+		Fields:       []*querypb.Field{{Name: "pk", Type: sqltypes.Int32}},
+		RowsAffected: 1,
+		Rows:         [][]sqltypes.Value{{sqltypes.MakeTrusted(sqltypes.Int32, []byte("3"))}},
+	})
+	testUtils := newTestUtils()
+	_ = testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer()
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_RDONLY}
+	err := tsv.StartService(target, dbconfigs, testUtils.newMysqld(&dbconfigs))
+	if err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+	ctx := context.Background()
+	sql := "select * from test_table where count > :count"
+	splits, err := tsv.SplitQuery(
+		ctx,
+		&querypb.Target{TabletType: topodatapb.TabletType_RDONLY},
+		querytypes.BoundQuery{Sql: sql},
+		[]string{}, /* splitColumns */
+		3,          /* splitCount */
+		0,          /* numRowsPerQueryPart */
+		querypb.SplitQueryRequest_SAMPLING)
+	if err != nil {
+		t.Fatalf("TabletServer.SplitQuery should succeed: %v, but get error: %v", sql, err)
+	}
+	if len(splits) != 3 {
+		t.Fatalf("got: %v, want: %v.\nsplits: %+v", len(splits), 3, splits)
+	}
+}
+
 func TestTabletServerSplitQueryInvalidQuery(t *testing.T) {
 	db := setUpTabletServerTest(t)
 	defer db.Close()
@@ -1776,6 +1891,52 @@ func TestTabletServerSplitQueryEqualSplitsOnStringColumn(t *testing.T) {
 	}
 }
 
+func TestTabletServerWaitForPosition(t *testing.T) {
+	testUtils := newTestUtils()
+	_ = testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer()
+	fmd := mysqlctl.NewFakeMysqlDaemon(nil)
+	tsv.mysqld = fmd
+	tsv.target = querypb.Target{TabletType: topodatapb.TabletType_REPLICA}
+	ctx := context.Background()
+
+	pos := replication.MustParsePosition("MariaDB", "0-1-100")
+	fmd.WaitMasterPosition = pos
+	options := &querypb.ExecuteOptions{WaitForPosition: replication.EncodePosition(pos)}
+	if err := tsv.waitForPosition(ctx, options); err != nil {
+		t.Errorf("waitForPosition: %v", err)
+	}
+
+	// Position not reached.
+	options.WaitForPosition = "MariaDB/0-1-101"
+	err := tsv.waitForPosition(ctx, options)
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_QUERY_NOT_SERVED {
+		t.Errorf("waitForPosition: %v, want QUERY_NOT_SERVED", err)
+	}
+
+	// Invalid position.
+	options.WaitForPosition = "MariaDB/bad"
+	err = tsv.waitForPosition(ctx, options)
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_BAD_INPUT {
+		t.Errorf("waitForPosition: %v, want BAD_INPUT", err)
+	}
+
+	// Masters never wait.
+	tsv.target.TabletType = topodatapb.TabletType_MASTER
+	options.WaitForPosition = "MariaDB/0-1-101"
+	if err := tsv.waitForPosition(ctx, options); err != nil {
+		t.Errorf("waitForPosition: %v", err)
+	}
+
+	// Masters report their position.
+	fmd.CurrentMasterPosition = pos
+	result := &sqltypes.Result{}
+	tsv.addPosition(result)
+	if got, want := result.Extras.Position, "MariaDB/0-1-100"; got != want {
+		t.Errorf("addPosition: %v, want %v", got, want)
+	}
+}
+
 func TestHandleExecUnknownError(t *testing.T) {
 	ctx := context.Background()
 	logStats := tabletenv.NewLogStats(ctx, "TestHandleExecError")
@@ -42,6 +42,16 @@ const txLogInterval = time.Duration(1 * time.Minute)
 var (
 	txOnce  sync.Once
 	txStats = stats.NewTimings("Transactions")
+
+	// txIsolations maps the requested isolation levels to the statement
+	// that sets them for the next transaction.
+	txIsolations = map[querypb.ExecuteOptions_TransactionIsolation]string{
+		querypb.ExecuteOptions_REPEATABLE_READ:               "set transaction isolation level repeatable read",
+		querypb.ExecuteOptions_READ_COMMITTED:                "set transaction isolation level read committed",
+		querypb.ExecuteOptions_READ_UNCOMMITTED:              "set transaction isolation level read uncommitted",
+		querypb.ExecuteOptions_SERIALIZABLE:                  "set transaction isolation level serializable",
+		querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY: "set transaction isolation level repeatable read",
+	}
 )
 
 // TxPool is the transaction pool for the query service.
@@ -138,7 +148,9 @@ func (axp *TxPool) WaitForEmpty() {
 
 // Begin begins a transaction, and returns the associated transaction id.
 // Subsequent statements can access the connection through the transaction id.
-func (axp *TxPool) Begin(ctx context.Context) (int64, error) {
+// The transaction is started with the isolation level
+// requested in the options, if any.
+func (axp *TxPool) Begin(ctx context.Context, options *querypb.ExecuteOptions) (int64, error) {
 	conn, err := axp.conns.Get(ctx)
 	if err != nil {
 		switch err {
@@ -150,21 +162,32 @@ func (axp *TxPool) Begin(ctx context.Context) (int64, error) {
 		}
 		return 0, tabletenv.NewTabletErrorSQL(vtrpcpb.ErrorCode_INTERNAL_ERROR, err)
 	}
-	if _, err := conn.Exec(ctx, "begin", 1, false); err != nil {
+	beginSQL := "begin"
+	if options != nil {
+		if isolationSQL, ok := txIsolations[options.TransactionIsolation]; ok {
+			if _, err := conn.Exec(ctx, isolationSQL, 1, false); err != nil {
+				conn.Recycle()
+				return 0, tabletenv.NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err)
+			}
+		}
+		if options.TransactionIsolation == querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY {
+			beginSQL = "start transaction with consistent snapshot, read only"
+		}
+	}
+	if _, err := conn.Exec(ctx, beginSQL, 1, false); err != nil {
 		conn.Recycle()
 		return 0, tabletenv.NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err)
 	}
 	transactionID := axp.lastID.Add(1)
-	axp.activePool.Register(
+	txConn := newTxConnection(
+		conn,
 		transactionID,
-		newTxConnection(
-			conn,
-			transactionID,
-			axp,
-			callerid.ImmediateCallerIDFromContext(ctx),
-			callerid.EffectiveCallerIDFromContext(ctx),
-		),
+		axp,
+		callerid.ImmediateCallerIDFromContext(ctx),
+		callerid.EffectiveCallerIDFromContext(ctx),
 	)
+	txConn.IncludePosition = options != nil && options.IncludePosition
+	axp.activePool.Register(transactionID, txConn)
 	return transactionID, nil
 }
 
@@ -200,7 +223,7 @@ func (axp *TxPool) Get(transactionID int64, reason string) (*TxConnection, error
 // It's used for executing transactions within a request. It's safe
 // to always call LocalConclude at the end.
 func (axp *TxPool) LocalBegin(ctx context.Context) (*TxConnection, error) {
-	transactionID, err := axp.Begin(ctx)
+	transactionID, err := axp.Begin(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -280,6 +303,9 @@ type TxConnection struct {
 	LogToFile         sync2.AtomicInt32
 	ImmediateCallerID *querypb.VTGateCallerID
 	EffectiveCallerID *vtrpcpb.CallerID
+	// IncludePosition is set if the position of the master
+	// must be returned when the transaction is committed.
+	IncludePosition bool
 }
 
 func newTxConnection(conn *connpool.DBConn, transactionID int64, pool *TxPool, immediate *querypb.VTGateCallerID, effective *vtrpcpb.CallerID) *TxConnection {
@@ -16,6 +16,8 @@ import (
 	"github.com/gitql/vitess/go/mysqlconn/fakesqldb"
 	"github.com/gitql/vitess/go/sqltypes"
 	"github.com/gitql/vitess/go/vt/tabletserver/tabletenv"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
 )
 
 func TestTxPoolExecuteRollback(t *testing.T) {
@@ -30,7 +32,7 @@ func TestTxPoolExecuteRollback(t *testing.T) {
 	txPool.Open(db.ConnParams(), db.ConnParams())
 	defer txPool.Close()
 	ctx := context.Background()
-	transactionID, err := txPool.Begin(ctx)
+	transactionID, err := txPool.Begin(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -47,6 +49,46 @@ func TestTxPoolExecuteRollback(t *testing.T) {
 	}
 }
 
+func TestTxPoolBeginWithIsolation(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	db.AddQuery("set transaction isolation level read committed", &sqltypes.Result{})
+	db.AddQuery("set transaction isolation level repeatable read", &sqltypes.Result{})
+	db.AddQuery("begin", &sqltypes.Result{})
+	db.AddQuery("start transaction with consistent snapshot, read only", &sqltypes.Result{})
+	db.AddQuery("rollback", &sqltypes.Result{})
+
+	txPool := newTxPool()
+	txPool.Open(db.ConnParams(), db.ConnParams())
+	defer txPool.Close()
+	ctx := context.Background()
+
+	transactionID, err := txPool.Begin(ctx, &querypb.ExecuteOptions{
+		TransactionIsolation: querypb.ExecuteOptions_READ_COMMITTED,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txPool.Rollback(ctx, transactionID)
+	if got := db.GetQueryCalledNum("set transaction isolation level read committed"); got != 1 {
+		t.Errorf("isolation level set %d times, want 1", got)
+	}
+
+	transactionID, err = txPool.Begin(ctx, &querypb.ExecuteOptions{
+		TransactionIsolation: querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txPool.Rollback(ctx, transactionID)
+	if got := db.GetQueryCalledNum("start transaction with consistent snapshot, read only"); got != 1 {
+		t.Errorf("consistent snapshot started %d times, want 1", got)
+	}
+	if got := db.GetQueryCalledNum("begin"); got != 1 {
+		t.Errorf("begin called %d times, want 1", got)
+	}
+}
+
 func TestTxPoolRollbackNonBusy(t *testing.T) {
 	db := fakesqldb.New(t)
 	defer db.Close()
@@ -57,11 +99,11 @@ func TestTxPoolRollbackNonBusy(t *testing.T) {
 	txPool.Open(db.ConnParams(), db.ConnParams())
 	defer txPool.Close()
 	ctx := context.Background()
-	txid1, err := txPool.Begin(ctx)
+	txid1, err := txPool.Begin(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = txPool.Begin(ctx)
+	_, err = txPool.Begin(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -96,7 +138,7 @@ func TestTxPoolTransactionKiller(t *testing.T) {
 	defer txPool.Close()
 	ctx := context.Background()
 	killCount := tabletenv.KillStats.Counts()["Transactions"]
-	transactionID, err := txPool.Begin(ctx)
+	transactionID, err := txPool.Begin(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -122,7 +164,7 @@ func TestTxPoolBeginAfterConnPoolClosed(t *testing.T) {
 	txPool.Open(db.ConnParams(), db.ConnParams())
 	txPool.Close()
 	ctx := context.Background()
-	_, err := txPool.Begin(ctx)
+	_, err := txPool.Begin(ctx, nil)
 	if err == nil {
 		t.Fatalf("expect to get an error")
 	}
@@ -140,7 +182,7 @@ func TestTxPoolBeginWithPoolConnectionError(t *testing.T) {
 	txPool.Open(db.ConnParams(), db.ConnParams())
 	defer txPool.Close()
 	ctx := context.Background()
-	_, err := txPool.Begin(ctx)
+	_, err := txPool.Begin(ctx, nil)
 	want := "errno 2003"
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("Begin: %v, want %s", err, want)
@@ -155,7 +197,7 @@ func TestTxPoolBeginWithExecError(t *testing.T) {
 	txPool.Open(db.ConnParams(), db.ConnParams())
 	defer txPool.Close()
 	ctx := context.Background()
-	_, err := txPool.Begin(ctx)
+	_, err := txPool.Begin(ctx, nil)
 	want := "error: rejected"
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("Begin: %v, want %s", err, want)
@@ -174,7 +216,7 @@ func TestTxPoolRollbackFail(t *testing.T) {
 	txPool.Open(db.ConnParams(), db.ConnParams())
 	defer txPool.Close()
 	ctx := context.Background()
-	transactionID, err := txPool.Begin(ctx)
+	transactionID, err := txPool.Begin(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -217,10 +259,10 @@ func TestTxPoolExecFailDueToConnFail(t *testing.T) {
 	txPool.Open(db.ConnParams(), db.ConnParams())
 	defer txPool.Close()
 	ctx := context.Background()
-	txPool.Begin(ctx)
+	txPool.Begin(ctx, nil)
 	sql := "alter table test_table add test_column int"
 
-	transactionID, err := txPool.Begin(ctx)
+	transactionID, err := txPool.Begin(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,336 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+	vschemapb "github.com/gitql/vitess/go/vt/proto/vschema"
+	workflowpb "github.com/gitql/vitess/go/vt/proto/workflow"
+)
+
+// This file provides the utility methods to save / retrieve the audit
+// log of the topology changes in the topology Backend.
+//
+// Each change is saved in its own file, in the cell of the changed
+// file, as audit/<path of the changed file>/<time of the change>.
+// Entries are only created, never updated: the log is append-only.
+// The entries are written by AuditImpl, and the old ones can be
+// deleted with PruneAuditLog.
+
+const (
+	auditPath = "audit"
+
+	// Path components of the objects we can query the audit log for.
+	keyspacesPath = "keyspaces"
+	shardsPath    = "shards"
+	tabletsPath   = "tablets"
+
+	// auditEntryNameLength is the length of the name of an entry:
+	// the time of the change in nanoseconds, padded with zeros.
+	auditEntryNameLength = 20
+)
+
+// The actions recorded in an AuditEntry.
+const (
+	AuditActionCreate = "Create"
+	AuditActionUpdate = "Update"
+	AuditActionDelete = "Delete"
+)
+
+// AuditEntry is one change of the topology, as saved in the audit log.
+type AuditEntry struct {
+	// Time is when the change was made.
+	Time time.Time
+
+	// Cell and Path identify the file that was changed.
+	Cell string
+	Path string
+
+	// Action is one of the AuditAction constants.
+	Action string
+
+	// Principal, Component and Subcomponent come from the effective
+	// caller id of the change, Username from its immediate caller id.
+	// vtctl commands use "vtctl" as component, and the name of the
+	// command as subcomponent.
+	Principal    string
+	Component    string
+	Subcomponent string
+	Username     string
+
+	// Process is the binary and host that made the change.
+	Process string
+
+	// OldContents is the file before the change, and NewContents
+	// the file after the change. They are empty if the file didn't
+	// exist.
+	OldContents []byte
+	NewContents []byte
+}
+
+// OldMessage returns the proto message stored in OldContents. It
+// returns nil if the file didn't exist before the change, or is not
+// one of the known topology objects.
+func (e *AuditEntry) OldMessage() (proto.Message, error) {
+	if e.Action == AuditActionCreate {
+		return nil, nil
+	}
+	return e.message(e.OldContents)
+}
+
+// NewMessage returns the proto message stored in NewContents. It
+// returns nil if the file was deleted, or is not one of the known
+// topology objects.
+func (e *AuditEntry) NewMessage() (proto.Message, error) {
+	if e.Action == AuditActionDelete {
+		return nil, nil
+	}
+	return e.message(e.NewContents)
+}
+
+func (e *AuditEntry) message(contents []byte) (proto.Message, error) {
+	var msg proto.Message
+	switch path.Base(e.Path) {
+	case CellInfoFile:
+		msg = &topodatapb.CellInfo{}
+	case KeyspaceFile:
+		msg = &topodatapb.Keyspace{}
+	case ShardFile:
+		msg = &topodatapb.Shard{}
+	case TabletFile:
+		msg = &topodatapb.Tablet{}
+	case ShardReplicationFile:
+		msg = &topodatapb.ShardReplication{}
+	case SrvKeyspaceFile:
+		msg = &topodatapb.SrvKeyspace{}
+	case VSchemaFile:
+		msg = &vschemapb.Keyspace{}
+	case SrvVSchemaFile:
+		msg = &vschemapb.SrvVSchema{}
+	case workflowFilename:
+		msg = &workflowpb.Workflow{}
+	default:
+		return nil, nil
+	}
+	if err := proto.Unmarshal(contents, msg); err != nil {
+		return nil, fmt.Errorf("bad %v data in audit entry: %v", path.Base(e.Path), err)
+	}
+	return msg, nil
+}
+
+func pathForAudit(filePath string) string {
+	return path.Join(auditPath, filePath)
+}
+
+func auditEntryName(nanos int64) string {
+	return fmt.Sprintf("%0*d", auditEntryNameLength, nanos)
+}
+
+// isAuditEntryName returns true if name is the name of an entry, and
+// not a path component of the changed files.
+func isAuditEntryName(name string) bool {
+	if len(name) != auditEntryNameLength {
+		return false
+	}
+	for _, c := range name {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateAuditEntry appends an entry to the audit log of the cell of
+// the entry.
+func (ts Server) CreateAuditEntry(ctx context.Context, entry *AuditEntry) error {
+	contents, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// If the same file was changed twice in the same nanosecond,
+	// we use the next free name.
+	dirPath := pathForAudit(entry.Path)
+	nanos := entry.Time.UnixNano()
+	for {
+		_, err := ts.Create(ctx, entry.Cell, path.Join(dirPath, auditEntryName(nanos)), contents)
+		if err != ErrNodeExists {
+			return err
+		}
+		nanos++
+	}
+}
+
+// auditEntryRef is the location of an entry in the audit log.
+type auditEntryRef struct {
+	cell string
+	path string
+	name string
+}
+
+// auditEntryRefsByName sorts entry locations by time of the change,
+// which is their name.
+type auditEntryRefsByName []auditEntryRef
+
+func (a auditEntryRefsByName) Len() int           { return len(a) }
+func (a auditEntryRefsByName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a auditEntryRefsByName) Less(i, j int) bool { return a[i].name < a[j].name }
+
+// GetAuditLog returns the audit log of the files in the provided cell
+// whose path is filePath, or starts with filePath/. Only the entries
+// of the changes made at or after since are returned, and only the
+// last limit ones if limit is positive. The entries are sorted by
+// time.
+func (ts Server) GetAuditLog(ctx context.Context, cell, filePath string, since time.Time, limit int) ([]*AuditEntry, error) {
+	return ts.getAuditLog(ctx, []string{cell}, filePath, since, limit)
+}
+
+// getAuditLog lists the entries for filePath in all the provided
+// cells, and only reads the ones it returns.
+func (ts Server) getAuditLog(ctx context.Context, cells []string, filePath string, since time.Time, limit int) ([]*AuditEntry, error) {
+	// The entry names are the times of the changes, so they
+	// can be selected before being read.
+	var refs []auditEntryRef
+	for _, cell := range cells {
+		if err := ts.listAuditDir(ctx, cell, pathForAudit(filePath), &refs); err != nil {
+			return nil, fmt.Errorf("cannot list the audit log of %v in cell %v: %v", filePath, cell, err)
+		}
+	}
+	sort.Stable(auditEntryRefsByName(refs))
+	if !since.IsZero() {
+		sinceName := auditEntryName(since.UnixNano())
+		i := sort.Search(len(refs), func(i int) bool {
+			return refs[i].name >= sinceName
+		})
+		refs = refs[i:]
+	}
+	if limit > 0 && len(refs) > limit {
+		refs = refs[len(refs)-limit:]
+	}
+
+	result := make([]*AuditEntry, len(refs))
+	for i, ref := range refs {
+		entryPath := path.Join(ref.path, ref.name)
+		contents, _, err := ts.Get(ctx, ref.cell, entryPath)
+		if err != nil {
+			return nil, err
+		}
+		entry := &AuditEntry{}
+		if err := json.Unmarshal(contents, entry); err != nil {
+			return nil, fmt.Errorf("bad audit entry %v: %v", entryPath, err)
+		}
+		result[i] = entry
+	}
+	return result, nil
+}
+
+// listAuditDir appends the locations of the entries under dirPath
+// in the cell to refs.
+func (ts Server) listAuditDir(ctx context.Context, cell, dirPath string, refs *[]auditEntryRef) error {
+	children, err := ts.ListDir(ctx, cell, dirPath)
+	switch err {
+	case nil:
+	case ErrNoNode:
+		return nil
+	default:
+		return err
+	}
+
+	for _, child := range children {
+		if !isAuditEntryName(child) {
+			if err := ts.listAuditDir(ctx, cell, path.Join(dirPath, child), refs); err != nil {
+				return err
+			}
+			continue
+		}
+		*refs = append(*refs, auditEntryRef{
+			cell: cell,
+			path: dirPath,
+			name: child,
+		})
+	}
+	return nil
+}
+
+// getAuditLogAllCells returns the audit log for filePath in the global
+// cell and in all the known cells, sorted by time.
+func (ts Server) getAuditLogAllCells(ctx context.Context, filePath string, since time.Time, limit int) ([]*AuditEntry, error) {
+	cells, err := ts.GetKnownCells(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ts.getAuditLog(ctx, append([]string{GlobalCell}, cells...), filePath, since, limit)
+}
+
+// GetKeyspaceAuditLog returns the audit log of a keyspace: the changes
+// to the keyspace and its shards, and to their serving and
+// replication graphs in all cells. since and limit are used like in
+// GetAuditLog.
+func (ts Server) GetKeyspaceAuditLog(ctx context.Context, keyspace string, since time.Time, limit int) ([]*AuditEntry, error) {
+	return ts.getAuditLogAllCells(ctx, path.Join(keyspacesPath, keyspace), since, limit)
+}
+
+// GetShardAuditLog returns the audit log of a shard: the changes to
+// the shard, and to its replication graph in all cells. since and
+// limit are used like in GetAuditLog.
+func (ts Server) GetShardAuditLog(ctx context.Context, keyspace, shard string, since time.Time, limit int) ([]*AuditEntry, error) {
+	return ts.getAuditLogAllCells(ctx, path.Join(keyspacesPath, keyspace, shardsPath, shard), since, limit)
+}
+
+// GetTabletAuditLog returns the audit log of a tablet. since and
+// limit are used like in GetAuditLog.
+func (ts Server) GetTabletAuditLog(ctx context.Context, alias *topodatapb.TabletAlias, since time.Time, limit int) ([]*AuditEntry, error) {
+	return ts.GetAuditLog(ctx, alias.Cell, path.Join(tabletsPath, topoproto.TabletAliasString(alias)), since, limit)
+}
+
+// PruneAuditLog deletes the entries of the changes made before
+// the provided time, in the global cell and in all the known
+// cells. It returns the number of deleted entries.
+func (ts Server) PruneAuditLog(ctx context.Context, before time.Time) (int, error) {
+	cells, err := ts.GetKnownCells(ctx)
+	if err != nil {
+		return 0, err
+	}
+	beforeName := auditEntryName(before.UnixNano())
+	deleted := 0
+	for _, cell := range append([]string{GlobalCell}, cells...) {
+		var refs []auditEntryRef
+		if err := ts.listAuditDir(ctx, cell, auditPath, &refs); err != nil {
+			return deleted, fmt.Errorf("cannot list the audit log in cell %v: %v", cell, err)
+		}
+		for _, ref := range refs {
+			if ref.name >= beforeName {
+				continue
+			}
+			// Another process may be pruning the same entries.
+			switch err := ts.Delete(ctx, ref.cell, path.Join(ref.path, ref.name), nil); err {
+			case nil:
+				deleted++
+			case ErrNoNode:
+			default:
+				return deleted, err
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// cleanAuditPath returns the canonical form of a file path, as used
+// in the audit log.
+func cleanAuditPath(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+filePath), "/")
+}
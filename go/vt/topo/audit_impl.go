@@ -0,0 +1,511 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/callerid"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+	vschemapb "github.com/gitql/vitess/go/vt/proto/vschema"
+)
+
+// AuditImpl is an implementation of Impl that uses an underlying
+// Impl for everything, and records all the changes in the audit log
+// of the underlying Impl. The entries are written after the change
+// succeeded. Failing to write an entry is logged, but doesn't fail
+// the change, as it is already done.
+//
+// It is used when the -topo_audit flag is set, and can also wrap an
+// Impl directly, like helpers.Tee.
+type AuditImpl struct {
+	impl Impl
+
+	// ts is a Server on the underlying Impl, used to write the
+	// entries without auditing them.
+	ts Server
+}
+
+// NewAuditImpl returns a new AuditImpl object.
+func NewAuditImpl(impl Impl) *AuditImpl {
+	return &AuditImpl{
+		impl: impl,
+		ts:   Server{Impl: impl},
+	}
+}
+
+var (
+	auditProcessOnce sync.Once
+	auditProcess     string
+)
+
+// auditProcessName returns the binary and host of this process.
+func auditProcessName() string {
+	auditProcessOnce.Do(func() {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "unknown"
+		}
+		auditProcess = fmt.Sprintf("%v@%v", path.Base(os.Args[0]), hostname)
+	})
+	return auditProcess
+}
+
+// record writes an entry in the audit log. The changes to the audit
+// log itself, like its pruning, are not recorded.
+func (a *AuditImpl) record(ctx context.Context, cell, filePath, action string, oldContents, newContents []byte) {
+	filePath = cleanAuditPath(filePath)
+	if filePath == auditPath || strings.HasPrefix(filePath, auditPath+"/") {
+		return
+	}
+	entry := &AuditEntry{
+		Time:        time.Now(),
+		Cell:        cell,
+		Path:        filePath,
+		Action:      action,
+		Process:     auditProcessName(),
+		OldContents: oldContents,
+		NewContents: newContents,
+	}
+	if ef := callerid.EffectiveCallerIDFromContext(ctx); ef != nil {
+		entry.Principal = callerid.GetPrincipal(ef)
+		entry.Component = callerid.GetComponent(ef)
+		entry.Subcomponent = callerid.GetSubcomponent(ef)
+	}
+	if im := callerid.ImmediateCallerIDFromContext(ctx); im != nil {
+		entry.Username = callerid.GetUsername(im)
+	}
+
+	// The change is done, so we want to record it even if the
+	// context of the caller expires now.
+	if err := a.ts.CreateAuditEntry(context.Background(), entry); err != nil {
+		log.Errorf("cannot record %v of %v in cell %v in the audit log: %v", action, filePath, cell, err)
+	}
+}
+
+// recordProto writes an entry in the audit log for proto values.
+// A nil value means the object didn't exist.
+func (a *AuditImpl) recordProto(ctx context.Context, cell, filePath, action string, oldValue, newValue proto.Message) {
+	a.record(ctx, cell, filePath, action, marshalAuditValue(filePath, oldValue), marshalAuditValue(filePath, newValue))
+}
+
+func marshalAuditValue(filePath string, value proto.Message) []byte {
+	if value == nil {
+		return nil
+	}
+	data, err := proto.Marshal(value)
+	if err != nil {
+		// ErrNil is returned for a nil pointer, when the
+		// object didn't exist.
+		if err != proto.ErrNil {
+			log.Errorf("cannot marshal value of %v for the audit log: %v", filePath, err)
+		}
+		return nil
+	}
+	return data
+}
+
+func keyspaceFilePath(keyspace string) string {
+	return path.Join(keyspacesPath, keyspace, KeyspaceFile)
+}
+
+func shardFilePath(keyspace, shard string) string {
+	return path.Join(keyspacesPath, keyspace, shardsPath, shard, ShardFile)
+}
+
+func tabletFilePath(alias *topodatapb.TabletAlias) string {
+	return path.Join(tabletsPath, topoproto.TabletAliasString(alias), TabletFile)
+}
+
+//
+// topo.Server management interface.
+//
+
+// Close is part of the topo.Server interface
+func (a *AuditImpl) Close() {
+	a.impl.Close()
+}
+
+//
+// Backend API
+//
+
+// ListDir is part of the topo.Backend interface.
+func (a *AuditImpl) ListDir(ctx context.Context, cell, dirPath string) ([]string, error) {
+	return a.impl.ListDir(ctx, cell, dirPath)
+}
+
+// Create is part of the topo.Backend interface.
+func (a *AuditImpl) Create(ctx context.Context, cell, filePath string, contents []byte) (Version, error) {
+	version, err := a.impl.Create(ctx, cell, filePath, contents)
+	if err != nil {
+		return nil, err
+	}
+	a.record(ctx, cell, filePath, AuditActionCreate, nil, contents)
+	return version, nil
+}
+
+// Update is part of the topo.Backend interface.
+func (a *AuditImpl) Update(ctx context.Context, cell, filePath string, contents []byte, version Version) (Version, error) {
+	oldContents, _, err := a.impl.Get(ctx, cell, filePath)
+	action := AuditActionUpdate
+	if err == ErrNoNode {
+		action = AuditActionCreate
+	}
+	newVersion, err := a.impl.Update(ctx, cell, filePath, contents, version)
+	if err != nil {
+		return nil, err
+	}
+	a.record(ctx, cell, filePath, action, oldContents, contents)
+	return newVersion, nil
+}
+
+// Get is part of the topo.Backend interface.
+func (a *AuditImpl) Get(ctx context.Context, cell, filePath string) ([]byte, Version, error) {
+	return a.impl.Get(ctx, cell, filePath)
+}
+
+// Delete is part of the topo.Backend interface.
+func (a *AuditImpl) Delete(ctx context.Context, cell, filePath string, version Version) error {
+	oldContents, _, _ := a.impl.Get(ctx, cell, filePath)
+	if err := a.impl.Delete(ctx, cell, filePath, version); err != nil {
+		return err
+	}
+	a.record(ctx, cell, filePath, AuditActionDelete, oldContents, nil)
+	return nil
+}
+
+// Watch is part of the topo.Backend interface.
+func (a *AuditImpl) Watch(ctx context.Context, cell, filePath string) (*WatchData, <-chan *WatchData, CancelFunc) {
+	return a.impl.Watch(ctx, cell, filePath)
+}
+
+// WatchRecursive is part of the topo.Backend interface.
+func (a *AuditImpl) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*WatchDataRecursive, <-chan *WatchDataRecursive, CancelFunc, error) {
+	return a.impl.WatchRecursive(ctx, cell, dirPath)
+}
+
+// NewMasterParticipation is part of the topo.Backend interface.
+func (a *AuditImpl) NewMasterParticipation(name, id string) (MasterParticipation, error) {
+	return a.impl.NewMasterParticipation(name, id)
+}
+
+//
+// Cell management, global
+//
+
+// GetKnownCells is part of the topo.Server interface
+func (a *AuditImpl) GetKnownCells(ctx context.Context) ([]string, error) {
+	return a.impl.GetKnownCells(ctx)
+}
+
+//
+// Keyspace management, global.
+//
+
+// CreateKeyspace is part of the topo.Server interface
+func (a *AuditImpl) CreateKeyspace(ctx context.Context, keyspace string, value *topodatapb.Keyspace) error {
+	if err := a.impl.CreateKeyspace(ctx, keyspace, value); err != nil {
+		return err
+	}
+	a.recordProto(ctx, GlobalCell, keyspaceFilePath(keyspace), AuditActionCreate, nil, value)
+	return nil
+}
+
+// UpdateKeyspace is part of the topo.Server interface
+func (a *AuditImpl) UpdateKeyspace(ctx context.Context, keyspace string, value *topodatapb.Keyspace, existingVersion int64) (int64, error) {
+	oldValue, _, _ := a.impl.GetKeyspace(ctx, keyspace)
+	newVersion, err := a.impl.UpdateKeyspace(ctx, keyspace, value, existingVersion)
+	if err != nil {
+		return newVersion, err
+	}
+	a.recordProto(ctx, GlobalCell, keyspaceFilePath(keyspace), AuditActionUpdate, oldValue, value)
+	return newVersion, nil
+}
+
+// DeleteKeyspace is part of the topo.Server interface
+func (a *AuditImpl) DeleteKeyspace(ctx context.Context, keyspace string) error {
+	oldValue, _, _ := a.impl.GetKeyspace(ctx, keyspace)
+	if err := a.impl.DeleteKeyspace(ctx, keyspace); err != nil {
+		return err
+	}
+	a.recordProto(ctx, GlobalCell, keyspaceFilePath(keyspace), AuditActionDelete, oldValue, nil)
+	return nil
+}
+
+// GetKeyspace is part of the topo.Server interface
+func (a *AuditImpl) GetKeyspace(ctx context.Context, keyspace string) (*topodatapb.Keyspace, int64, error) {
+	return a.impl.GetKeyspace(ctx, keyspace)
+}
+
+// GetKeyspaces is part of the topo.Server interface
+func (a *AuditImpl) GetKeyspaces(ctx context.Context) ([]string, error) {
+	return a.impl.GetKeyspaces(ctx)
+}
+
+//
+// Shard management, global.
+//
+
+// CreateShard is part of the topo.Server interface
+func (a *AuditImpl) CreateShard(ctx context.Context, keyspace, shard string, value *topodatapb.Shard) error {
+	if err := a.impl.CreateShard(ctx, keyspace, shard, value); err != nil {
+		return err
+	}
+	a.recordProto(ctx, GlobalCell, shardFilePath(keyspace, shard), AuditActionCreate, nil, value)
+	return nil
+}
+
+// UpdateShard is part of the topo.Server interface
+func (a *AuditImpl) UpdateShard(ctx context.Context, keyspace, shard string, value *topodatapb.Shard, existingVersion int64) (int64, error) {
+	oldValue, _, _ := a.impl.GetShard(ctx, keyspace, shard)
+	newVersion, err := a.impl.UpdateShard(ctx, keyspace, shard, value, existingVersion)
+	if err != nil {
+		return newVersion, err
+	}
+	a.recordProto(ctx, GlobalCell, shardFilePath(keyspace, shard), AuditActionUpdate, oldValue, value)
+	return newVersion, nil
+}
+
+// GetShard is part of the topo.Server interface
+func (a *AuditImpl) GetShard(ctx context.Context, keyspace, shard string) (*topodatapb.Shard, int64, error) {
+	return a.impl.GetShard(ctx, keyspace, shard)
+}
+
+// GetShardNames is part of the topo.Server interface
+func (a *AuditImpl) GetShardNames(ctx context.Context, keyspace string) ([]string, error) {
+	return a.impl.GetShardNames(ctx, keyspace)
+}
+
+// DeleteShard is part of the topo.Server interface
+func (a *AuditImpl) DeleteShard(ctx context.Context, keyspace, shard string) error {
+	oldValue, _, _ := a.impl.GetShard(ctx, keyspace, shard)
+	if err := a.impl.DeleteShard(ctx, keyspace, shard); err != nil {
+		return err
+	}
+	a.recordProto(ctx, GlobalCell, shardFilePath(keyspace, shard), AuditActionDelete, oldValue, nil)
+	return nil
+}
+
+//
+// Tablet management, per cell.
+//
+
+// CreateTablet is part of the topo.Server interface
+func (a *AuditImpl) CreateTablet(ctx context.Context, tablet *topodatapb.Tablet) error {
+	if err := a.impl.CreateTablet(ctx, tablet); err != nil {
+		return err
+	}
+	a.recordProto(ctx, tablet.Alias.Cell, tabletFilePath(tablet.Alias), AuditActionCreate, nil, tablet)
+	return nil
+}
+
+// UpdateTablet is part of the topo.Server interface
+func (a *AuditImpl) UpdateTablet(ctx context.Context, tablet *topodatapb.Tablet, existingVersion int64) (int64, error) {
+	oldValue, _, _ := a.impl.GetTablet(ctx, tablet.Alias)
+	newVersion, err := a.impl.UpdateTablet(ctx, tablet, existingVersion)
+	if err != nil {
+		return newVersion, err
+	}
+	a.recordProto(ctx, tablet.Alias.Cell, tabletFilePath(tablet.Alias), AuditActionUpdate, oldValue, tablet)
+	return newVersion, nil
+}
+
+// DeleteTablet is part of the topo.Server interface
+func (a *AuditImpl) DeleteTablet(ctx context.Context, alias *topodatapb.TabletAlias) error {
+	oldValue, _, _ := a.impl.GetTablet(ctx, alias)
+	if err := a.impl.DeleteTablet(ctx, alias); err != nil {
+		return err
+	}
+	a.recordProto(ctx, alias.Cell, tabletFilePath(alias), AuditActionDelete, oldValue, nil)
+	return nil
+}
+
+// GetTablet is part of the topo.Server interface
+func (a *AuditImpl) GetTablet(ctx context.Context, alias *topodatapb.TabletAlias) (*topodatapb.Tablet, int64, error) {
+	return a.impl.GetTablet(ctx, alias)
+}
+
+// GetTabletsByCell is part of the topo.Server interface
+func (a *AuditImpl) GetTabletsByCell(ctx context.Context, cell string) ([]*topodatapb.TabletAlias, error) {
+	return a.impl.GetTabletsByCell(ctx, cell)
+}
+
+//
+// Replication graph management, per cell.
+//
+
+// UpdateShardReplicationFields is part of the topo.Server interface
+func (a *AuditImpl) UpdateShardReplicationFields(ctx context.Context, cell, keyspace, shard string, update func(*topodatapb.ShardReplication) error) error {
+	// The update function may be called more than once, we record
+	// the values of the last call, which is the one that was saved.
+	var oldValue, newValue *topodatapb.ShardReplication
+	if err := a.impl.UpdateShardReplicationFields(ctx, cell, keyspace, shard, func(sr *topodatapb.ShardReplication) error {
+		oldValue = proto.Clone(sr).(*topodatapb.ShardReplication)
+		newValue = nil
+		if err := update(sr); err != nil {
+			return err
+		}
+		newValue = sr
+		return nil
+	}); err != nil {
+		return err
+	}
+	if newValue == nil {
+		// No update was needed.
+		return nil
+	}
+	filePath := path.Join(keyspacesPath, keyspace, shardsPath, shard, ShardReplicationFile)
+	a.recordProto(ctx, cell, filePath, AuditActionUpdate, oldValue, newValue)
+	return nil
+}
+
+// GetShardReplication is part of the topo.Server interface
+func (a *AuditImpl) GetShardReplication(ctx context.Context, cell, keyspace, shard string) (*ShardReplicationInfo, error) {
+	return a.impl.GetShardReplication(ctx, cell, keyspace, shard)
+}
+
+// DeleteShardReplication is part of the topo.Server interface
+func (a *AuditImpl) DeleteShardReplication(ctx context.Context, cell, keyspace, shard string) error {
+	var oldValue *topodatapb.ShardReplication
+	if sri, err := a.impl.GetShardReplication(ctx, cell, keyspace, shard); err == nil {
+		oldValue = sri.ShardReplication
+	}
+	if err := a.impl.DeleteShardReplication(ctx, cell, keyspace, shard); err != nil {
+		return err
+	}
+	filePath := path.Join(keyspacesPath, keyspace, shardsPath, shard, ShardReplicationFile)
+	a.recordProto(ctx, cell, filePath, AuditActionDelete, oldValue, nil)
+	return nil
+}
+
+// DeleteKeyspaceReplication is part of the topo.Server interface
+func (a *AuditImpl) DeleteKeyspaceReplication(ctx context.Context, cell, keyspace string) error {
+	if err := a.impl.DeleteKeyspaceReplication(ctx, cell, keyspace); err != nil {
+		return err
+	}
+	a.record(ctx, cell, path.Join(keyspacesPath, keyspace), AuditActionDelete, nil, nil)
+	return nil
+}
+
+//
+// Serving Graph management, per cell.
+//
+
+// GetSrvKeyspaceNames is part of the topo.Server interface
+func (a *AuditImpl) GetSrvKeyspaceNames(ctx context.Context, cell string) ([]string, error) {
+	return a.impl.GetSrvKeyspaceNames(ctx, cell)
+}
+
+// UpdateSrvKeyspace is part of the topo.Server interface
+func (a *AuditImpl) UpdateSrvKeyspace(ctx context.Context, cell, keyspace string, srvKeyspace *topodatapb.SrvKeyspace) error {
+	oldValue, err := a.impl.GetSrvKeyspace(ctx, cell, keyspace)
+	action := AuditActionUpdate
+	if err == ErrNoNode {
+		action = AuditActionCreate
+	}
+	if err := a.impl.UpdateSrvKeyspace(ctx, cell, keyspace, srvKeyspace); err != nil {
+		return err
+	}
+	filePath := path.Join(keyspacesPath, keyspace, SrvKeyspaceFile)
+	a.recordProto(ctx, cell, filePath, action, oldValue, srvKeyspace)
+	return nil
+}
+
+// DeleteSrvKeyspace is part of the topo.Server interface
+func (a *AuditImpl) DeleteSrvKeyspace(ctx context.Context, cell, keyspace string) error {
+	oldValue, _ := a.impl.GetSrvKeyspace(ctx, cell, keyspace)
+	if err := a.impl.DeleteSrvKeyspace(ctx, cell, keyspace); err != nil {
+		return err
+	}
+	filePath := path.Join(keyspacesPath, keyspace, SrvKeyspaceFile)
+	a.recordProto(ctx, cell, filePath, AuditActionDelete, oldValue, nil)
+	return nil
+}
+
+// GetSrvKeyspace is part of the topo.Server interface
+func (a *AuditImpl) GetSrvKeyspace(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, error) {
+	return a.impl.GetSrvKeyspace(ctx, cell, keyspace)
+}
+
+// UpdateSrvVSchema is part of the topo.Server interface
+func (a *AuditImpl) UpdateSrvVSchema(ctx context.Context, cell string, srvVSchema *vschemapb.SrvVSchema) error {
+	oldValue, err := a.impl.GetSrvVSchema(ctx, cell)
+	action := AuditActionUpdate
+	if err == ErrNoNode {
+		action = AuditActionCreate
+	}
+	if err := a.impl.UpdateSrvVSchema(ctx, cell, srvVSchema); err != nil {
+		return err
+	}
+	a.recordProto(ctx, cell, SrvVSchemaFile, action, oldValue, srvVSchema)
+	return nil
+}
+
+// GetSrvVSchema is part of the topo.Server interface
+func (a *AuditImpl) GetSrvVSchema(ctx context.Context, cell string) (*vschemapb.SrvVSchema, error) {
+	return a.impl.GetSrvVSchema(ctx, cell)
+}
+
+//
+// Keyspace and Shard locks for actions, global.
+//
+
+// LockKeyspaceForAction is part of the topo.Server interface
+func (a *AuditImpl) LockKeyspaceForAction(ctx context.Context, keyspace, contents string) (string, error) {
+	return a.impl.LockKeyspaceForAction(ctx, keyspace, contents)
+}
+
+// UnlockKeyspaceForAction is part of the topo.Server interface
+func (a *AuditImpl) UnlockKeyspaceForAction(ctx context.Context, keyspace, lockPath, results string) error {
+	return a.impl.UnlockKeyspaceForAction(ctx, keyspace, lockPath, results)
+}
+
+// LockShardForAction is part of the topo.Server interface
+func (a *AuditImpl) LockShardForAction(ctx context.Context, keyspace, shard, contents string) (string, error) {
+	return a.impl.LockShardForAction(ctx, keyspace, shard, contents)
+}
+
+// UnlockShardForAction is part of the topo.Server interface
+func (a *AuditImpl) UnlockShardForAction(ctx context.Context, keyspace, shard, lockPath, results string) error {
+	return a.impl.UnlockShardForAction(ctx, keyspace, shard, lockPath, results)
+}
+
+//
+// V3 Schema management, global
+//
+
+// SaveVSchema is part of the topo.Server interface
+func (a *AuditImpl) SaveVSchema(ctx context.Context, keyspace string, vschema *vschemapb.Keyspace) error {
+	oldValue, err := a.impl.GetVSchema(ctx, keyspace)
+	action := AuditActionUpdate
+	if err == ErrNoNode {
+		action = AuditActionCreate
+	}
+	if err := a.impl.SaveVSchema(ctx, keyspace, vschema); err != nil {
+		return err
+	}
+	filePath := path.Join(keyspacesPath, keyspace, VSchemaFile)
+	a.recordProto(ctx, GlobalCell, filePath, action, oldValue, vschema)
+	return nil
+}
+
+// GetVSchema is part of the topo.Server interface
+func (a *AuditImpl) GetVSchema(ctx context.Context, keyspace string) (*vschemapb.Keyspace, error) {
+	return a.impl.GetVSchema(ctx, keyspace)
+}
+
+var _ Impl = (*AuditImpl)(nil) // compile-time interface check
@@ -126,6 +126,35 @@ type Backend interface {
 	// filePath is a path relative to the root directory of the cell.
 	Watch(ctx context.Context, cell, filePath string) (current *WatchData, changes <-chan *WatchData, cancel CancelFunc)
 
+	// WatchRecursive starts watching all the files under a
+	// directory in the provided cell, including the files in its
+	// sub-directories. It returns the current files, a 'changes'
+	// channel to read the changes from, and a 'cancel' function to
+	// call to stop the watch. If the initial read fails, err is
+	// set and the other values are nil. The directory doesn't need
+	// to exist: if it doesn't, 'current' is empty, and the files
+	// created later on are sent on 'changes'. The provided context
+	// is only used to setup the watch, as for Watch.
+	//
+	// All the records on 'changes' have a Path:
+	// - with Err = nil when the file is created or updated. Contents
+	//   and Version are then set.
+	// - with Err = ErrNoNode when the file is deleted.
+	// Other records have no Path and Err != nil. They terminate the
+	// watch: ErrInterrupted if 'cancel' was called, or any other
+	// error. The channel is closed right after that record.
+	//
+	// Same as Watch, 'changes' has to be drained of all events,
+	// can return twice the same Version/Contents for a file, and
+	// may skip versions of rapidly changing files. Implementations
+	// that do not support recursive watches return
+	// ErrNotImplemented, and callers then usually fall back to
+	// polling.
+	//
+	// dirPath is a path relative to the root directory of the
+	// cell, and so are the paths of the returned records.
+	WatchRecursive(ctx context.Context, cell, dirPath string) (current []*WatchDataRecursive, changes <-chan *WatchDataRecursive, cancel CancelFunc, err error)
+
 	//
 	// Master election methods. This is meant to have a small
 	// number of processes elect a master within a group. The
@@ -182,6 +211,17 @@ type WatchData struct {
 	Err error
 }
 
+// WatchDataRecursive is the structure returned by the
+// WatchRecursive() API. See WatchRecursive for the meaning of Path
+// and Err.
+type WatchDataRecursive struct {
+	// Path is the path of the file, relative to the root
+	// directory of the cell.
+	Path string
+
+	WatchData
+}
+
 // MasterParticipation is the object returned by NewMasterParticipation.
 // Sample usage:
 //
@@ -4,6 +4,8 @@ import (
 	"flag"
 	"fmt"
 	"path"
+	"sort"
+	"strings"
 	"time"
 
 	"golang.org/x/net/context"
@@ -97,3 +99,130 @@ func (s *Server) Watch(ctx context.Context, cell, filePath string) (*topo.WatchD
 
 	return wd, notifications, topo.CancelFunc(watchCancel)
 }
+
+// WatchRecursive is part of the topo.Backend interface.
+// It uses blocking queries on the list of keys under the directory,
+// and compares the ModifyIndex of each key with the previous list.
+func (s *Server) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	c, err := s.clientForCell(ctx, cell)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("WatchRecursive cannot get cell: %v", err)
+	}
+	nodePath := path.Join(c.root, dirPath) + "/"
+	prefixLen := len(nodePath)
+
+	// files converts a list of pairs to the files by path
+	// relative to the root directory of the cell.
+	files := func(pairs api.KVPairs) (map[string]*api.KVPair, error) {
+		result := make(map[string]*api.KVPair, len(pairs))
+		for _, pair := range pairs {
+			if !strings.HasPrefix(pair.Key, nodePath) {
+				return nil, ErrBadResponse
+			}
+			result[path.Join(dirPath, pair.Key[prefixLen:])] = pair
+		}
+		return result, nil
+	}
+
+	// Initial list.
+	pairs, meta, err := c.kv.List(nodePath, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	currentFiles, err := files(pairs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	current := make([]*topo.WatchDataRecursive, 0, len(currentFiles))
+	for _, p := range sortedPaths(currentFiles) {
+		current = append(current, &topo.WatchDataRecursive{
+			Path: p,
+			WatchData: topo.WatchData{
+				Contents: currentFiles[p].Value,
+				Version:  ConsulVersion(currentFiles[p].ModifyIndex),
+			},
+		})
+	}
+
+	// Create a context, will be used to cancel the watch.
+	watchCtx, watchCancel := context.WithCancel(context.Background())
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchDataRecursive, 10)
+	go func() {
+		defer close(notifications)
+
+		waitIndex := meta.LastIndex
+		for {
+			// Wait/poll until something changes under the
+			// directory, or WaitTime expires.
+			pairs, meta, err := c.kv.List(nodePath, &api.QueryOptions{
+				WaitIndex: waitIndex,
+				WaitTime:  *watchPollDuration,
+			})
+			if err != nil {
+				// Serious error.
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: err},
+				}
+				return
+			}
+			waitIndex = meta.LastIndex
+
+			newFiles, err := files(pairs)
+			if err != nil {
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: err},
+				}
+				return
+			}
+
+			// Send the new and changed files, then the
+			// deleted ones.
+			for _, p := range sortedPaths(newFiles) {
+				pair := newFiles[p]
+				if old, ok := currentFiles[p]; ok && old.ModifyIndex == pair.ModifyIndex {
+					continue
+				}
+				notifications <- &topo.WatchDataRecursive{
+					Path: p,
+					WatchData: topo.WatchData{
+						Contents: pair.Value,
+						Version:  ConsulVersion(pair.ModifyIndex),
+					},
+				}
+			}
+			for _, p := range sortedPaths(currentFiles) {
+				if _, ok := newFiles[p]; !ok {
+					notifications <- &topo.WatchDataRecursive{
+						Path:      p,
+						WatchData: topo.WatchData{Err: topo.ErrNoNode},
+					}
+				}
+			}
+			currentFiles = newFiles
+
+			// See if the watch was canceled.
+			select {
+			case <-watchCtx.Done():
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: convertError(watchCtx.Err())},
+				}
+				return
+			default:
+			}
+		}
+	}()
+
+	return current, notifications, topo.CancelFunc(watchCancel), nil
+}
+
+// sortedPaths returns the keys of files, sorted.
+func sortedPaths(files map[string]*api.KVPair) []string {
+	result := make([]string, 0, len(files))
+	for p := range files {
+		result = append(result, p)
+	}
+	sort.Strings(result)
+	return result
+}
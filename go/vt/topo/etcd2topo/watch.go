@@ -3,6 +3,7 @@ package etcd2topo
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/coreos/etcd/clientv3"
 	"github.com/coreos/etcd/mvcc/mvccpb"
@@ -91,3 +92,117 @@ func (s *Server) Watch(ctx context.Context, cell, filePath string) (*topo.WatchD
 
 	return wd, notifications, topo.CancelFunc(watchCancel)
 }
+
+// WatchRecursive is part of the topo.Backend interface.
+// It uses a prefix watch, starting at the revision of the initial read.
+func (s *Server) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	c, err := s.clientForCell(ctx, cell)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("WatchRecursive cannot get cell: %v", err)
+	}
+	nodePath := path.Join(c.root, dirPath) + "/"
+	prefixLen := len(nodePath)
+
+	// relativePath returns the path of a key, relative to the
+	// root directory of the cell.
+	relativePath := func(key []byte) (string, error) {
+		p := string(key)
+		if !strings.HasPrefix(p, nodePath) {
+			return "", ErrBadResponse
+		}
+		return path.Join(dirPath, p[prefixLen:]), nil
+	}
+
+	// Get the initial version of the files.
+	initial, err := s.global.cli.Get(ctx, nodePath,
+		clientv3.WithPrefix(),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if err != nil {
+		return nil, nil, nil, convertError(err)
+	}
+	current := make([]*topo.WatchDataRecursive, 0, len(initial.Kvs))
+	for _, kv := range initial.Kvs {
+		p, err := relativePath(kv.Key)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		current = append(current, &topo.WatchDataRecursive{
+			Path: p,
+			WatchData: topo.WatchData{
+				Contents: kv.Value,
+				Version:  EtcdVersion(kv.ModRevision),
+			},
+		})
+	}
+
+	// Create a context, will be used to cancel the watch.
+	watchCtx, watchCancel := context.WithCancel(context.Background())
+
+	// Create the Watcher, for the changes after the initial read.
+	watcher := s.global.cli.Watch(watchCtx, nodePath,
+		clientv3.WithPrefix(),
+		clientv3.WithRev(initial.Header.Revision+1))
+	if watcher == nil {
+		watchCancel()
+		return nil, nil, nil, fmt.Errorf("WatchRecursive failed")
+	}
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchDataRecursive, 10)
+	go func() {
+		defer close(notifications)
+
+		for {
+			select {
+			case <-watchCtx.Done():
+				// This includes context cancelation errors.
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: convertError(watchCtx.Err())},
+				}
+				return
+			case wresp := <-watcher:
+				if wresp.Canceled {
+					// Final notification.
+					notifications <- &topo.WatchDataRecursive{
+						WatchData: topo.WatchData{Err: convertError(wresp.Err())},
+					}
+					return
+				}
+
+				for _, ev := range wresp.Events {
+					p, err := relativePath(ev.Kv.Key)
+					if err != nil {
+						watchCancel()
+						notifications <- &topo.WatchDataRecursive{
+							WatchData: topo.WatchData{Err: err},
+						}
+						return
+					}
+					switch ev.Type {
+					case mvccpb.PUT:
+						notifications <- &topo.WatchDataRecursive{
+							Path: p,
+							WatchData: topo.WatchData{
+								Contents: ev.Kv.Value,
+								Version:  EtcdVersion(ev.Kv.ModRevision),
+							},
+						}
+					case mvccpb.DELETE:
+						notifications <- &topo.WatchDataRecursive{
+							Path:      p,
+							WatchData: topo.WatchData{Err: topo.ErrNoNode},
+						}
+					default:
+						watchCancel()
+						notifications <- &topo.WatchDataRecursive{
+							WatchData: topo.WatchData{Err: fmt.Errorf("unexpected event received: %v", ev)},
+						}
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return current, notifications, topo.CancelFunc(watchCancel), nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// dirForCell returns the root directory of the given cell.
+// It caches the directories of previously requested cells.
+func (s *Server) dirForCell(ctx context.Context, cell string) (string, error) {
+	// Global cell is the easy case.
+	if cell == topo.GlobalCell {
+		return s.global, nil
+	}
+
+	// Return a cached directory if present.
+	s.mu.Lock()
+	dir, ok := s.cells[cell]
+	s.mu.Unlock()
+	if ok {
+		return dir, nil
+	}
+
+	// Read the cell directory from the global cell.
+	cellInfoPath := path.Join(cellsPath, cell, topo.CellInfoFile)
+	data, _, err := s.Get(ctx, topo.GlobalCell, cellInfoPath)
+	if err != nil {
+		return "", err
+	}
+	ci := &topodatapb.CellInfo{}
+	if err := proto.Unmarshal(data, ci); err != nil {
+		return "", fmt.Errorf("cannot unmarshal cell node %v: %v", cellInfoPath, err)
+	}
+	if ci.Root == "" {
+		return "", fmt.Errorf("CellInfo.Root node %v is empty, expected a directory", cellInfoPath)
+	}
+
+	// Update the cache.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cells[cell] = ci.Root
+	return ci.Root, nil
+}
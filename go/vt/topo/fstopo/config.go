@@ -0,0 +1,35 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"time"
+)
+
+const (
+	// Path components
+	cellsPath     = "cells"
+	keyspacesPath = "keyspaces"
+	shardsPath    = "shards"
+	tabletsPath   = "tablets"
+	electionsPath = "elections"
+)
+
+const (
+	// Hidden entries of a cell directory. They all start with a '.'
+	// so ListDir skips them.
+	generationFile = ".generation"
+	cellLockFile   = ".lock"
+	locksDir       = ".locks"
+	tmpFilePrefix  = ".tmp"
+
+	// lockFile is the name of the file in a lock directory on which
+	// the advisory lock is taken.
+	lockFile = "lock"
+
+	// lockPollInterval is how often we try again to take a lock
+	// that is held by someone else.
+	lockPollInterval = 10 * time.Millisecond
+)
@@ -0,0 +1,42 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+// ListDir is part of the topo.Backend interface.
+func (s *Server) ListDir(ctx context.Context, cell, dirPath string) ([]string, error) {
+	root, err := s.dirForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+
+	// ReadDir returns the entries sorted by name.
+	entries, err := ioutil.ReadDir(path.Join(root, dirPath))
+	if err != nil {
+		return nil, convertError(err)
+	}
+	var result []string
+	for _, e := range entries {
+		// Skip the entries used by the implementation.
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		result = append(result, e.Name())
+	}
+	if len(result) == 0 {
+		// An empty directory is the same as no directory.
+		return nil, topo.ErrNoNode
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"syscall"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+// NewMasterParticipation is part of the topo.Server interface
+func (s *Server) NewMasterParticipation(name, id string) (topo.MasterParticipation, error) {
+	return &fsMasterParticipation{
+		s:    s,
+		name: name,
+		id:   id,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}, nil
+}
+
+// fsMasterParticipation implements topo.MasterParticipation.
+//
+// We use the lock of a directory (in the global election path, with
+// the name). The process holding the lock is the master, and the lock
+// file contains its id.
+type fsMasterParticipation struct {
+	// s is our parent fs topo Server
+	s *Server
+
+	// name is the name of this MasterParticipation
+	name string
+
+	// id is the process's current id.
+	id string
+
+	// stop is a channel closed when Stop is called.
+	stop chan struct{}
+
+	// done is a channel closed when we're done processing the Stop
+	done chan struct{}
+}
+
+// WaitForMastership is part of the topo.MasterParticipation interface.
+func (mp *fsMasterParticipation) WaitForMastership() (context.Context, error) {
+	electionPath := path.Join(electionsPath, mp.name)
+
+	// We use a cancelable context here. If stop is closed,
+	// we just cancel that context, and release the lock if we got it.
+	lockCtx, lockCancel := context.WithCancel(context.Background())
+	locked := make(chan string, 1)
+	go func() {
+		<-mp.stop
+		lockCancel()
+		if actionPath := <-locked; actionPath != "" {
+			if err := mp.s.unlock(electionPath, actionPath); err != nil {
+				log.Errorf("failed to release lock %v for election %v: %v", actionPath, mp.name, err)
+			}
+		}
+		close(mp.done)
+	}()
+
+	// Try to get the mastership, by getting a lock.
+	actionPath, err := mp.s.lock(lockCtx, electionPath, mp.id)
+	locked <- actionPath
+	if err != nil {
+		// It can be that we were interrupted.
+		return nil, err
+	}
+
+	// We got the lock. Return the lockContext. If Stop() is called,
+	// it will cancel the lockCtx, and cancel the returned context.
+	return lockCtx, nil
+}
+
+// Stop is part of the topo.MasterParticipation interface
+func (mp *fsMasterParticipation) Stop() {
+	close(mp.stop)
+	<-mp.done
+}
+
+// GetCurrentMasterID is part of the topo.MasterParticipation interface
+func (mp *fsMasterParticipation) GetCurrentMasterID(ctx context.Context) (string, error) {
+	lockPath := path.Join(mp.s.global, locksDir, electionsPath, mp.name, lockFile)
+	f, err := os.Open(lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Nobody ever ran for this election.
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	// If we can take the lock, nobody is the master.
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_SH|syscall.LOCK_NB)
+	switch err {
+	case nil:
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		return "", nil
+	case syscall.EWOULDBLOCK:
+		// Someone holds the lock, and wrote their id in the file.
+	default:
+		return "", err
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
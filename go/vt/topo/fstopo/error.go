@@ -0,0 +1,39 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+// convertError converts an error from the os package or a context
+// error into a topo error.
+func convertError(err error) error {
+	switch err {
+	case context.Canceled:
+		return topo.ErrInterrupted
+	case context.DeadlineExceeded:
+		return topo.ErrTimeout
+	}
+	if os.IsNotExist(err) {
+		return topo.ErrNoNode
+	}
+	if pathErr, ok := err.(*os.PathError); ok {
+		switch pathErr.Err {
+		case syscall.ENOTDIR:
+			// A component of the path is a file.
+			return topo.ErrNoNode
+		case syscall.EISDIR:
+			// Directories are not files.
+			return topo.ErrNoNode
+		}
+	}
+	return err
+}
@@ -0,0 +1,217 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+// readFile returns the contents and version of the file at nodePath.
+func readFile(nodePath string) ([]byte, FSVersion, error) {
+	data, err := ioutil.ReadFile(nodePath)
+	if err != nil {
+		return nil, 0, convertError(err)
+	}
+	i := bytes.IndexByte(data, '\n')
+	if i < 0 {
+		return nil, 0, fmt.Errorf("bad file %v: no version line", nodePath)
+	}
+	version, err := strconv.ParseUint(string(data[:i]), 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("bad file %v: invalid version: %v", nodePath, err)
+	}
+	return data[i+1:], FSVersion(version), nil
+}
+
+// writeFileAtomic writes data to nodePath, creating the parent
+// directories if necessary. The data is written to a temporary file in
+// root first, which is then renamed, so readers either see the old or
+// the new file.
+func writeFileAtomic(root, nodePath string, data []byte) error {
+	if err := os.MkdirAll(path.Dir(nodePath), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(root, tmpFilePrefix)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), nodePath); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// writeFile writes a new version of the file at nodePath.
+// The cell lock must be held.
+func writeFile(root, nodePath string, contents []byte) (FSVersion, error) {
+	version, err := nextGeneration(root)
+	if err != nil {
+		return 0, err
+	}
+	data := append([]byte(fmt.Sprintf("%v\n", uint64(version))), contents...)
+	if err := writeFileAtomic(root, nodePath, data); err != nil {
+		return 0, convertError(err)
+	}
+	return version, nil
+}
+
+// nextGeneration increments the generation counter of the cell
+// directory root, and returns the new value. We use a counter per cell
+// so when creating a file, then deleting it, then re-creating it, we
+// don't restart the version at 1.
+// The cell lock must be held.
+func nextGeneration(root string) (FSVersion, error) {
+	generationPath := path.Join(root, generationFile)
+	var generation uint64
+	data, err := ioutil.ReadFile(generationPath)
+	switch {
+	case err == nil:
+		generation, err = strconv.ParseUint(string(data), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("bad generation file %v: %v", generationPath, err)
+		}
+	case os.IsNotExist(err):
+		// First write in this cell.
+	default:
+		return 0, err
+	}
+	generation++
+	if err := writeFileAtomic(root, generationPath, []byte(fmt.Sprintf("%v", generation))); err != nil {
+		return 0, err
+	}
+	return FSVersion(generation), nil
+}
+
+// removeEmptyDirs removes dir and its parents, up to root, as long as
+// they are empty. This way, ListDir doesn't return directories
+// without files.
+func removeEmptyDirs(root, dir string) {
+	root = path.Clean(root)
+	for strings.HasPrefix(dir, root+"/") {
+		if err := os.Remove(dir); err != nil {
+			// Not empty (or already gone), we're done.
+			return
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// Create is part of the topo.Backend interface.
+func (s *Server) Create(ctx context.Context, cell, filePath string, contents []byte) (topo.Version, error) {
+	root, err := s.dirForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	nodePath := path.Join(root, filePath)
+
+	l, err := lockCell(root)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseLockFile(l)
+
+	if _, err := os.Stat(nodePath); err == nil {
+		return nil, topo.ErrNodeExists
+	}
+	version, err := writeFile(root, nodePath, contents)
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// Update is part of the topo.Backend interface.
+func (s *Server) Update(ctx context.Context, cell, filePath string, contents []byte, version topo.Version) (topo.Version, error) {
+	root, err := s.dirForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	nodePath := path.Join(root, filePath)
+
+	l, err := lockCell(root)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseLockFile(l)
+
+	if version != nil {
+		_, current, err := readFile(nodePath)
+		if err != nil {
+			return nil, err
+		}
+		if current != version.(FSVersion) {
+			return nil, topo.ErrBadVersion
+		}
+	}
+	newVersion, err := writeFile(root, nodePath, contents)
+	if err != nil {
+		return nil, err
+	}
+	return newVersion, nil
+}
+
+// Get is part of the topo.Backend interface.
+func (s *Server) Get(ctx context.Context, cell, filePath string) ([]byte, topo.Version, error) {
+	root, err := s.dirForCell(ctx, cell)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodePath := path.Join(root, filePath)
+
+	// Files are replaced atomically, we don't need the cell lock.
+	contents, version, err := readFile(nodePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return contents, version, nil
+}
+
+// Delete is part of the topo.Backend interface.
+func (s *Server) Delete(ctx context.Context, cell, filePath string, version topo.Version) error {
+	root, err := s.dirForCell(ctx, cell)
+	if err != nil {
+		return err
+	}
+	nodePath := path.Join(root, filePath)
+
+	l, err := lockCell(root)
+	if err != nil {
+		return err
+	}
+	defer releaseLockFile(l)
+
+	_, current, err := readFile(nodePath)
+	if err != nil {
+		return err
+	}
+	if version != nil && current != version.(FSVersion) {
+		return topo.ErrBadVersion
+	}
+	if err := os.Remove(nodePath); err != nil {
+		return convertError(err)
+	}
+	removeEmptyDirs(root, path.Dir(nodePath))
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// CreateKeyspace implements topo.Server.
+func (s *Server) CreateKeyspace(ctx context.Context, keyspace string, value *topodatapb.Keyspace) error {
+	data, err := proto.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	keyspacePath := path.Join(keyspacesPath, keyspace, topo.KeyspaceFile)
+	_, err = s.Create(ctx, topo.GlobalCell, keyspacePath, data)
+	return err
+}
+
+// UpdateKeyspace implements topo.Server.
+func (s *Server) UpdateKeyspace(ctx context.Context, keyspace string, value *topodatapb.Keyspace, existingVersion int64) (int64, error) {
+	data, err := proto.Marshal(value)
+	if err != nil {
+		return -1, err
+	}
+
+	keyspacePath := path.Join(keyspacesPath, keyspace, topo.KeyspaceFile)
+	version, err := s.Update(ctx, topo.GlobalCell, keyspacePath, data, VersionFromInt(existingVersion))
+	if err != nil {
+		return -1, err
+	}
+	return int64(version.(FSVersion)), nil
+}
+
+// GetKeyspace implements topo.Server.
+func (s *Server) GetKeyspace(ctx context.Context, keyspace string) (*topodatapb.Keyspace, int64, error) {
+	keyspacePath := path.Join(keyspacesPath, keyspace, topo.KeyspaceFile)
+	data, version, err := s.Get(ctx, topo.GlobalCell, keyspacePath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	k := &topodatapb.Keyspace{}
+	if err = proto.Unmarshal(data, k); err != nil {
+		return nil, 0, fmt.Errorf("bad keyspace data %v", err)
+	}
+
+	return k, int64(version.(FSVersion)), nil
+}
+
+// GetKeyspaces implements topo.Server.
+func (s *Server) GetKeyspaces(ctx context.Context) ([]string, error) {
+	children, err := s.ListDir(ctx, topo.GlobalCell, keyspacesPath)
+	switch err {
+	case nil:
+		return children, nil
+	case topo.ErrNoNode:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+// DeleteKeyspace implements topo.Server.
+func (s *Server) DeleteKeyspace(ctx context.Context, keyspace string) error {
+	keyspacePath := path.Join(keyspacesPath, keyspace, topo.KeyspaceFile)
+	return s.Delete(ctx, topo.GlobalCell, keyspacePath, nil)
+}
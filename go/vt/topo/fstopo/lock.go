@@ -0,0 +1,162 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"syscall"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+// lockCell takes the lock that serializes the modifications of the
+// files in the cell directory root. It blocks until the lock is
+// available: it is only held for the duration of a single write.
+// Release it with releaseLockFile.
+func lockCell(root string) (*os.File, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path.Join(root, cellLockFile), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// acquireLockFile takes the advisory lock on the lock file of the
+// directory dir. The lock is exclusive between open files, so it works
+// the same between processes and within a process. It polls until
+// the lock is free, or ctx is done.
+func acquireLockFile(ctx context.Context, dir string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path.Join(dir, lockFile), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return f, nil
+		}
+		if err != syscall.EWOULDBLOCK {
+			f.Close()
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			f.Close()
+			return nil, convertError(ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// writeLockFile replaces the contents of a lock file we hold.
+func writeLockFile(f *os.File, contents string) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := f.WriteAt([]byte(contents), 0)
+	return err
+}
+
+// releaseLockFile clears a lock file we hold, and releases the lock.
+func releaseLockFile(f *os.File) error {
+	f.Truncate(0)
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_UN); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// lock takes the lock for the directory dirPath of the global cell,
+// and stores contents in the lock file. The locks are stored in a
+// hidden directory, so they never show up in ListDir.
+// It returns the action path to pass to unlock.
+func (s *Server) lock(ctx context.Context, dirPath, contents string) (string, error) {
+	f, err := acquireLockFile(ctx, path.Join(s.global, locksDir, dirPath))
+	if err != nil {
+		return "", err
+	}
+	if err := writeLockFile(f, contents); err != nil {
+		releaseLockFile(f)
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lockIndex++
+	actionPath := path.Join(dirPath, fmt.Sprintf("%v", s.lockIndex))
+	s.locks[actionPath] = f
+	return actionPath, nil
+}
+
+// unlock releases a lock acquired by lock() on the given directory.
+// The string returned by lock() should be passed as the actionPath.
+func (s *Server) unlock(dirPath, actionPath string) error {
+	// Sanity check.
+	if checkPath := path.Dir(actionPath); checkPath != dirPath {
+		return fmt.Errorf("unlock: actionPath doesn't match directory being unlocked: %q != %q", checkPath, dirPath)
+	}
+
+	s.mu.Lock()
+	f, ok := s.locks[actionPath]
+	delete(s.locks, actionPath)
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unlock: lock %v is not held", actionPath)
+	}
+	return releaseLockFile(f)
+}
+
+// LockKeyspaceForAction implements topo.Server.
+func (s *Server) LockKeyspaceForAction(ctx context.Context, keyspace, contents string) (string, error) {
+	// Check the keyspace exists first.
+	keyspacePath := path.Join(keyspacesPath, keyspace, topo.KeyspaceFile)
+	_, _, err := s.Get(ctx, topo.GlobalCell, keyspacePath)
+	if err != nil {
+		return "", err
+	}
+
+	return s.lock(ctx, path.Join(keyspacesPath, keyspace), contents)
+}
+
+// UnlockKeyspaceForAction implements topo.Server.
+func (s *Server) UnlockKeyspaceForAction(ctx context.Context, keyspace, actionPath, results string) error {
+	log.Infof("results of %v: %v", actionPath, results)
+	return s.unlock(path.Join(keyspacesPath, keyspace), actionPath)
+}
+
+// LockShardForAction implements topo.Server.
+func (s *Server) LockShardForAction(ctx context.Context, keyspace, shard, contents string) (string, error) {
+	shardPath := path.Join(keyspacesPath, keyspace, shardsPath, shard, topo.ShardFile)
+	_, _, err := s.Get(ctx, topo.GlobalCell, shardPath)
+	if err != nil {
+		return "", err
+	}
+
+	return s.lock(ctx, path.Join(keyspacesPath, keyspace, shardsPath, shard), contents)
+}
+
+// UnlockShardForAction implements topo.Server.
+func (s *Server) UnlockShardForAction(ctx context.Context, keyspace, shard, actionPath, results string) error {
+	log.Infof("results of %v: %v", actionPath, results)
+	return s.unlock(path.Join(keyspacesPath, keyspace, shardsPath, shard), actionPath)
+}
@@ -0,0 +1,107 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package fstopo implements topo.Server with a local directory as the backend.
+
+It is meant for development and test setups that want a topology which
+survives restarts, without running a ZooKeeper or etcd cluster. All
+processes sharing the topology must run on the same host (or use a
+shared file system with working advisory locks).
+
+The global cell is stored in the directory passed as the root of the
+server. Each cell is stored in the directory in the Root field of its
+CellInfo. The server address is not used.
+
+Within a cell directory:
+
+  - Each file is stored as a regular file. Its first line is the
+    version, the rest of the file is the contents.
+  - Files are written to a temporary file first, and then renamed to
+    their final name, so readers never see a partial file.
+  - Versions come from a generation counter stored in the cell directory.
+    It is only modified while holding an advisory lock on the cell, which
+    also serializes all file modifications.
+  - Entries starting with a '.' are used for the implementation, and are
+    never returned by ListDir.
+
+We follow these conventions within this package:
+
+  - Call convertError(err) on any errors returned from the os package.
+    Functions defined in this package can be assumed to have already converted
+    errors as necessary.
+*/
+package fstopo
+
+import (
+	"os"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+// Server is the implementation of topo.Server for a local directory.
+type Server struct {
+	// global is the root directory of the global cell.
+	global string
+
+	// mu protects the following fields.
+	mu sync.Mutex
+	// cells contains the root directories of the local cells.
+	// These should be accessed with the Server.dirForCell() method,
+	// which will read the directory for that cell from the global
+	// cell as needed.
+	cells map[string]string
+	// locks contains the open lock files of the locks held by this
+	// process, indexed by their action path.
+	locks map[string]*os.File
+	// lockIndex is used to generate unique action paths.
+	lockIndex int64
+}
+
+// Close implements topo.Server.Close.
+// It releases all the locks still held by this server.
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range s.locks {
+		releaseLockFile(f)
+	}
+	s.locks = nil
+	s.cells = nil
+}
+
+// GetKnownCells implements topo.Server.GetKnownCells.
+func (s *Server) GetKnownCells(ctx context.Context) ([]string, error) {
+	children, err := s.ListDir(ctx, topo.GlobalCell, cellsPath)
+	switch err {
+	case nil:
+		return children, nil
+	case topo.ErrNoNode:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+// NewServer returns a new fstopo.Server, storing the global cell in
+// the provided directory. The directory is created if necessary.
+func NewServer(root string) (*Server, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	return &Server{
+		global: root,
+		cells:  make(map[string]string),
+		locks:  make(map[string]*os.File),
+	}, nil
+}
+
+func init() {
+	topo.RegisterFactory("fs", func(serverAddr, root string) (topo.Impl, error) {
+		return NewServer(root)
+	})
+}
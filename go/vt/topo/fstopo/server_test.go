@@ -0,0 +1,117 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/test"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+func TestFSTopo(t *testing.T) {
+	// Create a temporary directory for all the tests.
+	dataDir, err := ioutil.TempDir("", "fstopo")
+	if err != nil {
+		t.Fatalf("cannot create tempdir: %v", err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	// Poll watched files often, so the watch tests are fast.
+	*watchPollInterval = 10 * time.Millisecond
+
+	// This function will create a toplevel directory for a new test.
+	testIndex := 0
+	newServer := func() (*Server, string) {
+		// Each test will use its own sub-directories.
+		testRoot := path.Join(dataDir, fmt.Sprintf("test-%v", testIndex))
+		testIndex++
+
+		// Create the server on the new root.
+		s, err := NewServer(path.Join(testRoot, "global"))
+		if err != nil {
+			t.Fatalf("NewServer() failed: %v", err)
+		}
+
+		return s, testRoot
+	}
+
+	// Run the TopoServerTestSuite tests.
+	test.TopoServerTestSuite(t, func() topo.Impl {
+		s, testRoot := newServer()
+
+		// Create the CellInfo.
+		ctx := context.Background()
+		cell := "test"
+		ci := &topodatapb.CellInfo{
+			Root: path.Join(testRoot, cell),
+		}
+		data, err := proto.Marshal(ci)
+		if err != nil {
+			t.Fatalf("cannot proto.Marshal CellInfo: %v", err)
+		}
+		nodePath := path.Join(cellsPath, cell, topo.CellInfoFile)
+		if _, err := s.Create(ctx, topo.GlobalCell, nodePath, data); err != nil {
+			t.Fatalf("s.Create(%v) failed: %v", nodePath, err)
+		}
+
+		return s
+	})
+
+	// Run fstopo-specific tests.
+	s, testRoot := newServer()
+	testPersistence(t, s, testRoot)
+}
+
+// testPersistence checks a new server on the same directory sees the
+// data and the locks of a previous one.
+func testPersistence(t *testing.T, ts *Server, testRoot string) {
+	ctx := context.Background()
+
+	if err := ts.CreateKeyspace(ctx, "test_keyspace", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace: %v", err)
+	}
+	actionPath, err := ts.LockKeyspaceForAction(ctx, "test_keyspace", "contents")
+	if err != nil {
+		t.Fatalf("LockKeyspaceForAction failed: %v", err)
+	}
+
+	ts2, err := NewServer(path.Join(testRoot, "global"))
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	defer ts2.Close()
+	keyspaces, err := ts2.GetKeyspaces(ctx)
+	if err != nil || len(keyspaces) != 1 || keyspaces[0] != "test_keyspace" {
+		t.Errorf("GetKeyspaces() = %v, %v, want [test_keyspace]", keyspaces, err)
+	}
+
+	// The lock is held by the first server.
+	fastCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := ts2.LockKeyspaceForAction(fastCtx, "test_keyspace", "contents2"); err != topo.ErrTimeout {
+		t.Errorf("LockKeyspaceForAction(locked) = %v, want ErrTimeout", err)
+	}
+
+	// Closing the first server releases its locks.
+	ts.Close()
+	actionPath, err = ts2.LockKeyspaceForAction(ctx, "test_keyspace", "contents2")
+	if err != nil {
+		t.Fatalf("LockKeyspaceForAction failed: %v", err)
+	}
+	if err := ts2.UnlockKeyspaceForAction(ctx, "test_keyspace", actionPath, "results"); err != nil {
+		t.Errorf("UnlockKeyspaceForAction failed: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"fmt"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+// FSVersion is the version of a file in a cell directory.
+// It implements topo.Version.
+// It is the value of the generation counter of the cell when the
+// file was last written.
+type FSVersion uint64
+
+// String is part of the topo.Version interface.
+func (v FSVersion) String() string {
+	return fmt.Sprintf("%v", uint64(v))
+}
+
+// VersionFromInt is used by old-style functions to create a proper
+// Version: if version is -1, returns nil. Otherwise returns the
+// FSVersion object.
+func VersionFromInt(version int64) topo.Version {
+	if version == -1 {
+		return nil
+	}
+	return FSVersion(version)
+}
@@ -0,0 +1,210 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fstopo
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+var (
+	watchPollInterval = flag.Duration("topo_fs_watch_poll_interval", time.Second, "how often a watched file is read to detect changes")
+)
+
+// Watch is part of the topo.Backend interface.
+// We don't use inotify, as it doesn't work on all file systems.
+// Instead, we read the file regularly, and compare its version.
+func (s *Server) Watch(ctx context.Context, cell, filePath string) (*topo.WatchData, <-chan *topo.WatchData, topo.CancelFunc) {
+	root, err := s.dirForCell(ctx, cell)
+	if err != nil {
+		return &topo.WatchData{Err: fmt.Errorf("Watch cannot get cell: %v", err)}, nil, nil
+	}
+	nodePath := path.Join(root, filePath)
+
+	// Get the initial version of the file.
+	contents, version, err := readFile(nodePath)
+	if err != nil {
+		return &topo.WatchData{Err: err}, nil, nil
+	}
+	wd := &topo.WatchData{
+		Contents: contents,
+		Version:  version,
+	}
+
+	// Create a context, will be used to cancel the watch.
+	watchCtx, watchCancel := context.WithCancel(context.Background())
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchData, 10)
+	go func() {
+		defer close(notifications)
+
+		ticker := time.NewTicker(*watchPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-watchCtx.Done():
+				// This includes context cancelation errors.
+				notifications <- &topo.WatchData{
+					Err: convertError(watchCtx.Err()),
+				}
+				return
+			case <-ticker.C:
+			}
+
+			contents, newVersion, err := readFile(nodePath)
+			if err != nil {
+				// The node is gone (ErrNoNode), or we can't
+				// read it any more. Send a final notice.
+				notifications <- &topo.WatchData{
+					Err: err,
+				}
+				return
+			}
+			if newVersion == version {
+				continue
+			}
+			version = newVersion
+			notifications <- &topo.WatchData{
+				Contents: contents,
+				Version:  newVersion,
+			}
+		}
+	}()
+
+	return wd, notifications, topo.CancelFunc(watchCancel)
+}
+
+// WatchRecursive is part of the topo.Backend interface.
+// Same as Watch, it reads all the files under the directory regularly,
+// and compares their versions.
+func (s *Server) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	root, err := s.dirForCell(ctx, cell)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("WatchRecursive cannot get cell: %v", err)
+	}
+	dirPath = strings.Trim(path.Clean("/"+dirPath), "/")
+
+	// Get the initial version of the files.
+	files := make(map[string]*topo.WatchDataRecursive)
+	if err := readFiles(root, dirPath, files); err != nil {
+		return nil, nil, nil, err
+	}
+	current := make([]*topo.WatchDataRecursive, 0, len(files))
+	for _, filePath := range sortedPaths(files) {
+		current = append(current, files[filePath])
+	}
+
+	// Create a context, will be used to cancel the watch.
+	watchCtx, watchCancel := context.WithCancel(context.Background())
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchDataRecursive, 10)
+	go func() {
+		defer close(notifications)
+
+		ticker := time.NewTicker(*watchPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-watchCtx.Done():
+				// This includes context cancelation errors.
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: convertError(watchCtx.Err())},
+				}
+				return
+			case <-ticker.C:
+			}
+
+			newFiles := make(map[string]*topo.WatchDataRecursive)
+			if err := readFiles(root, dirPath, newFiles); err != nil {
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: err},
+				}
+				return
+			}
+			for _, filePath := range sortedPaths(newFiles) {
+				wd := newFiles[filePath]
+				if old, ok := files[filePath]; ok && old.Version == wd.Version {
+					continue
+				}
+				notifications <- wd
+			}
+			for _, filePath := range sortedPaths(files) {
+				if _, ok := newFiles[filePath]; !ok {
+					notifications <- &topo.WatchDataRecursive{
+						Path:      filePath,
+						WatchData: topo.WatchData{Err: topo.ErrNoNode},
+					}
+				}
+			}
+			files = newFiles
+		}
+	}()
+
+	return current, notifications, topo.CancelFunc(watchCancel), nil
+}
+
+// readFiles reads all the files under dirPath, and adds them to
+// result by path. dirPath is relative to root. A missing directory has
+// no files.
+func readFiles(root, dirPath string, result map[string]*topo.WatchDataRecursive) error {
+	entries, err := ioutil.ReadDir(path.Join(root, dirPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return convertError(err)
+	}
+	for _, e := range entries {
+		// Skip the entries used by the implementation.
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		filePath := path.Join(dirPath, e.Name())
+		if e.IsDir() {
+			if err := readFiles(root, filePath, result); err != nil {
+				return err
+			}
+			continue
+		}
+		contents, version, err := readFile(path.Join(root, filePath))
+		switch err {
+		case nil:
+			result[filePath] = &topo.WatchDataRecursive{
+				Path: filePath,
+				WatchData: topo.WatchData{
+					Contents: contents,
+					Version:  version,
+				},
+			}
+		case topo.ErrNoNode:
+			// Deleted since we listed the directory.
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedPaths returns the keys of files, sorted.
+func sortedPaths(files map[string]*topo.WatchDataRecursive) []string {
+	result := make([]string, 0, len(files))
+	for filePath := range files {
+		result = append(result, filePath)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -38,12 +38,8 @@ func CopyKeyspaces(ctx context.Context, fromTS, toTS topo.Impl) {
 				return
 			}
 
-			if err := toTS.CreateKeyspace(ctx, keyspace, k); err != nil {
-				if err == topo.ErrNodeExists {
-					log.Warningf("keyspace %v already exists", keyspace)
-				} else {
-					rec.RecordError(fmt.Errorf("CreateKeyspace(%v): %v", keyspace, err))
-				}
+			if err := createKeyspace(ctx, toTS, keyspace, k); err != nil {
+				rec.RecordError(err)
 			}
 
 			vs, err := fromTS.GetVSchema(ctx, keyspace)
@@ -96,13 +92,8 @@ func CopyShards(ctx context.Context, fromTS, toTS topo.Impl) {
 						return
 					}
 
-					if err := toTS.CreateShard(ctx, keyspace, shard, s); err != nil {
-						if err == topo.ErrNodeExists {
-							log.Warningf("shard %v/%v already exists", keyspace, shard)
-						} else {
-							rec.RecordError(fmt.Errorf("CreateShard(%v, %v): %v", keyspace, shard, err))
-							return
-						}
+					if err := createShard(ctx, toTS, keyspace, shard, s); err != nil {
+						rec.RecordError(err)
 					}
 				}(keyspace, shard)
 			}
@@ -120,10 +111,6 @@ func CopyTablets(ctx context.Context, fromTS, toTS topo.Impl) {
 	if err != nil {
 		log.Fatalf("fromTS.GetKnownCells: %v", err)
 	}
-	tts := topo.Server{
-		Impl: toTS,
-	}
-
 	wg := sync.WaitGroup{}
 	rec := concurrency.AllErrorRecorder{}
 	for _, cell := range cells {
@@ -146,19 +133,8 @@ func CopyTablets(ctx context.Context, fromTS, toTS topo.Impl) {
 							return
 						}
 
-						// try to create the destination
-						err = toTS.CreateTablet(ctx, tablet)
-						if err == topo.ErrNodeExists {
-							// update the destination tablet
-							log.Warningf("tablet %v already exists, updating it", tabletAlias)
-							_, err = tts.UpdateTabletFields(ctx, tablet.Alias, func(t *topodatapb.Tablet) error {
-								*t = *tablet
-								return nil
-							})
-						}
-						if err != nil {
-							rec.RecordError(fmt.Errorf("CreateTablet(%v): %v", tabletAlias, err))
-							return
+						if err := saveTablet(ctx, toTS, tablet); err != nil {
+							rec.RecordError(err)
 						}
 					}(tabletAlias)
 				}
@@ -178,10 +154,6 @@ func CopyShardReplications(ctx context.Context, fromTS, toTS topo.Impl) {
 	if err != nil {
 		log.Fatalf("fromTS.GetKeyspaces: %v", err)
 	}
-	tts := topo.Server{
-		Impl: toTS,
-	}
-
 	wg := sync.WaitGroup{}
 	rec := concurrency.AllErrorRecorder{}
 	for _, keyspace := range keyspaces {
@@ -213,11 +185,8 @@ func CopyShardReplications(ctx context.Context, fromTS, toTS topo.Impl) {
 							continue
 						}
 
-						if err := tts.UpdateShardReplicationFields(ctx, cell, keyspace, shard, func(oldSR *topodatapb.ShardReplication) error {
-							*oldSR = *sri.ShardReplication
-							return nil
-						}); err != nil {
-							rec.RecordError(fmt.Errorf("UpdateShardReplicationFields(%v, %v, %v): %v", cell, keyspace, shard, err))
+						if err := saveShardReplication(ctx, toTS, cell, keyspace, shard, sri.ShardReplication); err != nil {
+							rec.RecordError(err)
 						}
 					}
 				}(keyspace, shard)
@@ -229,3 +198,64 @@ func CopyShardReplications(ctx context.Context, fromTS, toTS topo.Impl) {
 		log.Fatalf("copyShards failed: %v", rec.Error())
 	}
 }
+
+// The following functions write one object to the destination topo.
+// They are used by the Copy functions above, and to restore snapshots.
+
+// createKeyspace creates the keyspace, or logs a warning if it
+// already exists.
+func createKeyspace(ctx context.Context, toTS topo.Impl, keyspace string, k *topodatapb.Keyspace) error {
+	if err := toTS.CreateKeyspace(ctx, keyspace, k); err != nil {
+		if err == topo.ErrNodeExists {
+			log.Warningf("keyspace %v already exists", keyspace)
+			return nil
+		}
+		return fmt.Errorf("CreateKeyspace(%v): %v", keyspace, err)
+	}
+	return nil
+}
+
+// createShard creates the shard, or logs a warning if it already exists.
+func createShard(ctx context.Context, toTS topo.Impl, keyspace, shard string, s *topodatapb.Shard) error {
+	if err := toTS.CreateShard(ctx, keyspace, shard, s); err != nil {
+		if err == topo.ErrNodeExists {
+			log.Warningf("shard %v/%v already exists", keyspace, shard)
+			return nil
+		}
+		return fmt.Errorf("CreateShard(%v, %v): %v", keyspace, shard, err)
+	}
+	return nil
+}
+
+// saveTablet creates the tablet, or updates it if it already exists.
+func saveTablet(ctx context.Context, toTS topo.Impl, tablet *topodatapb.Tablet) error {
+	// try to create the destination
+	err := toTS.CreateTablet(ctx, tablet)
+	if err == topo.ErrNodeExists {
+		// update the destination tablet
+		log.Warningf("tablet %v already exists, updating it", tablet.Alias)
+		tts := topo.Server{
+			Impl: toTS,
+		}
+		_, err = tts.UpdateTabletFields(ctx, tablet.Alias, func(t *topodatapb.Tablet) error {
+			*t = *tablet
+			return nil
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("CreateTablet(%v): %v", tablet.Alias, err)
+	}
+	return nil
+}
+
+// saveShardReplication creates or replaces the ShardReplication object
+// of the shard in the cell.
+func saveShardReplication(ctx context.Context, toTS topo.Impl, cell, keyspace, shard string, sr *topodatapb.ShardReplication) error {
+	if err := toTS.UpdateShardReplicationFields(ctx, cell, keyspace, shard, func(oldSR *topodatapb.ShardReplication) error {
+		*oldSR = *sr
+		return nil
+	}); err != nil {
+		return fmt.Errorf("UpdateShardReplicationFields(%v, %v, %v): %v", cell, keyspace, shard, err)
+	}
+	return nil
+}
@@ -0,0 +1,665 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+	vschemapb "github.com/gitql/vitess/go/vt/proto/vschema"
+	workflowpb "github.com/gitql/vitess/go/vt/proto/workflow"
+)
+
+// SnapshotFormatVersion is the version of the snapshot files written
+// by WriteSnapshotFile. Increase it when the format changes in a way
+// older code cannot read.
+const SnapshotFormatVersion = 1
+
+// Snapshot is the complete content of a topology: the global cell
+// and all the known cells. It is saved as a JSON file.
+type Snapshot struct {
+	// FormatVersion is the SnapshotFormatVersion of the code that
+	// took the snapshot.
+	FormatVersion int
+
+	// Time is when the snapshot was taken.
+	Time time.Time
+
+	// Keyspaces has the global keyspace objects, sorted by name.
+	Keyspaces []*SnapshotKeyspace
+
+	// Cells has the cell objects, sorted by name.
+	Cells []*SnapshotCell
+
+	// Workflows has the global workflows, sorted by uuid.
+	Workflows []*workflowpb.Workflow
+}
+
+// SnapshotKeyspace has the global objects of a keyspace.
+type SnapshotKeyspace struct {
+	Name     string
+	Keyspace *topodatapb.Keyspace
+	// VSchema is nil if the keyspace has no VSchema.
+	VSchema *vschemapb.Keyspace
+	Shards  []*SnapshotShard
+}
+
+// SnapshotShard is a Shard object.
+type SnapshotShard struct {
+	Name  string
+	Shard *topodatapb.Shard
+}
+
+// SnapshotCell has the objects of a cell.
+type SnapshotCell struct {
+	Name string
+	// CellInfo is the record in the global cell. It is nil for
+	// topology implementations without CellInfo records.
+	CellInfo          *topodatapb.CellInfo
+	Tablets           []*topodatapb.Tablet
+	ShardReplications []*SnapshotShardReplication
+	SrvKeyspaces      []*SnapshotSrvKeyspace
+	// SrvVSchema is nil if the cell has no SrvVSchema.
+	SrvVSchema *vschemapb.SrvVSchema
+}
+
+// SnapshotShardReplication is a ShardReplication object of a cell.
+type SnapshotShardReplication struct {
+	Keyspace         string
+	Shard            string
+	ShardReplication *topodatapb.ShardReplication
+}
+
+// SnapshotSrvKeyspace is a SrvKeyspace object of a cell.
+type SnapshotSrvKeyspace struct {
+	Keyspace    string
+	SrvKeyspace *topodatapb.SrvKeyspace
+}
+
+// TakeSnapshot reads the complete topology.
+// It is not atomic: objects changed while it runs may be saved
+// before or after the change.
+func TakeSnapshot(ctx context.Context, ts topo.Server) (*Snapshot, error) {
+	s := &Snapshot{
+		FormatVersion: SnapshotFormatVersion,
+		Time:          time.Now(),
+	}
+
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetKeyspaces: %v", err)
+	}
+	// shardNames has the shards of each keyspace, to read the
+	// replication graph of the cells.
+	shardNames := make(map[string][]string)
+	for _, keyspace := range keyspaces {
+		sk, err := snapshotKeyspace(ctx, ts, keyspace)
+		if err != nil {
+			return nil, err
+		}
+		s.Keyspaces = append(s.Keyspaces, sk)
+		for _, shard := range sk.Shards {
+			shardNames[keyspace] = append(shardNames[keyspace], shard.Name)
+		}
+	}
+
+	cells, err := ts.GetKnownCells(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetKnownCells: %v", err)
+	}
+	for _, cell := range cells {
+		sc, err := snapshotCell(ctx, ts, cell, keyspaces, shardNames)
+		if err != nil {
+			return nil, err
+		}
+		s.Cells = append(s.Cells, sc)
+	}
+
+	uuids, err := ts.GetWorkflowNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetWorkflowNames: %v", err)
+	}
+	for _, uuid := range uuids {
+		wi, err := ts.GetWorkflow(ctx, uuid)
+		if err != nil {
+			return nil, fmt.Errorf("GetWorkflow(%v): %v", uuid, err)
+		}
+		s.Workflows = append(s.Workflows, wi.Workflow)
+	}
+	return s, nil
+}
+
+func snapshotKeyspace(ctx context.Context, ts topo.Server, keyspace string) (*SnapshotKeyspace, error) {
+	k, _, err := ts.Impl.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return nil, fmt.Errorf("GetKeyspace(%v): %v", keyspace, err)
+	}
+	sk := &SnapshotKeyspace{
+		Name:     keyspace,
+		Keyspace: k,
+	}
+
+	vs, err := ts.Impl.GetVSchema(ctx, keyspace)
+	switch err {
+	case nil:
+		sk.VSchema = vs
+	case topo.ErrNoNode:
+		// Nothing to do.
+	default:
+		return nil, fmt.Errorf("GetVSchema(%v): %v", keyspace, err)
+	}
+
+	shards, err := ts.Impl.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return nil, fmt.Errorf("GetShardNames(%v): %v", keyspace, err)
+	}
+	for _, shard := range shards {
+		s, _, err := ts.Impl.GetShard(ctx, keyspace, shard)
+		if err != nil {
+			return nil, fmt.Errorf("GetShard(%v, %v): %v", keyspace, shard, err)
+		}
+		sk.Shards = append(sk.Shards, &SnapshotShard{
+			Name:  shard,
+			Shard: s,
+		})
+	}
+	return sk, nil
+}
+
+func snapshotCell(ctx context.Context, ts topo.Server, cell string, keyspaces []string, shardNames map[string][]string) (*SnapshotCell, error) {
+	sc := &SnapshotCell{
+		Name: cell,
+	}
+
+	ci, err := ts.GetCellInfo(ctx, cell)
+	switch err {
+	case nil:
+		sc.CellInfo = ci
+	case topo.ErrNoNode:
+		// Nothing to do.
+	default:
+		return nil, fmt.Errorf("GetCellInfo(%v): %v", cell, err)
+	}
+
+	tabletAliases, err := ts.Impl.GetTabletsByCell(ctx, cell)
+	if err != nil {
+		return nil, fmt.Errorf("GetTabletsByCell(%v): %v", cell, err)
+	}
+	for _, tabletAlias := range tabletAliases {
+		tablet, _, err := ts.Impl.GetTablet(ctx, tabletAlias)
+		if err != nil {
+			return nil, fmt.Errorf("GetTablet(%v): %v", topoproto.TabletAliasString(tabletAlias), err)
+		}
+		sc.Tablets = append(sc.Tablets, tablet)
+	}
+
+	for _, keyspace := range keyspaces {
+		for _, shard := range shardNames[keyspace] {
+			sri, err := ts.Impl.GetShardReplication(ctx, cell, keyspace, shard)
+			switch err {
+			case nil:
+				sc.ShardReplications = append(sc.ShardReplications, &SnapshotShardReplication{
+					Keyspace:         keyspace,
+					Shard:            shard,
+					ShardReplication: sri.ShardReplication,
+				})
+			case topo.ErrNoNode:
+				// Nothing to do.
+			default:
+				return nil, fmt.Errorf("GetShardReplication(%v, %v, %v): %v", cell, keyspace, shard, err)
+			}
+		}
+	}
+
+	srvKeyspaces, err := ts.Impl.GetSrvKeyspaceNames(ctx, cell)
+	if err != nil {
+		return nil, fmt.Errorf("GetSrvKeyspaceNames(%v): %v", cell, err)
+	}
+	for _, keyspace := range srvKeyspaces {
+		sk, err := ts.Impl.GetSrvKeyspace(ctx, cell, keyspace)
+		switch err {
+		case nil:
+			sc.SrvKeyspaces = append(sc.SrvKeyspaces, &SnapshotSrvKeyspace{
+				Keyspace:    keyspace,
+				SrvKeyspace: sk,
+			})
+		case topo.ErrNoNode:
+			// Some implementations list keyspaces that have
+			// other objects but no SrvKeyspace.
+		default:
+			return nil, fmt.Errorf("GetSrvKeyspace(%v, %v): %v", cell, keyspace, err)
+		}
+	}
+
+	srvVSchema, err := ts.Impl.GetSrvVSchema(ctx, cell)
+	switch err {
+	case nil:
+		sc.SrvVSchema = srvVSchema
+	case topo.ErrNoNode:
+		// Nothing to do.
+	default:
+		return nil, fmt.Errorf("GetSrvVSchema(%v): %v", cell, err)
+	}
+	return sc, nil
+}
+
+// WriteSnapshotFile saves a snapshot to a file.
+func WriteSnapshotFile(fileName string, s *Snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal snapshot: %v", err)
+	}
+	return ioutil.WriteFile(fileName, data, 0644)
+}
+
+// ReadSnapshotFile reads a snapshot saved by WriteSnapshotFile.
+func ReadSnapshotFile(fileName string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal snapshot %v: %v", fileName, err)
+	}
+	if s.FormatVersion < 1 || s.FormatVersion > SnapshotFormatVersion {
+		return nil, fmt.Errorf("snapshot %v has format version %v, this code supports versions 1 to %v", fileName, s.FormatVersion, SnapshotFormatVersion)
+	}
+	return s, nil
+}
+
+// The actions of a SnapshotChange.
+const (
+	SnapshotActionCreate = "Create"
+	SnapshotActionUpdate = "Update"
+	SnapshotActionDelete = "Delete"
+)
+
+// SnapshotChange is a change to make to a topology to restore a
+// snapshot.
+type SnapshotChange struct {
+	// Action is one of the SnapshotAction constants.
+	Action string
+
+	// Object describes the changed object, for instance
+	// "Shard ks/-80" or "Tablet cell1-0000000100".
+	Object string
+
+	// Old is the current value, nil for Create.
+	// New is the value in the snapshot, nil for Delete.
+	Old proto.Message
+	New proto.Message
+
+	// apply makes the change.
+	apply func(ctx context.Context) error
+}
+
+// String returns a text representation of the change.
+func (c *SnapshotChange) String() string {
+	switch c.Action {
+	case SnapshotActionCreate:
+		return fmt.Sprintf("%v %v\n  + %v", c.Action, c.Object, proto.CompactTextString(c.New))
+	case SnapshotActionDelete:
+		return fmt.Sprintf("%v %v\n  - %v", c.Action, c.Object, proto.CompactTextString(c.Old))
+	default:
+		return fmt.Sprintf("%v %v\n  - %v\n  + %v", c.Action, c.Object, proto.CompactTextString(c.Old), proto.CompactTextString(c.New))
+	}
+}
+
+// protoOrNil returns nil for a nil pointer to a proto message, so we
+// can compare with nil.
+func protoOrNil(m proto.Message) proto.Message {
+	if m == nil || reflect.ValueOf(m).IsNil() {
+		return nil
+	}
+	return m
+}
+
+// snapshotDiff computes the changes between two snapshots.
+type snapshotDiff struct {
+	deleteExtra bool
+
+	// saves are the creations and updates, in dependency order.
+	saves []*SnapshotChange
+	// deletes are the deletions, in dependency order as well: they
+	// are made in reverse order.
+	deletes []*SnapshotChange
+}
+
+// add records the change for an object, if any. create, update and
+// remove make the change. remove can be nil if the object cannot be
+// deleted.
+func (d *snapshotDiff) add(object string, current, target proto.Message, create, update, remove func(ctx context.Context) error) {
+	current = protoOrNil(current)
+	target = protoOrNil(target)
+	switch {
+	case current == nil && target == nil:
+	case current == nil:
+		d.saves = append(d.saves, &SnapshotChange{
+			Action: SnapshotActionCreate,
+			Object: object,
+			New:    target,
+			apply:  create,
+		})
+	case target == nil:
+		if d.deleteExtra && remove != nil {
+			d.deletes = append(d.deletes, &SnapshotChange{
+				Action: SnapshotActionDelete,
+				Object: object,
+				Old:    current,
+				apply:  remove,
+			})
+		}
+	case !proto.Equal(current, target):
+		d.saves = append(d.saves, &SnapshotChange{
+			Action: SnapshotActionUpdate,
+			Object: object,
+			Old:    current,
+			New:    target,
+			apply:  update,
+		})
+	}
+}
+
+// changes returns all the changes, in the order they need to be made.
+func (d *snapshotDiff) changes() []*SnapshotChange {
+	result := d.saves
+	for i := len(d.deletes) - 1; i >= 0; i-- {
+		result = append(result, d.deletes[i])
+	}
+	return result
+}
+
+// DiffSnapshot returns the changes to make to the topology so it
+// matches the snapshot. The objects that are not in the snapshot are
+// only deleted if deleteExtra is set. VSchema and SrvVSchema objects
+// are never deleted.
+func DiffSnapshot(ctx context.Context, ts topo.Server, target *Snapshot, deleteExtra bool) ([]*SnapshotChange, error) {
+	current, err := TakeSnapshot(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+	d := &snapshotDiff{deleteExtra: deleteExtra}
+
+	// Cells first, as we need the CellInfo to access a cell.
+	currentCells := make(map[string]*SnapshotCell)
+	for _, sc := range current.Cells {
+		currentCells[sc.Name] = sc
+	}
+	targetCells := make(map[string]*SnapshotCell)
+	for _, sc := range target.Cells {
+		targetCells[sc.Name] = sc
+	}
+	for _, cell := range unionNames(currentCells, targetCells) {
+		cell := cell
+		var currentInfo, targetInfo *topodatapb.CellInfo
+		if sc, ok := currentCells[cell]; ok {
+			currentInfo = sc.CellInfo
+		}
+		if sc, ok := targetCells[cell]; ok {
+			targetInfo = sc.CellInfo
+		}
+		d.add("CellInfo "+cell, currentInfo, targetInfo,
+			func(ctx context.Context) error {
+				return ts.CreateCellInfo(ctx, cell, targetInfo)
+			},
+			func(ctx context.Context) error {
+				return ts.UpdateCellInfoFields(ctx, cell, func(ci *topodatapb.CellInfo) error {
+					*ci = *targetInfo
+					return nil
+				})
+			},
+			func(ctx context.Context) error {
+				return ts.DeleteCellInfo(ctx, cell)
+			})
+	}
+
+	// Then the global keyspace objects.
+	currentKeyspaces := make(map[string]*SnapshotKeyspace)
+	for _, sk := range current.Keyspaces {
+		currentKeyspaces[sk.Name] = sk
+	}
+	targetKeyspaces := make(map[string]*SnapshotKeyspace)
+	for _, sk := range target.Keyspaces {
+		targetKeyspaces[sk.Name] = sk
+	}
+	for _, keyspace := range unionNames(currentKeyspaces, targetKeyspaces) {
+		currentKeyspace := currentKeyspaces[keyspace]
+		if currentKeyspace == nil {
+			currentKeyspace = &SnapshotKeyspace{Name: keyspace}
+		}
+		targetKeyspace := targetKeyspaces[keyspace]
+		if targetKeyspace == nil {
+			targetKeyspace = &SnapshotKeyspace{Name: keyspace}
+		}
+		diffKeyspace(d, ts, currentKeyspace, targetKeyspace)
+	}
+
+	// Then the cell objects.
+	for _, cell := range unionNames(currentCells, targetCells) {
+		currentCell := currentCells[cell]
+		if currentCell == nil {
+			currentCell = &SnapshotCell{Name: cell}
+		}
+		targetCell := targetCells[cell]
+		if targetCell == nil {
+			targetCell = &SnapshotCell{Name: cell}
+		}
+		diffCell(d, ts, currentCell, targetCell)
+	}
+
+	// And the workflows.
+	currentWorkflows := make(map[string]*workflowpb.Workflow)
+	for _, w := range current.Workflows {
+		currentWorkflows[w.Uuid] = w
+	}
+	targetWorkflows := make(map[string]*workflowpb.Workflow)
+	for _, w := range target.Workflows {
+		targetWorkflows[w.Uuid] = w
+	}
+	for _, uuid := range unionNames(currentWorkflows, targetWorkflows) {
+		uuid := uuid
+		targetWorkflow := targetWorkflows[uuid]
+		d.add("Workflow "+uuid, currentWorkflows[uuid], targetWorkflow,
+			func(ctx context.Context) error {
+				_, err := ts.CreateWorkflow(ctx, targetWorkflow)
+				return err
+			},
+			func(ctx context.Context) error {
+				wi, err := ts.GetWorkflow(ctx, uuid)
+				if err != nil {
+					return err
+				}
+				wi.Workflow = targetWorkflow
+				return ts.SaveWorkflow(ctx, wi)
+			},
+			func(ctx context.Context) error {
+				wi, err := ts.GetWorkflow(ctx, uuid)
+				if err != nil {
+					return err
+				}
+				return ts.DeleteWorkflow(ctx, wi)
+			})
+	}
+
+	return d.changes(), nil
+}
+
+func diffKeyspace(d *snapshotDiff, ts topo.Server, current, target *SnapshotKeyspace) {
+	keyspace := target.Name
+	d.add("Keyspace "+keyspace, current.Keyspace, target.Keyspace,
+		func(ctx context.Context) error {
+			return createKeyspace(ctx, ts.Impl, keyspace, target.Keyspace)
+		},
+		func(ctx context.Context) error {
+			_, version, err := ts.Impl.GetKeyspace(ctx, keyspace)
+			if err != nil {
+				return err
+			}
+			_, err = ts.Impl.UpdateKeyspace(ctx, keyspace, target.Keyspace, version)
+			return err
+		},
+		func(ctx context.Context) error {
+			return ts.Impl.DeleteKeyspace(ctx, keyspace)
+		})
+
+	saveVSchema := func(ctx context.Context) error {
+		return ts.Impl.SaveVSchema(ctx, keyspace, target.VSchema)
+	}
+	d.add("VSchema "+keyspace, current.VSchema, target.VSchema, saveVSchema, saveVSchema, nil)
+
+	currentShards := make(map[string]*topodatapb.Shard)
+	for _, s := range current.Shards {
+		currentShards[s.Name] = s.Shard
+	}
+	targetShards := make(map[string]*topodatapb.Shard)
+	for _, s := range target.Shards {
+		targetShards[s.Name] = s.Shard
+	}
+	for _, shard := range unionNames(currentShards, targetShards) {
+		shard := shard
+		targetShard := targetShards[shard]
+		d.add("Shard "+topoproto.KeyspaceShardString(keyspace, shard), currentShards[shard], targetShard,
+			func(ctx context.Context) error {
+				return createShard(ctx, ts.Impl, keyspace, shard, targetShard)
+			},
+			func(ctx context.Context) error {
+				_, version, err := ts.Impl.GetShard(ctx, keyspace, shard)
+				if err != nil {
+					return err
+				}
+				_, err = ts.Impl.UpdateShard(ctx, keyspace, shard, targetShard, version)
+				return err
+			},
+			func(ctx context.Context) error {
+				return ts.Impl.DeleteShard(ctx, keyspace, shard)
+			})
+	}
+}
+
+func diffCell(d *snapshotDiff, ts topo.Server, current, target *SnapshotCell) {
+	cell := target.Name
+
+	currentTablets := make(map[string]*topodatapb.Tablet)
+	for _, t := range current.Tablets {
+		currentTablets[topoproto.TabletAliasString(t.Alias)] = t
+	}
+	targetTablets := make(map[string]*topodatapb.Tablet)
+	for _, t := range target.Tablets {
+		targetTablets[topoproto.TabletAliasString(t.Alias)] = t
+	}
+	for _, alias := range unionNames(currentTablets, targetTablets) {
+		currentTablet := currentTablets[alias]
+		targetTablet := targetTablets[alias]
+		saveTargetTablet := func(ctx context.Context) error {
+			return saveTablet(ctx, ts.Impl, targetTablet)
+		}
+		d.add("Tablet "+alias, currentTablet, targetTablet, saveTargetTablet, saveTargetTablet,
+			func(ctx context.Context) error {
+				return ts.Impl.DeleteTablet(ctx, currentTablet.Alias)
+			})
+	}
+
+	currentShardReplications := make(map[string]*SnapshotShardReplication)
+	for _, sr := range current.ShardReplications {
+		currentShardReplications[topoproto.KeyspaceShardString(sr.Keyspace, sr.Shard)] = sr
+	}
+	targetShardReplications := make(map[string]*SnapshotShardReplication)
+	for _, sr := range target.ShardReplications {
+		targetShardReplications[topoproto.KeyspaceShardString(sr.Keyspace, sr.Shard)] = sr
+	}
+	for _, keyspaceShard := range unionNames(currentShardReplications, targetShardReplications) {
+		var currentValue, targetValue *topodatapb.ShardReplication
+		var keyspace, shard string
+		if sr, ok := currentShardReplications[keyspaceShard]; ok {
+			currentValue = sr.ShardReplication
+			keyspace, shard = sr.Keyspace, sr.Shard
+		}
+		if sr, ok := targetShardReplications[keyspaceShard]; ok {
+			targetValue = sr.ShardReplication
+			keyspace, shard = sr.Keyspace, sr.Shard
+		}
+		save := func(ctx context.Context) error {
+			return saveShardReplication(ctx, ts.Impl, cell, keyspace, shard, targetValue)
+		}
+		d.add("ShardReplication "+cell+" "+keyspaceShard, currentValue, targetValue, save, save,
+			func(ctx context.Context) error {
+				return ts.Impl.DeleteShardReplication(ctx, cell, keyspace, shard)
+			})
+	}
+
+	currentSrvKeyspaces := make(map[string]*topodatapb.SrvKeyspace)
+	for _, sk := range current.SrvKeyspaces {
+		currentSrvKeyspaces[sk.Keyspace] = sk.SrvKeyspace
+	}
+	targetSrvKeyspaces := make(map[string]*topodatapb.SrvKeyspace)
+	for _, sk := range target.SrvKeyspaces {
+		targetSrvKeyspaces[sk.Keyspace] = sk.SrvKeyspace
+	}
+	for _, keyspace := range unionNames(currentSrvKeyspaces, targetSrvKeyspaces) {
+		keyspace := keyspace
+		targetSrvKeyspace := targetSrvKeyspaces[keyspace]
+		save := func(ctx context.Context) error {
+			return ts.Impl.UpdateSrvKeyspace(ctx, cell, keyspace, targetSrvKeyspace)
+		}
+		d.add("SrvKeyspace "+cell+" "+keyspace, currentSrvKeyspaces[keyspace], targetSrvKeyspace, save, save,
+			func(ctx context.Context) error {
+				return ts.Impl.DeleteSrvKeyspace(ctx, cell, keyspace)
+			})
+	}
+
+	saveSrvVSchema := func(ctx context.Context) error {
+		return ts.Impl.UpdateSrvVSchema(ctx, cell, target.SrvVSchema)
+	}
+	d.add("SrvVSchema "+cell, current.SrvVSchema, target.SrvVSchema, saveSrvVSchema, saveSrvVSchema, nil)
+}
+
+// unionNames returns the sorted keys of two maps with string keys.
+func unionNames(m1, m2 interface{}) []string {
+	names := make(map[string]bool)
+	for _, m := range []interface{}{m1, m2} {
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			names[k.String()] = true
+		}
+	}
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// RestoreSnapshot changes the topology so it matches the snapshot,
+// and returns the changes it made. If dryRun is set, it only returns
+// the changes it would make. See DiffSnapshot for deleteExtra.
+// The changes are made one by one: if one fails, the previous ones
+// are not rolled back.
+func RestoreSnapshot(ctx context.Context, ts topo.Server, s *Snapshot, deleteExtra, dryRun bool) ([]*SnapshotChange, error) {
+	changes, err := DiffSnapshot(ctx, ts, s, deleteExtra)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return changes, nil
+	}
+	for i, c := range changes {
+		if err := c.apply(ctx); err != nil {
+			return changes[:i], fmt.Errorf("%v %v failed: %v", c.Action, c.Object, err)
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package helpers
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+	vschemapb "github.com/gitql/vitess/go/vt/proto/vschema"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	fromTS, toTS := createSetup(ctx, t)
+	ts := topo.Server{Impl: fromTS}
+	if err := ts.SaveVSchema(ctx, "test_keyspace", &vschemapb.Keyspace{Sharded: true}); err != nil {
+		t.Fatalf("SaveVSchema failed: %v", err)
+	}
+	if err := ts.UpdateSrvKeyspace(ctx, "test_cell", "test_keyspace", &topodatapb.SrvKeyspace{ShardingColumnName: "id"}); err != nil {
+		t.Fatalf("UpdateSrvKeyspace failed: %v", err)
+	}
+
+	// Take a snapshot, and save it to a file.
+	s, err := TakeSnapshot(ctx, ts)
+	if err != nil {
+		t.Fatalf("TakeSnapshot failed: %v", err)
+	}
+	if len(s.Keyspaces) != 1 || len(s.Keyspaces[0].Shards) != 1 || len(s.Cells) != 1 || len(s.Cells[0].Tablets) != 2 || len(s.Cells[0].ShardReplications) != 1 || len(s.Cells[0].SrvKeyspaces) != 1 {
+		t.Fatalf("unexpected snapshot: %+v", s)
+	}
+	dir, err := ioutil.TempDir("", "snapshot_test")
+	if err != nil {
+		t.Fatalf("TempDir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := path.Join(dir, "topology.json")
+	if err := WriteSnapshotFile(fileName, s); err != nil {
+		t.Fatalf("WriteSnapshotFile failed: %v", err)
+	}
+	s, err = ReadSnapshotFile(fileName)
+	if err != nil {
+		t.Fatalf("ReadSnapshotFile failed: %v", err)
+	}
+
+	// Restoring on the same topology is a no-op.
+	changes, err := RestoreSnapshot(ctx, ts, s, true /* deleteExtra */, false /* dryRun */)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("RestoreSnapshot on same topology returned %v %v", changes, err)
+	}
+
+	// Change a few things.
+	if _, err := ts.UpdateTabletFields(ctx, &topodatapb.TabletAlias{Cell: "test_cell", Uid: 123}, func(tablet *topodatapb.Tablet) error {
+		tablet.Hostname = "newhost"
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateTabletFields failed: %v", err)
+	}
+	if err := ts.DeleteSrvKeyspace(ctx, "test_cell", "test_keyspace"); err != nil {
+		t.Fatalf("DeleteSrvKeyspace failed: %v", err)
+	}
+	if err := ts.CreateKeyspace(ctx, "extra_keyspace", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+
+	// A dry run returns the changes, and doesn't make them.
+	changes, err = RestoreSnapshot(ctx, ts, s, true /* deleteExtra */, true /* dryRun */)
+	if err != nil {
+		t.Fatalf("RestoreSnapshot(dryRun) failed: %v", err)
+	}
+	want := []struct {
+		action, object string
+	}{
+		{SnapshotActionUpdate, "Tablet test_cell-0000000123"},
+		{SnapshotActionCreate, "SrvKeyspace test_cell test_keyspace"},
+		{SnapshotActionDelete, "Keyspace extra_keyspace"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+	for i, c := range changes {
+		if c.Action != want[i].action || c.Object != want[i].object {
+			t.Errorf("change %v is %v %v, expected %v %v", i, c.Action, c.Object, want[i].action, want[i].object)
+		}
+	}
+	if _, _, err := fromTS.GetKeyspace(ctx, "extra_keyspace"); err != nil {
+		t.Fatalf("dry run deleted extra_keyspace: %v", err)
+	}
+
+	// Without deleteExtra, the extra keyspace is left alone.
+	changes, err = RestoreSnapshot(ctx, ts, s, false /* deleteExtra */, false /* dryRun */)
+	if err != nil || len(changes) != 2 {
+		t.Fatalf("RestoreSnapshot returned %v %v", changes, err)
+	}
+	tablet, _, err := fromTS.GetTablet(ctx, &topodatapb.TabletAlias{Cell: "test_cell", Uid: 123})
+	if err != nil || tablet.Hostname != "masterhost" {
+		t.Fatalf("tablet was not restored: %v %v", tablet, err)
+	}
+	if _, _, err := fromTS.GetKeyspace(ctx, "extra_keyspace"); err != nil {
+		t.Fatalf("extra_keyspace was deleted: %v", err)
+	}
+
+	// Restore into an empty topology.
+	if _, err := RestoreSnapshot(ctx, topo.Server{Impl: toTS}, s, true /* deleteExtra */, false /* dryRun */); err != nil {
+		t.Fatalf("RestoreSnapshot into empty topology failed: %v", err)
+	}
+	s2, err := TakeSnapshot(ctx, topo.Server{Impl: toTS})
+	if err != nil {
+		t.Fatalf("TakeSnapshot failed: %v", err)
+	}
+	s2.Time = s.Time
+	if len(s2.Keyspaces) != 1 || !proto.Equal(s2.Keyspaces[0].VSchema, s.Keyspaces[0].VSchema) || len(s2.Cells[0].Tablets) != 2 || !proto.Equal(s2.Cells[0].ShardReplications[0].ShardReplication, s.Cells[0].ShardReplications[0].ShardReplication) {
+		t.Fatalf("restored topology doesn't match snapshot: %+v", s2)
+	}
+}
@@ -160,6 +160,11 @@ func (tee *Tee) Watch(ctx context.Context, cell, filePath string) (*topo.WatchDa
 	return tee.primary.Watch(ctx, cell, filePath)
 }
 
+// WatchRecursive is part of the topo.Backend interface
+func (tee *Tee) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	return tee.primary.WatchRecursive(ctx, cell, dirPath)
+}
+
 //
 // Cell management, global
 //
@@ -33,6 +33,7 @@ func (mt *MemoryTopo) Create(ctx context.Context, cell, filePath string, content
 	// Create the file.
 	n := mt.newFile(file, contents, p)
 	p.children[file] = n
+	mt.notifyRecursiveWatches(cell, filePath, n)
 	return NodeVersion(n.version), nil
 }
 
@@ -68,6 +69,7 @@ func (mt *MemoryTopo) Update(ctx context.Context, cell, filePath string, content
 		}
 		n = mt.newFile(file, contents, p)
 		p.children[file] = n
+		mt.notifyRecursiveWatches(cell, filePath, n)
 		return NodeVersion(n.version), nil
 	}
 
@@ -92,6 +94,7 @@ func (mt *MemoryTopo) Update(ctx context.Context, cell, filePath string, content
 			Version:  NodeVersion(n.version),
 		}
 	}
+	mt.notifyRecursiveWatches(cell, filePath, n)
 
 	return NodeVersion(n.version), nil
 }
@@ -151,6 +154,7 @@ func (mt *MemoryTopo) Delete(ctx context.Context, cell, filePath string, version
 		}
 		close(w)
 	}
+	mt.notifyRecursiveWatches(cell, filePath, nil)
 
 	return nil
 }
@@ -45,6 +45,10 @@ type MemoryTopo struct {
 	// then deleting it, then re-creating it, we don't restart the
 	// version at 1.
 	generation uint64
+	// recursiveWatches has all the recursive watches, by index.
+	// They are not attached to a node, as the watched directory
+	// can be created and deleted while they run.
+	recursiveWatches map[int]*recursiveWatch
 }
 
 // node contains a directory or a file entry.
@@ -81,7 +85,8 @@ func (n *node) isDirectory() bool {
 // of a problem.
 func New(cells ...string) *MemoryTopo {
 	mt := &MemoryTopo{
-		cells: make(map[string]*node),
+		cells:            make(map[string]*node),
+		recursiveWatches: make(map[int]*recursiveWatch),
 	}
 	mt.cells[topo.GlobalCell] = mt.newDirectory(topo.GlobalCell, nil)
 
@@ -2,6 +2,9 @@ package memorytopo
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strings"
 
 	"golang.org/x/net/context"
 
@@ -50,3 +53,103 @@ func (mt *MemoryTopo) Watch(ctx context.Context, cell string, filePath string) (
 	}
 	return current, notifications, cancel
 }
+
+// recursiveWatch is a watch on all the files under a directory.
+type recursiveWatch struct {
+	cell string
+	// dirPath is cleaned by cleanPath.
+	dirPath string
+	c       chan *topo.WatchDataRecursive
+}
+
+// cleanPath returns a path with no leading or trailing '/', and no
+// empty parts. The root directory is "".
+func cleanPath(p string) string {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+// WatchRecursive is part of the topo.Backend interface.
+func (mt *MemoryTopo) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	if _, ok := mt.cells[cell]; !ok {
+		return nil, nil, nil, fmt.Errorf("cannot watch directory %v in unknown cell %v", dirPath, cell)
+	}
+	dirPath = cleanPath(dirPath)
+
+	var current []*topo.WatchDataRecursive
+	if n := mt.nodeByPath(cell, dirPath); n != nil {
+		if !n.isDirectory() {
+			return nil, nil, nil, fmt.Errorf("cannot recursively watch file %v in cell %v", dirPath, cell)
+		}
+		current = appendFiles(current, dirPath, n)
+	}
+
+	notifications := make(chan *topo.WatchDataRecursive, 100)
+	watchIndex := nextWatchIndex
+	nextWatchIndex++
+	mt.recursiveWatches[watchIndex] = &recursiveWatch{
+		cell:    cell,
+		dirPath: dirPath,
+		c:       notifications,
+	}
+
+	cancel := func() {
+		mt.mu.Lock()
+		defer mt.mu.Unlock()
+
+		if w, ok := mt.recursiveWatches[watchIndex]; ok {
+			delete(mt.recursiveWatches, watchIndex)
+			w.c <- &topo.WatchDataRecursive{WatchData: topo.WatchData{Err: topo.ErrInterrupted}}
+			close(w.c)
+		}
+	}
+	return current, notifications, cancel, nil
+}
+
+// appendFiles appends all the files under n to result, sorted by
+// path. n is at dirPath.
+func appendFiles(result []*topo.WatchDataRecursive, dirPath string, n *node) []*topo.WatchDataRecursive {
+	if !n.isDirectory() {
+		return append(result, &topo.WatchDataRecursive{
+			Path: dirPath,
+			WatchData: topo.WatchData{
+				Contents: n.contents,
+				Version:  NodeVersion(n.version),
+			},
+		})
+	}
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result = appendFiles(result, path.Join(dirPath, name), n.children[name])
+	}
+	return result
+}
+
+// notifyRecursiveWatches sends the new value of a file to the
+// recursive watches on its parent directories. n is nil if the file
+// was deleted. mt.mu must be held.
+func (mt *MemoryTopo) notifyRecursiveWatches(cell, filePath string, n *node) {
+	filePath = cleanPath(filePath)
+	for _, w := range mt.recursiveWatches {
+		if w.cell != cell {
+			continue
+		}
+		if w.dirPath != "" && !strings.HasPrefix(filePath, w.dirPath+"/") {
+			continue
+		}
+		wd := &topo.WatchDataRecursive{Path: filePath}
+		if n == nil {
+			wd.Err = topo.ErrNoNode
+		} else {
+			wd.Contents = n.contents
+			wd.Version = NodeVersion(n.version)
+		}
+		w.c <- wd
+	}
+}
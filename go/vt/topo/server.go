@@ -59,6 +59,10 @@ var (
 	// ErrNoUpdateNeeded can be returned by an 'UpdateFields' method
 	// to skip any update.
 	ErrNoUpdateNeeded = errors.New("no update needed")
+
+	// ErrNotImplemented is returned by the Backend methods an
+	// implementation doesn't support.
+	ErrNotImplemented = errors.New("not implemented")
 )
 
 // Impl is the interface used to talk to a persistent
@@ -297,6 +301,9 @@ var (
 	// server.
 	topoGlobalRoot = flag.String("topo_global_root", "", "the path of the global topology data in the global topology server")
 
+	// topoAudit is the flag to record all changes in the audit log.
+	topoAudit = flag.Bool("topo_audit", false, "record all the changes made by this process to the topology in the audit log, stored in the topology (see the GetAuditLog vtctl command)")
+
 	// factories has the factories for the Impl objects.
 	factories = make(map[string]Factory)
 )
@@ -323,6 +330,9 @@ func OpenServer(implementation, serverAddress, root string) (Server, error) {
 	if err != nil {
 		return Server{}, err
 	}
+	if *topoAudit {
+		impl = NewAuditImpl(impl)
+	}
 	return Server{Impl: impl}, nil
 }
 
@@ -6,8 +6,10 @@ package topo
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 
 	log "github.com/golang/glog"
@@ -401,3 +403,93 @@ func (ts Server) GetTabletMap(ctx context.Context, tabletAliases []*topodatapb.T
 	wg.Wait()
 	return tabletMap, someError
 }
+
+// WatchTabletData is streamed by WatchTablets. For a change, Alias is
+// set, and exactly one of Value or Err is set: Err is ErrNoNode if the
+// tablet was deleted. The last record has no Alias, and Err is set.
+type WatchTabletData struct {
+	Alias *topodatapb.TabletAlias
+	Value *topodatapb.Tablet
+	Err   error
+}
+
+// tabletAliasFromPath returns the alias of the tablet stored in a
+// file, or nil if the file is not a Tablet object.
+func tabletAliasFromPath(filePath string) *topodatapb.TabletAlias {
+	parts := strings.Split(filePath, "/")
+	if len(parts) != 3 || parts[0] != tabletsPath || parts[2] != TabletFile {
+		return nil
+	}
+	alias, err := topoproto.ParseTabletAlias(parts[1])
+	if err != nil {
+		return nil
+	}
+	return alias
+}
+
+// WatchTablets will set a watch on all the tablets of a cell.
+// It has the same contract as Backend.WatchRecursive, but it also
+// unpacks the contents into Tablet objects, and ignores the other
+// files.
+func (ts Server) WatchTablets(ctx context.Context, cell string) ([]*topodatapb.Tablet, <-chan *WatchTabletData, CancelFunc, error) {
+	current, wdChannel, cancel, err := ts.WatchRecursive(ctx, cell, tabletsPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var tablets []*topodatapb.Tablet
+	for _, wd := range current {
+		if tabletAliasFromPath(wd.Path) == nil {
+			continue
+		}
+		tablet := &topodatapb.Tablet{}
+		if err := proto.Unmarshal(wd.Contents, tablet); err != nil {
+			// Cancel the watch, drain channel.
+			cancel()
+			for range wdChannel {
+			}
+			return nil, nil, nil, fmt.Errorf("error unpacking initial Tablet object %v: %v", wd.Path, err)
+		}
+		tablets = append(tablets, tablet)
+	}
+
+	changes := make(chan *WatchTabletData, 10)
+
+	// The background routine reads any event from the watch channel,
+	// translates it, and sends it to the caller.
+	// If cancel() is called, the underlying WatchRecursive() code will
+	// send an ErrInterrupted and then close the channel. We'll
+	// just propagate that back to our caller.
+	go func() {
+		defer close(changes)
+
+		for wd := range wdChannel {
+			if wd.Path == "" {
+				// Last error value, we're done.
+				// wdChannel will be closed right after
+				// this, no need to do anything.
+				changes <- &WatchTabletData{Err: wd.Err}
+				return
+			}
+
+			alias := tabletAliasFromPath(wd.Path)
+			if alias == nil {
+				continue
+			}
+			if wd.Err != nil {
+				changes <- &WatchTabletData{Alias: alias, Err: wd.Err}
+				continue
+			}
+			tablet := &topodatapb.Tablet{}
+			if err := proto.Unmarshal(wd.Contents, tablet); err != nil {
+				cancel()
+				for range wdChannel {
+				}
+				changes <- &WatchTabletData{Err: fmt.Errorf("error unpacking Tablet object %v: %v", wd.Path, err)}
+				return
+			}
+			changes <- &WatchTabletData{Alias: alias, Value: tablet}
+		}
+	}()
+
+	return tablets, changes, cancel, nil
+}
@@ -52,6 +52,11 @@ func (ft FakeTopo) Watch(ctx context.Context, cell string, path string) (*topo.W
 	}, nil, nil
 }
 
+// WatchRecursive is part of the topo.Backend interface.
+func (ft FakeTopo) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	return nil, nil, nil, topo.ErrNotImplemented
+}
+
 // GetKnownCells is part of the topo.Server interface.
 func (ft FakeTopo) GetKnownCells(ctx context.Context) ([]string, error) {
 	return nil, errNotImplemented
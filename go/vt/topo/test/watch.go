@@ -193,3 +193,135 @@ func checkWatchInterrupt(t *testing.T, ts topo.Impl) {
 	// And calling cancel() again should just work.
 	cancel()
 }
+
+// waitForRecursiveEvent reads the changes of a recursive watch until
+// it gets one for filePath that satisfies check. Other events are
+// ignored, as the API allows duplicates.
+func waitForRecursiveEvent(t *testing.T, changes <-chan *topo.WatchDataRecursive, filePath string, check func(wd *topo.WatchDataRecursive) bool) {
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case wd, ok := <-changes:
+			if !ok {
+				t.Fatalf("recursive watch channel unexpectedly closed")
+			}
+			if wd.Path == "" {
+				t.Fatalf("recursive watch interrupted: %v", wd.Err)
+			}
+			if wd.Path == filePath && check(wd) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for change on %v", filePath)
+		}
+	}
+}
+
+// checkWatchRecursive runs the tests on the WatchRecursive part of the
+// Backend API.
+func checkWatchRecursive(t *testing.T, ts topo.Impl) {
+	ctx := context.Background()
+	cell := getLocalCell(ctx, t, ts)
+
+	// Start watching a directory that doesn't exist yet.
+	current, changes, cancel, err := ts.WatchRecursive(ctx, cell, "keyspaces/test_keyspace")
+	if err == topo.ErrNotImplemented {
+		t.Logf("WatchRecursive is not implemented, skipping test")
+		return
+	}
+	if err != nil {
+		t.Fatalf("WatchRecursive on missing directory failed: %v", err)
+	}
+	if len(current) != 0 {
+		t.Fatalf("WatchRecursive on missing directory returned files: %v", current)
+	}
+
+	// Create a file in the directory, and one in a sub-directory.
+	srvKeyspace := &topodatapb.SrvKeyspace{
+		ShardingColumnName: "user_id",
+	}
+	if err := ts.UpdateSrvKeyspace(ctx, cell, "test_keyspace", srvKeyspace); err != nil {
+		t.Fatalf("UpdateSrvKeyspace(1): %v", err)
+	}
+	srvKeyspacePath := "keyspaces/test_keyspace/SrvKeyspace"
+	hasSrvKeyspace := func(shardingColumnName string) func(wd *topo.WatchDataRecursive) bool {
+		return func(wd *topo.WatchDataRecursive) bool {
+			if wd.Err != nil {
+				return false
+			}
+			got := &topodatapb.SrvKeyspace{}
+			if err := proto.Unmarshal(wd.Contents, got); err != nil {
+				t.Fatalf("cannot proto-unmarshal data: %v", err)
+			}
+			return got.ShardingColumnName == shardingColumnName
+		}
+	}
+	waitForRecursiveEvent(t, changes, srvKeyspacePath, hasSrvKeyspace("user_id"))
+
+	subPath := "keyspaces/test_keyspace/sub/dir/file"
+	if _, err := ts.Create(ctx, cell, subPath, []byte("contents")); err != nil {
+		t.Fatalf("Create(%v): %v", subPath, err)
+	}
+	waitForRecursiveEvent(t, changes, subPath, func(wd *topo.WatchDataRecursive) bool {
+		return wd.Err == nil && string(wd.Contents) == "contents"
+	})
+
+	// Update a file.
+	srvKeyspace.ShardingColumnName = "new_user_id"
+	if err := ts.UpdateSrvKeyspace(ctx, cell, "test_keyspace", srvKeyspace); err != nil {
+		t.Fatalf("UpdateSrvKeyspace(2): %v", err)
+	}
+	waitForRecursiveEvent(t, changes, srvKeyspacePath, hasSrvKeyspace("new_user_id"))
+
+	// Delete the file in the sub-directory.
+	if err := ts.Delete(ctx, cell, subPath, nil); err != nil {
+		t.Fatalf("Delete(%v): %v", subPath, err)
+	}
+	waitForRecursiveEvent(t, changes, subPath, func(wd *topo.WatchDataRecursive) bool {
+		return wd.Err == topo.ErrNoNode
+	})
+
+	// A new watch returns the existing file.
+	current, changes2, cancel2, err := ts.WatchRecursive(ctx, cell, "keyspaces/test_keyspace")
+	if err != nil {
+		t.Fatalf("WatchRecursive on existing directory failed: %v", err)
+	}
+	found := false
+	for _, wd := range current {
+		if wd.Path == srvKeyspacePath && hasSrvKeyspace("new_user_id")(wd) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("WatchRecursive on existing directory didn't return %v: %v", srvKeyspacePath, current)
+	}
+
+	// Cancel the watches, make sure we get topo.ErrInterrupted
+	// eventually, and the channels are closed.
+	for _, w := range []struct {
+		changes <-chan *topo.WatchDataRecursive
+		cancel  topo.CancelFunc
+	}{{changes, cancel}, {changes2, cancel2}} {
+		w.cancel()
+		for {
+			wd, ok := <-w.changes
+			if !ok {
+				t.Fatalf("recursive watch channel unexpectedly closed")
+			}
+			if wd.Path != "" {
+				// A change, still good.
+				continue
+			}
+			if wd.Err != topo.ErrInterrupted {
+				t.Fatalf("bad error returned for cancel: %v", wd.Err)
+			}
+			break
+		}
+		if wd, ok := <-w.changes; ok {
+			t.Fatalf("got unexpected event after error: %v", wd)
+		}
+
+		// And calling cancel() again should just work.
+		w.cancel()
+	}
+}
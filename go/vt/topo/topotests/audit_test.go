@@ -0,0 +1,180 @@
+package topotests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/callerid"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// This file contains tests for the audit.go and audit_impl.go files.
+
+func checkAuditLog(t *testing.T, name string, entries []*topo.AuditEntry, want []string) {
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Action+" "+e.Cell+" "+e.Path)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%v: got entries %v, want %v", name, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%v: got entries %v, want %v", name, got, want)
+			return
+		}
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	cell := "cell1"
+	keyspace := "ks1"
+	shard := "-80"
+	ts := topo.Server{Impl: topo.NewAuditImpl(memorytopo.New(cell))}
+	ctx := callerid.NewContext(context.Background(),
+		callerid.NewEffectiveCallerID("alice", "vtctl", "ChangeSlaveType"),
+		callerid.NewImmediateCallerID("bob"))
+
+	// Create a keyspace, a shard and a tablet, change the
+	// shard and delete the tablet.
+	if err := ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	if err := ts.CreateShard(ctx, keyspace, shard); err != nil {
+		t.Fatalf("CreateShard failed: %v", err)
+	}
+	if _, err := ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.ServedTypes = nil
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateShardFields failed: %v", err)
+	}
+	alias := &topodatapb.TabletAlias{
+		Cell: cell,
+		Uid:  1,
+	}
+	tablet := &topodatapb.Tablet{
+		Alias:    alias,
+		Keyspace: keyspace,
+		Shard:    shard,
+	}
+	if err := ts.CreateTablet(ctx, tablet); err != nil {
+		t.Fatalf("CreateTablet failed: %v", err)
+	}
+	if err := ts.DeleteTablet(ctx, alias); err != nil {
+		t.Fatalf("DeleteTablet failed: %v", err)
+	}
+
+	// Check the logs.
+	entries, err := ts.GetKeyspaceAuditLog(ctx, keyspace, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("GetKeyspaceAuditLog failed: %v", err)
+	}
+	checkAuditLog(t, "keyspace", entries, []string{
+		"Create global keyspaces/ks1/Keyspace",
+		"Create global keyspaces/ks1/shards/-80/Shard",
+		"Update global keyspaces/ks1/shards/-80/Shard",
+		"Update cell1 keyspaces/ks1/shards/-80/ShardReplication",
+	})
+
+	entries, err = ts.GetShardAuditLog(ctx, keyspace, shard, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("GetShardAuditLog failed: %v", err)
+	}
+	checkAuditLog(t, "shard", entries, []string{
+		"Create global keyspaces/ks1/shards/-80/Shard",
+		"Update global keyspaces/ks1/shards/-80/Shard",
+		"Update cell1 keyspaces/ks1/shards/-80/ShardReplication",
+	})
+	oldValue, err := entries[1].OldMessage()
+	if err != nil || len(oldValue.(*topodatapb.Shard).ServedTypes) != 3 {
+		t.Errorf("bad old value of the shard update: %v %v", oldValue, err)
+	}
+	newValue, err := entries[1].NewMessage()
+	if err != nil || len(newValue.(*topodatapb.Shard).ServedTypes) != 0 {
+		t.Errorf("bad new value of the shard update: %v %v", newValue, err)
+	}
+
+	entries, err = ts.GetTabletAuditLog(ctx, alias, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("GetTabletAuditLog failed: %v", err)
+	}
+	checkAuditLog(t, "tablet", entries, []string{
+		"Create cell1 tablets/cell1-0000000001/Tablet",
+		"Delete cell1 tablets/cell1-0000000001/Tablet",
+	})
+	e := entries[1]
+	if e.Principal != "alice" || e.Component != "vtctl" || e.Subcomponent != "ChangeSlaveType" || e.Username != "bob" {
+		t.Errorf("bad caller in entry: %+v", e)
+	}
+	oldValue, err = e.OldMessage()
+	if err != nil || !proto.Equal(oldValue, tablet) {
+		t.Errorf("bad old value of the tablet deletion: %v %v", oldValue, err)
+	}
+	if newValue, err := e.NewMessage(); err != nil || newValue != nil {
+		t.Errorf("bad new value of the tablet deletion: %v %v", newValue, err)
+	}
+
+	// The audit log itself is not visible in the data.
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil || len(keyspaces) != 1 || keyspaces[0] != keyspace {
+		t.Errorf("GetKeyspaces() = %v %v, want [%v]", keyspaces, err, keyspace)
+	}
+}
+
+func TestAuditLogSinceLimitPrune(t *testing.T) {
+	cell := "cell1"
+	keyspace := "ks1"
+	ts := topo.Server{Impl: topo.NewAuditImpl(memorytopo.New(cell))}
+	ctx := context.Background()
+
+	if err := ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	middle := time.Now()
+	for _, shard := range []string{"-80", "80-"} {
+		if err := ts.CreateShard(ctx, keyspace, shard); err != nil {
+			t.Fatalf("CreateShard failed: %v", err)
+		}
+	}
+
+	entries, err := ts.GetKeyspaceAuditLog(ctx, keyspace, middle, 0)
+	if err != nil {
+		t.Fatalf("GetKeyspaceAuditLog failed: %v", err)
+	}
+	checkAuditLog(t, "since", entries, []string{
+		"Create global keyspaces/ks1/shards/-80/Shard",
+		"Create global keyspaces/ks1/shards/80-/Shard",
+	})
+	entries, err = ts.GetKeyspaceAuditLog(ctx, keyspace, time.Time{}, 1)
+	if err != nil {
+		t.Fatalf("GetKeyspaceAuditLog failed: %v", err)
+	}
+	checkAuditLog(t, "limit", entries, []string{
+		"Create global keyspaces/ks1/shards/80-/Shard",
+	})
+
+	// Pruning is not recorded in the audit log.
+	deleted, err := ts.PruneAuditLog(ctx, middle)
+	if err != nil || deleted != 1 {
+		t.Fatalf("PruneAuditLog() = %v %v, want 1", deleted, err)
+	}
+	entries, err = ts.GetKeyspaceAuditLog(ctx, keyspace, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("GetKeyspaceAuditLog failed: %v", err)
+	}
+	checkAuditLog(t, "pruned", entries, []string{
+		"Create global keyspaces/ks1/shards/-80/Shard",
+		"Create global keyspaces/ks1/shards/80-/Shard",
+	})
+	if _, err := ts.ListDir(ctx, topo.GlobalCell, "audit/audit"); err != topo.ErrNoNode {
+		t.Errorf("ListDir(audit/audit) = %v, want ErrNoNode", err)
+	}
+}
@@ -3,8 +3,11 @@ package zk2topo
 import (
 	"fmt"
 	"path"
+	"sort"
+	"strings"
 	"sync"
 
+	"github.com/samuel/go-zookeeper/zk"
 	"golang.org/x/net/context"
 
 	"github.com/gitql/vitess/go/vt/topo"
@@ -94,3 +97,241 @@ func (zs *Server) Watch(ctx context.Context, cell, filePath string) (*topo.Watch
 
 	return wd, c, cancel
 }
+
+// WatchRecursive is part of the topo.Backend interface.
+//
+// ZooKeeper watches are on a single node, and only fire once. So we
+// set a children watch on every node under dirPath, and a data watch
+// on every node without children, which are the files (as for
+// ListDir, we cannot distinguish an empty directory from a
+// file). When a watch fires, we set it again, and send the changes.
+func (zs *Server) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	conn, root, err := zs.connForCell(ctx, cell)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	rw := &recursiveWatcher{
+		conn:    conn,
+		root:    root,
+		dirPath: strings.Trim(path.Clean("/"+dirPath), "/"),
+		events:  make(chan recursiveWatchEvent, 10),
+		done:    make(chan struct{}),
+		dirs:    make(map[string]bool),
+		files:   make(map[string]bool),
+	}
+
+	// Get the initial values, set the initial watches.
+	if err := rw.watchChildren(ctx, rw.dirPath); err != nil {
+		close(rw.done)
+		return nil, nil, nil, err
+	}
+	current := rw.pending
+	rw.pending = nil
+
+	// mu protects the stop channel. We need to make sure the 'cancel'
+	// func can be called multiple times, and that we don't close 'stop'
+	// too many times.
+	mu := sync.Mutex{}
+	stop := make(chan struct{})
+	cancel := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stop != nil {
+			close(stop)
+			stop = nil
+		}
+	}
+
+	c := make(chan *topo.WatchDataRecursive, 10)
+	go func(stop chan struct{}) {
+		defer close(c)
+		defer close(rw.done)
+
+		for {
+			// Act on the watches, or on 'stop' close.
+			select {
+			case e := <-rw.events:
+				if !e.ok {
+					c <- &topo.WatchDataRecursive{WatchData: topo.WatchData{Err: fmt.Errorf("watch on %v was closed", path.Join(root, e.path))}}
+					return
+				}
+				if e.event.Err != nil {
+					c <- &topo.WatchDataRecursive{WatchData: topo.WatchData{Err: fmt.Errorf("received a non-OK event for %v: %v", path.Join(root, e.path), e.event.Err)}}
+					return
+				}
+
+				// Set the watch again, read the new values.
+				// The context used for the Watch call may
+				// be canceled by now.
+				var err error
+				if e.data {
+					if rw.files[e.path] {
+						err = rw.watchData(context.Background(), e.path)
+					}
+				} else {
+					err = rw.watchChildren(context.Background(), e.path)
+				}
+				if err != nil {
+					c <- &topo.WatchDataRecursive{WatchData: topo.WatchData{Err: err}}
+					return
+				}
+
+			case <-stop:
+				// user is not interested any more
+				c <- &topo.WatchDataRecursive{WatchData: topo.WatchData{Err: topo.ErrInterrupted}}
+				return
+			}
+
+			for _, wd := range rw.pending {
+				c <- wd
+			}
+			rw.pending = nil
+		}
+	}(stop)
+
+	return current, c, cancel, nil
+}
+
+// recursiveWatchEvent is a fired ZooKeeper watch.
+type recursiveWatchEvent struct {
+	// path is relative to the root directory of the cell.
+	path string
+	// data is true for a data watch, false for a children or
+	// exists watch.
+	data bool
+	// event is the received event, ok is false if the channel
+	// was closed.
+	event zk.Event
+	ok    bool
+}
+
+// recursiveWatcher has the state of a WatchRecursive call. It is
+// only used by one go routine at a time.
+type recursiveWatcher struct {
+	conn    Conn
+	root    string
+	dirPath string
+
+	// events receives the fired watches.
+	events chan recursiveWatchEvent
+	// done is closed when the watch stops, to stop the go
+	// routines waiting for ZooKeeper watches.
+	done chan struct{}
+
+	// dirs has the nodes with a children watch, and files the
+	// nodes with a data watch. Paths are relative to the root
+	// directory of the cell.
+	dirs  map[string]bool
+	files map[string]bool
+
+	// pending has the changes to send.
+	pending []*topo.WatchDataRecursive
+}
+
+// forward sends the event of a ZooKeeper watch to rw.events.
+func (rw *recursiveWatcher) forward(p string, data bool, watch <-chan zk.Event) {
+	go func() {
+		select {
+		case event, ok := <-watch:
+			select {
+			case rw.events <- recursiveWatchEvent{path: p, data: data, event: event, ok: ok}:
+			case <-rw.done:
+			}
+		case <-rw.done:
+		}
+	}()
+}
+
+// watchChildren sets the children watch on p, and sets the watches
+// on its new children. If p has no children, it is a file.
+func (rw *recursiveWatcher) watchChildren(ctx context.Context, p string) error {
+	zkPath := path.Join(rw.root, p)
+	children, _, watch, err := rw.conn.ChildrenW(ctx, zkPath)
+	if err != nil {
+		err = convertError(err)
+		if err != topo.ErrNoNode {
+			return err
+		}
+
+		// The node is gone. Its files send their deletion
+		// with their own data watch.
+		delete(rw.dirs, p)
+		if p != rw.dirPath {
+			return nil
+		}
+
+		// Wait for the watched directory to be created.
+		exists, _, watch, err := rw.conn.ExistsW(ctx, zkPath)
+		if err != nil {
+			return convertError(err)
+		}
+		if exists {
+			// It was just created, try again.
+			return rw.watchChildren(ctx, p)
+		}
+		rw.dirs[p] = true
+		rw.forward(p, false, watch)
+		return nil
+	}
+	rw.dirs[p] = true
+	rw.forward(p, false, watch)
+
+	if len(children) == 0 {
+		if p != rw.dirPath && !rw.files[p] {
+			return rw.watchData(ctx, p)
+		}
+		return nil
+	}
+	if rw.files[p] {
+		// What we thought was a file is a directory.
+		delete(rw.files, p)
+		rw.pending = append(rw.pending, &topo.WatchDataRecursive{
+			Path:      p,
+			WatchData: topo.WatchData{Err: topo.ErrNoNode},
+		})
+	}
+	sort.Strings(children)
+	for _, child := range children {
+		childPath := path.Join(p, child)
+		if rw.dirs[childPath] {
+			continue
+		}
+		if err := rw.watchChildren(ctx, childPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watchData sets the data watch on the file p, and adds its value to
+// the pending changes.
+func (rw *recursiveWatcher) watchData(ctx context.Context, p string) error {
+	data, stats, watch, err := rw.conn.GetW(ctx, path.Join(rw.root, p))
+	if err == nil && stats == nil {
+		// No stats --> node doesn't exist.
+		err = zk.ErrNoNode
+	}
+	if err != nil {
+		err = convertError(err)
+		if err != topo.ErrNoNode {
+			return err
+		}
+		delete(rw.files, p)
+		rw.pending = append(rw.pending, &topo.WatchDataRecursive{
+			Path:      p,
+			WatchData: topo.WatchData{Err: topo.ErrNoNode},
+		})
+		return nil
+	}
+	rw.files[p] = true
+	rw.forward(p, true, watch)
+	rw.pending = append(rw.pending, &topo.WatchDataRecursive{
+		Path: p,
+		WatchData: topo.WatchData{
+			Contents: data,
+			Version:  ZKVersion(stats.Version),
+		},
+	})
+	return nil
+}
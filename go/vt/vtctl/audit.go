@@ -0,0 +1,131 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtctl
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+	"github.com/gitql/vitess/go/vt/wrangler"
+)
+
+// This file contains the audit log command for vtctl.
+
+func init() {
+	addCommand("Generic", command{
+		"GetAuditLog",
+		commandGetAuditLog,
+		"[-since <duration>] [-limit <n>] {keyspace <keyspace> | shard <keyspace/shard> | tablet <tablet alias>}",
+		"Outputs the changes made to a keyspace (including its shards, and its serving and replication graphs), a shard (including its replication graph) or a tablet, oldest first. Only the changes made by processes running with -topo_audit are recorded."})
+	addCommand("Generic", command{
+		"PruneAuditLog",
+		commandPruneAuditLog,
+		"-older_than <duration>",
+		"Deletes the audit log entries of the changes made more than the provided duration ago, in all cells."})
+}
+
+// AuditLogEntry is an audit log entry with the old and new values
+// decoded, as returned by the GetAuditLog command and the vtctld API.
+type AuditLogEntry struct {
+	*topo.AuditEntry
+
+	// Old and New are the decoded OldContents and NewContents, if
+	// the file is a known topology object.
+	Old interface{}
+	New interface{}
+}
+
+// GetAuditLog returns the audit log of a keyspace, shard or tablet,
+// with the changes made at or after since, and at most the last
+// limit changes if limit is positive. objectType is one of
+// "keyspace", "shard" or "tablet", and name is the keyspace name, the
+// keyspace/shard or the tablet alias.
+func GetAuditLog(ctx context.Context, ts topo.Server, objectType, name string, since time.Time, limit int) ([]*AuditLogEntry, error) {
+	var entries []*topo.AuditEntry
+	var err error
+	switch objectType {
+	case "keyspace":
+		entries, err = ts.GetKeyspaceAuditLog(ctx, name, since, limit)
+	case "shard":
+		keyspace, shard, perr := topoproto.ParseKeyspaceShard(name)
+		if perr != nil {
+			return nil, perr
+		}
+		entries, err = ts.GetShardAuditLog(ctx, keyspace, shard, since, limit)
+	case "tablet":
+		alias, perr := topoproto.ParseTabletAlias(name)
+		if perr != nil {
+			return nil, perr
+		}
+		entries, err = ts.GetTabletAuditLog(ctx, alias, since, limit)
+	default:
+		return nil, fmt.Errorf("unknown audit log object type %q, expected keyspace, shard or tablet", objectType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = &AuditLogEntry{AuditEntry: entry}
+		if oldValue, err := entry.OldMessage(); err != nil {
+			result[i].Old = err.Error()
+		} else if oldValue != nil {
+			result[i].Old = oldValue
+		}
+		if newValue, err := entry.NewMessage(); err != nil {
+			result[i].New = err.Error()
+		} else if newValue != nil {
+			result[i].New = newValue
+		}
+	}
+	return result, nil
+}
+
+func commandGetAuditLog(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	since := subFlags.Duration("since", 0, "only outputs the changes made in this duration before now (all changes by default)")
+	limit := subFlags.Int("limit", 0, "only outputs the last <n> changes (all changes by default)")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <object type> and <name> arguments are required for the GetAuditLog command")
+	}
+
+	var sinceTime time.Time
+	if *since > 0 {
+		sinceTime = time.Now().Add(-*since)
+	}
+	entries, err := GetAuditLog(ctx, wr.TopoServer(), subFlags.Arg(0), subFlags.Arg(1), sinceTime, *limit)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), entries)
+}
+
+func commandPruneAuditLog(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	olderThan := subFlags.Duration("older_than", 0, "deletes the changes made more than this duration ago")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("the PruneAuditLog command takes no arguments")
+	}
+	if *olderThan <= 0 {
+		return fmt.Errorf("the -older_than flag is required for the PruneAuditLog command")
+	}
+
+	deleted, err := wr.TopoServer().PruneAuditLog(ctx, time.Now().Add(-*olderThan))
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("Deleted %v audit log entries.\n", deleted)
+	return nil
+}
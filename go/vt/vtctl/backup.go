@@ -8,8 +8,12 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
 
 	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/mysqlctl"
 	"github.com/gitql/vitess/go/vt/mysqlctl/backupstorage"
 	"github.com/gitql/vitess/go/vt/topo/topoproto"
 	"github.com/gitql/vitess/go/vt/wrangler"
@@ -20,13 +24,23 @@ func init() {
 	addCommand("Shards", command{
 		"ListBackups",
 		commandListBackups,
-		"<keyspace/shard>",
-		"Lists all the backups for a shard."})
+		"[-details] [-json] <keyspace/shard>",
+		"Lists all the backups for a shard. With -details, also shows their age, size, number of files and replication position."})
 	addCommand("Shards", command{
 		"RemoveBackup",
 		commandRemoveBackup,
 		"<keyspace/shard> <backup name>",
 		"Removes a backup for the BackupStorage."})
+	addCommand("Shards", command{
+		"PruneBackups",
+		commandPruneBackups,
+		"[-keep=N] [-keep_newer_than=<duration>] [-keep_daily=N] [-keep_weekly=N] [-dry_run] <keyspace/shard>",
+		"Removes the backups of a shard which are not kept by any of the retention rules. The most recent complete backup is always kept. Incomplete backups are removed once a more recent backup is complete."})
+	addCommand("Shards", command{
+		"VerifyBackup",
+		commandVerifyBackup,
+		"[-concurrency=4] <keyspace/shard> <backup name>",
+		"Reads all the files of a backup, and checks them against the hashes of its MANIFEST."})
 
 	addCommand("Tablets", command{
 		"RestoreFromBackup",
@@ -36,6 +50,8 @@ func init() {
 }
 
 func commandListBackups(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	details := subFlags.Bool("details", false, "Shows the details of each backup, read from its MANIFEST")
+	asJSON := subFlags.Bool("json", false, "Outputs the details of the backups as JSON")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -54,16 +70,67 @@ func commandListBackups(ctx context.Context, wr *wrangler.Wrangler, subFlags *fl
 		return err
 	}
 	defer bs.Close()
-	bhs, err := bs.ListBackups(ctx, bucket)
+	if !*details && !*asJSON {
+		bhs, err := bs.ListBackups(ctx, bucket)
+		if err != nil {
+			return err
+		}
+		for _, bh := range bhs {
+			wr.Logger().Printf("%v\n", bh.Name())
+		}
+		return nil
+	}
+
+	backups, err := mysqlctl.ListBackupInfos(ctx, bs, bucket)
 	if err != nil {
 		return err
 	}
-	for _, bh := range bhs {
-		wr.Logger().Printf("%v\n", bh.Name())
+	if *asJSON {
+		return printJSON(wr.Logger(), backups)
 	}
+	printBackups(wr.Logger(), backups)
 	return nil
 }
 
+// printBackups prints the details of backups as a table.
+func printBackups(logger logutil.Logger, backups []*mysqlctl.BackupInfo) {
+	table := tablewriter.NewWriter(loggerWriter{logger})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Name", "Age", "Files", "Size", "Position"})
+	now := time.Now()
+	for _, bi := range backups {
+		age := "unknown"
+		if !bi.Time.IsZero() {
+			age = (now.Sub(bi.Time) / time.Minute * time.Minute).String()
+		}
+		if !bi.Complete {
+			table.Append([]string{bi.Name, age, "", "", "incomplete: " + bi.Error})
+			continue
+		}
+		size := "unknown"
+		if bi.Size != 0 {
+			size = formatSize(bi.Size)
+		}
+		table.Append([]string{bi.Name, age, fmt.Sprintf("%v", bi.FileCount), size, bi.Position.String()})
+	}
+	table.Render()
+}
+
+// formatSize returns a human readable size, like 1.5 GiB.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%v B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
 func commandRemoveBackup(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -87,6 +154,95 @@ func commandRemoveBackup(ctx context.Context, wr *wrangler.Wrangler, subFlags *f
 	return bs.RemoveBackup(ctx, bucket, name)
 }
 
+func commandPruneBackups(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	keep := subFlags.Int("keep", 0, "Keeps the N most recent complete backups")
+	keepNewerThan := subFlags.Duration("keep_newer_than", 0, "Keeps the backups started less than this duration ago")
+	keepDaily := subFlags.Int("keep_daily", 0, "Keeps the most recent complete backup of each of the last N days which have one")
+	keepWeekly := subFlags.Int("keep_weekly", 0, "Keeps the most recent complete backup of each of the last N weeks which have one")
+	dryRun := subFlags.Bool("dry_run", false, "Only lists the backups which would be removed")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("action PruneBackups requires <keyspace/shard>")
+	}
+	if *keep < 0 || *keepNewerThan < 0 || *keepDaily < 0 || *keepWeekly < 0 {
+		return fmt.Errorf("the retention rules of PruneBackups cannot be negative")
+	}
+	policy := mysqlctl.RetentionPolicy{
+		Keep:          *keep,
+		KeepNewerThan: *keepNewerThan,
+		KeepDaily:     *keepDaily,
+		KeepWeekly:    *keepWeekly,
+	}
+	if policy.IsZero() {
+		return fmt.Errorf("action PruneBackups requires at least one of -keep, -keep_newer_than, -keep_daily or -keep_weekly")
+	}
+
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	bucket := fmt.Sprintf("%v/%v", keyspace, shard)
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+	removed, err := mysqlctl.PruneBackups(ctx, bs, bucket, policy, *dryRun, wr.Logger())
+	for _, bi := range removed {
+		if *dryRun {
+			wr.Logger().Printf("would remove %v\n", bi.Name)
+		} else {
+			wr.Logger().Printf("removed %v\n", bi.Name)
+		}
+	}
+	return err
+}
+
+func commandVerifyBackup(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	concurrency := subFlags.Int("concurrency", 4, "How many files to read concurrently")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("action VerifyBackup requires <keyspace/shard> <backup name>")
+	}
+	if *concurrency < 1 {
+		return fmt.Errorf("the -concurrency of VerifyBackup must be at least 1")
+	}
+
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	bucket := fmt.Sprintf("%v/%v", keyspace, shard)
+	name := subFlags.Arg(1)
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+	bhs, err := bs.ListBackups(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	for _, bh := range bhs {
+		if bh.Name() != name {
+			continue
+		}
+		count, err := mysqlctl.VerifyBackup(ctx, bh, *concurrency, wr.Logger())
+		if err != nil {
+			return fmt.Errorf("backup %v/%v failed verification: %v", bucket, name, err)
+		}
+		wr.Logger().Printf("backup %v/%v is valid: verified %v files\n", bucket, name, count)
+		return nil
+	}
+	return fmt.Errorf("no backup %v in %v", name, bucket)
+}
+
 func commandRestoreFromBackup(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
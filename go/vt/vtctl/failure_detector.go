@@ -0,0 +1,110 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtctl
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/failuredetector"
+	"github.com/gitql/vitess/go/vt/wrangler"
+)
+
+// This file contains the commands to control the failure detector of
+// vtctld. The settings are saved in the topology, so the commands
+// work from any vtctl, whichever vtctld runs the failure detector.
+
+func init() {
+	addCommand("Keyspaces", command{
+		"EnableFailureDetector",
+		commandEnableFailureDetector,
+		"[-dry_run] <keyspace>",
+		"Enables the failure detector of vtctld for the keyspace: the shards whose master failed are emergency reparented to their most advanced replica. With -dry_run, the reparents are only recorded. vtctld must run with -enable_failure_detector."})
+	addCommand("Keyspaces", command{
+		"DisableFailureDetector",
+		commandDisableFailureDetector,
+		"<keyspace>",
+		"Disables the failure detector of vtctld for the keyspace."})
+	addCommand("Keyspaces", command{
+		"GetFailureDetector",
+		commandGetFailureDetector,
+		"[-json] <keyspace>",
+		"Outputs the failure detector settings of the keyspace, and its last decisions about the failed masters of the keyspace."})
+}
+
+func commandEnableFailureDetector(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	dryRun := subFlags.Bool("dry_run", false, "Only record the reparents the failure detector would do")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the EnableFailureDetector command")
+	}
+	return failuredetector.SetKeyspaceSettings(ctx, wr.TopoServer(), subFlags.Arg(0), &failuredetector.KeyspaceSettings{
+		Enabled: true,
+		DryRun:  *dryRun,
+	})
+}
+
+func commandDisableFailureDetector(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the DisableFailureDetector command")
+	}
+	return failuredetector.SetKeyspaceSettings(ctx, wr.TopoServer(), subFlags.Arg(0), &failuredetector.KeyspaceSettings{})
+}
+
+func commandGetFailureDetector(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	asJSON := subFlags.Bool("json", false, "Output the settings and the decisions as JSON")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the GetFailureDetector command")
+	}
+	keyspace := subFlags.Arg(0)
+
+	settings, err := failuredetector.GetKeyspaceSettings(ctx, wr.TopoServer(), keyspace)
+	if err != nil {
+		return err
+	}
+	decisions, err := failuredetector.GetDecisions(ctx, wr.TopoServer(), keyspace)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(wr.Logger(), struct {
+			Settings  *failuredetector.KeyspaceSettings
+			Decisions []*failuredetector.Decision
+		}{settings, decisions})
+	}
+
+	wr.Logger().Printf("Enabled: %v, DryRun: %v\n", settings.Enabled, settings.DryRun)
+	if len(decisions) == 0 {
+		return nil
+	}
+	table := tablewriter.NewWriter(loggerWriter{wr.Logger()})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Time", "Shard", "Master", "Action", "New Master", "Reason"})
+	for _, d := range decisions {
+		table.Append([]string{
+			d.Time.UTC().Format(time.RFC3339),
+			d.Shard,
+			d.Master,
+			d.Action,
+			d.NewMaster,
+			d.Reason,
+		})
+	}
+	table.Render()
+	return nil
+}
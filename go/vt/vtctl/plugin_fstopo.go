@@ -0,0 +1,7 @@
+package vtctl
+
+import (
+	// Imports fstopo to register the fs implementation of
+	// TopoServer.
+	_ "github.com/gitql/vitess/go/vt/topo/fstopo"
+)
@@ -0,0 +1,153 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtctl
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/schemamanager/schemaqueue"
+	"github.com/gitql/vitess/go/vt/servenv"
+	"github.com/gitql/vitess/go/vt/workflow"
+	"github.com/gitql/vitess/go/vt/wrangler"
+)
+
+// This file contains the commands of the schema change queue. The
+// queued changes are workflows, so the commands are only available
+// where the WorkflowManager runs.
+
+const schemaGroupName = "Schema, Version, Permissions"
+
+func init() {
+	servenv.OnRun(func() {
+		if WorkflowManager == nil {
+			return
+		}
+
+		addCommand(schemaGroupName, command{
+			"SubmitSchemaChange",
+			commandSubmitSchemaChange,
+			"[-maintenance_window=HH:MM-HH:MM,...] [-allow_long_unavailability] [-wait_slave_timeout=10s] {-sql=<sql> || -sql-file=<filename>} <keyspace>",
+			"Queues the schema change of a keyspace. The changes of a keyspace are applied one at a time, in submission order, and one shard after the other. With -maintenance_window, the shards are only changed during the given daily UTC time ranges. Outputs the uuid of the change."})
+		addCommand(schemaGroupName, command{
+			"ListSchemaChanges",
+			commandListSchemaChanges,
+			"[-json] <keyspace>",
+			"Lists the queued, running and finished schema changes of a keyspace, in submission order."})
+		addCommand(schemaGroupName, command{
+			"RetrySchemaChange",
+			commandRetrySchemaChange,
+			"<uuid>",
+			"Retries the failed shards of a queued schema change."})
+		addCommand(schemaGroupName, command{
+			"CancelSchemaChange",
+			commandCancelSchemaChange,
+			"<uuid>",
+			"Cancels a queued schema change. The shard being changed, if any, is not interrupted."})
+	})
+}
+
+func commandSubmitSchemaChange(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	maintenanceWindow := subFlags.String("maintenance_window", "", "Comma separated list of daily UTC time ranges, like 02:00-04:00, during which the shards can be changed")
+	allowLongUnavailability := subFlags.Bool("allow_long_unavailability", false, "Allow large schema changes which incur a longer unavailability of the database.")
+	sql := subFlags.String("sql", "", "A list of semicolon-delimited SQL commands")
+	sqlFile := subFlags.String("sql-file", "", "Identifies the file that contains the SQL commands")
+	waitSlaveTimeout := subFlags.Duration("wait_slave_timeout", wrangler.DefaultWaitSlaveTimeout, "The amount of time to wait for slaves to receive the schema change via replication.")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the SubmitSchemaChange command")
+	}
+
+	keyspace := subFlags.Arg(0)
+	change, err := getFileParam(*sql, *sqlFile, "sql")
+	if err != nil {
+		return err
+	}
+	uuid, err := schemaqueue.Submit(ctx, WorkflowManager, keyspace, change, *maintenanceWindow, *allowLongUnavailability, *waitSlaveTimeout)
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("uuid: %v\n", uuid)
+	return nil
+}
+
+func commandListSchemaChanges(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	asJSON := subFlags.Bool("json", false, "Output the full status of the changes as JSON")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the ListSchemaChanges command")
+	}
+
+	changes, err := schemaqueue.ListSchemaChanges(ctx, wr.TopoServer(), subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(wr.Logger(), changes)
+	}
+
+	table := tablewriter.NewWriter(loggerWriter{wr.Logger()})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"UUID", "State", "Submitted", "Shards", "SQL"})
+	for _, c := range changes {
+		table.Append([]string{
+			c.UUID,
+			c.State,
+			time.Unix(0, c.SubmitTime).UTC().Format(time.RFC3339),
+			shardStates(c.Shards),
+			strings.Join(c.SQL, "; "),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+// shardStates summarizes the state of the shards of a change, like
+// "complete: 2, failed: 1".
+func shardStates(shards map[string]*schemaqueue.ShardStatus) string {
+	counts := make(map[string]int)
+	for _, s := range shards {
+		counts[s.State]++
+	}
+	var states []string
+	for state, count := range counts {
+		states = append(states, fmt.Sprintf("%v: %v", state, count))
+	}
+	sort.Strings(states)
+	return strings.Join(states, ", ")
+}
+
+func commandRetrySchemaChange(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	return schemaChangeAction(ctx, subFlags, args, "RetrySchemaChange", schemaqueue.RetryAction)
+}
+
+func commandCancelSchemaChange(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	return schemaChangeAction(ctx, subFlags, args, "CancelSchemaChange", schemaqueue.CancelAction)
+}
+
+// schemaChangeAction sends an action to the workflow of a schema change.
+func schemaChangeAction(ctx context.Context, subFlags *flag.FlagSet, args []string, commandName, action string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <uuid> argument is required for the %v command", commandName)
+	}
+	return WorkflowManager.NodeManager().Action(ctx, &workflow.ActionParameters{
+		Path: "/" + subFlags.Arg(0),
+		Name: action,
+	})
+}
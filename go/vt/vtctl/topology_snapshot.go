@@ -0,0 +1,72 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtctl
+
+import (
+	"flag"
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/topo/helpers"
+	"github.com/gitql/vitess/go/vt/wrangler"
+)
+
+// This file contains the topology snapshot commands for vtctl.
+
+func init() {
+	addCommand("Generic", command{
+		"ExportTopology",
+		commandExportTopology,
+		"<file>",
+		"Saves the complete topology (all cells, keyspaces, shards, tablets, replication and serving graphs, VSchemas and workflows) to a file. The file is written by the process running the command, i.e. vtctld when using vtctlclient."})
+	addCommand("Generic", command{
+		"ImportTopology",
+		commandImportTopology,
+		"[-dry_run] [-delete_extra] <file>",
+		"Changes the topology so it matches a file saved by ExportTopology, and outputs the changes. With -dry_run, only outputs the changes. With -delete_extra, also deletes the objects that are not in the file (VSchemas and SrvVSchemas are never deleted)."})
+}
+
+func commandExportTopology(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <file> argument is required for the ExportTopology command")
+	}
+
+	s, err := helpers.TakeSnapshot(ctx, wr.TopoServer())
+	if err != nil {
+		return err
+	}
+	return helpers.WriteSnapshotFile(subFlags.Arg(0), s)
+}
+
+func commandImportTopology(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	dryRun := subFlags.Bool("dry_run", false, "only outputs the changes, without making them")
+	deleteExtra := subFlags.Bool("delete_extra", false, "deletes the objects that are not in the file")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <file> argument is required for the ImportTopology command")
+	}
+
+	s, err := helpers.ReadSnapshotFile(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	changes, err := helpers.RestoreSnapshot(ctx, wr.TopoServer(), s, *deleteExtra, *dryRun)
+	for _, c := range changes {
+		wr.Logger().Printf("%v\n", c)
+	}
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		wr.Logger().Printf("The topology already matches %v.\n", subFlags.Arg(0))
+	}
+	return nil
+}
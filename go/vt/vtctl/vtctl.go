@@ -96,6 +96,7 @@ import (
 	"github.com/gitql/vitess/go/mysqlconn/replication"
 	"github.com/gitql/vitess/go/sqltypes"
 	"github.com/gitql/vitess/go/sync2"
+	"github.com/gitql/vitess/go/vt/callerid"
 	hk "github.com/gitql/vitess/go/vt/hook"
 	"github.com/gitql/vitess/go/vt/key"
 	"github.com/gitql/vitess/go/vt/logutil"
@@ -117,7 +118,7 @@ var (
 
 // Flags are exported for use in go/vt/vtctld.
 var (
-	HealthCheckTopologyRefresh = flag.Duration("vtctl_healthcheck_topology_refresh", 30*time.Second, "refresh interval for re-reading the topology")
+	HealthCheckTopologyRefresh = flag.Duration("vtctl_healthcheck_topology_refresh", 30*time.Second, "refresh interval for re-reading the topology (the tablets of a cell are only re-read if the topology server cannot watch them)")
 	HealthcheckRetryDelay      = flag.Duration("vtctl_healthcheck_retry_delay", 5*time.Second, "delay before retrying a failed healthcheck")
 	HealthCheckTimeout         = flag.Duration("vtctl_healthcheck_timeout", time.Minute, "the health check timeout period")
 )
@@ -339,6 +340,9 @@ var commands = []commandGroup{
 			{"ApplySchema", commandApplySchema,
 				"[-allow_long_unavailability] [-wait_slave_timeout=10s] {-sql=<sql> || -sql-file=<filename>} <keyspace>",
 				"Applies the schema change to the specified keyspace on every master, running in parallel on all shards. The changes are then propagated to slaves via replication. If -allow_long_unavailability is set, schema changes affecting a large number of rows (and possibly incurring a longer period of unavailability) will not be rejected."},
+			{"ApplyDeclarativeSchema", commandApplyDeclarativeSchema,
+				"[-dry_run] [-allow_drops] [-allow_long_unavailability] [-wait_slave_timeout=10s] -dir=<directory> <keyspace>",
+				"Compares the CREATE TABLE statements of the .sql files in a directory with the schema of the keyspace, and applies the CREATE TABLE, ALTER TABLE and DROP TABLE statements that make the keyspace match them. All the shards must need the same statements. With -dry_run, the statements are only printed. Statements that drop tables or columns are refused unless -allow_drops is set."},
 			{"CopySchemaShard", commandCopySchemaShard,
 				"[-tables=<table1>,<table2>,...] [-exclude_tables=<table1>,<table2>,...] [-include-views] [-wait_slave_timeout=10s] {<source keyspace/shard> || <source tablet alias>} <destination keyspace/shard>",
 				"Copies the schema from a source shard's master (or a specific tablet) to a destination shard. The schema is applied directly on the master of the destination shard, and it is propagated to the replicas through binlogs."},
@@ -1891,6 +1895,86 @@ func commandApplySchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *fl
 	)
 }
 
+func commandApplyDeclarativeSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	dir := subFlags.String("dir", "", "The directory that contains the CREATE TABLE statements, in .sql files")
+	dryRun := subFlags.Bool("dry_run", false, "Only prints the statements that would be applied")
+	allowDrops := subFlags.Bool("allow_drops", false, "Allow statements that drop tables or columns")
+	allowLongUnavailability := subFlags.Bool("allow_long_unavailability", false, "Allow large schema changes which incur a longer unavailability of the database.")
+	waitSlaveTimeout := subFlags.Duration("wait_slave_timeout", wrangler.DefaultWaitSlaveTimeout, "The amount of time to wait for slaves to receive the schema change via replication.")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the ApplyDeclarativeSchema command")
+	}
+	if *dir == "" {
+		return fmt.Errorf("the -dir flag is required for the ApplyDeclarativeSchema command")
+	}
+	keyspace := subFlags.Arg(0)
+
+	desired, err := schemamanager.LoadSchemaDir(*dir)
+	if err != nil {
+		return err
+	}
+	// Every shard is diffed, and they must all need the same
+	// changes, as the statements are applied to all of them.
+	shards, err := wr.TopoServer().GetShardNames(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	if len(shards) == 0 {
+		return fmt.Errorf("no shards in keyspace %v", keyspace)
+	}
+	sort.Strings(shards)
+	var changes *schemamanager.SchemaChanges
+	for _, shard := range shards {
+		si, err := wr.TopoServer().GetShard(ctx, keyspace, shard)
+		if err != nil {
+			return err
+		}
+		if !si.HasMaster() {
+			return fmt.Errorf("no master in shard %v/%v", keyspace, shard)
+		}
+		current, err := wr.GetSchema(ctx, si.MasterAlias, nil, nil, false /* includeViews */)
+		if err != nil {
+			return err
+		}
+		shardChanges, err := schemamanager.DiffSchemaToChanges(current, desired)
+		if err != nil {
+			return fmt.Errorf("shard %v/%v: %v", keyspace, shard, err)
+		}
+		if changes == nil {
+			changes = shardChanges
+			continue
+		}
+		if strings.Join(shardChanges.SQL, ";\n") != strings.Join(changes.SQL, ";\n") {
+			return fmt.Errorf("shards %v/%v and %v/%v need different changes, use ValidateSchemaKeyspace to compare their schemas", keyspace, shards[0], keyspace, shard)
+		}
+	}
+	if len(changes.SQL) == 0 {
+		wr.Logger().Printf("The schema of keyspace %v already matches %v.\n", keyspace, *dir)
+		return nil
+	}
+	if *dryRun {
+		for _, sql := range changes.SQL {
+			wr.Logger().Printf("%v;\n", sql)
+		}
+		if len(changes.Drops) != 0 {
+			wr.Logger().Printf("The changes drop %v, -allow_drops is required to apply them.\n", strings.Join(changes.Drops, ", "))
+		}
+		return nil
+	}
+	if len(changes.Drops) != 0 && !*allowDrops {
+		return fmt.Errorf("the changes drop %v, use -allow_drops to allow it", strings.Join(changes.Drops, ", "))
+	}
+
+	executor := schemamanager.NewTabletExecutor(wr, *waitSlaveTimeout)
+	if *allowLongUnavailability {
+		executor.AllowBigSchemaChange()
+	}
+	return schemamanager.Run(ctx, changes.Controller(keyspace), executor)
+}
+
 func commandCopySchemaShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	tables := subFlags.String("tables", "", "Specifies a comma-separated list of tables to copy. Each is either an exact match, or a regular expression of the form /regexp/")
 	excludeTables := subFlags.String("exclude_tables", "", "Specifies a comma-separated list of tables to exclude. Each is either an exact match, or a regular expression of the form /regexp/")
@@ -2260,6 +2344,12 @@ func RunCommand(ctx context.Context, wr *wrangler.Wrangler, args []string) error
 					wr.Logger().Printf("%s\n\n", cmd.help)
 					subFlags.PrintDefaults()
 				}
+				if callerid.EffectiveCallerIDFromContext(ctx) == nil {
+					// Identify the command in the topology audit log.
+					ctx = callerid.NewContext(ctx,
+						callerid.NewEffectiveCallerID("" /* principal */, "vtctl", cmd.name),
+						callerid.ImmediateCallerIDFromContext(ctx))
+				}
 				return cmd.method(ctx, wr, subFlags, args[1:])
 			}
 		}
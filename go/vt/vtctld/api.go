@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/gitql/vitess/go/acl"
 	"github.com/gitql/vitess/go/vt/logutil"
 	"github.com/gitql/vitess/go/vt/schemamanager"
+	"github.com/gitql/vitess/go/vt/schemamanager/schemaqueue"
 	"github.com/gitql/vitess/go/vt/tabletmanager/tmclient"
 	"github.com/gitql/vitess/go/vt/topo"
 	"github.com/gitql/vitess/go/vt/topo/topoproto"
@@ -519,6 +521,98 @@ func initAPI(ctx context.Context, ts topo.Server, actions *ActionRepository, rea
 			schemamanager.NewUIController(req.SQL, req.Keyspace, w), executor)
 	})
 
+	// Schema Change Queue
+	handleCollection("schema_changes", func(r *http.Request) (interface{}, error) {
+		keyspace := getItemPath(r.URL.Path)
+		if keyspace == "" {
+			return nil, errors.New("schema changes can only be listed per keyspace")
+		}
+		return schemaqueue.ListSchemaChanges(ctx, ts, keyspace)
+	})
+	handleAPI("schema/queue", func(w http.ResponseWriter, r *http.Request) error {
+		if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+			http.Error(w, "403 Forbidden", http.StatusForbidden)
+			return nil
+		}
+		if vtctl.WorkflowManager == nil {
+			return errors.New("the schema change queue needs the workflow manager, see -workflow_manager_init")
+		}
+		req := struct {
+			Keyspace, SQL, MaintenanceWindow string
+			AllowLongUnavailability          bool
+			SlaveTimeoutSeconds              int
+		}{}
+		if err := unmarshalRequest(r, &req); err != nil {
+			return fmt.Errorf("can't unmarshal request: %v", err)
+		}
+		if req.SlaveTimeoutSeconds <= 0 {
+			req.SlaveTimeoutSeconds = 10
+		}
+
+		uuid, err := schemaqueue.Submit(ctx, vtctl.WorkflowManager, req.Keyspace, req.SQL, req.MaintenanceWindow,
+			req.AllowLongUnavailability, time.Duration(req.SlaveTimeoutSeconds)*time.Second)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(map[string]string{"UUID": uuid}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json error: %v", err)
+		}
+		w.Header().Set("Content-Type", jsonContentType)
+		w.Write(data)
+		return nil
+	})
+	handleAPI("schema/queue_action", func(w http.ResponseWriter, r *http.Request) error {
+		if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+			http.Error(w, "403 Forbidden", http.StatusForbidden)
+			return nil
+		}
+		if vtctl.WorkflowManager == nil {
+			return errors.New("the schema change queue needs the workflow manager, see -workflow_manager_init")
+		}
+		req := struct {
+			UUID, Action string
+		}{}
+		if err := unmarshalRequest(r, &req); err != nil {
+			return fmt.Errorf("can't unmarshal request: %v", err)
+		}
+		if req.Action != schemaqueue.RetryAction && req.Action != schemaqueue.CancelAction {
+			return fmt.Errorf("unknown schema change action: %v", req.Action)
+		}
+		return vtctl.WorkflowManager.NodeManager().Action(ctx, &workflow.ActionParameters{
+			Path: "/" + req.UUID,
+			Name: req.Action,
+		})
+	})
+
+	// Topology Audit Log
+	handleCollection("audit_log", func(r *http.Request) (interface{}, error) {
+		// The item path is <keyspace|shard|tablet>/<name>.
+		parts := strings.SplitN(getItemPath(r.URL.Path), "/", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.New("the audit log can only be retrieved for keyspace/<keyspace>, shard/<keyspace>/<shard> or tablet/<tablet alias>")
+		}
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		var since time.Time
+		if sinceStr := r.FormValue("since"); sinceStr != "" {
+			d, err := time.ParseDuration(sinceStr)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse since: %v", err)
+			}
+			since = time.Now().Add(-d)
+		}
+		limit := 0
+		if limitStr := r.FormValue("limit"); limitStr != "" {
+			var err error
+			if limit, err = strconv.Atoi(limitStr); err != nil {
+				return nil, fmt.Errorf("can't parse limit: %v", err)
+			}
+		}
+		return vtctl.GetAuditLog(ctx, ts, parts[0], parts[1], since, limit)
+	})
+
 	// Features
 	handleAPI("features", func(w http.ResponseWriter, r *http.Request) error {
 		if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
@@ -0,0 +1,58 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtctld
+
+import (
+	"flag"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/servenv"
+	"github.com/gitql/vitess/go/vt/topo"
+)
+
+var (
+	auditLogRetention     = flag.Duration("audit_log_retention", 0, "if set, vtctld periodically deletes the audit log entries of the topology changes older than this duration (see PruneAuditLog)")
+	auditLogPruneInterval = flag.Duration("audit_log_prune_interval", time.Hour, "how often vtctld prunes the audit log, if audit_log_retention is set")
+)
+
+func initAuditLogPruning(ts topo.Server) {
+	if *auditLogRetention <= 0 {
+		return
+	}
+
+	// Several vtctlds may prune at the same time, which is harmless.
+	done := make(chan struct{})
+	servenv.OnRun(func() {
+		go func() {
+			ticker := time.NewTicker(*auditLogPruneInterval)
+			defer ticker.Stop()
+			for {
+				pruneAuditLog(ts)
+				select {
+				case <-ticker.C:
+				case <-done:
+					return
+				}
+			}
+		}()
+	})
+	servenv.OnTermSync(func() {
+		close(done)
+	})
+}
+
+func pruneAuditLog(ts topo.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), *auditLogPruneInterval)
+	defer cancel()
+	deleted, err := ts.PruneAuditLog(ctx, time.Now().Add(-*auditLogRetention))
+	if err != nil {
+		log.Errorf("Cannot prune the audit log, deleted %v entries: %v", deleted, err)
+		return
+	}
+	log.Infof("Deleted %v audit log entries older than %v", deleted, *auditLogRetention)
+}
@@ -0,0 +1,113 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtctld
+
+import (
+	"flag"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/failuredetector"
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/servenv"
+	"github.com/gitql/vitess/go/vt/tabletmanager/tmclient"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/vtctl"
+	"github.com/gitql/vitess/go/vt/wrangler"
+)
+
+var (
+	enableFailureDetector           = flag.Bool("enable_failure_detector", false, "if set, vtctld watches the health of the masters of the keyspaces the failure detector is enabled for (see EnableFailureDetector), and emergency reparents the shards whose master failed. A topology server-based master election ensures only one vtctld runs the failure detector.")
+	failureDetectorGracePeriod      = flag.Duration("failure_detector_grace_period", 30*time.Second, "how long the health check of a master must fail before the failure detector checks it")
+	failureDetectorCheckInterval    = flag.Duration("failure_detector_check_interval", 5*time.Second, "how often the failure detector checks the suspected masters")
+	failureDetectorCooldown         = flag.Duration("failure_detector_cooldown", 10*time.Minute, "minimum time between two reparents of a shard by the failure detector")
+	failureDetectorWaitSlaveTimeout = flag.Duration("failure_detector_wait_slave_timeout", wrangler.DefaultWaitSlaveTimeout, "timeout of the failure detector RPCs to the tablets, and of its emergency reparents")
+	failureDetectorDryRun           = flag.Bool("failure_detector_dry_run", false, "if set, the failure detector only records the reparents it would do, for all the keyspaces")
+)
+
+func initFailureDetector(ts topo.Server) {
+	if !*enableFailureDetector {
+		return
+	}
+
+	tmc := tmclient.NewTabletManagerClient()
+	wr := wrangler.New(logutil.NewConsoleLogger(), ts, tmc)
+	detector := failuredetector.NewDetector(ts, tmc, wr, failuredetector.Config{
+		GracePeriod:      *failureDetectorGracePeriod,
+		CheckInterval:    *failureDetectorCheckInterval,
+		Cooldown:         *failureDetectorCooldown,
+		WaitSlaveTimeout: *failureDetectorWaitSlaveTimeout,
+		DryRun:           *failureDetectorDryRun,
+	})
+
+	var mp topo.MasterParticipation
+
+	// We use servenv.ListeningURL which is only populated during Run,
+	// so we have to start this with OnRun.
+	servenv.OnRun(func() {
+		var err error
+		mp, err = ts.NewMasterParticipation("failure_detector", servenv.ListeningURL.Host)
+		if err != nil {
+			log.Errorf("Cannot start MasterParticipation, disabling failure detector: %v", err)
+			return
+		}
+
+		go func() {
+			for {
+				ctx, err := mp.WaitForMastership()
+				switch err {
+				case nil:
+					runFailureDetector(ctx, ts, detector)
+				case topo.ErrInterrupted:
+					return
+				default:
+					log.Errorf("Got error while waiting for master, will retry in 5s: %v", err)
+					time.Sleep(5 * time.Second)
+				}
+			}
+		}()
+	})
+
+	// When we get killed, clean up.
+	servenv.OnTermSync(func() {
+		if mp != nil {
+			mp.Stop()
+		}
+	})
+}
+
+// runFailureDetector watches the health of all the tablets, and runs
+// the failure detector until the context is canceled, when we lose
+// the mastership.
+func runFailureDetector(ctx context.Context, ts topo.Server, detector *failuredetector.Detector) {
+	log.Infof("Starting the failure detector")
+	defer log.Infof("Stopped the failure detector")
+
+	hc := discovery.NewHealthCheck(*vtctl.HealthCheckTimeout, *vtctl.HealthcheckRetryDelay, *vtctl.HealthCheckTimeout)
+	// sendDownEvents is set to true here, so the tablets removed
+	// from the topology are forgotten.
+	hc.SetListener(detector, true)
+	defer func() {
+		if err := hc.Close(); err != nil {
+			log.Warningf("healthCheck.Close() failed: %v", err)
+		}
+	}()
+
+	cells, err := ts.GetKnownCells(ctx)
+	if err != nil {
+		log.Errorf("Cannot get the cells, the failure detector is not running: %v", err)
+		<-ctx.Done()
+		return
+	}
+	for _, cell := range cells {
+		watcher := discovery.NewCellTabletsWatcher(ts, hc, cell, *vtctl.HealthCheckTopologyRefresh, discovery.DefaultTopoReadConcurrency)
+		defer watcher.Stop()
+	}
+
+	detector.Run(ctx)
+}
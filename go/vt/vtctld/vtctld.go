@@ -173,4 +173,10 @@ func InitVtctld(ts topo.Server) {
 
 	// Init workflow manager.
 	initWorkflowManager(ts)
+
+	// Init the failure detector.
+	initFailureDetector(ts)
+
+	// Init the pruning of the audit log.
+	initAuditLogPruning(ts)
 }
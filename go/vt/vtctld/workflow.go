@@ -8,6 +8,8 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/gitql/vitess/go/flagutil"
+	"github.com/gitql/vitess/go/vt/schemamanager/schemamigration"
+	"github.com/gitql/vitess/go/vt/schemamanager/schemaqueue"
 	"github.com/gitql/vitess/go/vt/schemamanager/schemaswap"
 	"github.com/gitql/vitess/go/vt/servenv"
 	"github.com/gitql/vitess/go/vt/topo"
@@ -40,6 +42,12 @@ func initWorkflowManager(ts topo.Server) {
 		// Register the Schema Swap workflow.
 		schemaswap.RegisterWorkflowFactory()
 
+		// Register the online Schema Migration workflow.
+		schemamigration.RegisterWorkflowFactory()
+
+		// Register the Schema Change queue workflow.
+		schemaqueue.RegisterWorkflowFactory()
+
 		// Register the Horizontal Resharding workflow.
 		resharding.Register()
 		// Unregister the blacklisted workflows.
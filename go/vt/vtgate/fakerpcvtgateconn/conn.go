@@ -322,17 +322,25 @@ func (conn *FakeVTGateConn) StreamExecuteKeyspaceIds(ctx context.Context, query
 // Begin please see vtgateconn.Impl.Begin
 func (conn *FakeVTGateConn) Begin(ctx context.Context, singledb bool) (interface{}, error) {
 	return &vtgatepb.Session{
-		InTransaction: true,
-		SingleDb:      singledb,
+		InTransaction:  true,
+		SingleDb:       singledb,
+		ReadAfterWrite: vtgateconn.ReadAfterWriteFromContext(ctx),
 	}, nil
 }
 
 // Commit please see vtgateconn.Impl.Commit
-func (conn *FakeVTGateConn) Commit(ctx context.Context, session interface{}, twopc bool) error {
+func (conn *FakeVTGateConn) Commit(ctx context.Context, session interface{}, twopc bool) (interface{}, error) {
 	if session == nil {
-		return errors.New("commit: not in transaction")
+		return nil, errors.New("commit: not in transaction")
 	}
-	return nil
+	s := session.(*vtgatepb.Session)
+	if !s.ReadAfterWrite {
+		return nil, nil
+	}
+	return &vtgatepb.Session{
+		ReadAfterWrite: true,
+		ShardPositions: s.ShardPositions,
+	}, nil
 }
 
 // Rollback please see vtgateconn.Impl.Rollback
@@ -17,8 +17,11 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/gitql/vitess/go/flagutil"
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/stats"
 	"github.com/gitql/vitess/go/vt/discovery"
 	"github.com/gitql/vitess/go/vt/tabletserver/queryservice"
+	"github.com/gitql/vitess/go/vt/tabletserver/querytypes"
 	"github.com/gitql/vitess/go/vt/tabletserver/tabletconn"
 	"github.com/gitql/vitess/go/vt/topo"
 	"github.com/gitql/vitess/go/vt/vterrors"
@@ -33,8 +36,12 @@ import (
 var (
 	cellsToWatch        = flag.String("cells_to_watch", "", "comma-separated list of cells for watching tablets")
 	tabletFilters       flagutil.StringListValue
-	refreshInterval     = flag.Duration("tablet_refresh_interval", 1*time.Minute, "tablet refresh interval")
+	refreshInterval     = flag.Duration("tablet_refresh_interval", 1*time.Minute, "tablet refresh interval, only used if the topology server cannot watch the tablets")
 	topoReadConcurrency = flag.Int("topo_read_concurrency", 32, "concurrent topo reads")
+	cellPreference      = flag.String("cell_preference", "", "comma-separated list of regions to send non-master queries to, by order of preference. A region is a '|'-separated list of cells, optionally prefixed by its name and ':', e.g. 'east:c1|c2,west:c3'. Queries go to the first region with healthy tablets. Defaults to the local cell only. The cells must also be in cells_to_watch.")
+	cellFailoverLag     = flag.Duration("cell_failover_max_replication_lag", 0, "if set, tablets with a higher replication lag are not used if another region of cell_preference has healthy tablets under that lag")
+
+	crossCellQueries = stats.NewMultiCounters("GatewayCrossCellQueries", []string{"Keyspace", "ShardName", "Cell"})
 )
 
 const (
@@ -50,6 +57,7 @@ type discoveryGateway struct {
 	queryservice.QueryService
 	hc            discovery.HealthCheck
 	tsc           *discovery.TabletStatsCache
+	schemaCache   *discovery.SchemaCache
 	topoServer    topo.Server
 	srvTopoServer topo.SrvTopoServer
 	localCell     string
@@ -67,12 +75,39 @@ type discoveryGateway struct {
 
 	// buffer, if enabled, buffers requests during a detected MASTER failover.
 	buffer *buffer.Buffer
+
+	// picker chooses the tablet each query is sent to.
+	picker TabletPicker
+
+	// hedger decides when reads are sent to a second tablet.
+	hedger *hedger
 }
 
 func createDiscoveryGateway(hc discovery.HealthCheck, topoServer topo.Server, serv topo.SrvTopoServer, cell string, retryCount int) Gateway {
+	regions := []discovery.CellRegion{{Name: cell, Cells: []string{cell}}}
+	if *cellPreference != "" {
+		var err error
+		regions, err = discovery.ParseCellRegions(*cellPreference)
+		if err != nil {
+			log.Fatalf("Cannot parse cell_preference parameter: %v", err)
+		}
+		watched := make(map[string]bool)
+		for _, c := range strings.Split(*cellsToWatch, ",") {
+			watched[c] = true
+		}
+		for _, region := range regions {
+			for _, c := range region.Cells {
+				if !watched[c] {
+					log.Warningf("cell %v of region %v is not in cells_to_watch, no tablet will be used from it", c, region.Name)
+				}
+			}
+		}
+	}
+
 	dg := &discoveryGateway{
 		hc:                hc,
-		tsc:               discovery.NewTabletStatsCacheDoNotSetListener(cell),
+		tsc:               discovery.NewTabletStatsCacheForRegions(regions, *cellFailoverLag),
+		schemaCache:       discovery.NewSchemaCache(),
 		topoServer:        topoServer,
 		srvTopoServer:     serv,
 		localCell:         cell,
@@ -80,9 +115,11 @@ func createDiscoveryGateway(hc discovery.HealthCheck, topoServer topo.Server, se
 		tabletsWatchers:   make([]*discovery.TopologyWatcher, 0, 1),
 		statusAggregators: make(map[string]*TabletStatusAggregator),
 		buffer:            buffer.New(),
+		picker:            newTabletPicker(),
+		hedger:            newHedger(),
 	}
 
-	// Set listener which will update TabletStatsCache and MasterBuffer.
+	// Set listener which will update TabletStatsCache, SchemaCache and MasterBuffer.
 	// We set sendDownEvents=true because it's required by TabletStatsCache.
 	hc.SetListener(dg, true /* sendDownEvents */)
 
@@ -107,10 +144,13 @@ func createDiscoveryGateway(hc discovery.HealthCheck, topoServer topo.Server, se
 	return dg
 }
 
-// StatsUpdate forwards HealthCheck updates to TabletStatsCache and MasterBuffer.
+// StatsUpdate forwards HealthCheck updates to TabletStatsCache, SchemaCache,
+// TabletPicker and MasterBuffer.
 // It is part of the discovery.HealthCheckStatsListener interface.
 func (dg *discoveryGateway) StatsUpdate(ts *discovery.TabletStats) {
 	dg.tsc.StatsUpdate(ts)
+	dg.schemaCache.StatsUpdate(ts)
+	dg.picker.StatsUpdate(ts)
 
 	if ts.Target.TabletType == topodatapb.TabletType_MASTER {
 		dg.buffer.StatsUpdate(ts)
@@ -157,12 +197,81 @@ func (dg *discoveryGateway) CacheStatus() TabletCacheStatusList {
 	return res
 }
 
+// SchemaCache is part of the Gateway interface.
+func (dg *discoveryGateway) SchemaCache() *discovery.SchemaCache {
+	return dg.schemaCache
+}
+
+// TabletPickerStatus is part of the Gateway interface.
+func (dg *discoveryGateway) TabletPickerStatus() *TabletPickerStatus {
+	return dg.picker.Status()
+}
+
+// Execute is part of the queryservice.QueryService interface.
+// Reads outside of a transaction may be hedged.
+func (dg *discoveryGateway) Execute(ctx context.Context, target *querypb.Target, query string, bindVars map[string]interface{}, transactionID int64, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
+	if transactionID != 0 || !dg.hedger.canHedge(target) {
+		return dg.QueryService.Execute(ctx, target, query, bindVars, transactionID, options)
+	}
+	// The inner function can run concurrently on two tablets:
+	// the first successful answer is kept.
+	var mu sync.Mutex
+	var qr *sqltypes.Result
+	err := dg.retry(ctx, target, false, false, true, func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) error {
+		innerqr, innerErr := conn.Execute(ctx, target, query, bindVars, 0, options)
+		if innerErr != nil {
+			return innerErr
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if qr == nil {
+			qr = innerqr
+		}
+		return nil
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	return qr, err
+}
+
+// ExecuteBatch is part of the queryservice.QueryService interface.
+// Reads outside of a transaction may be hedged.
+func (dg *discoveryGateway) ExecuteBatch(ctx context.Context, target *querypb.Target, queries []querytypes.BoundQuery, asTransaction bool, transactionID int64, options *querypb.ExecuteOptions) ([]sqltypes.Result, error) {
+	if asTransaction || transactionID != 0 || !dg.hedger.canHedge(target) {
+		return dg.QueryService.ExecuteBatch(ctx, target, queries, asTransaction, transactionID, options)
+	}
+	var mu sync.Mutex
+	var qrs []sqltypes.Result
+	err := dg.retry(ctx, target, false, false, true, func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) error {
+		innerqrs, innerErr := conn.ExecuteBatch(ctx, target, queries, false, 0, options)
+		if innerErr != nil {
+			return innerErr
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if qrs == nil {
+			qrs = innerqrs
+		}
+		return nil
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	return qrs, err
+}
+
 // withRetry gets available connections and executes the action. If there are retryable errors,
 // it retries retryCount times before failing. It does not retry if the connection is in
 // the middle of a transaction. While returning the error check if it maybe a result of
 // a resharding event, and set the re-resolve bit and let the upper layers
 // re-resolve and retry.
 func (dg *discoveryGateway) withRetry(ctx context.Context, target *querypb.Target, conn queryservice.QueryService, name string, inTransaction, isStreaming bool, inner func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) error) error {
+	return dg.retry(ctx, target, inTransaction, isStreaming, false, inner)
+}
+
+// retry implements withRetry. If hedge is set, inner may also be sent
+// to a second tablet if the first one is slow to answer, in which case
+// it must be safe to run concurrently.
+func (dg *discoveryGateway) retry(ctx context.Context, target *querypb.Target, inTransaction, isStreaming, hedge bool, inner func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) error) error {
 	var tabletLastUsed *topodatapb.Tablet
 	var err error
 	invalidTablets := make(map[string]bool)
@@ -202,15 +311,20 @@ func (dg *discoveryGateway) withRetry(ctx context.Context, target *querypb.Targe
 			err = vterrors.FromError(vtrpcpb.ErrorCode_INTERNAL_ERROR, fmt.Errorf("no valid tablet"))
 			break
 		}
-		shuffleTablets(tablets)
+		dg.picker.Sort(tablets)
 
 		// skip tablets we tried before
-		var ts *discovery.TabletStats
-		for _, t := range tablets {
-			if _, ok := invalidTablets[t.Key]; !ok {
-				ts = &t
-				break
+		var ts, hedgeTS *discovery.TabletStats
+		for j := range tablets {
+			if _, ok := invalidTablets[tablets[j].Key]; ok {
+				continue
+			}
+			if ts == nil {
+				ts = &tablets[j]
+				continue
 			}
+			hedgeTS = &tablets[j]
+			break
 		}
 		if ts == nil {
 			if err == nil {
@@ -222,6 +336,9 @@ func (dg *discoveryGateway) withRetry(ctx context.Context, target *querypb.Targe
 
 		// execute
 		tabletLastUsed = ts.Tablet
+		if cell := ts.Tablet.Alias.Cell; cell != dg.localCell && target.TabletType != topodatapb.TabletType_MASTER {
+			crossCellQueries.Add([]string{target.Keyspace, target.Shard, cell}, 1)
+		}
 		conn := dg.hc.GetConnection(ts.Key)
 		if conn == nil {
 			err = vterrors.FromError(vtrpcpb.ErrorCode_INTERNAL_ERROR, fmt.Errorf("no connection for key %v tablet %+v", ts.Key, ts.Tablet))
@@ -235,7 +352,20 @@ func (dg *discoveryGateway) withRetry(ctx context.Context, target *querypb.Targe
 		}
 
 		startTime := time.Now()
-		err = inner(ctx, ts.Target, conn)
+		if hedge && hedgeTS != nil {
+			var hedged *discovery.TabletStats
+			hedged, err = dg.executeHedged(ctx, target, ts, conn, hedgeTS, inner)
+			if hedged != nil && dg.canRetry(ctx, err, inTransaction, isStreaming) {
+				invalidTablets[hedged.Key] = true
+			}
+		} else {
+			dg.picker.QueryStart(ts)
+			err = inner(ctx, ts.Target, conn)
+			dg.picker.QueryEnd(ts, time.Now().Sub(startTime), isStreaming, err)
+			if hedge && err == nil {
+				dg.hedger.record(target, time.Now().Sub(startTime))
+			}
+		}
 		dg.updateStats(target, startTime, err)
 		if dg.canRetry(ctx, err, inTransaction, isStreaming) {
 			invalidTablets[ts.Key] = true
@@ -246,6 +376,79 @@ func (dg *discoveryGateway) withRetry(ctx context.Context, target *querypb.Targe
 	return NewShardError(err, target, tabletLastUsed, inTransaction)
 }
 
+// executeHedged sends the query to ts, and also to hedgeTS if ts
+// hasn't answered within the hedging delay. The first successful
+// answer is used and the other request is canceled. If both fail,
+// the last error is returned. It returns hedgeTS if the query was
+// sent to it.
+func (dg *discoveryGateway) executeHedged(ctx context.Context, target *querypb.Target, ts *discovery.TabletStats, conn queryservice.QueryService, hedgeTS *discovery.TabletStats, inner func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) error) (*discovery.TabletStats, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		ts      *discovery.TabletStats
+		elapsed time.Duration
+		err     error
+	}
+	// The channel is large enough for the
+	// loser to not block after we return.
+	results := make(chan result, 2)
+	send := func(ts *discovery.TabletStats, conn queryservice.QueryService) {
+		dg.picker.QueryStart(ts)
+		go func() {
+			startTime := time.Now()
+			err := inner(ctx, ts.Target, conn)
+			elapsed := time.Now().Sub(startTime)
+			if err != nil && ctx.Err() != nil {
+				// We canceled the slower request: its
+				// latency is at least what it took so far.
+				dg.picker.QueryEnd(ts, elapsed, false, nil)
+			} else {
+				dg.picker.QueryEnd(ts, elapsed, false, err)
+			}
+			results <- result{ts: ts, elapsed: elapsed, err: err}
+		}()
+	}
+
+	var hedged *discovery.TabletStats
+	send(ts, conn)
+	pending := 1
+	var timerC <-chan time.Time
+	if delay, ok := dg.hedger.delay(target); ok {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		timerC = timer.C
+	}
+	var err error
+	for pending > 0 {
+		select {
+		case <-timerC:
+			timerC = nil
+			if !dg.hedger.acquire() {
+				continue
+			}
+			hedgeConn := dg.hc.GetConnection(hedgeTS.Key)
+			if hedgeConn == nil {
+				continue
+			}
+			hedged = hedgeTS
+			send(hedgeTS, hedgeConn)
+			pending++
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				if r.ts == hedgeTS {
+					hedgeStats.Add("Won", 1)
+				}
+				dg.hedger.record(target, r.elapsed)
+				return hedged, nil
+			}
+			err = r.err
+		}
+	}
+	return hedged, err
+}
+
 // canRetry determines whether a query can be retried or not.
 // OperationalErrors like retry/fatal are retryable if query is not in a txn.
 // All other errors are non-retryable.
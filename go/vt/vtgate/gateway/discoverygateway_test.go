@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"golang.org/x/net/context"
 
 	"github.com/gitql/vitess/go/sqltypes"
 	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/tabletserver/queryservice"
 	"github.com/gitql/vitess/go/vt/tabletserver/querytypes"
 	"github.com/gitql/vitess/go/vt/topo"
 	"github.com/gitql/vitess/go/vt/vterrors"
@@ -53,14 +55,15 @@ func TestDiscoveryGatewayExecuteStream(t *testing.T) {
 
 func TestDiscoveryGatewayBegin(t *testing.T) {
 	testDiscoveryGatewayGeneric(t, false, func(dg Gateway, target *querypb.Target) error {
-		_, err := dg.Begin(context.Background(), target)
+		_, err := dg.Begin(context.Background(), target, nil)
 		return err
 	})
 }
 
 func TestDiscoveryGatewayCommit(t *testing.T) {
 	testDiscoveryGatewayTransact(t, false, func(dg Gateway, target *querypb.Target) error {
-		return dg.Commit(context.Background(), target, 1)
+		_, err := dg.Commit(context.Background(), target, 1)
+		return err
 	})
 }
 
@@ -266,3 +269,71 @@ func verifyShardError(t *testing.T, err error, wantErr string, wantCode vtrpcpb.
 		t.Errorf("wanted error code: %s, got: %v", wantCode, code)
 	}
 }
+
+func TestDiscoveryGatewayHedging(t *testing.T) {
+	keyspace := "ks"
+	shard := "0"
+	tabletType := topodatapb.TabletType_REPLICA
+	target := &querypb.Target{
+		Keyspace:   keyspace,
+		Shard:      shard,
+		TabletType: tabletType,
+	}
+	hc := discovery.NewFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", 2).(*discoveryGateway)
+	dg.hedger.enabled = true
+	dg.hedger.minDelay = time.Millisecond
+	dg.hedger.maxRatio = 1
+	hc.AddTestTablet("cell", "1.1.1.1", 1001, keyspace, shard, tabletType, true, 10, nil)
+	hc.AddTestTablet("cell", "1.1.1.1", 1002, keyspace, shard, tabletType, true, 10, nil)
+	tablets := dg.tsc.GetHealthyTabletStats(keyspace, shard, tabletType)
+	if len(tablets) != 2 {
+		t.Fatalf("want 2 tablets, got %v", tablets)
+	}
+	slow := hc.GetConnection(tablets[0].Key)
+	inner := func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) error {
+		if conn == slow {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	// No latency history: the read is not hedged.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	hedged, err := dg.executeHedged(ctx, target, &tablets[0], slow, &tablets[1], inner)
+	cancel()
+	if hedged != nil || err == nil {
+		t.Errorf("executeHedged: %v, %v, want nil and an error", hedged, err)
+	}
+
+	// The slow tablet is beaten by the hedged request.
+	for i := 0; i < hedgeRecomputeInterval; i++ {
+		dg.hedger.record(target, time.Millisecond)
+	}
+	won := hedgeStats.Counts()["Won"]
+	hedged, err = dg.executeHedged(context.Background(), target, &tablets[0], slow, &tablets[1], inner)
+	if hedged != &tablets[1] || err != nil {
+		t.Errorf("executeHedged: %v, %v, want %v and no error", hedged, err, &tablets[1])
+	}
+	if got := hedgeStats.Counts()["Won"]; got != won+1 {
+		t.Errorf("Won: %d, want %d", got, won+1)
+	}
+
+	// Out of budget: the read is not hedged.
+	dg.hedger.maxRatio = 0
+	dg.hedger.tokens = 0
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	hedged, err = dg.executeHedged(ctx, target, &tablets[0], slow, &tablets[1], inner)
+	cancel()
+	if hedged != nil || err == nil {
+		t.Errorf("executeHedged: %v, %v, want nil and an error", hedged, err)
+	}
+
+	// Execute returns the result of the tablet that answered.
+	dg.hedger.maxRatio = 1
+	qr, err := dg.Execute(context.Background(), target, "query", nil, 0, nil)
+	if err != nil || qr == nil {
+		t.Errorf("Execute: %v, %v, want a result", qr, err)
+	}
+}
@@ -40,6 +40,14 @@ type Gateway interface {
 
 	// CacheStatus returns a list of TabletCacheStatus per tablet.
 	CacheStatus() TabletCacheStatusList
+
+	// SchemaCache returns the table schemas published by the
+	// tablets, or nil if the gateway doesn't track them.
+	SchemaCache() *discovery.SchemaCache
+
+	// TabletPickerStatus returns the statistics the gateway uses
+	// to choose among tablets, or nil if it doesn't choose them.
+	TabletPickerStatus() *TabletPickerStatus
 }
 
 // Creator is the factory method which can create the actual gateway object.
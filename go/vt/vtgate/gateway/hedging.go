@@ -0,0 +1,167 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gitql/vitess/go/stats"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the hedging logic of the discovery gateway:
+// a read that hasn't been answered after a delay is sent to a
+// second tablet, and the first answer wins.
+
+var (
+	hedgeReads      = flag.Bool("gateway_hedge_reads", false, "If set, non-transactional reads on replica and rdonly tablets that take longer than the gateway_hedge_percentile latency are also sent to a second tablet")
+	hedgePercentile = flag.Float64("gateway_hedge_percentile", 95, "The latency percentile of a keyspace/shard/tablet_type after which a read is hedged")
+	hedgeMinDelay   = flag.Duration("gateway_hedge_min_delay", 5*time.Millisecond, "The minimum delay before a read is hedged")
+	hedgeMaxRatio   = flag.Float64("gateway_hedge_max_ratio", 0.05, "The maximum ratio of hedged requests to requests, which caps the extra load caused by hedging")
+
+	hedgeStats = stats.NewCounters("GatewayHedgedReads", "Sent", "Won", "Throttled")
+)
+
+const (
+	// hedgeWindowSize is the number of latency samples
+	// the percentile is computed on, per target.
+	hedgeWindowSize = 512
+	// hedgeRecomputeInterval is the number of samples after
+	// which the percentile is computed again. No read is hedged
+	// until that many samples have been recorded.
+	hedgeRecomputeInterval = 64
+	// hedgeMaxTokens bounds the number of hedged requests
+	// that can be sent in a burst.
+	hedgeMaxTokens = 10
+)
+
+// hedger decides when reads are hedged. It tracks the latency of
+// the reads per target, and limits the number of hedged requests.
+type hedger struct {
+	enabled    bool
+	percentile float64
+	minDelay   time.Duration
+	maxRatio   float64
+
+	// mu protects the fields below.
+	mu sync.Mutex
+	// windows is indexed by keyspace/shard/tablet_type.
+	windows map[string]*latencyWindow
+	// tokens is the number of hedged requests that can be sent.
+	// Every hedgeable request adds maxRatio to it.
+	tokens float64
+}
+
+// latencyWindow keeps the last latencies of a target.
+type latencyWindow struct {
+	samples [hedgeWindowSize]time.Duration
+	count   int
+	delay   time.Duration
+}
+
+func newHedger() *hedger {
+	return &hedger{
+		enabled:    *hedgeReads,
+		percentile: *hedgePercentile,
+		minDelay:   *hedgeMinDelay,
+		maxRatio:   *hedgeMaxRatio,
+		windows:    make(map[string]*latencyWindow),
+	}
+}
+
+// canHedge returns true if reads to the target can be hedged.
+func (h *hedger) canHedge(target *querypb.Target) bool {
+	if !h.enabled {
+		return false
+	}
+	switch target.TabletType {
+	case topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY:
+		return true
+	}
+	return false
+}
+
+func hedgeKey(target *querypb.Target) string {
+	return fmt.Sprintf("%v/%v/%v", target.Keyspace, target.Shard, target.TabletType.String())
+}
+
+// delay returns how long to wait for an answer before hedging a read
+// to the target. It returns false if the latency of the target is not
+// known yet, or if the limit on hedged requests has been reached.
+// Every call counts as one hedgeable request.
+func (h *hedger) delay(target *querypb.Target) (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tokens += h.maxRatio
+	if h.tokens > hedgeMaxTokens {
+		h.tokens = hedgeMaxTokens
+	}
+	w, ok := h.windows[hedgeKey(target)]
+	if !ok || w.count < hedgeRecomputeInterval {
+		return 0, false
+	}
+	if w.delay < h.minDelay {
+		return h.minDelay, true
+	}
+	return w.delay, true
+}
+
+// acquire consumes the right to send one hedged request.
+func (h *hedger) acquire() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.tokens < 1 {
+		hedgeStats.Add("Throttled", 1)
+		return false
+	}
+	h.tokens--
+	hedgeStats.Add("Sent", 1)
+	return true
+}
+
+// record adds the latency of a successful read to the target.
+func (h *hedger) record(target *querypb.Target, elapsed time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := hedgeKey(target)
+	w, ok := h.windows[key]
+	if !ok {
+		w = &latencyWindow{}
+		h.windows[key] = w
+	}
+	w.samples[w.count%hedgeWindowSize] = elapsed
+	w.count++
+	if w.count%hedgeRecomputeInterval == 0 {
+		w.delay = w.percentileLocked(h.percentile)
+	}
+}
+
+// percentileLocked computes the percentile p of the samples.
+func (w *latencyWindow) percentileLocked(p float64) time.Duration {
+	n := w.count
+	if n > hedgeWindowSize {
+		n = hedgeWindowSize
+	}
+	sorted := make(durations, n)
+	copy(sorted, w.samples[:n])
+	sort.Sort(sorted)
+	i := int(p / 100 * float64(n))
+	if i >= n {
+		i = n - 1
+	}
+	return sorted[i]
+}
+
+type durations []time.Duration
+
+func (d durations) Len() int           { return len(d) }
+func (d durations) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+func (d durations) Less(i, j int) bool { return d[i] < d[j] }
@@ -175,6 +175,18 @@ func (lg *l2VTGateGateway) CacheStatus() TabletCacheStatusList {
 	return res
 }
 
+// SchemaCache is part of the Gateway interface. The l2vtgate
+// gateway doesn't see the tablet health stream, so it returns nil.
+func (lg *l2VTGateGateway) SchemaCache() *discovery.SchemaCache {
+	return nil
+}
+
+// TabletPickerStatus is part of the Gateway interface. The l2vtgate
+// gateway sends queries to l2vtgates, not tablets, so it returns nil.
+func (lg *l2VTGateGateway) TabletPickerStatus() *TabletPickerStatus {
+	return nil
+}
+
 // getConn returns the right l2VTGateConn for a given keyspace / shard.
 func (lg *l2VTGateGateway) getConn(keyspace, shard string) (*l2VTGateConn, error) {
 	lg.mu.RLock()
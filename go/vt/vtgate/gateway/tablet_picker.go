@@ -0,0 +1,432 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"flag"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+
+	"github.com/gitql/vitess/go/ewma"
+	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+	"github.com/gitql/vitess/go/vt/vterrors"
+
+	vtrpcpb "github.com/gitql/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the TabletPicker interface definition, which the
+// discovery gateway uses to choose among the healthy tablets of a
+// shard, and its implementations.
+
+var tabletPicker = flag.String("gateway_tablet_picker", "random", "The policy used by the discovery gateway to choose among the healthy tablets of a shard: random, latency or least_outstanding")
+
+const (
+	// TabletPickerStatusTemplate is the display part to use to show
+	// a TabletPickerStatus.
+	TabletPickerStatusTemplate = `
+{{if .}}
+<p>Policy: {{.Policy}}</p>
+<table>
+  <tr>
+    <th>Keyspace</th>
+    <th>Shard</th>
+    <th>Tablet</th>
+    <th>Outstanding</th>
+    <th>Query Sent</th>
+    <th>Latency (ms) (ewma)</th>
+    <th>Error Rate (ewma)</th>
+  </tr>
+  {{range $i, $status := .Tablets}}
+  <tr>
+    <td>{{$status.Keyspace}}</td>
+    <td>{{$status.Shard}}</td>
+    <td>{{$status.Name}}</td>
+    <td>{{$status.Outstanding}}</td>
+    <td>{{$status.QueryCount}}</td>
+    <td>{{printf "%.3f" $status.Latency}}</td>
+    <td>{{printf "%.3f" $status.ErrorRate}}</td>
+  </tr>
+  {{end}}
+</table>
+{{else}}
+<p>The gateway does not pick tablets.</p>
+{{end}}
+`
+)
+
+// TabletPicker decides which of the healthy tablets of a shard
+// the gateway sends a query to. It is notified of the outcome
+// of every query, so it can adapt to the tablets' performance.
+type TabletPicker interface {
+	// Sort orders the tablets by preference, the preferred
+	// one first. The tablets are reordered in place.
+	Sort(tablets []discovery.TabletStats)
+
+	// QueryStart is called before a query is sent to the tablet.
+	QueryStart(ts *discovery.TabletStats)
+
+	// QueryEnd is called when the query sent to the tablet returns.
+	// Streaming queries do not provide a meaningful latency.
+	QueryEnd(ts *discovery.TabletStats, elapsed time.Duration, isStreaming bool, err error)
+
+	// StatsUpdate is called with the healthcheck updates, so the
+	// picker can forget the tablets that are removed.
+	StatsUpdate(ts *discovery.TabletStats)
+
+	// Status returns the statistics of the picker for the status page.
+	Status() *TabletPickerStatus
+}
+
+// TabletPickerCreator is the factory method which can create
+// a TabletPicker.
+type TabletPickerCreator func() TabletPicker
+
+var tabletPickerCreators = make(map[string]TabletPickerCreator)
+
+// RegisterTabletPicker registers a TabletPickerCreator with given name.
+func RegisterTabletPicker(name string, creator TabletPickerCreator) {
+	if _, ok := tabletPickerCreators[name]; ok {
+		log.Fatalf("TabletPicker %s already exists", name)
+	}
+	tabletPickerCreators[name] = creator
+}
+
+// newTabletPicker creates the TabletPicker specified by
+// the gateway_tablet_picker flag.
+func newTabletPicker() TabletPicker {
+	creator, ok := tabletPickerCreators[*tabletPicker]
+	if !ok {
+		log.Fatalf("No TabletPicker registered as %s", *tabletPicker)
+	}
+	return creator()
+}
+
+func init() {
+	RegisterTabletPicker("random", func() TabletPicker {
+		return &randomPicker{newTabletTracker("random")}
+	})
+	RegisterTabletPicker("latency", func() TabletPicker {
+		return &latencyPicker{newTabletTracker("latency")}
+	})
+	RegisterTabletPicker("least_outstanding", func() TabletPicker {
+		return &leastOutstandingPicker{newTabletTracker("least_outstanding")}
+	})
+}
+
+//
+// TabletPickerStatus definitions
+//
+
+// TabletPickerStatus is the status of a TabletPicker.
+type TabletPickerStatus struct {
+	Policy  string
+	Tablets TabletPickerTabletStatusList
+}
+
+// TabletPickerTabletStatus contains the statistics a TabletPicker
+// keeps for one tablet.
+type TabletPickerTabletStatus struct {
+	Keyspace    string
+	Shard       string
+	Name        string
+	Key         string
+	Outstanding int64
+	QueryCount  uint64
+	Latency     float64 // in milliseconds
+	ErrorRate   float64
+}
+
+// TabletPickerTabletStatusList is a slice of TabletPickerTabletStatus.
+type TabletPickerTabletStatusList []*TabletPickerTabletStatus
+
+// Len is part of sort.Interface.
+func (tl TabletPickerTabletStatusList) Len() int {
+	return len(tl)
+}
+
+// Less is part of sort.Interface.
+func (tl TabletPickerTabletStatusList) Less(i, j int) bool {
+	if tl[i].Keyspace != tl[j].Keyspace {
+		return tl[i].Keyspace < tl[j].Keyspace
+	}
+	if tl[i].Shard != tl[j].Shard {
+		return tl[i].Shard < tl[j].Shard
+	}
+	return tl[i].Name < tl[j].Name
+}
+
+// Swap is part of sort.Interface.
+func (tl TabletPickerTabletStatusList) Swap(i, j int) {
+	tl[i], tl[j] = tl[j], tl[i]
+}
+
+//
+// tabletTracker definitions
+//
+
+// tabletStats is what a tabletTracker records for one tablet.
+type tabletStats struct {
+	keyspace    string
+	shard       string
+	name        string
+	outstanding int64
+	queryCount  uint64
+	// latency is in milliseconds. hasLatency is false until
+	// a non streaming query succeeds on the tablet.
+	latency    *ewma.EWMA
+	hasLatency bool
+	errorRate  *ewma.EWMA
+}
+
+// tabletTracker keeps the per tablet statistics
+// shared by all TabletPicker implementations.
+type tabletTracker struct {
+	policy string
+
+	// mu protects tablets and its contents.
+	mu      sync.Mutex
+	tablets map[string]*tabletStats
+}
+
+func newTabletTracker(policy string) *tabletTracker {
+	return &tabletTracker{
+		policy:  policy,
+		tablets: make(map[string]*tabletStats),
+	}
+}
+
+// getLocked returns the stats of the tablet, creating them if needed.
+// mu must be held.
+func (tt *tabletTracker) getLocked(ts *discovery.TabletStats) *tabletStats {
+	st, ok := tt.tablets[ts.Key]
+	if !ok {
+		st = &tabletStats{
+			keyspace:  ts.Target.Keyspace,
+			shard:     ts.Target.Shard,
+			name:      topoproto.TabletAliasString(ts.Tablet.Alias),
+			latency:   ewma.NewEWMA(ewma.DefaultWeightingFactor),
+			errorRate: ewma.NewEWMA(ewma.DefaultWeightingFactor),
+		}
+		tt.tablets[ts.Key] = st
+	}
+	return st
+}
+
+// QueryStart is part of the TabletPicker interface.
+func (tt *tabletTracker) QueryStart(ts *discovery.TabletStats) {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	st := tt.getLocked(ts)
+	st.outstanding++
+	st.queryCount++
+}
+
+// QueryEnd is part of the TabletPicker interface.
+func (tt *tabletTracker) QueryEnd(ts *discovery.TabletStats, elapsed time.Duration, isStreaming bool, err error) {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	st, ok := tt.tablets[ts.Key]
+	if !ok {
+		// The tablet was removed while the query was in flight.
+		return
+	}
+	st.outstanding--
+	if isTabletError(err) {
+		// Errors are usually fast and would make a
+		// failing tablet look good: skip their latency.
+		st.errorRate.AddValue(1)
+		return
+	}
+	st.errorRate.AddValue(0)
+	if !isStreaming {
+		st.latency.AddValue(float64(elapsed.Nanoseconds()) / 1e6)
+		st.hasLatency = true
+	}
+}
+
+// StatsUpdate is part of the TabletPicker interface.
+func (tt *tabletTracker) StatsUpdate(ts *discovery.TabletStats) {
+	if ts.Up {
+		return
+	}
+	// The tablet is removed, or changes type. In the latter
+	// case, its stats start over with the new type.
+	tt.mu.Lock()
+	delete(tt.tablets, ts.Key)
+	tt.mu.Unlock()
+}
+
+// Status is part of the TabletPicker interface.
+func (tt *tabletTracker) Status() *TabletPickerStatus {
+	status := &TabletPickerStatus{Policy: tt.policy}
+	tt.mu.Lock()
+	for key, st := range tt.tablets {
+		status.Tablets = append(status.Tablets, &TabletPickerTabletStatus{
+			Keyspace:    st.keyspace,
+			Shard:       st.shard,
+			Name:        st.name,
+			Key:         key,
+			Outstanding: st.outstanding,
+			QueryCount:  st.queryCount,
+			Latency:     st.latency.GetEWMA(),
+			ErrorRate:   st.errorRate.GetEWMA(),
+		})
+	}
+	tt.mu.Unlock()
+	sort.Sort(status.Tablets)
+	return status
+}
+
+// isTabletError returns true if err is to be blamed on the tablet,
+// as opposed to the query itself.
+func isTabletError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch vterrors.RecoverVtErrorCode(err) {
+	case vtrpcpb.ErrorCode_INTEGRITY_ERROR, vtrpcpb.ErrorCode_BAD_INPUT:
+		return false
+	}
+	return true
+}
+
+//
+// TabletPicker implementations
+//
+
+// randomPicker spreads the queries evenly across the tablets.
+type randomPicker struct {
+	*tabletTracker
+}
+
+// Sort is part of the TabletPicker interface.
+func (rp *randomPicker) Sort(tablets []discovery.TabletStats) {
+	shuffleTablets(tablets)
+}
+
+// latencyPicker sends queries to the tablets with a probability
+// inversely proportional to their average latency, and reduced
+// by their error rate. Tablets that have no latency sample yet
+// are given the median latency of the others, so they get probed
+// without taking all the traffic.
+type latencyPicker struct {
+	*tabletTracker
+}
+
+// minLatency avoids giving an infinite weight to a tablet.
+const minLatency = 0.1
+
+// maxErrorRate keeps a failing tablet probed, so
+// it can recover once it stops failing.
+const maxErrorRate = 0.99
+
+// Sort is part of the TabletPicker interface.
+func (lp *latencyPicker) Sort(tablets []discovery.TabletStats) {
+	latencies := make([]float64, len(tablets))
+	hasLatency := make([]bool, len(tablets))
+	errorRates := make([]float64, len(tablets))
+	var sampled []float64
+	lp.mu.Lock()
+	for i, ts := range tablets {
+		st, ok := lp.tablets[ts.Key]
+		if !ok {
+			continue
+		}
+		errorRates[i] = st.errorRate.GetEWMA()
+		if st.hasLatency {
+			latencies[i] = st.latency.GetEWMA()
+			hasLatency[i] = true
+			sampled = append(sampled, latencies[i])
+		}
+	}
+	lp.mu.Unlock()
+
+	median := 1.0
+	if len(sampled) > 0 {
+		sort.Float64s(sampled)
+		median = (sampled[(len(sampled)-1)/2] + sampled[len(sampled)/2]) / 2
+	}
+	weights := make([]float64, len(tablets))
+	for i := range tablets {
+		latency := latencies[i]
+		if !hasLatency[i] {
+			latency = median
+		}
+		if latency < minLatency {
+			latency = minLatency
+		}
+		errorRate := errorRates[i]
+		if errorRate > maxErrorRate {
+			errorRate = maxErrorRate
+		}
+		weights[i] = (1 - errorRate) / latency
+	}
+	weightedShuffleTablets(tablets, weights)
+}
+
+// weightedShuffleTablets orders the tablets randomly, with
+// the probability for a tablet to come first proportional
+// to its weight.
+func weightedShuffleTablets(tablets []discovery.TabletStats, weights []float64) {
+	for i := 0; i < len(tablets)-1; i++ {
+		total := 0.0
+		for _, w := range weights[i:] {
+			total += w
+		}
+		r := rand.Float64() * total
+		j := i
+		for ; j < len(tablets)-1; j++ {
+			r -= weights[j]
+			if r < 0 {
+				break
+			}
+		}
+		tablets[i], tablets[j] = tablets[j], tablets[i]
+		weights[i], weights[j] = weights[j], weights[i]
+	}
+}
+
+// leastOutstandingPicker sends queries to the tablets
+// that have the fewest queries in flight. Ties are
+// broken randomly.
+type leastOutstandingPicker struct {
+	*tabletTracker
+}
+
+// Sort is part of the TabletPicker interface.
+func (lop *leastOutstandingPicker) Sort(tablets []discovery.TabletStats) {
+	shuffleTablets(tablets)
+	outstanding := make(map[string]int64, len(tablets))
+	lop.mu.Lock()
+	for _, ts := range tablets {
+		if st, ok := lop.tablets[ts.Key]; ok {
+			outstanding[ts.Key] = st.outstanding
+		}
+	}
+	lop.mu.Unlock()
+	sort.Stable(byOutstanding{tablets, outstanding})
+}
+
+type byOutstanding struct {
+	tablets     []discovery.TabletStats
+	outstanding map[string]int64
+}
+
+func (bo byOutstanding) Len() int {
+	return len(bo.tablets)
+}
+
+func (bo byOutstanding) Less(i, j int) bool {
+	return bo.outstanding[bo.tablets[i].Key] < bo.outstanding[bo.tablets[j].Key]
+}
+
+func (bo byOutstanding) Swap(i, j int) {
+	bo.tablets[i], bo.tablets[j] = bo.tablets[j], bo.tablets[i]
+}
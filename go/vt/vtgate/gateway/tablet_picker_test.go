@@ -0,0 +1,200 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"testing"
+	"time"
+
+	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/vterrors"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	vtrpcpb "github.com/gitql/vitess/go/vt/proto/vtrpc"
+)
+
+func newPickerTestTablets(n int) []discovery.TabletStats {
+	tablets := make([]discovery.TabletStats, n)
+	for i := range tablets {
+		tablets[i] = discovery.TabletStats{
+			Key:    fmt.Sprintf("t%d", i),
+			Tablet: topo.NewTablet(uint32(i), "cell", fmt.Sprintf("host%d", i)),
+			Target: &querypb.Target{Keyspace: "ks", Shard: "0"},
+		}
+	}
+	return tablets
+}
+
+func TestLeastOutstandingPicker(t *testing.T) {
+	picker := tabletPickerCreators["least_outstanding"]()
+	tablets := newPickerTestTablets(3)
+	picker.QueryStart(&tablets[0])
+	picker.QueryStart(&tablets[0])
+	picker.QueryStart(&tablets[2])
+
+	for i := 0; i < 10; i++ {
+		picker.Sort(tablets)
+		if tablets[0].Key != "t1" || tablets[1].Key != "t2" || tablets[2].Key != "t0" {
+			t.Fatalf("Sort: %v %v %v, want t1 t2 t0", tablets[0].Key, tablets[1].Key, tablets[2].Key)
+		}
+	}
+
+	// Once t0 is done, it's tied with t1.
+	for _, ts := range tablets {
+		if ts.Key == "t0" {
+			picker.QueryEnd(&ts, time.Millisecond, false, nil)
+			picker.QueryEnd(&ts, time.Millisecond, false, nil)
+		}
+	}
+	picker.Sort(tablets)
+	if tablets[2].Key != "t2" {
+		t.Errorf("Sort: %v is last, want t2", tablets[2].Key)
+	}
+}
+
+func TestLatencyPicker(t *testing.T) {
+	picker := tabletPickerCreators["latency"]()
+	tablets := newPickerTestTablets(4)
+	latencies := map[string]time.Duration{
+		"t0": 100 * time.Millisecond,
+		"t1": time.Millisecond,
+		"t2": time.Millisecond,
+	}
+	for _, ts := range tablets[:3] {
+		for i := 0; i < 10; i++ {
+			picker.QueryStart(&ts)
+			picker.QueryEnd(&ts, latencies[ts.Key], false, nil)
+		}
+	}
+
+	// t0 is 100 times slower than the others. t3 has no
+	// history, so it's given the median latency, 1ms.
+	first := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		picker.Sort(tablets)
+		first[tablets[0].Key]++
+	}
+	if first["t0"] > 50 || first["t1"] < 200 || first["t2"] < 200 || first["t3"] < 200 {
+		t.Errorf("first picks: %v, want t1, t2 and t3 to dominate", first)
+	}
+
+	// A tablet that only served streaming queries has no latency
+	// sample either: it doesn't take all the traffic.
+	picker = tabletPickerCreators["latency"]()
+	tablets = newPickerTestTablets(3)
+	for i := 0; i < 10; i++ {
+		picker.QueryStart(&tablets[0])
+		picker.QueryEnd(&tablets[0], 10*time.Millisecond, false, nil)
+		picker.QueryStart(&tablets[1])
+		picker.QueryEnd(&tablets[1], 10*time.Millisecond, false, nil)
+		picker.QueryStart(&tablets[2])
+		picker.QueryEnd(&tablets[2], time.Hour, true, nil)
+	}
+	first = make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		picker.Sort(tablets)
+		first[tablets[0].Key]++
+	}
+	if first["t2"] > 500 {
+		t.Errorf("first picks: %v, want t2 to get about a third", first)
+	}
+
+	// A failing tablet loses its share of the traffic,
+	// even if it fails fast. Application errors don't count.
+	picker = tabletPickerCreators["latency"]()
+	tablets = newPickerTestTablets(2)
+	for i := 0; i < 20; i++ {
+		picker.QueryStart(&tablets[0])
+		picker.QueryEnd(&tablets[0], time.Millisecond, false, vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, fmt.Errorf("syntax error")))
+		picker.QueryStart(&tablets[1])
+		picker.QueryEnd(&tablets[1], time.Microsecond, false, vterrors.FromError(vtrpcpb.ErrorCode_INTERNAL_ERROR, fmt.Errorf("no connection")))
+	}
+	status := picker.Status()
+	if len(status.Tablets) != 2 {
+		t.Fatalf("Status: %v, want 2 tablets", status.Tablets)
+	}
+	if got := status.Tablets[0].ErrorRate; got != 0 {
+		t.Errorf("ErrorRate of t0: %v, want 0", got)
+	}
+	if got := status.Tablets[1].ErrorRate; got < 0.9 {
+		t.Errorf("ErrorRate of t1: %v, want > 0.9", got)
+	}
+	first = make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		picker.Sort(tablets)
+		first[tablets[0].Key]++
+	}
+	if first["t0"] < 800 {
+		t.Errorf("first picks: %v, want t0 to dominate", first)
+	}
+}
+
+func TestTabletPickerStatsUpdate(t *testing.T) {
+	picker := tabletPickerCreators["least_outstanding"]()
+	tablets := newPickerTestTablets(2)
+	picker.QueryStart(&tablets[0])
+	picker.QueryStart(&tablets[1])
+
+	picker.StatsUpdate(&discovery.TabletStats{Key: "t0", Up: true})
+	if got := len(picker.Status().Tablets); got != 2 {
+		t.Fatalf("Status: %v tablets, want 2", got)
+	}
+
+	// Removed tablets are forgotten, and the queries
+	// they had in flight are ignored.
+	picker.StatsUpdate(&discovery.TabletStats{Key: "t0", Up: false})
+	picker.QueryEnd(&tablets[0], time.Millisecond, false, nil)
+	status := picker.Status()
+	if len(status.Tablets) != 1 || status.Tablets[0].Key != "t1" {
+		t.Fatalf("Status: %v, want t1 only", status.Tablets)
+	}
+}
+
+func TestTabletPickerStatus(t *testing.T) {
+	picker := tabletPickerCreators["random"]()
+	tablets := newPickerTestTablets(2)
+	picker.QueryStart(&tablets[1])
+	picker.QueryStart(&tablets[0])
+	picker.QueryEnd(&tablets[0], 2*time.Millisecond, false, nil)
+	picker.QueryStart(&tablets[0])
+	picker.QueryEnd(&tablets[0], time.Hour, true, nil)
+
+	status := picker.Status()
+	if status.Policy != "random" || len(status.Tablets) != 2 {
+		t.Fatalf("Status: %+v, want random policy with 2 tablets", status)
+	}
+	got := *status.Tablets[0]
+	want := TabletPickerTabletStatus{
+		Keyspace:   "ks",
+		Shard:      "0",
+		Name:       "cell-0000000000",
+		Key:        "t0",
+		QueryCount: 2,
+		Latency:    2,
+	}
+	if got != want {
+		t.Errorf("Status: %+v, want %+v", got, want)
+	}
+	if got := status.Tablets[1].Outstanding; got != 1 {
+		t.Errorf("Outstanding of t1: %v, want 1", got)
+	}
+
+	templ, err := template.New("").Parse(TabletPickerStatusTemplate)
+	if err != nil {
+		t.Fatalf("error parsing template: %v", err)
+	}
+	wr := &bytes.Buffer{}
+	if err := templ.Execute(wr, status); err != nil {
+		t.Fatalf("error executing template: %v", err)
+	}
+	var nilStatus *TabletPickerStatus
+	if err := templ.Execute(wr, nilStatus); err != nil {
+		t.Fatalf("error executing template: %v", err)
+	}
+}
@@ -404,8 +404,10 @@ func (conn *vtgateConn) StreamExecuteKeyspaceIds(ctx context.Context, query stri
 
 func (conn *vtgateConn) Begin(ctx context.Context, singledb bool) (interface{}, error) {
 	request := &vtgatepb.BeginRequest{
-		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
-		SingleDb: singledb,
+		CallerId:             callerid.EffectiveCallerIDFromContext(ctx),
+		SingleDb:             singledb,
+		TransactionIsolation: vtgateconn.TransactionIsolationFromContext(ctx),
+		ReadAfterWrite:       vtgateconn.ReadAfterWriteFromContext(ctx),
 	}
 	response, err := conn.c.Begin(ctx, request)
 	if err != nil {
@@ -414,14 +416,20 @@ func (conn *vtgateConn) Begin(ctx context.Context, singledb bool) (interface{},
 	return response.Session, nil
 }
 
-func (conn *vtgateConn) Commit(ctx context.Context, session interface{}, twopc bool) error {
+func (conn *vtgateConn) Commit(ctx context.Context, session interface{}, twopc bool) (interface{}, error) {
 	request := &vtgatepb.CommitRequest{
 		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
 		Session:  session.(*vtgatepb.Session),
 		Atomic:   twopc,
 	}
-	_, err := conn.c.Commit(ctx, request)
-	return vterrors.FromGRPCError(err)
+	response, err := conn.c.Commit(ctx, request)
+	if err != nil {
+		return nil, vterrors.FromGRPCError(err)
+	}
+	if response.Session == nil {
+		return nil, nil
+	}
+	return response.Session, nil
 }
 
 func (conn *vtgateConn) Rollback(ctx context.Context, session interface{}) error {
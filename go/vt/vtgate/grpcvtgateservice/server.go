@@ -353,6 +353,8 @@ func (vtg *VTGate) Begin(ctx context.Context, request *vtgatepb.BeginRequest) (r
 	ctx = withCallerIDContext(ctx, request.CallerId)
 	session, vtgErr := vtg.server.Begin(ctx, request.SingleDb)
 	if vtgErr == nil {
+		session.TransactionIsolation = request.TransactionIsolation
+		session.ReadAfterWrite = request.ReadAfterWrite
 		return &vtgatepb.BeginResponse{
 			Session: session,
 		}, nil
@@ -366,6 +368,9 @@ func (vtg *VTGate) Commit(ctx context.Context, request *vtgatepb.CommitRequest)
 	ctx = withCallerIDContext(ctx, request.CallerId)
 	vtgErr := vtg.server.Commit(ctx, request.Atomic, request.Session)
 	response = &vtgatepb.CommitResponse{}
+	if request.Session != nil && request.Session.ReadAfterWrite {
+		response.Session = request.Session
+	}
 	if vtgErr == nil {
 		return response, nil
 	}
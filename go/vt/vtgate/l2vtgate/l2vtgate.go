@@ -109,3 +109,9 @@ func (l *L2VTGate) endAction(startTime time.Time, statsKey []string, err *error)
 func (l *L2VTGate) GetGatewayCacheStatus() gateway.TabletCacheStatusList {
 	return l.gateway.CacheStatus()
 }
+
+// GetGatewayTabletPickerStatus returns a displayable version of
+// the statistics the Gateway uses to choose tablets.
+func (l *L2VTGate) GetGatewayTabletPickerStatus() *gateway.TabletPickerStatus {
+	return l.gateway.TabletPickerStatus()
+}
@@ -92,7 +92,9 @@ func init() {
 }
 
 func TestPlan(t *testing.T) {
-	vschema := loadSchema(t, "schema_test.json")
+	vschema := &vschemaWrapper{
+		v: loadSchema(t, "schema_test.json"),
+	}
 
 	testFile(t, "from_cases.txt", vschema)
 	testFile(t, "filter_cases.txt", vschema)
@@ -103,8 +105,22 @@ func TestPlan(t *testing.T) {
 	testFile(t, "unsupported_cases.txt", vschema)
 }
 
+func TestPlanWithColumns(t *testing.T) {
+	vschema := &vschemaWrapper{
+		v: loadSchema(t, "schema_test.json"),
+		columns: map[string][]string{
+			"user":       {"id", "name"},
+			"user_extra": {"user_id", "extra_id"},
+			"music":      {"user_id", "id"},
+		},
+	}
+	testFile(t, "schema_cases.txt", vschema)
+}
+
 func TestOne(t *testing.T) {
-	vschema := loadSchema(t, "schema_test.json")
+	vschema := &vschemaWrapper{
+		v: loadSchema(t, "schema_test.json"),
+	}
 	testFile(t, "onecase.txt", vschema)
 }
 
@@ -122,17 +138,30 @@ func loadSchema(t *testing.T, filename string) *vindexes.VSchema {
 
 type vschemaWrapper struct {
 	v *vindexes.VSchema
+	// columns, if set, simulates the columns
+	// vtgate learns from the tablets.
+	columns map[string][]string
 }
 
 func (vw *vschemaWrapper) Find(ks, tab sqlparser.TableIdent) (*vindexes.Table, error) {
-	return vw.v.Find(ks.String(), tab.String())
+	table, err := vw.v.Find(ks.String(), tab.String())
+	if err != nil {
+		return nil, err
+	}
+	cols, ok := vw.columns[tab.String()]
+	if !ok {
+		return table, nil
+	}
+	withColumns := *table
+	for _, col := range cols {
+		withColumns.Columns = append(withColumns.Columns, sqlparser.NewColIdent(col))
+	}
+	return &withColumns, nil
 }
 
-func testFile(t *testing.T, filename string, vschema *vindexes.VSchema) {
+func testFile(t *testing.T, filename string, vschema VSchema) {
 	for tcase := range iterateExecFile(filename) {
-		plan, err := Build(tcase.input, &vschemaWrapper{
-			v: vschema,
-		})
+		plan, err := Build(tcase.input, vschema)
 		var out string
 		if err != nil {
 			out = err.Error()
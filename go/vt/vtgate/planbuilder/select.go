@@ -179,23 +179,33 @@ func checkAggregates(sel *sqlparser.Select, bldr builder) error {
 // pusheSelectRoutes is a convenience function that pushes all the select
 // expressions and returns the list of colsyms generated for it.
 func pushSelectRoutes(selectExprs sqlparser.SelectExprs, bldr builder) ([]*colsym, error) {
-	colsyms := make([]*colsym, len(selectExprs))
-	for i, node := range selectExprs {
+	colsyms := make([]*colsym, 0, len(selectExprs))
+	for _, node := range selectExprs {
 		switch node := node.(type) {
 		case *sqlparser.NonStarExpr:
-			rb, err := findRoute(node.Expr, bldr)
-			if err != nil {
-				return nil, err
-			}
-			colsyms[i], _, err = bldr.PushSelect(node, rb)
+			colsym, err := pushSelectRoute(node, bldr)
 			if err != nil {
 				return nil, err
 			}
+			colsyms = append(colsyms, colsym)
 		case *sqlparser.StarExpr:
 			// We'll allow select * for simple routes.
 			rb, ok := bldr.(*route)
 			if !ok {
-				return nil, errors.New("unsupported: '*' expression in complex join")
+				// For complex joins, the '*' can be expanded
+				// if we know the columns of the tables.
+				exprs, err := expandStar(node, bldr.Symtab())
+				if err != nil {
+					return nil, err
+				}
+				for _, expr := range exprs {
+					colsym, err := pushSelectRoute(expr, bldr)
+					if err != nil {
+						return nil, err
+					}
+					colsyms = append(colsyms, colsym)
+				}
+				continue
 			}
 			// Validate keyspace reference if any.
 			if !node.TableName.IsEmpty() {
@@ -207,7 +217,7 @@ func pushSelectRoutes(selectExprs sqlparser.SelectExprs, bldr builder) ([]*colsy
 			}
 			// We can push without validating the reference because
 			// MySQL will fail if it's invalid.
-			colsyms[i] = rb.PushStar(node)
+			colsyms = append(colsyms, rb.PushStar(node))
 		case sqlparser.Nextval:
 			// For now, this is only supported as an implicit feature
 			// for auto_inc in inserts.
@@ -216,3 +226,43 @@ func pushSelectRoutes(selectExprs sqlparser.SelectExprs, bldr builder) ([]*colsy
 	}
 	return colsyms, nil
 }
+
+// pushSelectRoute pushes a single non-star select expression
+// into the route that can compute it.
+func pushSelectRoute(expr *sqlparser.NonStarExpr, bldr builder) (*colsym, error) {
+	rb, err := findRoute(expr.Expr, bldr)
+	if err != nil {
+		return nil, err
+	}
+	colsym, _, err := bldr.PushSelect(expr, rb)
+	return colsym, err
+}
+
+// expandStar expands a '*' expression into the list of columns
+// of the tables it references. This is possible only if the
+// columns of all those tables are known.
+func expandStar(expr *sqlparser.StarExpr, st *symtab) ([]*sqlparser.NonStarExpr, error) {
+	tables := st.tables
+	if !expr.TableName.IsEmpty() {
+		t := st.findTable(expr.TableName)
+		if t == nil {
+			return nil, fmt.Errorf("symbol %s not found", sqlparser.String(expr))
+		}
+		tables = []*tabsym{t}
+	}
+	var exprs []*sqlparser.NonStarExpr
+	for _, t := range tables {
+		if t.Columns == nil {
+			return nil, errors.New("unsupported: '*' expression in complex join")
+		}
+		for _, col := range t.Columns {
+			exprs = append(exprs, &sqlparser.NonStarExpr{
+				Expr: &sqlparser.ColName{
+					Qualifier: t.Alias,
+					Name:      col,
+				},
+			})
+		}
+	}
+	return exprs, nil
+}
@@ -70,6 +70,7 @@ func (st *symtab) AddAlias(alias *sqlparser.TableName, astName sqlparser.TableId
 		symtab:         st,
 		Keyspace:       table.Keyspace,
 		ColumnVindexes: table.ColumnVindexes,
+		Columns:        table.Columns,
 	})
 }
 
@@ -142,13 +143,21 @@ func (st *symtab) Find(col *sqlparser.ColName, autoResolve bool) (rb *route, isL
 				return rb, true, nil
 			}
 		}
-		if rb = st.searchTables(col, autoResolve); rb != nil {
+		rb, err = st.searchTables(col, autoResolve)
+		switch {
+		case err != nil:
+			return nil, false, err
+		case rb != nil:
 			return rb, true, nil
 		}
 		return nil, false, fmt.Errorf("symbol %s not found", sqlparser.String(col))
 	}
 
-	if rb = st.searchTables(col, autoResolve); rb != nil {
+	rb, err = st.searchTables(col, autoResolve)
+	switch {
+	case err != nil:
+		return nil, false, err
+	case rb != nil:
 		return rb, true, nil
 	}
 	if st.Outer == nil {
@@ -179,23 +188,51 @@ func (st *symtab) searchColsyms(col *sqlparser.ColName) (rb *route, err error) {
 	return nil, nil
 }
 
-func (st *symtab) searchTables(col *sqlparser.ColName, autoResolve bool) *route {
+func (st *symtab) searchTables(col *sqlparser.ColName, autoResolve bool) (*route, error) {
 	if col.Qualifier.IsEmpty() {
-		if autoResolve && len(st.tables) == 1 {
+		if !autoResolve {
+			return nil, nil
+		}
+		if len(st.tables) == 1 {
 			col.Metadata = st.tables[0]
-			return st.tables[0].Route()
+			return st.tables[0].Route(), nil
 		}
-		return nil
+		return st.searchColumns(col)
 	}
 	// TODO(sougou): this search should ideally match the search
 	// style provided by VSchema, where the default keyspace must be
 	// used if one is not provided.
 	alias := st.findTable(col.Qualifier)
 	if alias == nil {
-		return nil
+		return nil, nil
 	}
 	col.Metadata = alias
-	return alias.Route()
+	return alias.Route(), nil
+}
+
+// searchColumns resolves an unqualified column reference by looking
+// for it in the list of columns of each table. The search is performed
+// only if the columns of all the tables are known. Otherwise, the
+// column could belong to a table we know nothing about.
+func (st *symtab) searchColumns(col *sqlparser.ColName) (*route, error) {
+	var found *tabsym
+	for _, t := range st.tables {
+		if t.Columns == nil {
+			return nil, nil
+		}
+		if !t.HasColumn(col.Name) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("ambiguous symbol reference: %v", sqlparser.String(col))
+		}
+		found = t
+	}
+	if found == nil {
+		return nil, nil
+	}
+	col.Metadata = found
+	return found.Route(), nil
 }
 
 // Vindex returns the vindex if the expression is a plain column reference
@@ -254,6 +291,10 @@ type tabsym struct {
 	symtab         *symtab
 	Keyspace       *vindexes.Keyspace
 	ColumnVindexes []*vindexes.ColumnVindex
+	// Columns is the list of columns of the table, if known.
+	// It's used for expanding '*' expressions and for resolving
+	// unqualified column references in joins.
+	Columns []sqlparser.ColIdent
 }
 
 func (t *tabsym) newColRef(col *sqlparser.ColName) colref {
@@ -281,6 +322,16 @@ func (t *tabsym) FindVindex(name sqlparser.ColIdent) vindexes.Vindex {
 	return nil
 }
 
+// HasColumn returns true if the table is known to have the column.
+func (t *tabsym) HasColumn(name sqlparser.ColIdent) bool {
+	for _, col := range t.Columns {
+		if col.Equal(name) {
+			return true
+		}
+	}
+	return false
+}
+
 // colsym contains symbol info about a select expression. Just like
 // a tabsym, colsym also contains a backpointer to the symtab,
 // and a pointer to the route that would compute or fetch this value.
@@ -21,6 +21,7 @@ import (
 
 	"github.com/gitql/vitess/go/acl"
 	"github.com/gitql/vitess/go/cache"
+	"github.com/gitql/vitess/go/vt/discovery"
 	"github.com/gitql/vitess/go/vt/sqlparser"
 	"github.com/gitql/vitess/go/vt/topo"
 	"github.com/gitql/vitess/go/vt/vtgate/engine"
@@ -40,6 +41,7 @@ type Planner struct {
 	normalize    bool
 	plans        *cache.LRUCache
 	vschemaStats *VSchemaStats
+	schemaCache  *discovery.SchemaCache
 }
 
 // VSchemaStats contains a rollup of the VSchema stats.
@@ -153,10 +155,34 @@ func NewPlanner(ctx context.Context, serv topo.SrvTopoServer, cell string, cache
 	plannerOnce.Do(func() {
 		http.Handle("/debug/query_plans", plr)
 		http.Handle("/debug/vschema", plr)
+		http.Handle("/debug/keyspace_schema", plr)
 	})
 	return plr
 }
 
+// SetSchemaCache makes the planner use the table schemas published
+// by the tablets to expand '*' expressions and resolve columns.
+// The plans are recomputed every time a keyspace schema changes.
+func (plr *Planner) SetSchemaCache(sc *discovery.SchemaCache) {
+	if sc == nil {
+		return
+	}
+	plr.mu.Lock()
+	plr.schemaCache = sc
+	plr.mu.Unlock()
+	sc.AddListener(func(keyspace string) {
+		plr.plans.Clear()
+	})
+	plr.plans.Clear()
+}
+
+// SchemaCache returns the schema cache, or nil if there is none.
+func (plr *Planner) SchemaCache() *discovery.SchemaCache {
+	plr.mu.Lock()
+	defer plr.mu.Unlock()
+	return plr.schemaCache
+}
+
 // WatchSrvVSchema watches the SrvVSchema from the topo. The function does
 // not return an error. It instead logs warnings on failure.
 // The SrvVSchema object is roll-up of all the Keyspace information,
@@ -290,8 +316,9 @@ func (plr *Planner) GetPlan(sql, keyspace string, bindvars map[string]interface{
 	}
 	if !plr.normalize {
 		plan, err := planbuilder.Build(sql, &wrappedVSchema{
-			vschema:  plr.VSchema(),
-			keyspace: sqlparser.NewTableIdent(keyspace),
+			vschema:     plr.VSchema(),
+			schemaCache: plr.SchemaCache(),
+			keyspace:    sqlparser.NewTableIdent(keyspace),
 		})
 		if err != nil {
 			return nil, err
@@ -314,8 +341,9 @@ func (plr *Planner) GetPlan(sql, keyspace string, bindvars map[string]interface{
 		return result.(*engine.Plan), nil
 	}
 	plan, err := planbuilder.BuildFromStmt(normalized, stmt, &wrappedVSchema{
-		vschema:  plr.VSchema(),
-		keyspace: sqlparser.NewTableIdent(keyspace),
+		vschema:     plr.VSchema(),
+		schemaCache: plr.SchemaCache(),
+		keyspace:    sqlparser.NewTableIdent(keyspace),
 	})
 	if err != nil {
 		return nil, err
@@ -355,6 +383,20 @@ func (plr *Planner) ServeHTTP(response http.ResponseWriter, request *http.Reques
 		buf := bytes.NewBuffer(nil)
 		json.HTMLEscape(buf, b)
 		response.Write(buf.Bytes())
+	} else if request.URL.Path == "/debug/keyspace_schema" {
+		response.Header().Set("Content-Type", "application/json; charset=utf-8")
+		var keyspaces []*discovery.KeyspaceSchema
+		if sc := plr.SchemaCache(); sc != nil {
+			keyspaces = sc.Keyspaces()
+		}
+		b, err := json.MarshalIndent(keyspaces, "", " ")
+		if err != nil {
+			response.Write([]byte(err.Error()))
+			return
+		}
+		buf := bytes.NewBuffer(nil)
+		json.HTMLEscape(buf, b)
+		response.Write(buf.Bytes())
 	} else {
 		response.WriteHeader(http.StatusNotFound)
 	}
@@ -373,13 +415,29 @@ func (plr *Planner) VSchemaStats() *VSchemaStats {
 }
 
 type wrappedVSchema struct {
-	vschema  *vindexes.VSchema
-	keyspace sqlparser.TableIdent
+	vschema     *vindexes.VSchema
+	schemaCache *discovery.SchemaCache
+	keyspace    sqlparser.TableIdent
 }
 
 func (vs *wrappedVSchema) Find(keyspace, tablename sqlparser.TableIdent) (table *vindexes.Table, err error) {
 	if keyspace.IsEmpty() {
 		keyspace = vs.keyspace
 	}
-	return vs.vschema.Find(keyspace.String(), tablename.String())
+	table, err = vs.vschema.Find(keyspace.String(), tablename.String())
+	if err != nil || vs.schemaCache == nil {
+		return table, err
+	}
+	ts := vs.schemaCache.Table(table.Keyspace.Name, table.Name.String())
+	if ts == nil {
+		return table, nil
+	}
+	// The vschema is shared. So, we return a copy
+	// that has the columns filled in.
+	withColumns := *table
+	withColumns.Columns = make([]sqlparser.ColIdent, 0, len(ts.Columns))
+	for _, col := range ts.Columns {
+		withColumns.Columns = append(withColumns.Columns, sqlparser.NewColIdent(col.Name))
+	}
+	return &withColumns, nil
 }
@@ -433,6 +433,12 @@ func (res *Resolver) GetGatewayCacheStatus() gateway.TabletCacheStatusList {
 	return res.scatterConn.GetGatewayCacheStatus()
 }
 
+// GetGatewayTabletPickerStatus returns a displayable version of
+// the statistics the Gateway uses to choose tablets.
+func (res *Resolver) GetGatewayTabletPickerStatus() *gateway.TabletPickerStatus {
+	return res.scatterConn.GetGatewayTabletPickerStatus()
+}
+
 // StrsEquals compares contents of two string slices.
 func StrsEquals(a, b []string) bool {
 	if len(a) != len(b) {
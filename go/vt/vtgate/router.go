@@ -8,6 +8,7 @@ import (
 	"errors"
 
 	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/sqlparser"
 	"github.com/gitql/vitess/go/vt/topo"
 	"golang.org/x/net/context"
 
@@ -42,6 +43,13 @@ func (rtr *Router) Execute(ctx context.Context, sql string, bindVars map[string]
 	if bindVars == nil {
 		bindVars = make(map[string]interface{})
 	}
+	if stmt := sqlparser.ParseSavepoint(sql); stmt != nil {
+		// Savepoints apply to all the shards of the transaction.
+		if err := rtr.scatterConn.txConn.Savepoint(ctx, NewSafeSession(session), stmt); err != nil {
+			return nil, err
+		}
+		return &sqltypes.Result{}, nil
+	}
 	vcursor := newQueryExecutor(ctx, tabletType, session, options, rtr)
 	queryConstruct := queryinfo.NewQueryConstruct(sql, keyspace, bindVars, notInTransaction)
 	plan, err := rtr.planner.GetPlan(sql, keyspace, bindVars)
@@ -6,10 +6,13 @@ package vtgate
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/gitql/vitess/go/vt/sqlparser"
 	"github.com/gitql/vitess/go/vt/vterrors"
 
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
 	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
 	vtgatepb "github.com/gitql/vitess/go/vt/proto/vtgate"
 	vtrpcpb "github.com/gitql/vitess/go/vt/proto/vtrpc"
@@ -98,4 +101,149 @@ func (session *SafeSession) Reset() {
 	defer session.mu.Unlock()
 	session.Session.InTransaction = false
 	session.ShardSessions = nil
+	session.TransactionIsolation = querypb.ExecuteOptions_DEFAULT
+	session.Savepoints = nil
+}
+
+// ReadAfterWrite returns true if the session asked for reads
+// on non-master tablets to see its previous writes.
+func (session *SafeSession) ReadAfterWrite() bool {
+	if session == nil || session.Session == nil {
+		return false
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.Session.ReadAfterWrite
+}
+
+// ShardPosition returns the last known master position for a shard,
+// or "" if there is none.
+func (session *SafeSession) ShardPosition(keyspace, shard string) string {
+	if session == nil || session.Session == nil {
+		return ""
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	for _, sp := range session.ShardPositions {
+		if keyspace == sp.Keyspace && shard == sp.Shard {
+			return sp.Position
+		}
+	}
+	return ""
+}
+
+// SetShardPosition records the master position for a shard.
+// It is a no-op if the session didn't ask for read after write.
+func (session *SafeSession) SetShardPosition(keyspace, shard, position string) {
+	if session == nil || session.Session == nil || position == "" {
+		return
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if !session.Session.ReadAfterWrite {
+		return
+	}
+	for _, sp := range session.ShardPositions {
+		if keyspace == sp.Keyspace && shard == sp.Shard {
+			sp.Position = position
+			return
+		}
+	}
+	session.ShardPositions = append(session.ShardPositions, &vtgatepb.Session_ShardPosition{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Position: position,
+	})
+}
+
+// BeginOptions returns the options to use when a shard joins the
+// transaction. The isolation level of the session is used,
+// unless the options already specify one. If the session asked
+// for read after write, the masters are asked to return their
+// position when the transaction is committed.
+func (session *SafeSession) BeginOptions(options *querypb.ExecuteOptions) *querypb.ExecuteOptions {
+	if session == nil || session.Session == nil {
+		return options
+	}
+	session.mu.Lock()
+	isolation := session.Session.TransactionIsolation
+	readAfterWrite := session.Session.ReadAfterWrite
+	session.mu.Unlock()
+	setIsolation := isolation != querypb.ExecuteOptions_DEFAULT && (options == nil || options.TransactionIsolation == querypb.ExecuteOptions_DEFAULT)
+	if !setIsolation && !readAfterWrite {
+		return options
+	}
+	beginOptions := &querypb.ExecuteOptions{}
+	if options != nil {
+		*beginOptions = *options
+	}
+	if setIsolation {
+		beginOptions.TransactionIsolation = isolation
+	}
+	if readAfterWrite {
+		beginOptions.IncludePosition = true
+	}
+	return beginOptions
+}
+
+// SavepointNames returns a copy of the savepoints currently set
+// in the transaction, oldest first.
+func (session *SafeSession) SavepointNames() []string {
+	if session == nil || session.Session == nil {
+		return nil
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if len(session.Savepoints) == 0 {
+		return nil
+	}
+	return append([]string(nil), session.Savepoints...)
+}
+
+// HasSavepoint returns true if the named savepoint is set.
+// Like in MySQL, savepoint names are case insensitive.
+func (session *SafeSession) HasSavepoint(name string) bool {
+	if session == nil || session.Session == nil {
+		return false
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.findSavepoint(name) >= 0
+}
+
+// UpdateSavepoints records the effect of a savepoint statement.
+// Setting an existing savepoint moves it to the end of the list,
+// rolling back to a savepoint drops the ones set after it, and
+// releasing a savepoint also drops the savepoint itself.
+func (session *SafeSession) UpdateSavepoints(action, name string) error {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	i := session.findSavepoint(name)
+	switch action {
+	case sqlparser.SavepointStr:
+		if i >= 0 {
+			session.Savepoints = append(session.Savepoints[:i], session.Savepoints[i+1:]...)
+		}
+		session.Savepoints = append(session.Savepoints, name)
+		return nil
+	case sqlparser.RollbackToStr, sqlparser.ReleaseStr:
+		if i < 0 {
+			return vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, fmt.Errorf("savepoint %s does not exist", name))
+		}
+		if action == sqlparser.RollbackToStr {
+			i++
+		}
+		session.Savepoints = session.Savepoints[:i]
+		return nil
+	}
+	return vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, fmt.Errorf("unexpected savepoint action: %s", action))
+}
+
+func (session *SafeSession) findSavepoint(name string) int {
+	for i, sp := range session.Savepoints {
+		if strings.EqualFold(sp, name) {
+			return i
+		}
+	}
+	return -1
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/gitql/vitess/go/sqltypes"
 	"github.com/gitql/vitess/go/stats"
 	"github.com/gitql/vitess/go/vt/concurrency"
+	"github.com/gitql/vitess/go/vt/sqlannotation"
+	"github.com/gitql/vitess/go/vt/sqlparser"
 	"github.com/gitql/vitess/go/vt/tabletserver/querytypes"
 	"github.com/gitql/vitess/go/vt/topo/topoproto"
 	"github.com/gitql/vitess/go/vt/vterrors"
@@ -31,8 +33,11 @@ import (
 type ScatterConn struct {
 	timings              *stats.MultiTimings
 	tabletCallErrorCount *stats.MultiCounters
-	txConn               *TxConn
-	gateway              gateway.Gateway
+	// readAfterWriteFallbacks counts the reads sent to the master
+	// because no tablet had caught up with the session position.
+	readAfterWriteFallbacks *stats.MultiCounters
+	txConn                  *TxConn
+	gateway                 gateway.Gateway
 }
 
 // shardActionFunc defines the contract for a shard action
@@ -55,14 +60,17 @@ type shardActionTransactionFunc func(target *querypb.Target, shouldBegin bool, t
 // NewScatterConn creates a new ScatterConn.
 func NewScatterConn(statsName string, txConn *TxConn, gw gateway.Gateway) *ScatterConn {
 	tabletCallErrorCountStatsName := ""
+	readAfterWriteFallbacksStatsName := ""
 	if statsName != "" {
 		tabletCallErrorCountStatsName = statsName + "ErrorCount"
+		readAfterWriteFallbacksStatsName = statsName + "ReadAfterWriteFallbacks"
 	}
 	return &ScatterConn{
-		timings:              stats.NewMultiTimings(statsName, []string{"Operation", "Keyspace", "ShardName", "DbType"}),
-		tabletCallErrorCount: stats.NewMultiCounters(tabletCallErrorCountStatsName, []string{"Operation", "Keyspace", "ShardName", "DbType"}),
-		txConn:               txConn,
-		gateway:              gw,
+		timings:                 stats.NewMultiTimings(statsName, []string{"Operation", "Keyspace", "ShardName", "DbType"}),
+		tabletCallErrorCount:    stats.NewMultiCounters(tabletCallErrorCountStatsName, []string{"Operation", "Keyspace", "ShardName", "DbType"}),
+		readAfterWriteFallbacks: stats.NewMultiCounters(readAfterWriteFallbacksStatsName, []string{"Keyspace", "ShardName", "DbType"}),
+		txConn:                  txConn,
+		gateway:                 gw,
 	}
 }
 
@@ -118,13 +126,13 @@ func (stc *ScatterConn) Execute(
 			var innerqr *sqltypes.Result
 			if shouldBegin {
 				var err error
-				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, target, query, bindVars, options)
+				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, target, query, bindVars, session.BeginOptions(options))
 				if err != nil {
 					return transactionID, err
 				}
 			} else {
 				var err error
-				innerqr, err = stc.gateway.Execute(ctx, target, query, bindVars, transactionID, options)
+				innerqr, err = stc.execute(ctx, target, query, bindVars, transactionID, session, options)
 				if err != nil {
 					return transactionID, err
 				}
@@ -170,13 +178,13 @@ func (stc *ScatterConn) ExecuteMultiShard(
 			var innerqr *sqltypes.Result
 			if shouldBegin {
 				var err error
-				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, target, shardQueries[target.Shard].Sql, shardQueries[target.Shard].BindVariables, options)
+				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, target, shardQueries[target.Shard].Sql, shardQueries[target.Shard].BindVariables, session.BeginOptions(options))
 				if err != nil {
 					return transactionID, err
 				}
 			} else {
 				var err error
-				innerqr, err = stc.gateway.Execute(ctx, target, shardQueries[target.Shard].Sql, shardQueries[target.Shard].BindVariables, transactionID, options)
+				innerqr, err = stc.execute(ctx, target, shardQueries[target.Shard].Sql, shardQueries[target.Shard].BindVariables, transactionID, session, options)
 				if err != nil {
 					return transactionID, err
 				}
@@ -222,13 +230,13 @@ func (stc *ScatterConn) ExecuteEntityIds(
 
 			if shouldBegin {
 				var err error
-				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, target, sql, bindVar, options)
+				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, target, sql, bindVar, session.BeginOptions(options))
 				if err != nil {
 					return transactionID, err
 				}
 			} else {
 				var err error
-				innerqr, err = stc.gateway.Execute(ctx, target, sql, bindVar, transactionID, options)
+				innerqr, err = stc.execute(ctx, target, sql, bindVar, transactionID, session, options)
 				if err != nil {
 					return transactionID, err
 				}
@@ -242,6 +250,118 @@ func (stc *ScatterConn) ExecuteEntityIds(
 	return qr, err
 }
 
+// execute sends a single query to a tablet, outside of a BeginExecute.
+// Queries outside of a transaction honor the read after write setting
+// of the session.
+func (stc *ScatterConn) execute(ctx context.Context, target *querypb.Target, sql string, bindVars map[string]interface{}, transactionID int64, session *SafeSession, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
+	if transactionID != 0 || !session.ReadAfterWrite() {
+		return stc.gateway.Execute(ctx, target, sql, bindVars, transactionID, options)
+	}
+	var qr *sqltypes.Result
+	err := stc.readAfterWrite(target, session, options, sqlannotation.IsDML(sql), func(target *querypb.Target, options *querypb.ExecuteOptions) (string, error) {
+		var err error
+		qr, err = stc.gateway.Execute(ctx, target, sql, bindVars, 0, options)
+		return resultPosition(qr), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return qr, nil
+}
+
+// executeBatch is the batch version of execute.
+func (stc *ScatterConn) executeBatch(ctx context.Context, target *querypb.Target, queries []querytypes.BoundQuery, asTransaction bool, transactionID int64, session *SafeSession, options *querypb.ExecuteOptions) ([]sqltypes.Result, error) {
+	if transactionID != 0 || !session.ReadAfterWrite() {
+		return stc.gateway.ExecuteBatch(ctx, target, queries, asTransaction, transactionID, options)
+	}
+	isDML := false
+	for _, query := range queries {
+		if sqlannotation.IsDML(query.Sql) {
+			isDML = true
+			break
+		}
+	}
+	var qrs []sqltypes.Result
+	err := stc.readAfterWrite(target, session, options, isDML, func(target *querypb.Target, options *querypb.ExecuteOptions) (string, error) {
+		var err error
+		qrs, err = stc.gateway.ExecuteBatch(ctx, target, queries, asTransaction, 0, options)
+		// The last write has the most recent position.
+		position := ""
+		for i := range qrs {
+			if p := resultPosition(&qrs[i]); p != "" {
+				position = p
+			}
+		}
+		return position, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return qrs, nil
+}
+
+// readAfterWrite makes a call outside of a transaction, for a session
+// that asked for read after write. Calls to non-master tablets wait for
+// the last position recorded for the shard, and fall back to the master
+// if no tablet could catch up in time. Writes sent to a master record
+// the position returned by the call.
+func (stc *ScatterConn) readAfterWrite(target *querypb.Target, session *SafeSession, options *querypb.ExecuteOptions, isDML bool, call func(target *querypb.Target, options *querypb.ExecuteOptions) (string, error)) error {
+	if target.TabletType != topodatapb.TabletType_MASTER {
+		position := session.ShardPosition(target.Keyspace, target.Shard)
+		if position == "" {
+			_, err := call(target, options)
+			return err
+		}
+		waitOptions := copyOptions(options)
+		waitOptions.WaitForPosition = position
+		_, err := call(target, waitOptions)
+		if err == nil || vterrors.RecoverVtErrorCode(err) != vtrpcpb.ErrorCode_QUERY_NOT_SERVED {
+			return err
+		}
+		// None of the tablets caught up in time, the master
+		// is the only one guaranteed to have our writes.
+		stc.readAfterWriteFallbacks.Add([]string{target.Keyspace, target.Shard, topoproto.TabletTypeLString(target.TabletType)}, 1)
+		masterTarget := &querypb.Target{
+			Keyspace:   target.Keyspace,
+			Shard:      target.Shard,
+			TabletType: topodatapb.TabletType_MASTER,
+		}
+		_, err = call(masterTarget, options)
+		return err
+	}
+
+	if !isDML {
+		_, err := call(target, options)
+		return err
+	}
+	positionOptions := copyOptions(options)
+	positionOptions.IncludePosition = true
+	position, err := call(target, positionOptions)
+	if err != nil {
+		return err
+	}
+	session.SetShardPosition(target.Keyspace, target.Shard, position)
+	return nil
+}
+
+// resultPosition returns the master position of a result, if any.
+func resultPosition(qr *sqltypes.Result) string {
+	if qr == nil || qr.Extras == nil {
+		return ""
+	}
+	return qr.Extras.Position
+}
+
+// copyOptions returns a shallow copy of the options, so they can be
+// changed for a single call without affecting the caller's copy.
+func copyOptions(options *querypb.ExecuteOptions) *querypb.ExecuteOptions {
+	result := &querypb.ExecuteOptions{}
+	if options != nil {
+		*result = *options
+	}
+	return result
+}
+
 // scatterBatchRequest needs to be built to perform a scatter batch query.
 // A VTGate batch request will get translated into a different set of batches
 // for each keyspace:shard, and those results will map to different positions in the
@@ -288,9 +408,16 @@ func (stc *ScatterConn) ExecuteBatch(
 			defer stc.endAction(startTime, allErrors, statsKey, &err, session)
 
 			shouldBegin, transactionID := transactionInfo(target, session, false)
+			if shouldBegin && session.SavepointNames() != nil {
+				transactionID, err = stc.beginWithSavepoints(ctx, target, session)
+				if err != nil {
+					return
+				}
+				shouldBegin = false
+			}
 			var innerqrs []sqltypes.Result
 			if shouldBegin {
-				innerqrs, transactionID, err = stc.gateway.BeginExecuteBatch(ctx, target, req.Queries, asTransaction, options)
+				innerqrs, transactionID, err = stc.gateway.BeginExecuteBatch(ctx, target, req.Queries, asTransaction, session.BeginOptions(options))
 				if transactionID != 0 {
 					if appendErr := session.Append(&vtgatepb.Session_ShardSession{
 						Target:        target,
@@ -303,7 +430,7 @@ func (stc *ScatterConn) ExecuteBatch(
 					return
 				}
 			} else {
-				innerqrs, err = stc.gateway.ExecuteBatch(ctx, target, req.Queries, asTransaction, transactionID, options)
+				innerqrs, err = stc.executeBatch(ctx, target, req.Queries, asTransaction, transactionID, session, options)
 				if err != nil {
 					return
 				}
@@ -547,6 +674,12 @@ func (stc *ScatterConn) GetGatewayCacheStatus() gateway.TabletCacheStatusList {
 	return stc.gateway.CacheStatus()
 }
 
+// GetGatewayTabletPickerStatus returns a displayable version of
+// the statistics the Gateway uses to choose tablets.
+func (stc *ScatterConn) GetGatewayTabletPickerStatus() *gateway.TabletPickerStatus {
+	return stc.gateway.TabletPickerStatus()
+}
+
 // ScatterConnError is the ScatterConn specific error.
 // It implements vterrors.VtError.
 type ScatterConnError struct {
@@ -665,6 +798,15 @@ func (stc *ScatterConn) multiGoTransaction(
 		defer stc.endAction(startTime, allErrors, statsKey, &err, session)
 
 		shouldBegin, transactionID := transactionInfo(target, session, notInTransaction)
+		if shouldBegin && session.SavepointNames() != nil {
+			// The savepoints have to exist on this shard too,
+			// so they can be rolled back to later.
+			transactionID, err = stc.beginWithSavepoints(ctx, target, session)
+			if err != nil {
+				return
+			}
+			shouldBegin = false
+		}
 		transactionID, err = action(target, shouldBegin, transactionID)
 		if shouldBegin && transactionID != 0 {
 			if appendErr := session.Append(&vtgatepb.Session_ShardSession{
@@ -704,6 +846,31 @@ end:
 	return nil
 }
 
+// beginWithSavepoints begins a transaction on a shard that joins
+// the session after savepoints were set, and sets them again
+// on that shard, in the same order. The new transaction is
+// appended to the session even if setting the savepoints fails,
+// so it can be rolled back.
+func (stc *ScatterConn) beginWithSavepoints(ctx context.Context, target *querypb.Target, session *SafeSession) (int64, error) {
+	transactionID, err := stc.gateway.Begin(ctx, target, session.BeginOptions(nil))
+	if err != nil {
+		return 0, err
+	}
+	if err := session.Append(&vtgatepb.Session_ShardSession{
+		Target:        target,
+		TransactionId: transactionID,
+	}); err != nil {
+		return transactionID, err
+	}
+	for _, name := range session.SavepointNames() {
+		stmt := &sqlparser.Savepoint{Action: sqlparser.SavepointStr, Name: sqlparser.NewColIdent(name)}
+		if _, err := stc.gateway.Execute(ctx, target, sqlparser.String(stmt), nil, transactionID, nil); err != nil {
+			return transactionID, err
+		}
+	}
+	return transactionID, nil
+}
+
 // transactionInfo looks at the current session, and returns:
 // - shouldBegin: if we should call 'Begin' to get a transactionID
 // - transactionID: the transactionID to use, or 0 if not in a transaction.
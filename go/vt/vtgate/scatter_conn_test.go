@@ -455,6 +455,112 @@ func TestScatterConnSingleDB(t *testing.T) {
 	}
 }
 
+func TestScatterConnReadAfterWrite(t *testing.T) {
+	createSandbox("TestScatterConnReadAfterWrite")
+	hc := discovery.NewFakeHealthCheck()
+
+	hc.Reset()
+	sc := newTestScatterConn(hc, new(sandboxTopo), "aa")
+	sbcm := hc.AddTestTablet("aa", "0", 1, "TestScatterConnReadAfterWrite", "0", topodatapb.TabletType_MASTER, true, 1, nil)
+	sbcr := hc.AddTestTablet("aa", "1", 1, "TestScatterConnReadAfterWrite", "0", topodatapb.TabletType_REPLICA, true, 1, nil)
+	session := NewSafeSession(&vtgatepb.Session{ReadAfterWrite: true})
+
+	// A read without any previous write doesn't wait.
+	_, err := sc.Execute(context.Background(), "select id from t", nil, "TestScatterConnReadAfterWrite", []string{"0"}, topodatapb.TabletType_REPLICA, session, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sbcr.Options[0] != nil {
+		t.Errorf("Options: %v, want nil", sbcr.Options[0])
+	}
+
+	// A write records the master position.
+	sbcm.SetResults([]*sqltypes.Result{{
+		RowsAffected: 1,
+		Extras:       &querypb.ResultExtras{Position: "MySQL56/pos1"},
+	}})
+	_, err = sc.Execute(context.Background(), "update t set a = 1", nil, "TestScatterConnReadAfterWrite", []string{"0"}, topodatapb.TabletType_MASTER, session, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sbcm.Options[0].IncludePosition {
+		t.Errorf("IncludePosition: false, want true")
+	}
+	if got, want := session.ShardPosition("TestScatterConnReadAfterWrite", "0"), "MySQL56/pos1"; got != want {
+		t.Errorf("ShardPosition: %v, want %v", got, want)
+	}
+
+	// The next read waits for it.
+	_, err = sc.Execute(context.Background(), "select id from t", nil, "TestScatterConnReadAfterWrite", []string{"0"}, topodatapb.TabletType_REPLICA, session, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sbcr.Options[1].WaitForPosition, "MySQL56/pos1"; got != want {
+		t.Errorf("WaitForPosition: %v, want %v", got, want)
+	}
+
+	// If the replica can't catch up, the read goes to the master.
+	sbcr.MustFailRetry = 1
+	_, err = sc.Execute(context.Background(), "select id from t", nil, "TestScatterConnReadAfterWrite", []string{"0"}, topodatapb.TabletType_REPLICA, session, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(sbcm.Queries), 2; got != want {
+		t.Errorf("master queries: %d, want %d", got, want)
+	}
+	if got, want := sbcm.Queries[1].Sql, "select id from t"; got != want {
+		t.Errorf("master query: %v, want %v", got, want)
+	}
+
+	// Sessions without read after write don't record positions.
+	session = NewSafeSession(&vtgatepb.Session{})
+	_, err = sc.Execute(context.Background(), "update t set a = 1", nil, "TestScatterConnReadAfterWrite", []string{"0"}, topodatapb.TabletType_MASTER, session, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sbcm.Options[2] != nil {
+		t.Errorf("Options: %v, want nil", sbcm.Options[2])
+	}
+
+	// Batches record the position of their last write.
+	session = NewSafeSession(&vtgatepb.Session{ReadAfterWrite: true})
+	sbcm.SetResults([]*sqltypes.Result{{
+		RowsAffected: 1,
+		Extras:       &querypb.ResultExtras{Position: "MySQL56/pos2"},
+	}})
+	queries := []*vtgatepb.BoundShardQuery{{
+		Query:    &querypb.BoundQuery{Sql: "update t set a = 1"},
+		Keyspace: "TestScatterConnReadAfterWrite",
+		Shards:   []string{"0"},
+	}}
+	batchRequest, err := boundShardQueriesToScatterBatchRequest(queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.ExecuteBatch(context.Background(), batchRequest, topodatapb.TabletType_MASTER, false, session, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !sbcm.Options[3].IncludePosition {
+		t.Errorf("IncludePosition: false, want true")
+	}
+	if got, want := session.ShardPosition("TestScatterConnReadAfterWrite", "0"), "MySQL56/pos2"; got != want {
+		t.Errorf("ShardPosition: %v, want %v", got, want)
+	}
+
+	// And batch reads wait for it.
+	queries[0].Query.Sql = "select id from t"
+	batchRequest, err = boundShardQueriesToScatterBatchRequest(queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.ExecuteBatch(context.Background(), batchRequest, topodatapb.TabletType_REPLICA, false, session, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sbcr.Options[len(sbcr.Options)-1].WaitForPosition, "MySQL56/pos2"; got != want {
+		t.Errorf("WaitForPosition: %v, want %v", got, want)
+	}
+}
+
 func TestAppendResult(t *testing.T) {
 	qr := new(sqltypes.Result)
 	innerqr1 := &sqltypes.Result{
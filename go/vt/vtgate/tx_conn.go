@@ -15,6 +15,7 @@ import (
 
 	"github.com/gitql/vitess/go/vt/concurrency"
 	"github.com/gitql/vitess/go/vt/dtids"
+	"github.com/gitql/vitess/go/vt/sqlparser"
 	"github.com/gitql/vitess/go/vt/vterrors"
 	"github.com/gitql/vitess/go/vt/vtgate/gateway"
 
@@ -56,9 +57,12 @@ func (txc *TxConn) commitNormal(ctx context.Context, session *SafeSession) error
 			txc.gateway.Rollback(ctx, shardSession.Target, shardSession.TransactionId)
 			continue
 		}
-		if err = txc.gateway.Commit(ctx, shardSession.Target, shardSession.TransactionId); err != nil {
+		var position string
+		if position, err = txc.gateway.Commit(ctx, shardSession.Target, shardSession.TransactionId); err != nil {
 			committing = false
+			continue
 		}
+		session.SetShardPosition(shardSession.Target.Keyspace, shardSession.Target.Shard, position)
 	}
 	session.Reset()
 	return err
@@ -180,6 +184,34 @@ func (txc *TxConn) resumeCommit(ctx context.Context, target *querypb.Target, tra
 	return txc.gateway.ConcludeTransaction(ctx, target, transaction.Dtid)
 }
 
+// Savepoint executes a savepoint statement on all the shards
+// of the transaction, and records its effect in the session.
+// Shards that join the transaction later will get the current
+// savepoints set before their first statement. If the statement
+// fails on some shards, they don't agree on the savepoints any more,
+// and the transaction is rolled back.
+func (txc *TxConn) Savepoint(ctx context.Context, session *SafeSession, stmt *sqlparser.Savepoint) error {
+	if !session.InTransaction() {
+		return vterrors.FromError(vtrpcpb.ErrorCode_NOT_IN_TX, errors.New("savepoint: not in transaction"))
+	}
+	name := stmt.Name.String()
+	if stmt.Action != sqlparser.SavepointStr && !session.HasSavepoint(name) {
+		return vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, fmt.Errorf("savepoint %s does not exist", name))
+	}
+	sql := sqlparser.String(stmt)
+	err := txc.runSessions(session.ShardSessions, func(s *vtgatepb.Session_ShardSession) error {
+		_, err := txc.gateway.Execute(ctx, s.Target, sql, nil, s.TransactionId, nil)
+		return err
+	})
+	if err != nil {
+		if rollbackErr := txc.Rollback(ctx, session); rollbackErr != nil {
+			log.Warningf("Rollback failed after savepoint error: %v", rollbackErr)
+		}
+		return err
+	}
+	return session.UpdateSavepoints(stmt.Action, name)
+}
+
 // runSessions executes the action for all shardSessions in parallel and returns a consolildated error.
 func (txc *TxConn) runSessions(shardSessions []*vtgatepb.Session_ShardSession, action func(*vtgatepb.Session_ShardSession) error) error {
 	// Fastpath.
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/sqlparser"
 	"github.com/gitql/vitess/go/vt/tabletserver/sandboxconn"
 	"github.com/gitql/vitess/go/vt/vterrors"
 
@@ -103,6 +104,30 @@ func TestTxConnCommitSuccess(t *testing.T) {
 	}
 }
 
+func TestTxConnCommitReadAfterWrite(t *testing.T) {
+	sc, sbc0, sbc1 := newTestTxConnEnv("TestTxConn")
+	sbc0.CommitPosition = "MySQL56/pos0"
+	sbc1.CommitPosition = "MySQL56/pos1"
+
+	session := NewSafeSession(&vtgatepb.Session{InTransaction: true, ReadAfterWrite: true})
+	sc.Execute(context.Background(), "query1", nil, "TestTxConn", []string{"0", "1"}, topodatapb.TabletType_MASTER, session, false, nil)
+	if !sbc0.Options[0].IncludePosition {
+		t.Errorf("IncludePosition: false, want true")
+	}
+	if err := sc.txConn.Commit(context.Background(), false, session); err != nil {
+		t.Fatal(err)
+	}
+	for shard, want := range map[string]string{"0": "MySQL56/pos0", "1": "MySQL56/pos1"} {
+		if got := session.ShardPosition("TestTxConn", shard); got != want {
+			t.Errorf("ShardPosition(%v): %v, want %v", shard, got, want)
+		}
+	}
+	// The positions come from the commits, no other query is sent.
+	if got, want := len(sbc0.Queries), 1; got != want {
+		t.Errorf("len(sbc0.Queries): %d, want %d", got, want)
+	}
+}
+
 func TestTxConnCommit2PC(t *testing.T) {
 	sc, sbc0, sbc1 := newTestTxConnEnv("TestTxConnCommit2PC")
 
@@ -318,6 +343,66 @@ func TestTxConnRollback(t *testing.T) {
 	}
 }
 
+func TestTxConnSavepoint(t *testing.T) {
+	sc, sbc0, sbc1 := newTestTxConnEnv("TestTxConn")
+
+	session := NewSafeSession(&vtgatepb.Session{
+		InTransaction:        true,
+		TransactionIsolation: querypb.ExecuteOptions_READ_COMMITTED,
+	})
+	err := sc.txConn.Savepoint(context.Background(), session, sqlparser.ParseSavepoint("release savepoint a"))
+	want := "savepoint a does not exist"
+	if err == nil || err.Error() != want {
+		t.Errorf("Savepoint: %v, want %s", err, want)
+	}
+
+	sc.Execute(context.Background(), "query1", nil, "TestTxConn", []string{"0"}, topodatapb.TabletType_MASTER, session, false, nil)
+	if got := sbc0.Options[0].TransactionIsolation; got != querypb.ExecuteOptions_READ_COMMITTED {
+		t.Errorf("TransactionIsolation: %v, want READ_COMMITTED", got)
+	}
+	for _, sql := range []string{"savepoint a", "savepoint b"} {
+		if err := sc.txConn.Savepoint(context.Background(), session, sqlparser.ParseSavepoint(sql)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Shard 1 joins the transaction after the savepoints were set.
+	sc.Execute(context.Background(), "query2", nil, "TestTxConn", []string{"1"}, topodatapb.TabletType_MASTER, session, false, nil)
+	if err := sc.txConn.Savepoint(context.Background(), session, sqlparser.ParseSavepoint("rollback to a")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := session.SavepointNames(), []string{"a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SavepointNames: %v, want %v", got, want)
+	}
+
+	wantQueries := []string{"query1", "savepoint a", "savepoint b", "rollback to savepoint a"}
+	if got := queriesOf(sbc0); !reflect.DeepEqual(got, wantQueries) {
+		t.Errorf("sbc0.Queries: %v, want %v", got, wantQueries)
+	}
+	wantQueries = []string{"savepoint a", "savepoint b", "query2", "rollback to savepoint a"}
+	if got := queriesOf(sbc1); !reflect.DeepEqual(got, wantQueries) {
+		t.Errorf("sbc1.Queries: %v, want %v", got, wantQueries)
+	}
+
+	if err := sc.txConn.Rollback(context.Background(), session); err != nil {
+		t.Error(err)
+	}
+	if got := session.SavepointNames(); got != nil {
+		t.Errorf("SavepointNames after rollback: %v, want nil", got)
+	}
+	if got := session.TransactionIsolation; got != querypb.ExecuteOptions_DEFAULT {
+		t.Errorf("TransactionIsolation after rollback: %v, want DEFAULT", got)
+	}
+}
+
+func queriesOf(sbc *sandboxconn.SandboxConn) []string {
+	var queries []string
+	for _, q := range sbc.Queries {
+		queries = append(queries, q.Sql)
+	}
+	return queries
+}
+
 func TestTxConnResolveOnPrepare(t *testing.T) {
 	sc, sbc0, sbc1 := newTestTxConnEnv("TestTxConn")
 
@@ -31,6 +31,10 @@ type Table struct {
 	Ordered        []*ColumnVindex      `json:"ordered,omitempty"`
 	Owned          []*ColumnVindex      `json:"owned,omitempty"`
 	AutoIncrement  *AutoIncrement       `json:"auto_increment,omitempty"`
+	// Columns is the list of columns of the table. It's not part of
+	// the vschema: vtgate fills it in from the schema published by
+	// vttablet, if available. It's nil if the columns are unknown.
+	Columns []sqlparser.ColIdent `json:"columns,omitempty"`
 }
 
 // Keyspace contains the keyspcae info for each Table.
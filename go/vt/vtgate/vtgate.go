@@ -180,6 +180,7 @@ func Init(ctx context.Context, hc discovery.HealthCheck, topoServer topo.Server,
 		logUpdateStream:             logutil.NewThrottledLogger("UpdateStream", 5*time.Second),
 		logMessageStream:            logutil.NewThrottledLogger("MessageStream", 5*time.Second),
 	}
+	rpcVTGate.router.planner.SetSchemaCache(gw.SchemaCache())
 
 	normalErrors = stats.NewMultiCounters("VtgateApiErrorCounts", []string{"Operation", "Keyspace", "DbType"})
 	infoErrors = stats.NewCounters("VtgateInfoErrorCounts")
@@ -884,6 +885,12 @@ func (vtg *VTGate) GetGatewayCacheStatus() gateway.TabletCacheStatusList {
 	return vtg.resolver.GetGatewayCacheStatus()
 }
 
+// GetGatewayTabletPickerStatus returns a displayable version of
+// the statistics the Gateway uses to choose tablets.
+func (vtg *VTGate) GetGatewayTabletPickerStatus() *gateway.TabletPickerStatus {
+	return vtg.resolver.GetGatewayTabletPickerStatus()
+}
+
 // VSchemaStats returns the loaded vschema stats.
 func (vtg *VTGate) VSchemaStats() *VSchemaStats {
 	return vtg.router.planner.VSchemaStats()
@@ -7,6 +7,7 @@ package vtgateconn
 import (
 	"flag"
 	"fmt"
+	"sync"
 	"time"
 
 	log "github.com/golang/glog"
@@ -48,6 +49,41 @@ func AtomicityFromContext(ctx context.Context) Atomicity {
 	return v
 }
 
+// isolationKey is the context key for the transaction isolation level.
+type isolationKey int
+
+// WithTransactionIsolation returns a context with the isolation level
+// used by Begin set. The level applies to every shard that joins the
+// transaction.
+func WithTransactionIsolation(ctx context.Context, isolation querypb.ExecuteOptions_TransactionIsolation) context.Context {
+	return context.WithValue(ctx, isolationKey(0), isolation)
+}
+
+// TransactionIsolationFromContext returns the isolation level of the context.
+func TransactionIsolationFromContext(ctx context.Context) querypb.ExecuteOptions_TransactionIsolation {
+	v, _ := ctx.Value(isolationKey(0)).(querypb.ExecuteOptions_TransactionIsolation)
+	return v
+}
+
+// readAfterWriteKey is the context key for the read after write setting.
+type readAfterWriteKey int
+
+// WithReadAfterWrite returns a context that makes Begin ask for read
+// after write. Once the transaction is committed, the calls made
+// outside of a transaction on the same VTGateConn send the committed
+// session, and their reads on replica or rdonly tablets wait for the
+// committed writes.
+func WithReadAfterWrite(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readAfterWriteKey(0), true)
+}
+
+// ReadAfterWriteFromContext returns true if the context asks for
+// read after write.
+func ReadAfterWriteFromContext(ctx context.Context) bool {
+	v, _ := ctx.Value(readAfterWriteKey(0)).(bool)
+	return v
+}
+
 // VTGateConn is the client API object to talk to vtgate.
 // It is constructed using the Dial method.
 // It can be used concurrently across goroutines.
@@ -56,43 +92,83 @@ type VTGateConn struct {
 	// keyspace for Execute / StreamExecute.
 	keyspace string
 	impl     Impl
+
+	// mu protects session.
+	mu sync.Mutex
+	// session is the session committed by the last read after
+	// write transaction, or nil. It carries the positions of the
+	// writes, and is sent with the calls made outside of a
+	// transaction.
+	session interface{}
+}
+
+// readAfterWriteSession returns the session to send with a call
+// made outside of a transaction.
+func (conn *VTGateConn) readAfterWriteSession() interface{} {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	return conn.session
+}
+
+// updateReadAfterWriteSession keeps the session returned by a call,
+// as vtgate records the positions of the writes made outside of a
+// transaction in it. Calls made without a session return none.
+func (conn *VTGateConn) updateReadAfterWriteSession(sent, returned interface{}) {
+	if sent == nil || returned == nil {
+		return
+	}
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	conn.session = returned
 }
 
 // Execute executes a non-streaming query on vtgate.
 // This is using v3 API.
 func (conn *VTGateConn) Execute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.Execute(ctx, query, bindVars, conn.keyspace, tabletType, nil, options)
+	session := conn.readAfterWriteSession()
+	res, newSession, err := conn.impl.Execute(ctx, query, bindVars, conn.keyspace, tabletType, session, options)
+	conn.updateReadAfterWriteSession(session, newSession)
 	return res, err
 }
 
 // ExecuteShards executes a non-streaming query for multiple shards on vtgate.
 func (conn *VTGateConn) ExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteShards(ctx, query, keyspace, shards, bindVars, tabletType, nil, options)
+	session := conn.readAfterWriteSession()
+	res, newSession, err := conn.impl.ExecuteShards(ctx, query, keyspace, shards, bindVars, tabletType, session, options)
+	conn.updateReadAfterWriteSession(session, newSession)
 	return res, err
 }
 
 // ExecuteKeyspaceIds executes a non-streaming query for multiple keyspace_ids.
 func (conn *VTGateConn) ExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteKeyspaceIds(ctx, query, keyspace, keyspaceIds, bindVars, tabletType, nil, options)
+	session := conn.readAfterWriteSession()
+	res, newSession, err := conn.impl.ExecuteKeyspaceIds(ctx, query, keyspace, keyspaceIds, bindVars, tabletType, session, options)
+	conn.updateReadAfterWriteSession(session, newSession)
 	return res, err
 }
 
 // ExecuteKeyRanges executes a non-streaming query on a key range.
 func (conn *VTGateConn) ExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteKeyRanges(ctx, query, keyspace, keyRanges, bindVars, tabletType, nil, options)
+	session := conn.readAfterWriteSession()
+	res, newSession, err := conn.impl.ExecuteKeyRanges(ctx, query, keyspace, keyRanges, bindVars, tabletType, session, options)
+	conn.updateReadAfterWriteSession(session, newSession)
 	return res, err
 }
 
 // ExecuteEntityIds executes a non-streaming query for multiple entities.
 func (conn *VTGateConn) ExecuteEntityIds(ctx context.Context, query string, keyspace string, entityColumnName string, entityKeyspaceIDs []*vtgatepb.ExecuteEntityIdsRequest_EntityId, bindVars map[string]interface{}, tabletType topodatapb.TabletType, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteEntityIds(ctx, query, keyspace, entityColumnName, entityKeyspaceIDs, bindVars, tabletType, nil, options)
+	session := conn.readAfterWriteSession()
+	res, newSession, err := conn.impl.ExecuteEntityIds(ctx, query, keyspace, entityColumnName, entityKeyspaceIDs, bindVars, tabletType, session, options)
+	conn.updateReadAfterWriteSession(session, newSession)
 	return res, err
 }
 
 // ExecuteBatch executes a non-streaming list of queries on vtgate.
 // This is using v3 API.
 func (conn *VTGateConn) ExecuteBatch(ctx context.Context, queryList []string, bindVarsList []map[string]interface{}, tabletType topodatapb.TabletType, asTransaction bool, options *querypb.ExecuteOptions) ([]sqltypes.QueryResponse, error) {
-	res, _, err := conn.impl.ExecuteBatch(ctx, queryList, bindVarsList, conn.keyspace, tabletType, asTransaction, nil, options)
+	session := conn.readAfterWriteSession()
+	res, newSession, err := conn.impl.ExecuteBatch(ctx, queryList, bindVarsList, conn.keyspace, tabletType, asTransaction, session, options)
+	conn.updateReadAfterWriteSession(session, newSession)
 	return res, err
 }
 
@@ -100,7 +176,9 @@ func (conn *VTGateConn) ExecuteBatch(ctx context.Context, queryList []string, bi
 // If "asTransaction" is true, vtgate will automatically create a transaction
 // (per shard) that encloses all the batch queries.
 func (conn *VTGateConn) ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.BoundShardQuery, tabletType topodatapb.TabletType, asTransaction bool, options *querypb.ExecuteOptions) ([]sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteBatchShards(ctx, queries, tabletType, asTransaction, nil, options)
+	session := conn.readAfterWriteSession()
+	res, newSession, err := conn.impl.ExecuteBatchShards(ctx, queries, tabletType, asTransaction, session, options)
+	conn.updateReadAfterWriteSession(session, newSession)
 	return res, err
 }
 
@@ -108,7 +186,9 @@ func (conn *VTGateConn) ExecuteBatchShards(ctx context.Context, queries []*vtgat
 // If "asTransaction" is true, vtgate will automatically create a transaction
 // (per shard) that encloses all the batch queries.
 func (conn *VTGateConn) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgatepb.BoundKeyspaceIdQuery, tabletType topodatapb.TabletType, asTransaction bool, options *querypb.ExecuteOptions) ([]sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteBatchKeyspaceIds(ctx, queries, tabletType, asTransaction, nil, options)
+	session := conn.readAfterWriteSession()
+	res, newSession, err := conn.impl.ExecuteBatchKeyspaceIds(ctx, queries, tabletType, asTransaction, session, options)
+	conn.updateReadAfterWriteSession(session, newSession)
 	return res, err
 }
 
@@ -159,12 +239,16 @@ func (conn *VTGateConn) MessageAck(ctx context.Context, keyspace string, name st
 }
 
 // Begin starts a transaction and returns a VTGateTX.
+// If the context was returned by WithReadAfterWrite, the transaction
+// also carries the positions known to the connection, so that the
+// session it commits keeps them.
 func (conn *VTGateConn) Begin(ctx context.Context) (*VTGateTx, error) {
 	atomicity := AtomicityFromContext(ctx)
 	session, err := conn.impl.Begin(ctx, atomicity == AtomicitySingle)
 	if err != nil {
 		return nil, err
 	}
+	copyShardPositions(session, conn.readAfterWriteSession())
 
 	return &VTGateTx{
 		conn:      conn,
@@ -173,6 +257,26 @@ func (conn *VTGateConn) Begin(ctx context.Context) (*VTGateTx, error) {
 	}, nil
 }
 
+// copyShardPositions copies the positions of a read after write
+// session to a new one.
+func copyShardPositions(to, from interface{}) {
+	toSession, ok := to.(*vtgatepb.Session)
+	if !ok || toSession == nil || !toSession.ReadAfterWrite {
+		return
+	}
+	fromSession, ok := from.(*vtgatepb.Session)
+	if !ok || fromSession == nil {
+		return
+	}
+	for _, sp := range fromSession.ShardPositions {
+		toSession.ShardPositions = append(toSession.ShardPositions, &vtgatepb.Session_ShardPosition{
+			Keyspace: sp.Keyspace,
+			Shard:    sp.Shard,
+			Position: sp.Position,
+		})
+	}
+}
+
 // Close must be called for releasing resources.
 func (conn *VTGateConn) Close() {
 	conn.impl.Close()
@@ -305,14 +409,24 @@ func (tx *VTGateTx) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtga
 	return res, err
 }
 
-// Commit commits the current transaction.
+// Commit commits the current transaction. If the transaction asked
+// for read after write, the connection keeps the committed session
+// for the calls it makes outside of a transaction.
 func (tx *VTGateTx) Commit(ctx context.Context) error {
 	if tx.session == nil {
 		return fmt.Errorf("commit: not in transaction")
 	}
-	err := tx.conn.impl.Commit(ctx, tx.session, tx.atomicity == Atomicity2PC)
+	session, err := tx.conn.impl.Commit(ctx, tx.session, tx.atomicity == Atomicity2PC)
 	tx.session = nil
-	return err
+	if err != nil {
+		return err
+	}
+	if session != nil {
+		tx.conn.mu.Lock()
+		tx.conn.session = session
+		tx.conn.mu.Unlock()
+	}
+	return nil
 }
 
 // Rollback rolls back the current transaction.
@@ -370,8 +484,10 @@ type Impl interface {
 
 	// Begin starts a transaction and returns a VTGateTX.
 	Begin(ctx context.Context, singledb bool) (interface{}, error)
-	// Commit commits the current transaction.
-	Commit(ctx context.Context, session interface{}, twopc bool) error
+	// Commit commits the current transaction. It returns the
+	// committed session if the transaction asked for read after
+	// write, nil otherwise.
+	Commit(ctx context.Context, session interface{}, twopc bool) (interface{}, error)
 	// Rollback rolls back the current transaction.
 	Rollback(ctx context.Context, session interface{}) error
 	// ResolveTransaction resolves the specified 2pc transaction.
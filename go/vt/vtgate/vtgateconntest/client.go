@@ -646,7 +646,9 @@ func (f *fakeVTGateService) Begin(ctx context.Context, singledb bool) (*vtgatepb
 		// Communicate this as an error.
 		return nil, errors.New("single db")
 	}
-	return session1, nil
+	// The server sets the isolation level on the returned session.
+	session := *session1
+	return &session, nil
 }
 
 // Commit is part of the VTGateService interface
@@ -662,6 +664,12 @@ func (f *fakeVTGateService) Commit(ctx context.Context, twopc bool, inSession *v
 		// Communicate this as an error.
 		return errors.New("twopc")
 	}
+	if inSession.ReadAfterWrite {
+		// Record the position of the commit, as vtgate does.
+		inSession.InTransaction = false
+		inSession.ShardPositions = append(inSession.ShardPositions, readAfterWritePosition)
+		return nil
+	}
 	if !reflect.DeepEqual(inSession, session2) {
 		return errors.New("commit: session mismatch")
 	}
@@ -677,6 +685,10 @@ func (f *fakeVTGateService) Rollback(ctx context.Context, inSession *vtgatepb.Se
 		panic(fmt.Errorf("test forced panic"))
 	}
 	f.checkCallerID(ctx, "Rollback")
+	if inSession.TransactionIsolation != querypb.ExecuteOptions_DEFAULT {
+		// Communicate this as an error.
+		return fmt.Errorf("isolation %v", inSession.TransactionIsolation)
+	}
 	if !reflect.DeepEqual(inSession, session2) {
 		return errors.New("rollback: session mismatch")
 	}
@@ -890,6 +902,7 @@ func TestSuite(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGa
 	fs := fakeServer.(*fakeVTGateService)
 
 	testBegin(t, conn)
+	testBeginIsolation(t, conn)
 	testCommit(t, conn)
 	testExecute(t, conn)
 	testExecuteShards(t, conn)
@@ -903,6 +916,7 @@ func TestSuite(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGa
 	testStreamExecuteKeyRanges(t, conn)
 	testStreamExecuteKeyspaceIds(t, conn)
 	testTxPass(t, conn)
+	testReadAfterWrite(t)
 	testResolveTransaction(t, conn)
 	testTxFail(t, conn)
 	testMessageStream(t, conn)
@@ -1016,6 +1030,19 @@ func testBegin(t *testing.T, conn *vtgateconn.VTGateConn) {
 	}
 }
 
+func testBeginIsolation(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := vtgateconn.WithTransactionIsolation(newContext(), querypb.ExecuteOptions_READ_COMMITTED)
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tx.Rollback(ctx)
+	want := "isolation READ_COMMITTED"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Rollback: %v, want %v", err, want)
+	}
+}
+
 func testCommit(t *testing.T, conn *vtgateconn.VTGateConn) {
 	ctx := vtgateconn.WithAtomicity(newContext(), vtgateconn.Atomicity2PC)
 	tx, err := conn.Begin(ctx)
@@ -1697,6 +1724,35 @@ func testResolveTransaction(t *testing.T, conn *vtgateconn.VTGateConn) {
 	}
 }
 
+func testReadAfterWrite(t *testing.T) {
+	// Use a new connection, as it keeps the committed session.
+	conn, err := vtgateconn.DialProtocol(context.Background(), "test", "", 0, "connection_ks")
+	if err != nil {
+		t.Fatalf("Got err: %v from vtgateconn.DialProtocol", err)
+	}
+	ctx := vtgateconn.WithReadAfterWrite(newContext())
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The replica reads that follow must send the committed session.
+	ctx = newContext()
+	execCase := execMap["readAfterWriteRequest"]
+	for i := 0; i < 2; i++ {
+		qr, err := conn.Execute(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, execCase.execQuery.TabletType, testExecuteOptions)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(qr, execCase.result) {
+			t.Errorf("Unexpected result from Execute: got\n%#v want\n%#v", qr, execCase.result)
+		}
+	}
+}
+
 func testBeginError(t *testing.T, conn *vtgateconn.VTGateConn) {
 	ctx := newContext()
 	_, err := conn.Begin(ctx)
@@ -2491,6 +2547,25 @@ var execMap = map[string]struct {
 		result:     nil,
 		outSession: session2,
 	},
+	"readAfterWriteRequest": {
+		execQuery: &queryExecute{
+			SQL: "readAfterWriteRequest",
+			BindVariables: map[string]interface{}{
+				"bind1": &querypb.BindVariable{
+					Type:  querypb.Type_INT64,
+					Value: []byte("0"),
+				},
+			},
+			Keyspace:   "connection_ks",
+			TabletType: topodatapb.TabletType_REPLICA,
+			Session: &vtgatepb.Session{
+				ReadAfterWrite: true,
+				ShardPositions: []*vtgatepb.Session_ShardPosition{readAfterWritePosition},
+			},
+		},
+		result:     &result1,
+		outSession: nil,
+	},
 }
 
 var extras = querypb.ResultExtras{
@@ -2552,6 +2627,12 @@ var session2 = &vtgatepb.Session{
 	},
 }
 
+var readAfterWritePosition = &vtgatepb.Session_ShardPosition{
+	Keyspace: "ks",
+	Shard:    "1",
+	Position: "MariaDB/0-1-123",
+}
+
 var dtid2 = "aa"
 
 var splitQueryRequest = &querySplitQuery{
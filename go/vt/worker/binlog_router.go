@@ -0,0 +1,372 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/sqlparser"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "github.com/gitql/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// binlogRouter splits the statements of a binlog transaction of an external
+// MySQL server into the statements for each destination shard.
+//
+// The rows of an INSERT are routed to the shard of their keyspace id, which
+// is computed with the primary vindex of the table. An UPDATE or DELETE whose
+// WHERE clause restricts the primary vindex column to a single value is sent
+// to the shard of that value. Other UPDATE and DELETE statements are sent to
+// all shards because each row exists on one shard only. They must not have a
+// LIMIT because each shard would apply it separately. SET statements are sent
+// to all shards which receive other statements of the transaction.
+// Statements for tables which are not imported are dropped.
+//
+// "SET INSERT_ID=" statements are not forwarded. Instead, the generated
+// AUTO_INCREMENT values of the next INSERT are added to its rows. This way,
+// each shard gets the values of the external server and rows with a
+// generated vindex column can be routed.
+type binlogRouter struct {
+	shardCount int
+	tables     map[string]*routedTable
+}
+
+// routedTable has the routing information of an imported table.
+type routedTable struct {
+	// columns are the columns of the table in their original order. They
+	// are used for an INSERT without a column list.
+	columns []string
+	// vindexColumnIndex is the index of the primary vindex column in columns.
+	vindexColumnIndex int
+	// autoIncrementColumn is the AUTO_INCREMENT column of the table or
+	// empty if it has none.
+	autoIncrementColumn string
+	router              RowRouter
+}
+
+// setInsertID is the prefix of the statement which sets the first
+// AUTO_INCREMENT value generated by the next INSERT.
+const setInsertID = "SET INSERT_ID="
+
+// newBinlogRouter returns a binlogRouter for the given tables. The column
+// lists of "tableDefinitions" must be in the order of the table.
+// "autoIncrementColumns" maps a table name to its AUTO_INCREMENT column.
+func newBinlogRouter(destinationShards []*topo.ShardInfo, keyspaceSchema *vindexes.KeyspaceSchema, tableDefinitions []*tabletmanagerdatapb.TableDefinition, autoIncrementColumns map[string]string) (*binlogRouter, error) {
+	tables := make(map[string]*routedTable, len(tableDefinitions))
+	for _, td := range tableDefinitions {
+		resolver, err := newV3ResolverFromTableDefinition(keyspaceSchema, td)
+		if err != nil {
+			return nil, err
+		}
+		tables[td.Name] = &routedTable{
+			columns:             td.Columns,
+			vindexColumnIndex:   resolver.(*v3Resolver).shardingColumnIndex,
+			autoIncrementColumn: autoIncrementColumns[td.Name],
+			router:              NewRowRouter(destinationShards, resolver),
+		}
+	}
+	return &binlogRouter{
+		shardCount: len(destinationShards),
+		tables:     tables,
+	}, nil
+}
+
+// route returns the statements of the transaction for each destination shard.
+// The index of the returned slice is the index of the shard in the list
+// passed to newBinlogRouter. A shard which is not affected by the
+// transaction has no statements.
+func (r *binlogRouter) route(statements []*binlogdatapb.BinlogTransaction_Statement) ([][]string, error) {
+	result := make([][]string, r.shardCount)
+	hasDML := make([]bool, r.shardCount)
+	// insertID is the first AUTO_INCREMENT value of the next statement.
+	// 0 means that no value was set.
+	var insertID int64
+	for _, statement := range statements {
+		sql := string(statement.Sql)
+		switch statement.Category {
+		case binlogdatapb.BinlogTransaction_Statement_BL_SET:
+			if strings.HasPrefix(sql, setInsertID) {
+				id, err := strconv.ParseInt(sql[len(setInsertID):], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("cannot parse statement: %v: %v", sql, err)
+				}
+				insertID = id
+				continue
+			}
+			for i := range result {
+				result[i] = append(result[i], sql)
+			}
+		case binlogdatapb.BinlogTransaction_Statement_BL_INSERT, binlogdatapb.BinlogTransaction_Statement_BL_UPDATE, binlogdatapb.BinlogTransaction_Statement_BL_DELETE:
+			shardStatements, err := r.routeDML(sql, insertID)
+			if err != nil {
+				return nil, err
+			}
+			insertID = 0
+			for i, s := range shardStatements {
+				if s != "" {
+					result[i] = append(result[i], s)
+					hasDML[i] = true
+				}
+			}
+		case binlogdatapb.BinlogTransaction_Statement_BL_DDL:
+			return nil, fmt.Errorf("schema changes are not supported during the import. Apply the change to the keyspace and restart the import. Statement: %v", sql)
+		default:
+			return nil, fmt.Errorf("unsupported statement in the binlog (category: %v): %v", statement.Category, sql)
+		}
+	}
+
+	// Drop the SET statements of shards without any other statement.
+	for i := range result {
+		if !hasDML[i] {
+			result[i] = nil
+		}
+	}
+	return result, nil
+}
+
+// routeDML returns the statement for each destination shard, or an empty
+// string if the shard is not affected. "insertID" is the first generated
+// AUTO_INCREMENT value of an INSERT, or 0 if it's not known.
+func (r *binlogRouter) routeDML(sql string, insertID int64) ([]string, error) {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse statement (note that the binlog must be statement based): %v: %v", sql, err)
+	}
+
+	switch stmt := stmt.(type) {
+	case *sqlparser.Insert:
+		t, ok := r.tables[stmt.Table.Name.String()]
+		if !ok {
+			return nil, nil
+		}
+		return r.routeInsert(t, stmt, sql, insertID)
+	case *sqlparser.Update:
+		tableName, ok := stmt.Table.Expr.(*sqlparser.TableName)
+		if !ok {
+			return nil, fmt.Errorf("unsupported table expression in statement: %v", sql)
+		}
+		t, ok := r.tables[tableName.Name.String()]
+		if !ok {
+			return nil, nil
+		}
+		vindexColumn := t.columns[t.vindexColumnIndex]
+		for _, expr := range stmt.Exprs {
+			if expr.Name.Name.EqualString(vindexColumn) {
+				return nil, fmt.Errorf("cannot change the vindex column %v in statement: %v", vindexColumn, sql)
+			}
+		}
+		tableName.Qualifier = sqlparser.TableIdent{}
+		return r.routeByWhere(t, stmt.Where, stmt.Limit != nil, sqlparser.String(stmt), sql)
+	case *sqlparser.Delete:
+		t, ok := r.tables[stmt.Table.Name.String()]
+		if !ok {
+			return nil, nil
+		}
+		stmt.Table.Qualifier = sqlparser.TableIdent{}
+		return r.routeByWhere(t, stmt.Where, stmt.Limit != nil, sqlparser.String(stmt), sql)
+	}
+	return nil, fmt.Errorf("unsupported statement: %v", sql)
+}
+
+// routeByWhere routes an UPDATE or DELETE. If the WHERE clause restricts
+// the vindex column to a single value, "query" is sent to the shard of
+// that value only. Otherwise, it is sent to all shards, which is not
+// possible with a LIMIT.
+func (r *binlogRouter) routeByWhere(t *routedTable, where *sqlparser.Where, hasLimit bool, query, sql string) ([]string, error) {
+	vindexColumn := t.columns[t.vindexColumnIndex]
+	var value sqltypes.Value
+	if where != nil {
+		value = vindexValue(where.Expr, vindexColumn)
+	}
+	if value.IsNull() {
+		if hasLimit {
+			return nil, fmt.Errorf("a statement with a LIMIT must restrict the vindex column %v to a single value: %v", vindexColumn, sql)
+		}
+		return r.broadcast(query), nil
+	}
+
+	shardIndex, err := r.shardIndex(t, value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot route statement: %v: %v", sql, err)
+	}
+	result := make([]string, r.shardCount)
+	result[shardIndex] = query
+	return result, nil
+}
+
+// vindexValue returns the value of "column" if the condition "expr"
+// requires it to be equal to a value. Otherwise, it returns a NULL value.
+func vindexValue(expr sqlparser.Expr, column string) sqltypes.Value {
+	switch expr := expr.(type) {
+	case *sqlparser.AndExpr:
+		if value := vindexValue(expr.Left, column); !value.IsNull() {
+			return value
+		}
+		return vindexValue(expr.Right, column)
+	case *sqlparser.ParenExpr:
+		return vindexValue(expr.Expr, column)
+	case *sqlparser.ComparisonExpr:
+		if expr.Operator != sqlparser.EqualStr {
+			return sqltypes.Value{}
+		}
+		left, right := expr.Left, expr.Right
+		if _, ok := right.(*sqlparser.ColName); ok {
+			left, right = right, left
+		}
+		colName, ok := left.(*sqlparser.ColName)
+		if !ok || !colName.Name.EqualString(column) {
+			return sqltypes.Value{}
+		}
+		v, err := sqlparser.AsInterface(right)
+		if err != nil {
+			return sqltypes.Value{}
+		}
+		if value, ok := v.(sqltypes.Value); ok {
+			return value
+		}
+	}
+	return sqltypes.Value{}
+}
+
+// routeInsert splits the rows of an INSERT by destination shard.
+// If "insertID" is set, the generated AUTO_INCREMENT values are added to the
+// rows. They are consecutive because the import requires
+// auto_increment_increment=1 on the external server.
+func (r *binlogRouter) routeInsert(t *routedTable, ins *sqlparser.Insert, sql string, insertID int64) ([]string, error) {
+	rows, ok := ins.Rows.(sqlparser.Values)
+	if !ok {
+		return nil, fmt.Errorf("INSERT with a SELECT is not supported: %v", sql)
+	}
+	vindexColumn := t.columns[t.vindexColumnIndex]
+	for _, expr := range ins.OnDup {
+		if expr.Name.Name.EqualString(vindexColumn) {
+			return nil, fmt.Errorf("cannot change the vindex column %v in statement: %v", vindexColumn, sql)
+		}
+	}
+
+	if insertID != 0 && t.autoIncrementColumn != "" {
+		if err := addInsertIDs(t, ins, rows, insertID); err != nil {
+			return nil, fmt.Errorf("cannot add the AUTO_INCREMENT values to statement: %v: %v", sql, err)
+		}
+	}
+
+	// Find the position of the vindex column in the inserted rows.
+	position := columnPosition(t, ins.Columns, vindexColumn)
+	if position == -1 {
+		return nil, fmt.Errorf("INSERT does not set the vindex column %v: %v", vindexColumn, sql)
+	}
+
+	shardRows := make([]sqlparser.Values, r.shardCount)
+	for _, tuple := range rows {
+		if position >= len(tuple) {
+			return nil, fmt.Errorf("INSERT has fewer values than columns: %v", sql)
+		}
+		v, err := sqlparser.AsInterface(tuple[position])
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute the keyspace id of the vindex column %v in statement: %v: %v", vindexColumn, sql, err)
+		}
+		value, ok := v.(sqltypes.Value)
+		if !ok || value.IsNull() {
+			return nil, fmt.Errorf("invalid value for the vindex column %v in statement: %v", vindexColumn, sql)
+		}
+		shardIndex, err := r.shardIndex(t, value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot route statement: %v: %v", sql, err)
+		}
+		shardRows[shardIndex] = append(shardRows[shardIndex], tuple)
+	}
+
+	ins.Table.Qualifier = sqlparser.TableIdent{}
+	result := make([]string, r.shardCount)
+	for i, rows := range shardRows {
+		if len(rows) == 0 {
+			continue
+		}
+		ins.Rows = rows
+		result[i] = sqlparser.String(ins)
+	}
+	return result, nil
+}
+
+// addInsertIDs sets the AUTO_INCREMENT column of each row, for which MySQL
+// generated the value, to the value starting at "insertID". A value is
+// generated if the column is missing from the INSERT, or if it's NULL or 0.
+// The rows are modified in place.
+func addInsertIDs(t *routedTable, ins *sqlparser.Insert, rows sqlparser.Values, insertID int64) error {
+	position := columnPosition(t, ins.Columns, t.autoIncrementColumn)
+	if position == -1 {
+		if len(ins.Columns) == 0 {
+			return fmt.Errorf("unknown AUTO_INCREMENT column %v", t.autoIncrementColumn)
+		}
+		// The column is not in the column list. Add it.
+		ins.Columns = append(ins.Columns, sqlparser.NewColIdent(t.autoIncrementColumn))
+		position = len(ins.Columns) - 1
+		for i := range rows {
+			rows[i] = append(rows[i], &sqlparser.NullVal{})
+		}
+	}
+
+	for _, tuple := range rows {
+		if position >= len(tuple) {
+			return fmt.Errorf("INSERT has fewer values than columns")
+		}
+		v, err := sqlparser.AsInterface(tuple[position])
+		if err != nil {
+			// MySQL doesn't generate a value for an expression.
+			continue
+		}
+		if v != nil {
+			value, ok := v.(sqltypes.Value)
+			if !ok || value.String() != "0" {
+				continue
+			}
+		}
+		tuple[position] = sqlparser.NewIntVal([]byte(strconv.FormatInt(insertID, 10)))
+		insertID++
+	}
+	return nil
+}
+
+// columnPosition returns the position of "column" in the rows of an INSERT
+// with the column list "columns", or -1 if it's not set.
+func columnPosition(t *routedTable, columns sqlparser.Columns, column string) int {
+	if len(columns) == 0 {
+		for i, c := range t.columns {
+			if strings.EqualFold(c, column) {
+				return i
+			}
+		}
+		return -1
+	}
+	for i, c := range columns {
+		if c.EqualString(column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// shardIndex returns the index of the shard of the vindex column "value".
+func (r *binlogRouter) shardIndex(t *routedTable, value sqltypes.Value) (int, error) {
+	// The resolver looks only at the vindex column of the row.
+	row := make([]sqltypes.Value, len(t.columns))
+	row[t.vindexColumnIndex] = value
+	return t.router.Route(row)
+}
+
+// broadcast returns "sql" for each destination shard.
+func (r *binlogRouter) broadcast(sql string) []string {
+	result := make([]string, r.shardCount)
+	for i := range result {
+		result[i] = sql
+	}
+	return result
+}
@@ -0,0 +1,232 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "github.com/gitql/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+	vschemapb "github.com/gitql/vitess/go/vt/proto/vschema"
+)
+
+func newTestBinlogRouter(t *testing.T) *binlogRouter {
+	vs := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"numeric": {
+				Type: "numeric",
+			},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{
+					{
+						Column: "user_id",
+						Name:   "numeric",
+					},
+				},
+			},
+			"t2": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{
+					{
+						Column: "id",
+						Name:   "numeric",
+					},
+				},
+			},
+		},
+	}
+	keyspaceSchema, err := vindexes.BuildKeyspaceSchema(vs, "ks")
+	if err != nil {
+		t.Fatalf("BuildKeyspaceSchema failed: %v", err)
+	}
+	shards := []*topo.ShardInfo{
+		topo.NewShardInfo("ks", "-80", &topodatapb.Shard{KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}}, 0),
+		topo.NewShardInfo("ks", "80-", &topodatapb.Shard{KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}}, 0),
+	}
+	tds := []*tabletmanagerdatapb.TableDefinition{
+		{
+			Name:              "t1",
+			Columns:           []string{"id", "user_id", "msg"},
+			PrimaryKeyColumns: []string{"id"},
+			Type:              "BASE TABLE",
+		},
+		{
+			Name:              "t2",
+			Columns:           []string{"id", "msg"},
+			PrimaryKeyColumns: []string{"id"},
+			Type:              "BASE TABLE",
+		},
+	}
+	autoIncrementColumns := map[string]string{
+		"t1": "id",
+		"t2": "id",
+	}
+	r, err := newBinlogRouter(shards, keyspaceSchema, tds, autoIncrementColumns)
+	if err != nil {
+		t.Fatalf("newBinlogRouter failed: %v", err)
+	}
+	return r
+}
+
+func statement(category binlogdatapb.BinlogTransaction_Statement_Category, sql string) *binlogdatapb.BinlogTransaction_Statement {
+	return &binlogdatapb.BinlogTransaction_Statement{
+		Category: category,
+		Sql:      []byte(sql),
+	}
+}
+
+func TestBinlogRouter(t *testing.T) {
+	r := newTestBinlogRouter(t)
+
+	testcases := []struct {
+		desc       string
+		statements []*binlogdatapb.BinlogTransaction_Statement
+		want       [][]string
+	}{
+		{
+			desc: "rows of an INSERT are split by shard",
+			statements: []*binlogdatapb.BinlogTransaction_Statement{
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_SET, "SET TIMESTAMP=1"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into db.t1(id, user_id, msg) values (1, 1, 'a'), (2, 10376293541461622784, 'b'), (3, 2, now())"),
+			},
+			want: [][]string{
+				{"SET TIMESTAMP=1", "insert into t1(id, user_id, msg) values (1, 1, 'a'), (3, 2, now())"},
+				{"SET TIMESTAMP=1", "insert into t1(id, user_id, msg) values (2, 10376293541461622784, 'b')"},
+			},
+		},
+		{
+			desc: "INSERT without a column list",
+			statements: []*binlogdatapb.BinlogTransaction_Statement{
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_SET, "SET TIMESTAMP=1"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into t1 values (1, 10376293541461622784, 'a')"),
+			},
+			want: [][]string{
+				nil,
+				{"SET TIMESTAMP=1", "insert into t1 values (1, 10376293541461622784, 'a')"},
+			},
+		},
+		{
+			desc: "UPDATE and DELETE are sent to all shards",
+			statements: []*binlogdatapb.BinlogTransaction_Statement{
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_UPDATE, "update db.t1 set msg = 'b' where id = 1"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_DELETE, "delete from t1 where id = 2"),
+			},
+			want: [][]string{
+				{"update t1 set msg = 'b' where id = 1", "delete from t1 where id = 2"},
+				{"update t1 set msg = 'b' where id = 1", "delete from t1 where id = 2"},
+			},
+		},
+		{
+			desc: "generated AUTO_INCREMENT values are added to the rows",
+			statements: []*binlogdatapb.BinlogTransaction_Statement{
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_SET, "SET INSERT_ID=5"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into t1(user_id, msg) values (1, 'a'), (10376293541461622784, 'b')"),
+			},
+			want: [][]string{
+				{"insert into t1(user_id, msg, id) values (1, 'a', 5)"},
+				{"insert into t1(user_id, msg, id) values (10376293541461622784, 'b', 6)"},
+			},
+		},
+		{
+			desc: "INSERT with a generated vindex column",
+			statements: []*binlogdatapb.BinlogTransaction_Statement{
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_SET, "SET INSERT_ID=7"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into t2(id, msg) values (null, 'a'), (3, 'b'), (0, 'c')"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_SET, "SET INSERT_ID=9"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into t2 values (null, 'd')"),
+			},
+			want: [][]string{
+				{"insert into t2(id, msg) values (7, 'a'), (3, 'b'), (8, 'c')", "insert into t2 values (9, 'd')"},
+				nil,
+			},
+		},
+		{
+			desc: "UPDATE and DELETE with a single vindex value go to one shard",
+			statements: []*binlogdatapb.BinlogTransaction_Statement{
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_UPDATE, "update t1 set msg = 'b' where 10376293541461622784 = user_id and id > 1 limit 2"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_DELETE, "delete from t1 where (id = 2 and t1.user_id = 1) order by id limit 1"),
+			},
+			want: [][]string{
+				{"delete from t1 where (id = 2 and t1.user_id = 1) order by id asc limit 1"},
+				{"update t1 set msg = 'b' where 10376293541461622784 = user_id and id > 1 limit 2"},
+			},
+		},
+		{
+			desc: "tables which are not imported are dropped",
+			statements: []*binlogdatapb.BinlogTransaction_Statement{
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_SET, "SET TIMESTAMP=1"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into other(id) values (1)"),
+				statement(binlogdatapb.BinlogTransaction_Statement_BL_DELETE, "delete from other"),
+			},
+			want: [][]string{nil, nil},
+		},
+	}
+	for _, tc := range testcases {
+		got, err := r.route(tc.statements)
+		if err != nil {
+			t.Errorf("%v: route failed: %v", tc.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: route() = %q, want %q", tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestBinlogRouterErrors(t *testing.T) {
+	r := newTestBinlogRouter(t)
+
+	testcases := []struct {
+		statement *binlogdatapb.BinlogTransaction_Statement
+		want      string
+	}{
+		{
+			statement(binlogdatapb.BinlogTransaction_Statement_BL_DDL, "alter table t1 add column c int"),
+			"schema changes are not supported",
+		},
+		{
+			statement(binlogdatapb.BinlogTransaction_Statement_BL_UPDATE, "update t1 set user_id = 2 where id = 1"),
+			"cannot change the vindex column user_id",
+		},
+		{
+			statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into t1(id, msg) values (1, 'a')"),
+			"INSERT does not set the vindex column user_id",
+		},
+		{
+			statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into t1(id, user_id) values (1, 1 + 1)"),
+			"cannot compute the keyspace id",
+		},
+		{
+			statement(binlogdatapb.BinlogTransaction_Statement_BL_DELETE, "delete from t1 where id = 1 limit 1"),
+			"a statement with a LIMIT must restrict the vindex column user_id",
+		},
+		{
+			statement(binlogdatapb.BinlogTransaction_Statement_BL_UPDATE, "update t1 set msg = 'a' where user_id in (1, 2) order by id limit 1"),
+			"a statement with a LIMIT must restrict the vindex column user_id",
+		},
+		{
+			statement(binlogdatapb.BinlogTransaction_Statement_BL_INSERT, "insert into t2(msg) values ('a')"),
+			"INSERT does not set the vindex column id",
+		},
+		{
+			statement(binlogdatapb.BinlogTransaction_Statement_BL_UPDATE, "WIP: update table t1 set values = [1] where identifies = [1]"),
+			"the binlog must be statement based",
+		},
+	}
+	for _, tc := range testcases {
+		_, err := r.route([]*binlogdatapb.BinlogTransaction_Statement{tc.statement})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("route(%s) returned error: %v, want: %v", tc.statement.Sql, err, tc.want)
+		}
+	}
+}
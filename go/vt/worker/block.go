@@ -62,7 +62,7 @@ func (bw *BlockWorker) StatusAsText() string {
 
 // Run implements the Worker interface.
 func (bw *BlockWorker) Run(ctx context.Context) error {
-	resetVars()
+	resetVars(ctx)
 	err := bw.run(ctx)
 
 	bw.SetState(WorkerStateCleanUp)
@@ -26,6 +26,8 @@ const blockHTML = `
       <b>Error:</b> {{.Error}}</br>
     {{else}}
 	    <form action="/Debugging/Block" method="post">
+	      <LABEL for="job">Job Name (empty for the default job): </LABEL>
+	        <INPUT type="text" id="job" name="job" value=""></BR>
 	      <INPUT type="submit" name="submit" value="Block (until canceled)"/>
     </form>
     {{end}}
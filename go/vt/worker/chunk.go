@@ -2,6 +2,7 @@ package worker
 
 import (
 	"fmt"
+	"strings"
 
 	"golang.org/x/net/context"
 
@@ -14,17 +15,19 @@ import (
 )
 
 var (
-	completeChunk       = chunk{sqltypes.NULL, sqltypes.NULL, 1, 1}
+	completeChunk       = chunk{nil, nil, 1, 1}
 	singleCompleteChunk = []chunk{completeChunk}
 )
 
 // chunk holds the information which subset of the table should be worked on.
 // The subset is the range of rows in the range [start, end) where start and end
-// both refer to the first column of the primary key.
-// If the column is not numeric, both start and end will be sqltypes.NULL.
+// are tuples of the leading columns of the primary key. A tuple may be shorter
+// than the primary key e.g. the chunks of a table with the primary key (a, b)
+// may be split on "a" only.
+// A nil start or end means that the range is not bounded on that side.
 type chunk struct {
-	start sqltypes.Value
-	end   sqltypes.Value
+	start []sqltypes.Value
+	end   []sqltypes.Value
 	// number records the position of this chunk among all "total" chunks.
 	// The lowest value is 1.
 	number int
@@ -52,8 +55,10 @@ func digits(i int) int {
 }
 
 // generateChunks returns an array of chunks to use for splitting up a table
-// into multiple data chunks. It only works for tables with a primary key
-// whose first column is a numeric type.
+// into multiple data chunks. If the first column of the primary key is numeric,
+// the range between its MIN and MAX is split into intervals of equal size.
+// Otherwise, or if the range is too small, the chunks are sampled from the
+// primary key index (see sampleChunks()).
 func generateChunks(ctx context.Context, wr *wrangler.Wrangler, tablet *topodatapb.Tablet, td *tabletmanagerdatapb.TableDefinition, chunkCount, minRowsPerChunk int) ([]chunk, error) {
 	if len(td.PrimaryKeyColumns) == 0 {
 		// No explicit primary key. Cannot chunk the rows then.
@@ -110,26 +115,26 @@ func generateChunks(ctx context.Context, wr *wrangler.Wrangler, tablet *topodata
 		max := max.(int64)
 		interval = (max - min) / int64(chunkCount)
 		if interval == 0 {
-			wr.Logger().Infof("table=%v: Not splitting the table into multiple chunks, interval=0: %v to %v", td.Name, min, max)
-			return singleCompleteChunk, nil
+			wr.Logger().Infof("table=%v: Sampling the chunks from the primary key index, interval=0: %v to %v", td.Name, min, max)
+			return sampleChunks(ctx, wr, tablet, td, chunkCount)
 		}
 	case uint64:
 		max := max.(uint64)
 		interval = (max - min) / uint64(chunkCount)
 		if interval == 0 {
-			wr.Logger().Infof("table=%v: Not splitting the table into multiple chunks, interval=0: %v to %v", td.Name, min, max)
-			return singleCompleteChunk, nil
+			wr.Logger().Infof("table=%v: Sampling the chunks from the primary key index, interval=0: %v to %v", td.Name, min, max)
+			return sampleChunks(ctx, wr, tablet, td, chunkCount)
 		}
 	case float64:
 		max := max.(float64)
 		interval = (max - min) / float64(chunkCount)
 		if interval == 0 {
-			wr.Logger().Infof("table=%v: Not splitting the table into multiple chunks, interval=0: %v to %v", td.Name, min, max)
-			return singleCompleteChunk, nil
+			wr.Logger().Infof("table=%v: Sampling the chunks from the primary key index, interval=0: %v to %v", td.Name, min, max)
+			return sampleChunks(ctx, wr, tablet, td, chunkCount)
 		}
 	default:
-		wr.Logger().Infof("table=%v: Not splitting the table into multiple chunks, primary key not numeric.", td.Name)
-		return singleCompleteChunk, nil
+		wr.Logger().Infof("table=%v: Sampling the chunks from the primary key index, primary key not numeric.", td.Name)
+		return sampleChunks(ctx, wr, tablet, td, chunkCount)
 	}
 
 	// Create chunks.
@@ -147,11 +152,85 @@ func generateChunks(ctx context.Context, wr *wrangler.Wrangler, tablet *topodata
 	// Clear out the MIN and MAX on the first and last chunk respectively
 	// because other shards might have smaller or higher values than the one we
 	// looked at.
-	chunks[0].start = sqltypes.NULL
-	chunks[chunkCount-1].end = sqltypes.NULL
+	chunks[0].start = nil
+	chunks[chunkCount-1].end = nil
 	return chunks, nil
 }
 
+// sampleChunks returns up to "chunkCount" chunks whose boundaries are primary
+// key values sampled from the table. It reads the primary key index in a
+// single streaming query:
+//
+//	SELECT <pk columns> FROM <table> ORDER BY <pk columns>
+//
+// and keeps every "rows per chunk"-th row as a boundary. The query is
+// stopped once all the boundaries are found.
+// Unlike the MIN/MAX based chunks, this works for any primary key type and
+// number of columns. The number of rows per chunk is based on the estimated
+// row count of the table, so the last chunk may be smaller or larger than
+// the others.
+func sampleChunks(ctx context.Context, wr *wrangler.Wrangler, tablet *topodatapb.Tablet, td *tabletmanagerdatapb.TableDefinition, chunkCount int) ([]chunk, error) {
+	rowsPerChunk := td.RowCount / uint64(chunkCount)
+	if rowsPerChunk == 0 {
+		wr.Logger().Infof("table=%v: Not splitting the table into multiple chunks because it has only %d rows.", td.Name, td.RowCount)
+		return singleCompleteChunk, nil
+	}
+
+	columns := strings.Join(escapeAll(td.PrimaryKeyColumns), ", ")
+	query := fmt.Sprintf("SELECT %v FROM %v ORDER BY %v", columns, escape(td.Name), columns)
+	// Canceling the context stops the query once we're done.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	qrr, err := NewQueryResultReaderForTablet(ctx, wr.TopoServer(), tablet.Alias, query)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot sample the primary key of table %v: %v", td.Name, err)
+	}
+	defer qrr.Close(ctx)
+
+	boundaries, err := sampleBoundaries(NewRowReader(qrr), rowsPerChunk, chunkCount-1)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot sample the primary key of table %v: %v", td.Name, err)
+	}
+	if len(boundaries) == 0 {
+		wr.Logger().Infof("table=%v: Not splitting the table into multiple chunks, the primary key index has less than %d rows.", td.Name, rowsPerChunk+1)
+		return singleCompleteChunk, nil
+	}
+
+	total := len(boundaries) + 1
+	chunks := make([]chunk, total)
+	var start []sqltypes.Value
+	for i, end := range boundaries {
+		chunks[i] = chunk{start, end, i + 1, total}
+		start = end
+	}
+	chunks[total-1] = chunk{start, nil, total, total}
+	wr.Logger().Infof("table=%v: Sampled %d chunks of about %d rows from the primary key index.", td.Name, total, rowsPerChunk)
+	return chunks, nil
+}
+
+// sampleBoundaries returns every "rowsPerChunk"-th row of "rr", starting
+// with the first row of the second chunk, until it has "count" rows.
+// It returns fewer rows if the reader has fewer rows than expected.
+func sampleBoundaries(rr *RowReader, rowsPerChunk uint64, count int) ([][]sqltypes.Value, error) {
+	var boundaries [][]sqltypes.Value
+	var rows uint64
+	for len(boundaries) < count {
+		row, err := rr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			// The table has fewer rows than estimated.
+			break
+		}
+		if rows > 0 && rows%rowsPerChunk == 0 {
+			boundaries = append(boundaries, row)
+		}
+		rows++
+	}
+	return boundaries, nil
+}
+
 func add(start, interval interface{}) interface{} {
 	switch start := start.(type) {
 	case int64:
@@ -174,5 +253,18 @@ func toChunk(start, end interface{}, number, total int) (chunk, error) {
 	if err != nil {
 		return chunk{}, fmt.Errorf("Failed to convert calculated end value (%v) into internal sqltypes.Value: %v", end, err)
 	}
-	return chunk{startValue, endValue, number, total}, nil
+	return chunk{[]sqltypes.Value{startValue}, []sqltypes.Value{endValue}, number, total}, nil
+}
+
+// whereClauses returns the WHERE clause expressions which restrict a query on
+// the table with the primary key "columns" to the rows of the chunk.
+func (c chunk) whereClauses(columns []string) []string {
+	var clauses []string
+	if c.start != nil {
+		clauses = append(clauses, tupleWhereClause(columns[:len(c.start)], c.start, ">=")...)
+	}
+	if c.end != nil {
+		clauses = append(clauses, tupleWhereClause(columns[:len(c.end)], c.end, "<")...)
+	}
+	return clauses
 }
@@ -0,0 +1,81 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gitql/vitess/go/sqltypes"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+)
+
+func TestSampleBoundaries(t *testing.T) {
+	row := func(name, id string) []sqltypes.Value {
+		return []sqltypes.Value{
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte(name)),
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte(id)),
+		}
+	}
+	fields := []*querypb.Field{
+		{Name: "name", Type: sqltypes.VarChar},
+		{Name: "id", Type: sqltypes.Int64},
+	}
+	rows := [][]sqltypes.Value{
+		row("a", "1"), row("a", "2"), row("b", "1"),
+		row("b", "5"), row("c", "7"), row("d", "1"),
+		row("d", "2"), row("e", "3"),
+	}
+
+	testcases := []struct {
+		desc         string
+		rowsPerChunk uint64
+		count        int
+		want         [][]sqltypes.Value
+	}{{
+		desc:         "all boundaries found",
+		rowsPerChunk: 2,
+		count:        3,
+		want:         [][]sqltypes.Value{row("b", "1"), row("c", "7"), row("d", "2")},
+	}, {
+		// The table has fewer rows than estimated.
+		desc:         "fewer rows than estimated",
+		rowsPerChunk: 3,
+		count:        3,
+		want:         [][]sqltypes.Value{row("b", "5"), row("d", "2")},
+	}, {
+		desc:         "no boundary",
+		rowsPerChunk: 10,
+		count:        3,
+		want:         nil,
+	}}
+	for _, tc := range testcases {
+		rr := NewRowReader(newRowsResultReader(fields, rows...))
+		got, err := sampleBoundaries(rr, tc.rowsPerChunk, tc.count)
+		if err != nil {
+			t.Errorf("%v: sampleBoundaries failed: %v", tc.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: sampleBoundaries() = %v, want %v", tc.desc, got, tc.want)
+		}
+	}
+
+	// The rows after the last boundary are not read.
+	rr := NewRowReader(newRowsResultReader(fields, rows...))
+	if _, err := sampleBoundaries(rr, 2, 1); err != nil {
+		t.Fatalf("sampleBoundaries failed: %v", err)
+	}
+	if next, err := rr.Next(); err != nil || !reflect.DeepEqual(next, row("b", "5")) {
+		t.Errorf("Next() after sampleBoundaries() = %v %v, want %v", next, err, row("b", "5"))
+	}
+
+	c := chunk{row("b", "1"), row("c", "7"), 2, 3}
+	wantClauses := []string{"`name`>='b'", "(`name`,`id`)>=('b',1)", "`name`<='c'", "(`name`,`id`)<('c',7)"}
+	if got := c.whereClauses([]string{"name", "id"}); !reflect.DeepEqual(got, wantClauses) {
+		t.Errorf("whereClauses() = %v, want %v", got, wantClauses)
+	}
+}
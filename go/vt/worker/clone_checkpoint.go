@@ -0,0 +1,331 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"path"
+	"reflect"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/topo"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+)
+
+// This file contains the checkpoints of the SplitClone and
+// VerticalSplitClone commands, which allow to resume an interrupted clone
+// with -resume. They are saved as JSON files in the global topology, in the
+// directory of the command:
+// - "Phases" has the state of the online and offline clone phases.
+// - "<phase>/<table>" has the chunks of a table, and their progress.
+// The checkpoints are removed once the command succeeded.
+
+var cloneCheckpointInterval = flag.Duration("clone_checkpoint_interval", time.Minute, "how often (Vertical)SplitClone saves the progress of each chunk, so an interrupted clone can be resumed with -resume. The writes of a chunk are flushed before each checkpoint. 0 disables the checkpoints.")
+
+const (
+	cloneCheckpointsPath = "vtworker/clone_checkpoints"
+	phasesFilename       = "Phases"
+
+	onlinePhase  = "online"
+	offlinePhase = "offline"
+)
+
+// clonePhases is the state of the clone phases.
+type clonePhases struct {
+	// OnlineDone is true once the online clone is complete.
+	OnlineDone bool
+	// OfflineSourcePositions are the replication positions of the source
+	// tablets used by the offline clone, by source shard. The offline
+	// checkpoints are only valid for a snapshot at the same positions.
+	OfflineSourcePositions []string
+}
+
+// tableCheckpoint has the chunks of a table, as generated when the clone of
+// the table started, and their progress.
+type tableCheckpoint struct {
+	Chunks []*chunkCheckpoint
+}
+
+// chunkCheckpoint is the progress of a chunk. All destination shards are
+// flushed before a checkpoint, so the progress is the same for all of them.
+type chunkCheckpoint struct {
+	// Start and End are the boundaries of the chunk. nil means that the
+	// chunk is not bounded on that side.
+	Start []*querypb.Value
+	End   []*querypb.Value
+	// Complete is true once all rows of the chunk were written.
+	Complete bool
+	// LastKey is the primary key of the last written row, if the chunk was
+	// partially cloned.
+	LastKey []*querypb.Value
+	// CopiedRows is the number of processed rows.
+	CopiedRows int
+}
+
+// lastKey returns the primary key after which a partially cloned chunk
+// continues, or nil.
+func (cp *chunkCheckpoint) lastKey() []sqltypes.Value {
+	return valuesFromCheckpoint(cp.LastKey)
+}
+
+// cloneCheckpointer loads and saves the checkpoints of a clone.
+type cloneCheckpointer struct {
+	ts  topo.Server
+	dir string
+
+	// mu protects the following fields, and serializes the saves.
+	mu     sync.Mutex
+	phases clonePhases
+	// phase is the current phase, and tables are its checkpoints by table.
+	phase  string
+	tables map[string]*tableCheckpoint
+}
+
+func newCloneCheckpointer(ts topo.Server, command, keyspace, shard string) *cloneCheckpointer {
+	return &cloneCheckpointer{
+		ts:     ts,
+		dir:    path.Join(cloneCheckpointsPath, command, keyspace, shard),
+		tables: make(map[string]*tableCheckpoint),
+	}
+}
+
+// init loads the checkpoints if "resume" is true, or removes them
+// otherwise.
+func (cc *cloneCheckpointer) init(ctx context.Context, resume bool) error {
+	if !resume {
+		return cc.remove(ctx)
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.load(ctx, path.Join(cc.dir, phasesFilename), &cc.phases)
+}
+
+// onlineDone returns true if the online clone was completed by a previous
+// run.
+func (cc *cloneCheckpointer) onlineDone() bool {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.phases.OnlineDone
+}
+
+// startPhase loads the table checkpoints of a phase. "sourcePositions" are
+// the positions of the offline source tablets, and must be nil for the
+// online phase. If they changed, the offline checkpoints are removed.
+func (cc *cloneCheckpointer) startPhase(ctx context.Context, phase string, sourcePositions []string) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.phase = phase
+	cc.tables = make(map[string]*tableCheckpoint)
+	if phase == offlinePhase && !reflect.DeepEqual(sourcePositions, cc.phases.OfflineSourcePositions) {
+		if err := cc.removeDir(ctx, path.Join(cc.dir, offlinePhase)); err != nil {
+			return err
+		}
+		cc.phases.OfflineSourcePositions = sourcePositions
+		return cc.save(ctx, path.Join(cc.dir, phasesFilename), &cc.phases)
+	}
+
+	tables, err := cc.ts.ListDir(ctx, topo.GlobalCell, path.Join(cc.dir, phase))
+	switch err {
+	case nil:
+	case topo.ErrNoNode:
+		return nil
+	default:
+		return err
+	}
+	for _, table := range tables {
+		tc := &tableCheckpoint{}
+		if err := cc.load(ctx, path.Join(cc.dir, phase, table), tc); err != nil {
+			return err
+		}
+		cc.tables[table] = tc
+	}
+	return nil
+}
+
+// finishPhase records that a phase is complete.
+func (cc *cloneCheckpointer) finishPhase(ctx context.Context, phase string) error {
+	if phase != onlinePhase {
+		// The offline phase is the last one, the checkpoints are
+		// removed when the command succeeded.
+		return nil
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.phases.OnlineDone = true
+	return cc.save(ctx, path.Join(cc.dir, phasesFilename), &cc.phases)
+}
+
+// tableChunks returns the chunks of a table and their checkpoints, in the
+// current phase. If the table has no checkpoint yet, the chunks are
+// generated by "generate", and saved.
+func (cc *cloneCheckpointer) tableChunks(ctx context.Context, table string, generate func() ([]chunk, error)) ([]chunk, []*chunkCheckpoint, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if tc, ok := cc.tables[table]; ok {
+		chunks := make([]chunk, len(tc.Chunks))
+		for i, c := range tc.Chunks {
+			chunks[i] = chunk{
+				start:  valuesFromCheckpoint(c.Start),
+				end:    valuesFromCheckpoint(c.End),
+				number: i + 1,
+				total:  len(tc.Chunks),
+			}
+		}
+		return chunks, tc.Chunks, nil
+	}
+
+	chunks, err := generate()
+	if err != nil {
+		return nil, nil, err
+	}
+	tc := &tableCheckpoint{
+		Chunks: make([]*chunkCheckpoint, len(chunks)),
+	}
+	for i, c := range chunks {
+		tc.Chunks[i] = &chunkCheckpoint{
+			Start: valuesToCheckpoint(c.start),
+			End:   valuesToCheckpoint(c.end),
+		}
+	}
+	if err := cc.save(ctx, path.Join(cc.dir, cc.phase, table), tc); err != nil {
+		return nil, nil, err
+	}
+	cc.tables[table] = tc
+	return chunks, tc.Chunks, nil
+}
+
+// chunkProgress saves the progress of a chunk. All rows up to and including
+// "lastKey" must have been written on the destinations.
+func (cc *cloneCheckpointer) chunkProgress(ctx context.Context, table string, c chunk, lastKey []sqltypes.Value, copiedRows int) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cp := cc.tables[table].Chunks[c.number-1]
+	cp.LastKey = valuesToCheckpoint(lastKey)
+	cp.CopiedRows = copiedRows
+	return cc.save(ctx, path.Join(cc.dir, cc.phase, table), cc.tables[table])
+}
+
+// chunkDone saves that all rows of a chunk were written on the
+// destinations.
+func (cc *cloneCheckpointer) chunkDone(ctx context.Context, table string, c chunk, copiedRows int) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cp := cc.tables[table].Chunks[c.number-1]
+	cp.Complete = true
+	cp.LastKey = nil
+	cp.CopiedRows = copiedRows
+	return cc.save(ctx, path.Join(cc.dir, cc.phase, table), cc.tables[table])
+}
+
+// remove removes all checkpoints.
+func (cc *cloneCheckpointer) remove(ctx context.Context) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	for _, phase := range []string{onlinePhase, offlinePhase} {
+		if err := cc.removeDir(ctx, path.Join(cc.dir, phase)); err != nil {
+			return err
+		}
+	}
+	if err := cc.ts.Delete(ctx, topo.GlobalCell, path.Join(cc.dir, phasesFilename), nil); err != nil && err != topo.ErrNoNode {
+		return err
+	}
+	cc.phases = clonePhases{}
+	cc.tables = make(map[string]*tableCheckpoint)
+	return nil
+}
+
+// removeDir removes the table checkpoints in a directory.
+func (cc *cloneCheckpointer) removeDir(ctx context.Context, dir string) error {
+	tables, err := cc.ts.ListDir(ctx, topo.GlobalCell, dir)
+	switch err {
+	case nil:
+	case topo.ErrNoNode:
+		return nil
+	default:
+		return err
+	}
+	for _, table := range tables {
+		if err := cc.ts.Delete(ctx, topo.GlobalCell, path.Join(dir, table), nil); err != nil && err != topo.ErrNoNode {
+			return err
+		}
+	}
+	return nil
+}
+
+// load reads a JSON file. "value" is not changed if the file doesn't exist.
+func (cc *cloneCheckpointer) load(ctx context.Context, filePath string, value interface{}) error {
+	contents, _, err := cc.ts.Get(ctx, topo.GlobalCell, filePath)
+	switch err {
+	case nil:
+	case topo.ErrNoNode:
+		return nil
+	default:
+		return err
+	}
+	if err := json.Unmarshal(contents, value); err != nil {
+		return fmt.Errorf("cannot read clone checkpoint %v: %v", filePath, err)
+	}
+	return nil
+}
+
+// save writes a JSON file, whether it exists or not.
+func (cc *cloneCheckpointer) save(ctx context.Context, filePath string, value interface{}) error {
+	contents, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = cc.ts.Update(ctx, topo.GlobalCell, filePath, contents, nil)
+	return err
+}
+
+func valuesToCheckpoint(values []sqltypes.Value) []*querypb.Value {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]*querypb.Value, len(values))
+	for i, v := range values {
+		result[i] = v.ToProtoValue()
+	}
+	return result
+}
+
+func valuesFromCheckpoint(values []*querypb.Value) []sqltypes.Value {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]sqltypes.Value, len(values))
+	for i, v := range values {
+		// The value was built from a sqltypes.Value, and is trusted.
+		result[i] = sqltypes.MakeTrusted(v.Type, v.Value)
+	}
+	return result
+}
+
+// waitForWrites waits until all the pending writes were executed.
+func waitForWrites(ctx context.Context, pendingWrites *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		pendingWrites.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/topo/memorytopo"
+)
+
+func TestCloneCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+
+	boundary := []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.VarChar, []byte("abc")),
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("100")),
+	}
+	want := []chunk{
+		{nil, boundary, 1, 2},
+		{boundary, nil, 2, 2},
+	}
+	generated := 0
+	generate := func() ([]chunk, error) {
+		generated++
+		return want, nil
+	}
+
+	// First run: the chunks are generated and saved.
+	cc := newCloneCheckpointer(ts, "SplitClone", "ks", "0")
+	if err := cc.init(ctx, false /* resume */); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	if err := cc.startPhase(ctx, onlinePhase, nil); err != nil {
+		t.Fatalf("startPhase failed: %v", err)
+	}
+	chunks, cps, err := cc.tableChunks(ctx, "t1", generate)
+	if err != nil {
+		t.Fatalf("tableChunks failed: %v", err)
+	}
+	if !reflect.DeepEqual(chunks, want) || len(cps) != 2 || generated != 1 {
+		t.Fatalf("tableChunks returned wrong chunks: %v %v, generated %v times", chunks, cps, generated)
+	}
+	lastKey := []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("42"))}
+	if err := cc.chunkProgress(ctx, "t1", chunks[0], lastKey, 42); err != nil {
+		t.Fatalf("chunkProgress failed: %v", err)
+	}
+	if err := cc.chunkDone(ctx, "t1", chunks[1], 10); err != nil {
+		t.Fatalf("chunkDone failed: %v", err)
+	}
+	if err := cc.finishPhase(ctx, onlinePhase); err != nil {
+		t.Fatalf("finishPhase failed: %v", err)
+	}
+	if err := cc.startPhase(ctx, offlinePhase, []string{"pos1"}); err != nil {
+		t.Fatalf("startPhase failed: %v", err)
+	}
+	if _, _, err := cc.tableChunks(ctx, "t1", generate); err != nil {
+		t.Fatalf("tableChunks failed: %v", err)
+	}
+
+	// Resumed run: the checkpoints are loaded.
+	cc = newCloneCheckpointer(ts, "SplitClone", "ks", "0")
+	if err := cc.init(ctx, true /* resume */); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	if !cc.onlineDone() {
+		t.Errorf("onlineDone() = false, want true")
+	}
+	if err := cc.startPhase(ctx, onlinePhase, nil); err != nil {
+		t.Fatalf("startPhase failed: %v", err)
+	}
+	chunks, cps, err = cc.tableChunks(ctx, "t1", func() ([]chunk, error) {
+		return nil, errors.New("chunks must not be generated again")
+	})
+	if err != nil {
+		t.Fatalf("tableChunks failed: %v", err)
+	}
+	if !reflect.DeepEqual(chunks, want) {
+		t.Errorf("tableChunks returned wrong chunks: got %v, want %v", chunks, want)
+	}
+	if cps[0].Complete || !reflect.DeepEqual(cps[0].lastKey(), lastKey) || cps[0].CopiedRows != 42 {
+		t.Errorf("wrong checkpoint for the partial chunk: %+v", cps[0])
+	}
+	if !cps[1].Complete || cps[1].lastKey() != nil || cps[1].CopiedRows != 10 {
+		t.Errorf("wrong checkpoint for the complete chunk: %+v", cps[1])
+	}
+
+	// The offline checkpoints are kept for the same source positions only.
+	if err := cc.startPhase(ctx, offlinePhase, []string{"pos1"}); err != nil {
+		t.Fatalf("startPhase failed: %v", err)
+	}
+	if _, ok := cc.tables["t1"]; !ok {
+		t.Errorf("offline checkpoint was not loaded for the same positions")
+	}
+	if err := cc.startPhase(ctx, offlinePhase, []string{"pos2"}); err != nil {
+		t.Fatalf("startPhase failed: %v", err)
+	}
+	if _, ok := cc.tables["t1"]; ok {
+		t.Errorf("offline checkpoint was loaded for different positions")
+	}
+
+	// Without -resume, the checkpoints are removed.
+	cc = newCloneCheckpointer(ts, "SplitClone", "ks", "0")
+	if err := cc.init(ctx, false /* resume */); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	if cc.onlineDone() {
+		t.Errorf("onlineDone() = true after the checkpoints were removed")
+	}
+	if err := cc.startPhase(ctx, onlinePhase, nil); err != nil {
+		t.Fatalf("startPhase failed: %v", err)
+	}
+	if len(cc.tables) != 0 {
+		t.Errorf("checkpoints were not removed: %v", cc.tables)
+	}
+}
@@ -5,6 +5,7 @@
 package worker
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"html/template"
@@ -103,28 +104,49 @@ func commandWorker(wi *Instance, wr *wrangler.Wrangler, args []string, cell stri
 }
 
 // RunCommand executes the vtworker command specified by "args". Use WaitForCommand() to block on the returned done channel.
+// The command runs as the job given by an optional "-job <name>" prefix of
+// "args", or as the default job. Jobs with different names run concurrently.
 // If wr is nil, the default wrangler will be used.
 // If you pass a wr wrangler, note that a MemoryLogger will be added to its current logger.
 // The returned worker and done channel may be nil if no worker was started e.g. in case of a "Reset".
 func (wi *Instance) RunCommand(ctx context.Context, args []string, wr *wrangler.Wrangler, runFromCli bool) (Worker, chan struct{}, error) {
-	if len(args) >= 1 {
-		switch args[0] {
-		case "Reset":
-			return nil, nil, wi.Reset()
-		case "Cancel":
-			wi.Cancel()
-			return nil, nil, nil
-		}
-	}
-
 	if wr == nil {
 		wr = wi.wr
 	}
+
+	jobFlags := flag.NewFlagSet("job", flag.ContinueOnError)
+	jobFlags.SetOutput(logutil.NewLoggerWriter(wr.Logger()))
+	name := jobFlags.String("job", defaultJobName, "name of the job which runs the command. Jobs with different names run concurrently.")
+	if err := jobFlags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	args = jobFlags.Args()
+	if len(args) == 0 {
+		return nil, nil, errors.New("no command was specified")
+	}
+
+	switch args[0] {
+	case "Reset":
+		return nil, nil, wi.Reset(*name)
+	case "Cancel":
+		wi.Cancel(*name)
+		return nil, nil, nil
+	case "CutOver":
+		return nil, nil, wi.CutOver(*name)
+	case "Status":
+		return nil, nil, wi.printJobStatus(wr.Logger(), *name)
+	case "Logs":
+		return nil, nil, wi.printJobLogs(wr.Logger(), *name)
+	case "ListJobs":
+		wi.printJobs(wr.Logger())
+		return nil, nil, nil
+	}
+
 	wrk, err := commandWorker(wi, wr, args, wi.cell, runFromCli)
 	if err != nil {
 		return nil, nil, err
 	}
-	done, err := wi.setAndStartWorker(ctx, wrk, wr)
+	done, err := wi.setAndStartWorker(ctx, *name, wrk, wr)
 	if err != nil {
 		return nil, nil, vterrors.WithPrefix("cannot set worker: ", err)
 	}
@@ -139,19 +161,68 @@ func (wi *Instance) WaitForCommand(wrk Worker, done chan struct{}) error {
 		select {
 		case <-done:
 			log.Info(wrk.StatusAsText())
-			wi.currentWorkerMutex.Lock()
-			err := wi.lastRunError
-			wi.currentWorkerMutex.Unlock()
-			if err != nil {
-				return err
-			}
-			return nil
+			return wi.jobResult(wrk)
 		case <-timer:
 			log.Info(wrk.StatusAsText())
 		}
 	}
 }
 
+// printJobs prints one line for each job, and the usage of the resource budget.
+func (wi *Instance) printJobs(logger logutil.Logger) {
+	for _, j := range wi.jobList() {
+		logger.Printf("%v: %v\n", j.name, wi.jobState(j))
+	}
+	logger.Printf("Resource budget: %v\n", wi.budget)
+}
+
+// printJobStatus prints the worker status of the job "name".
+func (wi *Instance) printJobStatus(logger logutil.Logger, name string) error {
+	j, err := wi.job(name)
+	if err != nil {
+		return err
+	}
+	logger.Printf("%v%v\n", j.worker.StatusAsText(), wi.jobState(j))
+	return nil
+}
+
+// printJobLogs prints the logs of the job "name".
+func (wi *Instance) printJobLogs(logger logutil.Logger, name string) error {
+	j, err := wi.job(name)
+	if err != nil {
+		return err
+	}
+	logger.Printf("%v", j.memoryLogger.String())
+	return nil
+}
+
+// job returns the job "name", or an error if there is no such job.
+func (wi *Instance) job(name string) (*job, error) {
+	wi.mu.Lock()
+	defer wi.mu.Unlock()
+
+	j, ok := wi.jobs[name]
+	if !ok {
+		return nil, fmt.Errorf("no job with the name: %v", name)
+	}
+	return j, nil
+}
+
+// jobState returns whether the job is running, or how it ended.
+func (wi *Instance) jobState(j *job) string {
+	wi.mu.Lock()
+	defer wi.mu.Unlock()
+
+	switch {
+	case j.ctx != nil:
+		return "running"
+	case j.err != nil:
+		return fmt.Sprintf("ended at %v with an error: %v", j.stopTime, j.err)
+	default:
+		return fmt.Sprintf("ended at %v", j.stopTime)
+	}
+}
+
 // PrintAllCommands prints a help text for all registered commands to the given Logger.
 func PrintAllCommands(logger logutil.Logger) {
 	for _, group := range commands {
@@ -164,4 +235,8 @@ func PrintAllCommands(logger logutil.Logger) {
 		}
 		logger.Printf("\n")
 	}
+	logger.Printf("Jobs: Prefix a command with \"-job <name>\" to run it as a named job. Jobs with different names run concurrently.\n")
+	logger.Printf("  [-job <name>] Status|Logs|Cancel|CutOver|Reset\n")
+	logger.Printf("  ListJobs\n")
+	logger.Printf("\n")
 }
@@ -4,7 +4,11 @@
 
 package worker
 
-import "github.com/gitql/vitess/go/vt/throttler"
+import (
+	"time"
+
+	"github.com/gitql/vitess/go/vt/throttler"
+)
 
 const (
 	defaultOnline  = true
@@ -39,4 +43,17 @@ const (
 	defaultMinHealthyRdonlyTablets = 2
 	defaultMaxTPS                  = throttler.MaxRateModuleDisabled
 	defaultMaxReplicationLag       = throttler.ReplicationLagModuleDisabled
+
+	// defaultParallelDiffsCount is the number of tables which are diffed in
+	// parallel by the TableDiff command.
+	defaultParallelDiffsCount = 8
+
+	// defaultExportSplitCount is the number of query parts in which the
+	// ExportKeyspace command splits each table on each shard. Each part is
+	// written to its own file.
+	defaultExportSplitCount = 8
+	// defaultExportWriteBlockTimeout is the maximum time for which the
+	// ExportKeyspace command blocks the writes on the masters, while the
+	// RDONLY tablets catch up with them.
+	defaultExportWriteBlockTimeout = 30 * time.Second
 )
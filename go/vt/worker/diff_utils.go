@@ -39,11 +39,20 @@ type QueryResultReader struct {
 	output sqltypes.ResultStream
 	fields []*querypb.Field
 	conn   queryservice.QueryService
+	// position is the replication position returned with the fields,
+	// e.g. the position of a consistent snapshot.
+	position string
 }
 
 // NewQueryResultReaderForTablet creates a new QueryResultReader for
 // the provided tablet / sql query
 func NewQueryResultReaderForTablet(ctx context.Context, ts topo.Server, tabletAlias *topodatapb.TabletAlias, sql string) (*QueryResultReader, error) {
+	return newQueryResultReaderForTablet(ctx, ts, tabletAlias, sql, nil /* options */)
+}
+
+// newQueryResultReaderForTablet is like NewQueryResultReaderForTablet,
+// but the query is sent with "options".
+func newQueryResultReaderForTablet(ctx context.Context, ts topo.Server, tabletAlias *topodatapb.TabletAlias, sql string, options *querypb.ExecuteOptions) (*QueryResultReader, error) {
 	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
 	tablet, err := ts.GetTablet(shortCtx, tabletAlias)
 	cancel()
@@ -60,7 +69,7 @@ func NewQueryResultReaderForTablet(ctx context.Context, ts topo.Server, tabletAl
 		Keyspace:   tablet.Tablet.Keyspace,
 		Shard:      tablet.Tablet.Shard,
 		TabletType: tablet.Tablet.Type,
-	}, sql, make(map[string]interface{}), nil)
+	}, sql, make(map[string]interface{}), options)
 
 	// read the columns, or grab the error
 	cols, err := stream.Recv()
@@ -68,11 +77,15 @@ func NewQueryResultReaderForTablet(ctx context.Context, ts topo.Server, tabletAl
 		return nil, fmt.Errorf("Cannot read Fields for query '%v': %v", sql, err)
 	}
 
-	return &QueryResultReader{
+	qrr := &QueryResultReader{
 		output: stream,
 		fields: cols.Fields,
 		conn:   conn,
-	}, nil
+	}
+	if cols.Extras != nil {
+		qrr.position = cols.Extras.Position
+	}
+	return qrr, nil
 }
 
 // Next returns the next result on the stream. It implements ResultReader.
@@ -177,11 +190,47 @@ func uint64FromKeyspaceID(keyspaceID []byte) uint64 {
 // table, ordered by Primary Key. The returned columns are ordered
 // with the Primary Key columns in front.
 func TableScan(ctx context.Context, log logutil.Logger, ts topo.Server, tabletAlias *topodatapb.TabletAlias, tableDefinition *tabletmanagerdatapb.TableDefinition) (*QueryResultReader, error) {
-	sql := fmt.Sprintf("SELECT %v FROM %v ORDER BY %v", strings.Join(escapeAll(orderedColumns(tableDefinition)), ", "), escape(tableDefinition.Name), strings.Join(escapeAll(tableDefinition.PrimaryKeyColumns), ", "))
+	return tableScanChunk(ctx, log, ts, tabletAlias, tableDefinition, completeChunk)
+}
+
+// tableScanChunk is like TableScan, but only gets the rows of a chunk.
+func tableScanChunk(ctx context.Context, log logutil.Logger, ts topo.Server, tabletAlias *topodatapb.TabletAlias, tableDefinition *tabletmanagerdatapb.TableDefinition, c chunk) (*QueryResultReader, error) {
+	sql := tableScanSQL(tableDefinition, c)
 	log.Infof("SQL query for %v/%v: %v", topoproto.TabletAliasString(tabletAlias), tableDefinition.Name, sql)
 	return NewQueryResultReaderForTablet(ctx, ts, tabletAlias, sql)
 }
 
+// tableScanSnapshot is like TableScan, but the rows are read from a
+// consistent snapshot of the table. The tablet first waits until it
+// replicated up to "waitForPosition", if set. The position of the
+// snapshot is in the position field of the returned reader.
+func tableScanSnapshot(ctx context.Context, log logutil.Logger, ts topo.Server, tabletAlias *topodatapb.TabletAlias, tableDefinition *tabletmanagerdatapb.TableDefinition, waitForPosition string) (*QueryResultReader, error) {
+	sql := tableScanSQL(tableDefinition, completeChunk)
+	log.Infof("SQL query for a snapshot of %v/%v at a minimum of %q: %v", topoproto.TabletAliasString(tabletAlias), tableDefinition.Name, waitForPosition, sql)
+	qrr, err := newQueryResultReaderForTablet(ctx, ts, tabletAlias, sql, &querypb.ExecuteOptions{
+		WaitForPosition:      waitForPosition,
+		TransactionIsolation: querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if qrr.position == "" {
+		qrr.Close(ctx)
+		return nil, fmt.Errorf("tablet %v did not return the position of the snapshot of table %v", topoproto.TabletAliasString(tabletAlias), tableDefinition.Name)
+	}
+	return qrr, nil
+}
+
+// tableScanSQL returns the query which reads the rows of a chunk,
+// ordered by primary key.
+func tableScanSQL(tableDefinition *tabletmanagerdatapb.TableDefinition, c chunk) string {
+	where := ""
+	if clauses := c.whereClauses(tableDefinition.PrimaryKeyColumns); len(clauses) > 0 {
+		where = "WHERE " + strings.Join(clauses, " AND ") + " "
+	}
+	return fmt.Sprintf("SELECT %v FROM %v %vORDER BY %v", strings.Join(escapeAll(orderedColumns(tableDefinition)), ", "), escape(tableDefinition.Name), where, strings.Join(escapeAll(tableDefinition.PrimaryKeyColumns), ", "))
+}
+
 // TableScanByKeyRange returns a QueryResultReader that gets all the
 // rows from a table that match the supplied KeyRange, ordered by
 // Primary Key. The returned columns are ordered with the Primary Key
@@ -190,6 +239,12 @@ func TableScan(ctx context.Context, log logutil.Logger, ts topo.Server, tabletAl
 // source data, and filter here. Otherwise we stick with v2 mode, where we can
 // ask the source tablet to do the filtering.
 func TableScanByKeyRange(ctx context.Context, log logutil.Logger, ts topo.Server, tabletAlias *topodatapb.TabletAlias, tableDefinition *tabletmanagerdatapb.TableDefinition, keyRange *topodatapb.KeyRange, keyspaceSchema *vindexes.KeyspaceSchema, shardingColumnName string, shardingColumnType topodatapb.KeyspaceIdType) (*QueryResultReader, error) {
+	return tableScanByKeyRangeChunk(ctx, log, ts, tabletAlias, tableDefinition, completeChunk, keyRange, keyspaceSchema, shardingColumnName, shardingColumnType)
+}
+
+// tableScanByKeyRangeChunk is like TableScanByKeyRange, but only gets the
+// rows of a chunk.
+func tableScanByKeyRangeChunk(ctx context.Context, log logutil.Logger, ts topo.Server, tabletAlias *topodatapb.TabletAlias, tableDefinition *tabletmanagerdatapb.TableDefinition, c chunk, keyRange *topodatapb.KeyRange, keyspaceSchema *vindexes.KeyspaceSchema, shardingColumnName string, shardingColumnType topodatapb.KeyspaceIdType) (*QueryResultReader, error) {
 	if keyspaceSchema != nil {
 		// switch to v3 mode.
 		keyResolver, err := newV3ResolverFromColumnList(keyspaceSchema, tableDefinition.Name, orderedColumns(tableDefinition))
@@ -198,7 +253,7 @@ func TableScanByKeyRange(ctx context.Context, log logutil.Logger, ts topo.Server
 		}
 
 		// full table scan
-		scan, err := TableScan(ctx, log, ts, tabletAlias, tableDefinition)
+		scan, err := tableScanChunk(ctx, log, ts, tabletAlias, tableDefinition, c)
 		if err != nil {
 			return nil, err
 		}
@@ -248,6 +303,14 @@ func TableScanByKeyRange(ctx context.Context, log logutil.Logger, ts topo.Server
 	default:
 		return nil, fmt.Errorf("Unsupported ShardingColumnType: %v", shardingColumnType)
 	}
+	if clauses := c.whereClauses(tableDefinition.PrimaryKeyColumns); len(clauses) > 0 {
+		if where == "" {
+			where = "WHERE "
+		} else {
+			where += "AND "
+		}
+		where += strings.Join(clauses, " AND ") + " "
+	}
 
 	sql := fmt.Sprintf("SELECT %v FROM %v %vORDER BY %v", strings.Join(escapeAll(orderedColumns(tableDefinition)), ", "), escape(tableDefinition.Name), where, strings.Join(escapeAll(tableDefinition.PrimaryKeyColumns), ", "))
 	log.Infof("SQL query for %v/%v: %v", topoproto.TabletAliasString(tabletAlias), tableDefinition.Name, sql)
@@ -414,6 +477,18 @@ func CompareRows(fields []*querypb.Field, compareCount int, left, right []sqltyp
 	return 0, nil
 }
 
+// Kinds of differences which are passed to a rowMismatchFunc.
+const (
+	mismatchContent    = "content"
+	mismatchExtraLeft  = "extra_left"
+	mismatchExtraRight = "extra_right"
+)
+
+// rowMismatchFunc is called by the RowDiffer for each row which differs.
+// "kind" is one of the mismatch* constants. For mismatchContent, "row" is the
+// left row.
+type rowMismatchFunc func(kind string, row []sqltypes.Value)
+
 // RowDiffer will consume rows on both sides, and compare them.
 // It assumes left and right are sorted by ascending primary key.
 // it will record errors if extra rows exist on either side.
@@ -421,10 +496,12 @@ type RowDiffer struct {
 	left         *RowReader
 	right        *RowReader
 	pkFieldCount int
+	// onMismatch is optional. If set, it is called for every difference.
+	onMismatch rowMismatchFunc
 }
 
 // NewRowDiffer returns a new RowDiffer
-func NewRowDiffer(left, right *QueryResultReader, tableDefinition *tabletmanagerdatapb.TableDefinition) (*RowDiffer, error) {
+func NewRowDiffer(left, right ResultReader, tableDefinition *tabletmanagerdatapb.TableDefinition) (*RowDiffer, error) {
 	leftFields := left.Fields()
 	rightFields := right.Fields()
 	if len(leftFields) != len(rightFields) {
@@ -477,20 +554,20 @@ func (rd *RowDiffer) Go(log logutil.Logger) (dr DiffReport, err error) {
 			}
 
 			// drain right, update count
-			if count, err := rd.right.Drain(); err != nil {
+			if count, err := rd.drain(rd.right, mismatchExtraRight, right); err != nil {
 				return dr, err
 			} else {
-				dr.extraRowsRight += 1 + count
+				dr.extraRowsRight += count
 			}
 			return
 		}
 		if right == nil {
 			// no more rows from the right
 			// we know we have rows from left, drain, update count
-			if count, err := rd.left.Drain(); err != nil {
+			if count, err := rd.drain(rd.left, mismatchExtraLeft, left); err != nil {
 				return dr, err
 			} else {
-				dr.extraRowsLeft += 1 + count
+				dr.extraRowsLeft += count
 			}
 			return
 		}
@@ -510,6 +587,7 @@ func (rd *RowDiffer) Go(log logutil.Logger) (dr DiffReport, err error) {
 			if dr.mismatchedRows < 10 {
 				log.Errorf("Different content %v in same PK: %v != %v", dr.mismatchedRows, left, right)
 			}
+			rd.reportMismatch(mismatchContent, left)
 			dr.mismatchedRows++
 			advanceLeft = true
 			advanceRight = true
@@ -525,6 +603,7 @@ func (rd *RowDiffer) Go(log logutil.Logger) (dr DiffReport, err error) {
 			if dr.extraRowsLeft < 10 {
 				log.Errorf("Extra row %v on left: %v", dr.extraRowsLeft, left)
 			}
+			rd.reportMismatch(mismatchExtraLeft, left)
 			dr.extraRowsLeft++
 			advanceLeft = true
 			continue
@@ -532,6 +611,7 @@ func (rd *RowDiffer) Go(log logutil.Logger) (dr DiffReport, err error) {
 			if dr.extraRowsRight < 10 {
 				log.Errorf("Extra row %v on right: %v", dr.extraRowsRight, right)
 			}
+			rd.reportMismatch(mismatchExtraRight, right)
 			dr.extraRowsRight++
 			advanceRight = true
 			continue
@@ -543,8 +623,37 @@ func (rd *RowDiffer) Go(log logutil.Logger) (dr DiffReport, err error) {
 		if dr.mismatchedRows < 10 {
 			log.Errorf("Different content %v in same PK: %v != %v", dr.mismatchedRows, left, right)
 		}
+		rd.reportMismatch(mismatchContent, left)
 		dr.mismatchedRows++
 		advanceLeft = true
 		advanceRight = true
 	}
 }
+
+func (rd *RowDiffer) reportMismatch(kind string, row []sqltypes.Value) {
+	if rd.onMismatch != nil {
+		rd.onMismatch(kind, row)
+	}
+}
+
+// drain consumes the remaining rows of "rr" which only exist on one side.
+// "row" is the current row of that side. It returns the number of rows
+// including "row".
+func (rd *RowDiffer) drain(rr *RowReader, kind string, row []sqltypes.Value) (int, error) {
+	if rd.onMismatch == nil {
+		count, err := rr.Drain()
+		return 1 + count, err
+	}
+
+	count := 0
+	for row != nil {
+		rd.onMismatch(kind, row)
+		count++
+		var err error
+		row, err = rr.Next()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,427 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/mysqlctl/tmutils"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// A dump is a directory with one file per table, shard and query part, and
+// a manifest which describes them. It is written by the ExportKeyspace
+// command and read by the ImportKeyspace command.
+//
+// In the "csv" format, the first line of each file has the column names.
+// NULL is written as \N. A value which starts with a backslash gets an
+// additional backslash to tell it apart from NULL.
+// In the "json" format, each line is a JSON object with the column names as
+// keys. Numbers are JSON numbers, NULL is null and all other values are JSON
+// strings.
+// In both formats, the values of binary columns (e.g. BLOB or VARBINARY) are
+// base64 encoded.
+const (
+	dumpFormatCSV  = "csv"
+	dumpFormatJSON = "json"
+
+	dumpManifestFile = "manifest.json"
+	csvNull          = `\N`
+)
+
+// dumpManifest describes the files of a dump.
+type dumpManifest struct {
+	Keyspace string       `json:"keyspace"`
+	Format   string       `json:"format"`
+	Shards   []*dumpShard `json:"shards"`
+	Tables   []*dumpTable `json:"tables"`
+}
+
+// dumpShard describes the tablet from which a shard was exported.
+type dumpShard struct {
+	Name   string `json:"name"`
+	Tablet string `json:"tablet"`
+	// Position is the replication position (GTID set) of the tablet while
+	// the shard was exported.
+	Position string `json:"position"`
+}
+
+// dumpTable describes an exported table.
+type dumpTable struct {
+	Name string `json:"name"`
+	// Schema is the CREATE TABLE statement of the table.
+	Schema            string   `json:"schema"`
+	Columns           []string `json:"columns"`
+	PrimaryKeyColumns []string `json:"primary_key_columns"`
+	// Types has the type (e.g. "INT64") of each column in Columns.
+	Types []string    `json:"types"`
+	Files []*dumpFile `json:"files"`
+}
+
+// dumpFile is a file with the rows of one query part.
+type dumpFile struct {
+	Shard string `json:"shard"`
+	// Path is relative to the dump directory.
+	Path string `json:"path"`
+	Rows int64  `json:"rows"`
+}
+
+// dumpFileName returns the name of the file with the rows of "table" which
+// were exported from "shard" with the query part "part".
+func dumpFileName(table, shard string, part int, format string) string {
+	return fmt.Sprintf("%v.%v.%04d.%v", table, shard, part, format)
+}
+
+func checkDumpFormat(format string) error {
+	if format != dumpFormatCSV && format != dumpFormatJSON {
+		return fmt.Errorf("unknown dump format: %v, must be %v or %v", format, dumpFormatCSV, dumpFormatJSON)
+	}
+	return nil
+}
+
+// writeDumpManifest writes the manifest to the dump directory "dir".
+func writeDumpManifest(dir string, manifest *dumpManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(dir, dumpManifestFile), data, 0644)
+}
+
+// readDumpManifest reads the manifest of the dump directory "dir".
+func readDumpManifest(dir string) (*dumpManifest, error) {
+	data, err := ioutil.ReadFile(path.Join(dir, dumpManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &dumpManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("cannot parse %v: %v", dumpManifestFile, err)
+	}
+	if err := checkDumpFormat(manifest.Format); err != nil {
+		return nil, err
+	}
+	for _, table := range manifest.Tables {
+		if len(table.Types) != len(table.Columns) {
+			return nil, fmt.Errorf("table %v has %v columns but %v types", table.Name, len(table.Columns), len(table.Types))
+		}
+	}
+	return manifest, nil
+}
+
+// tableDefinition returns the definition of the table.
+func (t *dumpTable) tableDefinition() *tabletmanagerdatapb.TableDefinition {
+	var rowCount uint64
+	for _, f := range t.Files {
+		rowCount += uint64(f.Rows)
+	}
+	return &tabletmanagerdatapb.TableDefinition{
+		Name:              t.Name,
+		Schema:            t.Schema,
+		Columns:           t.Columns,
+		PrimaryKeyColumns: t.PrimaryKeyColumns,
+		Type:              tmutils.TableBaseTable,
+		RowCount:          rowCount,
+	}
+}
+
+// setTypes records the column types from the fields of a result.
+func (t *dumpTable) setTypes(fields []*querypb.Field) {
+	t.Types = make([]string, len(fields))
+	for i, field := range fields {
+		t.Types[i] = field.Type.String()
+	}
+}
+
+// columnTypes parses the column types.
+func (t *dumpTable) columnTypes() ([]querypb.Type, error) {
+	types := make([]querypb.Type, len(t.Types))
+	for i, name := range t.Types {
+		typ, ok := querypb.Type_value[name]
+		if !ok {
+			return nil, fmt.Errorf("table %v has an unknown type %v for column %v", t.Name, name, t.Columns[i])
+		}
+		types[i] = querypb.Type(typ)
+	}
+	return types, nil
+}
+
+// dumpRowWriter writes rows to a dump file.
+type dumpRowWriter interface {
+	writeRow(row []sqltypes.Value) error
+	// close flushes the buffered rows and closes the file.
+	close() error
+}
+
+// newDumpRowWriter creates the file "name" and returns a writer for rows
+// with "columns" of "types".
+func newDumpRowWriter(format, name string, columns []string, types []querypb.Type) (dumpRowWriter, error) {
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case dumpFormatCSV:
+		w := &csvRowWriter{
+			file:   file,
+			w:      csv.NewWriter(file),
+			types:  types,
+			record: make([]string, len(columns)),
+		}
+		if err := w.w.Write(columns); err != nil {
+			file.Close()
+			return nil, err
+		}
+		return w, nil
+	case dumpFormatJSON:
+		buf := bufio.NewWriter(file)
+		return &jsonRowWriter{
+			file:    file,
+			buf:     buf,
+			enc:     json.NewEncoder(buf),
+			columns: columns,
+			types:   types,
+		}, nil
+	}
+	file.Close()
+	return nil, checkDumpFormat(format)
+}
+
+// csvRowWriter implements dumpRowWriter for the "csv" format.
+type csvRowWriter struct {
+	file   *os.File
+	w      *csv.Writer
+	types  []querypb.Type
+	record []string
+}
+
+func (w *csvRowWriter) writeRow(row []sqltypes.Value) error {
+	for i, v := range row {
+		w.record[i] = encodeCSVValue(w.types[i], v)
+	}
+	return w.w.Write(w.record)
+}
+
+func (w *csvRowWriter) close() error {
+	w.w.Flush()
+	err := w.w.Error()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func encodeCSVValue(typ querypb.Type, v sqltypes.Value) string {
+	if v.IsNull() {
+		return csvNull
+	}
+	var s string
+	if sqltypes.IsBinary(typ) {
+		s = base64.StdEncoding.EncodeToString(v.Raw())
+	} else {
+		s = v.String()
+	}
+	if strings.HasPrefix(s, `\`) {
+		return `\` + s
+	}
+	return s
+}
+
+func decodeCSVValue(typ querypb.Type, s string) (sqltypes.Value, error) {
+	if s == csvNull {
+		return sqltypes.NULL, nil
+	}
+	s = strings.TrimPrefix(s, `\`)
+	if sqltypes.IsBinary(typ) {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		return sqltypes.MakeTrusted(typ, b), nil
+	}
+	return sqltypes.MakeTrusted(typ, []byte(s)), nil
+}
+
+// jsonRowWriter implements dumpRowWriter for the "json" format.
+type jsonRowWriter struct {
+	file    *os.File
+	buf     *bufio.Writer
+	enc     *json.Encoder
+	columns []string
+	types   []querypb.Type
+}
+
+func (w *jsonRowWriter) writeRow(row []sqltypes.Value) error {
+	object := make(map[string]interface{}, len(row))
+	for i, v := range row {
+		switch typ := w.types[i]; {
+		case v.IsNull():
+			object[w.columns[i]] = nil
+		case sqltypes.IsIntegral(typ) || sqltypes.IsFloat(typ):
+			object[w.columns[i]] = json.Number(v.String())
+		case sqltypes.IsBinary(typ):
+			// encoding/json encodes []byte as base64.
+			object[w.columns[i]] = v.Raw()
+		default:
+			object[w.columns[i]] = v.String()
+		}
+	}
+	return w.enc.Encode(object)
+}
+
+func (w *jsonRowWriter) close() error {
+	err := w.buf.Flush()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// dumpRowReader reads the rows of a dump file.
+type dumpRowReader interface {
+	// readRow returns io.EOF after the last row.
+	readRow() ([]sqltypes.Value, error)
+	close() error
+}
+
+// openDumpRowReader opens the file "name" with rows of "columns" of "types".
+func openDumpRowReader(format, name string, columns []string, types []querypb.Type) (dumpRowReader, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case dumpFormatCSV:
+		r := &csvRowReader{
+			file:  file,
+			r:     csv.NewReader(bufio.NewReader(file)),
+			types: types,
+		}
+		r.r.FieldsPerRecord = len(columns)
+		header, err := r.r.Read()
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("cannot read the header of %v: %v", name, err)
+		}
+		if strings.Join(header, ",") != strings.Join(columns, ",") {
+			file.Close()
+			return nil, fmt.Errorf("file %v has the columns %v, want: %v", name, header, columns)
+		}
+		return r, nil
+	case dumpFormatJSON:
+		dec := json.NewDecoder(bufio.NewReader(file))
+		dec.UseNumber()
+		return &jsonRowReader{
+			file:    file,
+			dec:     dec,
+			columns: columns,
+			types:   types,
+		}, nil
+	}
+	file.Close()
+	return nil, checkDumpFormat(format)
+}
+
+// csvRowReader implements dumpRowReader for the "csv" format.
+type csvRowReader struct {
+	file  *os.File
+	r     *csv.Reader
+	types []querypb.Type
+}
+
+func (r *csvRowReader) readRow() ([]sqltypes.Value, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	row := make([]sqltypes.Value, len(record))
+	for i, s := range record {
+		row[i], err = decodeCSVValue(r.types[i], s)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode column %v: %v", i, err)
+		}
+	}
+	return row, nil
+}
+
+func (r *csvRowReader) close() error {
+	return r.file.Close()
+}
+
+// jsonRowReader implements dumpRowReader for the "json" format.
+type jsonRowReader struct {
+	file    *os.File
+	dec     *json.Decoder
+	columns []string
+	types   []querypb.Type
+}
+
+func (r *jsonRowReader) readRow() ([]sqltypes.Value, error) {
+	object := make(map[string]interface{})
+	if err := r.dec.Decode(&object); err != nil {
+		return nil, err
+	}
+	row := make([]sqltypes.Value, len(r.columns))
+	for i, column := range r.columns {
+		value, ok := object[column]
+		if !ok {
+			return nil, fmt.Errorf("row has no column %v: %v", column, object)
+		}
+		var s string
+		switch v := value.(type) {
+		case nil:
+			row[i] = sqltypes.NULL
+			continue
+		case json.Number:
+			s = v.String()
+		case string:
+			s = v
+		default:
+			return nil, fmt.Errorf("column %v has a value of unexpected type %T: %v", column, value, value)
+		}
+		if sqltypes.IsBinary(r.types[i]) {
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode column %v: %v", column, err)
+			}
+			row[i] = sqltypes.MakeTrusted(r.types[i], b)
+			continue
+		}
+		row[i] = sqltypes.MakeTrusted(r.types[i], []byte(s))
+	}
+	return row, nil
+}
+
+func (r *jsonRowReader) close() error {
+	return r.file.Close()
+}
+
+// readDumpFile calls "f" for each row of a dump file.
+func readDumpFile(r dumpRowReader, f func(row []sqltypes.Value) error) error {
+	for {
+		row, err := r.readRow()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(row); err != nil {
+			return err
+		}
+	}
+}
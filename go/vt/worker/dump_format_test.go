@@ -0,0 +1,141 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gitql/vitess/go/sqltypes"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+)
+
+func TestDumpFileRoundTrip(t *testing.T) {
+	columns := []string{"id", "msg", "data", "price"}
+	types := []querypb.Type{sqltypes.Int64, sqltypes.VarChar, sqltypes.Blob, sqltypes.Float64}
+	rows := [][]sqltypes.Value{
+		{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("a, \"quoted\"\nvalue")),
+			sqltypes.MakeTrusted(sqltypes.Blob, []byte{0, 1, 0xff}),
+			sqltypes.MakeTrusted(sqltypes.Float64, []byte("1.5")),
+		},
+		{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("2")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte(`\N`)),
+			sqltypes.NULL,
+			sqltypes.NULL,
+		},
+		{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("3")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("")),
+			sqltypes.MakeTrusted(sqltypes.Blob, []byte("")),
+			sqltypes.MakeTrusted(sqltypes.Float64, []byte("-2")),
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "dump_format_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, format := range []string{dumpFormatCSV, dumpFormatJSON} {
+		name := path.Join(dir, dumpFileName("t1", "-80", 1, format))
+		writer, err := newDumpRowWriter(format, name, columns, types)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rows {
+			if err := writer.writeRow(row); err != nil {
+				t.Fatalf("%v: writeRow failed: %v", format, err)
+			}
+		}
+		if err := writer.close(); err != nil {
+			t.Fatal(err)
+		}
+
+		reader, err := openDumpRowReader(format, name, columns, types)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got [][]sqltypes.Value
+		err = readDumpFile(reader, func(row []sqltypes.Value) error {
+			got = append(got, row)
+			return nil
+		})
+		reader.close()
+		if err != nil {
+			t.Fatalf("%v: readDumpFile failed: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("%v: wrong rows after round trip:\n%v\nwant:\n%v", format, got, rows)
+		}
+	}
+
+	// Check the encoding of the values in the JSON format.
+	data, err := ioutil.ReadFile(path.Join(dir, dumpFileName("t1", "-80", 1, dumpFormatJSON)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"data":null,"id":2,"msg":"\\N","price":null}`
+	if got := strings.Split(string(data), "\n")[1]; got != want {
+		t.Errorf("wrong JSON row: got = %v, want = %v", got, want)
+	}
+}
+
+func TestDumpManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump_format_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	want := &dumpManifest{
+		Keyspace: "ks",
+		Format:   dumpFormatCSV,
+		Shards: []*dumpShard{
+			{Name: "-80", Tablet: "cell1-0000000100", Position: "MariaDB/0-1-1"},
+			{Name: "80-", Tablet: "cell1-0000000200", Position: "MariaDB/0-2-5"},
+		},
+		Tables: []*dumpTable{
+			{
+				Name:              "t1",
+				Schema:            "CREATE TABLE `t1` (...)",
+				Columns:           []string{"id", "msg"},
+				PrimaryKeyColumns: []string{"id"},
+				Types:             []string{"INT64", "VARCHAR"},
+				Files: []*dumpFile{
+					{Shard: "-80", Path: "t1.-80.0000.csv", Rows: 10},
+				},
+			},
+		},
+	}
+	if err := writeDumpManifest(dir, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readDumpManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readDumpManifest() = %+v, want = %+v", got, want)
+	}
+	types, err := got.Tables[0].columnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(types, []querypb.Type{sqltypes.Int64, sqltypes.VarChar}) {
+		t.Errorf("wrong column types: %v", types)
+	}
+	if got, want := got.Tables[0].tableDefinition().RowCount, uint64(10); got != want {
+		t.Errorf("wrong row count: got = %v, want = %v", got, want)
+	}
+}
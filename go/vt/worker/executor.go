@@ -46,9 +46,18 @@ func newExecutor(wr *wrangler.Wrangler, tsc *discovery.TabletStatsCache, throttl
 	}
 }
 
+// insertCommand is a write query which is sent to the writer threads of a
+// destination shard.
+type insertCommand struct {
+	sql string
+	// done is called after the query was executed, if set. It is not called
+	// if the query failed.
+	done func()
+}
+
 // fetchLoop loops over the provided insertChannel and sends the commands to the
 // current master.
-func (e *executor) fetchLoop(ctx context.Context, insertChannel chan string) error {
+func (e *executor) fetchLoop(ctx context.Context, insertChannel chan insertCommand) error {
 	for {
 		select {
 		case cmd, ok := <-insertChannel:
@@ -56,9 +65,17 @@ func (e *executor) fetchLoop(ctx context.Context, insertChannel chan string) err
 				// no more to read, we're done
 				return nil
 			}
-			if err := e.fetchWithRetries(ctx, cmd); err != nil {
+			if err := acquireDestinationWriter(ctx); err != nil {
 				return fmt.Errorf("ExecuteFetch failed: %v", err)
 			}
+			err := e.fetchWithRetries(ctx, cmd.sql)
+			releaseDestinationWriter(ctx)
+			if err != nil {
+				return fmt.Errorf("ExecuteFetch failed: %v", err)
+			}
+			if cmd.done != nil {
+				cmd.done()
+			}
 		case <-ctx.Done():
 			// Doesn't really matter if this select gets starved, because the other case
 			// will also return an error due to executeFetch's context being closed. This case
@@ -90,8 +107,8 @@ func (e *executor) fetchWithRetries(ctx context.Context, command string) error {
 		masters := e.tsc.GetHealthyTabletStats(e.keyspace, e.shard, topodatapb.TabletType_MASTER)
 		if len(masters) == 0 {
 			e.wr.Logger().Warningf("ExecuteFetch failed for keyspace/shard %v/%v because no MASTER is available; will retry until there is MASTER again", e.keyspace, e.shard)
-			statsRetryCount.Add(1)
-			statsRetryCounters.Add(retryCategoryNoMasterAvailable, 1)
+			statsRetryCount.Add(jobNameFromContext(ctx), 1)
+			statsRetryCounters.Add([]string{jobNameFromContext(ctx), retryCategoryNoMasterAvailable}, 1)
 			goto retry
 		}
 		master = &masters[0]
@@ -103,7 +120,7 @@ func (e *executor) fetchWithRetries(ctx context.Context, command string) error {
 				if backoff == throttler.NotThrottled {
 					break
 				}
-				statsThrottledCounters.Add(e.statsKey, 1)
+				statsThrottledCounters.Add(append([]string{jobNameFromContext(ctx)}, e.statsKey...), 1)
 				time.Sleep(backoff)
 			}
 		}
@@ -162,8 +179,8 @@ func (e *executor) checkError(ctx context.Context, err error, isRetry bool, mast
 	case <-ctx.Done():
 		if ctx.Err() == context.DeadlineExceeded {
 			e.wr.Logger().Warningf("ExecuteFetch failed on %v; will retry because it was a timeout error on the context", tabletString)
-			statsRetryCount.Add(1)
-			statsRetryCounters.Add(retryCategoryTimeoutError, 1)
+			statsRetryCount.Add(jobNameFromContext(ctx), 1)
+			statsRetryCounters.Add([]string{jobNameFromContext(ctx), retryCategoryTimeoutError}, 1)
 			return false, nil
 		}
 	default:
@@ -179,8 +196,8 @@ func (e *executor) checkError(ctx context.Context, err error, isRetry bool, mast
 	switch {
 	case errNo == "1290":
 		e.wr.Logger().Warningf("ExecuteFetch failed on %v; will reresolve and retry because it's due to a MySQL read-only error: %v", tabletString, err)
-		statsRetryCount.Add(1)
-		statsRetryCounters.Add(retryCategoryReadOnly, 1)
+		statsRetryCount.Add(jobNameFromContext(ctx), 1)
+		statsRetryCounters.Add([]string{jobNameFromContext(ctx), retryCategoryReadOnly}, 1)
 	case errNo == "2002" || errNo == "2006" || errNo == "2013":
 		// Note:
 		// "2006" happens if the connection is already dead. Retrying a query in
@@ -191,8 +208,8 @@ func (e *executor) checkError(ctx context.Context, err error, isRetry bool, mast
 		// assume that the previous execution was successful and ignore the error.
 		// See below for the handling of duplicate entry error "1062".
 		e.wr.Logger().Warningf("ExecuteFetch failed on %v; will reresolve and retry because it's due to a MySQL connection error: %v", tabletString, err)
-		statsRetryCount.Add(1)
-		statsRetryCounters.Add(retryCategoryConnectionError, 1)
+		statsRetryCount.Add(jobNameFromContext(ctx), 1)
+		statsRetryCounters.Add([]string{jobNameFromContext(ctx), retryCategoryConnectionError}, 1)
 	case errNo == "1062":
 		if !isRetry {
 			return false, fmt.Errorf("ExecuteFetch failed on %v on the first attempt; not retrying as this is not a recoverable error: %v", tabletString, err)
@@ -0,0 +1,629 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/mysqlconn/replication"
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/concurrency"
+	"github.com/gitql/vitess/go/vt/tabletserver/queryservice"
+	"github.com/gitql/vitess/go/vt/tabletserver/querytypes"
+	"github.com/gitql/vitess/go/vt/tabletserver/tabletconn"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+	"github.com/gitql/vitess/go/vt/wrangler"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// ExportKeyspaceWorker exports tables from all shards of a keyspace to files
+// in a local directory (see dump_format.go).
+// It uses one RDONLY tablet per shard, which is changed to DRAINED for the
+// duration of the export. The writes on the masters are briefly blocked,
+// and the replication of each tablet is stopped at the position of its
+// master. The replication stays stopped until the export is done.
+// Therefore, the export is a consistent snapshot of the keyspace, and the
+// position of each shard is recorded in the manifest. Each table is split
+// into query parts with SplitQuery and the parts are streamed in parallel
+// with StreamExecute.
+type ExportKeyspaceWorker struct {
+	StatusWorker
+
+	wr                      *wrangler.Wrangler
+	cell                    string
+	keyspace                string
+	directory               string
+	format                  string
+	tables                  []string
+	excludeTables           []string
+	splitCount              int
+	sourceReaderCount       int
+	minHealthyRdonlyTablets int
+	writeBlockTimeout       time.Duration
+	cleaner                 *wrangler.Cleaner
+
+	// populated during WorkerStateInit, read-only after that
+	shards []*topo.ShardInfo
+
+	// populated during WorkerStateFindTargets, read-only after that
+	tablets []*topodatapb.Tablet
+
+	// populated during WorkerStateSyncReplication, read-only after that
+	manifest *dumpManifest
+
+	// populated during WorkerStateCloneOffline
+	schemaDefinition *tabletmanagerdatapb.SchemaDefinition
+	tableStatusList  *tableStatusList
+	// mu guards the Types and Files of the tables in the manifest.
+	mu sync.Mutex
+}
+
+// exportPart is a query part of a table on a shard.
+type exportPart struct {
+	tableIndex int
+	shardIndex int
+	part       int
+	split      querytypes.QuerySplit
+}
+
+// newExportKeyspaceWorker returns a new worker object for the
+// ExportKeyspace command.
+func newExportKeyspaceWorker(wr *wrangler.Wrangler, cell, keyspace, directory, format string, tables, excludeTables []string, splitCount, sourceReaderCount, minHealthyRdonlyTablets int, writeBlockTimeout time.Duration) (Worker, error) {
+	if directory == "" {
+		return nil, fmt.Errorf("the directory of the export must be set")
+	}
+	if err := checkDumpFormat(format); err != nil {
+		return nil, err
+	}
+	if splitCount <= 0 {
+		return nil, fmt.Errorf("split_count must be > 0: %v", splitCount)
+	}
+	if sourceReaderCount <= 0 {
+		return nil, fmt.Errorf("source_reader_count must be > 0: %v", sourceReaderCount)
+	}
+	if writeBlockTimeout <= 0 {
+		return nil, fmt.Errorf("write_block_timeout must be > 0: %v", writeBlockTimeout)
+	}
+
+	return &ExportKeyspaceWorker{
+		StatusWorker:            NewStatusWorker(),
+		wr:                      wr,
+		cell:                    cell,
+		keyspace:                keyspace,
+		directory:               directory,
+		format:                  format,
+		tables:                  tables,
+		excludeTables:           excludeTables,
+		splitCount:              splitCount,
+		sourceReaderCount:       sourceReaderCount,
+		minHealthyRdonlyTablets: minHealthyRdonlyTablets,
+		writeBlockTimeout:       writeBlockTimeout,
+		cleaner:                 &wrangler.Cleaner{},
+
+		tableStatusList: &tableStatusList{},
+	}, nil
+}
+
+// StatusAsHTML implements the Worker interface.
+func (w *ExportKeyspaceWorker) StatusAsHTML() template.HTML {
+	return template.HTML(strings.Replace(w.status(), "\n", "</br>\n", -1))
+}
+
+// StatusAsText implements the Worker interface.
+func (w *ExportKeyspaceWorker) StatusAsText() string {
+	return w.status()
+}
+
+func (w *ExportKeyspaceWorker) status() string {
+	state := w.State()
+
+	result := "Working on: " + w.keyspace + " to " + w.directory + "\n"
+	result += "State: " + state.String() + "\n"
+	switch state {
+	case WorkerStateCloneOffline:
+		statuses, eta := w.tableStatusList.format()
+		result += "Running:\n"
+		result += "Exporting tables (ETA: " + eta.String() + "):\n"
+		result += strings.Join(statuses, "\n") + "\n"
+	case WorkerStateDone:
+		statuses, _ := w.tableStatusList.format()
+		result += "Success:\n"
+		result += strings.Join(statuses, "\n") + "\n"
+	}
+	return result
+}
+
+// Run is mostly a wrapper to run the cleanup at the end.
+func (w *ExportKeyspaceWorker) Run(ctx context.Context) error {
+	resetVars(ctx)
+	err := w.run(ctx)
+
+	w.SetState(WorkerStateCleanUp)
+	cerr := w.cleaner.CleanUp(w.wr)
+	if cerr != nil {
+		if err != nil {
+			w.wr.Logger().Errorf("CleanUp failed in addition to job error: %v", cerr)
+		} else {
+			err = cerr
+		}
+	}
+	if err != nil {
+		w.SetState(WorkerStateError)
+		return err
+	}
+	w.SetState(WorkerStateDone)
+	return nil
+}
+
+func (w *ExportKeyspaceWorker) run(ctx context.Context) error {
+	// first state: read what we need to do
+	if err := w.init(ctx); err != nil {
+		return fmt.Errorf("init() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// second state: find targets
+	if err := w.findTargets(ctx); err != nil {
+		return fmt.Errorf("findTargets() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// third phase: stop the replication of all targets at a consistent
+	// set of positions
+	if err := w.stopReplication(ctx); err != nil {
+		return fmt.Errorf("stopReplication() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// fourth phase: export the tables and write the manifest
+	if err := w.export(ctx); err != nil {
+		return fmt.Errorf("export() failed: %v", err)
+	}
+	return nil
+}
+
+// init phase:
+// - read the shards of the keyspace
+// - create the directory of the export
+func (w *ExportKeyspaceWorker) init(ctx context.Context) error {
+	w.SetState(WorkerStateInit)
+
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	shardMap, err := w.wr.TopoServer().FindAllShardsInKeyspace(shortCtx, w.keyspace)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot read the shards of keyspace %v: %v", w.keyspace, err)
+	}
+	if len(shardMap) == 0 {
+		return fmt.Errorf("keyspace %v has no shards", w.keyspace)
+	}
+	var shardNames []string
+	for shard := range shardMap {
+		shardNames = append(shardNames, shard)
+	}
+	sort.Strings(shardNames)
+	for _, shard := range shardNames {
+		w.shards = append(w.shards, shardMap[shard])
+	}
+
+	if _, err := os.Stat(path.Join(w.directory, dumpManifestFile)); err == nil {
+		return fmt.Errorf("directory %v already has an export", w.directory)
+	}
+	if err := os.MkdirAll(w.directory, 0755); err != nil {
+		return fmt.Errorf("cannot create directory %v: %v", w.directory, err)
+	}
+	return nil
+}
+
+// findTargets phase:
+// - find one healthy RDONLY tablet in each shard
+// - change it to DRAINED, so it doesn't serve stale queries
+func (w *ExportKeyspaceWorker) findTargets(ctx context.Context) error {
+	w.SetState(WorkerStateFindTargets)
+
+	for _, si := range w.shards {
+		alias, err := FindWorkerTablet(ctx, w.wr, w.cleaner, nil /* tsc */, w.cell, si.Keyspace(), si.ShardName(), w.minHealthyRdonlyTablets)
+		if err != nil {
+			return fmt.Errorf("FindWorkerTablet() failed for %v/%v/%v: %v", w.cell, si.Keyspace(), si.ShardName(), err)
+		}
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		ti, err := w.wr.TopoServer().GetTablet(shortCtx, alias)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("cannot read tablet %v: %v", topoproto.TabletAliasString(alias), err)
+		}
+		w.wr.Logger().Infof("Using tablet %v for %v/%v", topoproto.TabletAliasString(alias), si.Keyspace(), si.ShardName())
+		w.tablets = append(w.tablets, ti.Tablet)
+	}
+	return nil
+}
+
+// stopReplication phase:
+// - block the writes on the masters of all shards by making them read-only
+// - read the position of each master
+// - stop the replication of each tablet at the position of its master
+// - record the positions in the manifest
+// - allow the writes on the masters again
+// As no master accepts writes while the positions are read, the positions
+// of all shards are consistent with each other. The writes are blocked for
+// at most writeBlockTimeout, which bounds how long the tablets may take to
+// catch up. The replication is restarted by the cleaner at the end of the
+// command.
+func (w *ExportKeyspaceWorker) stopReplication(ctx context.Context) (err error) {
+	w.SetState(WorkerStateSyncReplication)
+
+	masters := make([]*topodatapb.Tablet, len(w.shards))
+	for i, si := range w.shards {
+		if si.MasterAlias == nil {
+			return fmt.Errorf("shard %v/%v has no master", si.Keyspace(), si.ShardName())
+		}
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		ti, err := w.wr.TopoServer().GetTablet(shortCtx, si.MasterAlias)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("cannot read master tablet %v: %v", topoproto.TabletAliasString(si.MasterAlias), err)
+		}
+		masters[i] = ti.Tablet
+	}
+
+	// The writes are allowed again whatever happens, even if only
+	// some masters were made read-only.
+	defer func() {
+		if werr := w.allowWrites(masters); werr != nil && err == nil {
+			err = werr
+		}
+	}()
+	rec := &concurrency.AllErrorRecorder{}
+	wg := sync.WaitGroup{}
+	for _, master := range masters {
+		wg.Add(1)
+		go func(master *topodatapb.Tablet) {
+			defer wg.Done()
+			shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+			err := w.wr.TabletManagerClient().SetReadOnly(shortCtx, master)
+			cancel()
+			if err != nil {
+				rec.RecordError(fmt.Errorf("cannot make master %v read-only: %v", topoproto.TabletAliasString(master.Alias), err))
+			}
+		}(master)
+	}
+	wg.Wait()
+	if rec.HasErrors() {
+		return rec.Error()
+	}
+	w.wr.Logger().Infof("Blocked the writes on the masters of %v shards", len(masters))
+
+	w.manifest = &dumpManifest{
+		Keyspace: w.keyspace,
+		Format:   w.format,
+		Shards:   make([]*dumpShard, len(w.shards)),
+	}
+	for i, tablet := range w.tablets {
+		wg.Add(1)
+		go func(i int, master, tablet *topodatapb.Tablet) {
+			defer wg.Done()
+			shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+			position, err := w.wr.TabletManagerClient().MasterPosition(shortCtx, master)
+			cancel()
+			if err != nil {
+				rec.RecordError(fmt.Errorf("cannot read the position of master %v: %v", topoproto.TabletAliasString(master.Alias), err))
+				return
+			}
+
+			alias := topoproto.TabletAliasString(tablet.Alias)
+			shortCtx, cancel = context.WithTimeout(ctx, w.writeBlockTimeout)
+			stoppedAt, err := w.wr.TabletManagerClient().StopSlaveMinimum(shortCtx, tablet, position, w.writeBlockTimeout)
+			cancel()
+			if err != nil {
+				rec.RecordError(fmt.Errorf("cannot stop slave %v at position %v: %v", alias, position, err))
+				return
+			}
+			wrangler.RecordStartSlaveAction(w.cleaner, tablet)
+			// Only writes which bypass read-only (e.g. of a super
+			// user) can take the tablet past the master position.
+			equal, err := equalPositions(stoppedAt, position)
+			if err != nil {
+				rec.RecordError(fmt.Errorf("cannot compare the positions of tablet %v and its master: %v", alias, err))
+				return
+			}
+			if !equal {
+				rec.RecordError(fmt.Errorf("tablet %v stopped at position %v instead of the master position %v", alias, stoppedAt, position))
+				return
+			}
+			w.wr.Logger().Infof("Stopped replication on tablet %v at position %v", alias, stoppedAt)
+			w.manifest.Shards[i] = &dumpShard{
+				Name:     tablet.Shard,
+				Tablet:   alias,
+				Position: stoppedAt,
+			}
+		}(i, masters[i], tablet)
+	}
+	wg.Wait()
+	return rec.Error()
+}
+
+// allowWrites makes the masters read-write again. It doesn't use the
+// context of the command, as it must run even if it was canceled.
+func (w *ExportKeyspaceWorker) allowWrites(masters []*topodatapb.Tablet) error {
+	rec := &concurrency.AllErrorRecorder{}
+	wg := sync.WaitGroup{}
+	for _, master := range masters {
+		wg.Add(1)
+		go func(master *topodatapb.Tablet) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), *remoteActionsTimeout)
+			err := w.wr.TabletManagerClient().SetReadWrite(ctx, master)
+			cancel()
+			if err != nil {
+				rec.RecordError(fmt.Errorf("cannot make master %v read-write again, it must be fixed manually: %v", topoproto.TabletAliasString(master.Alias), err))
+			}
+		}(master)
+	}
+	wg.Wait()
+	if rec.HasErrors() {
+		return rec.Error()
+	}
+	w.wr.Logger().Infof("Allowed the writes on the masters again")
+	return nil
+}
+
+// equalPositions returns true if both replication positions are the same.
+func equalPositions(left, right string) (bool, error) {
+	leftPosition, err := replication.DecodePosition(left)
+	if err != nil {
+		return false, err
+	}
+	rightPosition, err := replication.DecodePosition(right)
+	if err != nil {
+		return false, err
+	}
+	return leftPosition.Equal(rightPosition), nil
+}
+
+// export phase:
+// - read the schema of the tables from the first tablet
+// - split each table on each shard into query parts
+// - stream each query part into its own file
+// - write the manifest
+func (w *ExportKeyspaceWorker) export(ctx context.Context) error {
+	w.SetState(WorkerStateCloneOffline)
+	start := time.Now()
+	defer func() {
+		statsStateDurationsNs.Set([]string{jobNameFromContext(ctx), string(WorkerStateCloneOffline)}, time.Now().Sub(start).Nanoseconds())
+	}()
+
+	var err error
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	w.schemaDefinition, err = w.wr.GetSchema(shortCtx, w.tablets[0].Alias, w.tables, w.excludeTables, false /* includeViews */)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot get schema from tablet %v: %v", topoproto.TabletAliasString(w.tablets[0].Alias), err)
+	}
+	if len(w.schemaDefinition.TableDefinitions) == 0 {
+		return fmt.Errorf("no tables matching the table filter in keyspace %v", w.keyspace)
+	}
+	for i, td := range w.schemaDefinition.TableDefinitions {
+		if len(td.PrimaryKeyColumns) == 0 {
+			return fmt.Errorf("table %v has no primary key and cannot be split", td.Name)
+		}
+		td = reorderColumnsPrimaryKeyFirst(td)
+		w.schemaDefinition.TableDefinitions[i] = td
+		w.manifest.Tables = append(w.manifest.Tables, &dumpTable{
+			Name:              td.Name,
+			Schema:            td.Schema,
+			Columns:           td.Columns,
+			PrimaryKeyColumns: td.PrimaryKeyColumns,
+		})
+	}
+	w.tableStatusList.initialize(w.schemaDefinition)
+
+	conns := make([]queryservice.QueryService, len(w.tablets))
+	defer func() {
+		for _, conn := range conns {
+			if conn != nil {
+				conn.Close(ctx)
+			}
+		}
+	}()
+	for i, tablet := range w.tablets {
+		conns[i], err = tabletconn.GetDialer()(tablet, *remoteActionsTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to get dialer for tablet %v: %v", topoproto.TabletAliasString(tablet.Alias), err)
+		}
+	}
+
+	// Split all tables first to know the number of parts of each table.
+	parts := make([][]*exportPart, len(w.tablets))
+	for tableIndex, td := range w.schemaDefinition.TableDefinitions {
+		threadCount := 0
+		for shardIndex, tablet := range w.tablets {
+			splits, err := w.splitQuery(ctx, conns[shardIndex], tablet, td)
+			if err != nil {
+				return err
+			}
+			for part, split := range splits {
+				parts[shardIndex] = append(parts[shardIndex], &exportPart{
+					tableIndex: tableIndex,
+					shardIndex: shardIndex,
+					part:       part,
+					split:      split,
+				})
+			}
+			threadCount += len(splits)
+		}
+		w.tableStatusList.setThreadCount(tableIndex, threadCount)
+	}
+
+	// mu protects the context for cancelation, and firstError
+	mu := sync.Mutex{}
+	var firstError error
+
+	ctx, cancelExport := context.WithCancel(ctx)
+	defer cancelExport()
+	processError := func(format string, args ...interface{}) {
+		w.wr.Logger().Errorf(format, args...)
+		mu.Lock()
+		if firstError == nil {
+			firstError = fmt.Errorf(format, args...)
+			cancelExport()
+		}
+		mu.Unlock()
+	}
+
+	// Each shard streams up to sourceReaderCount parts at the same time.
+	wg := sync.WaitGroup{}
+	for shardIndex := range w.tablets {
+		partChannel := make(chan *exportPart, len(parts[shardIndex]))
+		for _, p := range parts[shardIndex] {
+			partChannel <- p
+		}
+		close(partChannel)
+
+		for i := 0; i < w.sourceReaderCount; i++ {
+			wg.Add(1)
+			go func(conn queryservice.QueryService, partChannel chan *exportPart) {
+				defer wg.Done()
+				for p := range partChannel {
+					if err := checkDone(ctx); err != nil {
+						return
+					}
+					if err := w.exportPart(ctx, conn, p); err != nil {
+						processError("table=%v shard=%v part=%v: %v", w.manifest.Tables[p.tableIndex].Name, w.tablets[p.shardIndex].Shard, p.part, err)
+						return
+					}
+				}
+			}(conns[shardIndex], partChannel)
+		}
+	}
+	wg.Wait()
+	if firstError != nil {
+		return firstError
+	}
+
+	for _, table := range w.manifest.Tables {
+		sort.Sort(dumpFilesByPath(table.Files))
+	}
+	if err := writeDumpManifest(w.directory, w.manifest); err != nil {
+		return fmt.Errorf("cannot write the manifest: %v", err)
+	}
+	w.wr.Logger().Infof("Exported %v tables of keyspace %v to %v", len(w.manifest.Tables), w.keyspace, w.directory)
+	return nil
+}
+
+// splitQuery splits the full table scan of "td" on "tablet" into query parts.
+func (w *ExportKeyspaceWorker) splitQuery(ctx context.Context, conn queryservice.QueryService, tablet *topodatapb.Tablet, td *tabletmanagerdatapb.TableDefinition) ([]querytypes.QuerySplit, error) {
+	query := querytypes.BoundQuery{
+		Sql:           "SELECT " + strings.Join(escapeAll(td.Columns), ", ") + " FROM " + escape(td.Name),
+		BindVariables: make(map[string]interface{}),
+	}
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	splits, err := conn.SplitQuery(shortCtx, exportTarget(tablet), query, nil /* splitColumns */, int64(w.splitCount), 0 /* numRowsPerQueryPart */, querypb.SplitQueryRequest_FULL_SCAN)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("SplitQuery for table %v failed on tablet %v: %v", td.Name, topoproto.TabletAliasString(tablet.Alias), err)
+	}
+	return splits, nil
+}
+
+// exportPart streams the rows of a query part into a new file.
+func (w *ExportKeyspaceWorker) exportPart(ctx context.Context, conn queryservice.QueryService, p *exportPart) error {
+	if err := acquireSourceReader(ctx); err != nil {
+		return err
+	}
+	defer releaseSourceReader(ctx)
+
+	table := w.manifest.Tables[p.tableIndex]
+	tablet := w.tablets[p.shardIndex]
+	w.tableStatusList.threadStarted(p.tableIndex)
+
+	name := dumpFileName(table.Name, tablet.Shard, p.part, w.format)
+	var writer dumpRowWriter
+	var rows int64
+	err := conn.StreamExecute(ctx, exportTarget(tablet), p.split.Sql, p.split.BindVariables, nil /* options */, func(qr *sqltypes.Result) error {
+		if writer == nil {
+			if qr.Fields == nil {
+				return fmt.Errorf("the first result has no fields")
+			}
+			types := make([]querypb.Type, len(qr.Fields))
+			for i, field := range qr.Fields {
+				types[i] = field.Type
+			}
+			var err error
+			writer, err = newDumpRowWriter(w.format, path.Join(w.directory, name), table.Columns, types)
+			if err != nil {
+				return err
+			}
+			w.mu.Lock()
+			if table.Types == nil {
+				table.setTypes(qr.Fields)
+			}
+			w.mu.Unlock()
+		}
+		for _, row := range qr.Rows {
+			if err := writer.writeRow(row); err != nil {
+				return fmt.Errorf("cannot write to %v: %v", name, err)
+			}
+		}
+		rows += int64(len(qr.Rows))
+		w.tableStatusList.addCopiedRows(p.tableIndex, len(qr.Rows))
+		return nil
+	})
+	if writer != nil {
+		if cerr := writer.close(); err == nil && cerr != nil {
+			err = fmt.Errorf("cannot write to %v: %v", name, cerr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if writer == nil {
+		return fmt.Errorf("the query returned no result: %v", p.split.Sql)
+	}
+
+	w.mu.Lock()
+	table.Files = append(table.Files, &dumpFile{
+		Shard: tablet.Shard,
+		Path:  name,
+		Rows:  rows,
+	})
+	w.mu.Unlock()
+	w.tableStatusList.threadDone(p.tableIndex)
+	return nil
+}
+
+// exportTarget returns the target for the queries on "tablet".
+func exportTarget(tablet *topodatapb.Tablet) *querypb.Target {
+	return &querypb.Target{
+		Keyspace:   tablet.Keyspace,
+		Shard:      tablet.Shard,
+		TabletType: tablet.Type,
+	}
+}
+
+// dumpFilesByPath implements sort.Interface.
+type dumpFilesByPath []*dumpFile
+
+func (f dumpFilesByPath) Len() int           { return len(f) }
+func (f dumpFilesByPath) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f dumpFilesByPath) Less(i, j int) bool { return f[i].Path < f[j].Path }
@@ -0,0 +1,153 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gitql/vitess/go/vt/wrangler"
+	"golang.org/x/net/context"
+)
+
+const exportKeyspaceHTML = `
+<!DOCTYPE html>
+<head>
+  <title>Export Keyspace Action</title>
+</head>
+<body>
+  <h1>Export Keyspace Action</h1>
+    <form action="/Clones/ExportKeyspace" method="post">
+      <LABEL for="keyspace">Keyspace: </LABEL>
+        <INPUT type="text" id="keyspace" name="keyspace" value=""></BR>
+      <LABEL for="directory">Directory (path on the vtworker host): </LABEL>
+        <INPUT type="text" id="directory" name="directory" value=""></BR>
+      <LABEL for="format">Format (csv or json): </LABEL>
+        <INPUT type="text" id="format" name="format" value="{{.DefaultFormat}}"></BR>
+      <LABEL for="tables">Tables (comma separated, all tables if empty): </LABEL>
+        <INPUT type="text" id="tables" name="tables" value=""></BR>
+      <LABEL for="excludeTables">Exclude Tables: </LABEL>
+        <INPUT type="text" id="excludeTables" name="excludeTables" value=""></BR>
+      <LABEL for="splitCount">Number of Query Parts per Table and Shard: </LABEL>
+        <INPUT type="text" id="splitCount" name="splitCount" value="{{.DefaultSplitCount}}"></BR>
+      <LABEL for="sourceReaderCount">Source Reader Count (per shard): </LABEL>
+        <INPUT type="text" id="sourceReaderCount" name="sourceReaderCount" value="{{.DefaultSourceReaderCount}}"></BR>
+      <LABEL for="minHealthyRdonlyTablets">Minimum Number of required healthy RDONLY tablets: </LABEL>
+        <INPUT type="text" id="minHealthyRdonlyTablets" name="minHealthyRdonlyTablets" value="{{.DefaultMinHealthyRdonlyTablets}}"></BR>
+      <LABEL for="writeBlockTimeout">Maximum Time the Writes on the Masters are Blocked: </LABEL>
+        <INPUT type="text" id="writeBlockTimeout" name="writeBlockTimeout" value="{{.DefaultWriteBlockTimeout}}"></BR>
+      <LABEL for="job">Job Name (empty for the default job): </LABEL>
+        <INPUT type="text" id="job" name="job" value=""></BR>
+      <INPUT type="submit" name="submit" value="Export Keyspace"/>
+    </form>
+
+  <h1>Help</h1>
+    <p>The tables are read from one RDONLY tablet per shard, which is changed to DRAINED during the export. The replication of these tablets is stopped and restarted after the export. The position of each shard is recorded in the manifest.json file of the directory.</p>
+    <p>To export a consistent snapshot of the keyspace, the masters are made read-only until each tablet has stopped at the position of its master. This blocks the writes for at most the given time, after which the export fails.</p>
+  </body>
+`
+
+var exportKeyspaceTemplate = mustParseTemplate("exportKeyspace", exportKeyspaceHTML)
+
+func commandExportKeyspace(wi *Instance, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) (Worker, error) {
+	directory := subFlags.String("directory", "", "local directory to which the tables are exported. It must not have an export yet")
+	format := subFlags.String("format", dumpFormatCSV, "format of the exported files: csv or json (one JSON object per line)")
+	tables := subFlags.String("tables", "", "comma separated list of tables to export (all tables by default). Each is either an exact match, or a regular expression of the form /regexp/")
+	excludeTables := subFlags.String("exclude_tables", "", "comma separated list of tables to exclude")
+	splitCount := subFlags.Int("split_count", defaultExportSplitCount, "number of query parts (and files) per table and shard")
+	sourceReaderCount := subFlags.Int("source_reader_count", defaultSourceReaderCount, "number of query parts which are streamed in parallel from each shard")
+	minHealthyRdonlyTablets := subFlags.Int("min_healthy_rdonly_tablets", defaultMinHealthyRdonlyTablets, "minimum number of healthy RDONLY tablets in each shard before one is used")
+	writeBlockTimeout := subFlags.Duration("write_block_timeout", defaultExportWriteBlockTimeout, "maximum time the writes on the masters are blocked while the RDONLY tablets catch up with them")
+	if err := subFlags.Parse(args); err != nil {
+		return nil, err
+	}
+	if subFlags.NArg() != 1 {
+		subFlags.Usage()
+		return nil, fmt.Errorf("command ExportKeyspace requires <keyspace>")
+	}
+
+	var tableArray []string
+	if *tables != "" {
+		tableArray = strings.Split(*tables, ",")
+	}
+	var excludeTableArray []string
+	if *excludeTables != "" {
+		excludeTableArray = strings.Split(*excludeTables, ",")
+	}
+	worker, err := newExportKeyspaceWorker(wr, wi.cell, subFlags.Arg(0), *directory, *format, tableArray, excludeTableArray, *splitCount, *sourceReaderCount, *minHealthyRdonlyTablets, *writeBlockTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create worker: %v", err)
+	}
+	return worker, nil
+}
+
+func interactiveExportKeyspace(ctx context.Context, wi *Instance, wr *wrangler.Wrangler, w http.ResponseWriter, r *http.Request) (Worker, *template.Template, map[string]interface{}, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse form: %s", err)
+	}
+	keyspace := r.FormValue("keyspace")
+	directory := r.FormValue("directory")
+
+	if keyspace == "" || directory == "" {
+		// display the input form
+		result := make(map[string]interface{})
+		result["DefaultFormat"] = dumpFormatCSV
+		result["DefaultSplitCount"] = fmt.Sprintf("%v", defaultExportSplitCount)
+		result["DefaultSourceReaderCount"] = fmt.Sprintf("%v", defaultSourceReaderCount)
+		result["DefaultMinHealthyRdonlyTablets"] = fmt.Sprintf("%v", defaultMinHealthyRdonlyTablets)
+		result["DefaultWriteBlockTimeout"] = fmt.Sprintf("%v", defaultExportWriteBlockTimeout)
+		return nil, exportKeyspaceTemplate, result, nil
+	}
+
+	// get other parameters
+	format := r.FormValue("format")
+	var tableArray []string
+	if tables := r.FormValue("tables"); tables != "" {
+		tableArray = strings.Split(tables, ",")
+	}
+	var excludeTableArray []string
+	if excludeTables := r.FormValue("excludeTables"); excludeTables != "" {
+		excludeTableArray = strings.Split(excludeTables, ",")
+	}
+	splitCountStr := r.FormValue("splitCount")
+	splitCount, err := strconv.ParseInt(splitCountStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse splitCount: %s", err)
+	}
+	sourceReaderCountStr := r.FormValue("sourceReaderCount")
+	sourceReaderCount, err := strconv.ParseInt(sourceReaderCountStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse sourceReaderCount: %s", err)
+	}
+	minHealthyRdonlyTabletsStr := r.FormValue("minHealthyRdonlyTablets")
+	minHealthyRdonlyTablets, err := strconv.ParseInt(minHealthyRdonlyTabletsStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse minHealthyRdonlyTablets: %s", err)
+	}
+	writeBlockTimeoutStr := r.FormValue("writeBlockTimeout")
+	writeBlockTimeout, err := time.ParseDuration(writeBlockTimeoutStr)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse writeBlockTimeout: %s", err)
+	}
+
+	// start the export job
+	wrk, err := newExportKeyspaceWorker(wr, wi.cell, keyspace, directory, format, tableArray, excludeTableArray, int(splitCount), int(sourceReaderCount), int(minHealthyRdonlyTablets), writeBlockTimeout)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot create worker: %v", err)
+	}
+	return wrk, nil, nil, nil
+}
+
+func init() {
+	AddCommand("Clones", Command{"ExportKeyspace",
+		commandExportKeyspace, interactiveExportKeyspace,
+		"--directory=<local directory> [--format=csv|json] [--tables=''] [--exclude_tables=''] [--write_block_timeout=30s] <keyspace>",
+		"Exports a consistent snapshot of tables from all shards of a keyspace to files in a local directory. The writes on the masters are briefly blocked to stop each shard at a consistent replication position, which is recorded in the manifest of the export. The files can be loaded into a keyspace with the ImportKeyspace command."})
+}
@@ -0,0 +1,774 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/mysqlconn/replication"
+	"github.com/gitql/vitess/go/sqldb"
+	"github.com/gitql/vitess/go/stats"
+	"github.com/gitql/vitess/go/sync2"
+	"github.com/gitql/vitess/go/vt/binlog"
+	"github.com/gitql/vitess/go/vt/binlog/binlogplayer"
+	"github.com/gitql/vitess/go/vt/concurrency"
+	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/tabletserver/queryservice"
+	"github.com/gitql/vitess/go/vt/tabletserver/tabletconn"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+	"github.com/gitql/vitess/go/vt/vtgate/vindexes"
+	"github.com/gitql/vitess/go/vt/wrangler"
+
+	binlogdatapb "github.com/gitql/vitess/go/vt/proto/binlogdata"
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// ExternalImportWorker imports the tables of a MySQL database which is not
+// managed by Vitess into a keyspace. It runs in three phases:
+// First, it copies the tables from a consistent snapshot of the external
+// database to the master tablets of the keyspace. The rows are routed to the
+// shards with the primary vindex of each table.
+// Then, it applies the binlog of the external database from the position of
+// the snapshot until an operator triggers the cut-over (see CutOver()).
+// Finally, it compares the external tables with the imported ones.
+//
+// The schema must already exist on the destination shards and the keyspace
+// must have a VSchema. The binlog of the external database must be statement
+// based and it must be kept until the copy is done.
+// If the import is interrupted, run it again: the copy reconciles the rows
+// which were already copied.
+type ExternalImportWorker struct {
+	StatusWorker
+
+	wr                     *wrangler.Wrangler
+	cell                   string
+	keyspace               string
+	source                 *externalSource
+	tables                 []string
+	excludeTables          []string
+	sourceReaderCount      int
+	writeQueryMaxRows      int
+	writeQueryMaxSize      int
+	destinationWriterCount int
+
+	// populated during WorkerStateInit, read-only after that
+	keyspaceSchema    *vindexes.KeyspaceSchema
+	destinationShards []*topo.ShardInfo
+	sourceSchema      *tabletmanagerdatapb.SchemaDefinition
+	router            *binlogRouter
+	// healthCheck is used to find the current MASTER tablet of each
+	// destination shard. It must be closed at the end of the command.
+	healthCheck discovery.HealthCheck
+	tsc         *discovery.TabletStatsCache
+	// shardWatchers contains a TopologyWatcher for each destination shard.
+	// Each watcher must be stopped at the end of the command.
+	shardWatchers []*discovery.TopologyWatcher
+
+	// populated during WorkerStateFindTargets, read-only after that
+	// destinationDbNames stores for each destination keyspace/shard the MySQL
+	// database name.
+	destinationDbNames map[string]string
+
+	// populated during WorkerStateCloneOffline, read-only after that
+	// snapshotPosition is the position of the external database at which
+	// the tables were copied.
+	snapshotPosition replication.Position
+	tableStatusList  *tableStatusList
+
+	// blplStats tracks the position and the lag of the applied binlog.
+	blplStats *binlogplayer.Stats
+	// appliedTransactions is the number of applied binlog transactions.
+	appliedTransactions sync2.AtomicInt64
+	// followConns has a connection to the master of each destination shard
+	// which received statements from the binlog. It is only used by the
+	// binlog streamer go routine.
+	// Map key format: "keyspace/shard" e.g. "test_keyspace/-80"
+	followConns map[string]*followConn
+
+	// cutOver is closed by CutOver().
+	cutOver     chan struct{}
+	cutOverOnce sync.Once
+
+	// diffResultsMu guards diffResults.
+	diffResultsMu sync.Mutex
+	// diffResults has the result of the final diff for each table.
+	diffResults []string
+}
+
+// followConn is a connection to the MASTER tablet of a destination shard.
+type followConn struct {
+	tablet *topodatapb.Tablet
+	conn   queryservice.QueryService
+}
+
+// newExternalImportWorker returns a new worker object for the ExternalImport
+// command.
+func newExternalImportWorker(wr *wrangler.Wrangler, cell, keyspace string, params sqldb.ConnParams, tables, excludeTables []string, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount int) (Worker, error) {
+	if params.DbName == "" {
+		return nil, fmt.Errorf("the database name of the external source must be set")
+	}
+	if sourceReaderCount <= 0 {
+		return nil, fmt.Errorf("source_reader_count must be > 0: %v", sourceReaderCount)
+	}
+	if writeQueryMaxRows <= 0 {
+		return nil, fmt.Errorf("write_query_max_rows must be > 0: %v", writeQueryMaxRows)
+	}
+	if writeQueryMaxSize <= 0 {
+		return nil, fmt.Errorf("write_query_max_size must be > 0: %v", writeQueryMaxSize)
+	}
+	if destinationWriterCount <= 0 {
+		return nil, fmt.Errorf("destination_writer_count must be > 0: %v", destinationWriterCount)
+	}
+
+	return &ExternalImportWorker{
+		StatusWorker:           NewStatusWorker(),
+		wr:                     wr,
+		cell:                   cell,
+		keyspace:               keyspace,
+		source:                 newExternalSource(params),
+		tables:                 tables,
+		excludeTables:          excludeTables,
+		sourceReaderCount:      sourceReaderCount,
+		writeQueryMaxRows:      writeQueryMaxRows,
+		writeQueryMaxSize:      writeQueryMaxSize,
+		destinationWriterCount: destinationWriterCount,
+
+		destinationDbNames: make(map[string]string),
+		tableStatusList:    &tableStatusList{},
+		blplStats:          binlogplayer.NewStats(),
+		followConns:        make(map[string]*followConn),
+		cutOver:            make(chan struct{}),
+	}, nil
+}
+
+// StatusAsHTML implements the Worker interface.
+func (w *ExternalImportWorker) StatusAsHTML() template.HTML {
+	return template.HTML(strings.Replace(w.status(), "\n", "</br>\n", -1))
+}
+
+// StatusAsText implements the Worker interface.
+func (w *ExternalImportWorker) StatusAsText() string {
+	return w.status()
+}
+
+func (w *ExternalImportWorker) status() string {
+	state := w.State()
+
+	result := "Importing: " + w.source.String() + " into keyspace " + w.keyspace + "\n"
+	result += "State: " + state.String() + "\n"
+	switch state {
+	case WorkerStateCloneOffline:
+		result += "Running:\n"
+		result += "Copying from snapshot at position: " + w.snapshotPosition.String() + "\n"
+		statuses, eta := w.tableStatusList.format()
+		result += "ETA: " + eta.String() + "\n"
+		result += strings.Join(statuses, "\n") + "\n"
+	case WorkerStateFollowBinlog:
+		result += "Running:\n"
+		result += fmt.Sprintf("Applied transactions: %v\n", w.appliedTransactions.Get())
+		result += "Position: " + w.blplStats.GetLastPosition().String() + "\n"
+		result += fmt.Sprintf("Seconds behind the external source: %v\n", w.blplStats.SecondsBehindMaster.Get())
+		result += "Stop all writes to the external source and run CutOver to finish the import.\n"
+	case WorkerStateDiff:
+		result += "Running:\n"
+		result += "Comparing the tables after the cut-over at position: " + w.blplStats.GetLastPosition().String() + "\n"
+	case WorkerStateDone:
+		result += "Success:\n"
+		result += "Copy Result:\n"
+		statuses, _ := w.tableStatusList.format()
+		result += strings.Join(statuses, "\n") + "\n"
+		result += fmt.Sprintf("Applied transactions: %v\n", w.appliedTransactions.Get())
+		result += "Diff Result:\n"
+		w.diffResultsMu.Lock()
+		result += strings.Join(w.diffResults, "\n") + "\n"
+		w.diffResultsMu.Unlock()
+	}
+	return result
+}
+
+// CutOver implements the CutOverWorker interface. It stops applying the
+// binlog once the current position of the external database is reached. All
+// writes to the external database must be stopped before.
+func (w *ExternalImportWorker) CutOver() error {
+	if state := w.State(); state != WorkerStateFollowBinlog {
+		return fmt.Errorf("the cut-over is only possible while following the binlog, current state: %v", state)
+	}
+	w.cutOverOnce.Do(func() {
+		close(w.cutOver)
+	})
+	return nil
+}
+
+// Run implements the Worker interface.
+func (w *ExternalImportWorker) Run(ctx context.Context) error {
+	resetVars(ctx)
+
+	// Run the command.
+	err := w.run(ctx)
+
+	// Cleanup.
+	w.SetState(WorkerStateCleanUp)
+	// Stop watchers to prevent new tablets from getting added to the healthCheck.
+	for _, watcher := range w.shardWatchers {
+		watcher.Stop()
+	}
+	// Stop healthCheck to make sure it stops calling our listener implementation.
+	if w.healthCheck != nil {
+		if err := w.healthCheck.Close(); err != nil {
+			w.wr.Logger().Errorf("HealthCheck.Close() failed: %v", err)
+		}
+	}
+	w.source.close()
+
+	if err != nil {
+		w.SetState(WorkerStateError)
+		return err
+	}
+	w.SetState(WorkerStateDone)
+	return nil
+}
+
+func (w *ExternalImportWorker) run(ctx context.Context) error {
+	// Phase 1: read what we need to do.
+	if err := w.init(ctx); err != nil {
+		return fmt.Errorf("init() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// Phase 2: find destination master tablets.
+	if err := w.findDestinationMasters(ctx); err != nil {
+		return fmt.Errorf("findDestinationMasters() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// Phase 3: copy the tables from a snapshot.
+	start := time.Now()
+	if err := w.copy(ctx); err != nil {
+		return fmt.Errorf("copy() failed: %v", err)
+	}
+	w.wr.Logger().Infof("Copy finished after %v.", time.Since(start))
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// Phase 4: apply the binlog until the cut-over.
+	if err := w.follow(ctx); err != nil {
+		return fmt.Errorf("follow() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// Phase 5: compare the tables.
+	if err := w.diff(ctx); err != nil {
+		return fmt.Errorf("diff() failed: %v", err)
+	}
+	return nil
+}
+
+// init phase:
+// - read the VSchema and the shards of the keyspace
+// - read the schema of the external database
+func (w *ExternalImportWorker) init(ctx context.Context) error {
+	w.SetState(WorkerStateInit)
+
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	kschema, err := w.wr.TopoServer().GetVSchema(shortCtx, w.keyspace)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot load VSchema for keyspace %v: %v", w.keyspace, err)
+	}
+	if kschema == nil {
+		return fmt.Errorf("no VSchema for keyspace %v", w.keyspace)
+	}
+	w.keyspaceSchema, err = vindexes.BuildKeyspaceSchema(kschema, w.keyspace)
+	if err != nil {
+		return fmt.Errorf("cannot build vschema for keyspace %v: %v", w.keyspace, err)
+	}
+
+	shortCtx, cancel = context.WithTimeout(ctx, *remoteActionsTimeout)
+	shards, err := w.wr.TopoServer().FindAllShardsInKeyspace(shortCtx, w.keyspace)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot read the shards of keyspace %v: %v", w.keyspace, err)
+	}
+	if len(shards) == 0 {
+		return fmt.Errorf("keyspace %v has no shards", w.keyspace)
+	}
+	for _, si := range shards {
+		if len(si.SourceShards) > 0 {
+			return fmt.Errorf("destination shard %v/%v has filtered replication enabled (SourceShards is set). Finish the resharding before the import", si.Keyspace(), si.ShardName())
+		}
+		w.destinationShards = append(w.destinationShards, si)
+	}
+
+	w.sourceSchema, err = w.source.getSchema(w.tables, w.excludeTables)
+	if err != nil {
+		return err
+	}
+	w.wr.Logger().Infof("External source %v has %v tables to import", w.source, len(w.sourceSchema.TableDefinitions))
+	autoIncrementColumns, err := w.source.autoIncrementColumns(ctx)
+	if err != nil {
+		return err
+	}
+	w.router, err = newBinlogRouter(w.destinationShards, w.keyspaceSchema, w.sourceSchema.TableDefinitions, autoIncrementColumns)
+	if err != nil {
+		return fmt.Errorf("cannot resolve sharding keys for keyspace %v: %v", w.keyspace, err)
+	}
+
+	// Initialize healthcheck and add destination shards to it.
+	w.healthCheck = discovery.NewHealthCheck(*remoteActionsTimeout, *healthcheckRetryDelay, *healthCheckTimeout)
+	w.tsc = discovery.NewTabletStatsCache(w.healthCheck, w.cell)
+	for _, si := range w.destinationShards {
+		watcher := discovery.NewShardReplicationWatcher(w.wr.TopoServer(), w.healthCheck,
+			w.cell, si.Keyspace(), si.ShardName(),
+			*healthCheckTopologyRefresh, discovery.DefaultTopoReadConcurrency)
+		w.shardWatchers = append(w.shardWatchers, watcher)
+	}
+	return nil
+}
+
+// findDestinationMasters finds for each destination shard the current master.
+func (w *ExternalImportWorker) findDestinationMasters(ctx context.Context) error {
+	w.SetState(WorkerStateFindTargets)
+
+	w.wr.Logger().Infof("Finding a MASTER tablet for each destination shard...")
+	for _, si := range w.destinationShards {
+		waitCtx, waitCancel := context.WithTimeout(ctx, *waitForHealthyTabletsTimeout)
+		defer waitCancel()
+		if err := w.tsc.WaitForTablets(waitCtx, w.cell, si.Keyspace(), si.ShardName(), []topodatapb.TabletType{topodatapb.TabletType_MASTER}); err != nil {
+			return fmt.Errorf("cannot find MASTER tablet for destination shard for %v/%v (in cell: %v): %v", si.Keyspace(), si.ShardName(), w.cell, err)
+		}
+		masters := w.tsc.GetHealthyTabletStats(si.Keyspace(), si.ShardName(), topodatapb.TabletType_MASTER)
+		if len(masters) == 0 {
+			return fmt.Errorf("cannot find MASTER tablet for destination shard for %v/%v (in cell: %v) in HealthCheck: empty TabletStats list", si.Keyspace(), si.ShardName(), w.cell)
+		}
+		master := masters[0]
+
+		// Get the MySQL database name of the tablet.
+		keyspaceAndShard := topoproto.KeyspaceShardString(si.Keyspace(), si.ShardName())
+		w.destinationDbNames[keyspaceAndShard] = topoproto.TabletDbName(master.Tablet)
+
+		w.wr.Logger().Infof("Using tablet %v as destination master for %v/%v", topoproto.TabletAliasString(master.Tablet.Alias), si.Keyspace(), si.ShardName())
+	}
+	return nil
+}
+
+// copy phase:
+// - take a consistent snapshot of the external database
+// - copy each table from the snapshot to the destination masters
+// - reconcile the rows which already exist there
+func (w *ExternalImportWorker) copy(ctx context.Context) error {
+	w.SetState(WorkerStateCloneOffline)
+	start := time.Now()
+	defer func() {
+		statsStateDurationsNs.Set([]string{jobNameFromContext(ctx), string(WorkerStateCloneOffline)}, time.Now().Sub(start).Nanoseconds())
+	}()
+
+	snapshot, err := w.source.snapshot(w.sourceReaderCount)
+	if err != nil {
+		return err
+	}
+	defer snapshot.close()
+	w.snapshotPosition = snapshot.position
+	w.blplStats.SetLastPosition(snapshot.position)
+	w.wr.Logger().Infof("Copying the tables from a snapshot of external source %v at position %v", w.source, snapshot.position)
+	w.tableStatusList.initialize(w.sourceSchema)
+	statsCounters := []*stats.MultiCounters{statsOfflineInsertsCounters, statsOfflineUpdatesCounters, statsOfflineDeletesCounters, statsOfflineEqualRowsCounters}
+
+	// mu protects the context for cancelation, and firstError
+	mu := sync.Mutex{}
+	var firstError error
+
+	ctx, cancelCopy := context.WithCancel(ctx)
+	defer cancelCopy()
+	processError := func(format string, args ...interface{}) {
+		w.wr.Logger().Errorf(format, args...)
+		mu.Lock()
+		if firstError == nil {
+			firstError = fmt.Errorf(format, args...)
+			cancelCopy()
+		}
+		mu.Unlock()
+	}
+
+	insertChannels := make([]chan insertCommand, len(w.destinationShards))
+	destinationWaitGroup := sync.WaitGroup{}
+	for shardIndex, si := range w.destinationShards {
+		insertChannels[shardIndex] = make(chan insertCommand, w.destinationWriterCount*2)
+		for j := 0; j < w.destinationWriterCount; j++ {
+			destinationWaitGroup.Add(1)
+			go func(keyspace, shard string, insertChannel chan insertCommand, threadID int) {
+				defer destinationWaitGroup.Done()
+
+				executor := newExecutor(w.wr, w.tsc, nil /* throttler */, keyspace, shard, threadID)
+				if err := executor.fetchLoop(ctx, insertChannel); err != nil {
+					processError("executer.FetchLoop failed: %v", err)
+				}
+			}(si.Keyspace(), si.ShardName(), insertChannels[shardIndex], j)
+		}
+	}
+
+	// Copy each table with one snapshot connection. Tables are not split
+	// into chunks: a snapshot connection reads one table at a time, so up to
+	// sourceReaderCount tables are copied in parallel.
+	dbNames := w.dbNames()
+	sourceWaitGroup := sync.WaitGroup{}
+	for tableIndex, td := range w.sourceSchema.TableDefinitions {
+		td = reorderColumnsPrimaryKeyFirst(td)
+
+		keyResolver, err := newV3ResolverFromTableDefinition(w.keyspaceSchema, td)
+		if err != nil {
+			processError("cannot resolve sharding keys for keyspace %v: %v", w.keyspace, err)
+			break
+		}
+		w.tableStatusList.setThreadCount(tableIndex, 1)
+
+		sourceWaitGroup.Add(1)
+		go func(td *tabletmanagerdatapb.TableDefinition, tableIndex int, keyResolver keyspaceIDResolver) {
+			defer sourceWaitGroup.Done()
+			errPrefix := fmt.Sprintf("table=%v", td.Name)
+
+			conn := snapshot.get()
+			defer snapshot.put(conn)
+			if err := checkDone(ctx); err != nil {
+				return
+			}
+			if err := acquireSourceReader(ctx); err != nil {
+				processError("%v: %v", errPrefix, err)
+				return
+			}
+			defer releaseSourceReader(ctx)
+
+			w.tableStatusList.threadStarted(tableIndex)
+
+			sourceReader, err := newExternalResultReader(conn, w.source.dbName, td)
+			if err != nil {
+				processError("%v: %v", errPrefix, err)
+				return
+			}
+			defer sourceReader.Close()
+			destReader, closeDestReader, err := w.destinationReader(ctx, td)
+			if err != nil {
+				processError("%v: %v", errPrefix, err)
+				return
+			}
+			defer closeDestReader()
+
+			// Copy the rows and reconcile any differences.
+			differ, err := NewRowDiffer2(ctx, sourceReader, destReader, td, w.tableStatusList, tableIndex,
+				w.destinationShards, keyResolver,
+				insertChannels, nil /* pendingWrites */, ctx.Done(), dbNames, w.writeQueryMaxRows, w.writeQueryMaxSize, statsCounters)
+			if err != nil {
+				processError("%v: NewRowDiffer2 failed: %v", errPrefix, err)
+				return
+			}
+			if _, err := differ.Diff(); err != nil {
+				processError("%v: RowDiffer2 failed: %v", errPrefix, err)
+				return
+			}
+
+			w.tableStatusList.threadDone(tableIndex)
+		}(td, tableIndex, keyResolver)
+	}
+	sourceWaitGroup.Wait()
+
+	for shardIndex := range w.destinationShards {
+		close(insertChannels[shardIndex])
+	}
+	destinationWaitGroup.Wait()
+	return firstError
+}
+
+// follow phase:
+// - apply the binlog of the external database from the snapshot position
+// - after the cut-over, stop at the current position of the external source
+// The binlog is streamed and applied here instead of by a binlogplayer
+// because binlogplayer reads from the update stream of a vttablet and writes
+// each transaction to a single database. Only its Stats are reused.
+func (w *ExternalImportWorker) follow(ctx context.Context) error {
+	w.SetState(WorkerStateFollowBinlog)
+	w.wr.Logger().Infof("Applying the binlog of external source %v from position %v. Run CutOver to finish the import.", w.source, w.snapshotPosition)
+
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	streamErr := make(chan error, 1)
+	streamDone := make(chan struct{})
+	go func() {
+		defer close(streamDone)
+		streamer := binlog.NewStreamer(w.source.dbName, w.source.mysqld, nil /* clientCharset */, w.snapshotPosition, 0 /* timestamp */, func(trans *binlogdatapb.BinlogTransaction) error {
+			return w.applyTransaction(streamCtx, trans)
+		})
+		streamErr <- streamer.Stream(streamCtx)
+	}()
+	defer func() {
+		cancelStream()
+		<-streamDone
+		w.closeFollowConns(ctx)
+	}()
+
+	select {
+	case err := <-streamErr:
+		return fmt.Errorf("binlog stream from external source %v failed: %v", w.source, err)
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.cutOver:
+	}
+
+	stopPosition, err := w.source.masterPosition()
+	if err != nil {
+		return fmt.Errorf("cannot read the replication position of external source %v: %v", w.source, err)
+	}
+	w.wr.Logger().Infof("Cut-over was triggered. Waiting until the binlog is applied up to position %v", stopPosition)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for !w.blplStats.GetLastPosition().AtLeast(stopPosition) {
+		select {
+		case err := <-streamErr:
+			return fmt.Errorf("binlog stream from external source %v failed: %v", w.source, err)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	w.wr.Logger().Infof("Binlog was applied up to position %v (%v transactions)", w.blplStats.GetLastPosition(), w.appliedTransactions.Get())
+	return nil
+}
+
+// applyTransaction applies the statements of a binlog transaction to the
+// destination shards. The statements of each shard run in one transaction.
+// Note that a transaction which affects multiple shards is not atomic.
+// The charset of the statements is ignored: The external tables must use the
+// same charset as the destination connections.
+func (w *ExternalImportWorker) applyTransaction(ctx context.Context, trans *binlogdatapb.BinlogTransaction) error {
+	defer w.blplStats.Timings.Record(binlogplayer.BlplTransaction, time.Now())
+
+	shardStatements, err := w.router.route(trans.Statements)
+	if err != nil {
+		return err
+	}
+	for shardIndex, statements := range shardStatements {
+		if len(statements) == 0 {
+			continue
+		}
+		si := w.destinationShards[shardIndex]
+		if err := w.applyStatements(ctx, si.Keyspace(), si.ShardName(), statements); err != nil {
+			return err
+		}
+	}
+
+	position, err := replication.DecodePosition(trans.EventToken.Position)
+	if err != nil {
+		return err
+	}
+	w.blplStats.SetLastPosition(position)
+	w.blplStats.SecondsBehindMaster.Set(time.Now().Unix() - trans.EventToken.Timestamp)
+	w.appliedTransactions.Add(1)
+	return nil
+}
+
+// applyStatements runs the statements in one transaction on the current
+// master of the shard.
+func (w *ExternalImportWorker) applyStatements(ctx context.Context, keyspace, shard string, statements []string) error {
+	fc, err := w.followConn(keyspace, shard)
+	if err != nil {
+		return err
+	}
+	alias := topoproto.TabletAliasString(fc.tablet.Alias)
+	target := &querypb.Target{
+		Keyspace:   keyspace,
+		Shard:      shard,
+		TabletType: topodatapb.TabletType_MASTER,
+	}
+
+	transactionID, err := fc.conn.Begin(ctx, target, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin a transaction on tablet %v: %v", alias, err)
+	}
+	for _, sql := range statements {
+		if _, err := fc.conn.Execute(ctx, target, sql, nil, transactionID, nil); err != nil {
+			fc.conn.Rollback(ctx, target, transactionID)
+			return fmt.Errorf("statement failed on tablet %v: %v: %v", alias, sql, err)
+		}
+	}
+	if _, err := fc.conn.Commit(ctx, target, transactionID); err != nil {
+		return fmt.Errorf("cannot commit a transaction on tablet %v: %v", alias, err)
+	}
+	return nil
+}
+
+// followConn returns a connection to the current master of the shard.
+func (w *ExternalImportWorker) followConn(keyspace, shard string) (*followConn, error) {
+	masters := w.tsc.GetHealthyTabletStats(keyspace, shard, topodatapb.TabletType_MASTER)
+	if len(masters) == 0 {
+		return nil, fmt.Errorf("cannot find MASTER tablet for destination shard %v/%v in HealthCheck", keyspace, shard)
+	}
+	master := masters[0].Tablet
+
+	keyspaceAndShard := topoproto.KeyspaceShardString(keyspace, shard)
+	if fc, ok := w.followConns[keyspaceAndShard]; ok {
+		if topoproto.TabletAliasEqual(fc.tablet.Alias, master.Alias) {
+			return fc, nil
+		}
+		// The master has changed.
+		fc.conn.Close(context.Background())
+		delete(w.followConns, keyspaceAndShard)
+	}
+
+	conn, err := tabletconn.GetDialer()(master, *remoteActionsTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dialer for tablet %v: %v", topoproto.TabletAliasString(master.Alias), err)
+	}
+	fc := &followConn{
+		tablet: master,
+		conn:   conn,
+	}
+	w.followConns[keyspaceAndShard] = fc
+	return fc, nil
+}
+
+func (w *ExternalImportWorker) closeFollowConns(ctx context.Context) {
+	for keyspaceAndShard, fc := range w.followConns {
+		fc.conn.Close(ctx)
+		delete(w.followConns, keyspaceAndShard)
+	}
+}
+
+// diff phase:
+// - compare each table of a new snapshot with the destination masters
+func (w *ExternalImportWorker) diff(ctx context.Context) error {
+	w.SetState(WorkerStateDiff)
+	start := time.Now()
+	defer func() {
+		statsStateDurationsNs.Set([]string{jobNameFromContext(ctx), string(WorkerStateDiff)}, time.Now().Sub(start).Nanoseconds())
+	}()
+
+	snapshot, err := w.source.snapshot(w.sourceReaderCount)
+	if err != nil {
+		return err
+	}
+	defer snapshot.close()
+	if appliedPosition := w.blplStats.GetLastPosition(); !snapshot.position.Equal(appliedPosition) {
+		w.wr.Logger().Warningf("External source %v was written to after the cut-over (current position: %v, applied position: %v). The diff may report differences.", w.source, snapshot.position, appliedPosition)
+	}
+
+	rec := concurrency.AllErrorRecorder{}
+	wg := sync.WaitGroup{}
+	for _, td := range w.sourceSchema.TableDefinitions {
+		td = reorderColumnsPrimaryKeyFirst(td)
+
+		wg.Add(1)
+		go func(td *tabletmanagerdatapb.TableDefinition) {
+			defer wg.Done()
+
+			conn := snapshot.get()
+			defer snapshot.put(conn)
+			if err := acquireSourceReader(ctx); err != nil {
+				rec.RecordError(err)
+				return
+			}
+			defer releaseSourceReader(ctx)
+
+			w.wr.Logger().Infof("Starting the diff on table %v", td.Name)
+			sourceReader, err := newExternalResultReader(conn, w.source.dbName, td)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+			defer sourceReader.Close()
+			destReader, closeDestReader, err := w.destinationReader(ctx, td)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+			defer closeDestReader()
+
+			differ, err := NewRowDiffer(sourceReader, destReader, td)
+			if err != nil {
+				rec.RecordError(fmt.Errorf("NewRowDiffer failed for table %v: %v", td.Name, err))
+				return
+			}
+			report, err := differ.Go(w.wr.Logger())
+			if err != nil {
+				rec.RecordError(fmt.Errorf("Differ.Go failed for table %v: %v", td.Name, err))
+				return
+			}
+
+			var result string
+			if report.HasDifferences() {
+				err := fmt.Errorf("Table %v has differences: %v", td.Name, report.String())
+				rec.RecordError(err)
+				w.wr.Logger().Warningf(err.Error())
+				result = err.Error()
+			} else {
+				result = fmt.Sprintf("Table %v checks out (%v rows processed, %v qps)", td.Name, report.processedRows, report.processingQPS)
+				w.wr.Logger().Infof(result)
+			}
+			w.diffResultsMu.Lock()
+			w.diffResults = append(w.diffResults, result)
+			w.diffResultsMu.Unlock()
+		}(td)
+	}
+	wg.Wait()
+	return rec.Error()
+}
+
+// destinationReader returns a reader for the rows of the table "td" on all
+// destination masters, ordered by the primary key. The returned function
+// closes the reader.
+func (w *ExternalImportWorker) destinationReader(ctx context.Context, td *tabletmanagerdatapb.TableDefinition) (ResultReader, func(), error) {
+	var readers []*RestartableResultReader
+	closeReaders := func() {
+		for _, r := range readers {
+			r.Close(ctx)
+		}
+	}
+
+	for _, si := range w.destinationShards {
+		tp := newShardMasterTabletProvider(w.tsc, si.Keyspace(), si.ShardName())
+		r, err := NewRestartableResultReader(ctx, w.wr.Logger(), tp, td, completeChunk, true /* allowMultipleRetries */)
+		if err != nil {
+			closeReaders()
+			return nil, nil, fmt.Errorf("NewRestartableResultReader for destination: %v failed: %v", tp.description(), err)
+		}
+		readers = append(readers, r)
+	}
+	if len(readers) == 1 {
+		return readers[0], closeReaders, nil
+	}
+
+	resultReaders := make([]ResultReader, len(readers))
+	for i, r := range readers {
+		resultReaders[i] = r
+	}
+	merger, err := NewResultMerger(resultReaders, len(td.PrimaryKeyColumns))
+	if err != nil {
+		closeReaders()
+		return nil, nil, fmt.Errorf("NewResultMerger for destination tablets failed: %v", err)
+	}
+	return merger, closeReaders, nil
+}
+
+// dbNames returns the MySQL database name of each destination shard.
+func (w *ExternalImportWorker) dbNames() []string {
+	dbNames := make([]string, len(w.destinationShards))
+	for i, si := range w.destinationShards {
+		keyspaceAndShard := topoproto.KeyspaceShardString(si.Keyspace(), si.ShardName())
+		dbNames[i] = w.destinationDbNames[keyspaceAndShard]
+	}
+	return dbNames
+}
@@ -0,0 +1,230 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gitql/vitess/go/sqldb"
+	"github.com/gitql/vitess/go/vt/wrangler"
+	"golang.org/x/net/context"
+)
+
+const externalImportHTML = `
+<!DOCTYPE html>
+<head>
+  <title>External Import Action</title>
+</head>
+<body>
+  <h1>External Import Action</h1>
+
+    {{if .Error}}
+      <b>Error:</b> {{.Error}}</br>
+    {{else}}
+      <p>Choose the destination keyspace for this action.</p>
+      <ul>
+      {{range $i, $si := .Keyspaces}}
+        <li><a href="/Clones/ExternalImport?keyspace={{$si}}">{{$si}}</a></li>
+      {{end}}
+      </ul>
+    {{end}}
+</body>
+`
+
+const externalImportHTML2 = `
+<!DOCTYPE html>
+<head>
+  <title>External Import Action</title>
+</head>
+<body>
+  <p>Destination keyspace: {{.Keyspace}}</p>
+  <h1>External Import Action</h1>
+    <form action="/Clones/ExternalImport" method="post">
+      <LABEL for="mysqlHost">MySQL Host: </LABEL>
+        <INPUT type="text" id="mysqlHost" name="mysqlHost" value=""></BR>
+      <LABEL for="mysqlPort">MySQL Port: </LABEL>
+        <INPUT type="text" id="mysqlPort" name="mysqlPort" value="3306"></BR>
+      <LABEL for="mysqlSocket">MySQL Socket (used instead of host and port if set): </LABEL>
+        <INPUT type="text" id="mysqlSocket" name="mysqlSocket" value=""></BR>
+      <LABEL for="mysqlUser">MySQL User: </LABEL>
+        <INPUT type="text" id="mysqlUser" name="mysqlUser" value=""></BR>
+      <LABEL for="mysqlPassword">MySQL Password (if empty, it is read from the --db-credentials-file of vtworker): </LABEL>
+        <INPUT type="password" id="mysqlPassword" name="mysqlPassword" value=""></BR>
+      <LABEL for="mysqlDbname">MySQL Database: </LABEL>
+        <INPUT type="text" id="mysqlDbname" name="mysqlDbname" value=""></BR>
+      <LABEL for="tables">Tables (comma separated, all tables if empty): </LABEL>
+        <INPUT type="text" id="tables" name="tables" value=""></BR>
+      <LABEL for="excludeTables">Exclude Tables: </LABEL>
+        <INPUT type="text" id="excludeTables" name="excludeTables" value=""></BR>
+      <LABEL for="sourceReaderCount">Source Reader Count: </LABEL>
+        <INPUT type="text" id="sourceReaderCount" name="sourceReaderCount" value="{{.DefaultSourceReaderCount}}"></BR>
+      <LABEL for="writeQueryMaxRows">Maximum Number of Rows per Write Query: </LABEL>
+        <INPUT type="text" id="writeQueryMaxRows" name="writeQueryMaxRows" value="{{.DefaultWriteQueryMaxRows}}"></BR>
+      <LABEL for="writeQueryMaxSize">Maximum Size (in bytes) per Write Query: </LABEL>
+        <INPUT type="text" id="writeQueryMaxSize" name="writeQueryMaxSize" value="{{.DefaultWriteQueryMaxSize}}"></BR>
+      <LABEL for="destinationWriterCount">Destination Writer Count: </LABEL>
+        <INPUT type="text" id="destinationWriterCount" name="destinationWriterCount" value="{{.DefaultDestinationWriterCount}}"></BR>
+      <INPUT type="hidden" name="keyspace" value="{{.Keyspace}}"/>
+      <LABEL for="job">Job Name (empty for the default job): </LABEL>
+        <INPUT type="text" id="job" name="job" value=""></BR>
+      <INPUT type="submit" value="Import"/>
+    </form>
+
+  <h1>Help</h1>
+    <p>The import copies the tables from a consistent snapshot of the external database and then applies its binlog until you trigger the cut-over. Stop all writes to the external database before you click "Cut Over" on the status page. After the cut-over, the tables are compared.</p>
+    <p>Requirements and limitations:</p>
+    <ul>
+      <li>The keyspace must have a VSchema with a unique primary vindex for each table. The schema must already exist on all shards.</li>
+      <li>The external database must use GTIDs and statement based replication. Its binlog must be kept until the copy is done.</li>
+      <li>The user needs the RELOAD privilege to take the snapshot, and the REPLICATION SLAVE privilege to stream the binlog.</li>
+      <li>Schema changes on the external database fail the import.</li>
+      <li>A transaction which writes to multiple shards is applied in one transaction per shard.</li>
+      <li>If the import is interrupted, run it again. The copy reconciles the rows which were already imported.</li>
+    </ul>
+  </body>
+`
+
+var externalImportTemplate = mustParseTemplate("externalImport", externalImportHTML)
+var externalImportTemplate2 = mustParseTemplate("externalImport2", externalImportHTML2)
+
+func commandExternalImport(wi *Instance, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) (Worker, error) {
+	mysqlHost := subFlags.String("mysql_host", "", "host of the external MySQL server")
+	mysqlPort := subFlags.Int("mysql_port", 3306, "port of the external MySQL server")
+	mysqlSocket := subFlags.String("mysql_socket", "", "unix socket of the external MySQL server (used instead of --mysql_host and --mysql_port if set)")
+	mysqlUser := subFlags.String("mysql_user", "", "user for the external MySQL server")
+	mysqlPassword := subFlags.String("mysql_password", "", "password for the external MySQL server. If empty, the password of --mysql_user is read from the --db-credentials-file of vtworker")
+	mysqlDbname := subFlags.String("mysql_dbname", "", "database on the external MySQL server which will be imported")
+	tables := subFlags.String("tables", "", "comma separated list of tables to import (all tables by default). Each is either an exact match, or a regular expression of the form /regexp/")
+	excludeTables := subFlags.String("exclude_tables", "", "comma separated list of tables to exclude. Each is either an exact match, or a regular expression of the form /regexp/")
+	sourceReaderCount := subFlags.Int("source_reader_count", defaultSourceReaderCount, "number of concurrent streaming queries to use on the source")
+	writeQueryMaxRows := subFlags.Int("write_query_max_rows", defaultWriteQueryMaxRows, "maximum number of rows per write query")
+	writeQueryMaxSize := subFlags.Int("write_query_max_size", defaultWriteQueryMaxSize, "maximum size (in bytes) per write query")
+	destinationWriterCount := subFlags.Int("destination_writer_count", defaultDestinationWriterCount, "number of concurrent RPCs to execute on the destination")
+	if err := subFlags.Parse(args); err != nil {
+		return nil, err
+	}
+	if subFlags.NArg() != 1 {
+		subFlags.Usage()
+		return nil, fmt.Errorf("command ExternalImport requires <destination keyspace>")
+	}
+
+	keyspace := subFlags.Arg(0)
+	params := sqldb.ConnParams{
+		Host:       *mysqlHost,
+		Port:       *mysqlPort,
+		UnixSocket: *mysqlSocket,
+		Uname:      *mysqlUser,
+		Pass:       *mysqlPassword,
+		DbName:     *mysqlDbname,
+	}
+	var tableArray []string
+	if *tables != "" {
+		tableArray = strings.Split(*tables, ",")
+	}
+	var excludeTableArray []string
+	if *excludeTables != "" {
+		excludeTableArray = strings.Split(*excludeTables, ",")
+	}
+	worker, err := newExternalImportWorker(wr, wi.cell, keyspace, params, tableArray, excludeTableArray, *sourceReaderCount, *writeQueryMaxRows, *writeQueryMaxSize, *destinationWriterCount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create worker: %v", err)
+	}
+	return worker, nil
+}
+
+func interactiveExternalImport(ctx context.Context, wi *Instance, wr *wrangler.Wrangler, w http.ResponseWriter, r *http.Request) (Worker, *template.Template, map[string]interface{}, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse form: %s", err)
+	}
+
+	keyspace := r.FormValue("keyspace")
+	if keyspace == "" {
+		// display the list of possible keyspaces to choose from
+		result := make(map[string]interface{})
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		keyspaces, err := wr.TopoServer().GetKeyspaces(shortCtx)
+		cancel()
+		if err != nil {
+			result["Error"] = fmt.Sprintf("failed to get list of keyspaces: %v", err)
+		} else {
+			result["Keyspaces"] = keyspaces
+		}
+		return nil, externalImportTemplate, result, nil
+	}
+
+	mysqlDbname := r.FormValue("mysqlDbname")
+	if mysqlDbname == "" {
+		// display the input form
+		result := make(map[string]interface{})
+		result["Keyspace"] = keyspace
+		result["DefaultSourceReaderCount"] = fmt.Sprintf("%v", defaultSourceReaderCount)
+		result["DefaultWriteQueryMaxRows"] = fmt.Sprintf("%v", defaultWriteQueryMaxRows)
+		result["DefaultWriteQueryMaxSize"] = fmt.Sprintf("%v", defaultWriteQueryMaxSize)
+		result["DefaultDestinationWriterCount"] = fmt.Sprintf("%v", defaultDestinationWriterCount)
+		return nil, externalImportTemplate2, result, nil
+	}
+
+	// get other parameters
+	mysqlPortStr := r.FormValue("mysqlPort")
+	mysqlPort, err := strconv.ParseInt(mysqlPortStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse mysqlPort: %s", err)
+	}
+	params := sqldb.ConnParams{
+		Host:       r.FormValue("mysqlHost"),
+		Port:       int(mysqlPort),
+		UnixSocket: r.FormValue("mysqlSocket"),
+		Uname:      r.FormValue("mysqlUser"),
+		Pass:       r.FormValue("mysqlPassword"),
+		DbName:     mysqlDbname,
+	}
+	var tableArray []string
+	if tables := r.FormValue("tables"); tables != "" {
+		tableArray = strings.Split(tables, ",")
+	}
+	var excludeTableArray []string
+	if excludeTables := r.FormValue("excludeTables"); excludeTables != "" {
+		excludeTableArray = strings.Split(excludeTables, ",")
+	}
+	sourceReaderCountStr := r.FormValue("sourceReaderCount")
+	sourceReaderCount, err := strconv.ParseInt(sourceReaderCountStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse sourceReaderCount: %s", err)
+	}
+	writeQueryMaxRowsStr := r.FormValue("writeQueryMaxRows")
+	writeQueryMaxRows, err := strconv.ParseInt(writeQueryMaxRowsStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse writeQueryMaxRows: %s", err)
+	}
+	writeQueryMaxSizeStr := r.FormValue("writeQueryMaxSize")
+	writeQueryMaxSize, err := strconv.ParseInt(writeQueryMaxSizeStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse writeQueryMaxSize: %s", err)
+	}
+	destinationWriterCountStr := r.FormValue("destinationWriterCount")
+	destinationWriterCount, err := strconv.ParseInt(destinationWriterCountStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse destinationWriterCount: %s", err)
+	}
+
+	// start the import job
+	wrk, err := newExternalImportWorker(wr, wi.cell, keyspace, params, tableArray, excludeTableArray, int(sourceReaderCount), int(writeQueryMaxRows), int(writeQueryMaxSize), int(destinationWriterCount))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot create worker: %v", err)
+	}
+	return wrk, nil, nil, nil
+}
+
+func init() {
+	AddCommand("Clones", Command{"ExternalImport",
+		commandExternalImport, interactiveExternalImport,
+		"--mysql_host=<host> --mysql_port=<port> --mysql_user=<user> --mysql_dbname=<dbname> [--tables=''] [--exclude_tables=''] <destination keyspace>",
+		"Imports the tables of a MySQL database which is not managed by Vitess into a keyspace. The binlog of the database is applied until the cut-over is triggered (e.g. with the CutOver command), and the tables are compared afterwards."})
+}
@@ -0,0 +1,254 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gitql/vitess/go/mysqlconn/replication"
+	"github.com/gitql/vitess/go/sqldb"
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/stats"
+	"github.com/gitql/vitess/go/vt/dbconfigs"
+	"github.com/gitql/vitess/go/vt/dbconnpool"
+	"github.com/gitql/vitess/go/vt/mysqlctl"
+	"golang.org/x/net/context"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// externalResultBatchSize is the maximum number of rows which an
+// externalResultReader returns per Next() call.
+const externalResultBatchSize = 1000
+
+// statsExternalMysql tracks the queries which were sent to external MySQL
+// servers e.g. by the ExternalImport command.
+var statsExternalMysql = stats.NewTimings("WorkerExternalMysql")
+
+// externalSource is a MySQL server which is not managed by Vitess.
+type externalSource struct {
+	params sqldb.ConnParams
+	dbName string
+	// mysqld is used to read the schema and the replication position, and to
+	// stream the binlog.
+	mysqld *mysqlctl.Mysqld
+}
+
+// newExternalSource returns an externalSource for the database
+// "params.DbName" of the server given by "params".
+func newExternalSource(params sqldb.ConnParams) *externalSource {
+	return &externalSource{
+		params: params,
+		dbName: params.DbName,
+		mysqld: mysqlctl.NewMysqld(&mysqlctl.Mycnf{}, &dbconfigs.DBConfigs{Dba: params}, dbconfigs.DbaConfig),
+	}
+}
+
+// String returns the address of the server and the database name.
+func (s *externalSource) String() string {
+	if s.params.UnixSocket != "" {
+		return fmt.Sprintf("%v/%v", s.params.UnixSocket, s.dbName)
+	}
+	return fmt.Sprintf("%v:%v/%v", s.params.Host, s.params.Port, s.dbName)
+}
+
+// getSchema returns the definition of the tables which match the filters.
+// Each table must have a primary key.
+func (s *externalSource) getSchema(tables, excludeTables []string) (*tabletmanagerdatapb.SchemaDefinition, error) {
+	sd, err := s.mysqld.GetSchema(s.dbName, tables, excludeTables, false /* includeViews */)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get schema from external source %v: %v", s, err)
+	}
+	if len(sd.TableDefinitions) == 0 {
+		return nil, fmt.Errorf("no tables matching the table filter in external source %v", s)
+	}
+	for _, td := range sd.TableDefinitions {
+		if len(td.PrimaryKeyColumns) == 0 {
+			return nil, fmt.Errorf("table %v has no primary key", td.Name)
+		}
+	}
+	return sd, nil
+}
+
+// autoIncrementColumns returns the AUTO_INCREMENT column of each table which
+// has one. The binlog router adds the generated values to the rows, and it
+// can only compute them if auto_increment_increment is 1.
+func (s *externalSource) autoIncrementColumns(ctx context.Context) (map[string]string, error) {
+	qr, err := s.mysqld.FetchSuperQuery(ctx, "SELECT @@global.auto_increment_increment")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read auto_increment_increment from external source %v: %v", s, err)
+	}
+	if len(qr.Rows) != 1 || qr.Rows[0][0].String() != "1" {
+		return nil, fmt.Errorf("external source %v must use auto_increment_increment=1, got: %v", s, qr.Rows)
+	}
+
+	qr, err = s.mysqld.FetchSuperQuery(ctx, "SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = '"+s.dbName+"' AND extra LIKE '%auto_increment%'")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the AUTO_INCREMENT columns of external source %v: %v", s, err)
+	}
+	columns := make(map[string]string, len(qr.Rows))
+	for _, row := range qr.Rows {
+		columns[row[0].String()] = row[1].String()
+	}
+	return columns, nil
+}
+
+// masterPosition returns the current replication position.
+func (s *externalSource) masterPosition() (replication.Position, error) {
+	return s.mysqld.MasterPosition()
+}
+
+// snapshot opens "count" connections which all read from the same consistent
+// snapshot of the data. The returned snapshot must be closed.
+// The tables are locked with FLUSH TABLES WITH READ LOCK while the snapshot
+// is taken. This requires the RELOAD privilege.
+func (s *externalSource) snapshot(count int) (*externalSnapshot, error) {
+	lockConn, err := dbconnpool.NewDBConnection(&s.params, statsExternalMysql)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to external source %v: %v", s, err)
+	}
+	defer lockConn.Close()
+	if _, err := lockConn.ExecuteFetch("FLUSH TABLES WITH READ LOCK", 0, false); err != nil {
+		return nil, fmt.Errorf("cannot lock the tables of external source %v: %v", s, err)
+	}
+
+	snapshot := &externalSnapshot{
+		conns: make(chan *dbconnpool.DBConnection, count),
+	}
+	for i := 0; i < count; i++ {
+		conn, err := dbconnpool.NewDBConnection(&s.params, statsExternalMysql)
+		if err != nil {
+			snapshot.close()
+			return nil, fmt.Errorf("cannot connect to external source %v: %v", s, err)
+		}
+		snapshot.all = append(snapshot.all, conn)
+		snapshot.conns <- conn
+		for _, query := range []string{
+			"SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ",
+			"START TRANSACTION WITH CONSISTENT SNAPSHOT",
+		} {
+			if _, err := conn.ExecuteFetch(query, 0, false); err != nil {
+				snapshot.close()
+				return nil, fmt.Errorf("cannot start a snapshot on external source %v: %v", s, err)
+			}
+		}
+	}
+
+	// No writes are possible while the tables are locked. Therefore, the
+	// position is the one of the snapshot.
+	snapshot.position, err = s.masterPosition()
+	if err != nil {
+		snapshot.close()
+		return nil, fmt.Errorf("cannot read the replication position of external source %v: %v", s, err)
+	}
+	if _, err := lockConn.ExecuteFetch("UNLOCK TABLES", 0, false); err != nil {
+		snapshot.close()
+		return nil, fmt.Errorf("cannot unlock the tables of external source %v: %v", s, err)
+	}
+	return snapshot, nil
+}
+
+// close closes the MySQL connections of the source.
+func (s *externalSource) close() {
+	s.mysqld.Close()
+}
+
+// externalSnapshot is a set of connections to an external source which
+// read from the same consistent snapshot.
+type externalSnapshot struct {
+	// position is the replication position of the snapshot.
+	position replication.Position
+	// conns has the connections which are not in use.
+	conns chan *dbconnpool.DBConnection
+	// all has all connections.
+	all []*dbconnpool.DBConnection
+}
+
+// get returns a connection which is not in use. It blocks until one is
+// returned with put().
+func (s *externalSnapshot) get() *dbconnpool.DBConnection {
+	return <-s.conns
+}
+
+// put returns a connection which was obtained with get().
+func (s *externalSnapshot) put(conn *dbconnpool.DBConnection) {
+	s.conns <- conn
+}
+
+// close closes all connections.
+func (s *externalSnapshot) close() {
+	for _, conn := range s.all {
+		conn.Close()
+	}
+}
+
+// externalResultReader streams all rows of a table from an external source.
+// It implements the ResultReader interface.
+type externalResultReader struct {
+	conn   *dbconnpool.DBConnection
+	fields []*querypb.Field
+	done   bool
+}
+
+// newExternalResultReader starts streaming the rows of the table "td",
+// ordered by the primary key. It uses "conn" until Close is called.
+// NOTE: We assume that the Columns field in "td" was ordered by a preceding
+// call to reorderColumnsPrimaryKeyFirst().
+func newExternalResultReader(conn *dbconnpool.DBConnection, dbName string, td *tabletmanagerdatapb.TableDefinition) (*externalResultReader, error) {
+	query := "SELECT " + strings.Join(escapeAll(td.Columns), ",") + " FROM " + escape(dbName) + "." + escape(td.Name) +
+		" ORDER BY " + strings.Join(escapeAll(td.PrimaryKeyColumns), ",")
+	if err := conn.Conn.ExecuteStreamFetch(query); err != nil {
+		return nil, fmt.Errorf("cannot stream the rows of table %v with query '%v': %v", td.Name, query, err)
+	}
+	fields, err := conn.Fields()
+	if err != nil {
+		conn.CloseResult()
+		return nil, fmt.Errorf("cannot read Fields for query '%v': %v", query, err)
+	}
+	return &externalResultReader{
+		conn:   conn,
+		fields: fields,
+	}, nil
+}
+
+// Fields returns the field information. It implements ResultReader.
+func (r *externalResultReader) Fields() []*querypb.Field {
+	return r.fields
+}
+
+// Next returns the next rows of the stream. It implements ResultReader.
+func (r *externalResultReader) Next() (*sqltypes.Result, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	result := &sqltypes.Result{
+		Fields: r.fields,
+	}
+	for len(result.Rows) < externalResultBatchSize {
+		row, err := r.conn.FetchNext()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			r.done = true
+			break
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if len(result.Rows) == 0 {
+		return nil, io.EOF
+	}
+	result.RowsAffected = uint64(len(result.Rows))
+	return result, nil
+}
+
+// Close finishes the stream. The connection can be used for the next query
+// afterwards.
+func (r *externalResultReader) Close() {
+	r.conn.CloseResult()
+}
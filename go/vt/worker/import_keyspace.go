@@ -0,0 +1,464 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"fmt"
+	"html/template"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/discovery"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+	"github.com/gitql/vitess/go/vt/vtgate/vindexes"
+	"github.com/gitql/vitess/go/vt/wrangler"
+
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// ImportKeyspaceWorker loads a dump which was written by the ExportKeyspace
+// command into a keyspace. The sharding of the keyspace does not have to
+// match the sharding of the exported keyspace: each row is routed to the
+// shard which owns its keyspace id.
+// The tables must already exist on the destination shards and should be
+// empty. The rows are inserted on the master tablets.
+type ImportKeyspaceWorker struct {
+	StatusWorker
+
+	wr                     *wrangler.Wrangler
+	cell                   string
+	keyspace               string
+	directory              string
+	tables                 []string
+	sourceReaderCount      int
+	writeQueryMaxRows      int
+	writeQueryMaxSize      int
+	destinationWriterCount int
+
+	// populated during WorkerStateInit, read-only after that
+	manifest          *dumpManifest
+	schemaDefinition  *tabletmanagerdatapb.SchemaDefinition
+	keyspaceInfo      *topo.KeyspaceInfo
+	keyspaceSchema    *vindexes.KeyspaceSchema
+	destinationShards []*topo.ShardInfo
+	// healthCheck is used to find the current MASTER tablet of each
+	// destination shard. It must be closed at the end of the command.
+	healthCheck discovery.HealthCheck
+	tsc         *discovery.TabletStatsCache
+	// shardWatchers contains a TopologyWatcher for each destination shard.
+	// Each watcher must be stopped at the end of the command.
+	shardWatchers []*discovery.TopologyWatcher
+
+	// populated during WorkerStateFindTargets, read-only after that
+	// destinationDbNames stores for each destination keyspace/shard the MySQL
+	// database name.
+	destinationDbNames map[string]string
+
+	// populated during WorkerStateCloneOffline
+	tableStatusList *tableStatusList
+}
+
+// importFile is a dump file of a table.
+type importFile struct {
+	tableIndex int
+	file       *dumpFile
+}
+
+// newImportKeyspaceWorker returns a new worker object for the
+// ImportKeyspace command.
+func newImportKeyspaceWorker(wr *wrangler.Wrangler, cell, keyspace, directory string, tables []string, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount int) (Worker, error) {
+	if directory == "" {
+		return nil, fmt.Errorf("the directory of the import must be set")
+	}
+	if sourceReaderCount <= 0 {
+		return nil, fmt.Errorf("source_reader_count must be > 0: %v", sourceReaderCount)
+	}
+	if writeQueryMaxRows <= 0 {
+		return nil, fmt.Errorf("write_query_max_rows must be > 0: %v", writeQueryMaxRows)
+	}
+	if writeQueryMaxSize <= 0 {
+		return nil, fmt.Errorf("write_query_max_size must be > 0: %v", writeQueryMaxSize)
+	}
+	if destinationWriterCount <= 0 {
+		return nil, fmt.Errorf("destination_writer_count must be > 0: %v", destinationWriterCount)
+	}
+
+	return &ImportKeyspaceWorker{
+		StatusWorker:           NewStatusWorker(),
+		wr:                     wr,
+		cell:                   cell,
+		keyspace:               keyspace,
+		directory:              directory,
+		tables:                 tables,
+		sourceReaderCount:      sourceReaderCount,
+		writeQueryMaxRows:      writeQueryMaxRows,
+		writeQueryMaxSize:      writeQueryMaxSize,
+		destinationWriterCount: destinationWriterCount,
+
+		destinationDbNames: make(map[string]string),
+		tableStatusList:    &tableStatusList{},
+	}, nil
+}
+
+// StatusAsHTML implements the Worker interface.
+func (w *ImportKeyspaceWorker) StatusAsHTML() template.HTML {
+	return template.HTML(strings.Replace(w.status(), "\n", "</br>\n", -1))
+}
+
+// StatusAsText implements the Worker interface.
+func (w *ImportKeyspaceWorker) StatusAsText() string {
+	return w.status()
+}
+
+func (w *ImportKeyspaceWorker) status() string {
+	state := w.State()
+
+	result := "Working on: " + w.directory + " to " + w.keyspace + "\n"
+	result += "State: " + state.String() + "\n"
+	switch state {
+	case WorkerStateCloneOffline:
+		statuses, eta := w.tableStatusList.format()
+		result += "Running:\n"
+		result += "Importing tables (ETA: " + eta.String() + "):\n"
+		result += strings.Join(statuses, "\n") + "\n"
+	case WorkerStateDone:
+		statuses, _ := w.tableStatusList.format()
+		result += "Success:\n"
+		result += strings.Join(statuses, "\n") + "\n"
+	}
+	return result
+}
+
+// Run implements the Worker interface.
+func (w *ImportKeyspaceWorker) Run(ctx context.Context) error {
+	resetVars(ctx)
+
+	// Run the command.
+	err := w.run(ctx)
+
+	// Cleanup.
+	w.SetState(WorkerStateCleanUp)
+	// Stop watchers to prevent new tablets from getting added to the healthCheck.
+	for _, watcher := range w.shardWatchers {
+		watcher.Stop()
+	}
+	// Stop healthCheck to make sure it stops calling our listener implementation.
+	if w.healthCheck != nil {
+		if err := w.healthCheck.Close(); err != nil {
+			w.wr.Logger().Errorf("HealthCheck.Close() failed: %v", err)
+		}
+	}
+
+	if err != nil {
+		w.SetState(WorkerStateError)
+		return err
+	}
+	w.SetState(WorkerStateDone)
+	return nil
+}
+
+func (w *ImportKeyspaceWorker) run(ctx context.Context) error {
+	// Phase 1: read what we need to do.
+	if err := w.init(ctx); err != nil {
+		return fmt.Errorf("init() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// Phase 2: find destination master tablets.
+	if err := w.findDestinationMasters(ctx); err != nil {
+		return fmt.Errorf("findDestinationMasters() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// Phase 3: load the files.
+	start := time.Now()
+	if err := w.load(ctx); err != nil {
+		return fmt.Errorf("load() failed: %v", err)
+	}
+	w.wr.Logger().Infof("Import finished after %v.", time.Since(start))
+	return nil
+}
+
+// init phase:
+// - read the manifest of the dump
+// - read the sharding scheme and the shards of the keyspace
+func (w *ImportKeyspaceWorker) init(ctx context.Context) error {
+	w.SetState(WorkerStateInit)
+
+	var err error
+	w.manifest, err = readDumpManifest(w.directory)
+	if err != nil {
+		return fmt.Errorf("cannot read the manifest of %v: %v", w.directory, err)
+	}
+	if err := w.filterTables(); err != nil {
+		return err
+	}
+	w.schemaDefinition = &tabletmanagerdatapb.SchemaDefinition{}
+	for _, table := range w.manifest.Tables {
+		w.schemaDefinition.TableDefinitions = append(w.schemaDefinition.TableDefinitions, table.tableDefinition())
+	}
+	w.wr.Logger().Infof("Importing %v tables which were exported from keyspace %v", len(w.manifest.Tables), w.manifest.Keyspace)
+
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	w.keyspaceInfo, err = w.wr.TopoServer().GetKeyspace(shortCtx, w.keyspace)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot read keyspace %v: %v", w.keyspace, err)
+	}
+	if *useV3ReshardingMode {
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		kschema, err := w.wr.TopoServer().GetVSchema(shortCtx, w.keyspace)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("cannot load VSchema for keyspace %v: %v", w.keyspace, err)
+		}
+		if kschema == nil {
+			return fmt.Errorf("no VSchema for keyspace %v", w.keyspace)
+		}
+		w.keyspaceSchema, err = vindexes.BuildKeyspaceSchema(kschema, w.keyspace)
+		if err != nil {
+			return fmt.Errorf("cannot build vschema for keyspace %v: %v", w.keyspace, err)
+		}
+	}
+
+	shortCtx, cancel = context.WithTimeout(ctx, *remoteActionsTimeout)
+	shards, err := w.wr.TopoServer().FindAllShardsInKeyspace(shortCtx, w.keyspace)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot read the shards of keyspace %v: %v", w.keyspace, err)
+	}
+	if len(shards) == 0 {
+		return fmt.Errorf("keyspace %v has no shards", w.keyspace)
+	}
+	for _, si := range shards {
+		if len(si.SourceShards) > 0 {
+			return fmt.Errorf("destination shard %v/%v has filtered replication enabled (SourceShards is set). Finish the resharding before the import", si.Keyspace(), si.ShardName())
+		}
+		w.destinationShards = append(w.destinationShards, si)
+	}
+
+	// Initialize healthcheck and add destination shards to it.
+	w.healthCheck = discovery.NewHealthCheck(*remoteActionsTimeout, *healthcheckRetryDelay, *healthCheckTimeout)
+	w.tsc = discovery.NewTabletStatsCache(w.healthCheck, w.cell)
+	for _, si := range w.destinationShards {
+		watcher := discovery.NewShardReplicationWatcher(w.wr.TopoServer(), w.healthCheck,
+			w.cell, si.Keyspace(), si.ShardName(),
+			*healthCheckTopologyRefresh, discovery.DefaultTopoReadConcurrency)
+		w.shardWatchers = append(w.shardWatchers, watcher)
+	}
+	return nil
+}
+
+// filterTables removes the tables from the manifest which should not be
+// imported.
+func (w *ImportKeyspaceWorker) filterTables() error {
+	if len(w.tables) == 0 {
+		return nil
+	}
+	tables := make(map[string]*dumpTable)
+	for _, table := range w.manifest.Tables {
+		tables[table.Name] = table
+	}
+	var filtered []*dumpTable
+	for _, name := range w.tables {
+		table, ok := tables[name]
+		if !ok {
+			return fmt.Errorf("table %v is not in the dump %v", name, w.directory)
+		}
+		filtered = append(filtered, table)
+	}
+	w.manifest.Tables = filtered
+	return nil
+}
+
+// findDestinationMasters finds for each destination shard the current master.
+func (w *ImportKeyspaceWorker) findDestinationMasters(ctx context.Context) error {
+	w.SetState(WorkerStateFindTargets)
+
+	w.wr.Logger().Infof("Finding a MASTER tablet for each destination shard...")
+	for _, si := range w.destinationShards {
+		waitCtx, waitCancel := context.WithTimeout(ctx, *waitForHealthyTabletsTimeout)
+		defer waitCancel()
+		if err := w.tsc.WaitForTablets(waitCtx, w.cell, si.Keyspace(), si.ShardName(), []topodatapb.TabletType{topodatapb.TabletType_MASTER}); err != nil {
+			return fmt.Errorf("cannot find MASTER tablet for destination shard for %v/%v (in cell: %v): %v", si.Keyspace(), si.ShardName(), w.cell, err)
+		}
+		masters := w.tsc.GetHealthyTabletStats(si.Keyspace(), si.ShardName(), topodatapb.TabletType_MASTER)
+		if len(masters) == 0 {
+			return fmt.Errorf("cannot find MASTER tablet for destination shard for %v/%v (in cell: %v) in HealthCheck: empty TabletStats list", si.Keyspace(), si.ShardName(), w.cell)
+		}
+		master := masters[0]
+
+		// Get the MySQL database name of the tablet.
+		keyspaceAndShard := topoproto.KeyspaceShardString(si.Keyspace(), si.ShardName())
+		w.destinationDbNames[keyspaceAndShard] = topoproto.TabletDbName(master.Tablet)
+
+		w.wr.Logger().Infof("Using tablet %v as destination master for %v/%v", topoproto.TabletAliasString(master.Tablet.Alias), si.Keyspace(), si.ShardName())
+	}
+	return nil
+}
+
+// load phase:
+// - read the files of all tables in parallel
+// - route each row to its destination shard and insert it there
+func (w *ImportKeyspaceWorker) load(ctx context.Context) error {
+	w.SetState(WorkerStateCloneOffline)
+	start := time.Now()
+	defer func() {
+		statsStateDurationsNs.Set([]string{jobNameFromContext(ctx), string(WorkerStateCloneOffline)}, time.Now().Sub(start).Nanoseconds())
+	}()
+
+	w.tableStatusList.initialize(w.schemaDefinition)
+	routers := make([]RowRouter, len(w.manifest.Tables))
+	var files []*importFile
+	for tableIndex, td := range w.schemaDefinition.TableDefinitions {
+		var keyResolver keyspaceIDResolver
+		if len(w.destinationShards) > 1 {
+			var err error
+			keyResolver, err = w.keyResolver(td)
+			if err != nil {
+				return fmt.Errorf("cannot resolve sharding keys for keyspace %v: %v", w.keyspace, err)
+			}
+		}
+		routers[tableIndex] = NewRowRouter(w.destinationShards, keyResolver)
+
+		table := w.manifest.Tables[tableIndex]
+		for _, f := range table.Files {
+			files = append(files, &importFile{tableIndex, f})
+		}
+		w.tableStatusList.setThreadCount(tableIndex, len(table.Files))
+	}
+
+	// mu protects the context for cancelation, and firstError
+	mu := sync.Mutex{}
+	var firstError error
+
+	ctx, cancelLoad := context.WithCancel(ctx)
+	defer cancelLoad()
+	processError := func(format string, args ...interface{}) {
+		w.wr.Logger().Errorf(format, args...)
+		mu.Lock()
+		if firstError == nil {
+			firstError = fmt.Errorf(format, args...)
+			cancelLoad()
+		}
+		mu.Unlock()
+	}
+
+	insertChannels := make([]chan insertCommand, len(w.destinationShards))
+	destinationWaitGroup := sync.WaitGroup{}
+	for shardIndex, si := range w.destinationShards {
+		insertChannels[shardIndex] = make(chan insertCommand, w.destinationWriterCount*2)
+		for j := 0; j < w.destinationWriterCount; j++ {
+			destinationWaitGroup.Add(1)
+			go func(keyspace, shard string, insertChannel chan insertCommand, threadID int) {
+				defer destinationWaitGroup.Done()
+
+				executor := newExecutor(w.wr, w.tsc, nil /* throttler */, keyspace, shard, threadID)
+				if err := executor.fetchLoop(ctx, insertChannel); err != nil {
+					processError("executer.FetchLoop failed: %v", err)
+				}
+			}(si.Keyspace(), si.ShardName(), insertChannels[shardIndex], j)
+		}
+	}
+
+	fileChannel := make(chan *importFile, len(files))
+	for _, f := range files {
+		fileChannel <- f
+	}
+	close(fileChannel)
+	sourceWaitGroup := sync.WaitGroup{}
+	for i := 0; i < w.sourceReaderCount; i++ {
+		sourceWaitGroup.Add(1)
+		go func() {
+			defer sourceWaitGroup.Done()
+			for f := range fileChannel {
+				if err := checkDone(ctx); err != nil {
+					return
+				}
+				if err := w.loadFile(ctx, f, routers[f.tableIndex], insertChannels); err != nil {
+					processError("file=%v: %v", f.file.Path, err)
+					return
+				}
+			}
+		}()
+	}
+	sourceWaitGroup.Wait()
+
+	for shardIndex := range w.destinationShards {
+		close(insertChannels[shardIndex])
+	}
+	destinationWaitGroup.Wait()
+	return firstError
+}
+
+// loadFile reads the rows of a file and sends them to the writers of their
+// destination shards.
+func (w *ImportKeyspaceWorker) loadFile(ctx context.Context, f *importFile, router RowRouter, insertChannels []chan insertCommand) error {
+	if err := acquireSourceReader(ctx); err != nil {
+		return err
+	}
+	defer releaseSourceReader(ctx)
+
+	table := w.manifest.Tables[f.tableIndex]
+	td := w.schemaDefinition.TableDefinitions[f.tableIndex]
+	types, err := table.columnTypes()
+	if err != nil {
+		return err
+	}
+	reader, err := openDumpRowReader(w.manifest.Format, path.Join(w.directory, f.file.Path), table.Columns, types)
+	if err != nil {
+		return err
+	}
+	defer reader.close()
+	w.tableStatusList.threadStarted(f.tableIndex)
+
+	aggregators := make([]*RowAggregator, len(w.destinationShards))
+	for i, si := range w.destinationShards {
+		dbName := w.destinationDbNames[topoproto.KeyspaceShardString(si.Keyspace(), si.ShardName())]
+		aggregators[i] = NewRowAggregator(ctx, w.writeQueryMaxRows, w.writeQueryMaxSize,
+			insertChannels[i], nil /* pendingWrites */, dbName, td, DiffMissing, statsOfflineInsertsCounters)
+	}
+	err = readDumpFile(reader, func(row []sqltypes.Value) error {
+		shardIndex, err := router.Route(row)
+		if err != nil {
+			return err
+		}
+		if err := aggregators[shardIndex].Add(row); err != nil {
+			return err
+		}
+		w.tableStatusList.addCopiedRows(f.tableIndex, 1)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, aggregator := range aggregators {
+		if err := aggregator.Flush(); err != nil {
+			return err
+		}
+	}
+	w.tableStatusList.threadDone(f.tableIndex)
+	return nil
+}
+
+// keyResolver returns the resolver for the keyspace ids of the destination
+// keyspace.
+func (w *ImportKeyspaceWorker) keyResolver(td *tabletmanagerdatapb.TableDefinition) (keyspaceIDResolver, error) {
+	if *useV3ReshardingMode {
+		return newV3ResolverFromTableDefinition(w.keyspaceSchema, td)
+	}
+	return newV2Resolver(w.keyspaceInfo, td)
+}
@@ -0,0 +1,136 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gitql/vitess/go/vt/wrangler"
+	"golang.org/x/net/context"
+)
+
+const importKeyspaceHTML = `
+<!DOCTYPE html>
+<head>
+  <title>Import Keyspace Action</title>
+</head>
+<body>
+  <h1>Import Keyspace Action</h1>
+    <form action="/Clones/ImportKeyspace" method="post">
+      <LABEL for="directory">Directory (path on the vtworker host): </LABEL>
+        <INPUT type="text" id="directory" name="directory" value=""></BR>
+      <LABEL for="keyspace">Destination Keyspace: </LABEL>
+        <INPUT type="text" id="keyspace" name="keyspace" value=""></BR>
+      <LABEL for="tables">Tables (comma separated, all tables of the export if empty): </LABEL>
+        <INPUT type="text" id="tables" name="tables" value=""></BR>
+      <LABEL for="sourceReaderCount">Number of Files which are read in parallel: </LABEL>
+        <INPUT type="text" id="sourceReaderCount" name="sourceReaderCount" value="{{.DefaultSourceReaderCount}}"></BR>
+      <LABEL for="writeQueryMaxRows">Maximum Number of Rows per Write Query: </LABEL>
+        <INPUT type="text" id="writeQueryMaxRows" name="writeQueryMaxRows" value="{{.DefaultWriteQueryMaxRows}}"></BR>
+      <LABEL for="writeQueryMaxSize">Maximum Size (in bytes) per Write Query: </LABEL>
+        <INPUT type="text" id="writeQueryMaxSize" name="writeQueryMaxSize" value="{{.DefaultWriteQueryMaxSize}}"></BR>
+      <LABEL for="destinationWriterCount">Destination Writer Count: </LABEL>
+        <INPUT type="text" id="destinationWriterCount" name="destinationWriterCount" value="{{.DefaultDestinationWriterCount}}"></BR>
+      <LABEL for="job">Job Name (empty for the default job): </LABEL>
+        <INPUT type="text" id="job" name="job" value=""></BR>
+      <INPUT type="submit" name="submit" value="Import Keyspace"/>
+    </form>
+
+  <h1>Help</h1>
+    <p>The directory must have been written by the ExportKeyspace command. The destination keyspace may be sharded differently than the exported keyspace.</p>
+    <p>The tables must already exist on all destination shards and should be empty. Their CREATE TABLE statements are in the manifest.json file of the directory.</p>
+  </body>
+`
+
+var importKeyspaceTemplate = mustParseTemplate("importKeyspace", importKeyspaceHTML)
+
+func commandImportKeyspace(wi *Instance, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) (Worker, error) {
+	directory := subFlags.String("directory", "", "local directory with the files of an export")
+	tables := subFlags.String("tables", "", "comma separated list of tables to import (all tables of the export by default)")
+	sourceReaderCount := subFlags.Int("source_reader_count", defaultSourceReaderCount, "number of files which are read in parallel")
+	writeQueryMaxRows := subFlags.Int("write_query_max_rows", defaultWriteQueryMaxRows, "maximum number of rows per write query")
+	writeQueryMaxSize := subFlags.Int("write_query_max_size", defaultWriteQueryMaxSize, "maximum size (in bytes) per write query")
+	destinationWriterCount := subFlags.Int("destination_writer_count", defaultDestinationWriterCount, "number of concurrent RPCs to execute on the destination")
+	if err := subFlags.Parse(args); err != nil {
+		return nil, err
+	}
+	if subFlags.NArg() != 1 {
+		subFlags.Usage()
+		return nil, fmt.Errorf("command ImportKeyspace requires <destination keyspace>")
+	}
+
+	var tableArray []string
+	if *tables != "" {
+		tableArray = strings.Split(*tables, ",")
+	}
+	worker, err := newImportKeyspaceWorker(wr, wi.cell, subFlags.Arg(0), *directory, tableArray, *sourceReaderCount, *writeQueryMaxRows, *writeQueryMaxSize, *destinationWriterCount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create worker: %v", err)
+	}
+	return worker, nil
+}
+
+func interactiveImportKeyspace(ctx context.Context, wi *Instance, wr *wrangler.Wrangler, w http.ResponseWriter, r *http.Request) (Worker, *template.Template, map[string]interface{}, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse form: %s", err)
+	}
+	directory := r.FormValue("directory")
+	keyspace := r.FormValue("keyspace")
+
+	if directory == "" || keyspace == "" {
+		// display the input form
+		result := make(map[string]interface{})
+		result["DefaultSourceReaderCount"] = fmt.Sprintf("%v", defaultSourceReaderCount)
+		result["DefaultWriteQueryMaxRows"] = fmt.Sprintf("%v", defaultWriteQueryMaxRows)
+		result["DefaultWriteQueryMaxSize"] = fmt.Sprintf("%v", defaultWriteQueryMaxSize)
+		result["DefaultDestinationWriterCount"] = fmt.Sprintf("%v", defaultDestinationWriterCount)
+		return nil, importKeyspaceTemplate, result, nil
+	}
+
+	// get other parameters
+	var tableArray []string
+	if tables := r.FormValue("tables"); tables != "" {
+		tableArray = strings.Split(tables, ",")
+	}
+	sourceReaderCountStr := r.FormValue("sourceReaderCount")
+	sourceReaderCount, err := strconv.ParseInt(sourceReaderCountStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse sourceReaderCount: %s", err)
+	}
+	writeQueryMaxRowsStr := r.FormValue("writeQueryMaxRows")
+	writeQueryMaxRows, err := strconv.ParseInt(writeQueryMaxRowsStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse writeQueryMaxRows: %s", err)
+	}
+	writeQueryMaxSizeStr := r.FormValue("writeQueryMaxSize")
+	writeQueryMaxSize, err := strconv.ParseInt(writeQueryMaxSizeStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse writeQueryMaxSize: %s", err)
+	}
+	destinationWriterCountStr := r.FormValue("destinationWriterCount")
+	destinationWriterCount, err := strconv.ParseInt(destinationWriterCountStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse destinationWriterCount: %s", err)
+	}
+
+	// start the import job
+	wrk, err := newImportKeyspaceWorker(wr, wi.cell, keyspace, directory, tableArray, int(sourceReaderCount), int(writeQueryMaxRows), int(writeQueryMaxSize), int(destinationWriterCount))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot create worker: %v", err)
+	}
+	return wrk, nil, nil, nil
+}
+
+func init() {
+	AddCommand("Clones", Command{"ImportKeyspace",
+		commandImportKeyspace, interactiveImportKeyspace,
+		"--directory=<local directory> [--tables=''] <destination keyspace>",
+		"Loads the files of an ExportKeyspace run into a keyspace with any sharding. The rows are routed to the destination shards by their keyspace id and inserted on the master tablets."})
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -26,37 +28,59 @@ import (
 	"github.com/gitql/vitess/go/vt/wrangler"
 )
 
+// defaultJobName is the name of the job which runs the commands without
+// an explicit "-job <name>" prefix.
+const defaultJobName = "default"
+
 // Instance encapsulate the execution state of vtworker.
+// It runs one or more named jobs concurrently. Each job runs one worker.
 type Instance struct {
 	// Default wrangler for all operations.
 	// Users can specify their own in RunCommand() e.g. the gRPC server does this.
 	wr *wrangler.Wrangler
 
-	// mutex is protecting all the following variables
-	// 3 states here:
-	// - no job ever ran (or reset was run): currentWorker is nil,
-	// currentContext/currentCancelFunc is nil, lastRunError is nil
-	// - one worker running: currentWorker is set,
-	//   currentContext/currentCancelFunc is set, lastRunError is nil
-	// - (at least) one worker already ran, none is running atm:
-	//   currentWorker is set, currentContext is nil, lastRunError
-	//   has the error returned by the worker.
-	currentWorkerMutex  sync.Mutex
-	currentWorker       Worker
-	currentMemoryLogger *logutil.MemoryLogger
-	currentContext      context.Context
-	currentCancelFunc   context.CancelFunc
-	lastRunError        error
-	lastRunStopTime     time.Time
+	// mu is protecting jobs and the fields of each job.
+	mu sync.Mutex
+	// jobs has the current job for each name.
+	// 3 states here, for each name:
+	// - no job ever ran (or reset was run): there is no entry.
+	// - one worker running: the job's ctx/cancel is set, err is nil
+	// - a worker already ran, none is running atm:
+	//   the job's ctx is nil and err has the error returned by the worker.
+	jobs map[string]*job
+
+	// budget limits the source readers and destination writers across
+	// all jobs.
+	budget *resourceBudget
 
 	topoServer             topo.Server
 	cell                   string
 	commandDisplayInterval time.Duration
 }
 
+// job is a worker run by the Instance under a name.
+type job struct {
+	name         string
+	worker       Worker
+	memoryLogger *logutil.MemoryLogger
+	done         chan struct{}
+
+	// The following fields are protected by Instance.mu.
+	ctx      context.Context
+	cancel   context.CancelFunc
+	err      error
+	stopTime time.Time
+}
+
 // NewInstance creates a new Instance.
 func NewInstance(ts topo.Server, cell string, commandDisplayInterval time.Duration) *Instance {
-	wi := &Instance{topoServer: ts, cell: cell, commandDisplayInterval: commandDisplayInterval}
+	wi := &Instance{
+		jobs:                   make(map[string]*job),
+		budget:                 newResourceBudget(*maxSourceReaders, *maxDestinationWriters),
+		topoServer:             ts,
+		cell:                   cell,
+		commandDisplayInterval: commandDisplayInterval,
+	}
 	// Note: setAndStartWorker() also adds a MemoryLogger for the webserver.
 	wi.wr = wi.CreateWrangler(logutil.NewConsoleLogger())
 	return wi
@@ -67,53 +91,72 @@ func (wi *Instance) CreateWrangler(logger logutil.Logger) *wrangler.Wrangler {
 	return wrangler.New(logger, wi.topoServer, tmclient.NewTabletManagerClient())
 }
 
-// setAndStartWorker will set the current worker.
+// setAndStartWorker will start the worker as the job "name".
 // We always log to both memory logger (for display on the web) and
-// console logger (for records / display of command line worker).
-func (wi *Instance) setAndStartWorker(ctx context.Context, wrk Worker, wr *wrangler.Wrangler) (chan struct{}, error) {
-	wi.currentWorkerMutex.Lock()
-	defer wi.currentWorkerMutex.Unlock()
-
-	if wi.currentContext != nil {
-		return nil, vterrors.FromError(vtrpcpb.ErrorCode_TRANSIENT_ERROR,
-			fmt.Errorf("A worker job is already in progress: %v", wi.currentWorker.StatusAsText()))
+// the current logger of the wrangler. Concurrent jobs must not share a wrangler.
+func (wi *Instance) setAndStartWorker(ctx context.Context, name string, wrk Worker, wr *wrangler.Wrangler) (chan struct{}, error) {
+	// The job name is the first part of the keys of the debug variables,
+	// which are joined with ".".
+	if name == "" || strings.Contains(name, ".") {
+		return nil, vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT,
+			fmt.Errorf("invalid job name %q: it must not be empty or contain a '.'", name))
 	}
 
-	if wi.currentWorker != nil {
+	wi.mu.Lock()
+	defer wi.mu.Unlock()
+
+	if j, ok := wi.jobs[name]; ok {
+		if j.ctx != nil {
+			return nil, vterrors.FromError(vtrpcpb.ErrorCode_TRANSIENT_ERROR,
+				fmt.Errorf("A worker job is already in progress (job: %v): %v", name, j.worker.StatusAsText()))
+		}
+
 		// During the grace period, we answer with a retryable error.
 		const gracePeriod = 1 * time.Minute
 		gracePeriodEnd := time.Now().Add(gracePeriod)
-		if wi.lastRunStopTime.Before(gracePeriodEnd) {
+		if j.stopTime.Before(gracePeriodEnd) {
 			return nil, vterrors.FromError(vtrpcpb.ErrorCode_TRANSIENT_ERROR,
-				fmt.Errorf("A worker job was recently stopped (%f seconds ago): %v",
-					time.Now().Sub(wi.lastRunStopTime).Seconds(),
-					wi.currentWorker))
+				fmt.Errorf("A worker job was recently stopped (%f seconds ago, job: %v): %v",
+					time.Now().Sub(j.stopTime).Seconds(),
+					name,
+					j.worker))
 		}
 
 		// QUERY_NOT_SERVED = FailedPrecondition => manual resolution required.
 		return nil, vterrors.FromError(vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
-			fmt.Errorf("The worker job was stopped %.1f minutes ago, but not reset. You have to reset it manually. Job: %v",
-				time.Now().Sub(wi.lastRunStopTime).Minutes(),
-				wi.currentWorker))
+			fmt.Errorf("The worker job %v was stopped %.1f minutes ago, but not reset. You have to reset it manually. Job: %v",
+				name,
+				time.Now().Sub(j.stopTime).Minutes(),
+				j.worker))
 	}
 
-	wi.currentWorker = wrk
-	wi.currentMemoryLogger = logutil.NewMemoryLogger()
-	wi.currentContext, wi.currentCancelFunc = context.WithCancel(ctx)
-	wi.lastRunError = nil
-	wi.lastRunStopTime = time.Unix(0, 0)
-	done := make(chan struct{})
+	j := &job{
+		name:         name,
+		worker:       wrk,
+		memoryLogger: logutil.NewMemoryLogger(),
+		done:         make(chan struct{}),
+	}
+	j.ctx, j.cancel = context.WithCancel(ctx)
+	wi.jobs[name] = j
 	wranglerLogger := wr.Logger()
 	if wr == wi.wr {
 		// If it's the default wrangler, do not reuse its logger because it may have been set before.
 		// Resuing it would result into an endless recursion.
 		wranglerLogger = logutil.NewConsoleLogger()
 	}
-	wr.SetLogger(logutil.NewTeeLogger(wi.currentMemoryLogger, wranglerLogger))
+	wr.SetLogger(logutil.NewTeeLogger(j.memoryLogger, wranglerLogger))
+
+	// The worker gets the resource budget and its job name through its context.
+	runCtx := withJobName(withResourceBudget(j.ctx, wi.budget), name)
+	if jw, ok := wrk.(interface {
+		setJobName(string)
+	}); ok {
+		jw.setJobName(name)
+	}
 
 	// one go function runs the worker, changes state when done
 	go func() {
-		log.Infof("Starting worker...")
+		log.Infof("Starting worker job %v...", name)
 		var err error
 
 		// Catch all panics and always save the execution state at the end.
@@ -124,30 +167,61 @@ func (wi *Instance) setAndStartWorker(ctx context.Context, wrk Worker, wr *wrang
 				err = fmt.Errorf("uncaught vtworker panic: %v", x)
 			}
 
-			wi.currentWorkerMutex.Lock()
-			wi.currentContext = nil
-			wi.currentCancelFunc = nil
-			wi.lastRunError = err
-			wi.lastRunStopTime = time.Now()
-			wi.currentWorkerMutex.Unlock()
-			close(done)
+			wi.mu.Lock()
+			j.ctx = nil
+			j.cancel = nil
+			j.err = err
+			j.stopTime = time.Now()
+			wi.mu.Unlock()
+			close(j.done)
 		}()
 
 		// run will take a long time
-		err = wrk.Run(wi.currentContext)
+		err = wrk.Run(runCtx)
 
 		// If the context was canceled, include the respective error code.
 		select {
-		case <-wi.currentContext.Done():
+		case <-runCtx.Done():
 			// Context is done i.e. probably canceled.
-			if wi.currentContext.Err() == context.Canceled {
+			if runCtx.Err() == context.Canceled {
 				err = vterrors.NewVitessError(vtrpcpb.ErrorCode_CANCELLED, err, "vtworker command was canceled: %v", err)
 			}
 		default:
 		}
 	}()
 
-	return done, nil
+	return j.done, nil
+}
+
+// jobList returns the current jobs, sorted by name.
+func (wi *Instance) jobList() []*job {
+	wi.mu.Lock()
+	defer wi.mu.Unlock()
+
+	names := make([]string, 0, len(wi.jobs))
+	for name := range wi.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	jobs := make([]*job, 0, len(names))
+	for _, name := range names {
+		jobs = append(jobs, wi.jobs[name])
+	}
+	return jobs
+}
+
+// jobResult returns the error of the job which ran the worker.
+// It returns nil if there is no such job anymore.
+func (wi *Instance) jobResult(wrk Worker) error {
+	wi.mu.Lock()
+	defer wi.mu.Unlock()
+
+	for _, j := range wi.jobs {
+		if j.worker == wrk {
+			return j.err
+		}
+	}
+	return nil
 }
 
 // InstallSignalHandlers installs signal handler which exit vtworker gracefully.
@@ -159,12 +233,17 @@ func (wi *Instance) InstallSignalHandlers() {
 			// We got a signal, notify our modules.
 			// Use an extra function to properly unlock using defer.
 			func() {
-				wi.currentWorkerMutex.Lock()
-				defer wi.currentWorkerMutex.Unlock()
-				if wi.currentCancelFunc != nil {
-					log.Infof("Trying to cancel current worker after receiving signal: %v", s)
-					wi.currentCancelFunc()
-				} else {
+				wi.mu.Lock()
+				defer wi.mu.Unlock()
+				running := false
+				for name, j := range wi.jobs {
+					if j.cancel != nil {
+						log.Infof("Trying to cancel worker job %v after receiving signal: %v", name, s)
+						j.cancel()
+						running = true
+					}
+				}
+				if !running {
 					log.Infof("Shutting down idle worker after receiving signal: %v", s)
 					os.Exit(0)
 				}
@@ -173,40 +252,61 @@ func (wi *Instance) InstallSignalHandlers() {
 	}()
 }
 
-// Reset resets the state of a finished worker. It returns an error if the worker is still running.
-func (wi *Instance) Reset() error {
-	wi.currentWorkerMutex.Lock()
-	defer wi.currentWorkerMutex.Unlock()
+// Reset resets the state of the finished job "name".
+// It returns an error if the job is still running.
+func (wi *Instance) Reset(name string) error {
+	wi.mu.Lock()
+	defer wi.mu.Unlock()
 
-	if wi.currentWorker == nil {
+	j, ok := wi.jobs[name]
+	if !ok {
 		return nil
 	}
 
 	// check the worker is really done
-	if wi.currentContext == nil {
-		wi.currentWorker = nil
-		wi.currentMemoryLogger = nil
+	if j.ctx == nil {
+		delete(wi.jobs, name)
 		return nil
 	}
 
 	return errors.New("worker still executing")
 }
 
-// Cancel calls the cancel function of the current vtworker job.
-// It returns true, if a job was running. False otherwise.
+// Cancel calls the cancel function of the vtworker job "name".
+// It returns true, if the job was running. False otherwise.
 // NOTE: Cancel won't reset the state as well. Use Reset() to do so.
-func (wi *Instance) Cancel() bool {
-	wi.currentWorkerMutex.Lock()
+func (wi *Instance) Cancel(name string) bool {
+	wi.mu.Lock()
 
-	if wi.currentWorker == nil || wi.currentCancelFunc == nil {
-		wi.currentWorkerMutex.Unlock()
+	j, ok := wi.jobs[name]
+	if !ok || j.cancel == nil {
+		wi.mu.Unlock()
 		return false
 	}
 
-	cancel := wi.currentCancelFunc
-	wi.currentWorkerMutex.Unlock()
+	cancel := j.cancel
+	wi.mu.Unlock()
 
 	cancel()
 
 	return true
 }
+
+// CutOver triggers the cut-over of the running job "name". It returns an
+// error if the job is not running, or if its worker has no cut-over.
+func (wi *Instance) CutOver(name string) error {
+	wi.mu.Lock()
+	j, ok := wi.jobs[name]
+	if !ok || j.ctx == nil {
+		wi.mu.Unlock()
+		return fmt.Errorf("no running job with the name: %v", name)
+	}
+	wrk := j.worker
+	wi.mu.Unlock()
+
+	cw, ok := wrk.(CutOverWorker)
+	if !ok {
+		return fmt.Errorf("the worker of job %v has no cut-over", name)
+	}
+	return cw.CutOver()
+}
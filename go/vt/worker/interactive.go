@@ -14,6 +14,7 @@ import (
 	log "github.com/golang/glog"
 
 	"github.com/gitql/vitess/go/acl"
+	"github.com/gitql/vitess/go/vt/logutil"
 	"github.com/gitql/vitess/go/vt/servenv"
 )
 
@@ -103,7 +104,9 @@ func (wi *Instance) InitInteractiveMode() {
 					return
 				}
 
-				wrk, template, data, err := pc.Interactive(context.Background(), wi, wi.wr, w, r)
+				// Each job gets its own wrangler, to keep the logs of the jobs apart.
+				wr := wi.CreateWrangler(logutil.NewConsoleLogger())
+				wrk, template, data, err := pc.Interactive(context.Background(), wi, wr, w, r)
 				if err != nil {
 					httpError(w, "%s", err)
 				} else if template != nil && data != nil {
@@ -116,7 +119,7 @@ func (wi *Instance) InitInteractiveMode() {
 					return
 				}
 
-				if _, err := wi.setAndStartWorker(context.Background(), wrk, wi.wr); err != nil {
+				if _, err := wi.setAndStartWorker(context.Background(), jobName(r), wrk, wr); err != nil {
 					httpError(w, "Could not set %s worker: %s", c.Name, err)
 					return
 				}
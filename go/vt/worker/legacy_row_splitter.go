@@ -58,7 +58,7 @@ func (rs *RowSplitter) Split(result [][][]sqltypes.Value, rows [][]sqltypes.Valu
 }
 
 // Send will send the rows to the list of channels. Returns true if aborted.
-func (rs *RowSplitter) Send(fields []*querypb.Field, result [][][]sqltypes.Value, baseCmds []string, insertChannels []chan string, abort <-chan struct{}) bool {
+func (rs *RowSplitter) Send(fields []*querypb.Field, result [][][]sqltypes.Value, baseCmds []string, insertChannels []chan insertCommand, abort <-chan struct{}) bool {
 	for i, c := range insertChannels {
 		// one of the chunks might be empty, so no need
 		// to send data in that case
@@ -66,7 +66,7 @@ func (rs *RowSplitter) Send(fields []*querypb.Field, result [][][]sqltypes.Value
 			cmd := baseCmds[i] + makeValueString(fields, result[i])
 			// also check on abort, so we don't wait forever
 			select {
-			case c <- cmd:
+			case c <- insertCommand{sql: cmd}:
 			case <-abort:
 				return true
 			}
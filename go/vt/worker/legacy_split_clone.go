@@ -190,7 +190,7 @@ func (scw *LegacySplitCloneWorker) StatusAsText() string {
 
 // Run implements the Worker interface
 func (scw *LegacySplitCloneWorker) Run(ctx context.Context) error {
-	resetVars()
+	resetVars(ctx)
 
 	// Run the command.
 	err := scw.run(ctx)
@@ -439,7 +439,7 @@ func (scw *LegacySplitCloneWorker) copy(ctx context.Context) error {
 	scw.setState(WorkerStateCloneOffline)
 	start := time.Now()
 	defer func() {
-		statsStateDurationsNs.Set(string(WorkerStateCloneOffline), time.Now().Sub(start).Nanoseconds())
+		statsStateDurationsNs.Set([]string{jobNameFromContext(ctx), string(WorkerStateCloneOffline)}, time.Now().Sub(start).Nanoseconds())
 	}()
 
 	// get source schema from the first shard
@@ -481,7 +481,7 @@ func (scw *LegacySplitCloneWorker) copy(ctx context.Context) error {
 		mu.Unlock()
 	}
 
-	insertChannels := make([]chan string, len(scw.destinationShards))
+	insertChannels := make([]chan insertCommand, len(scw.destinationShards))
 	destinationWaitGroup := sync.WaitGroup{}
 	for shardIndex, si := range scw.destinationShards {
 		// we create one channel per destination tablet.  It
@@ -489,9 +489,9 @@ func (scw *LegacySplitCloneWorker) copy(ctx context.Context) error {
 		// destinationWriterCount * 2 items, to hopefully
 		// always have data. We then have
 		// destinationWriterCount go routines reading from it.
-		insertChannels[shardIndex] = make(chan string, scw.destinationWriterCount*2)
+		insertChannels[shardIndex] = make(chan insertCommand, scw.destinationWriterCount*2)
 
-		go func(keyspace, shard string, insertChannel chan string) {
+		go func(keyspace, shard string, insertChannel chan insertCommand) {
 			for j := 0; j < scw.destinationWriterCount; j++ {
 				destinationWaitGroup.Add(1)
 				go func(threadID int) {
@@ -560,6 +560,11 @@ func (scw *LegacySplitCloneWorker) copy(ctx context.Context) error {
 
 					sema.Acquire()
 					defer sema.Release()
+					if err := acquireSourceReader(ctx); err != nil {
+						processError("table=%v chunk=%v: %v", td.Name, chunk, err)
+						return
+					}
+					defer releaseSourceReader(ctx)
 
 					scw.tableStatusList.threadStarted(tableIndex)
 
@@ -682,7 +687,7 @@ func (scw *LegacySplitCloneWorker) copy(ctx context.Context) error {
 
 // processData pumps the data out of the provided QueryResultReader.
 // It returns any error the source encounters.
-func (scw *LegacySplitCloneWorker) processData(ctx context.Context, dbNames []string, td *tabletmanagerdatapb.TableDefinition, tableIndex int, rr ResultReader, rowSplitter *RowSplitter, insertChannels []chan string, destinationPackCount int) error {
+func (scw *LegacySplitCloneWorker) processData(ctx context.Context, dbNames []string, td *tabletmanagerdatapb.TableDefinition, tableIndex int, rr ResultReader, rowSplitter *RowSplitter, insertChannels []chan insertCommand, destinationPackCount int) error {
 	// Store the baseCmd per destination shard because each tablet may have a
 	// different dbName.
 	baseCmds := make([]string, len(dbNames))
@@ -67,6 +67,8 @@ const legacySplitCloneHTML2 = `
         <INPUT type="text" id="maxTPS" name="maxTPS" value="{{.DefaultMaxTPS}}"></BR>
       <INPUT type="hidden" name="keyspace" value="{{.Keyspace}}"/>
       <INPUT type="hidden" name="shard" value="{{.Shard}}"/>
+      <LABEL for="job">Job Name (empty for the default job): </LABEL>
+        <INPUT type="text" id="job" name="job" value=""></BR>
       <INPUT type="submit" value="Clone"/>
     </form>
 
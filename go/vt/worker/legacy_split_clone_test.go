@@ -323,7 +323,7 @@ func TestLegacySplitCloneV2_Throttled(t *testing.T) {
 	//           throttle request interval (negligible backoff)
 	// - 3rd tx: throttled for 33 ms at least since 2nd tx happened
 	want := 33 * time.Millisecond
-	copyDuration := time.Duration(statsStateDurationsNs.Counts()[string(WorkerStateCloneOffline)]) * time.Nanosecond
+	copyDuration := time.Duration(statsStateDurationsNs.Counts()[defaultJobName+"."+string(WorkerStateCloneOffline)]) * time.Nanosecond
 	if copyDuration < want {
 		t.Errorf("throttled copy was too fast: %v < %v", copyDuration, want)
 	}
@@ -354,11 +354,11 @@ func TestLegacySplitCloneV2_RetryDueToReadonly(t *testing.T) {
 	}
 
 	wantRetryCount := int64(2)
-	if got := statsRetryCount.Get(); got != wantRetryCount {
+	if got := statsRetryCount.Counts()[defaultJobName]; got != wantRetryCount {
 		t.Errorf("Wrong statsRetryCounter: got %v, wanted %v", got, wantRetryCount)
 	}
 	wantRetryReadOnlyCount := int64(2)
-	if got := statsRetryCounters.Counts()[retryCategoryReadOnly]; got != wantRetryReadOnlyCount {
+	if got := statsRetryCounters.Counts()[defaultJobName+"."+retryCategoryReadOnly]; got != wantRetryReadOnlyCount {
 		t.Errorf("Wrong statsRetryCounters: got %v, wanted %v", got, wantRetryReadOnlyCount)
 	}
 }
@@ -419,7 +419,7 @@ func TestLegacySplitCloneV2_RetryDueToReparent(t *testing.T) {
 	}
 
 	wantRetryCountMin := int64(1)
-	if got := statsRetryCount.Get(); got < wantRetryCountMin {
+	if got := statsRetryCount.Counts()[defaultJobName]; got < wantRetryCountMin {
 		t.Errorf("Wrong statsRetryCounter: got %v, wanted >= %v", got, wantRetryCountMin)
 	}
 }
@@ -460,7 +460,7 @@ func TestLegacySplitCloneV2_NoMasterAvailable(t *testing.T) {
 		defer cancel()
 
 		for {
-			if statsRetryCounters.Counts()[retryCategoryNoMasterAvailable] >= 1 {
+			if statsRetryCounters.Counts()[defaultJobName+"."+retryCategoryNoMasterAvailable] >= 1 {
 				break
 			}
 
@@ -58,7 +58,7 @@ func (pw *PanicWorker) StatusAsText() string {
 
 // Run implements the Worker interface.
 func (pw *PanicWorker) Run(ctx context.Context) error {
-	resetVars()
+	resetVars(ctx)
 	err := pw.run(ctx)
 
 	pw.SetState(WorkerStateCleanUp)
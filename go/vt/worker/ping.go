@@ -65,7 +65,7 @@ func (pw *PingWorker) StatusAsText() string {
 
 // Run implements the Worker interface.
 func (pw *PingWorker) Run(ctx context.Context) error {
-	resetVars()
+	resetVars(ctx)
 	err := pw.run(ctx)
 
 	pw.SetState(WorkerStateCleanUp)
@@ -28,6 +28,8 @@ const pingHTML = `
 	    <form action="/Debugging/Ping" method="post">
 	      <LABEL for="message">Message to be logged with level CONSOLE: </LABEL>
 					<INPUT type="text" id="message" name="message" value="pong"></BR>
+	      <LABEL for="job">Job Name (empty for the default job): </LABEL>
+	        <INPUT type="text" id="job" name="job" value=""></BR>
 	      <INPUT type="submit" value="Ping"/>
     </form>
     {{end}}
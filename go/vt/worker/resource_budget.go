@@ -0,0 +1,107 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+)
+
+// resourceBudget limits the number of concurrent source readers and
+// destination writers across all jobs of an Instance.
+// A nil channel means that the resource is unlimited.
+type resourceBudget struct {
+	sourceReaders      chan struct{}
+	destinationWriters chan struct{}
+}
+
+func newResourceBudget(maxSourceReaders, maxDestinationWriters int) *resourceBudget {
+	b := &resourceBudget{}
+	if maxSourceReaders > 0 {
+		b.sourceReaders = make(chan struct{}, maxSourceReaders)
+	}
+	if maxDestinationWriters > 0 {
+		b.destinationWriters = make(chan struct{}, maxDestinationWriters)
+	}
+	return b
+}
+
+// String returns the current usage of the budget.
+func (b *resourceBudget) String() string {
+	return fmt.Sprintf("source readers: %v, destination writers: %v", usage(b.sourceReaders), usage(b.destinationWriters))
+}
+
+func usage(c chan struct{}) string {
+	if c == nil {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%v/%v in use", len(c), cap(c))
+}
+
+// resourceBudgetKey is the context key of the resourceBudget.
+type resourceBudgetKey struct{}
+
+// withResourceBudget returns a context which carries the budget to the worker.
+func withResourceBudget(ctx context.Context, b *resourceBudget) context.Context {
+	return context.WithValue(ctx, resourceBudgetKey{}, b)
+}
+
+// budgetFromContext returns the budget of the context, or nil if the worker
+// was not started by an Instance.
+func budgetFromContext(ctx context.Context) *resourceBudget {
+	b, _ := ctx.Value(resourceBudgetKey{}).(*resourceBudget)
+	return b
+}
+
+func acquire(ctx context.Context, c chan struct{}) error {
+	if c == nil {
+		return nil
+	}
+	select {
+	case c <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func release(c chan struct{}) {
+	if c != nil {
+		<-c
+	}
+}
+
+// acquireSourceReader blocks until the global budget allows one more source
+// reader, or the context is done. releaseSourceReader must be called
+// when the reader is done.
+func acquireSourceReader(ctx context.Context) error {
+	if b := budgetFromContext(ctx); b != nil {
+		return acquire(ctx, b.sourceReaders)
+	}
+	return nil
+}
+
+func releaseSourceReader(ctx context.Context) {
+	if b := budgetFromContext(ctx); b != nil {
+		release(b.sourceReaders)
+	}
+}
+
+// acquireDestinationWriter blocks until the global budget allows one more
+// write to the destination, or the context is done.
+// releaseDestinationWriter must be called when the write is done.
+func acquireDestinationWriter(ctx context.Context) error {
+	if b := budgetFromContext(ctx); b != nil {
+		return acquire(ctx, b.destinationWriters)
+	}
+	return nil
+}
+
+func releaseDestinationWriter(ctx context.Context) {
+	if b := budgetFromContext(ctx); b != nil {
+		release(b.destinationWriters)
+	}
+}
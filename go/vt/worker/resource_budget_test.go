@@ -0,0 +1,48 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestResourceBudget(t *testing.T) {
+	b := newResourceBudget(1, 0)
+	ctx := withResourceBudget(context.Background(), b)
+
+	if err := acquireSourceReader(ctx); err != nil {
+		t.Fatalf("acquireSourceReader failed: %v", err)
+	}
+	// The destination writers are unlimited.
+	for i := 0; i < 10; i++ {
+		if err := acquireDestinationWriter(ctx); err != nil {
+			t.Fatalf("acquireDestinationWriter failed: %v", err)
+		}
+	}
+	if got, want := b.String(), "source readers: 1/1 in use, destination writers: unlimited"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+
+	// The second source reader has to wait for the first one.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := acquireSourceReader(timeoutCtx); err != context.DeadlineExceeded {
+		t.Fatalf("acquireSourceReader() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	releaseSourceReader(ctx)
+	if err := acquireSourceReader(ctx); err != nil {
+		t.Fatalf("acquireSourceReader failed after release: %v", err)
+	}
+	releaseSourceReader(ctx)
+
+	// Without a budget in the context, there is no limit.
+	if err := acquireSourceReader(context.Background()); err != nil {
+		t.Fatalf("acquireSourceReader without a budget failed: %v", err)
+	}
+	releaseSourceReader(context.Background())
+}
@@ -58,6 +58,14 @@ type RestartableResultReader struct {
 // NOTE: We assume that the Columns field in "td" was ordered by a preceding
 // call to reorderColumnsPrimaryKeyFirst().
 func NewRestartableResultReader(ctx context.Context, logger logutil.Logger, tp tabletProvider, td *tabletmanagerdatapb.TableDefinition, chunk chunk, allowMultipleRetries bool) (*RestartableResultReader, error) {
+	return newRestartableResultReaderAfter(ctx, logger, tp, td, chunk, nil /* lastKey */, allowMultipleRetries)
+}
+
+// newRestartableResultReaderAfter is like NewRestartableResultReader, but
+// only streams the rows of the chunk after "lastKey", if it is set.
+// "lastKey" has the values of the primary key columns of a row. It is used to
+// resume the clone of a chunk from a checkpoint.
+func newRestartableResultReaderAfter(ctx context.Context, logger logutil.Logger, tp tabletProvider, td *tabletmanagerdatapb.TableDefinition, chunk chunk, lastKey []sqltypes.Value, allowMultipleRetries bool) (*RestartableResultReader, error) {
 	r := &RestartableResultReader{
 		ctx:                  ctx,
 		logger:               logger,
@@ -65,6 +73,7 @@ func NewRestartableResultReader(ctx context.Context, logger logutil.Logger, tp t
 		td:                   td,
 		chunk:                chunk,
 		allowMultipleRetries: allowMultipleRetries,
+		lastRow:              lastKey,
 	}
 
 	// If the initial connection fails, we do not restart.
@@ -129,7 +138,7 @@ func (r *RestartableResultReader) startStream() (bool, error) {
 	r.output = stream
 
 	alias := topoproto.TabletAliasString(r.tablet.Alias)
-	statsStreamingQueryCounters.Add(alias, 1)
+	statsStreamingQueryCounters.Add([]string{jobNameFromContext(r.ctx), alias}, 1)
 	log.V(2).Infof("tablet=%v table=%v chunk=%v: Starting to stream rows using query '%v'.", alias, r.td.Name, r.chunk, r.query)
 	return false, nil
 }
@@ -142,7 +151,7 @@ func (r *RestartableResultReader) Next() (*sqltypes.Result, error) {
 		// of starting a timer (for the retry timeout) for every Next() call
 		// when no error occurs.
 		alias := topoproto.TabletAliasString(r.tablet.Alias)
-		statsStreamingQueryErrorsCounters.Add(alias, 1)
+		statsStreamingQueryErrorsCounters.Add([]string{jobNameFromContext(r.ctx), alias}, 1)
 		log.V(2).Infof("tablet=%v table=%v chunk=%v: Failed to read next rows from active streaming query. Trying to restart stream on the same tablet. Original Error: %v", alias, r.td.Name, r.chunk, err)
 		result, err = r.nextWithRetries()
 	}
@@ -200,9 +209,9 @@ func (r *RestartableResultReader) nextWithRetries() (*sqltypes.Result, error) {
 			alias := topoproto.TabletAliasString(r.tablet.Alias)
 			log.V(2).Infof("tablet=%v table=%v chunk=%v: Successfully restarted streaming query with query '%v' after %.1f seconds.", alias, r.td.Name, r.chunk, r.query, time.Now().Sub(start).Seconds())
 			if attempt == 2 {
-				statsStreamingQueryRestartsSameTabletCounters.Add(alias, 1)
+				statsStreamingQueryRestartsSameTabletCounters.Add([]string{jobNameFromContext(r.ctx), alias}, 1)
 			} else {
-				statsStreamingQueryRestartsDifferentTablet.Add(1)
+				statsStreamingQueryRestartsDifferentTablet.Add(jobNameFromContext(r.ctx), 1)
 			}
 
 			// Recv() was successful.
@@ -220,7 +229,7 @@ func (r *RestartableResultReader) nextWithRetries() (*sqltypes.Result, error) {
 			alias = topoproto.TabletAliasString(r.tablet.Alias)
 			// tablet may be nil if e.g. the HealthCheck module currently does not
 			// return a tablet.
-			statsStreamingQueryErrorsCounters.Add(alias, 1)
+			statsStreamingQueryErrorsCounters.Add([]string{jobNameFromContext(r.ctx), alias}, 1)
 		}
 
 		deadline, _ := retryCtx.Deadline()
@@ -260,30 +269,22 @@ func (r *RestartableResultReader) generateQuery() {
 	// start value.
 	if r.lastRow == nil {
 		// Initial query.
-		if !r.chunk.start.IsNull() {
-			var b bytes.Buffer
-			writeEscaped(&b, r.td.PrimaryKeyColumns[0])
-			b.WriteString(">=")
-			r.chunk.start.EncodeSQL(&b)
-			clauses = append(clauses, b.String())
+		if r.chunk.start != nil {
+			clauses = append(clauses, tupleWhereClause(r.td.PrimaryKeyColumns[:len(r.chunk.start)], r.chunk.start, ">=")...)
 		}
 	} else {
 		// This is a restart. Read after the last row.
 		// Note that we don't have to be concerned that the new start might be > end
 		// because lastRow < end is always true. That's because the initial query
-		// had the clause 'WHERE PrimaryKeyColumns[0] < end'.
+		// had the clause 'WHERE (PrimaryKeyColumns...) < end'.
 		// TODO(mberlin): Write an e2e test to verify that restarts also work with
 		// string types and MySQL collation rules.
 		clauses = append(clauses, greaterThanTupleWhereClause(r.td.PrimaryKeyColumns, r.lastRow)...)
 	}
 
 	// end value.
-	if !r.chunk.end.IsNull() {
-		var b bytes.Buffer
-		writeEscaped(&b, r.td.PrimaryKeyColumns[0])
-		b.WriteString("<")
-		r.chunk.end.EncodeSQL(&b)
-		clauses = append(clauses, b.String())
+	if r.chunk.end != nil {
+		clauses = append(clauses, tupleWhereClause(r.td.PrimaryKeyColumns[:len(r.chunk.end)], r.chunk.end, "<")...)
 	}
 
 	if len(clauses) > 0 {
@@ -316,13 +317,25 @@ func (r *RestartableResultReader) generateQuery() {
 // when we use the short-form. With the additional clause we skip the full
 // table scan up the primary key we're interested it.
 func greaterThanTupleWhereClause(columns []string, row []sqltypes.Value) []string {
+	return tupleWhereClause(columns, row, ">")
+}
+
+// tupleWhereClause builds a WHERE clause expression which compares the
+// "columns" with the values in "row" using the operator "op" (one of ">",
+// ">=" and "<"). See greaterThanTupleWhereClause() for the details.
+// For "<", the extra clause on the first column is "a<=1".
+func tupleWhereClause(columns []string, row []sqltypes.Value, op string) []string {
 	var clauses []string
 
 	// Additional clause on the first column for multi-columns.
 	if len(columns) > 1 {
 		var b bytes.Buffer
 		writeEscaped(&b, columns[0])
-		b.WriteString(">=")
+		if op == "<" {
+			b.WriteString("<=")
+		} else {
+			b.WriteString(">=")
+		}
 		row[0].EncodeSQL(&b)
 		clauses = append(clauses, b.String())
 	}
@@ -338,7 +351,7 @@ func greaterThanTupleWhereClause(columns []string, row []sqltypes.Value) []strin
 	}
 
 	// Operator.
-	b.WriteString(">")
+	b.WriteString(op)
 
 	// List of values.
 	if len(columns) > 1 {
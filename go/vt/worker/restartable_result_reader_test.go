@@ -67,8 +67,8 @@ func TestGreaterThanTupleWhereClause(t *testing.T) {
 func TestGenerateQuery(t *testing.T) {
 	testcases := []struct {
 		desc              string
-		start             sqltypes.Value
-		end               sqltypes.Value
+		start             []sqltypes.Value
+		end               []sqltypes.Value
 		table             string
 		columns           []string
 		primaryKeyColumns []string
@@ -77,8 +77,8 @@ func TestGenerateQuery(t *testing.T) {
 	}{
 		{
 			desc:              "start and end defined",
-			start:             sqltypes.MakeTrusted(sqltypes.Int64, []byte("11")),
-			end:               sqltypes.MakeTrusted(sqltypes.Int64, []byte("26")),
+			start:             []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("11"))},
+			end:               []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("26"))},
 			table:             "t1",
 			columns:           []string{"a", "msg1", "msg2"},
 			primaryKeyColumns: []string{"a"},
@@ -86,7 +86,7 @@ func TestGenerateQuery(t *testing.T) {
 		},
 		{
 			desc:              "only end defined",
-			end:               sqltypes.MakeTrusted(sqltypes.Int64, []byte("26")),
+			end:               []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("26"))},
 			table:             "t1",
 			columns:           []string{"a", "msg1", "msg2"},
 			primaryKeyColumns: []string{"a"},
@@ -94,7 +94,7 @@ func TestGenerateQuery(t *testing.T) {
 		},
 		{
 			desc:              "only start defined",
-			start:             sqltypes.MakeTrusted(sqltypes.Int64, []byte("11")),
+			start:             []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("11"))},
 			table:             "t1",
 			columns:           []string{"a", "msg1", "msg2"},
 			primaryKeyColumns: []string{"a"},
@@ -116,8 +116,8 @@ func TestGenerateQuery(t *testing.T) {
 		},
 		{
 			desc:              "start and end defined (multi-column primary key)",
-			start:             sqltypes.MakeTrusted(sqltypes.Int64, []byte("11")),
-			end:               sqltypes.MakeTrusted(sqltypes.Int64, []byte("26")),
+			start:             []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("11"))},
+			end:               []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("26"))},
 			table:             "t1",
 			columns:           []string{"a", "b", "msg1", "msg2"},
 			primaryKeyColumns: []string{"a", "b"},
@@ -125,8 +125,8 @@ func TestGenerateQuery(t *testing.T) {
 		},
 		{
 			desc:              "start overriden by last row (multi-column primary key)",
-			start:             sqltypes.MakeTrusted(sqltypes.Int64, []byte("11")),
-			end:               sqltypes.MakeTrusted(sqltypes.Int64, []byte("26")),
+			start:             []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("11"))},
+			end:               []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("26"))},
 			table:             "t1",
 			columns:           []string{"a", "b", "msg1", "msg2"},
 			primaryKeyColumns: []string{"a", "b"},
@@ -147,6 +147,28 @@ func TestGenerateQuery(t *testing.T) {
 			},
 			want: "SELECT `a`,`b`,`msg1`,`msg2` FROM `t1` WHERE `a`>=1 AND (`a`,`b`)>(1,2) ORDER BY `a`,`b`",
 		},
+		{
+			desc:              "start and end tuples defined (multi-column primary key)",
+			start:             []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.VarChar, []byte("abc")), sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))},
+			end:               []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.VarChar, []byte("def")), sqltypes.MakeTrusted(sqltypes.Int64, []byte("2"))},
+			table:             "t1",
+			columns:           []string{"a", "b", "msg1", "msg2"},
+			primaryKeyColumns: []string{"a", "b"},
+			want:              "SELECT `a`,`b`,`msg1`,`msg2` FROM `t1` WHERE `a`>='abc' AND (`a`,`b`)>=('abc',1) AND `a`<='def' AND (`a`,`b`)<('def',2) ORDER BY `a`,`b`",
+		},
+		{
+			desc:              "start tuple overriden by last row (multi-column primary key)",
+			start:             []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.VarChar, []byte("abc")), sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))},
+			end:               []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.VarChar, []byte("def")), sqltypes.MakeTrusted(sqltypes.Int64, []byte("2"))},
+			table:             "t1",
+			columns:           []string{"a", "b", "msg1", "msg2"},
+			primaryKeyColumns: []string{"a", "b"},
+			lastRow: []sqltypes.Value{
+				sqltypes.MakeTrusted(sqltypes.VarChar, []byte("bcd")),
+				sqltypes.MakeTrusted(sqltypes.Int64, []byte("5")),
+			},
+			want: "SELECT `a`,`b`,`msg1`,`msg2` FROM `t1` WHERE `a`>='bcd' AND (`a`,`b`)>('bcd',5) AND `a`<='def' AND (`a`,`b`)<('def',2) ORDER BY `a`,`b`",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"sync"
 
 	"golang.org/x/net/context"
 
@@ -31,13 +32,18 @@ type RowAggregator struct {
 	ctx           context.Context
 	maxRows       int
 	maxSize       int
-	insertChannel chan string
+	insertChannel chan insertCommand
+	// pendingWrites, if set, tracks the sent queries which were not executed
+	// yet.
+	pendingWrites *sync.WaitGroup
 	td            *tabletmanagerdatapb.TableDefinition
 	diffType      DiffType
 	builder       QueryBuilder
-	// statsCounters has a "diffType" specific stats.Counters object to track how
-	// many rows were changed per table.
-	statsCounters *stats.Counters
+	// statsCounters has a "diffType" specific stats.MultiCounters object to
+	// track how many rows were changed per job and table.
+	statsCounters *stats.MultiCounters
+	// statsKey is the key of the job and table in statsCounters.
+	statsKey []string
 
 	buffer       bytes.Buffer
 	bufferedRows int
@@ -47,7 +53,9 @@ type RowAggregator struct {
 // The index of the elements in statCounters must match the elements
 // in "DiffTypes" i.e. the first counter is for inserts, second for updates
 // and the third for deletes.
-func NewRowAggregator(ctx context.Context, maxRows, maxSize int, insertChannel chan string, dbName string, td *tabletmanagerdatapb.TableDefinition, diffType DiffType, statsCounters *stats.Counters) *RowAggregator {
+// If pendingWrites is not nil, it is incremented for each sent query and
+// decremented once the query was executed.
+func NewRowAggregator(ctx context.Context, maxRows, maxSize int, insertChannel chan insertCommand, pendingWrites *sync.WaitGroup, dbName string, td *tabletmanagerdatapb.TableDefinition, diffType DiffType, statsCounters *stats.MultiCounters) *RowAggregator {
 	// Construct head and tail base commands for the reconciliation statement.
 	var builder QueryBuilder
 	switch diffType {
@@ -71,10 +79,12 @@ func NewRowAggregator(ctx context.Context, maxRows, maxSize int, insertChannel c
 		maxRows:       maxRows,
 		maxSize:       maxSize,
 		insertChannel: insertChannel,
+		pendingWrites: pendingWrites,
 		td:            td,
 		diffType:      diffType,
 		builder:       builder,
 		statsCounters: statsCounters,
+		statsKey:      []string{jobNameFromContext(ctx), td.Name},
 	}
 }
 
@@ -109,15 +119,23 @@ func (ra *RowAggregator) Flush() error {
 	}
 
 	ra.builder.WriteTail(&ra.buffer)
+	cmd := insertCommand{sql: ra.buffer.String()}
+	if ra.pendingWrites != nil {
+		ra.pendingWrites.Add(1)
+		cmd.done = ra.pendingWrites.Done
+	}
 	// select blocks until sending the SQL succeeded or the context was canceled.
 	select {
-	case ra.insertChannel <- ra.buffer.String():
+	case ra.insertChannel <- cmd:
 	case <-ra.ctx.Done():
+		if ra.pendingWrites != nil {
+			ra.pendingWrites.Done()
+		}
 		return fmt.Errorf("failed to flush RowAggregator and send the query to a writer thread channel: %v", ra.ctx.Err())
 	}
 
 	// Update our statistics.
-	ra.statsCounters.Add(ra.td.Name, int64(ra.bufferedRows))
+	ra.statsCounters.Add(ra.statsKey, int64(ra.bufferedRows))
 
 	ra.buffer.Reset()
 	ra.bufferedRows = 0
@@ -6,6 +6,7 @@ package worker
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"golang.org/x/net/context"
@@ -61,10 +62,15 @@ type RowDiffer2 struct {
 	router RowRouter
 	// aggregators are keyed by destination shard and DiffType.
 	aggregators [][]*RowAggregator
-	// equalRowsStatsCounters tracks per table how many rows are equal.
-	equalRowsStatsCounters *stats.Counters
-	// tableName is required to update "equalRowsStatsCounters".
-	tableName string
+	// equalRowsStatsCounters tracks per job and table how many rows are equal.
+	equalRowsStatsCounters *stats.MultiCounters
+	// statsKey is the job and table key in "equalRowsStatsCounters".
+	statsKey []string
+
+	// checkpoint, if set, is called at most every checkpointInterval by Diff.
+	// See SetCheckpoint.
+	checkpoint         func(lastKey []sqltypes.Value, processedRows int) error
+	checkpointInterval time.Duration
 }
 
 // NewRowDiffer2 returns a new RowDiffer2.
@@ -73,11 +79,12 @@ type RowDiffer2 struct {
 // The column list td.Columns must be have all primary key columns first and
 // then the non-primary-key columns. The columns in the rows returned by
 // both ResultReader must have the same order as td.Columns.
+// pendingWrites is optional and passed to the RowAggregator instances.
 func NewRowDiffer2(ctx context.Context, left, right ResultReader, td *tabletmanagerdatapb.TableDefinition, tableStatusList *tableStatusList, tableIndex int,
 	// Parameters required by RowRouter.
 	destinationShards []*topo.ShardInfo, keyResolver keyspaceIDResolver,
 	// Parameters required by RowAggregator.
-	insertChannels []chan string, abort <-chan struct{}, dbNames []string, writeQueryMaxRows, writeQueryMaxSize int, statsCounters []*stats.Counters) (*RowDiffer2, error) {
+	insertChannels []chan insertCommand, pendingWrites *sync.WaitGroup, abort <-chan struct{}, dbNames []string, writeQueryMaxRows, writeQueryMaxSize int, statsCounters []*stats.MultiCounters) (*RowDiffer2, error) {
 
 	if len(statsCounters) != len(DiffTypes) {
 		panic(fmt.Sprintf("statsCounter has the wrong number of elements. got = %v, want = %v", len(statsCounters), len(DiffTypes)))
@@ -94,7 +101,7 @@ func NewRowDiffer2(ctx context.Context, left, right ResultReader, td *tabletmana
 		for _, typ := range DiffFoundTypes {
 			maxRows := writeQueryMaxRows
 			aggregators[i][typ] = NewRowAggregator(ctx, maxRows, writeQueryMaxSize,
-				insertChannels[i], dbNames[i], td, typ, statsCounters[typ])
+				insertChannels[i], pendingWrites, dbNames[i], td, typ, statsCounters[typ])
 		}
 	}
 
@@ -107,7 +114,7 @@ func NewRowDiffer2(ctx context.Context, left, right ResultReader, td *tabletmana
 		router:                 NewRowRouter(destinationShards, keyResolver),
 		aggregators:            aggregators,
 		equalRowsStatsCounters: statsCounters[DiffEqual],
-		tableName:              td.Name,
+		statsKey:               []string{jobNameFromContext(ctx), td.Name},
 	}, nil
 }
 
@@ -123,6 +130,16 @@ func compareFields(left, right []*querypb.Field) error {
 	return nil
 }
 
+// SetCheckpoint makes Diff call "checkpoint" at most every "interval" with
+// the primary key of the last processed row, and the number of processed
+// rows. Before each call, the reconciliations of all the rows up to and
+// including that key are flushed to the insert channels.
+// It has no effect if the table has no primary key.
+func (rd *RowDiffer2) SetCheckpoint(interval time.Duration, checkpoint func(lastKey []sqltypes.Value, processedRows int) error) {
+	rd.checkpointInterval = interval
+	rd.checkpoint = checkpoint
+}
+
 // Diff runs the diff and reconcile.
 // If an error occurs, it will return and stop.
 func (rd *RowDiffer2) Diff() (DiffReport, error) {
@@ -137,7 +154,27 @@ func (rd *RowDiffer2) Diff() (DiffReport, error) {
 	var right []sqltypes.Value
 	advanceLeft := true
 	advanceRight := true
+	lastCheckpoint := time.Now()
 	for {
+		// The rows which will be advanced were processed. Since both sides are
+		// sorted by primary key, all rows up to that key are reconciled.
+		if rd.checkpoint != nil && rd.pkFieldCount > 0 && time.Since(lastCheckpoint) >= rd.checkpointInterval {
+			processed := right
+			if advanceLeft {
+				processed = left
+			}
+			if processed != nil && (advanceLeft || advanceRight) {
+				if err := rd.flush(); err != nil {
+					return dr, err
+				}
+				lastKey := append([]sqltypes.Value(nil), processed[:rd.pkFieldCount]...)
+				if err := rd.checkpoint(lastKey, dr.processedRows); err != nil {
+					return dr, fmt.Errorf("checkpoint failed: %v", err)
+				}
+				lastCheckpoint = time.Now()
+			}
+		}
+
 		if advanceLeft {
 			if left, err = rd.left.Next(); err != nil {
 				return dr, err
@@ -240,22 +277,30 @@ func (rd *RowDiffer2) Diff() (DiffReport, error) {
 	}
 
 	// Flush all aggregators in case they have buffered queries left.
+	if err := rd.flush(); err != nil {
+		return dr, err
+	}
+
+	return dr, nil
+}
+
+// flush sends the buffered queries of all aggregators.
+func (rd *RowDiffer2) flush() error {
 	for i := range rd.aggregators {
 		for _, aggregator := range rd.aggregators[i] {
 			if err := aggregator.Flush(); err != nil {
-				return dr, err
+				return err
 			}
 		}
 	}
-
-	return dr, nil
+	return nil
 }
 
 // skipRow is used for the DiffType DiffEqual.
 // Currently, it only updates the statistics and therefore does not require the
 // row as input.
 func (rd *RowDiffer2) skipRow() {
-	rd.equalRowsStatsCounters.Add(rd.tableName, 1)
+	rd.equalRowsStatsCounters.Add(rd.statsKey, 1)
 	rd.tableStatusList.addCopiedRows(rd.tableIndex, 1)
 }
 
@@ -15,6 +15,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/gitql/vitess/go/event"
+	"github.com/gitql/vitess/go/sqltypes"
 	"github.com/gitql/vitess/go/stats"
 	"github.com/gitql/vitess/go/sync2"
 	"github.com/gitql/vitess/go/vt/binlog/binlogplayer"
@@ -56,6 +57,9 @@ type SplitCloneWorker struct {
 	shard               string
 	online              bool
 	offline             bool
+	// resume is true if the clone continues from the checkpoints of a
+	// previous run.
+	resume bool
 	// verticalSplit only: List of tables which should be split out.
 	tables []string
 	// horizontalResharding only: List of tables which will be skipped.
@@ -72,6 +76,9 @@ type SplitCloneWorker struct {
 	maxReplicationLag       int64
 	cleaner                 *wrangler.Cleaner
 	tabletTracker           *TabletTracker
+	// checkpointer saves the progress of the clone. It is nil if the
+	// checkpoints are disabled.
+	checkpointer *cloneCheckpointer
 
 	// populated during WorkerStateInit, read-only after that
 	destinationKeyspaceInfo *topo.KeyspaceInfo
@@ -126,20 +133,20 @@ type SplitCloneWorker struct {
 }
 
 // newSplitCloneWorker returns a new worker object for the SplitClone command.
-func newSplitCloneWorker(wr *wrangler.Wrangler, cell, keyspace, shard string, online, offline bool, excludeTables []string, strategyStr string, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets int, maxTPS, maxReplicationLag int64) (Worker, error) {
-	return newCloneWorker(wr, horizontalResharding, cell, keyspace, shard, online, offline, nil /* tables */, excludeTables, strategyStr, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets, maxTPS, maxReplicationLag)
+func newSplitCloneWorker(wr *wrangler.Wrangler, cell, keyspace, shard string, online, offline, resume bool, excludeTables []string, strategyStr string, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets int, maxTPS, maxReplicationLag int64) (Worker, error) {
+	return newCloneWorker(wr, horizontalResharding, cell, keyspace, shard, online, offline, resume, nil /* tables */, excludeTables, strategyStr, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets, maxTPS, maxReplicationLag)
 }
 
 // newVerticalSplitCloneWorker returns a new worker object for the
 // VerticalSplitClone command.
-func newVerticalSplitCloneWorker(wr *wrangler.Wrangler, cell, keyspace, shard string, online, offline bool, tables []string, strategyStr string, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets int, maxTPS, maxReplicationLag int64) (Worker, error) {
-	return newCloneWorker(wr, verticalSplit, cell, keyspace, shard, online, offline, tables, nil /* excludeTables */, strategyStr, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets, maxTPS, maxReplicationLag)
+func newVerticalSplitCloneWorker(wr *wrangler.Wrangler, cell, keyspace, shard string, online, offline, resume bool, tables []string, strategyStr string, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets int, maxTPS, maxReplicationLag int64) (Worker, error) {
+	return newCloneWorker(wr, verticalSplit, cell, keyspace, shard, online, offline, resume, tables, nil /* excludeTables */, strategyStr, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets, maxTPS, maxReplicationLag)
 }
 
 // newCloneWorker returns a new SplitCloneWorker object which is used both by
 // the SplitClone and VerticalSplitClone command.
 // TODO(mberlin): Rename SplitCloneWorker to cloneWorker.
-func newCloneWorker(wr *wrangler.Wrangler, cloneType cloneType, cell, keyspace, shard string, online, offline bool, tables, excludeTables []string, strategyStr string, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets int, maxTPS, maxReplicationLag int64) (Worker, error) {
+func newCloneWorker(wr *wrangler.Wrangler, cloneType cloneType, cell, keyspace, shard string, online, offline, resume bool, tables, excludeTables []string, strategyStr string, chunkCount, minRowsPerChunk, sourceReaderCount, writeQueryMaxRows, writeQueryMaxSize, destinationWriterCount, minHealthyRdonlyTablets int, maxTPS, maxReplicationLag int64) (Worker, error) {
 	if cloneType != horizontalResharding && cloneType != verticalSplit {
 		return nil, fmt.Errorf("unknown cloneType: %v This is a bug. Please report", cloneType)
 	}
@@ -185,6 +192,16 @@ func newCloneWorker(wr *wrangler.Wrangler, cloneType cloneType, cell, keyspace,
 	if maxReplicationLag <= 0 {
 		return nil, fmt.Errorf("max_replication_lag must be >= 1s: %v", maxReplicationLag)
 	}
+	var checkpointer *cloneCheckpointer
+	if *cloneCheckpointInterval > 0 {
+		command := "SplitClone"
+		if cloneType == verticalSplit {
+			command = "VerticalSplitClone"
+		}
+		checkpointer = newCloneCheckpointer(wr.TopoServer(), command, keyspace, shard)
+	} else if resume {
+		return nil, errors.New("resume requires the checkpoints, but -clone_checkpoint_interval is 0")
+	}
 
 	scw := &SplitCloneWorker{
 		StatusWorker:            NewStatusWorker(),
@@ -195,6 +212,7 @@ func newCloneWorker(wr *wrangler.Wrangler, cloneType cloneType, cell, keyspace,
 		shard:                   shard,
 		online:                  online,
 		offline:                 offline,
+		resume:                  resume,
 		tables:                  tables,
 		excludeTables:           excludeTables,
 		strategy:                strategy,
@@ -209,6 +227,7 @@ func newCloneWorker(wr *wrangler.Wrangler, cloneType cloneType, cell, keyspace,
 		maxReplicationLag:       maxReplicationLag,
 		cleaner:                 &wrangler.Cleaner{},
 		tabletTracker:           NewTabletTracker(),
+		checkpointer:            checkpointer,
 		throttlers:              make(map[string]*throttler.Throttler),
 
 		destinationDbNames: make(map[string]string),
@@ -379,10 +398,16 @@ func (scw *SplitCloneWorker) StatusAsText() string {
 
 // Run implements the Worker interface
 func (scw *SplitCloneWorker) Run(ctx context.Context) error {
-	resetVars()
+	resetVars(ctx)
 
 	// Run the command.
 	err := scw.run(ctx)
+	if err == nil && scw.checkpointer != nil {
+		// The checkpoints are not needed anymore.
+		if err = scw.checkpointer.remove(ctx); err != nil {
+			err = fmt.Errorf("cannot remove the clone checkpoints: %v", err)
+		}
+	}
 
 	// Cleanup.
 	scw.setState(WorkerStateCleanUp)
@@ -422,6 +447,11 @@ func (scw *SplitCloneWorker) run(ctx context.Context) error {
 	if err := scw.init(ctx); err != nil {
 		return fmt.Errorf("init() failed: %v", err)
 	}
+	if scw.checkpointer != nil {
+		if err := scw.checkpointer.init(ctx, scw.resume); err != nil {
+			return fmt.Errorf("cannot read the clone checkpoints: %v", err)
+		}
+	}
 	if err := checkDone(ctx); err != nil {
 		return err
 	}
@@ -444,7 +474,9 @@ func (scw *SplitCloneWorker) run(ctx context.Context) error {
 	}
 
 	// Phase 3: (optional) online clone.
-	if scw.online {
+	if scw.online && scw.checkpointer != nil && scw.checkpointer.onlineDone() {
+		scw.wr.Logger().Infof("Online clone skipped because it was completed by the resumed run.")
+	} else if scw.online {
 		scw.wr.Logger().Infof("Online clone will be run now.")
 		// 3a: Wait for minimum number of source tablets (required for the diff).
 		if err := scw.waitForTablets(ctx, scw.sourceShards, *waitForHealthyTabletsTimeout); err != nil {
@@ -792,7 +824,7 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 	scw.setState(state)
 	start := time.Now()
 	defer func() {
-		statsStateDurationsNs.Set(string(state), time.Now().Sub(start).Nanoseconds())
+		statsStateDurationsNs.Set([]string{jobNameFromContext(ctx), string(state)}, time.Now().Sub(start).Nanoseconds())
 	}()
 
 	var firstSourceTablet *topodatapb.Tablet
@@ -809,15 +841,32 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 		}
 		firstSourceTablet = tablets[0].Tablet
 	}
-	var statsCounters []*stats.Counters
+	var statsCounters []*stats.MultiCounters
 	var tableStatusList *tableStatusList
+	var phase string
 	switch state {
 	case WorkerStateCloneOnline:
-		statsCounters = []*stats.Counters{statsOnlineInsertsCounters, statsOnlineUpdatesCounters, statsOnlineDeletesCounters, statsOnlineEqualRowsCounters}
+		statsCounters = []*stats.MultiCounters{statsOnlineInsertsCounters, statsOnlineUpdatesCounters, statsOnlineDeletesCounters, statsOnlineEqualRowsCounters}
 		tableStatusList = scw.tableStatusListOnline
+		phase = onlinePhase
 	case WorkerStateCloneOffline:
-		statsCounters = []*stats.Counters{statsOfflineInsertsCounters, statsOfflineUpdatesCounters, statsOfflineDeletesCounters, statsOfflineEqualRowsCounters}
+		statsCounters = []*stats.MultiCounters{statsOfflineInsertsCounters, statsOfflineUpdatesCounters, statsOfflineDeletesCounters, statsOfflineEqualRowsCounters}
 		tableStatusList = scw.tableStatusListOffline
+		phase = offlinePhase
+	}
+
+	if scw.checkpointer != nil {
+		var sourcePositions []string
+		if state == WorkerStateCloneOffline {
+			// The offline checkpoints can only be reused for the same snapshot.
+			var err error
+			if sourcePositions, err = scw.sourcePositions(ctx); err != nil {
+				return err
+			}
+		}
+		if err := scw.checkpointer.startPhase(ctx, phase, sourcePositions); err != nil {
+			return fmt.Errorf("cannot read the clone checkpoints: %v", err)
+		}
 	}
 
 	// The throttlers exist only for the duration of this clone() call.
@@ -852,18 +901,18 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 		mu.Unlock()
 	}
 
-	insertChannels := make([]chan string, len(scw.destinationShards))
+	insertChannels := make([]chan insertCommand, len(scw.destinationShards))
 	destinationWaitGroup := sync.WaitGroup{}
 	for shardIndex, si := range scw.destinationShards {
 		// We create one channel per destination tablet. It is sized to have a
 		// buffer of a maximum of destinationWriterCount * 2 items, to hopefully
 		// always have data. We then have destinationWriterCount go routines reading
 		// from it.
-		insertChannels[shardIndex] = make(chan string, scw.destinationWriterCount*2)
+		insertChannels[shardIndex] = make(chan insertCommand, scw.destinationWriterCount*2)
 
 		for j := 0; j < scw.destinationWriterCount; j++ {
 			destinationWaitGroup.Add(1)
-			go func(keyspace, shard string, insertChannel chan string, throttler *throttler.Throttler, threadID int) {
+			go func(keyspace, shard string, insertChannel chan insertCommand, throttler *throttler.Throttler, threadID int) {
 				defer destinationWaitGroup.Done()
 				defer throttler.ThreadFinished(threadID)
 
@@ -889,15 +938,40 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 
 		// TODO(mberlin): We're going to chunk *all* source shards based on the MIN
 		// and MAX values of the *first* source shard. Is this going to be a problem?
-		chunks, err := generateChunks(ctx, scw.wr, firstSourceTablet, td, scw.chunkCount, scw.minRowsPerChunk)
+		generate := func() ([]chunk, error) {
+			return generateChunks(ctx, scw.wr, firstSourceTablet, td, scw.chunkCount, scw.minRowsPerChunk)
+		}
+		var chunks []chunk
+		var checkpoints []*chunkCheckpoint
+		if scw.checkpointer != nil {
+			// The chunks of a resumed table are the ones of the previous run.
+			chunks, checkpoints, err = scw.checkpointer.tableChunks(ctx, td.Name, generate)
+		} else {
+			chunks, err = generate()
+		}
 		if err != nil {
 			return err
 		}
 		tableStatusList.setThreadCount(tableIndex, len(chunks))
 
-		for _, c := range chunks {
+		for i, c := range chunks {
+			// lastKey and copiedRows are the progress of a resumed chunk.
+			var lastKey []sqltypes.Value
+			copiedRows := 0
+			if checkpoints != nil {
+				cp := checkpoints[i]
+				copiedRows = cp.CopiedRows
+				tableStatusList.addCopiedRows(tableIndex, copiedRows)
+				if cp.Complete {
+					tableStatusList.threadStarted(tableIndex)
+					tableStatusList.threadDone(tableIndex)
+					continue
+				}
+				lastKey = cp.lastKey()
+			}
+
 			sourceWaitGroup.Add(1)
-			go func(td *tabletmanagerdatapb.TableDefinition, tableIndex int, chunk chunk) {
+			go func(td *tabletmanagerdatapb.TableDefinition, tableIndex int, chunk chunk, lastKey []sqltypes.Value, copiedRows int) {
 				defer sourceWaitGroup.Done()
 				errPrefix := fmt.Sprintf("table=%v chunk=%v", td.Name, chunk)
 
@@ -906,6 +980,11 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 
 				sema.Acquire()
 				defer sema.Release()
+				if err := acquireSourceReader(ctx); err != nil {
+					processError("%v: %v", errPrefix, err)
+					return
+				}
+				defer releaseSourceReader(ctx)
 
 				tableStatusList.threadStarted(tableIndex)
 
@@ -936,7 +1015,7 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 					} else {
 						tp = newShardTabletProvider(scw.tsc, scw.tabletTracker, si.Keyspace(), si.ShardName())
 					}
-					sourceResultReader, err := NewRestartableResultReader(ctx, scw.wr.Logger(), tp, td, chunk, allowMultipleRetries)
+					sourceResultReader, err := newRestartableResultReaderAfter(ctx, scw.wr.Logger(), tp, td, chunk, lastKey, allowMultipleRetries)
 					if err != nil {
 						processError("%v: NewRestartableResultReader for source: %v failed", errPrefix, tp.description())
 						return
@@ -955,7 +1034,7 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 
 				for shardIndex, si := range scw.destinationShards {
 					tp := newShardTabletProvider(scw.tsc, scw.tabletTracker, si.Keyspace(), si.ShardName())
-					destResultReader, err := NewRestartableResultReader(ctx, scw.wr.Logger(), tp, td, chunk, true /* allowMultipleRetries */)
+					destResultReader, err := newRestartableResultReaderAfter(ctx, scw.wr.Logger(), tp, td, chunk, lastKey, true /* allowMultipleRetries */)
 					if err != nil {
 						processError("%v: NewRestartableResultReader for destination: %v failed: %v", errPrefix, tp.description(), err)
 						return
@@ -991,22 +1070,44 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 					dbNames[i] = scw.destinationDbNames[keyspaceAndShard]
 				}
 				// Compare the data and reconcile any differences.
+				// pendingWrites tracks the writes of this chunk which were not
+				// executed yet.
+				var pendingWrites sync.WaitGroup
 				differ, err := NewRowDiffer2(ctx, sourceReader, destReader, td, tableStatusList, tableIndex,
 					scw.destinationShards, keyResolver,
-					insertChannels, ctx.Done(), dbNames, scw.writeQueryMaxRows, scw.writeQueryMaxSize, statsCounters)
+					insertChannels, &pendingWrites, ctx.Done(), dbNames, scw.writeQueryMaxRows, scw.writeQueryMaxSize, statsCounters)
 				if err != nil {
 					processError("%v: NewRowDiffer2 failed: %v", errPrefix, err)
 					return
 				}
-				// Ignore the diff report because all diffs should get reconciled.
-				_ /* DiffReport */, err = differ.Diff()
+				if scw.checkpointer != nil {
+					differ.SetCheckpoint(*cloneCheckpointInterval, func(key []sqltypes.Value, processedRows int) error {
+						if err := waitForWrites(ctx, &pendingWrites); err != nil {
+							return err
+						}
+						return scw.checkpointer.chunkProgress(ctx, td.Name, chunk, key, copiedRows+processedRows)
+					})
+				}
+				// All diffs get reconciled. The diff report is only used for the
+				// number of processed rows.
+				dr, err := differ.Diff()
 				if err != nil {
 					processError("%v: RowDiffer2 failed: %v", errPrefix, err)
 					return
 				}
+				if scw.checkpointer != nil {
+					if err := waitForWrites(ctx, &pendingWrites); err != nil {
+						processError("%v: failed to wait for the writes: %v", errPrefix, err)
+						return
+					}
+					if err := scw.checkpointer.chunkDone(ctx, td.Name, chunk, copiedRows+dr.processedRows); err != nil {
+						processError("%v: failed to save the clone checkpoint: %v", errPrefix, err)
+						return
+					}
+				}
 
 				tableStatusList.threadDone(tableIndex)
-			}(td, tableIndex, c)
+			}(td, tableIndex, c, lastKey, copiedRows)
 		}
 	}
 	sourceWaitGroup.Wait()
@@ -1018,6 +1119,11 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 	if firstError != nil {
 		return firstError
 	}
+	if scw.checkpointer != nil {
+		if err := scw.checkpointer.finishPhase(ctx, phase); err != nil {
+			return fmt.Errorf("cannot save the clone checkpoints: %v", err)
+		}
+	}
 
 	if state == WorkerStateCloneOffline {
 		// Create and populate the blp_checkpoint table to give filtered replication
@@ -1116,6 +1222,22 @@ func (scw *SplitCloneWorker) clone(ctx context.Context, state StatusWorkerState)
 	return firstError
 }
 
+// sourcePositions returns the replication positions of the offline source
+// tablets.
+func (scw *SplitCloneWorker) sourcePositions(ctx context.Context) ([]string, error) {
+	positions := make([]string, len(scw.sourceTablets))
+	for i, tablet := range scw.sourceTablets {
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		status, err := scw.wr.TabletManagerClient().SlaveStatus(shortCtx, tablet)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		positions[i] = status.Position
+	}
+	return positions, nil
+}
+
 func (scw *SplitCloneWorker) getSourceSchema(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.SchemaDefinition, error) {
 	// get source schema from the first shard
 	// TODO(alainjobart): for now, we assume the schema is compatible
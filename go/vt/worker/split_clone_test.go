@@ -558,10 +558,10 @@ func TestSplitCloneV2_Offline_RestartStreamingQuery(t *testing.T) {
 	}
 
 	alias := tc.sourceRdonlyQs[0].alias
-	if got, want := statsStreamingQueryErrorsCounters.Counts()[alias], int64(1); got != want {
+	if got, want := statsStreamingQueryErrorsCounters.Counts()[defaultJobName+"."+alias], int64(1); got != want {
 		t.Errorf("wrong number of errored streaming query for tablet: %v: got = %v, want = %v", alias, got, want)
 	}
-	if got, want := statsStreamingQueryCounters.Counts()[alias], int64(11); got != want {
+	if got, want := statsStreamingQueryCounters.Counts()[defaultJobName+"."+alias], int64(11); got != want {
 		t.Errorf("wrong number of streaming query starts for tablet: %v: got = %v, want = %v", alias, got, want)
 	}
 }
@@ -594,10 +594,10 @@ func TestSplitCloneV2_Offline_FailOverStreamingQuery_NotAllowed(t *testing.T) {
 	}
 
 	alias := tc.sourceRdonlyQs[0].alias
-	if got, want := statsStreamingQueryErrorsCounters.Counts()[alias], int64(1); got != want {
+	if got, want := statsStreamingQueryErrorsCounters.Counts()[defaultJobName+"."+alias], int64(1); got != want {
 		t.Errorf("wrong number of errored streaming query for tablet: %v: got = %v, want = %v", alias, got, want)
 	}
-	if got, want := statsStreamingQueryCounters.Counts()[alias], int64(1); got != want {
+	if got, want := statsStreamingQueryCounters.Counts()[defaultJobName+"."+alias], int64(1); got != want {
 		t.Errorf("wrong number of streaming query starts for tablet: %v: got = %v, want = %v", alias, got, want)
 	}
 }
@@ -642,13 +642,13 @@ func TestSplitCloneV2_Online_FailOverStreamingQuery(t *testing.T) {
 
 	first := tc.sourceRdonlyQs[0].alias
 	second := tc.sourceRdonlyQs[1].alias
-	if got, want := statsStreamingQueryErrorsCounters.Counts()[first], int64(2); got < want {
+	if got, want := statsStreamingQueryErrorsCounters.Counts()[defaultJobName+"."+first], int64(2); got < want {
 		t.Errorf("wrong number of errored streaming query for tablet: %v: got = %v, want >= %v", first, got, want)
 	}
-	if got, want := statsStreamingQueryCounters.Counts()[first], int64(1); got != want {
+	if got, want := statsStreamingQueryCounters.Counts()[defaultJobName+"."+first], int64(1); got != want {
 		t.Errorf("wrong number of streaming query starts for tablet: %v: got = %v, want = %v", first, got, want)
 	}
-	if got, want := statsStreamingQueryCounters.Counts()[second], int64(1); got != want {
+	if got, want := statsStreamingQueryCounters.Counts()[defaultJobName+"."+second], int64(1); got != want {
 		t.Errorf("wrong number of streaming query starts for tablet: %v: got = %v, want = %v", second, got, want)
 	}
 }
@@ -706,10 +706,10 @@ func TestSplitCloneV2_Online_TabletsUnavailableDuringRestart(t *testing.T) {
 	first := tc.sourceRdonlyQs[0].alias
 	// Note that we can track only 2 errors for the first tablet because it
 	// becomes unavailable after that.
-	if got, want := statsStreamingQueryErrorsCounters.Counts()[first], int64(2); got < want {
+	if got, want := statsStreamingQueryErrorsCounters.Counts()[defaultJobName+"."+first], int64(2); got < want {
 		t.Errorf("wrong number of errored streaming query for tablet: %v: got = %v, want >= %v", first, got, want)
 	}
-	if got, want := statsStreamingQueryCounters.Counts()[first], int64(1); got != want {
+	if got, want := statsStreamingQueryCounters.Counts()[defaultJobName+"."+first], int64(1); got != want {
 		t.Errorf("wrong number of streaming query starts for tablet: %v: got = %v, want = %v", first, got, want)
 	}
 }
@@ -867,7 +867,7 @@ func TestSplitCloneV2_Throttled(t *testing.T) {
 	//           throttle request interval (negligible backoff)
 	// - 3rd tx: throttled for 33 ms at least since 2nd tx happened
 	want := 33 * time.Millisecond
-	copyDuration := time.Duration(statsStateDurationsNs.Counts()[string(WorkerStateCloneOffline)]) * time.Nanosecond
+	copyDuration := time.Duration(statsStateDurationsNs.Counts()[defaultJobName+"."+string(WorkerStateCloneOffline)]) * time.Nanosecond
 	if copyDuration < want {
 		t.Errorf("throttled copy was too fast: %v < %v", copyDuration, want)
 	}
@@ -898,11 +898,11 @@ func TestSplitCloneV2_RetryDueToReadonly(t *testing.T) {
 	}
 
 	wantRetryCount := int64(2)
-	if got := statsRetryCount.Get(); got != wantRetryCount {
+	if got := statsRetryCount.Counts()[defaultJobName]; got != wantRetryCount {
 		t.Errorf("Wrong statsRetryCounter: got %v, wanted %v", got, wantRetryCount)
 	}
 	wantRetryReadOnlyCount := int64(2)
-	if got := statsRetryCounters.Counts()[retryCategoryReadOnly]; got != wantRetryReadOnlyCount {
+	if got := statsRetryCounters.Counts()[defaultJobName+"."+retryCategoryReadOnly]; got != wantRetryReadOnlyCount {
 		t.Errorf("Wrong statsRetryCounters: got %v, wanted %v", got, wantRetryReadOnlyCount)
 	}
 }
@@ -963,7 +963,7 @@ func TestSplitCloneV2_RetryDueToReparent(t *testing.T) {
 	}
 
 	wantRetryCountMin := int64(1)
-	if got := statsRetryCount.Get(); got < wantRetryCountMin {
+	if got := statsRetryCount.Counts()[defaultJobName]; got < wantRetryCountMin {
 		t.Errorf("Wrong statsRetryCounter: got %v, wanted >= %v", got, wantRetryCountMin)
 	}
 }
@@ -1004,7 +1004,7 @@ func TestSplitCloneV2_NoMasterAvailable(t *testing.T) {
 		defer cancel()
 
 		for {
-			if statsRetryCounters.Counts()[retryCategoryNoMasterAvailable] >= 1 {
+			if statsRetryCounters.Counts()[defaultJobName+"."+retryCategoryNoMasterAvailable] >= 1 {
 				break
 			}
 
@@ -1043,16 +1043,16 @@ func TestSplitCloneV3(t *testing.T) {
 
 func verifyOnlineCounters(inserts, updates, deletes, equal int64) error {
 	rec := concurrency.AllErrorRecorder{}
-	if got, want := statsOnlineInsertsCounters.Counts()["table1"], inserts; got != want {
+	if got, want := statsOnlineInsertsCounters.Counts()["default.table1"], inserts; got != want {
 		rec.RecordError(fmt.Errorf("wrong online INSERTs count: got = %v, want = %v", got, want))
 	}
-	if got, want := statsOnlineUpdatesCounters.Counts()["table1"], updates; got != want {
+	if got, want := statsOnlineUpdatesCounters.Counts()["default.table1"], updates; got != want {
 		rec.RecordError(fmt.Errorf("wrong online UPDATEs count: got = %v, want = %v", got, want))
 	}
-	if got, want := statsOnlineDeletesCounters.Counts()["table1"], deletes; got != want {
+	if got, want := statsOnlineDeletesCounters.Counts()["default.table1"], deletes; got != want {
 		rec.RecordError(fmt.Errorf("wrong online DELETEs count: got = %v, want = %v", got, want))
 	}
-	if got, want := statsOnlineEqualRowsCounters.Counts()["table1"], equal; got != want {
+	if got, want := statsOnlineEqualRowsCounters.Counts()["default.table1"], equal; got != want {
 		rec.RecordError(fmt.Errorf("wrong online equal rows count: got = %v, want = %v", got, want))
 	}
 	return rec.Error()
@@ -1060,16 +1060,16 @@ func verifyOnlineCounters(inserts, updates, deletes, equal int64) error {
 
 func verifyOfflineCounters(inserts, updates, deletes, equal int64) error {
 	rec := concurrency.AllErrorRecorder{}
-	if got, want := statsOfflineInsertsCounters.Counts()["table1"], inserts; got != want {
+	if got, want := statsOfflineInsertsCounters.Counts()["default.table1"], inserts; got != want {
 		rec.RecordError(fmt.Errorf("wrong offline INSERTs count: got = %v, want = %v", got, want))
 	}
-	if got, want := statsOfflineUpdatesCounters.Counts()["table1"], updates; got != want {
+	if got, want := statsOfflineUpdatesCounters.Counts()["default.table1"], updates; got != want {
 		rec.RecordError(fmt.Errorf("wrong offline UPDATEs count: got = %v, want = %v", got, want))
 	}
-	if got, want := statsOfflineDeletesCounters.Counts()["table1"], deletes; got != want {
+	if got, want := statsOfflineDeletesCounters.Counts()["default.table1"], deletes; got != want {
 		rec.RecordError(fmt.Errorf("wrong offline DELETEs count: got = %v, want = %v", got, want))
 	}
-	if got, want := statsOfflineEqualRowsCounters.Counts()["table1"], equal; got != want {
+	if got, want := statsOfflineEqualRowsCounters.Counts()["default.table1"], equal; got != want {
 		rec.RecordError(fmt.Errorf("wrong offline equal rows count: got = %v, want = %v", got, want))
 	}
 	return rec.Error()
@@ -35,6 +35,8 @@ type SplitDiffWorker struct {
 	shard                   string
 	sourceUID               uint32
 	excludeTables           []string
+	chunkCount              int
+	minRowsPerChunk         int
 	minHealthyRdonlyTablets int
 	cleaner                 *wrangler.Cleaner
 
@@ -52,7 +54,7 @@ type SplitDiffWorker struct {
 }
 
 // NewSplitDiffWorker returns a new SplitDiffWorker object.
-func NewSplitDiffWorker(wr *wrangler.Wrangler, cell, keyspace, shard string, sourceUID uint32, excludeTables []string, minHealthyRdonlyTablets int) Worker {
+func NewSplitDiffWorker(wr *wrangler.Wrangler, cell, keyspace, shard string, sourceUID uint32, excludeTables []string, chunkCount, minRowsPerChunk, minHealthyRdonlyTablets int) Worker {
 	return &SplitDiffWorker{
 		StatusWorker:            NewStatusWorker(),
 		wr:                      wr,
@@ -61,6 +63,8 @@ func NewSplitDiffWorker(wr *wrangler.Wrangler, cell, keyspace, shard string, sou
 		shard:                   shard,
 		sourceUID:               sourceUID,
 		excludeTables:           excludeTables,
+		chunkCount:              chunkCount,
+		minRowsPerChunk:         minRowsPerChunk,
 		minHealthyRdonlyTablets: minHealthyRdonlyTablets,
 		cleaner:                 &wrangler.Cleaner{},
 	}
@@ -99,7 +103,7 @@ func (sdw *SplitDiffWorker) StatusAsText() string {
 
 // Run is mostly a wrapper to run the cleanup at the end.
 func (sdw *SplitDiffWorker) Run(ctx context.Context) error {
-	resetVars()
+	resetVars(ctx)
 	err := sdw.run(ctx)
 
 	sdw.SetState(WorkerStateCleanUp)
@@ -412,6 +416,14 @@ func (sdw *SplitDiffWorker) diff(ctx context.Context) error {
 		return fmt.Errorf("Source shard doesn't overlap with destination: %v", err)
 	}
 
+	// The chunks of each table are generated on the source tablet.
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	sourceTablet, err := sdw.wr.TopoServer().GetTablet(shortCtx, sdw.sourceAlias)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot read source tablet %v: %v", topoproto.TabletAliasString(sdw.sourceAlias), err)
+	}
+
 	// run the diffs, 8 at a time
 	sdw.wr.Logger().Infof("Running the diffs...")
 	// TODO(mberlin): Parameterize the hard coded value 8.
@@ -421,65 +433,29 @@ func (sdw *SplitDiffWorker) diff(ctx context.Context) error {
 		go func(tableDefinition *tabletmanagerdatapb.TableDefinition) {
 			defer wg.Done()
 			sem.Acquire()
-			defer sem.Release()
-
-			sdw.wr.Logger().Infof("Starting the diff on table %v", tableDefinition.Name)
-
-			// On the source, see if we need a full scan
-			// or a filtered scan.
-			var sourceQueryResultReader *QueryResultReader
-			if key.KeyRangeEqual(overlap, sdw.shardInfo.SourceShards[sdw.sourceUID].KeyRange) {
-				sourceQueryResultReader, err = TableScan(ctx, sdw.wr.Logger(), sdw.wr.TopoServer(), sdw.sourceAlias, tableDefinition)
-			} else {
-				sourceQueryResultReader, err = TableScanByKeyRange(ctx, sdw.wr.Logger(), sdw.wr.TopoServer(), sdw.sourceAlias, tableDefinition, overlap, keyspaceSchema, sdw.keyspaceInfo.ShardingColumnName, sdw.keyspaceInfo.ShardingColumnType)
-			}
+			chunks, err := generateChunks(ctx, sdw.wr, sourceTablet.Tablet, tableDefinition, sdw.chunkCount, sdw.minRowsPerChunk)
+			sem.Release()
 			if err != nil {
-				newErr := fmt.Errorf("TableScan(ByKeyRange?)(source) failed: %v", err)
+				newErr := fmt.Errorf("cannot split table %v into chunks: %v", tableDefinition.Name, err)
 				rec.RecordError(newErr)
 				sdw.wr.Logger().Errorf("%v", newErr)
 				return
 			}
-			defer sourceQueryResultReader.Close(ctx)
-
-			// On the destination, see if we need a full scan
-			// or a filtered scan.
-			var destinationQueryResultReader *QueryResultReader
-			if key.KeyRangeEqual(overlap, sdw.shardInfo.KeyRange) {
-				destinationQueryResultReader, err = TableScan(ctx, sdw.wr.Logger(), sdw.wr.TopoServer(), sdw.destinationAlias, tableDefinition)
-			} else {
-				destinationQueryResultReader, err = TableScanByKeyRange(ctx, sdw.wr.Logger(), sdw.wr.TopoServer(), sdw.destinationAlias, tableDefinition, overlap, keyspaceSchema, sdw.keyspaceInfo.ShardingColumnName, sdw.keyspaceInfo.ShardingColumnType)
-			}
-			if err != nil {
-				newErr := fmt.Errorf("TableScan(ByKeyRange?)(destination) failed: %v", err)
-				rec.RecordError(newErr)
-				sdw.wr.Logger().Errorf("%v", newErr)
-				return
-			}
-			defer destinationQueryResultReader.Close(ctx)
 
-			// Create the row differ.
-			differ, err := NewRowDiffer(sourceQueryResultReader, destinationQueryResultReader, tableDefinition)
-			if err != nil {
-				newErr := fmt.Errorf("NewRowDiffer() failed: %v", err)
-				rec.RecordError(newErr)
-				sdw.wr.Logger().Errorf("%v", newErr)
-				return
-			}
-
-			// And run the diff.
-			report, err := differ.Go(sdw.wr.Logger())
-			if err != nil {
-				newErr := fmt.Errorf("Differ.Go failed: %v", err.Error())
-				rec.RecordError(newErr)
-				sdw.wr.Logger().Errorf("%v", newErr)
-			} else {
-				if report.HasDifferences() {
-					err := fmt.Errorf("Table %v has differences: %v", tableDefinition.Name, report.String())
-					rec.RecordError(err)
-					sdw.wr.Logger().Warningf(err.Error())
-				} else {
-					sdw.wr.Logger().Infof("Table %v checks out (%v rows processed, %v qps)", tableDefinition.Name, report.processedRows, report.processingQPS)
-				}
+			for _, c := range chunks {
+				wg.Add(1)
+				go func(c chunk) {
+					defer wg.Done()
+					sem.Acquire()
+					defer sem.Release()
+					if err := acquireSourceReader(ctx); err != nil {
+						rec.RecordError(err)
+						return
+					}
+					defer releaseSourceReader(ctx)
+
+					sdw.diffChunk(ctx, tableDefinition, c, overlap, keyspaceSchema, rec)
+				}(c)
 			}
 		}(tableDefinition)
 	}
@@ -487,3 +463,71 @@ func (sdw *SplitDiffWorker) diff(ctx context.Context) error {
 
 	return rec.Error()
 }
+
+// diffChunk diffs the rows of a chunk of a table, and records the differences
+// and errors in "rec".
+func (sdw *SplitDiffWorker) diffChunk(ctx context.Context, tableDefinition *tabletmanagerdatapb.TableDefinition, c chunk, overlap *topodatapb.KeyRange, keyspaceSchema *vindexes.KeyspaceSchema, rec concurrency.ErrorRecorder) {
+	name := tableDefinition.Name
+	if c.total > 1 {
+		name = fmt.Sprintf("%v (chunk %v)", tableDefinition.Name, c)
+	}
+	sdw.wr.Logger().Infof("Starting the diff on table %v", name)
+
+	// On the source, see if we need a full scan
+	// or a filtered scan.
+	var sourceQueryResultReader *QueryResultReader
+	var err error
+	if key.KeyRangeEqual(overlap, sdw.shardInfo.SourceShards[sdw.sourceUID].KeyRange) {
+		sourceQueryResultReader, err = tableScanChunk(ctx, sdw.wr.Logger(), sdw.wr.TopoServer(), sdw.sourceAlias, tableDefinition, c)
+	} else {
+		sourceQueryResultReader, err = tableScanByKeyRangeChunk(ctx, sdw.wr.Logger(), sdw.wr.TopoServer(), sdw.sourceAlias, tableDefinition, c, overlap, keyspaceSchema, sdw.keyspaceInfo.ShardingColumnName, sdw.keyspaceInfo.ShardingColumnType)
+	}
+	if err != nil {
+		newErr := fmt.Errorf("TableScan(ByKeyRange?)(source) failed: %v", err)
+		rec.RecordError(newErr)
+		sdw.wr.Logger().Errorf("%v", newErr)
+		return
+	}
+	defer sourceQueryResultReader.Close(ctx)
+
+	// On the destination, see if we need a full scan
+	// or a filtered scan.
+	var destinationQueryResultReader *QueryResultReader
+	if key.KeyRangeEqual(overlap, sdw.shardInfo.KeyRange) {
+		destinationQueryResultReader, err = tableScanChunk(ctx, sdw.wr.Logger(), sdw.wr.TopoServer(), sdw.destinationAlias, tableDefinition, c)
+	} else {
+		destinationQueryResultReader, err = tableScanByKeyRangeChunk(ctx, sdw.wr.Logger(), sdw.wr.TopoServer(), sdw.destinationAlias, tableDefinition, c, overlap, keyspaceSchema, sdw.keyspaceInfo.ShardingColumnName, sdw.keyspaceInfo.ShardingColumnType)
+	}
+	if err != nil {
+		newErr := fmt.Errorf("TableScan(ByKeyRange?)(destination) failed: %v", err)
+		rec.RecordError(newErr)
+		sdw.wr.Logger().Errorf("%v", newErr)
+		return
+	}
+	defer destinationQueryResultReader.Close(ctx)
+
+	// Create the row differ.
+	differ, err := NewRowDiffer(sourceQueryResultReader, destinationQueryResultReader, tableDefinition)
+	if err != nil {
+		newErr := fmt.Errorf("NewRowDiffer() failed: %v", err)
+		rec.RecordError(newErr)
+		sdw.wr.Logger().Errorf("%v", newErr)
+		return
+	}
+
+	// And run the diff.
+	report, err := differ.Go(sdw.wr.Logger())
+	if err != nil {
+		newErr := fmt.Errorf("Differ.Go failed: %v", err.Error())
+		rec.RecordError(newErr)
+		sdw.wr.Logger().Errorf("%v", newErr)
+	} else {
+		if report.HasDifferences() {
+			err := fmt.Errorf("Table %v has differences: %v", name, report.String())
+			rec.RecordError(err)
+			sdw.wr.Logger().Warningf(err.Error())
+		} else {
+			sdw.wr.Logger().Infof("Table %v checks out (%v rows processed, %v qps)", name, report.processedRows, report.processingQPS)
+		}
+	}
+}
@@ -50,10 +50,16 @@ const splitDiffHTML2 = `
         <INPUT type="text" id="sourceUID" name="sourceUID" value="{{.DefaultSourceUID}}"></BR>
       <LABEL for="excludeTables">Exclude Tables: </LABEL>
         <INPUT type="text" id="excludeTables" name="excludeTables" value=""></BR>
+      <LABEL for="chunkCount">Chunk Count: </LABEL>
+        <INPUT type="text" id="chunkCount" name="chunkCount" value="{{.DefaultChunkCount}}"></BR>
+      <LABEL for="minRowsPerChunk">Minimun Number of Rows per Chunk (may reduce the Chunk Count): </LABEL>
+        <INPUT type="text" id="minRowsPerChunk" name="minRowsPerChunk" value="{{.DefaultMinRowsPerChunk}}"></BR>
       <LABEL for="minHealthyRdonlyTablets">Minimum Number of required healthy RDONLY tablets: </LABEL>
         <INPUT type="text" id="minHealthyRdonlyTablets" name="minHealthyRdonlyTablets" value="{{.DefaultMinHealthyRdonlyTablets}}"></BR>
       <INPUT type="hidden" name="keyspace" value="{{.Keyspace}}"/>
       <INPUT type="hidden" name="shard" value="{{.Shard}}"/>
+      <LABEL for="job">Job Name (empty for the default job): </LABEL>
+        <INPUT type="text" id="job" name="job" value=""></BR>
       <INPUT type="submit" name="submit" value="Split Diff"/>
     </form>
   </body>
@@ -65,6 +71,8 @@ var splitDiffTemplate2 = mustParseTemplate("splitDiff2", splitDiffHTML2)
 func commandSplitDiff(wi *Instance, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) (Worker, error) {
 	sourceUID := subFlags.Int("source_uid", 0, "uid of the source shard to run the diff against")
 	excludeTables := subFlags.String("exclude_tables", "", "comma separated list of tables to exclude")
+	chunkCount := subFlags.Int("chunk_count", defaultChunkCount, "number of chunks per table, which are diffed in parallel")
+	minRowsPerChunk := subFlags.Int("min_rows_per_chunk", defaultMinRowsPerChunk, "minimum number of rows per chunk (may reduce --chunk_count)")
 	minHealthyRdonlyTablets := subFlags.Int("min_healthy_rdonly_tablets", defaultMinHealthyRdonlyTablets, "minimum number of healthy RDONLY tablets before taking out one")
 	if err := subFlags.Parse(args); err != nil {
 		return nil, err
@@ -81,7 +89,13 @@ func commandSplitDiff(wi *Instance, wr *wrangler.Wrangler, subFlags *flag.FlagSe
 	if *excludeTables != "" {
 		excludeTableArray = strings.Split(*excludeTables, ",")
 	}
-	return NewSplitDiffWorker(wr, wi.cell, keyspace, shard, uint32(*sourceUID), excludeTableArray, *minHealthyRdonlyTablets), nil
+	if *chunkCount <= 0 {
+		return nil, fmt.Errorf("chunk_count must be > 0: %v", *chunkCount)
+	}
+	if *minRowsPerChunk <= 0 {
+		return nil, fmt.Errorf("min_rows_per_chunk must be > 0: %v", *minRowsPerChunk)
+	}
+	return NewSplitDiffWorker(wr, wi.cell, keyspace, shard, uint32(*sourceUID), excludeTableArray, *chunkCount, *minRowsPerChunk, *minHealthyRdonlyTablets), nil
 }
 
 // shardsWithSources returns all the shards that have SourceShards set
@@ -170,6 +184,8 @@ func interactiveSplitDiff(ctx context.Context, wi *Instance, wr *wrangler.Wrangl
 		result["Keyspace"] = keyspace
 		result["Shard"] = shard
 		result["DefaultSourceUID"] = "0"
+		result["DefaultChunkCount"] = fmt.Sprintf("%v", defaultChunkCount)
+		result["DefaultMinRowsPerChunk"] = fmt.Sprintf("%v", defaultMinRowsPerChunk)
 		result["DefaultMinHealthyRdonlyTablets"] = fmt.Sprintf("%v", defaultMinHealthyRdonlyTablets)
 		return nil, splitDiffTemplate2, result, nil
 	}
@@ -185,6 +201,16 @@ func interactiveSplitDiff(ctx context.Context, wi *Instance, wr *wrangler.Wrangl
 	if excludeTables != "" {
 		excludeTableArray = strings.Split(excludeTables, ",")
 	}
+	chunkCountStr := r.FormValue("chunkCount")
+	chunkCount, err := strconv.ParseInt(chunkCountStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse chunkCount: %s", err)
+	}
+	minRowsPerChunkStr := r.FormValue("minRowsPerChunk")
+	minRowsPerChunk, err := strconv.ParseInt(minRowsPerChunkStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse minRowsPerChunk: %s", err)
+	}
 	minHealthyRdonlyTabletsStr := r.FormValue("minHealthyRdonlyTablets")
 	minHealthyRdonlyTablets, err := strconv.ParseInt(minHealthyRdonlyTabletsStr, 0, 64)
 	if err != nil {
@@ -192,7 +218,7 @@ func interactiveSplitDiff(ctx context.Context, wi *Instance, wr *wrangler.Wrangl
 	}
 
 	// start the diff job
-	wrk := NewSplitDiffWorker(wr, wi.cell, keyspace, shard, uint32(sourceUID), excludeTableArray, int(minHealthyRdonlyTablets))
+	wrk := NewSplitDiffWorker(wr, wi.cell, keyspace, shard, uint32(sourceUID), excludeTableArray, int(chunkCount), int(minRowsPerChunk), int(minHealthyRdonlyTablets))
 	return wrk, nil, nil, nil
 }
 
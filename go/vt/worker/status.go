@@ -36,29 +36,33 @@ const workerStatusHTML = `
 <title>Worker Status</title>
 </head>
 <body>
-{{if .Status}}
-  <h2>Worker status:</h2>
+{{range .Jobs}}
+  <h2>Worker status (job: {{.Name}}):</h2>
   <blockquote>
     {{.Status}}
   </blockquote>
-  <h2>Worker logs:</h2>
+  <h2>Worker logs (job: {{.Name}}):</h2>
   <blockquote>
     {{.Logs}}
   </blockquote>
   {{if .Done}}
-  <p><a href="/reset">Reset Job</a></p>
+  <p><a href="/reset?job={{.Name}}">Reset Job</a></p>
   {{else}}
-  <p><a href="/cancel">Cancel Job</a></p>
+  <p><a href="/cancel?job={{.Name}}">Cancel Job</a></p>
+  {{if .CutOver}}
+  <p><a href="/cutover?job={{.Name}}">Cut Over</a></p>
+  {{end}}
   {{end}}
 {{else}}
   <p>This worker is idle.</p>
-  <p><a href="/">Toplevel Menu</a></p>
 {{end}}
+<p>Resource budget: {{.Budget}}</p>
+<p><a href="/">Toplevel Menu</a></p>
 </body>
 </html>
 `
 
-// InitStatusHandling installs webserver handlers for global actions like /status, /reset and /cancel.
+// InitStatusHandling installs webserver handlers for global actions like /status, /reset, /cancel and /cutover.
 func (wi *Instance) InitStatusHandling() {
 	// code to serve /status
 	workerTemplate := mustParseTemplate("worker", workerStatusHTML)
@@ -68,18 +72,22 @@ func (wi *Instance) InitStatusHandling() {
 			return
 		}
 
-		wi.currentWorkerMutex.Lock()
-		wrk := wi.currentWorker
-		logger := wi.currentMemoryLogger
-		ctx := wi.currentContext
-		err := wi.lastRunError
-		stopTime := wi.lastRunStopTime
-		wi.currentWorkerMutex.Unlock()
-
-		data := make(map[string]interface{})
-		if wrk != nil {
-			status := template.HTML("Current worker:<br>\n") + wrk.StatusAsHTML()
-			if ctx == nil {
+		var jobs []map[string]interface{}
+		for _, j := range wi.jobList() {
+			wi.mu.Lock()
+			running := j.ctx != nil
+			err := j.err
+			stopTime := j.stopTime
+			wi.mu.Unlock()
+
+			data := map[string]interface{}{
+				"Name": j.name,
+			}
+			if _, ok := j.worker.(CutOverWorker); ok {
+				data["CutOver"] = true
+			}
+			status := template.HTML("Current worker:<br>\n") + j.worker.StatusAsHTML()
+			if !running {
 				data["Done"] = true
 				if err != nil {
 					status += template.HTML(fmt.Sprintf("<br>\nEnded with an error: %v<br>\n", err))
@@ -87,11 +95,12 @@ func (wi *Instance) InitStatusHandling() {
 				status += template.HTML(fmt.Sprintf("<br>\n<b>End Time:</b> %v<br>\n", stopTime))
 			}
 			data["Status"] = status
-			if logger != nil {
-				data["Logs"] = template.HTML(strings.Replace(logger.String(), "\n", "</br>\n", -1))
-			} else {
-				data["Logs"] = template.HTML("See console for logs</br>\n")
-			}
+			data["Logs"] = template.HTML(strings.Replace(j.memoryLogger.String(), "\n", "</br>\n", -1))
+			jobs = append(jobs, data)
+		}
+		data := map[string]interface{}{
+			"Jobs":   jobs,
+			"Budget": wi.budget.String(),
 		}
 		executeTemplate(w, workerTemplate, data)
 	})
@@ -108,7 +117,7 @@ func (wi *Instance) InitStatusHandling() {
 			return
 		}
 
-		if err := wi.Reset(); err != nil {
+		if err := wi.Reset(jobName(r)); err != nil {
 			httpError(w, err.Error(), nil)
 		} else {
 			// No worker currently running, we go to the menu.
@@ -123,7 +132,7 @@ func (wi *Instance) InitStatusHandling() {
 			return
 		}
 
-		if wi.Cancel() {
+		if wi.Cancel(jobName(r)) {
 			// We canceled the running worker. Go back to the status page.
 			http.Redirect(w, r, servenv.StatusURLPath(), http.StatusTemporaryRedirect)
 		} else {
@@ -131,4 +140,28 @@ func (wi *Instance) InitStatusHandling() {
 			http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
 		}
 	})
+
+	// cut-over handler
+	http.HandleFunc("/cutover", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+
+		if err := wi.CutOver(jobName(r)); err != nil {
+			httpError(w, err.Error(), nil)
+		} else {
+			// The worker finishes in the background. Go back to the status page.
+			http.Redirect(w, r, servenv.StatusURLPath(), http.StatusTemporaryRedirect)
+		}
+	})
+}
+
+// jobName returns the job name of the "job" parameter of the request, or the
+// default job name.
+func jobName(r *http.Request) string {
+	if name := r.FormValue("job"); name != "" {
+		return name
+	}
+	return defaultJobName
 }
@@ -34,6 +34,9 @@ const (
 	WorkerStateCloneOnline StatusWorkerState = "cloning the data (online)"
 	// WorkerStateCloneOffline is set when the worker copies the data in the offline phase.
 	WorkerStateCloneOffline StatusWorkerState = "cloning the data (offline)"
+	// WorkerStateFollowBinlog is set when the worker applies the binlog of the
+	// source to the destination until the cut-over.
+	WorkerStateFollowBinlog StatusWorkerState = "following the binlog"
 
 	// WorkerStateDiff is set when the worker compares the data.
 	WorkerStateDiff StatusWorkerState = "running the diff"
@@ -59,6 +62,9 @@ type StatusWorker struct {
 	mu *sync.Mutex
 	// state contains the worker's current state. Guarded by mu.
 	state StatusWorkerState
+	// job is the name of the job which runs the worker, or empty for the
+	// default job. Guarded by mu.
+	job string
 }
 
 // NewStatusWorker returns a StatusWorker in state WorkerStateNotStarted.
@@ -75,7 +81,20 @@ func (w *StatusWorker) SetState(state StatusWorkerState) {
 	defer w.mu.Unlock()
 
 	w.state = state
-	statsState.Set(string(state))
+	job := w.job
+	if job == "" {
+		job = defaultJobName
+	}
+	statsState.Set(job, string(state))
+}
+
+// setJobName sets the name of the job whose debug variables show the state.
+// It is called by the Instance before it runs the worker.
+func (w *StatusWorker) setJobName(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.job = name
 }
 
 // State is part of the Worker interface.
@@ -0,0 +1,821 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/mysqlconn/replication"
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/sync2"
+	"github.com/gitql/vitess/go/vt/concurrency"
+	"github.com/gitql/vitess/go/vt/key"
+	"github.com/gitql/vitess/go/vt/mysqlctl/tmutils"
+	"github.com/gitql/vitess/go/vt/topo"
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+	"github.com/gitql/vitess/go/vt/vtgate/vindexes"
+	"github.com/gitql/vitess/go/vt/wrangler"
+
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+// TableDiffWorker compares a set of tables between the shards of two
+// keyspaces. The keyspaces can have different sharding schemes.
+//
+// The rows of each table are streamed from one RDONLY tablet per shard,
+// from a consistent snapshot of the table. The tablets keep serving and
+// replicating: vttablet only locks the table while it starts the snapshot.
+// If the destination shards replicate from the source keyspace, the
+// snapshots are taken at matching positions: filtered replication is
+// paused while the source snapshots are taken, it then runs until their
+// positions, and the destination tablets take their snapshots once they
+// reached the resulting positions of their masters.
+type TableDiffWorker struct {
+	StatusWorker
+
+	wr                      *wrangler.Wrangler
+	cell                    string
+	sourceKeyspace          string
+	sourceShardNames        []string
+	destinationKeyspace     string
+	destinationShardNames   []string
+	tables                  []string
+	excludeTables           []string
+	parallelDiffsCount      int
+	minHealthyRdonlyTablets int
+	mismatchFile            string
+	cleaner                 *wrangler.Cleaner
+
+	// populated during WorkerStateInit, read-only after that
+	sourceShards            []*topo.ShardInfo
+	destinationShards       []*topo.ShardInfo
+	destinationKeyspaceInfo *topo.KeyspaceInfo
+	// keyspaceSchema is the VSchema of the destination keyspace. It is only
+	// set in the v3 resharding mode.
+	keyspaceSchema *vindexes.KeyspaceSchema
+	// matchPositions is true if the destination shards replicate from the
+	// source keyspace.
+	matchPositions bool
+
+	// populated during WorkerStateFindTargets, read-only after that
+	sourceAliases      []*topodatapb.TabletAlias
+	destinationAliases []*topodatapb.TabletAlias
+	// destinationMasters has the master of each destination shard. It is
+	// only set if matchPositions is true.
+	destinationMasters []*topodatapb.Tablet
+
+	// snapshotMu serializes the snapshots of the tables, so that filtered
+	// replication is paused for one table at a time.
+	snapshotMu sync.Mutex
+
+	// populated during WorkerStateDiff
+	schemaDefinition *tabletmanagerdatapb.SchemaDefinition
+	mismatches       *mismatchReport
+	// diffResultsMu guards diffResults.
+	diffResultsMu sync.Mutex
+	// diffResults has the result of each table.
+	diffResults []string
+}
+
+// newTableDiffWorker returns a new worker object for the TableDiff command.
+// If "sourceShardNames" or "destinationShardNames" are empty, all shards of
+// the keyspace are compared.
+func newTableDiffWorker(wr *wrangler.Wrangler, cell, sourceKeyspace string, sourceShardNames []string, destinationKeyspace string, destinationShardNames []string, tables, excludeTables []string, parallelDiffsCount, minHealthyRdonlyTablets int, mismatchFile string) (Worker, error) {
+	if sourceKeyspace == destinationKeyspace && len(sourceShardNames) == 0 && len(destinationShardNames) == 0 {
+		return nil, fmt.Errorf("source and destination must be different: keyspace %v is compared with itself", sourceKeyspace)
+	}
+	if parallelDiffsCount <= 0 {
+		return nil, fmt.Errorf("parallel_diffs_count must be > 0: %v", parallelDiffsCount)
+	}
+
+	return &TableDiffWorker{
+		StatusWorker:            NewStatusWorker(),
+		wr:                      wr,
+		cell:                    cell,
+		sourceKeyspace:          sourceKeyspace,
+		sourceShardNames:        sourceShardNames,
+		destinationKeyspace:     destinationKeyspace,
+		destinationShardNames:   destinationShardNames,
+		tables:                  tables,
+		excludeTables:           excludeTables,
+		parallelDiffsCount:      parallelDiffsCount,
+		minHealthyRdonlyTablets: minHealthyRdonlyTablets,
+		mismatchFile:            mismatchFile,
+		cleaner:                 &wrangler.Cleaner{},
+	}, nil
+}
+
+// StatusAsHTML is part of the Worker interface.
+func (w *TableDiffWorker) StatusAsHTML() template.HTML {
+	return template.HTML(strings.Replace(w.status(), "\n", "</br>\n", -1))
+}
+
+// StatusAsText is part of the Worker interface.
+func (w *TableDiffWorker) StatusAsText() string {
+	return w.status()
+}
+
+func (w *TableDiffWorker) status() string {
+	state := w.State()
+
+	result := "Working on: " + w.sourceKeyspace + " vs. " + w.destinationKeyspace + "\n"
+	result += "State: " + state.String() + "\n"
+	switch state {
+	case WorkerStateDiff:
+		result += "Running...\n"
+		result += w.formatDiffResults()
+	case WorkerStateDone:
+		result += "Success.\n"
+		result += w.formatDiffResults()
+	}
+	return result
+}
+
+func (w *TableDiffWorker) formatDiffResults() string {
+	w.diffResultsMu.Lock()
+	defer w.diffResultsMu.Unlock()
+
+	if len(w.diffResults) == 0 {
+		return ""
+	}
+	return strings.Join(w.diffResults, "\n") + "\n"
+}
+
+// Run is mostly a wrapper to run the cleanup at the end.
+func (w *TableDiffWorker) Run(ctx context.Context) error {
+	resetVars(ctx)
+	err := w.run(ctx)
+
+	w.SetState(WorkerStateCleanUp)
+	cerr := w.cleaner.CleanUp(w.wr)
+	if cerr != nil {
+		if err != nil {
+			w.wr.Logger().Errorf("CleanUp failed in addition to job error: %v", cerr)
+		} else {
+			err = cerr
+		}
+	}
+	if err != nil {
+		w.SetState(WorkerStateError)
+		return err
+	}
+	w.SetState(WorkerStateDone)
+	return nil
+}
+
+func (w *TableDiffWorker) run(ctx context.Context) error {
+	// first state: read what we need to do
+	if err := w.init(ctx); err != nil {
+		return fmt.Errorf("init() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// second state: find targets
+	if err := w.findTargets(ctx); err != nil {
+		return fmt.Errorf("findTargets() failed: %v", err)
+	}
+	if err := checkDone(ctx); err != nil {
+		return err
+	}
+
+	// third phase: diff
+	if err := w.diff(ctx); err != nil {
+		return fmt.Errorf("diff() failed: %v", err)
+	}
+	return checkDone(ctx)
+}
+
+// init phase:
+// - read the shards of both keyspaces
+// - read the sharding scheme of the destination keyspace
+func (w *TableDiffWorker) init(ctx context.Context) error {
+	w.SetState(WorkerStateInit)
+
+	var err error
+	w.sourceShards, err = w.findShards(ctx, w.sourceKeyspace, w.sourceShardNames)
+	if err != nil {
+		return err
+	}
+	w.destinationShards, err = w.findShards(ctx, w.destinationKeyspace, w.destinationShardNames)
+	if err != nil {
+		return err
+	}
+
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	w.destinationKeyspaceInfo, err = w.wr.TopoServer().GetKeyspace(shortCtx, w.destinationKeyspace)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot read destination keyspace %v: %v", w.destinationKeyspace, err)
+	}
+	if *useV3ReshardingMode {
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		kschema, err := w.wr.TopoServer().GetVSchema(shortCtx, w.destinationKeyspace)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("cannot load VSchema for keyspace %v: %v", w.destinationKeyspace, err)
+		}
+		if kschema == nil {
+			return fmt.Errorf("no VSchema for keyspace %v", w.destinationKeyspace)
+		}
+		w.keyspaceSchema, err = vindexes.BuildKeyspaceSchema(kschema, w.destinationKeyspace)
+		if err != nil {
+			return fmt.Errorf("cannot build vschema for keyspace %v: %v", w.destinationKeyspace, err)
+		}
+	}
+
+	// The positions can only be matched if the destination shards replicate
+	// from exactly the compared source shards.
+	sourceShardNames := make(map[string]bool)
+	for _, si := range w.sourceShards {
+		sourceShardNames[si.ShardName()] = true
+	}
+	referencedShardNames := make(map[string]bool)
+	w.matchPositions = true
+	for _, si := range w.destinationShards {
+		if len(si.SourceShards) == 0 {
+			w.matchPositions = false
+		}
+		for _, ss := range si.SourceShards {
+			if ss.Keyspace != w.sourceKeyspace || !sourceShardNames[ss.Shard] {
+				w.matchPositions = false
+			}
+			referencedShardNames[ss.Shard] = true
+		}
+	}
+	if len(referencedShardNames) != len(sourceShardNames) {
+		w.matchPositions = false
+	}
+	if !w.matchPositions {
+		w.wr.Logger().Warningf("The destination shards do not replicate from the source shards. The snapshots cannot be taken at matching positions and rows which are modified meanwhile may be reported as differences.")
+	}
+	return nil
+}
+
+// findShards returns the shards "shardNames" of "keyspace", or all shards
+// if "shardNames" is empty. The shards are sorted by name.
+func (w *TableDiffWorker) findShards(ctx context.Context, keyspace string, shardNames []string) ([]*topo.ShardInfo, error) {
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	shardMap, err := w.wr.TopoServer().FindAllShardsInKeyspace(shortCtx, keyspace)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the shards of keyspace %v: %v", keyspace, err)
+	}
+	if len(shardNames) == 0 {
+		for shard := range shardMap {
+			shardNames = append(shardNames, shard)
+		}
+		sort.Strings(shardNames)
+	}
+	if len(shardNames) == 0 {
+		return nil, fmt.Errorf("keyspace %v has no shards", keyspace)
+	}
+
+	var shards []*topo.ShardInfo
+	for _, shard := range shardNames {
+		si, ok := shardMap[shard]
+		if !ok {
+			return nil, fmt.Errorf("keyspace %v has no shard %v", keyspace, shard)
+		}
+		shards = append(shards, si)
+	}
+	return shards, nil
+}
+
+// findTargets phase:
+// - find one healthy rdonly tablet in each source and destination shard
+// - read the destination masters if the positions are matched
+// The tablets are not taken out of serving.
+func (w *TableDiffWorker) findTargets(ctx context.Context) error {
+	w.SetState(WorkerStateFindTargets)
+
+	var err error
+	w.sourceAliases, err = w.findRdonlyTablets(ctx, w.sourceShards)
+	if err != nil {
+		return err
+	}
+	w.destinationAliases, err = w.findRdonlyTablets(ctx, w.destinationShards)
+	if err != nil {
+		return err
+	}
+
+	if !w.matchPositions {
+		return nil
+	}
+	for _, si := range w.destinationShards {
+		if si.MasterAlias == nil {
+			return fmt.Errorf("destination shard %v/%v has no master", si.Keyspace(), si.ShardName())
+		}
+		master, err := w.getTablet(ctx, si.MasterAlias)
+		if err != nil {
+			return err
+		}
+		w.destinationMasters = append(w.destinationMasters, master)
+	}
+	return nil
+}
+
+func (w *TableDiffWorker) findRdonlyTablets(ctx context.Context, shards []*topo.ShardInfo) ([]*topodatapb.TabletAlias, error) {
+	var aliases []*topodatapb.TabletAlias
+	for _, si := range shards {
+		alias, err := FindHealthyRdonlyTablet(ctx, w.wr, nil /* tsc */, w.cell, si.Keyspace(), si.ShardName(), w.minHealthyRdonlyTablets)
+		if err != nil {
+			return nil, fmt.Errorf("FindHealthyRdonlyTablet() failed for %v/%v/%v: %v", w.cell, si.Keyspace(), si.ShardName(), err)
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, nil
+}
+
+func (w *TableDiffWorker) getTablet(ctx context.Context, alias *topodatapb.TabletAlias) (*topodatapb.Tablet, error) {
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	ti, err := w.wr.TopoServer().GetTablet(shortCtx, alias)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read tablet %v: %v", topoproto.TabletAliasString(alias), err)
+	}
+	return ti.Tablet, nil
+}
+
+// diff phase:
+// - read the schema of the tables from the first destination tablet
+// - for each table, compare the rows of all source and destination shards
+// - check that each destination row is in the right shard
+func (w *TableDiffWorker) diff(ctx context.Context) error {
+	w.SetState(WorkerStateDiff)
+	start := time.Now()
+	defer func() {
+		statsStateDurationsNs.Set([]string{jobNameFromContext(ctx), string(WorkerStateDiff)}, time.Now().Sub(start).Nanoseconds())
+	}()
+
+	var err error
+	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+	w.schemaDefinition, err = w.wr.GetSchema(shortCtx, w.destinationAliases[0], w.tables, w.excludeTables, false /* includeViews */)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("cannot get schema from destination %v: %v", topoproto.TabletAliasString(w.destinationAliases[0]), err)
+	}
+	if len(w.schemaDefinition.TableDefinitions) == 0 {
+		return fmt.Errorf("no tables matching the table filter in destination keyspace %v", w.destinationKeyspace)
+	}
+
+	if w.mismatchFile != "" {
+		w.mismatches, err = newMismatchReport(w.mismatchFile)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := w.mismatches.close(); err != nil {
+				w.wr.Logger().Errorf("cannot write mismatch file %v: %v", w.mismatchFile, err)
+			}
+		}()
+	}
+
+	w.wr.Logger().Infof("Running the diffs...")
+	rec := &concurrency.AllErrorRecorder{}
+	wg := sync.WaitGroup{}
+	sem := sync2.NewSemaphore(w.parallelDiffsCount, 0)
+	for _, td := range w.schemaDefinition.TableDefinitions {
+		if len(td.PrimaryKeyColumns) == 0 {
+			newErr := fmt.Errorf("table %v has no primary key and cannot be diffed", td.Name)
+			rec.RecordError(newErr)
+			w.wr.Logger().Errorf("%v", newErr)
+			continue
+		}
+		td = reorderColumnsPrimaryKeyFirst(td)
+
+		wg.Add(1)
+		go func(td *tabletmanagerdatapb.TableDefinition) {
+			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			if err := checkDone(ctx); err != nil {
+				rec.RecordError(err)
+				return
+			}
+			if err := acquireSourceReader(ctx); err != nil {
+				rec.RecordError(err)
+				return
+			}
+			defer releaseSourceReader(ctx)
+
+			if err := w.diffTable(ctx, td); err != nil {
+				rec.RecordError(err)
+				w.wr.Logger().Errorf("%v", err)
+			}
+		}(td)
+	}
+	wg.Wait()
+
+	if w.mismatches != nil {
+		w.wr.Logger().Infof("Wrote %v mismatched primary keys to %v", w.mismatches.count(), w.mismatchFile)
+	}
+	return rec.Error()
+}
+
+// diffTable compares the rows of the table "td" and returns an error if
+// they differ.
+func (w *TableDiffWorker) diffTable(ctx context.Context, td *tabletmanagerdatapb.TableDefinition) error {
+	w.wr.Logger().Infof("Starting the diff on table %v", td.Name)
+
+	sourceScans, destinationScans, err := w.openSnapshots(ctx, td)
+	if err != nil {
+		return fmt.Errorf("cannot read snapshots of table %v: %v", td.Name, err)
+	}
+	defer closeScans(ctx, sourceScans)
+	defer closeScans(ctx, destinationScans)
+
+	sourceReader, err := newShardsResultReader(sourceScans, td, nil /* wrap */)
+	if err != nil {
+		return fmt.Errorf("cannot read table %v from the source: %v", td.Name, err)
+	}
+
+	// Check the shard of each destination row if the keyspace is sharded.
+	var wrap func(si *topo.ShardInfo, r ResultReader) ResultReader
+	if w.destinationShardsArePartial() {
+		keyResolver, err := w.keyResolver(td)
+		if err != nil {
+			return fmt.Errorf("cannot resolve sharding keys for table %v: %v", td.Name, err)
+		}
+		wrap = func(si *topo.ShardInfo, r ResultReader) ResultReader {
+			return &keyRangeCheckReader{
+				ResultReader: r,
+				keyResolver:  keyResolver,
+				shardInfo:    si,
+				onMismatch: func(row []sqltypes.Value) {
+					w.mismatches.record(td, mismatchWrongShard, si.ShardName(), row)
+				},
+			}
+		}
+	}
+	destinationReader, err := newShardsResultReader(destinationScans, td, func(i int, r ResultReader) ResultReader {
+		if wrap == nil {
+			return r
+		}
+		return wrap(w.destinationShards[i], r)
+	})
+	if err != nil {
+		return fmt.Errorf("cannot read table %v from the destination: %v", td.Name, err)
+	}
+
+	differ, err := NewRowDiffer(sourceReader, destinationReader, td)
+	if err != nil {
+		return fmt.Errorf("NewRowDiffer() failed for table %v: %v", td.Name, err)
+	}
+	differ.onMismatch = func(kind string, row []sqltypes.Value) {
+		w.mismatches.record(td, mismatchKinds[kind], "", row)
+	}
+	report, err := differ.Go(w.wr.Logger())
+	if err != nil {
+		return fmt.Errorf("Differ.Go failed for table %v: %v", td.Name, err)
+	}
+
+	var result string
+	var wrongShardErr error
+	for _, r := range destinationReader.readers {
+		if kr, ok := r.(*keyRangeCheckReader); ok && kr.wrongShardRows > 0 {
+			wrongShardErr = fmt.Errorf("Table %v has %v rows in the wrong destination shard %v", td.Name, kr.wrongShardRows, kr.shardInfo.ShardName())
+			w.wr.Logger().Warningf(wrongShardErr.Error())
+			result += wrongShardErr.Error() + "\n"
+		}
+	}
+	if report.HasDifferences() {
+		err = fmt.Errorf("Table %v has differences: %v", td.Name, report.String())
+		w.wr.Logger().Warningf(err.Error())
+		result += err.Error()
+	} else {
+		result += fmt.Sprintf("Table %v checks out (%v rows processed, %v qps)", td.Name, report.processedRows, report.processingQPS)
+		w.wr.Logger().Infof("Table %v checks out (%v rows processed, %v qps)", td.Name, report.processedRows, report.processingQPS)
+	}
+	w.diffResultsMu.Lock()
+	w.diffResults = append(w.diffResults, result)
+	w.diffResultsMu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return wrongShardErr
+}
+
+// openSnapshots starts the streams of table "td" from a consistent snapshot
+// on each source and destination tablet, and returns them in the order of
+// the shards. If the destination shards replicate from the source shards:
+// 1 - stop filtered replication on all destination masters
+//   (add a cleanup task to restart filtered replication on them)
+// 2 - take the source snapshots once the source tablets reached the
+//   highest position of the destination masters
+// 3 - run filtered replication until the positions of the source snapshots
+// 4 - take the destination snapshots once the destination tablets reached
+//   the positions of their masters
+// 5 - restart filtered replication on the destination masters
+//   (remove the cleanup task that does the same)
+// Otherwise, the snapshots are taken at the current positions.
+func (w *TableDiffWorker) openSnapshots(ctx context.Context, td *tabletmanagerdatapb.TableDefinition) (sourceScans, destinationScans []*QueryResultReader, err error) {
+	defer func() {
+		if err != nil {
+			closeScans(ctx, sourceScans)
+			closeScans(ctx, destinationScans)
+		}
+	}()
+
+	if !w.matchPositions {
+		for _, alias := range w.sourceAliases {
+			scan, err := tableScanSnapshot(ctx, w.wr.Logger(), w.wr.TopoServer(), alias, td, "" /* waitForPosition */)
+			if err != nil {
+				return sourceScans, destinationScans, err
+			}
+			sourceScans = append(sourceScans, scan)
+		}
+		for _, alias := range w.destinationAliases {
+			scan, err := tableScanSnapshot(ctx, w.wr.Logger(), w.wr.TopoServer(), alias, td, "" /* waitForPosition */)
+			if err != nil {
+				return sourceScans, destinationScans, err
+			}
+			destinationScans = append(destinationScans, scan)
+		}
+		return sourceScans, destinationScans, nil
+	}
+
+	w.snapshotMu.Lock()
+	defer w.snapshotMu.Unlock()
+
+	// 1 - stop filtered replication on all destination masters
+	// waitPositions has the highest position of each source shard across
+	// all destination masters.
+	waitPositions := make(map[string]replication.Position)
+	for i, si := range w.destinationShards {
+		master := w.destinationMasters[i]
+		w.wr.Logger().Infof("Stopping master binlog replication on %v", topoproto.TabletAliasString(si.MasterAlias))
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		blpPositionList, err := w.wr.TabletManagerClient().StopBlp(shortCtx, master)
+		cancel()
+		if err != nil {
+			return sourceScans, destinationScans, fmt.Errorf("StopBlp for %v failed: %v", topoproto.TabletAliasString(si.MasterAlias), err)
+		}
+		wrangler.RecordStartBlpAction(w.cleaner, master)
+
+		for _, ss := range si.SourceShards {
+			blpPos := tmutils.FindBlpPositionByID(blpPositionList, ss.Uid)
+			if blpPos == nil {
+				return sourceScans, destinationScans, fmt.Errorf("no binlog position on the master %v for Uid %v", topoproto.TabletAliasString(si.MasterAlias), ss.Uid)
+			}
+			pos, err := replication.DecodePosition(blpPos.Position)
+			if err != nil {
+				return sourceScans, destinationScans, fmt.Errorf("cannot decode the binlog position of master %v for Uid %v: %v", topoproto.TabletAliasString(si.MasterAlias), ss.Uid, err)
+			}
+			waitPos, ok := waitPositions[ss.Shard]
+			switch {
+			case !ok || pos.AtLeast(waitPos):
+				waitPositions[ss.Shard] = pos
+			case !waitPos.AtLeast(pos):
+				return sourceScans, destinationScans, fmt.Errorf("the binlog positions of the destination masters for source shard %v cannot be compared: %v and %v", ss.Shard, waitPos, pos)
+			}
+		}
+	}
+
+	// 2 - take the source snapshots once the source tablets reached the
+	//     highest position of the destination masters
+	snapshotPositions := make(map[string]string)
+	for i, si := range w.sourceShards {
+		waitPos := replication.EncodePosition(waitPositions[si.ShardName()])
+		scan, err := tableScanSnapshot(ctx, w.wr.Logger(), w.wr.TopoServer(), w.sourceAliases[i], td, waitPos)
+		if err != nil {
+			return sourceScans, destinationScans, err
+		}
+		sourceScans = append(sourceScans, scan)
+		snapshotPositions[si.ShardName()] = scan.position
+	}
+
+	for i, si := range w.destinationShards {
+		// 3 - run filtered replication until the positions of the source
+		//     snapshots
+		var positionList []*tabletmanagerdatapb.BlpPosition
+		for _, ss := range si.SourceShards {
+			positionList = append(positionList, &tabletmanagerdatapb.BlpPosition{
+				Uid:      ss.Uid,
+				Position: snapshotPositions[ss.Shard],
+			})
+		}
+		w.wr.Logger().Infof("Restarting master %v until it catches up to %v", topoproto.TabletAliasString(si.MasterAlias), positionList)
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		masterPos, err := w.wr.TabletManagerClient().RunBlpUntil(shortCtx, w.destinationMasters[i], positionList, *remoteActionsTimeout)
+		cancel()
+		if err != nil {
+			return sourceScans, destinationScans, fmt.Errorf("RunBlpUntil for %v until %v failed: %v", topoproto.TabletAliasString(si.MasterAlias), positionList, err)
+		}
+
+		// 4 - take the destination snapshot once the destination tablet
+		//     reached the position of its master
+		scan, err := tableScanSnapshot(ctx, w.wr.Logger(), w.wr.TopoServer(), w.destinationAliases[i], td, masterPos)
+		if err != nil {
+			return sourceScans, destinationScans, err
+		}
+		destinationScans = append(destinationScans, scan)
+	}
+
+	// 5 - restart filtered replication
+	for i, si := range w.destinationShards {
+		w.wr.Logger().Infof("Restarting filtered replication on master %v", topoproto.TabletAliasString(si.MasterAlias))
+		shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
+		err := w.wr.TabletManagerClient().StartBlp(shortCtx, w.destinationMasters[i])
+		cancel()
+		if err != nil {
+			return sourceScans, destinationScans, fmt.Errorf("StartBlp failed for %v: %v", topoproto.TabletAliasString(si.MasterAlias), err)
+		}
+		if err := w.cleaner.RemoveActionByName(wrangler.StartBlpActionName, topoproto.TabletAliasString(si.MasterAlias)); err != nil {
+			w.wr.Logger().Warningf("Cannot find cleaning action %v/%v: %v", wrangler.StartBlpActionName, topoproto.TabletAliasString(si.MasterAlias), err)
+		}
+	}
+	return sourceScans, destinationScans, nil
+}
+
+// closeScans ends the streaming queries "scans".
+func closeScans(ctx context.Context, scans []*QueryResultReader) {
+	for _, scan := range scans {
+		scan.Close(ctx)
+	}
+}
+
+// destinationShardsArePartial returns true if a destination shard does not
+// cover the full key range.
+func (w *TableDiffWorker) destinationShardsArePartial() bool {
+	for _, si := range w.destinationShards {
+		if key.KeyRangeIsPartial(si.KeyRange) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyResolver returns the resolver for the keyspace ids of the destination
+// keyspace.
+func (w *TableDiffWorker) keyResolver(td *tabletmanagerdatapb.TableDefinition) (keyspaceIDResolver, error) {
+	if *useV3ReshardingMode {
+		return newV3ResolverFromTableDefinition(w.keyspaceSchema, td)
+	}
+	return newV2Resolver(w.destinationKeyspaceInfo, td)
+}
+
+// shardsResultReader reads the rows of a table from one tablet of each
+// shard, ordered by the primary key.
+// It implements the ResultReader interface.
+type shardsResultReader struct {
+	ResultReader
+	// readers has the reader of each shard.
+	readers []ResultReader
+}
+
+// newShardsResultReader returns a reader for the rows of "td" streamed by
+// "scans". "wrap" is optional and wraps the reader of each shard.
+// The scans are not closed by the returned reader.
+func newShardsResultReader(scans []*QueryResultReader, td *tabletmanagerdatapb.TableDefinition, wrap func(i int, r ResultReader) ResultReader) (*shardsResultReader, error) {
+	r := &shardsResultReader{}
+	for i, scan := range scans {
+		if wrap != nil {
+			r.readers = append(r.readers, wrap(i, scan))
+		} else {
+			r.readers = append(r.readers, scan)
+		}
+	}
+	if len(r.readers) == 1 {
+		r.ResultReader = r.readers[0]
+		return r, nil
+	}
+	merger, err := NewResultMerger(r.readers, len(td.PrimaryKeyColumns))
+	if err != nil {
+		return nil, fmt.Errorf("NewResultMerger failed: %v", err)
+	}
+	r.ResultReader = merger
+	return r, nil
+}
+
+// Kind of difference for a row which is in the wrong shard.
+const mismatchWrongShard = "wrong_shard"
+
+// mismatchKinds maps the RowDiffer kinds to the kinds in the mismatch file.
+var mismatchKinds = map[string]string{
+	mismatchContent:    "content",
+	mismatchExtraLeft:  "only_in_source",
+	mismatchExtraRight: "only_in_destination",
+}
+
+// keyRangeCheckReader checks that each row of a shard belongs to the key
+// range of the shard. It implements the ResultReader interface.
+type keyRangeCheckReader struct {
+	ResultReader
+	keyResolver keyspaceIDResolver
+	shardInfo   *topo.ShardInfo
+	onMismatch  func(row []sqltypes.Value)
+
+	// wrongShardRows is the number of rows which are not in the key range.
+	wrongShardRows int
+}
+
+// Next returns the next rows and checks their keyspace id. It implements
+// ResultReader.
+func (r *keyRangeCheckReader) Next() (*sqltypes.Result, error) {
+	result, err := r.ResultReader.Next()
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range result.Rows {
+		keyspaceID, err := r.keyResolver.keyspaceID(row)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute the keyspace id of row %v: %v", row, err)
+		}
+		if !key.KeyRangeContains(r.shardInfo.KeyRange, keyspaceID) {
+			r.wrongShardRows++
+			r.onMismatch(row)
+		}
+	}
+	return result, nil
+}
+
+// mismatchReport writes the primary keys of the rows which differ to a file.
+// Each line is a JSON object. A nil *mismatchReport discards the rows.
+type mismatchReport struct {
+	// mu guards all fields.
+	mu       sync.Mutex
+	file     *os.File
+	encoder  *json.Encoder
+	rows     int
+	writeErr error
+}
+
+// mismatchRow is a line of the mismatch file.
+type mismatchRow struct {
+	Table string `json:"table"`
+	// Kind is "content" (the rows differ), "only_in_source",
+	// "only_in_destination" or "wrong_shard" (the row is in the wrong
+	// destination shard).
+	Kind string `json:"kind"`
+	// Shard is the destination shard for "wrong_shard".
+	Shard string `json:"shard,omitempty"`
+	// PrimaryKey maps each primary key column to its value.
+	PrimaryKey map[string]string `json:"primary_key"`
+}
+
+func newMismatchReport(path string) (*mismatchReport, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create mismatch file: %v", err)
+	}
+	return &mismatchReport{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// record writes a line for "row" which must be ordered by
+// reorderColumnsPrimaryKeyFirst(). Write errors are returned by close().
+func (m *mismatchReport) record(td *tabletmanagerdatapb.TableDefinition, kind, shard string, row []sqltypes.Value) {
+	if m == nil {
+		return
+	}
+
+	pk := make(map[string]string)
+	for i, column := range td.PrimaryKeyColumns {
+		pk[column] = row[i].String()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rows++
+	if m.writeErr != nil {
+		return
+	}
+	m.writeErr = m.encoder.Encode(&mismatchRow{
+		Table:      td.Name,
+		Kind:       kind,
+		Shard:      shard,
+		PrimaryKey: pk,
+	})
+}
+
+func (m *mismatchReport) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rows
+}
+
+func (m *mismatchReport) close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.file.Close(); err != nil && m.writeErr == nil {
+		m.writeErr = err
+	}
+	return m.writeErr
+}
@@ -0,0 +1,162 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gitql/vitess/go/vt/topo/topoproto"
+	"github.com/gitql/vitess/go/vt/wrangler"
+	"golang.org/x/net/context"
+)
+
+const tableDiffHTML = `
+<!DOCTYPE html>
+<head>
+  <title>Table Diff Action</title>
+</head>
+<body>
+  <h1>Table Diff Action</h1>
+    <form action="/Diffs/TableDiff" method="post">
+      <LABEL for="source">Source (keyspace or keyspace/shard1,shard2,...): </LABEL>
+        <INPUT type="text" id="source" name="source" value=""></BR>
+      <LABEL for="destination">Destination (keyspace or keyspace/shard1,shard2,...): </LABEL>
+        <INPUT type="text" id="destination" name="destination" value=""></BR>
+      <LABEL for="tables">Tables (comma separated, all tables of the destination if empty): </LABEL>
+        <INPUT type="text" id="tables" name="tables" value=""></BR>
+      <LABEL for="excludeTables">Exclude Tables: </LABEL>
+        <INPUT type="text" id="excludeTables" name="excludeTables" value=""></BR>
+      <LABEL for="parallelDiffsCount">Number of Tables which are diffed in parallel: </LABEL>
+        <INPUT type="text" id="parallelDiffsCount" name="parallelDiffsCount" value="{{.DefaultParallelDiffsCount}}"></BR>
+      <LABEL for="minHealthyRdonlyTablets">Minimum Number of required healthy RDONLY tablets: </LABEL>
+        <INPUT type="text" id="minHealthyRdonlyTablets" name="minHealthyRdonlyTablets" value="{{.DefaultMinHealthyRdonlyTablets}}"></BR>
+      <LABEL for="mismatchFile">Mismatch File (path on the vtworker host, optional): </LABEL>
+        <INPUT type="text" id="mismatchFile" name="mismatchFile" value=""></BR>
+      <LABEL for="job">Job Name (empty for the default job): </LABEL>
+        <INPUT type="text" id="job" name="job" value=""></BR>
+      <INPUT type="submit" name="submit" value="Table Diff"/>
+    </form>
+
+  <h1>Help</h1>
+    <p>The rows of each table are streamed from a consistent snapshot on one RDONLY tablet per shard. The tablets keep serving and replicating during the diff. If the destination shards replicate from the source shards, filtered replication is briefly paused for each table, so that the snapshots are taken at matching positions.</p>
+    <p>The mismatch file has one JSON object per line with the table, the kind of difference and the primary key of the row.</p>
+  </body>
+`
+
+var tableDiffTemplate = mustParseTemplate("tableDiff", tableDiffHTML)
+
+func commandTableDiff(wi *Instance, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) (Worker, error) {
+	tables := subFlags.String("tables", "", "comma separated list of tables to diff (all tables of the destination by default). Each is either an exact match, or a regular expression of the form /regexp/")
+	excludeTables := subFlags.String("exclude_tables", "", "comma separated list of tables to exclude")
+	parallelDiffsCount := subFlags.Int("parallel_diffs_count", defaultParallelDiffsCount, "number of tables which are diffed in parallel")
+	minHealthyRdonlyTablets := subFlags.Int("min_healthy_rdonly_tablets", defaultMinHealthyRdonlyTablets, "minimum number of healthy RDONLY tablets in each shard before one is used")
+	mismatchFile := subFlags.String("mismatch_file", "", "if set, the primary keys of the rows which differ are written to this file (one JSON object per line)")
+	if err := subFlags.Parse(args); err != nil {
+		return nil, err
+	}
+	if subFlags.NArg() != 2 {
+		subFlags.Usage()
+		return nil, fmt.Errorf("command TableDiff requires <source keyspace[/shards]> <destination keyspace[/shards]>")
+	}
+
+	sourceKeyspace, sourceShards, err := parseKeyspaceShards(subFlags.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+	destinationKeyspace, destinationShards, err := parseKeyspaceShards(subFlags.Arg(1))
+	if err != nil {
+		return nil, err
+	}
+	var tableArray []string
+	if *tables != "" {
+		tableArray = strings.Split(*tables, ",")
+	}
+	var excludeTableArray []string
+	if *excludeTables != "" {
+		excludeTableArray = strings.Split(*excludeTables, ",")
+	}
+	worker, err := newTableDiffWorker(wr, wi.cell, sourceKeyspace, sourceShards, destinationKeyspace, destinationShards, tableArray, excludeTableArray, *parallelDiffsCount, *minHealthyRdonlyTablets, *mismatchFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create worker: %v", err)
+	}
+	return worker, nil
+}
+
+// parseKeyspaceShards parses "keyspace" or "keyspace/shard1,shard2,...".
+// It returns no shards for "keyspace".
+func parseKeyspaceShards(param string) (string, []string, error) {
+	if !strings.Contains(param, "/") {
+		return param, nil, nil
+	}
+	keyspace, shards, err := topoproto.ParseKeyspaceShard(param)
+	if err != nil {
+		return "", nil, err
+	}
+	return keyspace, strings.Split(shards, ","), nil
+}
+
+func interactiveTableDiff(ctx context.Context, wi *Instance, wr *wrangler.Wrangler, w http.ResponseWriter, r *http.Request) (Worker, *template.Template, map[string]interface{}, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse form: %s", err)
+	}
+	source := r.FormValue("source")
+	destination := r.FormValue("destination")
+
+	if source == "" || destination == "" {
+		// display the input form
+		result := make(map[string]interface{})
+		result["DefaultParallelDiffsCount"] = fmt.Sprintf("%v", defaultParallelDiffsCount)
+		result["DefaultMinHealthyRdonlyTablets"] = fmt.Sprintf("%v", defaultMinHealthyRdonlyTablets)
+		return nil, tableDiffTemplate, result, nil
+	}
+
+	// get other parameters
+	sourceKeyspace, sourceShards, err := parseKeyspaceShards(source)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse source: %s", err)
+	}
+	destinationKeyspace, destinationShards, err := parseKeyspaceShards(destination)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse destination: %s", err)
+	}
+	var tableArray []string
+	if tables := r.FormValue("tables"); tables != "" {
+		tableArray = strings.Split(tables, ",")
+	}
+	var excludeTableArray []string
+	if excludeTables := r.FormValue("excludeTables"); excludeTables != "" {
+		excludeTableArray = strings.Split(excludeTables, ",")
+	}
+	parallelDiffsCountStr := r.FormValue("parallelDiffsCount")
+	parallelDiffsCount, err := strconv.ParseInt(parallelDiffsCountStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse parallelDiffsCount: %s", err)
+	}
+	minHealthyRdonlyTabletsStr := r.FormValue("minHealthyRdonlyTablets")
+	minHealthyRdonlyTablets, err := strconv.ParseInt(minHealthyRdonlyTabletsStr, 0, 64)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot parse minHealthyRdonlyTablets: %s", err)
+	}
+	mismatchFile := r.FormValue("mismatchFile")
+
+	// start the diff job
+	wrk, err := newTableDiffWorker(wr, wi.cell, sourceKeyspace, sourceShards, destinationKeyspace, destinationShards, tableArray, excludeTableArray, int(parallelDiffsCount), int(minHealthyRdonlyTablets), mismatchFile)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot create worker: %v", err)
+	}
+	return wrk, nil, nil, nil
+}
+
+func init() {
+	AddCommand("Diffs", Command{"TableDiff",
+		commandTableDiff, interactiveTableDiff,
+		"[--tables=''] [--exclude_tables=''] [--mismatch_file=''] <source keyspace[/shard1,shard2,...]> <destination keyspace[/shard1,shard2,...]>",
+		"Diffs a set of tables between the shards of two keyspaces, which may be sharded differently. The rows are streamed from consistent snapshots on RDONLY tablets, which keep serving and replicating during the diff."})
+}
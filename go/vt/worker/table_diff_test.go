@@ -0,0 +1,394 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gitql/vitess/go/sqltypes"
+	"github.com/gitql/vitess/go/vt/logutil"
+	"github.com/gitql/vitess/go/vt/mysqlctl/tmutils"
+	"github.com/gitql/vitess/go/vt/tabletmanager/tmclient"
+	"github.com/gitql/vitess/go/vt/tabletserver/grpcqueryservice"
+	"github.com/gitql/vitess/go/vt/tabletserver/queryservice/fakes"
+	"github.com/gitql/vitess/go/vt/topo/memorytopo"
+	"github.com/gitql/vitess/go/vt/wrangler"
+	"github.com/gitql/vitess/go/vt/wrangler/testlib"
+
+	querypb "github.com/gitql/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/gitql/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/gitql/vitess/go/vt/proto/topodata"
+)
+
+func newRowsResultReader(fields []*querypb.Field, rows ...[]sqltypes.Value) ResultReader {
+	return &memoryResultReader{
+		fields: fields,
+		results: []*sqltypes.Result{
+			{
+				Fields:       fields,
+				RowsAffected: uint64(len(rows)),
+				Rows:         rows,
+			},
+		},
+	}
+}
+
+func TestRowDifferMismatchReport(t *testing.T) {
+	td := &tabletmanagerdatapb.TableDefinition{
+		Name:              "t1",
+		Columns:           []string{"id", "msg"},
+		PrimaryKeyColumns: []string{"id"},
+	}
+	source := newRowsResultReader(singlePk,
+		createRowSinglePk(1), createRowSinglePk(2), createRowSinglePk(4), createRowSinglePk(5))
+	changedRow := createRowSinglePk(2)
+	changedRow[1] = sqltypes.MakeString([]byte("changed"))
+	destination := newRowsResultReader(singlePk,
+		createRowSinglePk(1), changedRow, createRowSinglePk(3), createRowSinglePk(6))
+
+	dir, err := ioutil.TempDir("", "table_diff_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	mismatchFile := path.Join(dir, "mismatches.json")
+	mismatches, err := newMismatchReport(mismatchFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	differ, err := NewRowDiffer(source, destination, td)
+	if err != nil {
+		t.Fatal(err)
+	}
+	differ.onMismatch = func(kind string, row []sqltypes.Value) {
+		mismatches.record(td, mismatchKinds[kind], "", row)
+	}
+	report, err := differ.Go(logutil.NewMemoryLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.matchingRows != 1 || report.mismatchedRows != 1 || report.extraRowsLeft != 2 || report.extraRowsRight != 2 {
+		t.Errorf("wrong diff report: %v", report.String())
+	}
+
+	if err := mismatches.close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mismatches.count(), 5; got != want {
+		t.Errorf("wrong number of mismatches: got = %v, want = %v", got, want)
+	}
+	data, err := ioutil.ReadFile(mismatchFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`{"table":"t1","kind":"content","primary_key":{"id":"2"}}`,
+		`{"table":"t1","kind":"only_in_destination","primary_key":{"id":"3"}}`,
+		`{"table":"t1","kind":"only_in_source","primary_key":{"id":"4"}}`,
+		`{"table":"t1","kind":"only_in_source","primary_key":{"id":"5"}}`,
+		`{"table":"t1","kind":"only_in_destination","primary_key":{"id":"6"}}`,
+	}
+	if got := strings.Split(strings.TrimSpace(string(data)), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("wrong mismatch file:\n%v\nwant:\n%v", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseKeyspaceShards(t *testing.T) {
+	testcases := []struct {
+		param    string
+		keyspace string
+		shards   []string
+	}{
+		{"ks", "ks", nil},
+		{"ks/0", "ks", []string{"0"}},
+		{"ks/-80,80-", "ks", []string{"-80", "80-"}},
+	}
+	for _, tc := range testcases {
+		keyspace, shards, err := parseKeyspaceShards(tc.param)
+		if err != nil {
+			t.Errorf("parseKeyspaceShards(%v) failed: %v", tc.param, err)
+			continue
+		}
+		if keyspace != tc.keyspace || strings.Join(shards, ",") != strings.Join(tc.shards, ",") {
+			t.Errorf("parseKeyspaceShards(%v) = %v, %v, want: %v, %v", tc.param, keyspace, shards, tc.keyspace, tc.shards)
+		}
+	}
+}
+
+// tableDiffTabletServer is a local QueryService implementation which
+// streams the rows of table1 from a snapshot.
+type tableDiffTabletServer struct {
+	t *testing.T
+
+	*fakes.StreamHealthQueryService
+	rows [][]sqltypes.Value
+	// waitForPosition is the position the snapshot must be taken after.
+	waitForPosition string
+	// snapshotPosition is the position of the snapshot.
+	snapshotPosition string
+}
+
+func (sq *tableDiffTabletServer) StreamExecute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, options *querypb.ExecuteOptions, callback func(reply *sqltypes.Result) error) error {
+	if !strings.Contains(sql, "ORDER BY `id`") {
+		sq.t.Errorf("the rows must be ordered by the primary key; query received: %v", sql)
+	}
+	sq.t.Logf("tableDiffTabletServer: got query: %v", sql)
+	if options == nil || options.TransactionIsolation != querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY {
+		sq.t.Errorf("the rows must be read from a snapshot; options received: %v", options)
+	} else if options.WaitForPosition != sq.waitForPosition {
+		sq.t.Errorf("snapshot of %v taken after position %v, want %v", target, options.WaitForPosition, sq.waitForPosition)
+	}
+
+	// Send the headers
+	if err := callback(&sqltypes.Result{
+		Extras: &querypb.ResultExtras{
+			Position: sq.snapshotPosition,
+		},
+		Fields: []*querypb.Field{
+			{
+				Name: "id",
+				Type: sqltypes.Int64,
+			},
+			{
+				Name: "msg",
+				Type: sqltypes.VarChar,
+			},
+			{
+				Name: "keyspace_id",
+				Type: sqltypes.Int64,
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	// Send the values
+	for _, row := range sq.rows {
+		if err := callback(&sqltypes.Result{
+			Rows: [][]sqltypes.Value{row},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableDiffTMC is a fake TabletManagerClient for the filtered replication
+// of the destination masters.
+type tableDiffTMC struct {
+	tmclient.TabletManagerClient
+	t *testing.T
+
+	// blpPosition is the position of filtered replication when it is
+	// stopped.
+	blpPosition string
+	// runBlpUntil is the position filtered replication must run until.
+	runBlpUntil string
+}
+
+// StopBlp is part of the tmclient.TabletManagerClient interface.
+func (c *tableDiffTMC) StopBlp(ctx context.Context, tablet *topodatapb.Tablet) ([]*tabletmanagerdatapb.BlpPosition, error) {
+	return []*tabletmanagerdatapb.BlpPosition{
+		{
+			Uid:      0,
+			Position: c.blpPosition,
+		},
+	}, nil
+}
+
+// RunBlpUntil is part of the tmclient.TabletManagerClient interface. The
+// master then is at tableDiffMasterPosition.
+func (c *tableDiffTMC) RunBlpUntil(ctx context.Context, tablet *topodatapb.Tablet, positions []*tabletmanagerdatapb.BlpPosition, waitTime time.Duration) (string, error) {
+	if len(positions) != 1 || positions[0].Position != c.runBlpUntil {
+		c.t.Errorf("RunBlpUntil(%v, %v), want position %v", tablet.Alias, positions, c.runBlpUntil)
+	}
+	return tableDiffMasterPosition(tablet.Alias.Uid), nil
+}
+
+// tableDiffMasterPosition returns the position of the destination master
+// "uid" after RunBlpUntil.
+func tableDiffMasterPosition(uid uint32) string {
+	return fmt.Sprintf("MariaDB/0-%v-200", uid)
+}
+
+// tableDiffRow returns row "i" of table1. Even rows are in the shard -80,
+// odd rows in 80-.
+func tableDiffRow(i int) []sqltypes.Value {
+	ksids := []uint64{0x2000000000000000, 0xA000000000000000}
+	return []sqltypes.Value{
+		sqltypes.MakeString([]byte(fmt.Sprintf("%v", i))),
+		sqltypes.MakeString([]byte(fmt.Sprintf("Text for %v", i))),
+		sqltypes.MakeString([]byte(fmt.Sprintf("%v", ksids[i%2]))),
+	}
+}
+
+// testTableDiff diffs table1 between the unsharded keyspace "source_ks" and
+// the keyspace "dest_ks" which is split into -80 and 80-. "changeRow" is
+// called for each row of the destination shards.
+func testTableDiff(t *testing.T, changeRow func(row []sqltypes.Value), extraArgs ...string) error {
+	*useV3ReshardingMode = false
+	ts := memorytopo.NewServer("cell1", "cell2")
+	ctx := context.Background()
+	wi := NewInstance(ts, "cell1", time.Second)
+
+	if err := ts.CreateKeyspace(ctx, "dest_ks", &topodatapb.Keyspace{
+		ShardingColumnName: "keyspace_id",
+		ShardingColumnType: topodatapb.KeyspaceIdType_UINT64,
+	}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+
+	sourceMaster := testlib.NewFakeTablet(t, wi.wr, "cell1", 0,
+		topodatapb.TabletType_MASTER, nil, testlib.TabletKeyspaceShard(t, "source_ks", "0"))
+	sourceRdonly := testlib.NewFakeTablet(t, wi.wr, "cell1", 1,
+		topodatapb.TabletType_RDONLY, nil, testlib.TabletKeyspaceShard(t, "source_ks", "0"))
+	leftMaster := testlib.NewFakeTablet(t, wi.wr, "cell1", 10,
+		topodatapb.TabletType_MASTER, nil, testlib.TabletKeyspaceShard(t, "dest_ks", "-80"))
+	leftRdonly := testlib.NewFakeTablet(t, wi.wr, "cell1", 11,
+		topodatapb.TabletType_RDONLY, nil, testlib.TabletKeyspaceShard(t, "dest_ks", "-80"))
+	rightMaster := testlib.NewFakeTablet(t, wi.wr, "cell1", 20,
+		topodatapb.TabletType_MASTER, nil, testlib.TabletKeyspaceShard(t, "dest_ks", "80-"))
+	rightRdonly := testlib.NewFakeTablet(t, wi.wr, "cell1", 21,
+		topodatapb.TabletType_RDONLY, nil, testlib.TabletKeyspaceShard(t, "dest_ks", "80-"))
+
+	for _, ft := range []*testlib.FakeTablet{sourceMaster, sourceRdonly, leftMaster, leftRdonly, rightMaster, rightRdonly} {
+		ft.StartActionLoop(t, wi.wr)
+		defer ft.StopActionLoop(t)
+	}
+
+	// The destination shards replicate from the source shard, which makes
+	// the worker synchronize the replication.
+	for _, shard := range []string{"-80", "80-"} {
+		if err := wi.wr.SetSourceShards(ctx, "dest_ks", shard, []*topodatapb.TabletAlias{sourceRdonly.Tablet.Alias}, nil); err != nil {
+			t.Fatalf("SetSourceShards failed: %v", err)
+		}
+	}
+
+	for _, rdonly := range []*testlib.FakeTablet{sourceRdonly, leftRdonly, rightRdonly} {
+		rdonly.FakeMysqlDaemon.Schema = &tabletmanagerdatapb.SchemaDefinition{
+			DatabaseSchema: "",
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+				{
+					Name:              "table1",
+					Columns:           []string{"id", "msg", "keyspace_id"},
+					PrimaryKeyColumns: []string{"id"},
+					Type:              tmutils.TableBaseTable,
+				},
+			},
+		}
+	}
+
+	// The ids are compared as strings: use single digits to keep them
+	// ordered.
+	var sourceRows, leftRows, rightRows [][]sqltypes.Value
+	for i := 0; i < 10; i++ {
+		sourceRows = append(sourceRows, tableDiffRow(i))
+		row := tableDiffRow(i)
+		changeRow(row)
+		if i%2 == 0 {
+			leftRows = append(leftRows, row)
+		} else {
+			rightRows = append(rightRows, row)
+		}
+	}
+	// Filtered replication is stopped at blpPosition, the source snapshot
+	// must be taken after it, and filtered replication then runs until
+	// the source snapshot. The destination snapshots must be taken after
+	// the positions of their masters.
+	blpPosition := "MariaDB/0-1-100"
+	sourceSnapshotPosition := "MariaDB/0-1-105"
+	for _, tc := range []struct {
+		rdonly           *testlib.FakeTablet
+		rows             [][]sqltypes.Value
+		waitForPosition  string
+		snapshotPosition string
+	}{
+		{sourceRdonly, sourceRows, blpPosition, sourceSnapshotPosition},
+		{leftRdonly, leftRows, tableDiffMasterPosition(10), tableDiffMasterPosition(10)},
+		{rightRdonly, rightRows, tableDiffMasterPosition(20), tableDiffMasterPosition(20)},
+	} {
+		qs := fakes.NewStreamHealthQueryService(tc.rdonly.Target())
+		qs.AddDefaultHealthResponse()
+		grpcqueryservice.Register(tc.rdonly.RPCServer, &tableDiffTabletServer{
+			t:                        t,
+			StreamHealthQueryService: qs,
+			rows:                     tc.rows,
+			waitForPosition:          tc.waitForPosition,
+			snapshotPosition:         tc.snapshotPosition,
+		})
+	}
+
+	// Run the vtworker command.
+	args := []string{"TableDiff", "-min_healthy_rdonly_tablets", "1"}
+	args = append(args, extraArgs...)
+	args = append(args, "source_ks", "dest_ks")
+	// We need to use FakeTabletManagerClient because we don't
+	// have a good way to fake the binlog player yet, which is
+	// necessary for synchronizing replication.
+	wr := wrangler.New(logutil.NewConsoleLogger(), ts, &tableDiffTMC{
+		TabletManagerClient: newFakeTMCTopo(ts),
+		t:                   t,
+		blpPosition:         blpPosition,
+		runBlpUntil:         sourceSnapshotPosition,
+	})
+	err := runCommand(t, wi, wr, args)
+
+	// The tablets must not be taken out of serving.
+	for _, rdonly := range []*testlib.FakeTablet{sourceRdonly, leftRdonly, rightRdonly} {
+		ti, err := ts.GetTablet(ctx, rdonly.Tablet.Alias)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ti.Type != topodatapb.TabletType_RDONLY {
+			t.Errorf("tablet %v has type %v after the diff, want RDONLY", ti.AliasString(), ti.Type)
+		}
+		if _, ok := ti.Tags["worker"]; ok {
+			t.Errorf("tablet %v was tagged by the worker", ti.AliasString())
+		}
+	}
+	return err
+}
+
+func TestTableDiff(t *testing.T) {
+	if err := testTableDiff(t, func(row []sqltypes.Value) {}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTableDiffMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "table_diff_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	mismatchFile := path.Join(dir, "mismatches.json")
+
+	err = testTableDiff(t, func(row []sqltypes.Value) {
+		if row[0].String() == "3" {
+			row[1] = sqltypes.MakeString([]byte("changed"))
+		}
+	}, "-mismatch_file", mismatchFile)
+	want := "Table table1 has differences"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("TableDiff = %v, want an error containing %v", err, want)
+	}
+
+	data, err := ioutil.ReadFile(mismatchFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(data)), `{"table":"table1","kind":"content","primary_key":{"id":"3"}}`; got != want {
+		t.Errorf("wrong mismatch file: %v, want: %v", got, want)
+	}
+}
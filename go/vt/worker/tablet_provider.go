@@ -84,3 +84,29 @@ func (p *shardTabletProvider) returnTablet(t *topodatapb.Tablet) {
 func (p *shardTabletProvider) description() string {
 	return topoproto.KeyspaceShardString(p.keyspace, p.shard)
 }
+
+// shardMasterTabletProvider returns the current healthy MASTER tablet for a
+// given keyspace and shard. It uses the HealthCheck module to retrieve it.
+type shardMasterTabletProvider struct {
+	tsc      *discovery.TabletStatsCache
+	keyspace string
+	shard    string
+}
+
+func newShardMasterTabletProvider(tsc *discovery.TabletStatsCache, keyspace, shard string) *shardMasterTabletProvider {
+	return &shardMasterTabletProvider{tsc, keyspace, shard}
+}
+
+func (p *shardMasterTabletProvider) getTablet() (*topodatapb.Tablet, error) {
+	masters := p.tsc.GetHealthyTabletStats(p.keyspace, p.shard, topodatapb.TabletType_MASTER)
+	if len(masters) == 0 {
+		return nil, fmt.Errorf("%v: no healthy MASTER tablet available", p.description())
+	}
+	return masters[0].Tablet, nil
+}
+
+func (p *shardMasterTabletProvider) returnTablet(*topodata.Tablet) {}
+
+func (p *shardMasterTabletProvider) description() string {
+	return topoproto.KeyspaceShardString(p.keyspace, p.shard)
+}
@@ -180,31 +180,31 @@ func TestVerticalSplitClone(t *testing.T) {
 	if err := runCommand(t, wi, wi.wr, args); err != nil {
 		t.Fatal(err)
 	}
-	if inserts := statsOnlineInsertsCounters.Counts()["moving1"]; inserts != 100 {
+	if inserts := statsOnlineInsertsCounters.Counts()["default.moving1"]; inserts != 100 {
 		t.Errorf("wrong number of rows inserted: got = %v, want = %v", inserts, 100)
 	}
-	if updates := statsOnlineUpdatesCounters.Counts()["moving1"]; updates != 0 {
+	if updates := statsOnlineUpdatesCounters.Counts()["default.moving1"]; updates != 0 {
 		t.Errorf("wrong number of rows updated: got = %v, want = %v", updates, 0)
 	}
-	if deletes := statsOnlineDeletesCounters.Counts()["moving1"]; deletes != 0 {
+	if deletes := statsOnlineDeletesCounters.Counts()["default.moving1"]; deletes != 0 {
 		t.Errorf("wrong number of rows deleted: got = %v, want = %v", deletes, 0)
 	}
-	if inserts := statsOfflineInsertsCounters.Counts()["moving1"]; inserts != 0 {
+	if inserts := statsOfflineInsertsCounters.Counts()["default.moving1"]; inserts != 0 {
 		t.Errorf("no stats for the offline clone phase should have been modified. got inserts = %v", inserts)
 	}
-	if updates := statsOfflineUpdatesCounters.Counts()["moving1"]; updates != 0 {
+	if updates := statsOfflineUpdatesCounters.Counts()["default.moving1"]; updates != 0 {
 		t.Errorf("no stats for the offline clone phase should have been modified. got updates = %v", updates)
 	}
-	if deletes := statsOfflineDeletesCounters.Counts()["moving1"]; deletes != 0 {
+	if deletes := statsOfflineDeletesCounters.Counts()["default.moving1"]; deletes != 0 {
 		t.Errorf("no stats for the offline clone phase should have been modified. got deletes = %v", deletes)
 	}
 
 	wantRetryCount := int64(1)
-	if got := statsRetryCount.Get(); got != wantRetryCount {
+	if got := statsRetryCount.Counts()[defaultJobName]; got != wantRetryCount {
 		t.Errorf("Wrong statsRetryCounter: got %v, wanted %v", got, wantRetryCount)
 	}
 	wantRetryReadOnlyCount := int64(1)
-	if got := statsRetryCounters.Counts()[retryCategoryReadOnly]; got != wantRetryReadOnlyCount {
+	if got := statsRetryCounters.Counts()[defaultJobName+"."+retryCategoryReadOnly]; got != wantRetryReadOnlyCount {
 		t.Errorf("Wrong statsRetryCounters: got %v, wanted %v", got, wantRetryReadOnlyCount)
 	}
 }
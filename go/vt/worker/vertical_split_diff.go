@@ -93,7 +93,7 @@ func (vsdw *VerticalSplitDiffWorker) StatusAsText() string {
 
 // Run is mostly a wrapper to run the cleanup at the end.
 func (vsdw *VerticalSplitDiffWorker) Run(ctx context.Context) error {
-	resetVars()
+	resetVars(ctx)
 	err := vsdw.run(ctx)
 
 	vsdw.SetState(WorkerStateCleanUp)
@@ -376,6 +376,11 @@ func (vsdw *VerticalSplitDiffWorker) diff(ctx context.Context) error {
 			defer wg.Done()
 			sem.Acquire()
 			defer sem.Release()
+			if err := acquireSourceReader(ctx); err != nil {
+				rec.RecordError(err)
+				return
+			}
+			defer releaseSourceReader(ctx)
 
 			vsdw.wr.Logger().Infof("Starting the diff on table %v", tableDefinition.Name)
 			sourceQueryResultReader, err := TableScan(ctx, vsdw.wr.Logger(), vsdw.wr.TopoServer(), vsdw.sourceAlias, tableDefinition)
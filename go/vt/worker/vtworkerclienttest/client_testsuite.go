@@ -59,6 +59,8 @@ func TestSuite(t *testing.T, c vtworkerclient.Client) {
 
 	commandErrorsBecauseBusy(t, c, true /* server side cancelation */)
 
+	namedJobsRunConcurrently(t, c)
+
 	commandPanics(t, c)
 }
 
@@ -88,20 +90,28 @@ func commandSucceeds(t *testing.T, client vtworkerclient.Client) {
 }
 
 func runVtworkerCommand(client vtworkerclient.Client, args []string) error {
+	_, err := runVtworkerCommandWithOutput(client, args)
+	return err
+}
+
+// runVtworkerCommandWithOutput is like runVtworkerCommand, but it also
+// returns the logged output of the command.
+func runVtworkerCommandWithOutput(client vtworkerclient.Client, args []string) (string, error) {
 	stream, err := client.ExecuteVtworkerCommand(context.Background(), args)
 	if err != nil {
-		return fmt.Errorf("cannot execute remote command: %v", err)
+		return "", fmt.Errorf("cannot execute remote command: %v", err)
 	}
 
+	output := ""
 	for {
-		_, err := stream.Recv()
+		e, err := stream.Recv()
 		switch err {
 		case nil:
-			// Consume next response.
+			output += logutil.EventString(e)
 		case io.EOF:
-			return nil
+			return output, nil
 		default:
-			return vterrors.WithPrefix("unexpected error when reading the stream: ", err)
+			return output, vterrors.WithPrefix("unexpected error when reading the stream: ", err)
 		}
 	}
 }
@@ -180,7 +190,7 @@ func commandErrorsBecauseBusy(t *testing.T, client vtworkerclient.Client, server
 	}
 
 	// Reset vtworker for the next test function.
-	if err := resetVtworker(t, client); err != nil {
+	if err := resetVtworker(t, client, []string{"Reset"}); err != nil {
 		t.Fatal(err)
 	}
 
@@ -203,12 +213,12 @@ func commandErrorsBecauseBusy(t *testing.T, client vtworkerclient.Client, server
 // d) vtworker is still canceling and shutting down the command.
 // e) A new vtworker command e.g. "Reset" would fail at this point with
 // "vtworker still executing" until the cancelation is complete.
-func resetVtworker(t *testing.T, client vtworkerclient.Client) error {
+func resetVtworker(t *testing.T, client vtworkerclient.Client, resetArgs []string) error {
 	start := time.Now()
 	attempts := 0
 	for {
 		attempts++
-		err := runVtworkerCommand(client, []string{"Reset"})
+		err := runVtworkerCommand(client, resetArgs)
 
 		if err == nil {
 			return nil
@@ -267,3 +277,73 @@ func commandPanics(t *testing.T, client vtworkerclient.Client) {
 		t.Fatalf("Unexpected remote error, got: '%v' was expecting to find '%v'", err, expected)
 	}
 }
+
+// namedJobsRunConcurrently tests that commands with different job names run
+// concurrently, and that each job can be inspected and canceled on its own.
+func namedJobsRunConcurrently(t *testing.T, client vtworkerclient.Client) {
+	// Run the vtworker "Block" command as the job "blocker".
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	blockCommandStarted := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		stream, err := client.ExecuteVtworkerCommand(ctx, []string{"-job", "blocker", "Block"})
+		if err != nil {
+			t.Errorf("Block command should not have failed: %v", err)
+			close(blockCommandStarted)
+			return
+		}
+		// The first log line will come from the "Block" command.
+		stream.Recv()
+		close(blockCommandStarted)
+		for {
+			if _, err := stream.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+	<-blockCommandStarted
+
+	// A job with a different name is not blocked.
+	if err := runVtworkerCommand(client, []string{"-job", "pinger", "Ping", "Are you busy?"}); err != nil {
+		t.Fatalf("Ping as a different job should not have failed: %v", err)
+	}
+
+	got, err := runVtworkerCommandWithOutput(client, []string{"-job", "blocker", "Status"})
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !strings.Contains(got, "Block Command") || !strings.Contains(got, "running") {
+		t.Errorf("Status returned the wrong status for the job: %v", got)
+	}
+	got, err = runVtworkerCommandWithOutput(client, []string{"-job", "pinger", "Logs"})
+	if err != nil {
+		t.Fatalf("Logs failed: %v", err)
+	}
+	if !strings.Contains(got, "Are you busy?") {
+		t.Errorf("Logs returned the wrong logs for the job: %v", got)
+	}
+	got, err = runVtworkerCommandWithOutput(client, []string{"ListJobs"})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if !strings.Contains(got, "blocker: running") || !strings.Contains(got, "pinger: ended") {
+		t.Errorf("ListJobs returned the wrong jobs: %v", got)
+	}
+
+	// Cancel the "blocker" job on the server side only.
+	if err := runVtworkerCommand(client, []string{"-job", "blocker", "Cancel"}); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	// Reset both jobs for the next test function.
+	if err := resetVtworker(t, client, []string{"-job", "blocker", "Reset"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runVtworkerCommand(client, []string{"-job", "pinger", "Reset"}); err != nil {
+		t.Fatal(err)
+	}
+}
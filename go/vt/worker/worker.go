@@ -11,6 +11,7 @@ package worker
 import (
 	"flag"
 	"html/template"
+	"strings"
 	"time"
 
 	"golang.org/x/net/context"
@@ -35,64 +36,80 @@ type Worker interface {
 	Run(context.Context) error
 }
 
+// CutOverWorker is implemented by workers which keep running until an
+// operator triggers the cut-over e.g. ExternalImportWorker.
+type CutOverWorker interface {
+	Worker
+
+	// CutOver tells the worker to finish. It must not block until the
+	// worker is done.
+	CutOver() error
+}
+
 var (
 	retryDuration         = flag.Duration("retry_duration", 2*time.Hour, "Amount of time we wait before giving up on a retryable action (e.g. write to destination, waiting for healthy tablets)")
 	executeFetchRetryTime = flag.Duration("executefetch_retry_time", 30*time.Second, "Amount of time we should wait before retrying ExecuteFetch calls")
 	remoteActionsTimeout  = flag.Duration("remote_actions_timeout", time.Minute, "Amount of time to wait for remote actions (like replication stop, ...)")
 	useV3ReshardingMode   = flag.Bool("use_v3_resharding_mode", false, "True iff the workers should use V3-style resharding, which doesn't require a preset sharding key column.")
 
+	maxSourceReaders      = flag.Int("max_concurrent_source_readers", 0, "maximum number of chunks which are read from the source tablets at the same time, across all jobs of this vtworker. 0 means unlimited. Each worker is also limited by its own --source_reader_count.")
+	maxDestinationWriters = flag.Int("max_concurrent_destination_writers", 0, "maximum number of write queries which are sent to the destination tablets at the same time, across all jobs of this vtworker. 0 means unlimited. Each worker is also limited by its own --destination_writer_count.")
+
 	healthCheckTopologyRefresh = flag.Duration("worker_healthcheck_topology_refresh", 30*time.Second, "refresh interval for re-reading the topology")
 	healthcheckRetryDelay      = flag.Duration("worker_healthcheck_retry_delay", 5*time.Second, "delay before retrying a failed healthcheck")
 	healthCheckTimeout         = flag.Duration("worker_healthcheck_timeout", time.Minute, "the health check timeout period")
 
-	statsState = stats.NewString("WorkerState")
+	// The debug variables below are shared by all jobs of this process. They
+	// are keyed by the name of the job, which is always their first label.
+
+	statsState = stats.NewStringMap("WorkerState")
 	// statsRetryCount is the total number of times a query to vttablet had to be retried.
-	statsRetryCount = stats.NewInt("WorkerRetryCount")
+	statsRetryCount = stats.NewCounters("WorkerRetryCount")
 	// statsRetryCount groups the number of retries by category e.g. "TimeoutError" or "Readonly".
-	statsRetryCounters = stats.NewCounters("WorkerRetryCounters")
+	statsRetryCounters = stats.NewMultiCounters("WorkerRetryCounters", []string{"job", "category"})
 	// statsThrottledCounters is the number of times a write has been throttled,
-	// grouped by (keyspace, shard, threadID). Mainly used for testing.
+	// grouped by (job, keyspace, shard, threadID). Mainly used for testing.
 	// If throttling is enabled, this should always be non-zero for all threads.
-	statsThrottledCounters = stats.NewMultiCounters("WorkerThrottledCounters", []string{"keyspace", "shardname", "thread_id"})
+	statsThrottledCounters = stats.NewMultiCounters("WorkerThrottledCounters", []string{"job", "keyspace", "shardname", "thread_id"})
 	// statsStateDurations tracks for each state how much time was spent in it. Mainly used for testing.
-	statsStateDurationsNs = stats.NewCounters("WorkerStateDurations")
+	statsStateDurationsNs = stats.NewMultiCounters("WorkerStateDurations", []string{"job", "state"})
 
 	// statsOnlineInsertsCounters tracks for every table how many rows were
 	// inserted during the online clone (reconciliation) phase.
-	statsOnlineInsertsCounters = stats.NewCounters("WorkerOnlineInsertsCounters")
+	statsOnlineInsertsCounters = stats.NewMultiCounters("WorkerOnlineInsertsCounters", []string{"job", "table"})
 	// statsOnlineUpdatesCounters tracks for every table how many rows were updated.
-	statsOnlineUpdatesCounters = stats.NewCounters("WorkerOnlineUpdatesCounters")
+	statsOnlineUpdatesCounters = stats.NewMultiCounters("WorkerOnlineUpdatesCounters", []string{"job", "table"})
 	// statsOnlineUpdatesCounters tracks for every table how many rows were deleted.
-	statsOnlineDeletesCounters = stats.NewCounters("WorkerOnlineDeletesCounters")
+	statsOnlineDeletesCounters = stats.NewMultiCounters("WorkerOnlineDeletesCounters", []string{"job", "table"})
 	// statsOnlineEqualRowsCounters tracks for every table how many rows were equal.
-	statsOnlineEqualRowsCounters = stats.NewCounters("WorkerOnlineEqualRowsCounters")
+	statsOnlineEqualRowsCounters = stats.NewMultiCounters("WorkerOnlineEqualRowsCounters", []string{"job", "table"})
 
 	// statsOfflineInsertsCounters tracks for every table how many rows were
 	// inserted during the online clone (reconciliation) phase.
-	statsOfflineInsertsCounters = stats.NewCounters("WorkerOfflineInsertsCounters")
+	statsOfflineInsertsCounters = stats.NewMultiCounters("WorkerOfflineInsertsCounters", []string{"job", "table"})
 	// statsOfflineUpdatesCounters tracks for every table how many rows were updated.
-	statsOfflineUpdatesCounters = stats.NewCounters("WorkerOfflineUpdatesCounters")
+	statsOfflineUpdatesCounters = stats.NewMultiCounters("WorkerOfflineUpdatesCounters", []string{"job", "table"})
 	// statsOfflineUpdatesCounters tracks for every table how many rows were deleted.
-	statsOfflineDeletesCounters = stats.NewCounters("WorkerOfflineDeletesCounters")
+	statsOfflineDeletesCounters = stats.NewMultiCounters("WorkerOfflineDeletesCounters", []string{"job", "table"})
 	// statsOfflineEqualRowsCounters tracks for every table how many rows were equal.
-	statsOfflineEqualRowsCounters = stats.NewCounters("WorkerOfflineEqualRowsCounters")
+	statsOfflineEqualRowsCounters = stats.NewMultiCounters("WorkerOfflineEqualRowsCounters", []string{"job", "table"})
 
 	// statsStreamingQueryRestartsCounters tracks for every tablet alias how often
 	// a streaming query was succesfully established there.
-	statsStreamingQueryCounters = stats.NewCounters("StreamingQueryCounters")
+	statsStreamingQueryCounters = stats.NewMultiCounters("StreamingQueryCounters", []string{"job", "tablet"})
 	// statsStreamingQueryErrorsCounters tracks for every tablet alias how often
 	// a (previously successfully established) streaming query did error.
-	statsStreamingQueryErrorsCounters = stats.NewCounters("StreamingQueryErrorsCounters")
+	statsStreamingQueryErrorsCounters = stats.NewMultiCounters("StreamingQueryErrorsCounters", []string{"job", "tablet"})
 	// statsStreamingQueryRestartsSameTabletCounters tracks for every tablet alias
 	// how often we successfully restarted a streaming query on the first retry.
 	// This kind of restart is usually necessary when our streaming query is idle
 	// and MySQL aborts it after a timeout.
-	statsStreamingQueryRestartsSameTabletCounters = stats.NewCounters("StreamingQueryRestartsSameTabletCounters")
+	statsStreamingQueryRestartsSameTabletCounters = stats.NewMultiCounters("StreamingQueryRestartsSameTabletCounters", []string{"job", "tablet"})
 	// statsStreamingQueryRestartsDifferentTablet records how many restarts were
 	// successful on the 2 (or higher) retry after the initial retry to the same
 	// tablet failed and we switched to a different tablet. In practice, this
 	// happens when a tablet did go away due to a maintenance operation.
-	statsStreamingQueryRestartsDifferentTablet = stats.NewInt("StreamingQueryRestartsDifferentTablet")
+	statsStreamingQueryRestartsDifferentTablet = stats.NewCounters("StreamingQueryRestartsDifferentTablet")
 )
 
 const (
@@ -104,23 +121,57 @@ const (
 
 // resetVars resets the debug variables that are meant to provide information on a
 // per-run basis. This should be called at the beginning of each worker run.
-func resetVars() {
-	statsState.Set("")
-	statsRetryCount.Set(0)
-	statsRetryCounters.Reset()
-
-	statsOnlineInsertsCounters.Reset()
-	statsOnlineUpdatesCounters.Reset()
-	statsOnlineDeletesCounters.Reset()
-	statsOnlineEqualRowsCounters.Reset()
-
-	statsOfflineInsertsCounters.Reset()
-	statsOfflineUpdatesCounters.Reset()
-	statsOfflineDeletesCounters.Reset()
-	statsOfflineEqualRowsCounters.Reset()
-
-	statsStreamingQueryCounters.Reset()
-	statsStreamingQueryErrorsCounters.Reset()
+// Only the variables of the job of ctx are reset, the other jobs may still
+// be running.
+func resetVars(ctx context.Context) {
+	job := jobNameFromContext(ctx)
+
+	statsState.Set(job, "")
+	resetJobCounters(job,
+		statsRetryCount,
+		&statsRetryCounters.Counters,
+
+		&statsOnlineInsertsCounters.Counters,
+		&statsOnlineUpdatesCounters.Counters,
+		&statsOnlineDeletesCounters.Counters,
+		&statsOnlineEqualRowsCounters.Counters,
+
+		&statsOfflineInsertsCounters.Counters,
+		&statsOfflineUpdatesCounters.Counters,
+		&statsOfflineDeletesCounters.Counters,
+		&statsOfflineEqualRowsCounters.Counters,
+
+		&statsStreamingQueryCounters.Counters,
+		&statsStreamingQueryErrorsCounters.Counters)
+}
+
+// resetJobCounters sets the counters of the job to zero. The name of each
+// counter is the job name, or starts with it.
+func resetJobCounters(job string, counters ...*stats.Counters) {
+	for _, c := range counters {
+		for name := range c.Counts() {
+			if name == job || strings.HasPrefix(name, job+".") {
+				c.Set(name, 0)
+			}
+		}
+	}
+}
+
+// jobNameKey is the context key of the name of the job which runs the worker.
+type jobNameKey struct{}
+
+// withJobName returns a context which carries the job name to the worker.
+func withJobName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, jobNameKey{}, name)
+}
+
+// jobNameFromContext returns the job name of the context, or the default
+// job name if the worker was not started by an Instance e.g. in tests.
+func jobNameFromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(jobNameKey{}).(string); ok {
+		return name
+	}
+	return defaultJobName
 }
 
 // checkDone returns ctx.Err() iff ctx.Done().
@@ -0,0 +1,52 @@
+// Copyright 2017, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package worker
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestResetVarsKeepsOtherJobs(t *testing.T) {
+	ctx1 := withJobName(context.Background(), "job1")
+	ctx2 := withJobName(context.Background(), "job2")
+	resetVars(ctx1)
+	resetVars(ctx2)
+
+	statsRetryCount.Add("job1", 1)
+	statsRetryCount.Add("job2", 2)
+	statsOnlineInsertsCounters.Add([]string{"job1", "table1"}, 10)
+	statsOnlineInsertsCounters.Add([]string{"job2", "table1"}, 20)
+
+	w1 := NewStatusWorker()
+	w1.setJobName("job1")
+	w1.SetState(WorkerStateCloneOnline)
+	w2 := NewStatusWorker()
+	w2.setJobName("job2")
+	w2.SetState(WorkerStateDiff)
+
+	// A new run of job1 must not reset the stats of job2.
+	resetVars(ctx1)
+
+	if got, want := statsRetryCount.Counts()["job1"], int64(0); got != want {
+		t.Errorf("statsRetryCount of job1 = %v, want %v", got, want)
+	}
+	if got, want := statsRetryCount.Counts()["job2"], int64(2); got != want {
+		t.Errorf("statsRetryCount of job2 = %v, want %v", got, want)
+	}
+	if got, want := statsOnlineInsertsCounters.Counts()["job1.table1"], int64(0); got != want {
+		t.Errorf("statsOnlineInsertsCounters of job1 = %v, want %v", got, want)
+	}
+	if got, want := statsOnlineInsertsCounters.Counts()["job2.table1"], int64(20); got != want {
+		t.Errorf("statsOnlineInsertsCounters of job2 = %v, want %v", got, want)
+	}
+	if got, want := statsState.Get("job1"), ""; got != want {
+		t.Errorf("statsState of job1 = %q, want %q", got, want)
+	}
+	if got, want := statsState.Get("job2"), string(WorkerStateDiff); got != want {
+		t.Errorf("statsState of job2 = %q, want %q", got, want)
+	}
+}
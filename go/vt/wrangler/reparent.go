@@ -541,6 +541,28 @@ func (wr *Wrangler) chooseNewMaster(
 	return maxPosSearch.maxPosTablet.Alias, nil
 }
 
+// ChooseNewMaster returns the tablet of a shard which should replace
+// its master avoidMasterTabletAlias, as chosen by chooseNewMaster. It
+// returns nil if there is no candidate.
+// The tablets of the cells which cannot be read are not considered,
+// because the failed master may have taken its cell down with it.
+func (wr *Wrangler) ChooseNewMaster(ctx context.Context, keyspace, shard string, avoidMasterTabletAlias *topodatapb.TabletAlias, waitSlaveTimeout time.Duration) (*topodatapb.TabletAlias, error) {
+	shardInfo, err := wr.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	tabletMap, err := wr.ts.GetTabletMapForShard(ctx, keyspace, shard)
+	switch err {
+	case nil:
+		// keep going
+	case topo.ErrPartialResult:
+		wr.logger.Warningf("ChooseNewMaster: got partial result for shard %v/%v, some tablets are not considered", keyspace, shard)
+	default:
+		return nil, err
+	}
+	return wr.chooseNewMaster(ctx, shardInfo, tabletMap, avoidMasterTabletAlias, waitSlaveTimeout)
+}
+
 // EmergencyReparentShard will make the provided tablet the master for
 // the shard, when the old master is completely unreachable.
 func (wr *Wrangler) EmergencyReparentShard(ctx context.Context, keyspace, shard string, masterElectTabletAlias *topodatapb.TabletAlias, waitSlaveTimeout time.Duration) (err error) {
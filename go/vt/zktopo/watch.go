@@ -101,3 +101,10 @@ func (zkts *Server) Watch(ctx context.Context, cell, filePath string) (*topo.Wat
 
 	return wd, c, cancel
 }
+
+// WatchRecursive is part of the topo.Backend interface.
+// It is not supported by this implementation, as it doesn't store all
+// the objects in the Backend format. Use zk2topo instead.
+func (zkts *Server) WatchRecursive(ctx context.Context, cell, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, topo.CancelFunc, error) {
+	return nil, nil, nil, topo.ErrNotImplemented
+}
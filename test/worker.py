@@ -477,7 +477,8 @@ class TestBaseSplitCloneResiliency(TestBaseSplitClone):
       utils.poll_for_vars(
           'vtworker', worker_port,
           'WorkerRetryCount >= 2',
-          condition_fn=lambda v: v.get('WorkerRetryCount') >= 2)
+          condition_fn=lambda v: v.get(
+              'WorkerRetryCount', {}).get('default', 0) >= 2)
       logging.debug('Worker has retried at least twice, starting reparent now')
 
       # vtworker is blocked at this point. This is a good time to test that its
@@ -519,8 +520,8 @@ class TestBaseSplitCloneResiliency(TestBaseSplitClone):
       utils.poll_for_vars(
           'vtworker', worker_port,
           'WorkerState == cloning the data (online)',
-          condition_fn=lambda v: v.get('WorkerState') == 'cloning the'
-          ' data (online)')
+          condition_fn=lambda v: v.get('WorkerState', {}).get(
+              'default') == 'cloning the data (online)')
       logging.debug('Worker is in copy state, starting reparent now')
 
       utils.run_vtctl(
@@ -534,7 +535,7 @@ class TestBaseSplitCloneResiliency(TestBaseSplitClone):
 
     # Verify that we were forced to re-resolve and retry.
     worker_vars = utils.get_vars(worker_port)
-    self.assertGreater(worker_vars['WorkerRetryCount'], 1,
+    self.assertGreater(worker_vars['WorkerRetryCount']['default'], 1,
                        "expected vtworker to retry each of the two reparented"
                        " destination masters at least once, but it didn't")
     self.assertNotEqual(worker_vars['WorkerRetryCount'], {},
@@ -626,7 +627,8 @@ class TestVtworkerWebinterface(unittest.TestCase):
       utils.poll_for_vars(
           'vtworker', self.worker_port,
           'WorkerState == done',
-          condition_fn=lambda v: v.get('WorkerState') == 'done')
+          condition_fn=lambda v: v.get('WorkerState', {}).get(
+              'default') == 'done')
       # Verify that the command logged something and its available at /status.
       status = urllib2.urlopen(worker_base_url + '/status').read()
       self.assertIn(
@@ -47,6 +47,16 @@ app.constant('routes', [
     controller: 'SchemaCtrl',
     showInNav: true,
     icon: 'storage'
+  },
+  {
+    name: 'audit',
+    title: 'Audit Log',
+    urlBase: '/audit/',
+    urlPattern: '/audit/',
+    templateUrl: 'audit.html',
+    controller: 'AuditCtrl',
+    showInNav: true,
+    icon: 'history'
   }
 ]);
 
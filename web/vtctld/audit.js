@@ -0,0 +1,36 @@
+app.controller('AuditCtrl', function($scope, $http) {
+  $scope.query = {Type: 'keyspace', Name: '', Since: '', Limit: 100};
+  $scope.log = {entries: [], error: '', loaded: false};
+
+  $scope.refreshData = function() {
+    if (!$scope.query.Name) {
+      $scope.log = {entries: [], error: '', loaded: false};
+      return;
+    }
+    var params = {};
+    if ($scope.query.Since) params.since = $scope.query.Since;
+    if ($scope.query.Limit) params.limit = $scope.query.Limit;
+    $http.get('../api/audit_log/' + $scope.query.Type + '/' +
+              $scope.query.Name, {params: params})
+      .success(function(data) {
+        // Most recent changes first.
+        $scope.log = {entries: data.reverse(), error: '', loaded: true};
+      })
+      .error(function(data) {
+        $scope.log = {entries: [], error: data, loaded: true};
+      });
+  };
+
+  $scope.caller = function(entry) {
+    var parts = [];
+    if (entry.Principal) parts.push(entry.Principal);
+    if (entry.Username && entry.Username != entry.Principal)
+      parts.push('as ' + entry.Username);
+    if (entry.Component) {
+      var component = entry.Component;
+      if (entry.Subcomponent) component += ' ' + entry.Subcomponent;
+      parts.push('(' + component + ')');
+    }
+    return parts.join(' ') || 'unknown';
+  };
+});
@@ -2,20 +2,51 @@ app.controller('SchemaCtrl', function($scope, $http, $mdDialog,
                actions, keyspaces) {
   $scope.refreshData = function() {
     $scope.keyspaces = keyspaces.query();
+    $scope.refreshHistory();
   };
-  $scope.refreshData();
+
+  function keyspaceSelector() {
+    return {
+      searchText: '',
+      items: function() {
+        var searchText = this.searchText;
+        if (!searchText) return $scope.keyspaces;
+        return $scope.keyspaces.filter(function(item) {
+          return item.indexOf(searchText) != -1;
+        });
+      }
+    };
+  }
 
   $scope.schemaChange = {Keyspace: '', SQL: ''};
-  $scope.keyspaceSelector = {
-    searchText: '',
-    items: function() {
-      var searchText = this.searchText;
-      if (!searchText) return $scope.keyspaces;
-      return $scope.keyspaces.filter(function(item) {
-        return item.indexOf(searchText) != -1;
-      });
+  $scope.keyspaceSelector = keyspaceSelector();
+
+  $scope.queuedChange = {
+    Keyspace: '', SQL: '', MaintenanceWindow: '',
+    AllowLongUnavailability: false
+  };
+  $scope.queueKeyspaceSelector = keyspaceSelector();
+
+  $scope.history = {Keyspace: '', changes: [], error: ''};
+  $scope.historyKeyspaceSelector = keyspaceSelector();
+
+  $scope.refreshHistory = function() {
+    if (!$scope.history.Keyspace) {
+      $scope.history.changes = [];
+      return;
     }
+    $http.get('../api/schema_changes/' + $scope.history.Keyspace)
+      .success(function(data) {
+        // Most recent changes first.
+        $scope.history.changes = data.reverse();
+        $scope.history.error = '';
+      })
+      .error(function(data) {
+        $scope.history.changes = [];
+        $scope.history.error = data;
+      });
   };
+  $scope.refreshData();
 
   $scope.submitSchema = function(ev) {
     var action = {
@@ -40,4 +71,74 @@ app.controller('SchemaCtrl', function($scope, $http, $mdDialog,
       return result;
     });
   };
+
+  $scope.submitQueuedChange = function(ev) {
+    var action = {
+      title: 'Queue Schema Change',
+      confirm: 'This will queue the provided SQL. It will be executed on each shard in the keyspace, after the changes queued before it, during the maintenance window.'
+    };
+    actions.applyFunc(ev, action, function() {
+      var result = {$resolved: false};
+
+      $http.post('../api/schema/queue', $scope.queuedChange)
+        .success(function(data) {
+          result.$resolved = true;
+          result.Output = 'Queued schema change ' + data.UUID;
+          result.Error = false;
+          $scope.history.Keyspace = $scope.queuedChange.Keyspace;
+          $scope.refreshHistory();
+        })
+        .error(function(data) {
+          result.$resolved = true;
+          result.Output = data;
+          result.Error = true;
+        });
+
+      return result;
+    });
+  };
+
+  $scope.shardStates = function(change) {
+    var counts = {};
+    angular.forEach(change.Shards, function(status) {
+      counts[status.State] = (counts[status.State] || 0) + 1;
+    });
+    var states = [];
+    angular.forEach(counts, function(count, state) {
+      states.push(state + ': ' + count);
+    });
+    return states.sort().join(', ');
+  };
+
+  $scope.isActive = function(change) {
+    return !change.EndTime &&
+        (change.State == 'queued' || change.State == 'running' ||
+         change.State == 'failed');
+  };
+
+  $scope.schemaChangeAction = function(ev, change, name) {
+    var action = {
+      title: name + ' Schema Change',
+      confirm: name == 'Cancel' ?
+          'This will cancel the schema change. The shard being changed, if any, is not interrupted.' :
+          'This will retry the schema change on the failed shards.'
+    };
+    actions.applyFunc(ev, action, function() {
+      var result = {$resolved: false};
+
+      $http.post('../api/schema/queue_action', {UUID: change.UUID, Action: name})
+        .success(function(data) {
+          result.$resolved = true;
+          result.Error = false;
+          $scope.refreshHistory();
+        })
+        .error(function(data) {
+          result.$resolved = true;
+          result.Output = data;
+          result.Error = true;
+        });
+
+      return result;
+    });
+  };
 });